@@ -13,9 +13,13 @@ type Loader interface {
 
 var _ Loader = &YamlLoader{}
 
-// YamlLoader load config from config yaml path.
+// YamlLoader load config from config yaml path. Value can be overridden by
+// env var, read from file named by env var with _FILE suffix, e.g
+// POSTGRES_PASSWORD_FILE, or reference secret in SecretSources, see
+// SecretRef.
 type YamlLoader struct {
-	Path string
+	Path          string
+	SecretSources map[string]SecretSource
 }
 
 func (y *YamlLoader) loadConfig(cfg *Config) error {
@@ -23,16 +27,27 @@ func (y *YamlLoader) loadConfig(cfg *Config) error {
 	if err != nil {
 		return fmt.Errorf("cleanenv.ReadConfig: %w", err)
 	}
+	err = readSecretFiles(cfg)
+	if err != nil {
+		return fmt.Errorf("readSecretFiles: %w", err)
+	}
+	err = resolveSecretRefs(cfg, y.SecretSources)
+	if err != nil {
+		return fmt.Errorf("resolveSecretRefs: %w", err)
+	}
 	return nil
 }
 
 var _ Loader = &EnvLoader{}
 
-// EnvLoader load config from env var.
+// EnvLoader load config from env var. Like YamlLoader, value can be read from
+// file named by env var with _FILE suffix or reference secret in
+// SecretSources.
 type EnvLoader struct {
 	// YAMLPath will read yaml config first then read env var. If you do not
 	// specify then env var should have all required config.
-	YAMLPath string
+	YAMLPath      string
+	SecretSources map[string]SecretSource
 }
 
 func (e *EnvLoader) loadConfig(cfg *Config) error {
@@ -42,9 +57,18 @@ func (e *EnvLoader) loadConfig(cfg *Config) error {
 			return fmt.Errorf("cleanenv.ReadConfig: %w", err)
 		}
 	}
-	err := cleanenv.ReadEnv(cfg)
+	// before env var, so required value can come from _FILE only.
+	err := readSecretFiles(cfg)
+	if err != nil {
+		return fmt.Errorf("readSecretFiles: %w", err)
+	}
+	err = cleanenv.ReadEnv(cfg)
 	if err != nil {
 		return fmt.Errorf("cleanenv.ReadEnv: %w", err)
 	}
+	err = resolveSecretRefs(cfg, e.SecretSources)
+	if err != nil {
+		return fmt.Errorf("resolveSecretRefs: %w", err)
+	}
 	return nil
 }
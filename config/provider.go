@@ -0,0 +1,188 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// liveSections are Config fields that can change live, other fields, e.g
+// ports and postgres, are read once on start.
+var liveSections = map[string]bool{
+	"Logger":    true,
+	"JWT":       true,
+	"RateLimit": true,
+}
+
+// Provider hold config loaded by loader and reload it, see Watch. Reloaded
+// config is validated and may only change live sections: logger, jwt and
+// rate_limit. Every copy of config got from Provider reads the latest applied
+// config with Config.Live.
+type Provider struct {
+	loader Loader
+	live   *atomic.Pointer[Config]
+
+	mu          sync.Mutex
+	subscribers []func(Config)
+}
+
+// NewProvider load config with loader like Init, return *Provider holding
+// it. Logrus level follows reloaded config.
+func NewProvider(loader Loader) (*Provider, error) {
+	cfg, err := Init(loader)
+	if err != nil {
+		return nil, fmt.Errorf("Init: %w", err)
+	}
+
+	p := &Provider{
+		loader: loader,
+		live:   &atomic.Pointer[Config]{},
+	}
+
+	cfg.live = p.live
+	p.live.Store(&cfg)
+
+	p.Subscribe(func(cfg Config) {
+		err := initLogrusConfig(cfg)
+		if err != nil {
+			logrus.WithError(err).Warn("apply reloaded logger config")
+		}
+	})
+
+	return p, nil
+}
+
+// Get return latest applied config.
+func (p *Provider) Get() Config {
+	return *p.live.Load()
+}
+
+// Subscribe register fn to be called with new config each time config is
+// reloaded.
+func (p *Provider) Subscribe(fn func(Config)) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.subscribers = append(p.subscribers, fn)
+}
+
+// Reload load config again, apply it and call subscribers. Config which is
+// invalid or changes section that can not change live is rejected, current
+// config is kept.
+func (p *Provider) Reload() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	cfg := Config{}
+
+	err := p.loader.loadConfig(&cfg)
+	if err != nil {
+		return fmt.Errorf("Loader.loadConfig: %w", err)
+	}
+
+	err = cfg.validate()
+	if err != nil {
+		return fmt.Errorf("Config.validate: %w", err)
+	}
+
+	changed := staticSectionsChanged(p.Get(), cfg)
+	if len(changed) > 0 {
+		return fmt.Errorf("config %s can not change live, restart to apply", strings.Join(changed, ", "))
+	}
+
+	cfg.live = p.live
+	p.live.Store(&cfg)
+
+	for _, fn := range p.subscribers {
+		fn(cfg)
+	}
+
+	return nil
+}
+
+// Watch reload config on SIGHUP, and when file at path changes, checked every
+// interval, until ctx is done. Rejected config is logged, current config is
+// kept.
+func (p *Provider) Watch(ctx context.Context, path string, interval time.Duration) {
+	sighup := make(chan os.Signal, 1)
+	signal.Notify(sighup, syscall.SIGHUP)
+	defer signal.Stop(sighup)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	modTime := fileModTime(path)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-sighup:
+			logrus.Info("SIGHUP received, reloading config")
+		case <-ticker.C:
+			newModTime := fileModTime(path)
+			if newModTime.Equal(modTime) {
+				continue
+			}
+			modTime = newModTime
+			logrus.WithField("path", path).Info("config file changed, reloading config")
+		}
+
+		err := p.Reload()
+		if err != nil {
+			logrus.WithError(err).Error("config reload rejected, current config is kept")
+			continue
+		}
+		logrus.Info("config reloaded")
+	}
+}
+
+// Live return latest config applied by Provider, or c itself if c does not
+// come from Provider. Read settings of live sections through it.
+func (c Config) Live() Config {
+	if c.live == nil {
+		return c
+	}
+	return *c.live.Load()
+}
+
+// staticSectionsChanged return yaml name of sections that can not change live
+// but differ between current and next.
+func staticSectionsChanged(current, next Config) []string {
+	currentValue := reflect.ValueOf(current)
+	nextValue := reflect.ValueOf(next)
+	configType := currentValue.Type()
+
+	changed := []string{}
+	for i := range configType.NumField() {
+		field := configType.Field(i)
+		if !field.IsExported() || liveSections[field.Name] {
+			continue
+		}
+		if !reflect.DeepEqual(currentValue.Field(i).Interface(), nextValue.Field(i).Interface()) {
+			name, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+			changed = append(changed, name)
+		}
+	}
+
+	return changed
+}
+
+// fileModTime return modification time of file at path, zero if it can not
+// be read.
+func fileModTime(path string) time.Time {
+	info, err := os.Stat(path)
+	if err != nil {
+		return time.Time{}
+	}
+	return info.ModTime()
+}
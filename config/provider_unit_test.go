@@ -0,0 +1,121 @@
+package config
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeConfig write config.yml of the repo with replacements applied to file
+// at path.
+func writeConfig(t *testing.T, path string, replacements ...string) {
+	t.Helper()
+
+	yml, err := os.ReadFile("config.yml")
+	require.NoError(t, err)
+
+	content := strings.NewReplacer(replacements...).Replace(string(yml))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	// mod time may not move between quick writes.
+	future := time.Now().Add(time.Hour)
+	require.NoError(t, os.Chtimes(path, future, future))
+}
+
+func newTestProvider(t *testing.T) (*Provider, string) {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "config.yml")
+	writeConfig(t, path)
+
+	p, err := NewProvider(&YamlLoader{Path: path})
+	require.NoError(t, err)
+
+	return p, path
+}
+
+func TestUnitProviderReload(t *testing.T) {
+	t.Parallel()
+
+	t.Run("live section change should be applied to every copy", func(t *testing.T) {
+		t.Parallel()
+
+		p, path := newTestProvider(t)
+		cfg := p.Get()
+
+		var notified atomic.Int64
+		p.Subscribe(func(cfg Config) { notified.Store(int64(cfg.JWT.ExpireHour)) })
+
+		writeConfig(t, path, "expire_hour: 36", "expire_hour: 48")
+		err := p.Reload()
+
+		require.NoError(t, err)
+		assert.Equal(t, 36, cfg.JWT.ExpireHour)
+		assert.Equal(t, 48, cfg.Live().JWT.ExpireHour)
+		assert.Equal(t, 48, p.Get().JWT.ExpireHour)
+		assert.Equal(t, int64(48), notified.Load())
+	})
+	t.Run("static section change should be rejected", func(t *testing.T) {
+		t.Parallel()
+
+		p, path := newTestProvider(t)
+
+		writeConfig(t, path, "port: 10000", "port: 10001", "expire_hour: 36", "expire_hour: 48")
+		err := p.Reload()
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "config http can not change live")
+		assert.Equal(t, 36, p.Get().JWT.ExpireHour)
+	})
+	t.Run("invalid config should be rejected", func(t *testing.T) {
+		t.Parallel()
+
+		p, path := newTestProvider(t)
+
+		writeConfig(t, path, `log_level: "debug"`, `log_level: "verbose"`)
+		err := p.Reload()
+
+		require.Error(t, err)
+		assert.Equal(t, logLevel("debug"), p.Get().Logger.LogLevel)
+	})
+}
+
+func TestUnitProviderWatch(t *testing.T) {
+	t.Parallel()
+
+	t.Run("config file change should be reloaded", func(t *testing.T) {
+		t.Parallel()
+
+		p, path := newTestProvider(t)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go p.Watch(ctx, path, 10*time.Millisecond)
+
+		time.Sleep(50 * time.Millisecond) // wait watch read initial mod time.
+		writeConfig(t, path, "burst: 5", "burst: 7")
+
+		assert.Eventually(t, func() bool {
+			return p.Get().RateLimit.Auth.Burst == 7
+		}, 5*time.Second, 10*time.Millisecond)
+	})
+}
+
+func TestUnitConfigLive(t *testing.T) {
+	t.Parallel()
+
+	t.Run("config not from provider should return itself", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := Config{JWT: JWT{ExpireHour: 1}}
+
+		assert.Equal(t, cfg, cfg.Live())
+	})
+}
@@ -0,0 +1,32 @@
+package config
+
+import (
+	"reflect"
+
+	"github.com/Hidayathamir/go-user/pkg/gouser"
+)
+
+// Redact return copy of config with non empty string field tagged
+// `redact:"true"` replaced by gouser.Redacted, safe to print.
+func (c Config) Redact() Config {
+	c.live = nil
+	redactFields(reflect.ValueOf(&c).Elem())
+	return c
+}
+
+func redactFields(v reflect.Value) {
+	t := v.Type()
+	for i := range t.NumField() {
+		field := v.Field(i)
+		if !t.Field(i).IsExported() {
+			continue
+		}
+		if field.Kind() == reflect.Struct {
+			redactFields(field)
+			continue
+		}
+		if t.Field(i).Tag.Get("redact") == "true" && field.Kind() == reflect.String && field.String() != "" {
+			field.SetString(gouser.Redacted)
+		}
+	}
+}
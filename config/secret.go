@@ -0,0 +1,149 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// secretRefPrefix is prefix of config value referencing secret in
+// SecretSource, e.g "secret://vault/secret/data/gouser#jwt_signed_key".
+const secretRefPrefix = "secret://"
+
+// fileEnvSuffix is suffix of env var holding path of file to read config value
+// from, e.g POSTGRES_PASSWORD_FILE for POSTGRES_PASSWORD, the convention of
+// docker and kubernetes secrets.
+const fileEnvSuffix = "_FILE"
+
+// secretResolveTimeout bound resolving every secret reference of config.
+const secretResolveTimeout = 10 * time.Second
+
+// SecretRef is parsed secret reference "secret://<source>/<path>#<key>".
+type SecretRef struct {
+	// Source is name SecretSource is registered with in loader, e.g "vault".
+	Source string
+	// Path is path of secret in source, with leading slash.
+	Path string
+	// Key is key inside secret, empty if secret is a single value.
+	Key string
+}
+
+// String return ref as "secret://<source>/<path>#<key>".
+func (s SecretRef) String() string {
+	ref := secretRefPrefix + s.Source + s.Path
+	if s.Key != "" {
+		ref += "#" + s.Key
+	}
+	return ref
+}
+
+// SecretSource resolve secret reference to its value.
+type SecretSource interface {
+	Resolve(ctx context.Context, ref SecretRef) (string, error)
+}
+
+func parseSecretRef(value string) (SecretRef, error) {
+	u, err := url.Parse(value)
+	if err != nil {
+		return SecretRef{}, fmt.Errorf("url.Parse: %w", err)
+	}
+	if u.Host == "" || u.Path == "" || u.Path == "/" {
+		return SecretRef{}, errors.New("secret reference should be 'secret://<source>/<path>#<key>'")
+	}
+	return SecretRef{Source: u.Host, Path: u.Path, Key: u.Fragment}, nil
+}
+
+// readSecretFiles set every string config field whose env var has a _FILE
+// counterpart set, e.g POSTGRES_PASSWORD_FILE, to content of that file
+// without trailing newline. Setting both env var and its _FILE counterpart is
+// an error.
+func readSecretFiles(cfg *Config) error {
+	return walkStringFields(reflect.ValueOf(cfg).Elem(), "", func(field reflect.Value, envName string) error {
+		path := os.Getenv(envName + fileEnvSuffix)
+		if path == "" {
+			return nil
+		}
+		if _, ok := os.LookupEnv(envName); ok {
+			return fmt.Errorf("both %s and %s are set", envName, envName+fileEnvSuffix)
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("read %s: %w", envName+fileEnvSuffix, err)
+		}
+
+		field.SetString(strings.TrimRight(string(content), "\r\n"))
+
+		return nil
+	})
+}
+
+// resolveSecretRefs replace every string config value referencing secret,
+// see SecretRef, with secret resolved by source registered with its name.
+func resolveSecretRefs(cfg *Config, sources map[string]SecretSource) error {
+	ctx, cancel := context.WithTimeout(context.Background(), secretResolveTimeout)
+	defer cancel()
+
+	return walkStringFields(reflect.ValueOf(cfg).Elem(), "", func(field reflect.Value, envName string) error {
+		if !strings.HasPrefix(field.String(), secretRefPrefix) {
+			return nil
+		}
+
+		ref, err := parseSecretRef(field.String())
+		if err != nil {
+			return fmt.Errorf("parse %s secret reference: %w", envName, err)
+		}
+
+		source, ok := sources[ref.Source]
+		if !ok {
+			return fmt.Errorf("unknown secret source '%s' of %s", ref.Source, envName)
+		}
+
+		secret, err := source.Resolve(ctx, ref)
+		if err != nil {
+			return fmt.Errorf("resolve %s secret reference '%s': %w", envName, ref, err)
+		}
+
+		field.SetString(secret)
+
+		return nil
+	})
+}
+
+// walkStringFields call fn with every settable string field of struct v
+// having env tag, and its env var name prefixed by env-prefix of enclosing
+// fields.
+func walkStringFields(v reflect.Value, envPrefix string, fn func(field reflect.Value, envName string) error) error {
+	t := v.Type()
+	for i := range t.NumField() {
+		fieldType := t.Field(i)
+		field := v.Field(i)
+		if !fieldType.IsExported() {
+			continue
+		}
+
+		if field.Kind() == reflect.Struct {
+			err := walkStringFields(field, envPrefix+fieldType.Tag.Get("env-prefix"), fn)
+			if err != nil {
+				return err
+			}
+			continue
+		}
+
+		env, ok := fieldType.Tag.Lookup("env")
+		if field.Kind() != reflect.String || !ok {
+			continue
+		}
+
+		err := fn(field, envPrefix+env)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,112 @@
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Secret source name list, used as source of SecretRef.
+const (
+	SecretSourceFile  = "file"
+	SecretSourceVault = "vault"
+)
+
+var _ SecretSource = &FileSecretSource{}
+
+// FileSecretSource resolve "secret://file/<path>" to content of file at path
+// inside Dir, without trailing newline. Path is absolute if Dir is empty.
+type FileSecretSource struct {
+	Dir string
+}
+
+// Resolve implement SecretSource.
+func (f *FileSecretSource) Resolve(_ context.Context, ref SecretRef) (string, error) {
+	if ref.Key != "" {
+		return "", errors.New("file secret does not support key")
+	}
+
+	path := filepath.Join(f.Dir, filepath.FromSlash(ref.Path))
+	if f.Dir != "" && !strings.HasPrefix(path, filepath.Clean(f.Dir)+string(filepath.Separator)) {
+		return "", fmt.Errorf("file secret path '%s' is outside '%s'", ref.Path, f.Dir)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("os.ReadFile: %w", err)
+	}
+
+	return strings.TrimRight(string(content), "\r\n"), nil
+}
+
+var _ SecretSource = &VaultSecretSource{}
+
+// VaultSecretSource resolve "secret://vault/<path>#<key>" by reading
+// <Address>/v1/<path> from Vault compatible HTTP API with Token. Key is looked
+// up in data.data of KV version 2 secret, or in data of KV version 1 secret.
+type VaultSecretSource struct {
+	// Address eg. http://127.0.0.1:8200.
+	Address string
+	Token   string
+	// HTTPClient send requests, http.DefaultClient if nil.
+	HTTPClient *http.Client
+}
+
+type vaultSecretResponse struct {
+	Data map[string]any `json:"data"`
+}
+
+// Resolve implement SecretSource.
+func (v *VaultSecretSource) Resolve(ctx context.Context, ref SecretRef) (string, error) {
+	if ref.Key == "" {
+		return "", errors.New("vault secret reference should have key, e.g 'secret://vault/secret/data/gouser#jwt_signed_key'")
+	}
+
+	url := strings.TrimRight(v.Address, "/") + "/v1" + ref.Path
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", fmt.Errorf("http.NewRequestWithContext: %w", err)
+	}
+	httpReq.Header.Set("X-Vault-Token", v.Token)
+
+	httpClient := v.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	httpRes, err := httpClient.Do(httpReq)
+	if err != nil {
+		return "", fmt.Errorf("http.Client.Do: %w", err)
+	}
+	defer func() {
+		_ = httpRes.Body.Close()
+	}()
+
+	if httpRes.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("vault responded status %d", httpRes.StatusCode)
+	}
+
+	res := vaultSecretResponse{}
+	err = json.NewDecoder(httpRes.Body).Decode(&res)
+	if err != nil {
+		return "", fmt.Errorf("json.Decoder.Decode: %w", err)
+	}
+
+	data := res.Data
+	if kv2Data, ok := data["data"].(map[string]any); ok {
+		data = kv2Data
+	}
+
+	value, ok := data[ref.Key].(string)
+	if !ok {
+		return "", fmt.Errorf("vault secret has no string key '%s'", ref.Key)
+	}
+
+	return value, nil
+}
@@ -0,0 +1,287 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSecretSource resolve secret reference string to its value.
+type fakeSecretSource map[string]string
+
+func (f fakeSecretSource) Resolve(_ context.Context, ref SecretRef) (string, error) {
+	secret, ok := f[ref.String()]
+	if !ok {
+		return "", errors.New("secret not found")
+	}
+	return secret, nil
+}
+
+func writeSecretFile(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "secret")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	return path
+}
+
+// Env var is process wide, tests below setting it can not run in parallel.
+
+func TestUnitYamlLoaderSecretFile(t *testing.T) {
+	t.Run("value should be read from _FILE env var", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "config.yml")
+		writeConfig(t, path)
+		t.Setenv("JWT_SIGNED_KEY_FILE", writeSecretFile(t, "signed-key-from-file\n"))
+
+		cfg, err := Init(&YamlLoader{Path: path})
+
+		require.NoError(t, err)
+		assert.Equal(t, "signed-key-from-file", cfg.JWT.SignedKey)
+	})
+	t.Run("env var and _FILE env var both set should return error", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "config.yml")
+		writeConfig(t, path)
+		t.Setenv("JWT_SIGNED_KEY", "signed-key-from-env")
+		t.Setenv("JWT_SIGNED_KEY_FILE", writeSecretFile(t, "signed-key-from-file"))
+
+		_, err := Init(&YamlLoader{Path: path})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "both JWT_SIGNED_KEY and JWT_SIGNED_KEY_FILE are set")
+	})
+	t.Run("missing file should return error", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "config.yml")
+		writeConfig(t, path)
+		t.Setenv("JWT_SIGNED_KEY_FILE", filepath.Join(t.TempDir(), "missing"))
+
+		_, err := Init(&YamlLoader{Path: path})
+
+		require.Error(t, err)
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+}
+
+func TestUnitEnvLoaderSecretFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yml")
+	writeConfig(t, path)
+	t.Setenv("POSTGRES_PASSWORD_FILE", writeSecretFile(t, "password-from-file\r\n"))
+
+	cfg, err := Init(&EnvLoader{YAMLPath: path})
+
+	require.NoError(t, err)
+	assert.Equal(t, "password-from-file", cfg.PG.Password)
+}
+
+func TestUnitYamlLoaderSecretRef(t *testing.T) {
+	t.Parallel()
+
+	t.Run("secret reference should be resolved by its source", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "config.yml")
+		writeConfig(t, path, `"5f4a252a-539b-47f6-2224-d4c2edd71ca4"`, `"secret://fake/gouser#jwt_signed_key"`)
+		sources := map[string]SecretSource{
+			"fake": fakeSecretSource{"secret://fake/gouser#jwt_signed_key": "signed-key-from-source"},
+		}
+
+		cfg, err := Init(&YamlLoader{Path: path, SecretSources: sources})
+
+		require.NoError(t, err)
+		assert.Equal(t, "signed-key-from-source", cfg.JWT.SignedKey)
+	})
+	t.Run("unknown source should return error", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "config.yml")
+		writeConfig(t, path, `"5f4a252a-539b-47f6-2224-d4c2edd71ca4"`, `"secret://unknown/gouser#jwt_signed_key"`)
+
+		_, err := Init(&YamlLoader{Path: path})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unknown secret source 'unknown' of JWT_SIGNED_KEY")
+	})
+	t.Run("source error should return error", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "config.yml")
+		writeConfig(t, path, `"5f4a252a-539b-47f6-2224-d4c2edd71ca4"`, `"secret://fake/missing"`)
+		sources := map[string]SecretSource{"fake": fakeSecretSource{}}
+
+		_, err := Init(&YamlLoader{Path: path, SecretSources: sources})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "resolve JWT_SIGNED_KEY secret reference 'secret://fake/missing': secret not found")
+	})
+	t.Run("reference without path should return error", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "config.yml")
+		writeConfig(t, path, `"5f4a252a-539b-47f6-2224-d4c2edd71ca4"`, `"secret://fake"`)
+		sources := map[string]SecretSource{"fake": fakeSecretSource{}}
+
+		_, err := Init(&YamlLoader{Path: path, SecretSources: sources})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "parse JWT_SIGNED_KEY secret reference")
+	})
+}
+
+func TestUnitFileSecretSource(t *testing.T) {
+	t.Parallel()
+
+	t.Run("file content should be returned without trailing newline", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "jwt_signed_key"), []byte("signed-key\n"), 0o600))
+		source := &FileSecretSource{Dir: dir}
+
+		secret, err := source.Resolve(context.Background(), SecretRef{Source: SecretSourceFile, Path: "/jwt_signed_key"})
+
+		require.NoError(t, err)
+		assert.Equal(t, "signed-key", secret)
+	})
+	t.Run("path outside dir should return error", func(t *testing.T) {
+		t.Parallel()
+
+		source := &FileSecretSource{Dir: t.TempDir()}
+
+		_, err := source.Resolve(context.Background(), SecretRef{Source: SecretSourceFile, Path: "/../secret"})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "is outside")
+	})
+	t.Run("key should return error", func(t *testing.T) {
+		t.Parallel()
+
+		source := &FileSecretSource{}
+
+		_, err := source.Resolve(context.Background(), SecretRef{Source: SecretSourceFile, Path: "/secret", Key: "key"})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "does not support key")
+	})
+}
+
+func TestUnitVaultSecretSource(t *testing.T) {
+	t.Parallel()
+
+	newStubVault := func(t *testing.T) *httptest.Server {
+		t.Helper()
+
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("X-Vault-Token") != "vault-token" {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			switch r.URL.Path {
+			case "/v1/secret/data/gouser":
+				_, _ = w.Write([]byte(`{"data":{"data":{"jwt_signed_key":"signed-key-kv2"},"metadata":{"version":1}}}`))
+			case "/v1/kv/gouser":
+				_, _ = w.Write([]byte(`{"data":{"jwt_signed_key":"signed-key-kv1"}}`))
+			default:
+				w.WriteHeader(http.StatusNotFound)
+			}
+		}))
+		t.Cleanup(srv.Close)
+
+		return srv
+	}
+
+	t.Run("kv version 2 secret key should be returned", func(t *testing.T) {
+		t.Parallel()
+
+		srv := newStubVault(t)
+		source := &VaultSecretSource{Address: srv.URL, Token: "vault-token"}
+
+		secret, err := source.Resolve(context.Background(), SecretRef{Source: SecretSourceVault, Path: "/secret/data/gouser", Key: "jwt_signed_key"})
+
+		require.NoError(t, err)
+		assert.Equal(t, "signed-key-kv2", secret)
+	})
+	t.Run("kv version 1 secret key should be returned", func(t *testing.T) {
+		t.Parallel()
+
+		srv := newStubVault(t)
+		source := &VaultSecretSource{Address: srv.URL + "/", Token: "vault-token"}
+
+		secret, err := source.Resolve(context.Background(), SecretRef{Source: SecretSourceVault, Path: "/kv/gouser", Key: "jwt_signed_key"})
+
+		require.NoError(t, err)
+		assert.Equal(t, "signed-key-kv1", secret)
+	})
+	t.Run("missing key should return error", func(t *testing.T) {
+		t.Parallel()
+
+		srv := newStubVault(t)
+		source := &VaultSecretSource{Address: srv.URL, Token: "vault-token"}
+
+		_, err := source.Resolve(context.Background(), SecretRef{Source: SecretSourceVault, Path: "/secret/data/gouser", Key: "missing"})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "vault secret has no string key 'missing'")
+	})
+	t.Run("wrong token should return error", func(t *testing.T) {
+		t.Parallel()
+
+		srv := newStubVault(t)
+		source := &VaultSecretSource{Address: srv.URL, Token: "wrong-token"}
+
+		_, err := source.Resolve(context.Background(), SecretRef{Source: SecretSourceVault, Path: "/secret/data/gouser", Key: "jwt_signed_key"})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "vault responded status 403")
+	})
+	t.Run("empty key should return error", func(t *testing.T) {
+		t.Parallel()
+
+		source := &VaultSecretSource{Address: "http://127.0.0.1:0", Token: "vault-token"}
+
+		_, err := source.Resolve(context.Background(), SecretRef{Source: SecretSourceVault, Path: "/secret/data/gouser"})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "should have key")
+	})
+}
+
+func TestUnitJWTValidateProd(t *testing.T) {
+	t.Parallel()
+
+	validateProd := func(signedKey string) error {
+		v := &validator{}
+		JWT{ExpireHour: 24, SignedKey: signedKey}.validate(v, "jwt", envProd)
+		return v.err()
+	}
+
+	t.Run("dev key should return error", func(t *testing.T) {
+		t.Parallel()
+
+		err := validateProd("5f4a252a-539b-47f6-2224-d4c2edd71ca4")
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "jwt.signed_key: is a dev key")
+	})
+	t.Run("short key should return error", func(t *testing.T) {
+		t.Parallel()
+
+		err := validateProd("short")
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "jwt.signed_key: must be at least 32 characters")
+	})
+	t.Run("long secret key should pass", func(t *testing.T) {
+		t.Parallel()
+
+		err := validateProd("a-long-secret-key-nobody-else-knows-about")
+
+		require.NoError(t, err)
+	})
+}
@@ -0,0 +1,102 @@
+package config
+
+import (
+	"fmt"
+	"net"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// FieldError is invalid config field.
+type FieldError struct {
+	// Path is yaml path of field, e.g "postgres.pool_max".
+	Path    string
+	Message string
+}
+
+// Error implements error.
+func (f FieldError) Error() string {
+	return f.Path + ": " + f.Message
+}
+
+// ValidationError hold every invalid config field, so all of them can be
+// fixed at once.
+type ValidationError struct {
+	Fields []FieldError
+}
+
+// Error implements error.
+func (v *ValidationError) Error() string {
+	msgs := make([]string, 0, len(v.Fields))
+	for _, field := range v.Fields {
+		msgs = append(msgs, field.Error())
+	}
+	return fmt.Sprintf("%d invalid config field(s): %s", len(v.Fields), strings.Join(msgs, "; "))
+}
+
+// validator collect invalid config fields.
+type validator struct {
+	fields []FieldError
+}
+
+// fieldf record field at path as invalid.
+func (v *validator) fieldf(path string, format string, args ...any) {
+	v.fields = append(v.fields, FieldError{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+func (v *validator) required(path string, value string) {
+	if value == "" {
+		v.fieldf(path, "is required")
+	}
+}
+
+func (v *validator) positive(path string, value int) {
+	if value <= 0 {
+		v.fieldf(path, "must be positive, got %d", value)
+	}
+}
+
+func (v *validator) nonNegative(path string, value int) {
+	if value < 0 {
+		v.fieldf(path, "can not be negative, got %d", value)
+	}
+}
+
+func (v *validator) port(path string, value int) {
+	if value < 1 || value > 65535 {
+		v.fieldf(path, "must be between 1 and 65535, got %d", value)
+	}
+}
+
+func (v *validator) oneOf(path string, value string, allowed ...string) bool {
+	if !slices.Contains(allowed, value) {
+		v.fieldf(path, "unknown value '%s', should be one of '%s'", value, strings.Join(allowed, "', '"))
+		return false
+	}
+	return true
+}
+
+func (v *validator) httpURL(path string, value string) {
+	u, err := url.ParseRequestURI(value)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		v.fieldf(path, "must be http or https url, got '%s'", value)
+	}
+}
+
+func (v *validator) ipOrCIDR(path string, value string) {
+	if net.ParseIP(value) != nil {
+		return
+	}
+	if _, _, err := net.ParseCIDR(value); err != nil {
+		v.fieldf(path, "must be ip or cidr, got '%s'", value)
+	}
+}
+
+// err return *ValidationError if any field is invalid.
+func (v *validator) err() error {
+	if len(v.fields) == 0 {
+		return nil
+	}
+	return &ValidationError{Fields: v.fields}
+}
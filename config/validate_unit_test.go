@@ -0,0 +1,152 @@
+package config
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/Hidayathamir/go-user/pkg/gouser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func loadTestConfig(t *testing.T, replacements ...string) Config {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "config.yml")
+	writeConfig(t, path, replacements...)
+
+	cfg := Config{}
+	require.NoError(t, (&YamlLoader{Path: path}).loadConfig(&cfg))
+
+	return cfg
+}
+
+func TestUnitConfigValidate(t *testing.T) {
+	t.Parallel()
+
+	t.Run("repo config should be valid", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := loadTestConfig(t)
+
+		require.NoError(t, cfg.validate())
+	})
+	t.Run("every invalid field should be reported with its path", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := loadTestConfig(t)
+		cfg.App.Environment = "staging"
+		cfg.App.ShutdownDrainSecond = -1
+		cfg.HTTP.Port = 70000
+		cfg.HTTP.TrustedProxies = []string{"10.0.0.0/8", "proxy.local"}
+		cfg.Metrics.Port = cfg.GRPC.Port
+		cfg.PG.PoolMax = -1
+		cfg.PG.Host = ""
+		cfg.JWT.ExpireHour = -1
+		cfg.JWT.SignedKey = ""
+		cfg.Outbox.Publisher = OutboxPublisherWebhook
+		cfg.Outbox.WebhookURL = "localhost:8080/events"
+		cfg.HTTP.TLS.CertFile = "cert.pem"
+		cfg.RateLimit.APIKeySHA256 = []string{"secret-api-key"}
+		cfg.RateLimit.Users.Key = "session"
+
+		err := cfg.validate()
+
+		validationErr := &ValidationError{}
+		require.ErrorAs(t, err, &validationErr)
+		paths := []string{}
+		for _, field := range validationErr.Fields {
+			paths = append(paths, field.Path)
+		}
+		assert.Equal(t, []string{
+			"app.environment",
+			"app.shutdown_drain_second",
+			"http.port",
+			"http.trusted_proxies",
+			"http.tls.key_file",
+			"metrics.port",
+			"postgres.host",
+			"postgres.pool_max",
+			"jwt.expire_hour",
+			"jwt.signed_key",
+			"outbox.webhook_url",
+			"rate_limit.api_key_sha256",
+			"rate_limit.users.key",
+		}, paths)
+		assert.Contains(t, err.Error(), "13 invalid config field(s): app.environment: unknown value 'staging', should be one of 'dev', 'prod'; ")
+		assert.Contains(t, err.Error(), "metrics.port: conflicts with grpc.port 11000")
+		assert.Contains(t, err.Error(), "app.shutdown_drain_second: can not be negative, got -1")
+		assert.Contains(t, err.Error(), "postgres.pool_max: must be positive, got -1")
+		assert.Contains(t, err.Error(), "http.trusted_proxies: must be ip or cidr, got 'proxy.local'")
+	})
+	t.Run("disabled rate limit should not be validated", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := loadTestConfig(t)
+		cfg.RateLimit.Enabled = false
+		cfg.RateLimit.Auth = RateLimitRule{}
+
+		require.NoError(t, cfg.validate())
+	})
+	t.Run("same port on different host should be valid", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := loadTestConfig(t)
+		cfg.Metrics.Host = "127.0.0.1"
+		cfg.Metrics.Port = cfg.HTTP.Port
+
+		require.NoError(t, cfg.validate())
+	})
+}
+
+func TestUnitConfigDefault(t *testing.T) {
+	t.Parallel()
+
+	cfg := loadTestConfig(t, "  pool_max: 2\n", "", "  port: 5000\n", "", "  expire_hour: 36\n", "", `log_level: "debug"`, `log_level: ""`)
+
+	assert.Equal(t, 10, cfg.PG.PoolMax)
+	assert.Equal(t, 5432, cfg.PG.Port)
+	assert.Equal(t, 24, cfg.JWT.ExpireHour)
+	assert.Equal(t, logLevel("info"), cfg.Logger.LogLevel)
+	require.NoError(t, cfg.validate())
+}
+
+func TestUnitCheck(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid config should be returned with validation error", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "config.yml")
+		writeConfig(t, path, "pool_max: 2", "pool_max: -1")
+
+		cfg, err := Check(&YamlLoader{Path: path})
+
+		validationErr := &ValidationError{}
+		require.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, []FieldError{{Path: "postgres.pool_max", Message: "must be positive, got -1"}}, validationErr.Fields)
+		assert.Equal(t, -1, cfg.PG.PoolMax)
+	})
+	t.Run("config which can not be loaded should return error", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := Check(&YamlLoader{Path: filepath.Join(t.TempDir(), "missing.yml")})
+
+		require.Error(t, err)
+		assert.False(t, errors.As(err, new(*ValidationError)))
+	})
+}
+
+func TestUnitConfigRedact(t *testing.T) {
+	t.Parallel()
+
+	cfg := loadTestConfig(t)
+
+	redacted := cfg.Redact()
+
+	assert.Equal(t, gouser.Redacted, redacted.PG.Password)
+	assert.Equal(t, gouser.Redacted, redacted.JWT.SignedKey)
+	assert.Equal(t, cfg.PG.Username, redacted.PG.Username)
+	assert.Equal(t, "password", cfg.PG.Password)
+}
@@ -2,46 +2,91 @@
 package app
 
 import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
 	"github.com/Hidayathamir/go-user/config"
-	"github.com/Hidayathamir/go-user/internal/controller/grpc"
-	"github.com/Hidayathamir/go-user/internal/controller/http"
+	"github.com/Hidayathamir/go-user/internal/pkg/logger"
+	"github.com/Hidayathamir/go-user/internal/pkg/tracing"
 	"github.com/Hidayathamir/go-user/internal/repo/db"
 	"github.com/sirupsen/logrus"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 )
 
-// Run application.
-func Run() {
+// Run application until SIGINT or SIGTERM is received, then shut it down
+// gracefully. Config is reloaded on SIGHUP or config file change.
+func Run() error {
 	arg := parseCLIArgs()
 
-	cfg := initConfig(arg)
+	switch {
+	case arg.isConfigCheck():
+		cfgLoader, err := newConfigLoader(arg)
+		if err != nil {
+			return fmt.Errorf("newConfigLoader: %w", err)
+		}
+		err = checkConfig(os.Stdout, cfgLoader)
+		if err != nil {
+			return fmt.Errorf("checkConfig: %w", err)
+		}
+		return nil
+	case len(arg.command) > 0:
+		return fmt.Errorf("unknown command '%s', available command is '%s'", strings.Join(arg.command, " "), strings.Join(configCheckCommand, " "))
+	}
 
-	handleCommandLineArgsMigrate(cfg, arg)
+	cfgProvider, err := initConfig(arg)
+	if err != nil {
+		return fmt.Errorf("initConfig: %w", err)
+	}
 
-	db := newDBPostgres(cfg)
+	cfg := cfgProvider.Get()
 
-	go runGRPCServer(cfg, db)
+	logrus.AddHook(logger.NewRedactHook())
 
-	runHTTPServer(cfg, db)
-}
+	err = handleCommandLineArgsMigrate(cfg, arg)
+	if err != nil {
+		return fmt.Errorf("handleCommandLineArgsMigrate: %w", err)
+	}
+
+	tracerProvider, err := tracing.Init(context.Background(), cfg)
+	if err != nil {
+		return fmt.Errorf("tracing.Init: %w", err)
+	}
+	defer shutdownTracerProvider(cfg, tracerProvider)
 
-func newDBPostgres(cfg config.Config) *db.Postgres {
 	db, err := db.NewPGPoolConn(cfg)
 	if err != nil {
-		logrus.Fatalf("db.NewPostgresPoolConnection: %v", err)
+		return fmt.Errorf("db.NewPGPoolConn: %w", err)
 	}
-	return db
-}
 
-func runGRPCServer(cfg config.Config, db *db.Postgres) {
-	err := grpc.RunServer(cfg, db)
+	l, err := newLifecycle(cfg, db)
+	if err != nil {
+		db.Pool.Close()
+		return fmt.Errorf("newLifecycle: %w", err)
+	}
+
+	watchCtx, stopWatch := context.WithCancel(context.Background())
+	defer stopWatch()
+	go cfgProvider.Watch(watchCtx, configPath, configWatchInterval)
+
+	err = l.run(context.Background())
 	if err != nil {
-		logrus.Fatalf("grpc.RunServer: %v", err)
+		return fmt.Errorf("lifecycle.run: %w", err)
 	}
+
+	return nil
 }
 
-func runHTTPServer(cfg config.Config, db *db.Postgres) {
-	err := http.RunServer(cfg, db)
+// shutdownTracerProvider flush buffered spans to exporter, bounded by shutdown
+// timeout.
+func shutdownTracerProvider(cfg config.Config, tracerProvider *sdktrace.TracerProvider) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(cfg.App.ShutdownTimeoutSecond)*time.Second)
+	defer cancel()
+
+	err := tracerProvider.Shutdown(ctx)
 	if err != nil {
-		logrus.Fatalf("http.RunServer: %v", err)
+		logrus.Warnf("sdktrace.TracerProvider.Shutdown: %v", err)
 	}
 }
@@ -2,6 +2,7 @@ package app
 
 import (
 	"flag"
+	"slices"
 
 	"github.com/Hidayathamir/go-user/config"
 	"github.com/ilyakaznacheev/cleanenv"
@@ -10,6 +11,16 @@ import (
 type cliArg struct {
 	isIncludeMigrate bool
 	isLoadEnv        bool
+	// command is non flag args, e.g ["config", "check"], empty to run app.
+	command []string
+}
+
+// configCheckCommand print effective config and exit non zero if it is
+// invalid, e.g "go run . -load-env config check".
+var configCheckCommand = []string{"config", "check"}
+
+func (c cliArg) isConfigCheck() bool {
+	return slices.Equal(c.command, configCheckCommand)
 }
 
 func parseCLIArgs() cliArg {
@@ -22,5 +33,7 @@ func parseCLIArgs() cliArg {
 
 	flag.Parse()
 
+	arg.command = flag.Args()
+
 	return arg
 }
@@ -1,26 +1,112 @@
 package app
 
 import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
 	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/Hidayathamir/go-user/config"
-	"github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v3"
 )
 
-func initConfig(arg cliArg) config.Config {
-	yamlPath := filepath.Join("config", "config.yml")
+// configWatchInterval is how often config file is checked for change.
+const configWatchInterval = 2 * time.Second
+
+var configPath = filepath.Join("config", "config.yml")
+
+func initConfig(arg cliArg) (*config.Provider, error) {
+	cfgLoader, err := newConfigLoader(arg)
+	if err != nil {
+		return nil, fmt.Errorf("newConfigLoader: %w", err)
+	}
+
+	cfgProvider, err := config.NewProvider(cfgLoader)
+	if err != nil {
+		return nil, fmt.Errorf("config.NewProvider: %w", err)
+	}
+
+	return cfgProvider, nil
+}
+
+func newConfigLoader(arg cliArg) (config.Loader, error) {
+	secretSources, err := initSecretSources()
+	if err != nil {
+		return nil, fmt.Errorf("initSecretSources: %w", err)
+	}
 
-	var cfgLoader config.Loader
 	if arg.isLoadEnv {
-		cfgLoader = &config.EnvLoader{YAMLPath: yamlPath}
-	} else {
-		cfgLoader = &config.YamlLoader{Path: yamlPath}
+		return &config.EnvLoader{YAMLPath: configPath, SecretSources: secretSources}, nil
+	}
+	return &config.YamlLoader{Path: configPath, SecretSources: secretSources}, nil
+}
+
+// checkConfig write effective config as yaml with secrets redacted to w, then
+// every invalid field. Error is returned if config can not be loaded or is
+// invalid.
+func checkConfig(w io.Writer, cfgLoader config.Loader) error {
+	cfg, checkErr := config.Check(cfgLoader)
+
+	var validationErr *config.ValidationError
+	if checkErr != nil && !errors.As(checkErr, &validationErr) {
+		return fmt.Errorf("config.Check: %w", checkErr)
 	}
 
-	cfg, err := config.Init(cfgLoader)
+	encoder := yaml.NewEncoder(w)
+	encoder.SetIndent(2)
+	err := encoder.Encode(cfg.Redact())
 	if err != nil {
-		logrus.Fatalf("config.Init: %v", err)
+		return fmt.Errorf("yaml.Encoder.Encode: %w", err)
 	}
+	err = encoder.Close()
+	if err != nil {
+		return fmt.Errorf("yaml.Encoder.Close: %w", err)
+	}
+
+	if validationErr == nil {
+		return nil
+	}
+
+	_, err = fmt.Fprintf(w, "\n# %d invalid field(s):\n", len(validationErr.Fields))
+	if err != nil {
+		return fmt.Errorf("fmt.Fprintf: %w", err)
+	}
+	for _, field := range validationErr.Fields {
+		_, err = fmt.Fprintf(w, "# - %s\n", field)
+		if err != nil {
+			return fmt.Errorf("fmt.Fprintf: %w", err)
+		}
+	}
+
+	return fmt.Errorf("config.Check: %w", checkErr)
+}
+
+// initSecretSources return secret sources config can reference. Vault source
+// is registered only if VAULT_ADDR is set, token is read from VAULT_TOKEN or
+// file at VAULT_TOKEN_FILE.
+func initSecretSources() (map[string]config.SecretSource, error) {
+	secretSources := map[string]config.SecretSource{
+		config.SecretSourceFile: &config.FileSecretSource{},
+	}
+
+	vaultAddr := os.Getenv("VAULT_ADDR")
+	if vaultAddr == "" {
+		return secretSources, nil
+	}
+
+	vaultToken := os.Getenv("VAULT_TOKEN")
+	if path := os.Getenv("VAULT_TOKEN_FILE"); path != "" {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("os.ReadFile: %w", err)
+		}
+		vaultToken = strings.TrimRight(string(content), "\r\n")
+	}
+
+	secretSources[config.SecretSourceVault] = &config.VaultSecretSource{Address: vaultAddr, Token: vaultToken}
 
-	return cfg
+	return secretSources, nil
 }
@@ -0,0 +1,60 @@
+package app
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Hidayathamir/go-user/config"
+	"github.com/Hidayathamir/go-user/pkg/gouser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var repoConfigPath = filepath.Join("..", "..", "config", "config.yml")
+
+func TestUnitCheckConfig(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid config should be printed with secret redacted", func(t *testing.T) {
+		t.Parallel()
+
+		out := &bytes.Buffer{}
+
+		err := checkConfig(out, &config.YamlLoader{Path: repoConfigPath})
+
+		require.NoError(t, err)
+		assert.Contains(t, out.String(), "\n  pool_max: 2\n")
+		assert.Contains(t, out.String(), "signed_key: '"+gouser.Redacted+"'")
+		assert.NotContains(t, out.String(), "5f4a252a-539b-47f6-2224-d4c2edd71ca4")
+		assert.NotContains(t, out.String(), "invalid field")
+	})
+	t.Run("invalid config should be printed with every invalid field and return error", func(t *testing.T) {
+		t.Parallel()
+
+		yml, err := os.ReadFile(repoConfigPath)
+		require.NoError(t, err)
+		path := filepath.Join(t.TempDir(), "config.yml")
+		content := strings.NewReplacer("pool_max: 2", "pool_max: -1", "expire_hour: 36", "expire_hour: -1").Replace(string(yml))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		out := &bytes.Buffer{}
+
+		err = checkConfig(out, &config.YamlLoader{Path: path})
+
+		require.Error(t, err)
+		assert.Contains(t, out.String(), "pool_max: -1\n")
+		assert.Contains(t, out.String(), "# 2 invalid field(s):\n# - postgres.pool_max: must be positive, got -1\n# - jwt.expire_hour: must be positive, got -1\n")
+	})
+	t.Run("config which can not be loaded should return error", func(t *testing.T) {
+		t.Parallel()
+
+		out := &bytes.Buffer{}
+
+		err := checkConfig(out, &config.YamlLoader{Path: filepath.Join(t.TempDir(), "missing.yml")})
+
+		require.Error(t, err)
+		assert.Empty(t, out.String())
+	})
+}
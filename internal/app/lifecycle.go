@@ -0,0 +1,307 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	nethttp "net/http"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/Hidayathamir/go-user/config"
+	"github.com/Hidayathamir/go-user/internal/controller/grpc"
+	"github.com/Hidayathamir/go-user/internal/controller/http"
+	"github.com/Hidayathamir/go-user/internal/controller/job"
+	"github.com/Hidayathamir/go-user/internal/pkg/health"
+	"github.com/Hidayathamir/go-user/internal/pkg/metrics"
+	"github.com/Hidayathamir/go-user/internal/repo/db"
+	"github.com/sirupsen/logrus"
+)
+
+// lifecycle run http server, metrics server, grpc server and job scheduler,
+// then shut them down gracefully on stop signal.
+type lifecycle struct {
+	cfg           config.Config
+	db            *db.Postgres
+	readiness     *health.Readiness
+	checker       *health.Checker
+	httpServer    *nethttp.Server
+	metricsServer *nethttp.Server
+	grpcServer    *grpc.Server
+
+	// runScheduler block until ctx is done.
+	runScheduler func(ctx context.Context) error
+}
+
+func newLifecycle(cfg config.Config, pg *db.Postgres) (*lifecycle, error) {
+	readiness := health.NewReadiness()
+
+	timeout := time.Duration(cfg.Health.CheckTimeoutMillisecond) * time.Millisecond
+	checker := health.NewChecker(
+		health.Check{Name: "app", Timeout: timeout, Check: readiness.Check},
+		health.Check{Name: "postgres", Timeout: timeout, Check: pg.Pool.Ping},
+		health.Check{Name: "migration", Timeout: timeout, Check: func(ctx context.Context) error {
+			return db.CheckMigration(ctx, pg, schemaMigrationPath)
+		}},
+	)
+
+	metrics.Registry.MustRegister(metrics.NewPGPoolCollector(func() metrics.PGPoolStat {
+		return pg.Pool.Stat()
+	}))
+
+	httpServer, err := http.NewServer(cfg, pg, checker)
+	if err != nil {
+		return nil, fmt.Errorf("http.NewServer: %w", err)
+	}
+
+	grpcServer, err := grpc.NewServer(cfg, pg)
+	if err != nil {
+		return nil, fmt.Errorf("grpc.NewServer: %w", err)
+	}
+
+	return &lifecycle{
+		cfg:           cfg,
+		db:            pg,
+		readiness:     readiness,
+		checker:       checker,
+		httpServer:    httpServer,
+		metricsServer: http.NewMetricsServer(cfg),
+		grpcServer:    grpcServer,
+		runScheduler: func(ctx context.Context) error {
+			return job.RunScheduler(ctx, cfg, pg)
+		},
+	}, nil
+}
+
+// run start all components and block until ctx is done, SIGINT or SIGTERM is
+// received or a component fails. Then readiness is flipped, servers keep
+// serving for cfg.App.ShutdownDrainSecond, then are given
+// cfg.App.ShutdownTimeoutSecond to finish in flight requests, job scheduler
+// is stopped and db pool is closed. Error of a failed component is returned
+// together with shutdown error.
+func (l *lifecycle) run(ctx context.Context) error {
+	defer l.closeDB()
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	httpServers := l.httpServers()
+
+	addrs := []string{l.grpcServer.Addr}
+	for _, httpServer := range httpServers {
+		addrs = append(addrs, httpServer.Addr)
+	}
+
+	listeners, err := listen(addrs)
+	if err != nil {
+		return fmt.Errorf("listen: %w", err)
+	}
+
+	grpcListener := listeners[0]
+	httpListeners := listeners[1:]
+
+	errCh := make(chan error, len(listeners)+1)
+
+	for i, httpServer := range httpServers {
+		go func(httpServer *nethttp.Server, httpListener net.Listener) {
+			logrus.WithField("address", httpListener.Addr().String()).Info("run http server")
+			err := serveHTTP(httpServer, httpListener)
+			if err != nil && !errors.Is(err, nethttp.ErrServerClosed) {
+				errCh <- fmt.Errorf("http.Server.Serve: %w", err)
+			}
+		}(httpServer, httpListeners[i])
+	}
+
+	go func() {
+		logrus.WithField("address", grpcListener.Addr().String()).Info("run grpc server")
+		err := l.grpcServer.Serve(grpcListener)
+		if err != nil {
+			errCh <- fmt.Errorf("grpc.Server.Serve: %w", err)
+		}
+	}()
+
+	backgroundCtx, stopBackground := context.WithCancel(context.Background())
+	defer stopBackground()
+
+	schedulerDone := make(chan struct{})
+	go func() {
+		defer close(schedulerDone)
+		err := l.runScheduler(backgroundCtx)
+		if err != nil {
+			errCh <- fmt.Errorf("job.RunScheduler: %w", err)
+		}
+	}()
+
+	l.readiness.SetReady(true)
+	logrus.Info("app is ready")
+
+	grpcHealthDone := make(chan struct{})
+	go func() {
+		defer close(grpcHealthDone)
+		l.updateGRPCHealth(backgroundCtx)
+	}()
+
+	var errRun error
+	select {
+	case <-ctx.Done():
+		logrus.Info("stop signal received, shutting down")
+	case errRun = <-errCh:
+		logrus.Errorf("shutting down: %v", errRun)
+	}
+
+	errShutdown := l.shutdown(stopBackground, grpcHealthDone, schedulerDone)
+
+	return errors.Join(errRun, errShutdown)
+}
+
+// shutdown fail readiness and stop background goroutines, keep serving for
+// cfg.App.ShutdownDrainSecond so load balancer stops routing new traffic
+// here, then stop receiving new traffic and wait in flight requests and
+// running jobs to finish within cfg.App.ShutdownTimeoutSecond.
+func (l *lifecycle) shutdown(stopBackground context.CancelFunc, grpcHealthDone <-chan struct{}, schedulerDone <-chan struct{}) error {
+	l.readiness.SetReady(false)
+
+	stopBackground()
+
+	// Wait the health updater, so it does not set SERVING again after this.
+	<-grpcHealthDone
+	l.grpcServer.SetServing(false)
+
+	drain := time.Duration(l.cfg.App.ShutdownDrainSecond) * time.Second
+	if drain > 0 {
+		logrus.WithField("drain", drain.String()).Info("readiness failed, draining before servers stop")
+		time.Sleep(drain)
+	}
+
+	timeout := time.Duration(l.cfg.App.ShutdownTimeoutSecond) * time.Second
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	errs := []error{}
+	mu := sync.Mutex{}
+	addErr := func(err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		errs = append(errs, err)
+	}
+
+	wg := sync.WaitGroup{}
+
+	for _, httpServer := range l.httpServers() {
+		wg.Add(1)
+		go func(httpServer *nethttp.Server) {
+			defer wg.Done()
+			err := httpServer.Shutdown(ctx)
+			if err != nil {
+				addErr(fmt.Errorf("http.Server.Shutdown '%s': %w", httpServer.Addr, err))
+			}
+		}(httpServer)
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		err := l.stopGRPCServer(ctx)
+		if err != nil {
+			addErr(fmt.Errorf("lifecycle.stopGRPCServer: %w", err))
+		}
+	}()
+
+	select {
+	case <-schedulerDone:
+	case <-ctx.Done():
+		addErr(fmt.Errorf("wait job scheduler: %w", ctx.Err()))
+	}
+
+	wg.Wait()
+
+	logrus.Info("servers and job scheduler stopped")
+
+	return errors.Join(errs...)
+}
+
+// httpServers return public http server and metrics server.
+func (l *lifecycle) httpServers() []*nethttp.Server {
+	return []*nethttp.Server{l.httpServer, l.metricsServer}
+}
+
+// serveHTTP serve httpServer on listener, with TLS if its TLSConfig is set.
+// Certificate comes from TLSConfig, so no file is passed to ServeTLS.
+func serveHTTP(httpServer *nethttp.Server, listener net.Listener) error {
+	if httpServer.TLSConfig != nil {
+		return httpServer.ServeTLS(listener, "", "")
+	}
+	return httpServer.Serve(listener)
+}
+
+// listen listen on every address before any server runs, so bind error is
+// returned instead of happening in background. On error listeners already
+// opened are closed.
+func listen(addrs []string) ([]net.Listener, error) {
+	listeners := make([]net.Listener, 0, len(addrs))
+	for _, addr := range addrs {
+		listener, err := net.Listen("tcp", addr)
+		if err != nil {
+			for _, listener := range listeners {
+				_ = listener.Close()
+			}
+			return nil, fmt.Errorf("net.Listen '%s': %w", addr, err)
+		}
+		listeners = append(listeners, listener)
+	}
+
+	return listeners, nil
+}
+
+// updateGRPCHealth set grpc health status from checker result every
+// cfg.Health.CheckIntervalSecond until ctx is done.
+func (l *lifecycle) updateGRPCHealth(ctx context.Context) {
+	ticker := time.NewTicker(time.Duration(l.cfg.Health.CheckIntervalSecond) * time.Second)
+	defer ticker.Stop()
+
+	isServing := false
+	for {
+		report := l.checker.Check(ctx)
+		if report.IsUp() != isServing {
+			isServing = report.IsUp()
+			logrus.WithField("report", report).Infof("grpc health serving: %t", isServing)
+		}
+		l.grpcServer.SetServing(isServing)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// stopGRPCServer cancel running streams first, so WatchUsers poll loop
+// returns, then stop grpc server gracefully, and forcefully once ctx is done.
+func (l *lifecycle) stopGRPCServer(ctx context.Context) error {
+	l.grpcServer.StopStreams()
+
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		l.grpcServer.GracefulStop()
+	}()
+
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		l.grpcServer.Stop()
+		<-stopped
+		return fmt.Errorf("grpc.Server.GracefulStop: %w", ctx.Err())
+	}
+}
+
+func (l *lifecycle) closeDB() {
+	l.db.Pool.Close()
+	logrus.Info("db pool closed")
+}
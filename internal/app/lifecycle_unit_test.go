@@ -0,0 +1,290 @@
+package app
+
+import (
+	"context"
+	"net"
+	nethttp "net/http"
+	"testing"
+	"time"
+
+	"github.com/Hidayathamir/go-user/config"
+	"github.com/Hidayathamir/go-user/internal/controller/grpc"
+	"github.com/Hidayathamir/go-user/internal/pkg/health"
+	"github.com/Hidayathamir/go-user/internal/repo/db"
+	"github.com/pashagolub/pgxmock/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	grpcgo "google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func newTestLifecycle(t *testing.T, handler nethttp.Handler) (*lifecycle, pgxmock.PgxPoolIface) {
+	t.Helper()
+
+	mockpool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	mockpool.ExpectClose()
+
+	cfg := config.Config{
+		App:    config.App{ShutdownTimeoutSecond: 1},
+		GRPC:   config.GRPC{Host: "127.0.0.1", Port: 0},
+		Health: config.Health{CheckTimeoutMillisecond: 100, CheckIntervalSecond: 1},
+	}
+	pg := &db.Postgres{Pool: mockpool}
+	readiness := health.NewReadiness()
+
+	grpcServer, err := grpc.NewServer(cfg, pg)
+	require.NoError(t, err)
+
+	l := &lifecycle{
+		cfg:           cfg,
+		db:            pg,
+		readiness:     readiness,
+		checker:       health.NewChecker(health.Check{Name: "app", Timeout: time.Second, Check: readiness.Check}),
+		httpServer:    &nethttp.Server{Addr: "127.0.0.1:0", Handler: handler, ReadHeaderTimeout: time.Second},
+		metricsServer: &nethttp.Server{Addr: "127.0.0.1:0", Handler: nethttp.NotFoundHandler(), ReadHeaderTimeout: time.Second},
+		grpcServer:    grpcServer,
+		runScheduler: func(ctx context.Context) error {
+			<-ctx.Done()
+			return nil
+		},
+	}
+
+	return l, mockpool
+}
+
+func TestUnitLifecycleRun(t *testing.T) {
+	t.Parallel()
+
+	t.Run("ctx done should shutdown gracefully and close db pool", func(t *testing.T) {
+		t.Parallel()
+
+		l, mockpool := newTestLifecycle(t, nethttp.NotFoundHandler())
+
+		schedulerStopped := make(chan struct{})
+		l.runScheduler = func(ctx context.Context) error {
+			<-ctx.Done()
+			close(schedulerStopped)
+			return nil
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		errCh := make(chan error, 1)
+		go func() {
+			errCh <- l.run(ctx)
+		}()
+
+		require.Eventually(t, l.readiness.IsReady, time.Second, 10*time.Millisecond)
+
+		cancel()
+
+		require.NoError(t, <-errCh)
+		assert.False(t, l.readiness.IsReady())
+		select {
+		case <-schedulerStopped:
+		default:
+			t.Fatal("job scheduler is not stopped")
+		}
+		require.NoError(t, mockpool.ExpectationsWereMet())
+	})
+	t.Run("in flight request should finish before shutdown returns", func(t *testing.T) {
+		t.Parallel()
+
+		requestStarted := make(chan struct{})
+		releaseRequest := make(chan struct{})
+		handler := nethttp.HandlerFunc(func(w nethttp.ResponseWriter, _ *nethttp.Request) {
+			close(requestStarted)
+			<-releaseRequest
+			w.WriteHeader(nethttp.StatusOK)
+		})
+
+		l, mockpool := newTestLifecycle(t, handler)
+
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		l.httpServer.Addr = listener.Addr().String()
+		require.NoError(t, listener.Close())
+
+		ctx, cancel := context.WithCancel(context.Background())
+		errCh := make(chan error, 1)
+		go func() {
+			errCh <- l.run(ctx)
+		}()
+
+		require.Eventually(t, l.readiness.IsReady, time.Second, 10*time.Millisecond)
+
+		resCh := make(chan int, 1)
+		go func() {
+			res, err := nethttp.Get("http://" + l.httpServer.Addr)
+			if err != nil {
+				resCh <- 0
+				return
+			}
+			defer res.Body.Close()
+			resCh <- res.StatusCode
+		}()
+
+		<-requestStarted
+		cancel()
+
+		require.Eventually(t, func() bool { return !l.readiness.IsReady() }, time.Second, 10*time.Millisecond)
+		select {
+		case <-errCh:
+			t.Fatal("run returned before in flight request finished")
+		default:
+		}
+
+		close(releaseRequest)
+
+		assert.Equal(t, nethttp.StatusOK, <-resCh)
+		require.NoError(t, <-errCh)
+		require.NoError(t, mockpool.ExpectationsWereMet())
+	})
+	t.Run("drain should fail readiness and keep serving before servers stop", func(t *testing.T) {
+		t.Parallel()
+
+		l, mockpool := newTestLifecycle(t, nethttp.HandlerFunc(func(w nethttp.ResponseWriter, _ *nethttp.Request) {
+			w.WriteHeader(nethttp.StatusOK)
+		}))
+		l.cfg.App.ShutdownDrainSecond = 1
+
+		httpListener, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		l.httpServer.Addr = httpListener.Addr().String()
+		require.NoError(t, httpListener.Close())
+
+		grpcListener, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		l.grpcServer.Addr = grpcListener.Addr().String()
+		require.NoError(t, grpcListener.Close())
+
+		ctx, cancel := context.WithCancel(context.Background())
+		errCh := make(chan error, 1)
+		go func() {
+			errCh <- l.run(ctx)
+		}()
+
+		require.Eventually(t, l.readiness.IsReady, time.Second, 10*time.Millisecond)
+
+		conn, err := grpcgo.Dial(l.grpcServer.Addr, grpcgo.WithTransportCredentials(insecure.NewCredentials()))
+		require.NoError(t, err)
+		defer conn.Close()
+		healthClient := healthpb.NewHealthClient(conn)
+
+		start := time.Now()
+		cancel()
+
+		require.Eventually(t, func() bool { return !l.readiness.IsReady() }, time.Second, 10*time.Millisecond)
+		require.Eventually(t, func() bool {
+			res, err := healthClient.Check(context.Background(), &healthpb.HealthCheckRequest{})
+			return err == nil && res.GetStatus() == healthpb.HealthCheckResponse_NOT_SERVING
+		}, time.Second, 10*time.Millisecond)
+
+		res, err := nethttp.Get("http://" + l.httpServer.Addr)
+		require.NoError(t, err)
+		defer res.Body.Close()
+		assert.Equal(t, nethttp.StatusOK, res.StatusCode)
+		select {
+		case <-errCh:
+			t.Fatal("run returned before drain finished")
+		default:
+		}
+
+		require.NoError(t, <-errCh)
+		assert.GreaterOrEqual(t, time.Since(start), time.Duration(l.cfg.App.ShutdownDrainSecond)*time.Second)
+		require.NoError(t, mockpool.ExpectationsWereMet())
+	})
+	t.Run("running stream should be canceled so it does not block shutdown", func(t *testing.T) {
+		t.Parallel()
+
+		l, mockpool := newTestLifecycle(t, nethttp.NotFoundHandler())
+
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		l.grpcServer.Addr = listener.Addr().String()
+		require.NoError(t, listener.Close())
+
+		ctx, cancel := context.WithCancel(context.Background())
+		errCh := make(chan error, 1)
+		go func() {
+			errCh <- l.run(ctx)
+		}()
+
+		require.Eventually(t, l.readiness.IsReady, time.Second, 10*time.Millisecond)
+
+		conn, err := grpcgo.Dial(l.grpcServer.Addr, grpcgo.WithTransportCredentials(insecure.NewCredentials()))
+		require.NoError(t, err)
+		defer conn.Close()
+
+		// Health Watch is a stream which lasts until its context is done,
+		// like Profile.WatchUsers.
+		stream, err := healthpb.NewHealthClient(conn).Watch(context.Background(), &healthpb.HealthCheckRequest{})
+		require.NoError(t, err)
+		_, err = stream.Recv()
+		require.NoError(t, err)
+
+		start := time.Now()
+		cancel()
+
+		require.NoError(t, <-errCh)
+		assert.Less(t, time.Since(start), time.Duration(l.cfg.App.ShutdownTimeoutSecond)*time.Second)
+		require.NoError(t, mockpool.ExpectationsWereMet())
+	})
+	t.Run("shutdown timeout should return error", func(t *testing.T) {
+		t.Parallel()
+
+		l, mockpool := newTestLifecycle(t, nethttp.NotFoundHandler())
+		l.runScheduler = func(context.Context) error {
+			time.Sleep(2 * time.Second)
+			return nil
+		}
+
+		ctx, cancel := context.WithCancel(context.Background())
+		errCh := make(chan error, 1)
+		go func() {
+			errCh <- l.run(ctx)
+		}()
+
+		require.Eventually(t, l.readiness.IsReady, time.Second, 10*time.Millisecond)
+
+		cancel()
+
+		err := <-errCh
+		require.Error(t, err)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		require.NoError(t, mockpool.ExpectationsWereMet())
+	})
+	t.Run("component error should shutdown and return error", func(t *testing.T) {
+		t.Parallel()
+
+		l, mockpool := newTestLifecycle(t, nethttp.NotFoundHandler())
+		l.runScheduler = func(context.Context) error {
+			return assert.AnError
+		}
+
+		err := l.run(context.Background())
+
+		require.Error(t, err)
+		require.ErrorIs(t, err, assert.AnError)
+		assert.False(t, l.readiness.IsReady())
+		require.NoError(t, mockpool.ExpectationsWereMet())
+	})
+	t.Run("listen error should return error and close db pool", func(t *testing.T) {
+		t.Parallel()
+
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		require.NoError(t, err)
+		defer listener.Close()
+
+		l, mockpool := newTestLifecycle(t, nethttp.NotFoundHandler())
+		l.httpServer.Addr = listener.Addr().String()
+
+		err = l.run(context.Background())
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "net.Listen '"+listener.Addr().String()+"'")
+		assert.False(t, l.readiness.IsReady())
+		require.NoError(t, mockpool.ExpectationsWereMet())
+	})
+}
@@ -1,20 +1,23 @@
 package app
 
 import (
+	"fmt"
 	"path/filepath"
 
 	"github.com/Hidayathamir/go-user/config"
 	"github.com/Hidayathamir/go-user/internal/repo/db"
-	"github.com/sirupsen/logrus"
 )
 
+var schemaMigrationPath = filepath.Join("internal", "repo", "db", "schema_migration")
+
 // handleCommandLineArgsMigrate do db migration.
-func handleCommandLineArgsMigrate(cfg config.Config, arg cliArg) {
+func handleCommandLineArgsMigrate(cfg config.Config, arg cliArg) error {
 	if arg.isIncludeMigrate {
-		schemaMigrationPath := filepath.Join("internal", "repo", "db", "schema_migration")
 		err := db.MigrateUp(cfg, schemaMigrationPath)
 		if err != nil {
-			logrus.Fatalf("db.MigrateUp: %v", err)
+			return fmt.Errorf("db.MigrateUp: %w", err)
 		}
 	}
+
+	return nil
 }
@@ -0,0 +1,118 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Hidayathamir/go-user/config"
+	"github.com/Hidayathamir/go-user/internal/usecase"
+	"github.com/Hidayathamir/go-user/pkg/gouser"
+	"github.com/Hidayathamir/go-user/pkg/gousergrpc"
+)
+
+// Account is controller GRPC for account lifecycle related.
+type Account struct {
+	gousergrpc.UnimplementedAccountServer
+
+	cfg            config.Config
+	usecaseAccount usecase.IAccount
+}
+
+var _ gousergrpc.AccountServer = &Account{}
+
+func newAccount(cfg config.Config, usecaseAccount usecase.IAccount) *Account {
+	return &Account{
+		cfg:            cfg,
+		usecaseAccount: usecaseAccount,
+	}
+}
+
+// DeleteAccount implements gousergrpc.AccountServer.
+func (a *Account) DeleteAccount(c context.Context, r *gousergrpc.ReqDeleteAccount) (*gousergrpc.AccountEmpty, error) {
+	req := gouser.ReqDeleteAccount{
+		UserJWT:   r.GetUserJwt(),
+		Password:  r.GetPassword(),
+		UserAgent: getClientUserAgent(c),
+		IP:        getClientIP(c),
+		RequestID: gouser.RequestIDFromContext(c),
+	}
+
+	err := a.usecaseAccount.DeleteAccount(c, req)
+	if err != nil {
+		err := fmt.Errorf("Account.usecaseAccount.DeleteAccount: %w", err)
+		return nil, err
+	}
+
+	res := &gousergrpc.AccountEmpty{}
+
+	return res, nil
+}
+
+// RestoreAccount implements gousergrpc.AccountServer.
+func (a *Account) RestoreAccount(c context.Context, r *gousergrpc.ReqRestoreAccount) (*gousergrpc.AccountEmpty, error) {
+	req := gouser.ReqRestoreAccount{
+		Username:  r.GetUsername(),
+		Password:  r.GetPassword(),
+		UserAgent: getClientUserAgent(c),
+		IP:        getClientIP(c),
+		RequestID: gouser.RequestIDFromContext(c),
+	}
+
+	err := a.usecaseAccount.RestoreAccount(c, req)
+	if err != nil {
+		err := fmt.Errorf("Account.usecaseAccount.RestoreAccount: %w", err)
+		return nil, err
+	}
+
+	res := &gousergrpc.AccountEmpty{}
+
+	return res, nil
+}
+
+// UpdateUserStatus implements gousergrpc.AccountServer.
+func (a *Account) UpdateUserStatus(c context.Context, r *gousergrpc.ReqUpdateUserStatus) (*gousergrpc.AccountEmpty, error) {
+	req := gouser.ReqUpdateUserStatus{
+		UserJWT:          r.GetUserJwt(),
+		UserID:           r.GetUserId(),
+		Status:           r.GetStatus(),
+		SuspensionReason: r.GetSuspensionReason(),
+		UserAgent:        getClientUserAgent(c),
+		IP:               getClientIP(c),
+		RequestID:        gouser.RequestIDFromContext(c),
+	}
+	if r.GetSuspendedUntil() != nil {
+		suspendedUntil := r.GetSuspendedUntil().AsTime()
+		req.SuspendedUntil = &suspendedUntil
+	}
+
+	err := a.usecaseAccount.UpdateUserStatus(c, req)
+	if err != nil {
+		err := fmt.Errorf("Account.usecaseAccount.UpdateUserStatus: %w", err)
+		return nil, err
+	}
+
+	res := &gousergrpc.AccountEmpty{}
+
+	return res, nil
+}
+
+// ChangeUsername implements gousergrpc.AccountServer.
+func (a *Account) ChangeUsername(c context.Context, r *gousergrpc.ReqChangeUsername) (*gousergrpc.AccountEmpty, error) {
+	req := gouser.ReqChangeUsername{
+		UserJWT:   r.GetUserJwt(),
+		Username:  r.GetUsername(),
+		UserAgent: getClientUserAgent(c),
+		IP:        getClientIP(c),
+		RequestID: gouser.RequestIDFromContext(c),
+	}
+
+	err := a.usecaseAccount.ChangeUsername(c, req)
+	if err != nil {
+		err := fmt.Errorf("Account.usecaseAccount.ChangeUsername: %w", err)
+		return nil, err
+	}
+
+	res := &gousergrpc.AccountEmpty{}
+
+	return res, nil
+}
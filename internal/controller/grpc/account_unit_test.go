@@ -0,0 +1,260 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/Hidayathamir/go-user/config"
+	"github.com/Hidayathamir/go-user/internal/usecase/mockusecase"
+	"github.com/Hidayathamir/go-user/pkg/gouser"
+	"github.com/Hidayathamir/go-user/pkg/gousergrpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestUnitAccountDeleteAccount(t *testing.T) {
+	t.Parallel()
+
+	t.Run("call usecase DeleteAccount success should return success", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		usecaseAccount := mockusecase.NewMockIAccount(ctrl)
+
+		a := &Account{
+			cfg:            config.Config{},
+			usecaseAccount: usecaseAccount,
+		}
+
+		ctx := gouser.WithRequestID(context.Background(), "req-1")
+		ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("user-agent", "grpc-go/1.62.1"))
+		ctx = peer.NewContext(ctx, &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("192.0.2.1"), Port: 5000}})
+
+		usecaseAccount.EXPECT().
+			DeleteAccount(gomock.Any(), gouser.ReqDeleteAccount{
+				UserJWT:   "Bearer dummyUserJWT",
+				Password:  "mypassword",
+				UserAgent: "grpc-go/1.62.1",
+				IP:        "192.0.2.1",
+				RequestID: "req-1",
+			}).Return(nil)
+
+		res, err := a.DeleteAccount(ctx, &gousergrpc.ReqDeleteAccount{
+			UserJwt:  "Bearer dummyUserJWT",
+			Password: "mypassword",
+		})
+
+		require.NoError(t, err)
+		assert.NotNil(t, res)
+	})
+	t.Run("call usecase DeleteAccount error should return error", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		usecaseAccount := mockusecase.NewMockIAccount(ctrl)
+
+		a := &Account{
+			cfg:            config.Config{},
+			usecaseAccount: usecaseAccount,
+		}
+
+		usecaseAccount.EXPECT().
+			DeleteAccount(gomock.Any(), gomock.Any()).
+			Return(assert.AnError)
+
+		res, err := a.DeleteAccount(context.Background(), &gousergrpc.ReqDeleteAccount{})
+
+		require.Error(t, err)
+		require.ErrorIs(t, err, assert.AnError)
+		assert.Nil(t, res)
+	})
+}
+
+func TestUnitAccountRestoreAccount(t *testing.T) {
+	t.Parallel()
+
+	t.Run("call usecase RestoreAccount success should return success", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		usecaseAccount := mockusecase.NewMockIAccount(ctrl)
+
+		a := &Account{
+			cfg:            config.Config{},
+			usecaseAccount: usecaseAccount,
+		}
+
+		usecaseAccount.EXPECT().
+			RestoreAccount(gomock.Any(), gouser.ReqRestoreAccount{
+				Username: "hidayat",
+				Password: "mypassword",
+			}).Return(nil)
+
+		res, err := a.RestoreAccount(context.Background(), &gousergrpc.ReqRestoreAccount{
+			Username: "hidayat",
+			Password: "mypassword",
+		})
+
+		require.NoError(t, err)
+		assert.NotNil(t, res)
+	})
+	t.Run("call usecase RestoreAccount error should return error", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		usecaseAccount := mockusecase.NewMockIAccount(ctrl)
+
+		a := &Account{
+			cfg:            config.Config{},
+			usecaseAccount: usecaseAccount,
+		}
+
+		usecaseAccount.EXPECT().
+			RestoreAccount(gomock.Any(), gomock.Any()).
+			Return(assert.AnError)
+
+		res, err := a.RestoreAccount(context.Background(), &gousergrpc.ReqRestoreAccount{})
+
+		require.Error(t, err)
+		require.ErrorIs(t, err, assert.AnError)
+		assert.Nil(t, res)
+	})
+}
+
+func TestUnitAccountUpdateUserStatus(t *testing.T) {
+	t.Parallel()
+
+	t.Run("call usecase UpdateUserStatus success should return success", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		usecaseAccount := mockusecase.NewMockIAccount(ctrl)
+
+		a := &Account{
+			cfg:            config.Config{},
+			usecaseAccount: usecaseAccount,
+		}
+
+		suspendedUntil := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+		usecaseAccount.EXPECT().
+			UpdateUserStatus(gomock.Any(), gouser.ReqUpdateUserStatus{
+				UserJWT:          "Bearer dummyAdminJWT",
+				UserID:           44,
+				Status:           "suspended",
+				SuspendedUntil:   &suspendedUntil,
+				SuspensionReason: "spam",
+			}).Return(nil)
+
+		res, err := a.UpdateUserStatus(context.Background(), &gousergrpc.ReqUpdateUserStatus{
+			UserJwt:          "Bearer dummyAdminJWT",
+			UserId:           44,
+			Status:           "suspended",
+			SuspendedUntil:   timestamppb.New(suspendedUntil),
+			SuspensionReason: "spam",
+		})
+
+		require.NoError(t, err)
+		assert.NotNil(t, res)
+	})
+	t.Run("call usecase UpdateUserStatus error should return error", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		usecaseAccount := mockusecase.NewMockIAccount(ctrl)
+
+		a := &Account{
+			cfg:            config.Config{},
+			usecaseAccount: usecaseAccount,
+		}
+
+		usecaseAccount.EXPECT().
+			UpdateUserStatus(gomock.Any(), gouser.ReqUpdateUserStatus{
+				UserJWT: "Bearer dummyAdminJWT",
+				UserID:  44,
+				Status:  "disabled",
+			}).Return(assert.AnError)
+
+		res, err := a.UpdateUserStatus(context.Background(), &gousergrpc.ReqUpdateUserStatus{
+			UserJwt: "Bearer dummyAdminJWT",
+			UserId:  44,
+			Status:  "disabled",
+		})
+
+		require.Error(t, err)
+		require.ErrorIs(t, err, assert.AnError)
+		assert.Nil(t, res)
+	})
+}
+
+func TestUnitAccountChangeUsername(t *testing.T) {
+	t.Parallel()
+
+	t.Run("call usecase ChangeUsername success should return success", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		usecaseAccount := mockusecase.NewMockIAccount(ctrl)
+
+		a := &Account{
+			cfg:            config.Config{},
+			usecaseAccount: usecaseAccount,
+		}
+
+		usecaseAccount.EXPECT().
+			ChangeUsername(gomock.Any(), gouser.ReqChangeUsername{
+				UserJWT:  "Bearer dummyUserJWT",
+				Username: "hidayat2",
+			}).Return(nil)
+
+		res, err := a.ChangeUsername(context.Background(), &gousergrpc.ReqChangeUsername{
+			UserJwt:  "Bearer dummyUserJWT",
+			Username: "hidayat2",
+		})
+
+		require.NoError(t, err)
+		assert.NotNil(t, res)
+	})
+	t.Run("call usecase ChangeUsername error should return error", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		usecaseAccount := mockusecase.NewMockIAccount(ctrl)
+
+		a := &Account{
+			cfg:            config.Config{},
+			usecaseAccount: usecaseAccount,
+		}
+
+		usecaseAccount.EXPECT().
+			ChangeUsername(gomock.Any(), gomock.Any()).
+			Return(assert.AnError)
+
+		res, err := a.ChangeUsername(context.Background(), &gousergrpc.ReqChangeUsername{})
+
+		require.Error(t, err)
+		require.ErrorIs(t, err, assert.AnError)
+		assert.Nil(t, res)
+	})
+}
@@ -30,8 +30,11 @@ func newAuth(cfg config.Config, usecaseAuth usecase.IAuth) *Auth {
 // LoginUser implements gousergrpc.AuthServer.
 func (a *Auth) LoginUser(c context.Context, r *gousergrpc.ReqLoginUser) (*gousergrpc.ResLoginUser, error) {
 	req := gouser.ReqLoginUser{
-		Username: r.GetUsername(),
-		Password: r.GetPassword(),
+		Username:  r.GetUsername(),
+		Password:  r.GetPassword(),
+		UserAgent: getClientUserAgent(c),
+		IP:        getClientIP(c),
+		RequestID: gouser.RequestIDFromContext(c),
 	}
 
 	resLoginUser, err := a.usecaseAuth.LoginUser(c, req)
@@ -50,8 +53,11 @@ func (a *Auth) LoginUser(c context.Context, r *gousergrpc.ReqLoginUser) (*gouser
 // RegisterUser implements gousergrpc.AuthServer.
 func (a *Auth) RegisterUser(c context.Context, r *gousergrpc.ReqRegisterUser) (*gousergrpc.ResRegisterUser, error) {
 	req := gouser.ReqRegisterUser{
-		Username: r.GetUsername(),
-		Password: r.GetPassword(),
+		Username:  r.GetUsername(),
+		Password:  r.GetPassword(),
+		UserAgent: getClientUserAgent(c),
+		IP:        getClientIP(c),
+		RequestID: gouser.RequestIDFromContext(c),
 	}
 
 	resRegisterUser, err := a.usecaseAuth.RegisterUser(c, req)
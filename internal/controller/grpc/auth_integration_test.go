@@ -28,7 +28,9 @@ func TestIntegrationAuthLoginUser(t *testing.T) {
 
 		repoAuth := repo.NewAuth(cfg, pg)
 		repoProfile := repo.NewProfile(cfg, pg)
-		usecaseAuth := usecase.NewAuth(cfg, repoAuth, repoProfile)
+		repoSession := repo.NewSession(cfg, pg)
+		repoAccount := repo.NewAccount(cfg, pg)
+		usecaseAuth := usecase.NewAuth(cfg, repoAuth, repoProfile, repoSession, repoAccount, repo.NewAuditLog(cfg, pg), repo.NewTransactor(cfg, pg))
 		controllerAuth := newAuth(cfg, usecaseAuth)
 
 		username := uuid.NewString()
@@ -61,7 +63,9 @@ func TestIntegrationAuthLoginUser(t *testing.T) {
 
 		repoAuth := repo.NewAuth(cfg, pg)
 		repoProfile := repo.NewProfile(cfg, pg)
-		usecaseAuth := usecase.NewAuth(cfg, repoAuth, repoProfile)
+		repoSession := repo.NewSession(cfg, pg)
+		repoAccount := repo.NewAccount(cfg, pg)
+		usecaseAuth := usecase.NewAuth(cfg, repoAuth, repoProfile, repoSession, repoAccount, repo.NewAuditLog(cfg, pg), repo.NewTransactor(cfg, pg))
 		controllerAuth := newAuth(cfg, usecaseAuth)
 
 		username := uuid.NewString()
@@ -89,7 +93,9 @@ func TestIntegrationAuthLoginUser(t *testing.T) {
 
 		repoAuth := repo.NewAuth(cfg, pg)
 		repoProfile := repo.NewProfile(cfg, pg)
-		usecaseAuth := usecase.NewAuth(cfg, repoAuth, repoProfile)
+		repoSession := repo.NewSession(cfg, pg)
+		repoAccount := repo.NewAccount(cfg, pg)
+		usecaseAuth := usecase.NewAuth(cfg, repoAuth, repoProfile, repoSession, repoAccount, repo.NewAuditLog(cfg, pg), repo.NewTransactor(cfg, pg))
 		controllerAuth := newAuth(cfg, usecaseAuth)
 
 		resLogin, err := controllerAuth.LoginUser(context.Background(), &gousergrpc.ReqLoginUser{
@@ -111,7 +117,9 @@ func TestIntegrationAuthLoginUser(t *testing.T) {
 
 		repoAuth := repo.NewAuth(cfg, pg)
 		repoProfile := repo.NewProfile(cfg, pg)
-		usecaseAuth := usecase.NewAuth(cfg, repoAuth, repoProfile)
+		repoSession := repo.NewSession(cfg, pg)
+		repoAccount := repo.NewAccount(cfg, pg)
+		usecaseAuth := usecase.NewAuth(cfg, repoAuth, repoProfile, repoSession, repoAccount, repo.NewAuditLog(cfg, pg), repo.NewTransactor(cfg, pg))
 		controllerAuth := newAuth(cfg, usecaseAuth)
 
 		t.Run("request username empty should error", func(t *testing.T) {
@@ -148,7 +156,9 @@ func TestIntegrationAuthRegisterUser(t *testing.T) {
 
 		repoAuth := repo.NewAuth(cfg, pg)
 		repoProfile := repo.NewProfile(cfg, pg)
-		usecaseAuth := usecase.NewAuth(cfg, repoAuth, repoProfile)
+		repoSession := repo.NewSession(cfg, pg)
+		repoAccount := repo.NewAccount(cfg, pg)
+		usecaseAuth := usecase.NewAuth(cfg, repoAuth, repoProfile, repoSession, repoAccount, repo.NewAuditLog(cfg, pg), repo.NewTransactor(cfg, pg))
 		controllerAuth := newAuth(cfg, usecaseAuth)
 
 		res, err := controllerAuth.RegisterUser(context.Background(), &gousergrpc.ReqRegisterUser{
@@ -168,7 +178,9 @@ func TestIntegrationAuthRegisterUser(t *testing.T) {
 
 		repoAuth := repo.NewAuth(cfg, pg)
 		repoProfile := repo.NewProfile(cfg, pg)
-		usecaseAuth := usecase.NewAuth(cfg, repoAuth, repoProfile)
+		repoSession := repo.NewSession(cfg, pg)
+		repoAccount := repo.NewAccount(cfg, pg)
+		usecaseAuth := usecase.NewAuth(cfg, repoAuth, repoProfile, repoSession, repoAccount, repo.NewAuditLog(cfg, pg), repo.NewTransactor(cfg, pg))
 		controllerAuth := newAuth(cfg, usecaseAuth)
 
 		username := uuid.NewString()
@@ -197,7 +209,9 @@ func TestIntegrationAuthRegisterUser(t *testing.T) {
 
 		repoAuth := repo.NewAuth(cfg, pg)
 		repoProfile := repo.NewProfile(cfg, pg)
-		usecaseAuth := usecase.NewAuth(cfg, repoAuth, repoProfile)
+		repoSession := repo.NewSession(cfg, pg)
+		repoAccount := repo.NewAccount(cfg, pg)
+		usecaseAuth := usecase.NewAuth(cfg, repoAuth, repoProfile, repoSession, repoAccount, repo.NewAuditLog(cfg, pg), repo.NewTransactor(cfg, pg))
 		controllerAuth := newAuth(cfg, usecaseAuth)
 		t.Run("request username empty should error", func(t *testing.T) {
 			res, err := controllerAuth.RegisterUser(context.Background(), &gousergrpc.ReqRegisterUser{
@@ -0,0 +1,74 @@
+package grpc
+
+import (
+	"context"
+	"net"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// getClientUserAgent return user agent of the grpc client from incoming
+// metadata.
+func getClientUserAgent(ctx context.Context) string {
+	return getIncomingMetadata(ctx, "user-agent")
+}
+
+// getIncomingMetadata return the first value of key in incoming metadata.
+func getIncomingMetadata(ctx context.Context, key string) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	values := md.Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+// getClientIP return ip of the grpc client from peer address.
+func getClientIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
+
+// clientCertIdentity is identity of grpc client taken from its TLS
+// certificate, verified against client CA, see config.TLS.
+type clientCertIdentity struct {
+	CommonName string
+	DNSNames   []string
+	URIs       []string
+}
+
+// getClientCertIdentity return identity of grpc client certificate, false if
+// connection is not TLS or client sent no certificate.
+func getClientCertIdentity(ctx context.Context) (clientCertIdentity, bool) {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return clientCertIdentity{}, false
+	}
+	tlsInfo, ok := p.AuthInfo.(credentials.TLSInfo)
+	if !ok || len(tlsInfo.State.PeerCertificates) == 0 {
+		return clientCertIdentity{}, false
+	}
+
+	cert := tlsInfo.State.PeerCertificates[0]
+	identity := clientCertIdentity{
+		CommonName: cert.Subject.CommonName,
+		DNSNames:   cert.DNSNames,
+	}
+	for _, uri := range cert.URIs {
+		identity.URIs = append(identity.URIs, uri.String())
+	}
+
+	return identity, true
+}
@@ -0,0 +1,101 @@
+package grpc
+
+import (
+	"bufio"
+	"fmt"
+
+	"github.com/Hidayathamir/go-user/config"
+	"github.com/Hidayathamir/go-user/internal/usecase"
+	"github.com/Hidayathamir/go-user/pkg/gouser"
+	"github.com/Hidayathamir/go-user/pkg/gousergrpc"
+)
+
+// exportChunkSize is the max size of data sent in one gousergrpc.ExportChunk.
+const exportChunkSize = 32 * 1024
+
+// Export is controller GRPC for personal data export related.
+type Export struct {
+	gousergrpc.UnimplementedExportServer
+
+	cfg           config.Config
+	usecaseExport usecase.IExport
+}
+
+var _ gousergrpc.ExportServer = &Export{}
+
+func newExport(cfg config.Config, usecaseExport usecase.IExport) *Export {
+	return &Export{
+		cfg:           cfg,
+		usecaseExport: usecaseExport,
+	}
+}
+
+// ExportMyData implements gousergrpc.ExportServer.
+func (e *Export) ExportMyData(r *gousergrpc.ReqExportMyData, stream gousergrpc.Export_ExportMyDataServer) error {
+	req := gouser.ReqExportMyData{
+		UserJWT: r.GetUserJwt(),
+	}
+
+	w := bufio.NewWriterSize(exportChunkWriter{stream: stream}, exportChunkSize)
+
+	err := e.usecaseExport.ExportMyData(stream.Context(), req, w)
+	if err != nil {
+		err := fmt.Errorf("Export.usecaseExport.ExportMyData: %w", err)
+		return err
+	}
+
+	err = w.Flush()
+	if err != nil {
+		err := fmt.Errorf("bufio.Writer.Flush: %w", err)
+		return err
+	}
+
+	return nil
+}
+
+// ExportUserData implements gousergrpc.ExportServer.
+func (e *Export) ExportUserData(r *gousergrpc.ReqExportUserData, stream gousergrpc.Export_ExportUserDataServer) error {
+	req := gouser.ReqExportUserData{
+		UserJWT: r.GetUserJwt(),
+		UserID:  r.GetUserId(),
+	}
+
+	w := bufio.NewWriterSize(exportChunkWriter{stream: stream}, exportChunkSize)
+
+	err := e.usecaseExport.ExportUserData(stream.Context(), req, w)
+	if err != nil {
+		err := fmt.Errorf("Export.usecaseExport.ExportUserData: %w", err)
+		return err
+	}
+
+	err = w.Flush()
+	if err != nil {
+		err := fmt.Errorf("bufio.Writer.Flush: %w", err)
+		return err
+	}
+
+	return nil
+}
+
+// exportChunkSender is server stream of export RPCs.
+type exportChunkSender interface {
+	Send(*gousergrpc.ExportChunk) error
+}
+
+// exportChunkWriter is io.Writer which send every write as one
+// gousergrpc.ExportChunk.
+type exportChunkWriter struct {
+	stream exportChunkSender
+}
+
+func (e exportChunkWriter) Write(p []byte) (int, error) {
+	data := make([]byte, len(p))
+	copy(data, p)
+
+	err := e.stream.Send(&gousergrpc.ExportChunk{Data: data})
+	if err != nil {
+		return 0, fmt.Errorf("exportChunkSender.Send: %w", err)
+	}
+
+	return len(p), nil
+}
@@ -0,0 +1,160 @@
+package grpc
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/Hidayathamir/go-user/config"
+	"github.com/Hidayathamir/go-user/internal/usecase/mockusecase"
+	"github.com/Hidayathamir/go-user/pkg/gouser"
+	"github.com/Hidayathamir/go-user/pkg/gousergrpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc"
+)
+
+type mockExportMyDataServer struct {
+	grpc.ServerStream
+
+	chunks [][]byte
+}
+
+func (m *mockExportMyDataServer) Context() context.Context {
+	return context.Background()
+}
+
+func (m *mockExportMyDataServer) Send(chunk *gousergrpc.ExportChunk) error {
+	m.chunks = append(m.chunks, chunk.GetData())
+	return nil
+}
+
+type mockExportUserDataServer struct {
+	grpc.ServerStream
+
+	chunks [][]byte
+}
+
+func (m *mockExportUserDataServer) Context() context.Context {
+	return context.Background()
+}
+
+func (m *mockExportUserDataServer) Send(chunk *gousergrpc.ExportChunk) error {
+	m.chunks = append(m.chunks, chunk.GetData())
+	return nil
+}
+
+func TestUnitExportExportMyData(t *testing.T) {
+	t.Parallel()
+
+	t.Run("call usecase ExportMyData success should stream archive", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		usecaseExport := mockusecase.NewMockIExport(ctrl)
+
+		e := &Export{
+			cfg:           config.Config{},
+			usecaseExport: usecaseExport,
+		}
+
+		usecaseExport.EXPECT().
+			ExportMyData(gomock.Any(), gouser.ReqExportMyData{UserJWT: "Bearer dummyUserJWT"}, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ gouser.ReqExportMyData, w io.Writer) error {
+				_, err := io.WriteString(w, `{"version":1}`)
+				return err
+			})
+
+		stream := &mockExportMyDataServer{}
+		err := e.ExportMyData(&gousergrpc.ReqExportMyData{UserJwt: "Bearer dummyUserJWT"}, stream)
+
+		require.NoError(t, err)
+		assert.Equal(t, `{"version":1}`, string(bytes.Join(stream.chunks, nil)))
+	})
+	t.Run("call usecase ExportMyData error should return error", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		usecaseExport := mockusecase.NewMockIExport(ctrl)
+
+		e := &Export{
+			cfg:           config.Config{},
+			usecaseExport: usecaseExport,
+		}
+
+		usecaseExport.EXPECT().
+			ExportMyData(gomock.Any(), gouser.ReqExportMyData{UserJWT: "Bearer dummyUserJWT"}, gomock.Any()).
+			Return(assert.AnError)
+
+		stream := &mockExportMyDataServer{}
+		err := e.ExportMyData(&gousergrpc.ReqExportMyData{UserJwt: "Bearer dummyUserJWT"}, stream)
+
+		require.Error(t, err)
+		require.ErrorIs(t, err, assert.AnError)
+		assert.Empty(t, stream.chunks)
+	})
+}
+
+func TestUnitExportExportUserData(t *testing.T) {
+	t.Parallel()
+
+	t.Run("call usecase ExportUserData success should stream archive in chunks", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		usecaseExport := mockusecase.NewMockIExport(ctrl)
+
+		e := &Export{
+			cfg:           config.Config{},
+			usecaseExport: usecaseExport,
+		}
+
+		archive := `{"version":1,"sessions":[` + strings.Repeat(`{},`, exportChunkSize/3) + `{}]}`
+		usecaseExport.EXPECT().
+			ExportUserData(gomock.Any(), gouser.ReqExportUserData{UserJWT: "Bearer dummyAdminJWT", UserID: 44}, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ gouser.ReqExportUserData, w io.Writer) error {
+				_, err := io.WriteString(w, archive)
+				return err
+			})
+
+		stream := &mockExportUserDataServer{}
+		err := e.ExportUserData(&gousergrpc.ReqExportUserData{UserJwt: "Bearer dummyAdminJWT", UserId: 44}, stream)
+
+		require.NoError(t, err)
+		require.Len(t, stream.chunks, 2)
+		assert.Equal(t, archive, string(bytes.Join(stream.chunks, nil)))
+	})
+	t.Run("call usecase ExportUserData error should return error", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		usecaseExport := mockusecase.NewMockIExport(ctrl)
+
+		e := &Export{
+			cfg:           config.Config{},
+			usecaseExport: usecaseExport,
+		}
+
+		usecaseExport.EXPECT().
+			ExportUserData(gomock.Any(), gouser.ReqExportUserData{UserJWT: "Bearer dummyAdminJWT", UserID: 44}, gomock.Any()).
+			Return(assert.AnError)
+
+		stream := &mockExportUserDataServer{}
+		err := e.ExportUserData(&gousergrpc.ReqExportUserData{UserJwt: "Bearer dummyAdminJWT", UserId: 44}, stream)
+
+		require.Error(t, err)
+		require.ErrorIs(t, err, assert.AnError)
+		assert.Empty(t, stream.chunks)
+	})
+}
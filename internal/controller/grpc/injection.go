@@ -10,14 +10,54 @@ import (
 func injectionAuth(cfg config.Config, db *db.Postgres) *Auth {
 	repoAuth := repo.NewAuth(cfg, db)
 	repoProfile := repo.NewProfile(cfg, db)
-	usecaseAuth := usecase.NewAuth(cfg, repoAuth, repoProfile)
+	repoSession := repo.NewSession(cfg, db)
+	repoAccount := repo.NewAccount(cfg, db)
+	repoAuditLog := repo.NewAuditLog(cfg, db)
+	transactor := repo.NewTransactor(cfg, db)
+	usecaseAuth := usecase.NewAuthTracing(usecase.NewAuth(cfg, repoAuth, repoProfile, repoSession, repoAccount, repoAuditLog, transactor))
 	controllerAuth := newAuth(cfg, usecaseAuth)
 	return controllerAuth
 }
 
 func injectionProfile(cfg config.Config, db *db.Postgres) *Profile {
 	repoProfile := repo.NewProfile(cfg, db)
-	usecaseProfile := usecase.NewProfile(cfg, repoProfile)
+	repoSession := repo.NewSession(cfg, db)
+	repoOutbox := repo.NewOutbox(cfg, db)
+	repoAuditLog := repo.NewAuditLog(cfg, db)
+	transactor := repo.NewTransactor(cfg, db)
+	usecaseProfile := usecase.NewProfileTracing(usecase.NewProfile(cfg, repoProfile, repoSession, repoOutbox, repoAuditLog, transactor))
 	controllerProfile := newProfile(cfg, usecaseProfile)
 	return controllerProfile
 }
+
+func injectionSession(cfg config.Config, db *db.Postgres) *Session {
+	repoProfile := repo.NewProfile(cfg, db)
+	repoSession := repo.NewSession(cfg, db)
+	repoAuditLog := repo.NewAuditLog(cfg, db)
+	transactor := repo.NewTransactor(cfg, db)
+	usecaseSession := usecase.NewSession(cfg, repoSession, repoProfile, repoAuditLog, transactor)
+	controllerSession := newSession(cfg, usecaseSession)
+	return controllerSession
+}
+
+func injectionAccount(cfg config.Config, db *db.Postgres) *Account {
+	repoAccount := repo.NewAccount(cfg, db)
+	repoProfile := repo.NewProfile(cfg, db)
+	repoSession := repo.NewSession(cfg, db)
+	repoOutbox := repo.NewOutbox(cfg, db)
+	repoWebhook := repo.NewWebhook(cfg, db)
+	repoAuditLog := repo.NewAuditLog(cfg, db)
+	transactor := repo.NewTransactor(cfg, db)
+	usecaseAccount := usecase.NewAccount(cfg, repoAccount, repoProfile, repoSession, repoOutbox, repoWebhook, repoAuditLog, transactor)
+	controllerAccount := newAccount(cfg, usecaseAccount)
+	return controllerAccount
+}
+
+func injectionExport(cfg config.Config, db *db.Postgres) *Export {
+	repoProfile := repo.NewProfile(cfg, db)
+	repoSession := repo.NewSession(cfg, db)
+	repoAuditLog := repo.NewAuditLog(cfg, db)
+	usecaseExport := usecase.NewExport(cfg, repoProfile, repoSession, repoAuditLog)
+	controllerExport := newExport(cfg, usecaseExport)
+	return controllerExport
+}
@@ -0,0 +1,107 @@
+package grpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/Hidayathamir/go-user/internal/pkg/logger"
+	"github.com/Hidayathamir/go-user/internal/pkg/requestid"
+	"github.com/Hidayathamir/go-user/pkg/gouser"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// requestIDMetadataKey is metadata key of request id, the grpc counterpart of
+// X-Request-ID header.
+const requestIDMetadataKey = "x-request-id"
+
+// requestIDUnaryInterceptor take request id from incoming metadata, or
+// generate one if absent or invalid, put it in context and echo it in response
+// header metadata.
+func requestIDUnaryInterceptor(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	ctx, md := withRequestID(ctx)
+
+	// Error is ignored, header can only fail to be set when already sent.
+	_ = grpc.SetHeader(ctx, md)
+
+	return handler(ctx, req)
+}
+
+// requestIDStreamInterceptor is like requestIDUnaryInterceptor for stream.
+func requestIDStreamInterceptor(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, md := withRequestID(ss.Context())
+
+	_ = ss.SetHeader(md)
+
+	return handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+}
+
+func withRequestID(ctx context.Context) (context.Context, metadata.MD) {
+	requestID := requestid.Resolve(getIncomingMetadata(ctx, requestIDMetadataKey))
+	return gouser.WithRequestID(ctx, requestID), metadata.Pairs(requestIDMetadataKey, requestID)
+}
+
+// accessLogUnaryInterceptor put log and request logger fields in context, then
+// log the request once handled.
+func accessLogUnaryInterceptor(log *logrus.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		ctx = newLogContext(ctx, log, info.FullMethod)
+
+		res, err := handler(ctx, req)
+
+		logRequest(ctx, err, start)
+
+		return res, err
+	}
+}
+
+// accessLogStreamInterceptor is like accessLogUnaryInterceptor for stream, the
+// stream is logged once it ends.
+func accessLogStreamInterceptor(log *logrus.Logger) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		ctx := newLogContext(ss.Context(), log, info.FullMethod)
+
+		err := handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
+
+		logRequest(ctx, err, start)
+
+		return err
+	}
+}
+
+func newLogContext(ctx context.Context, log *logrus.Logger, fullMethod string) context.Context {
+	ctx = logger.WithLogger(ctx, log)
+	return logger.NewContext(ctx, logrus.Fields{logger.FieldRoute: fullMethod})
+}
+
+// logRequest log request by code, server error is logged as error, client
+// error as warning.
+func logRequest(ctx context.Context, err error, start time.Time) {
+	code := status.Code(err)
+
+	entry := logger.FromContext(ctx).WithFields(logrus.Fields{
+		"code":       code.String(),
+		"latency_ms": time.Since(start).Milliseconds(),
+		"client_ip":  getClientIP(ctx),
+	})
+	if identity, ok := getClientCertIdentity(ctx); ok {
+		entry = entry.WithField("client_cert_cn", identity.CommonName)
+	}
+	if err != nil {
+		entry = entry.WithError(err)
+	}
+
+	switch code {
+	case codes.OK:
+		entry.Info("grpc request")
+	case codes.Unknown, codes.Internal, codes.Unavailable, codes.DataLoss:
+		entry.Error("grpc request")
+	default:
+		entry.Warn("grpc request")
+	}
+}
@@ -0,0 +1,102 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Hidayathamir/go-user/internal/pkg/logger"
+	"github.com/Hidayathamir/go-user/pkg/gouser"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	logrustest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestUnitRequestIDUnaryInterceptor(t *testing.T) {
+	t.Parallel()
+
+	t.Run("request id from metadata should be put in context", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(requestIDMetadataKey, "req-from-client"))
+		var handlerRequestID string
+		handler := func(ctx context.Context, _ any) (any, error) {
+			handlerRequestID = gouser.RequestIDFromContext(ctx)
+			return nil, nil
+		}
+
+		_, err := requestIDUnaryInterceptor(ctx, nil, &grpc.UnaryServerInfo{}, handler)
+
+		require.NoError(t, err)
+		assert.Equal(t, "req-from-client", handlerRequestID)
+	})
+	t.Run("request without request id should get generated one", func(t *testing.T) {
+		t.Parallel()
+
+		var handlerRequestID string
+		handler := func(ctx context.Context, _ any) (any, error) {
+			handlerRequestID = gouser.RequestIDFromContext(ctx)
+			return nil, nil
+		}
+
+		_, err := requestIDUnaryInterceptor(context.Background(), nil, &grpc.UnaryServerInfo{}, handler)
+
+		require.NoError(t, err)
+		_, err = uuid.Parse(handlerRequestID)
+		require.NoError(t, err)
+	})
+}
+
+func TestUnitAccessLogUnaryInterceptor(t *testing.T) {
+	t.Parallel()
+
+	t.Run("client error should be logged as warning with request fields", func(t *testing.T) {
+		t.Parallel()
+
+		log, hook := logrustest.NewNullLogger()
+		ctx := gouser.WithRequestID(context.Background(), "req-access-log-warn")
+		info := &grpc.UnaryServerInfo{FullMethod: "/test.Logging/Unary"}
+		handler := func(ctx context.Context, _ any) (any, error) {
+			logger.AddFields(ctx, logrus.Fields{logger.FieldUserID: int64(99)})
+			return nil, status.Error(codes.InvalidArgument, "invalid")
+		}
+
+		_, err := accessLogUnaryInterceptor(log)(ctx, nil, info, handler)
+
+		require.Error(t, err)
+		entry := hook.LastEntry()
+		require.NotNil(t, entry)
+		assert.Equal(t, "grpc request", entry.Message)
+		assert.Equal(t, "req-access-log-warn", entry.Data[logger.FieldRequestID])
+		assert.Equal(t, logrus.WarnLevel, entry.Level)
+		assert.Equal(t, "/test.Logging/Unary", entry.Data[logger.FieldRoute])
+		assert.Equal(t, int64(99), entry.Data[logger.FieldUserID])
+		assert.Equal(t, codes.InvalidArgument.String(), entry.Data["code"])
+		assert.Equal(t, err, entry.Data[logrus.ErrorKey])
+	})
+	t.Run("internal error should be logged as error", func(t *testing.T) {
+		t.Parallel()
+
+		log, hook := logrustest.NewNullLogger()
+		ctx := gouser.WithRequestID(context.Background(), "req-access-log-error")
+		info := &grpc.UnaryServerInfo{FullMethod: "/test.Logging/Unary"}
+		handler := func(context.Context, any) (any, error) {
+			return nil, assert.AnError
+		}
+
+		_, err := accessLogUnaryInterceptor(log)(ctx, nil, info, handler)
+
+		require.Error(t, err)
+		entry := hook.LastEntry()
+		require.NotNil(t, entry)
+		assert.Equal(t, "grpc request", entry.Message)
+		assert.Equal(t, "req-access-log-error", entry.Data[logger.FieldRequestID])
+		assert.Equal(t, logrus.ErrorLevel, entry.Level)
+		assert.Equal(t, codes.Unknown.String(), entry.Data["code"])
+	})
+}
@@ -0,0 +1,41 @@
+package grpc
+
+import (
+	"context"
+	"time"
+
+	"github.com/Hidayathamir/go-user/internal/pkg/metrics"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+// metricsUnaryInterceptor count request and observe its latency by method
+// and code.
+func metricsUnaryInterceptor(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	start := time.Now()
+
+	res, err := handler(ctx, req)
+
+	observeRequest(info.FullMethod, err, start)
+
+	return res, err
+}
+
+// metricsStreamInterceptor count stream and observe its lifetime by method
+// and code.
+func metricsStreamInterceptor(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+
+	err := handler(srv, ss)
+
+	observeRequest(info.FullMethod, err, start)
+
+	return err
+}
+
+func observeRequest(method string, err error, start time.Time) {
+	code := status.Code(err).String()
+
+	metrics.GRPCRequestsTotal.WithLabelValues(method, code).Inc()
+	metrics.GRPCRequestDuration.WithLabelValues(method, code).Observe(time.Since(start).Seconds())
+}
@@ -0,0 +1,52 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Hidayathamir/go-user/internal/pkg/metrics"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestUnitMetricsUnaryInterceptor(t *testing.T) {
+	t.Parallel()
+
+	t.Run("request should be counted by method and code", func(t *testing.T) {
+		t.Parallel()
+
+		info := &grpc.UnaryServerInfo{FullMethod: "/test.Metrics/Unary"}
+		handler := func(context.Context, any) (any, error) {
+			return nil, status.Error(codes.NotFound, "not found")
+		}
+
+		_, err := metricsUnaryInterceptor(context.Background(), nil, info, handler)
+
+		require.Error(t, err)
+		counter := metrics.GRPCRequestsTotal.WithLabelValues("/test.Metrics/Unary", codes.NotFound.String())
+		assert.Equal(t, float64(1), testutil.ToFloat64(counter))
+	})
+}
+
+func TestUnitMetricsStreamInterceptor(t *testing.T) {
+	t.Parallel()
+
+	t.Run("stream should be counted by method and code", func(t *testing.T) {
+		t.Parallel()
+
+		info := &grpc.StreamServerInfo{FullMethod: "/test.Metrics/Stream"}
+		handler := func(any, grpc.ServerStream) error {
+			return nil
+		}
+
+		err := metricsStreamInterceptor(nil, &mockWatchUsersServer{}, info, handler)
+
+		require.NoError(t, err)
+		counter := metrics.GRPCRequestsTotal.WithLabelValues("/test.Metrics/Stream", codes.OK.String())
+		assert.Equal(t, float64(1), testutil.ToFloat64(counter))
+	})
+}
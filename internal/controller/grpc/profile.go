@@ -2,13 +2,18 @@ package grpc
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/Hidayathamir/go-user/config"
 	"github.com/Hidayathamir/go-user/internal/usecase"
 	"github.com/Hidayathamir/go-user/pkg/gouser"
 	"github.com/Hidayathamir/go-user/pkg/gousergrpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
 )
 
 // Profile is controller GRPC for profile related.
@@ -43,6 +48,53 @@ func (p *Profile) GetProfileByUsername(c context.Context, r *gousergrpc.ReqGetPr
 		Username:  user.Username,
 		CreatedAt: timestamppb.New(user.CreatedAt),
 		UpdatedAt: timestamppb.New(user.UpdatedAt),
+		Moved:     user.Moved,
+	}
+
+	return res, nil
+}
+
+// GetProfileByUserID implements gousergrpc.ProfileServer.
+func (p *Profile) GetProfileByUserID(c context.Context, r *gousergrpc.ReqGetProfileByUserID) (*gousergrpc.ResGetProfileByUsername, error) {
+	req := gouser.ReqGetProfileByUserID{UserID: r.GetUserId()}
+
+	user, err := p.usecaseProfile.GetProfileByUserID(c, req)
+	if err != nil {
+		err := fmt.Errorf("Profile.usecaseProfile.GetProfileByUserID: %w", err)
+		return nil, err
+	}
+
+	res := &gousergrpc.ResGetProfileByUsername{
+		Id:        user.ID,
+		Username:  user.Username,
+		CreatedAt: timestamppb.New(user.CreatedAt),
+		UpdatedAt: timestamppb.New(user.UpdatedAt),
+	}
+
+	return res, nil
+}
+
+// GetMyProfile implements gousergrpc.ProfileServer.
+func (p *Profile) GetMyProfile(c context.Context, r *gousergrpc.ReqGetMyProfile) (*gousergrpc.ResGetMyProfile, error) {
+	req := gouser.ReqGetMyProfile{UserJWT: r.GetUserJwt()}
+
+	user, err := p.usecaseProfile.GetMyProfile(c, req)
+	if err != nil {
+		err := fmt.Errorf("Profile.usecaseProfile.GetMyProfile: %w", err)
+		return nil, err
+	}
+
+	res := &gousergrpc.ResGetMyProfile{
+		Id:               user.ID,
+		Username:         user.Username,
+		Role:             user.Role,
+		Status:           user.Status,
+		SuspensionReason: user.SuspensionReason,
+		CreatedAt:        timestamppb.New(user.CreatedAt),
+		UpdatedAt:        timestamppb.New(user.UpdatedAt),
+	}
+	if user.SuspendedUntil != nil {
+		res.SuspendedUntil = timestamppb.New(*user.SuspendedUntil)
 	}
 
 	return res, nil
@@ -51,8 +103,11 @@ func (p *Profile) GetProfileByUsername(c context.Context, r *gousergrpc.ReqGetPr
 // UpdateProfileByUserID implements gousergrpc.ProfileServer.
 func (p *Profile) UpdateProfileByUserID(c context.Context, r *gousergrpc.ReqUpdateProfileByUserID) (*gousergrpc.ProfileEmpty, error) {
 	req := gouser.ReqUpdateProfileByUserID{
-		UserJWT:  r.GetUserJwt(),
-		Password: r.GetPassword(),
+		UserJWT:   r.GetUserJwt(),
+		Password:  r.GetPassword(),
+		UserAgent: getClientUserAgent(c),
+		IP:        getClientIP(c),
+		RequestID: gouser.RequestIDFromContext(c),
 	}
 
 	err := p.usecaseProfile.UpdateProfileByUserID(c, req)
@@ -65,3 +120,132 @@ func (p *Profile) UpdateProfileByUserID(c context.Context, r *gousergrpc.ReqUpda
 
 	return res, nil
 }
+
+// ListUsers implements gousergrpc.ProfileServer.
+func (p *Profile) ListUsers(c context.Context, r *gousergrpc.ReqListUsers) (*gousergrpc.ResListUsers, error) {
+	req := gouser.ReqListUsers{
+		UserJWT:        r.GetUserJwt(),
+		Cursor:         r.GetCursor(),
+		Limit:          int(r.GetLimit()),
+		Status:         r.GetStatus(),
+		UsernamePrefix: r.GetUsernamePrefix(),
+		SortBy:         r.GetSortBy(),
+		SortOrder:      r.GetSortOrder(),
+		WithTotalCount: r.GetWithTotalCount(),
+	}
+	if r.GetCreatedFrom() != nil {
+		createdFrom := r.GetCreatedFrom().AsTime()
+		req.CreatedFrom = &createdFrom
+	}
+	if r.GetCreatedTo() != nil {
+		createdTo := r.GetCreatedTo().AsTime()
+		req.CreatedTo = &createdTo
+	}
+
+	resListUsers, err := p.usecaseProfile.ListUsers(c, req)
+	if err != nil {
+		err := fmt.Errorf("Profile.usecaseProfile.ListUsers: %w", err)
+		return nil, err
+	}
+
+	res := &gousergrpc.ResListUsers{
+		Users:      make([]*gousergrpc.User, 0, len(resListUsers.Users)),
+		NextCursor: resListUsers.NextCursor,
+	}
+	for _, user := range resListUsers.Users {
+		resUser := &gousergrpc.User{
+			Id:               user.ID,
+			Username:         user.Username,
+			Role:             user.Role,
+			Status:           user.Status,
+			SuspensionReason: user.SuspensionReason,
+			CreatedAt:        timestamppb.New(user.CreatedAt),
+			UpdatedAt:        timestamppb.New(user.UpdatedAt),
+		}
+		if user.SuspendedUntil != nil {
+			resUser.SuspendedUntil = timestamppb.New(*user.SuspendedUntil)
+		}
+		res.Users = append(res.Users, resUser)
+	}
+	if resListUsers.TotalCount != nil {
+		res.TotalCount = wrapperspb.Int64(*resListUsers.TotalCount)
+	}
+
+	return res, nil
+}
+
+// BatchGetProfiles implements gousergrpc.ProfileServer.
+func (p *Profile) BatchGetProfiles(c context.Context, r *gousergrpc.ReqBatchGetProfiles) (*gousergrpc.ResBatchGetProfiles, error) {
+	req := gouser.ReqBatchGetProfiles{
+		UserIDs:   r.GetUserIds(),
+		Usernames: r.GetUsernames(),
+	}
+
+	resBatchGetProfiles, err := p.usecaseProfile.BatchGetProfiles(c, req)
+	if err != nil {
+		err := fmt.Errorf("Profile.usecaseProfile.BatchGetProfiles: %w", err)
+		return nil, err
+	}
+
+	res := &gousergrpc.ResBatchGetProfiles{
+		Profiles:         make([]*gousergrpc.ResGetProfileByUsername, 0, len(resBatchGetProfiles.Profiles)),
+		MissingUserIds:   resBatchGetProfiles.MissingUserIDs,
+		MissingUsernames: resBatchGetProfiles.MissingUsernames,
+	}
+	for _, user := range resBatchGetProfiles.Profiles {
+		res.Profiles = append(res.Profiles, &gousergrpc.ResGetProfileByUsername{
+			Id:        user.ID,
+			Username:  user.Username,
+			CreatedAt: timestamppb.New(user.CreatedAt),
+			UpdatedAt: timestamppb.New(user.UpdatedAt),
+		})
+	}
+
+	return res, nil
+}
+
+// WatchUsers implements gousergrpc.ProfileServer. The start cursor is sent in
+// header gousergrpc.WatchUsersCursorHeader before any event.
+func (p *Profile) WatchUsers(r *gousergrpc.ReqWatchUsers, stream gousergrpc.Profile_WatchUsersServer) error {
+	req := gouser.ReqWatchUsers{
+		UserJWT: r.GetUserJwt(),
+		Cursor:  r.GetCursor(),
+	}
+
+	started := func(cursor string) error {
+		err := stream.SendHeader(metadata.Pairs(gousergrpc.WatchUsersCursorHeader, cursor))
+		if err != nil {
+			return fmt.Errorf("gousergrpc.Profile_WatchUsersServer.SendHeader: %w", err)
+		}
+		return nil
+	}
+
+	err := p.usecaseProfile.WatchUsers(stream.Context(), req, started, func(userEvent gouser.UserEvent) error {
+		err := stream.Send(&gousergrpc.UserEvent{
+			Cursor:      userEvent.Cursor,
+			Change:      userEvent.Change,
+			UserId:      userEvent.UserID,
+			Username:    userEvent.Username,
+			OldUsername: userEvent.OldUsername,
+			OccurredAt:  timestamppb.New(userEvent.OccurredAt),
+		})
+		if err != nil {
+			return fmt.Errorf("gousergrpc.Profile_WatchUsersServer.Send: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		err := fmt.Errorf("Profile.usecaseProfile.WatchUsers: %w", err)
+		switch {
+		case errors.Is(err, gouser.ErrRequestInvalid):
+			return status.Error(codes.InvalidArgument, err.Error())
+		case errors.Is(err, gouser.ErrJWTAuth), errors.Is(err, gouser.ErrUserNotActive):
+			return status.Error(codes.Unauthenticated, err.Error())
+		case errors.Is(err, gouser.ErrForbidden):
+			return status.Error(codes.PermissionDenied, err.Error())
+		}
+		return err
+	}
+
+	return nil
+}
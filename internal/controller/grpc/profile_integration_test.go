@@ -27,10 +27,12 @@ func TestIntegrationProfileUpdateProfileByUserID(t *testing.T) {
 
 		repoAuth := repo.NewAuth(cfg, pg)
 		repoProfile := repo.NewProfile(cfg, pg)
-		usecaseAuth := usecase.NewAuth(cfg, repoAuth, repoProfile)
+		repoSession := repo.NewSession(cfg, pg)
+		repoAccount := repo.NewAccount(cfg, pg)
+		usecaseAuth := usecase.NewAuth(cfg, repoAuth, repoProfile, repoSession, repoAccount, repo.NewAuditLog(cfg, pg), repo.NewTransactor(cfg, pg))
 		controllerAuth := newAuth(cfg, usecaseAuth)
 
-		usecaseProfile := usecase.NewProfile(cfg, repoProfile)
+		usecaseProfile := usecase.NewProfile(cfg, repoProfile, repoSession, repo.NewOutbox(cfg, pg), repo.NewAuditLog(cfg, pg), repo.NewTransactor(cfg, pg))
 		controllerProfile := newProfile(cfg, usecaseProfile)
 
 		username := uuid.NewString()
@@ -84,7 +86,10 @@ func TestIntegrationProfileUpdateProfileByUserID(t *testing.T) {
 		require.NoError(t, err)
 
 		repoProfile := repo.NewProfile(cfg, pg)
-		usecaseProfile := usecase.NewProfile(cfg, repoProfile)
+
+		repoSession := repo.NewSession(cfg, pg)
+		repoAccount := repo.NewAccount(cfg, pg)
+		usecaseProfile := usecase.NewProfile(cfg, repoProfile, repoSession, repo.NewOutbox(cfg, pg), repo.NewAuditLog(cfg, pg), repo.NewTransactor(cfg, pg))
 		controllerProfile := newProfile(cfg, usecaseProfile)
 
 		t.Run("request user jwt empty should error", func(t *testing.T) {
@@ -106,7 +111,7 @@ func TestIntegrationProfileUpdateProfileByUserID(t *testing.T) {
 		})
 		t.Run("request password empty should error", func(t *testing.T) {
 			repoAuth := repo.NewAuth(cfg, pg)
-			usecaseAuth := usecase.NewAuth(cfg, repoAuth, repoProfile)
+			usecaseAuth := usecase.NewAuth(cfg, repoAuth, repoProfile, repoSession, repoAccount, repo.NewAuditLog(cfg, pg), repo.NewTransactor(cfg, pg))
 			controllerAuth := newAuth(cfg, usecaseAuth)
 
 			username := uuid.NewString()
@@ -149,10 +154,12 @@ func TestIntegrationProfileGetProfileByUsername(t *testing.T) {
 
 		repoAuth := repo.NewAuth(cfg, pg)
 		repoProfile := repo.NewProfile(cfg, pg)
-		usecaseAuth := usecase.NewAuth(cfg, repoAuth, repoProfile)
+		repoSession := repo.NewSession(cfg, pg)
+		repoAccount := repo.NewAccount(cfg, pg)
+		usecaseAuth := usecase.NewAuth(cfg, repoAuth, repoProfile, repoSession, repoAccount, repo.NewAuditLog(cfg, pg), repo.NewTransactor(cfg, pg))
 		controllerAuth := newAuth(cfg, usecaseAuth)
 
-		usecaseProfile := usecase.NewProfile(cfg, repoProfile)
+		usecaseProfile := usecase.NewProfile(cfg, repoProfile, repoSession, repo.NewOutbox(cfg, pg), repo.NewAuditLog(cfg, pg), repo.NewTransactor(cfg, pg))
 		controllerProfile := newProfile(cfg, usecaseProfile)
 
 		username := uuid.NewString()
@@ -181,7 +188,9 @@ func TestIntegrationProfileGetProfileByUsername(t *testing.T) {
 		require.NoError(t, err)
 
 		repoProfile := repo.NewProfile(cfg, pg)
-		usecaseProfile := usecase.NewProfile(cfg, repoProfile)
+
+		repoSession := repo.NewSession(cfg, pg)
+		usecaseProfile := usecase.NewProfile(cfg, repoProfile, repoSession, repo.NewOutbox(cfg, pg), repo.NewAuditLog(cfg, pg), repo.NewTransactor(cfg, pg))
 		controllerProfile := newProfile(cfg, usecaseProfile)
 
 		res, err := controllerProfile.GetProfileByUsername(context.Background(), &gousergrpc.ReqGetProfileByUsername{
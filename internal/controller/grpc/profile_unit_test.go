@@ -12,6 +12,11 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 func TestUnitProfileGetProfileByUsername(t *testing.T) {
@@ -148,3 +153,400 @@ func TestProfileUpdateProfileByUserID(t *testing.T) {
 		require.ErrorIs(t, err, assert.AnError)
 	})
 }
+
+func TestUnitProfileListUsers(t *testing.T) {
+	t.Parallel()
+
+	t.Run("call usecase ListUsers success should return success", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		usecaseProfile := mockusecase.NewMockIProfile(ctrl)
+
+		p := &Profile{
+			cfg:            config.Config{},
+			usecaseProfile: usecaseProfile,
+		}
+
+		createdFrom := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+		suspendedUntil := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+		totalCount := int64(3)
+		usecaseProfile.EXPECT().
+			ListUsers(gomock.Any(), gouser.ReqListUsers{
+				UserJWT:        "Bearer dummyAdminJWT",
+				Limit:          2,
+				Status:         "suspended",
+				CreatedFrom:    &createdFrom,
+				WithTotalCount: true,
+			}).
+			Return(gouser.ResListUsers{
+				Users:      []gouser.User{{ID: 9, Username: "hidayat", Status: "suspended", SuspendedUntil: &suspendedUntil}},
+				NextCursor: "def",
+				TotalCount: &totalCount,
+			}, nil)
+
+		res, err := p.ListUsers(context.Background(), &gousergrpc.ReqListUsers{
+			UserJwt:        "Bearer dummyAdminJWT",
+			Limit:          2,
+			Status:         "suspended",
+			CreatedFrom:    timestamppb.New(createdFrom),
+			WithTotalCount: true,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, res.GetUsers(), 1)
+		assert.Equal(t, int64(9), res.GetUsers()[0].GetId())
+		assert.True(t, suspendedUntil.Equal(res.GetUsers()[0].GetSuspendedUntil().AsTime()))
+		assert.Equal(t, "def", res.GetNextCursor())
+		assert.Equal(t, int64(3), res.GetTotalCount().GetValue())
+	})
+	t.Run("call usecase ListUsers error should return error", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		usecaseProfile := mockusecase.NewMockIProfile(ctrl)
+
+		p := &Profile{
+			cfg:            config.Config{},
+			usecaseProfile: usecaseProfile,
+		}
+
+		usecaseProfile.EXPECT().
+			ListUsers(gomock.Any(), gouser.ReqListUsers{UserJWT: "Bearer dummyAdminJWT"}).
+			Return(gouser.ResListUsers{}, assert.AnError)
+
+		res, err := p.ListUsers(context.Background(), &gousergrpc.ReqListUsers{UserJwt: "Bearer dummyAdminJWT"})
+
+		assert.Nil(t, res)
+		require.Error(t, err)
+		require.ErrorIs(t, err, assert.AnError)
+	})
+}
+
+func TestUnitProfileBatchGetProfiles(t *testing.T) {
+	t.Parallel()
+
+	t.Run("call usecase BatchGetProfiles success should return success", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		usecaseProfile := mockusecase.NewMockIProfile(ctrl)
+
+		p := &Profile{
+			cfg:            config.Config{},
+			usecaseProfile: usecaseProfile,
+		}
+
+		usecaseProfile.EXPECT().
+			BatchGetProfiles(gomock.Any(), gouser.ReqBatchGetProfiles{UserIDs: []int64{1, 2}, Usernames: []string{"hidayat"}}).
+			Return(gouser.ResBatchGetProfiles{
+				Profiles:         []gouser.ResGetProfileByUsername{{ID: 1, Username: "hidayat1"}, {ID: 3, Username: "hidayat"}},
+				MissingUserIDs:   []int64{2},
+				MissingUsernames: []string{},
+			}, nil)
+
+		res, err := p.BatchGetProfiles(context.Background(), &gousergrpc.ReqBatchGetProfiles{
+			UserIds:   []int64{1, 2},
+			Usernames: []string{"hidayat"},
+		})
+
+		require.NoError(t, err)
+		require.Len(t, res.GetProfiles(), 2)
+		assert.Equal(t, int64(1), res.GetProfiles()[0].GetId())
+		assert.Equal(t, "hidayat", res.GetProfiles()[1].GetUsername())
+		assert.Equal(t, []int64{2}, res.GetMissingUserIds())
+		assert.Empty(t, res.GetMissingUsernames())
+	})
+	t.Run("call usecase BatchGetProfiles error should return error", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		usecaseProfile := mockusecase.NewMockIProfile(ctrl)
+
+		p := &Profile{
+			cfg:            config.Config{},
+			usecaseProfile: usecaseProfile,
+		}
+
+		usecaseProfile.EXPECT().
+			BatchGetProfiles(gomock.Any(), gouser.ReqBatchGetProfiles{UserIDs: []int64{1}}).
+			Return(gouser.ResBatchGetProfiles{}, assert.AnError)
+
+		res, err := p.BatchGetProfiles(context.Background(), &gousergrpc.ReqBatchGetProfiles{UserIds: []int64{1}})
+
+		assert.Nil(t, res)
+		require.Error(t, err)
+		require.ErrorIs(t, err, assert.AnError)
+	})
+}
+
+func TestUnitProfileGetProfileByUserID(t *testing.T) {
+	t.Parallel()
+
+	t.Run("call usecase GetProfileByUserID success should return success", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		usecaseProfile := mockusecase.NewMockIProfile(ctrl)
+
+		p := &Profile{
+			cfg:            config.Config{},
+			usecaseProfile: usecaseProfile,
+		}
+
+		usecaseProfile.EXPECT().
+			GetProfileByUserID(gomock.Any(), gouser.ReqGetProfileByUserID{UserID: 23}).
+			Return(gouser.ResGetProfileByUsername{ID: 23, Username: "hidayat"}, nil)
+
+		res, err := p.GetProfileByUserID(context.Background(), &gousergrpc.ReqGetProfileByUserID{UserId: 23})
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(23), res.GetId())
+		assert.Equal(t, "hidayat", res.GetUsername())
+	})
+	t.Run("call usecase GetProfileByUserID error should return error", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		usecaseProfile := mockusecase.NewMockIProfile(ctrl)
+
+		p := &Profile{
+			cfg:            config.Config{},
+			usecaseProfile: usecaseProfile,
+		}
+
+		usecaseProfile.EXPECT().
+			GetProfileByUserID(gomock.Any(), gouser.ReqGetProfileByUserID{UserID: 23}).
+			Return(gouser.ResGetProfileByUsername{}, assert.AnError)
+
+		res, err := p.GetProfileByUserID(context.Background(), &gousergrpc.ReqGetProfileByUserID{UserId: 23})
+
+		assert.Nil(t, res)
+		require.Error(t, err)
+		require.ErrorIs(t, err, assert.AnError)
+	})
+}
+
+func TestUnitProfileGetMyProfile(t *testing.T) {
+	t.Parallel()
+
+	t.Run("call usecase GetMyProfile success should return success", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		usecaseProfile := mockusecase.NewMockIProfile(ctrl)
+
+		p := &Profile{
+			cfg:            config.Config{},
+			usecaseProfile: usecaseProfile,
+		}
+
+		suspendedUntil := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+		usecaseProfile.EXPECT().
+			GetMyProfile(gomock.Any(), gouser.ReqGetMyProfile{UserJWT: "Bearer dummyUserJWT"}).
+			Return(gouser.ResGetMyProfile{
+				ID: 23, Username: "hidayat", Role: "user", Status: "suspended",
+				SuspendedUntil: &suspendedUntil, SuspensionReason: "spam",
+			}, nil)
+
+		res, err := p.GetMyProfile(context.Background(), &gousergrpc.ReqGetMyProfile{UserJwt: "Bearer dummyUserJWT"})
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(23), res.GetId())
+		assert.Equal(t, "user", res.GetRole())
+		assert.Equal(t, "suspended", res.GetStatus())
+		assert.True(t, suspendedUntil.Equal(res.GetSuspendedUntil().AsTime()))
+		assert.Equal(t, "spam", res.GetSuspensionReason())
+	})
+	t.Run("call usecase GetMyProfile error should return error", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		usecaseProfile := mockusecase.NewMockIProfile(ctrl)
+
+		p := &Profile{
+			cfg:            config.Config{},
+			usecaseProfile: usecaseProfile,
+		}
+
+		usecaseProfile.EXPECT().
+			GetMyProfile(gomock.Any(), gouser.ReqGetMyProfile{UserJWT: "Bearer dummyUserJWT"}).
+			Return(gouser.ResGetMyProfile{}, assert.AnError)
+
+		res, err := p.GetMyProfile(context.Background(), &gousergrpc.ReqGetMyProfile{UserJwt: "Bearer dummyUserJWT"})
+
+		assert.Nil(t, res)
+		require.Error(t, err)
+		require.ErrorIs(t, err, assert.AnError)
+	})
+}
+
+type mockWatchUsersServer struct {
+	grpc.ServerStream
+
+	header     metadata.MD
+	userEvents []*gousergrpc.UserEvent
+}
+
+func (m *mockWatchUsersServer) SendHeader(header metadata.MD) error {
+	m.header = header
+	return nil
+}
+
+func (m *mockWatchUsersServer) Context() context.Context {
+	return context.Background()
+}
+
+func (m *mockWatchUsersServer) Send(userEvent *gousergrpc.UserEvent) error {
+	m.userEvents = append(m.userEvents, userEvent)
+	return nil
+}
+
+func TestUnitProfileWatchUsers(t *testing.T) {
+	t.Parallel()
+
+	t.Run("call usecase WatchUsers should send every user event", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		usecaseProfile := mockusecase.NewMockIProfile(ctrl)
+
+		p := &Profile{
+			cfg:            config.Config{},
+			usecaseProfile: usecaseProfile,
+		}
+
+		occurredAt := time.Date(2024, 4, 7, 9, 0, 0, 0, time.UTC)
+		usecaseProfile.EXPECT().
+			WatchUsers(gomock.Any(), gouser.ReqWatchUsers{UserJWT: "adminjwt", Cursor: "c7"}, gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ gouser.ReqWatchUsers, started func(string) error, send func(gouser.UserEvent) error) error {
+				err := started("c7")
+				if err != nil {
+					return err
+				}
+				err = send(gouser.UserEvent{Cursor: "c8", Change: gouser.UserChangeUpdated, UserID: 44, Username: "hidayat2", OldUsername: "hidayat", OccurredAt: occurredAt})
+				if err != nil {
+					return err
+				}
+				return send(gouser.UserEvent{Cursor: "c9", Change: gouser.UserChangeDeleted, UserID: 44, OccurredAt: occurredAt})
+			})
+
+		stream := &mockWatchUsersServer{}
+		err := p.WatchUsers(&gousergrpc.ReqWatchUsers{UserJwt: "adminjwt", Cursor: "c7"}, stream)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"c7"}, stream.header.Get(gousergrpc.WatchUsersCursorHeader))
+		require.Len(t, stream.userEvents, 2)
+		assert.Equal(t, "c8", stream.userEvents[0].GetCursor())
+		assert.Equal(t, gouser.UserChangeUpdated, stream.userEvents[0].GetChange())
+		assert.Equal(t, "hidayat", stream.userEvents[0].GetOldUsername())
+		assert.Equal(t, timestamppb.New(occurredAt).AsTime(), stream.userEvents[0].GetOccurredAt().AsTime())
+		assert.Equal(t, gouser.UserChangeDeleted, stream.userEvents[1].GetChange())
+	})
+	t.Run("request invalid should return invalid argument", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		usecaseProfile := mockusecase.NewMockIProfile(ctrl)
+
+		p := &Profile{
+			cfg:            config.Config{},
+			usecaseProfile: usecaseProfile,
+		}
+
+		usecaseProfile.EXPECT().
+			WatchUsers(gomock.Any(), gouser.ReqWatchUsers{Cursor: "bad"}, gomock.Any(), gomock.Any()).
+			Return(gouser.ErrRequestInvalid)
+
+		err := p.WatchUsers(&gousergrpc.ReqWatchUsers{Cursor: "bad"}, &mockWatchUsersServer{})
+
+		require.Error(t, err)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+	t.Run("jwt auth error should return unauthenticated", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		usecaseProfile := mockusecase.NewMockIProfile(ctrl)
+
+		p := &Profile{
+			cfg:            config.Config{},
+			usecaseProfile: usecaseProfile,
+		}
+
+		usecaseProfile.EXPECT().
+			WatchUsers(gomock.Any(), gouser.ReqWatchUsers{UserJWT: "expiredjwt"}, gomock.Any(), gomock.Any()).
+			Return(gouser.ErrJWTAuth)
+
+		err := p.WatchUsers(&gousergrpc.ReqWatchUsers{UserJwt: "expiredjwt"}, &mockWatchUsersServer{})
+
+		require.Error(t, err)
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+	t.Run("non admin should return permission denied", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		usecaseProfile := mockusecase.NewMockIProfile(ctrl)
+
+		p := &Profile{
+			cfg:            config.Config{},
+			usecaseProfile: usecaseProfile,
+		}
+
+		usecaseProfile.EXPECT().
+			WatchUsers(gomock.Any(), gouser.ReqWatchUsers{UserJWT: "userjwt"}, gomock.Any(), gomock.Any()).
+			Return(gouser.ErrForbidden)
+
+		err := p.WatchUsers(&gousergrpc.ReqWatchUsers{UserJwt: "userjwt"}, &mockWatchUsersServer{})
+
+		require.Error(t, err)
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+	})
+	t.Run("call usecase WatchUsers error should return error", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		usecaseProfile := mockusecase.NewMockIProfile(ctrl)
+
+		p := &Profile{
+			cfg:            config.Config{},
+			usecaseProfile: usecaseProfile,
+		}
+
+		usecaseProfile.EXPECT().
+			WatchUsers(gomock.Any(), gouser.ReqWatchUsers{}, gomock.Any(), gomock.Any()).
+			Return(assert.AnError)
+
+		err := p.WatchUsers(&gousergrpc.ReqWatchUsers{}, &mockWatchUsersServer{})
+
+		require.Error(t, err)
+		require.ErrorIs(t, err, assert.AnError)
+	})
+}
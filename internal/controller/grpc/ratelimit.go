@@ -0,0 +1,132 @@
+package grpc
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/Hidayathamir/go-user/internal/pkg/logger"
+	"github.com/Hidayathamir/go-user/internal/pkg/metrics"
+	"github.com/Hidayathamir/go-user/internal/pkg/ratelimit"
+	"github.com/Hidayathamir/go-user/pkg/gouser"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// rate limit metadata key, the grpc counterpart of rate limit http headers.
+const (
+	apiKeyMetadataKey             = "x-api-key"
+	rateLimitLimitMetadataKey     = "ratelimit-limit"
+	rateLimitRemainingMetadataKey = "ratelimit-remaining"
+	rateLimitResetMetadataKey     = "ratelimit-reset"
+	retryAfterMetadataKey         = "retry-after"
+)
+
+// rateLimitGroups map full method to rate limit route group, mirroring http
+// route groups. Method not listed, e.g Ping, is not rate limited.
+var rateLimitGroups = map[string]string{
+	"/gousergrpc.Auth/LoginUser":                ratelimit.GroupAuth,
+	"/gousergrpc.Auth/RegisterUser":             ratelimit.GroupAuth,
+	"/gousergrpc.Account/RestoreAccount":        ratelimit.GroupAuth,
+	"/gousergrpc.Profile/GetProfileByUsername":  ratelimit.GroupUsers,
+	"/gousergrpc.Profile/GetProfileByUserID":    ratelimit.GroupUsers,
+	"/gousergrpc.Profile/GetMyProfile":          ratelimit.GroupUsers,
+	"/gousergrpc.Profile/UpdateProfileByUserID": ratelimit.GroupUsers,
+	"/gousergrpc.Profile/ListUsers":             ratelimit.GroupUsers,
+	"/gousergrpc.Profile/BatchGetProfiles":      ratelimit.GroupUsers,
+	"/gousergrpc.Profile/WatchUsers":            ratelimit.GroupUsers,
+	"/gousergrpc.Account/DeleteAccount":         ratelimit.GroupUsers,
+	"/gousergrpc.Account/ChangeUsername":        ratelimit.GroupUsers,
+	"/gousergrpc.Export/ExportMyData":           ratelimit.GroupUsers,
+	"/gousergrpc.Session/GetMySessions":         ratelimit.GroupSessions,
+	"/gousergrpc.Session/RevokeMySession":       ratelimit.GroupSessions,
+	"/gousergrpc.Session/GetSessionsByUserID":   ratelimit.GroupAdmin,
+	"/gousergrpc.Session/RevokeSessionByID":     ratelimit.GroupAdmin,
+	"/gousergrpc.Account/UpdateUserStatus":      ratelimit.GroupAdmin,
+	"/gousergrpc.Export/ExportUserData":         ratelimit.GroupAdmin,
+}
+
+// userJWTRequest is request carrying user JWT.
+type userJWTRequest interface {
+	GetUserJwt() string
+}
+
+// rateLimitUnaryInterceptor take a token from bucket of the client in group
+// of the method, client is identified by x-api-key metadata, request user JWT
+// or ip by group rule. Rate limited request gets ResourceExhausted with
+// retry-after header metadata. Request is let through if limiter store fails.
+func rateLimitUnaryInterceptor(limiter *ratelimit.Limiter) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		client := ratelimit.Client{
+			IP:     getClientIP(ctx),
+			APIKey: getIncomingMetadata(ctx, apiKeyMetadataKey),
+		}
+		if r, ok := req.(userJWTRequest); ok {
+			client.UserJWT = r.GetUserJwt()
+		}
+
+		md, err := allow(ctx, limiter, info.FullMethod, client)
+
+		// Error is ignored, header can only fail to be set when already sent.
+		_ = grpc.SetHeader(ctx, md)
+
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// rateLimitStreamInterceptor is like rateLimitUnaryInterceptor for stream.
+// Stream request is not read yet, so client is identified by api key or ip.
+func rateLimitStreamInterceptor(limiter *ratelimit.Limiter) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx := ss.Context()
+		client := ratelimit.Client{
+			IP:     getClientIP(ctx),
+			APIKey: getIncomingMetadata(ctx, apiKeyMetadataKey),
+		}
+
+		md, err := allow(ctx, limiter, info.FullMethod, client)
+
+		_ = ss.SetHeader(md)
+
+		if err != nil {
+			return err
+		}
+
+		return handler(srv, ss)
+	}
+}
+
+// allow take a token of client for method, return rate limit header metadata
+// and ResourceExhausted error if client is rate limited.
+func allow(ctx context.Context, limiter *ratelimit.Limiter, method string, client ratelimit.Client) (metadata.MD, error) {
+	group, ok := rateLimitGroups[method]
+	if !ok {
+		return metadata.MD{}, nil
+	}
+
+	res, err := limiter.Allow(ctx, group, client)
+	if err != nil {
+		logger.FromContext(ctx).WithError(err).Warn("rate limiter failed, request is let through")
+		return metadata.MD{}, nil
+	}
+
+	md := metadata.MD{}
+	if res.Limit > 0 {
+		md.Set(rateLimitLimitMetadataKey, strconv.Itoa(res.Limit))
+		md.Set(rateLimitRemainingMetadataKey, strconv.Itoa(res.Remaining))
+		md.Set(rateLimitResetMetadataKey, strconv.Itoa(res.ResetSecond()))
+	}
+
+	if !res.Allowed {
+		metrics.RateLimitedTotal.WithLabelValues(group).Inc()
+		md.Set(retryAfterMetadataKey, strconv.Itoa(res.RetryAfterSecond()))
+		return md, status.Error(codes.ResourceExhausted, gouser.ErrTooManyRequests.Error())
+	}
+
+	return md, nil
+}
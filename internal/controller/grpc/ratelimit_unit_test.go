@@ -0,0 +1,72 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Hidayathamir/go-user/config"
+	"github.com/Hidayathamir/go-user/internal/pkg/ratelimit"
+	"github.com/Hidayathamir/go-user/internal/repo/db"
+	"github.com/Hidayathamir/go-user/pkg/gousergrpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+func TestUnitRateLimitUnaryInterceptor(t *testing.T) {
+	t.Parallel()
+
+	cfg := config.Config{RateLimit: config.RateLimit{
+		Enabled: true,
+		Auth:    config.RateLimitRule{RequestsPerSecond: 0.1, Burst: 1, Key: config.RateLimitKeyIP},
+	}}
+	handler := func(context.Context, any) (any, error) { return "ok", nil }
+
+	t.Run("request over burst should get ResourceExhausted", func(t *testing.T) {
+		t.Parallel()
+
+		interceptor := rateLimitUnaryInterceptor(ratelimit.NewLimiter(cfg, ratelimit.NewMemoryStore()))
+		info := &grpc.UnaryServerInfo{FullMethod: "/gousergrpc.Auth/LoginUser"}
+
+		res, err := interceptor(context.Background(), &gousergrpc.ReqLoginUser{}, info, handler)
+		require.NoError(t, err)
+		assert.Equal(t, "ok", res)
+
+		_, err = interceptor(context.Background(), &gousergrpc.ReqLoginUser{}, info, handler)
+		assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	})
+	t.Run("method without group should not be limited", func(t *testing.T) {
+		t.Parallel()
+
+		interceptor := rateLimitUnaryInterceptor(ratelimit.NewLimiter(cfg, ratelimit.NewMemoryStore()))
+		info := &grpc.UnaryServerInfo{FullMethod: "/gousergrpc.Ping/Ping"}
+
+		for range 3 {
+			_, err := interceptor(context.Background(), nil, info, handler)
+			require.NoError(t, err)
+		}
+	})
+}
+
+func TestUnitRateLimitGroups(t *testing.T) {
+	t.Parallel()
+
+	t.Run("every method except ping and health should have group", func(t *testing.T) {
+		t.Parallel()
+
+		server, err := NewServer(config.Config{}, &db.Postgres{})
+		require.NoError(t, err)
+
+		for service, info := range server.GetServiceInfo() {
+			if service == healthpb.Health_ServiceDesc.ServiceName || service == gousergrpc.Ping_ServiceDesc.ServiceName {
+				continue
+			}
+			for _, method := range info.Methods {
+				assert.Contains(t, rateLimitGroups, "/"+service+"/"+method.Name)
+			}
+		}
+	})
+}
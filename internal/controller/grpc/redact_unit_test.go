@@ -0,0 +1,162 @@
+package grpc
+
+import (
+	"context"
+	"io"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Hidayathamir/go-user/config"
+	"github.com/Hidayathamir/go-user/internal/pkg/auth"
+	"github.com/Hidayathamir/go-user/internal/pkg/logger"
+	"github.com/Hidayathamir/go-user/internal/repo/db"
+	"github.com/Masterminds/squirrel"
+	"github.com/pashagolub/pgxmock/v3"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// formattedLogHook capture every log entry formatted by text and json
+// formatter, as it would be written.
+type formattedLogHook struct {
+	mu    sync.Mutex
+	lines []string
+}
+
+func (h *formattedLogHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (h *formattedLogHook) Fire(entry *logrus.Entry) error {
+	text, err := (&logrus.TextFormatter{}).Format(entry)
+	if err != nil {
+		return err
+	}
+	jsonLine, err := (&logrus.JSONFormatter{}).Format(entry)
+	if err != nil {
+		return err
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.lines = append(h.lines, string(text), string(jsonLine))
+
+	return nil
+}
+
+func (h *formattedLogHook) String() string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return strings.Join(h.lines, "")
+}
+
+func TestUnitNoSecretInLogs(t *testing.T) {
+	t.Parallel()
+
+	logHook := &formattedLogHook{}
+	log := logrus.New()
+	log.SetOutput(io.Discard)
+	log.AddHook(logger.NewRedactHook())
+	log.AddHook(logHook)
+
+	mockpool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	pg := &db.Postgres{Builder: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar), Pool: mockpool}
+
+	cfg := config.Config{JWT: config.JWT{ExpireHour: 1, SignedKey: "secretjwtkey"}}
+	server, err := newServer(cfg, pg, log)
+	require.NoError(t, err)
+
+	listener := bufconn.Listen(1024 * 1024)
+	go func() { _ = server.Serve(listener) }()
+	defer server.Stop()
+
+	conn, err := grpc.DialContext(context.Background(), "bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	defer func() { _ = conn.Close() }()
+
+	const password = "pl41nt3xt-p4ssw0rd"
+	userJWT := auth.GenerateUserJWTToken(99, "jti-redact-test", cfg)
+
+	newMessage := func(t *testing.T, name protoreflect.FullName) protoreflect.Message {
+		t.Helper()
+		messageType, err := protoregistry.GlobalTypes.FindMessageByName(name)
+		require.NoError(t, err)
+		return messageType.New()
+	}
+
+	// newRequest return request message of method with every secret field
+	// set.
+	newRequest := func(t *testing.T, method protoreflect.MethodDescriptor) proto.Message {
+		t.Helper()
+		req := newMessage(t, method.Input().FullName())
+		fields := req.Descriptor().Fields()
+		for i := 0; i < fields.Len(); i++ {
+			switch fields.Get(i).Name() {
+			case "password", "new_password", "secret":
+				req.Set(fields.Get(i), protoreflect.ValueOfString(password))
+			case "user_jwt":
+				req.Set(fields.Get(i), protoreflect.ValueOfString(userJWT))
+			}
+		}
+		return req.Interface()
+	}
+
+	invoked := 0
+	for serviceName, serviceInfo := range server.GetServiceInfo() {
+		if serviceName == healthpb.Health_ServiceDesc.ServiceName {
+			continue
+		}
+
+		descriptor, err := protoregistry.GlobalFiles.FindDescriptorByName(protoreflect.FullName(serviceName))
+		require.NoError(t, err)
+		serviceDescriptor, ok := descriptor.(protoreflect.ServiceDescriptor)
+		require.True(t, ok)
+
+		for _, methodInfo := range serviceInfo.Methods {
+			method := serviceDescriptor.Methods().ByName(protoreflect.Name(methodInfo.Name))
+			require.NotNil(t, method, methodInfo.Name)
+			fullMethod := "/" + serviceName + "/" + methodInfo.Name
+			req := newRequest(t, method)
+
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			ctx = metadata.AppendToOutgoingContext(ctx, "authorization", userJWT)
+
+			if methodInfo.IsServerStream {
+				stream, err := conn.NewStream(ctx, &grpc.StreamDesc{ServerStreams: true}, fullMethod)
+				require.NoError(t, err)
+				require.NoError(t, stream.SendMsg(req))
+				require.NoError(t, stream.CloseSend())
+				res := newMessage(t, method.Output().FullName()).Interface()
+				for stream.RecvMsg(res) == nil {
+				}
+			} else {
+				_ = conn.Invoke(ctx, fullMethod, req, newMessage(t, method.Output().FullName()).Interface())
+			}
+
+			cancel()
+			invoked++
+		}
+	}
+	require.Positive(t, invoked)
+
+	logs := logHook.String()
+	assert.NotEmpty(t, logs)
+	assert.NotContains(t, logs, password)
+	assert.NotContains(t, logs, userJWT)
+}
@@ -14,7 +14,13 @@ func registerServer(cfg config.Config, grpcServer *grpc.Server, db *db.Postgres)
 
 	cAuth := injectionAuth(cfg, db)
 	cProfile := injectionProfile(cfg, db)
+	cSession := injectionSession(cfg, db)
+	cAccount := injectionAccount(cfg, db)
+	cExport := injectionExport(cfg, db)
 
 	gousergrpc.RegisterAuthServer(grpcServer, cAuth)
 	gousergrpc.RegisterProfileServer(grpcServer, cProfile)
+	gousergrpc.RegisterSessionServer(grpcServer, cSession)
+	gousergrpc.RegisterAccountServer(grpcServer, cAccount)
+	gousergrpc.RegisterExportServer(grpcServer, cExport)
 }
@@ -1,33 +1,153 @@
 package grpc
 
 import (
+	"context"
 	"fmt"
 	"net"
 	"strconv"
 
 	"github.com/Hidayathamir/go-user/config"
+	"github.com/Hidayathamir/go-user/internal/pkg/ratelimit"
+	"github.com/Hidayathamir/go-user/internal/pkg/tlsconfig"
 	"github.com/Hidayathamir/go-user/internal/repo/db"
 	"github.com/sirupsen/logrus"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 )
 
-// RunServer run grpc server.
-func RunServer(cfg config.Config, db *db.Postgres) error {
-	grpcServer := grpc.NewServer()
+// Server is grpc server with all services registered, including standard
+// grpc.health.v1.Health. Its GracefulStop ends running streams first, so long
+// lived stream like Profile.WatchUsers does not block it.
+type Server struct {
+	*grpc.Server
 
-	registerServer(cfg, grpcServer, db)
+	// Addr is address the server should listen on.
+	Addr string
+
+	healthServer  *grpchealth.Server
+	stopStreamCtx context.Context
+	stopStream    context.CancelFunc
+}
+
+// NewServer return *Server. Caller runs it with Serve and stops it with
+// GracefulStop or Stop. It serves TLS if cfg.GRPC.TLS is enabled, see
+// getClientCertIdentity for client certificate. Methods are rate limited per
+// route group by cfg.RateLimit, with buckets kept in memory.
+func NewServer(cfg config.Config, db *db.Postgres) (*Server, error) {
+	return newServer(cfg, db, logrus.StandardLogger())
+}
 
-	addr := net.JoinHostPort(cfg.GRPC.Host, strconv.Itoa(cfg.GRPC.Port))
-	lis, err := net.Listen("tcp", addr)
+// newServer is like NewServer, request logs are written by log.
+func newServer(cfg config.Config, db *db.Postgres, log *logrus.Logger) (*Server, error) {
+	tlsConfig, err := tlsconfig.NewServerConfig(cfg.GRPC.TLS)
 	if err != nil {
-		return fmt.Errorf("net.Listen: %w", err)
+		return nil, fmt.Errorf("tlsconfig.NewServerConfig: %w", err)
 	}
 
-	logrus.WithField("address", addr).Info("run grpc server")
-	err = grpcServer.Serve(lis)
-	if err != nil {
-		return fmt.Errorf("grpc.Server.Serve: %w", err)
+	stopStreamCtx, stopStream := context.WithCancel(context.Background())
+
+	limiter := ratelimit.NewLimiter(cfg, ratelimit.NewMemoryStore())
+
+	opts := []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(
+			tracingUnaryInterceptor,
+			requestIDUnaryInterceptor,
+			accessLogUnaryInterceptor(log),
+			metricsUnaryInterceptor,
+			rateLimitUnaryInterceptor(limiter),
+		),
+		grpc.ChainStreamInterceptor(
+			tracingStreamInterceptor,
+			requestIDStreamInterceptor,
+			accessLogStreamInterceptor(log),
+			metricsStreamInterceptor,
+			rateLimitStreamInterceptor(limiter),
+			stopStreamInterceptor(stopStreamCtx),
+		),
+	}
+	if tlsConfig != nil {
+		opts = append(opts, grpc.Creds(credentials.NewTLS(tlsConfig)))
+	}
+
+	grpcServer := grpc.NewServer(opts...)
+
+	registerServer(cfg, grpcServer, db)
+
+	healthServer := grpchealth.NewServer()
+	healthpb.RegisterHealthServer(grpcServer, healthServer)
+
+	s := &Server{
+		Server:        grpcServer,
+		Addr:          net.JoinHostPort(cfg.GRPC.Host, strconv.Itoa(cfg.GRPC.Port)),
+		healthServer:  healthServer,
+		stopStreamCtx: stopStreamCtx,
+		stopStream:    stopStream,
+	}
+
+	s.SetServing(false)
+
+	return s, nil
+}
+
+// SetServing set health status of the server (empty service name) and of
+// every registered service to SERVING or NOT_SERVING.
+func (s *Server) SetServing(serving bool) {
+	status := healthpb.HealthCheckResponse_NOT_SERVING
+	if serving {
+		status = healthpb.HealthCheckResponse_SERVING
+	}
+
+	s.healthServer.SetServingStatus("", status)
+	for service := range s.Server.GetServiceInfo() {
+		if service == healthpb.Health_ServiceDesc.ServiceName {
+			continue
+		}
+		s.healthServer.SetServingStatus(service, status)
+	}
+}
+
+// StopStreams set every health status to NOT_SERVING for good and cancel
+// context of running and new streams, so long lived stream like
+// Profile.WatchUsers returns instead of blocking GracefulStop.
+func (s *Server) StopStreams() {
+	s.healthServer.Shutdown()
+	s.stopStream()
+}
+
+// GracefulStop call StopStreams, stop accepting new connections and RPCs, then
+// block until all pending RPCs are finished.
+func (s *Server) GracefulStop() {
+	s.StopStreams()
+	s.Server.GracefulStop()
+}
+
+// Stop call StopStreams and stop the server immediately.
+func (s *Server) Stop() {
+	s.StopStreams()
+	s.Server.Stop()
+}
+
+// stopStreamInterceptor cancel stream context once stopStreamCtx is done.
+func stopStreamInterceptor(stopStreamCtx context.Context) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, cancel := context.WithCancel(ss.Context())
+		defer cancel()
+
+		stop := context.AfterFunc(stopStreamCtx, cancel)
+		defer stop()
+
+		return handler(srv, &serverStream{ServerStream: ss, ctx: ctx})
 	}
+}
+
+// serverStream is grpc.ServerStream with overridden context.
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
 
-	return nil
+func (s *serverStream) Context() context.Context {
+	return s.ctx
 }
@@ -0,0 +1,95 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Hidayathamir/go-user/config"
+	"github.com/Hidayathamir/go-user/internal/repo/db"
+	"github.com/Hidayathamir/go-user/pkg/gousergrpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+func TestUnitServerSetServing(t *testing.T) {
+	t.Parallel()
+
+	checkStatus := func(t *testing.T, s *Server, service string) healthpb.HealthCheckResponse_ServingStatus {
+		t.Helper()
+		res, err := s.healthServer.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+		require.NoError(t, err)
+		return res.GetStatus()
+	}
+
+	t.Run("new server should not serving until set serving", func(t *testing.T) {
+		t.Parallel()
+
+		s, err := NewServer(config.Config{}, &db.Postgres{})
+		require.NoError(t, err)
+
+		assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, checkStatus(t, s, ""))
+		assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, checkStatus(t, s, gousergrpc.Profile_ServiceDesc.ServiceName))
+
+		s.SetServing(true)
+
+		assert.Equal(t, healthpb.HealthCheckResponse_SERVING, checkStatus(t, s, ""))
+		assert.Equal(t, healthpb.HealthCheckResponse_SERVING, checkStatus(t, s, gousergrpc.Profile_ServiceDesc.ServiceName))
+		assert.Equal(t, healthpb.HealthCheckResponse_SERVING, checkStatus(t, s, gousergrpc.Auth_ServiceDesc.ServiceName))
+	})
+	t.Run("stopped server should stay not serving", func(t *testing.T) {
+		t.Parallel()
+
+		s, err := NewServer(config.Config{}, &db.Postgres{})
+		require.NoError(t, err)
+		s.SetServing(true)
+
+		s.GracefulStop()
+		s.SetServing(true)
+
+		assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, checkStatus(t, s, ""))
+		assert.Equal(t, healthpb.HealthCheckResponse_NOT_SERVING, checkStatus(t, s, gousergrpc.Profile_ServiceDesc.ServiceName))
+	})
+}
+
+func TestUnitStopStreamInterceptor(t *testing.T) {
+	t.Parallel()
+
+	t.Run("stop stream should cancel running stream context", func(t *testing.T) {
+		t.Parallel()
+
+		stopStreamCtx, stopStream := context.WithCancel(context.Background())
+		interceptor := stopStreamInterceptor(stopStreamCtx)
+
+		handlerStarted := make(chan struct{})
+		errCh := make(chan error, 1)
+		go func() {
+			errCh <- interceptor(nil, &mockWatchUsersServer{}, &grpc.StreamServerInfo{}, func(_ any, stream grpc.ServerStream) error {
+				close(handlerStarted)
+				<-stream.Context().Done()
+				return stream.Context().Err()
+			})
+		}()
+
+		<-handlerStarted
+		stopStream()
+
+		err := <-errCh
+		require.Error(t, err)
+		require.ErrorIs(t, err, context.Canceled)
+	})
+	t.Run("stream not stopped should keep stream context", func(t *testing.T) {
+		t.Parallel()
+
+		stopStreamCtx, stopStream := context.WithCancel(context.Background())
+		defer stopStream()
+		interceptor := stopStreamInterceptor(stopStreamCtx)
+
+		err := interceptor(nil, &mockWatchUsersServer{}, &grpc.StreamServerInfo{}, func(_ any, stream grpc.ServerStream) error {
+			return stream.Context().Err()
+		})
+
+		assert.NoError(t, err)
+	})
+}
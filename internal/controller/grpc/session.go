@@ -0,0 +1,122 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Hidayathamir/go-user/config"
+	"github.com/Hidayathamir/go-user/internal/usecase"
+	"github.com/Hidayathamir/go-user/pkg/gouser"
+	"github.com/Hidayathamir/go-user/pkg/gousergrpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Session is controller GRPC for session related.
+type Session struct {
+	gousergrpc.UnimplementedSessionServer
+
+	cfg            config.Config
+	usecaseSession usecase.ISession
+}
+
+var _ gousergrpc.SessionServer = &Session{}
+
+func newSession(cfg config.Config, usecaseSession usecase.ISession) *Session {
+	return &Session{
+		cfg:            cfg,
+		usecaseSession: usecaseSession,
+	}
+}
+
+// GetMySessions implements gousergrpc.SessionServer.
+func (s *Session) GetMySessions(c context.Context, r *gousergrpc.ReqGetMySessions) (*gousergrpc.ResGetSessions, error) {
+	req := gouser.ReqGetMySessions{UserJWT: r.GetUserJwt()}
+
+	resGetSessions, err := s.usecaseSession.GetMySessions(c, req)
+	if err != nil {
+		err := fmt.Errorf("Session.usecaseSession.GetMySessions: %w", err)
+		return nil, err
+	}
+
+	res := toGRPCResGetSessions(resGetSessions)
+
+	return res, nil
+}
+
+// RevokeMySession implements gousergrpc.SessionServer.
+func (s *Session) RevokeMySession(c context.Context, r *gousergrpc.ReqRevokeMySession) (*gousergrpc.SessionEmpty, error) {
+	req := gouser.ReqRevokeMySession{
+		UserJWT:   r.GetUserJwt(),
+		SessionID: r.GetSessionId(),
+		UserAgent: getClientUserAgent(c),
+		IP:        getClientIP(c),
+		RequestID: gouser.RequestIDFromContext(c),
+	}
+
+	err := s.usecaseSession.RevokeMySession(c, req)
+	if err != nil {
+		err := fmt.Errorf("Session.usecaseSession.RevokeMySession: %w", err)
+		return nil, err
+	}
+
+	res := &gousergrpc.SessionEmpty{}
+
+	return res, nil
+}
+
+// GetSessionsByUserID implements gousergrpc.SessionServer.
+func (s *Session) GetSessionsByUserID(c context.Context, r *gousergrpc.ReqGetSessionsByUserID) (*gousergrpc.ResGetSessions, error) {
+	req := gouser.ReqGetSessionsByUserID{
+		UserJWT: r.GetUserJwt(),
+		UserID:  r.GetUserId(),
+	}
+
+	resGetSessions, err := s.usecaseSession.GetSessionsByUserID(c, req)
+	if err != nil {
+		err := fmt.Errorf("Session.usecaseSession.GetSessionsByUserID: %w", err)
+		return nil, err
+	}
+
+	res := toGRPCResGetSessions(resGetSessions)
+
+	return res, nil
+}
+
+// RevokeSessionByID implements gousergrpc.SessionServer.
+func (s *Session) RevokeSessionByID(c context.Context, r *gousergrpc.ReqRevokeSessionByID) (*gousergrpc.SessionEmpty, error) {
+	req := gouser.ReqRevokeSessionByID{
+		UserJWT:   r.GetUserJwt(),
+		SessionID: r.GetSessionId(),
+		UserAgent: getClientUserAgent(c),
+		IP:        getClientIP(c),
+		RequestID: gouser.RequestIDFromContext(c),
+	}
+
+	err := s.usecaseSession.RevokeSessionByID(c, req)
+	if err != nil {
+		err := fmt.Errorf("Session.usecaseSession.RevokeSessionByID: %w", err)
+		return nil, err
+	}
+
+	res := &gousergrpc.SessionEmpty{}
+
+	return res, nil
+}
+
+func toGRPCResGetSessions(resGetSessions gouser.ResGetSessions) *gousergrpc.ResGetSessions {
+	res := &gousergrpc.ResGetSessions{
+		Sessions: make([]*gousergrpc.SessionItem, 0, len(resGetSessions.Sessions)),
+	}
+	for _, session := range resGetSessions.Sessions {
+		res.Sessions = append(res.Sessions, &gousergrpc.SessionItem{
+			Id:         session.ID,
+			UserAgent:  session.UserAgent,
+			Ip:         session.IP,
+			CreatedAt:  timestamppb.New(session.CreatedAt),
+			LastSeenAt: timestamppb.New(session.LastSeenAt),
+			ExpiredAt:  timestamppb.New(session.ExpiredAt),
+			IsCurrent:  session.IsCurrent,
+		})
+	}
+	return res
+}
@@ -0,0 +1,128 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Hidayathamir/go-user/config"
+	"github.com/Hidayathamir/go-user/internal/usecase/mockusecase"
+	"github.com/Hidayathamir/go-user/pkg/gouser"
+	"github.com/Hidayathamir/go-user/pkg/gousergrpc"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUnitSessionGetMySessions(t *testing.T) {
+	t.Parallel()
+
+	t.Run("call usecase GetMySessions success should return success", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		usecaseSession := mockusecase.NewMockISession(ctrl)
+
+		s := &Session{
+			cfg:            config.Config{},
+			usecaseSession: usecaseSession,
+		}
+
+		now := time.Now()
+		usecaseSession.EXPECT().
+			GetMySessions(gomock.Any(), gouser.ReqGetMySessions{UserJWT: "Bearer dummyUserJWT"}).
+			Return(gouser.ResGetSessions{Sessions: []gouser.Session{
+				{ID: 3, UserAgent: "grpc-go/1.58.3", IP: "10.0.0.1", CreatedAt: now, IsCurrent: true},
+			}}, nil)
+
+		res, err := s.GetMySessions(context.Background(), &gousergrpc.ReqGetMySessions{UserJwt: "Bearer dummyUserJWT"})
+
+		require.NoError(t, err)
+		require.Len(t, res.GetSessions(), 1)
+		assert.Equal(t, int64(3), res.GetSessions()[0].GetId())
+		assert.Equal(t, "10.0.0.1", res.GetSessions()[0].GetIp())
+		assert.True(t, res.GetSessions()[0].GetIsCurrent())
+		assert.True(t, now.Equal(res.GetSessions()[0].GetCreatedAt().AsTime()))
+	})
+	t.Run("call usecase GetMySessions error should return error", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		usecaseSession := mockusecase.NewMockISession(ctrl)
+
+		s := &Session{
+			cfg:            config.Config{},
+			usecaseSession: usecaseSession,
+		}
+
+		usecaseSession.EXPECT().
+			GetMySessions(gomock.Any(), gouser.ReqGetMySessions{UserJWT: "Bearer dummyUserJWT"}).
+			Return(gouser.ResGetSessions{}, assert.AnError)
+
+		res, err := s.GetMySessions(context.Background(), &gousergrpc.ReqGetMySessions{UserJwt: "Bearer dummyUserJWT"})
+
+		assert.Nil(t, res)
+		require.Error(t, err)
+		require.ErrorIs(t, err, assert.AnError)
+	})
+}
+
+func TestUnitSessionRevokeSessionByID(t *testing.T) {
+	t.Parallel()
+
+	t.Run("call usecase RevokeSessionByID success should return success", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		usecaseSession := mockusecase.NewMockISession(ctrl)
+
+		s := &Session{
+			cfg:            config.Config{},
+			usecaseSession: usecaseSession,
+		}
+
+		usecaseSession.EXPECT().
+			RevokeSessionByID(gomock.Any(), gouser.ReqRevokeSessionByID{UserJWT: "Bearer adminJWT", SessionID: 9}).
+			Return(nil)
+
+		res, err := s.RevokeSessionByID(context.Background(), &gousergrpc.ReqRevokeSessionByID{
+			UserJwt:   "Bearer adminJWT",
+			SessionId: 9,
+		})
+
+		assert.NotNil(t, res)
+		require.NoError(t, err)
+	})
+	t.Run("call usecase RevokeSessionByID error should return error", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		usecaseSession := mockusecase.NewMockISession(ctrl)
+
+		s := &Session{
+			cfg:            config.Config{},
+			usecaseSession: usecaseSession,
+		}
+
+		usecaseSession.EXPECT().
+			RevokeSessionByID(gomock.Any(), gouser.ReqRevokeSessionByID{UserJWT: "Bearer adminJWT", SessionID: 9}).
+			Return(gouser.ErrForbidden)
+
+		res, err := s.RevokeSessionByID(context.Background(), &gousergrpc.ReqRevokeSessionByID{
+			UserJwt:   "Bearer adminJWT",
+			SessionId: 9,
+		})
+
+		assert.Nil(t, res)
+		require.Error(t, err)
+		require.ErrorIs(t, err, gouser.ErrForbidden)
+	})
+}
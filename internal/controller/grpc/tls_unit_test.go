@@ -0,0 +1,107 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/Hidayathamir/go-user/config"
+	"github.com/Hidayathamir/go-user/internal/pkg/logger"
+	"github.com/Hidayathamir/go-user/internal/pkg/tlsconfig/tlsconfigtest"
+	"github.com/Hidayathamir/go-user/internal/repo/db"
+	"github.com/Hidayathamir/go-user/pkg/gouser"
+	"github.com/Hidayathamir/go-user/pkg/gousergrpc"
+	logrustest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func TestUnitNewServerTLS(t *testing.T) {
+	t.Parallel()
+
+	log, hook := logrustest.NewNullLogger()
+
+	ca := tlsconfigtest.NewCA(t, "test-ca")
+	dir := t.TempDir()
+	certFile, keyFile := ca.IssueFiles(t, dir, "server")
+	caFile := tlsconfigtest.WriteFile(t, dir, "ca.crt", ca.CertPEM())
+	clientCertFile, clientKeyFile := ca.IssueFiles(t, dir, "client-1")
+
+	cfg := config.Config{GRPC: config.GRPC{TLS: config.TLS{
+		CertFile:          certFile,
+		KeyFile:           keyFile,
+		ClientCAFile:      caFile,
+		RequireClientCert: true,
+	}}}
+	server, err := newServer(cfg, &db.Postgres{}, log)
+	require.NoError(t, err)
+
+	listener := bufconn.Listen(1024 * 1024)
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+
+	// ping call Ping over TLS of clientTLS, return error of the call.
+	ping := func(t *testing.T, clientTLS gouser.ClientTLS, requestID string) error {
+		t.Helper()
+
+		tlsConfig, err := gouser.NewClientTLSConfig(clientTLS)
+		require.NoError(t, err)
+
+		conn, err := grpc.DialContext(context.Background(), "bufconn",
+			grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+			gousergrpc.WithTLS(tlsConfig),
+		)
+		require.NoError(t, err)
+		defer func() { _ = conn.Close() }()
+
+		ctx := metadata.AppendToOutgoingContext(context.Background(), requestIDMetadataKey, requestID)
+		_, err = gousergrpc.NewPingClient(conn).Ping(ctx, &gousergrpc.PingEmpty{})
+		return err
+	}
+
+	t.Run("client with certificate should be served and identified", func(t *testing.T) {
+		t.Parallel()
+
+		err := ping(t, gouser.ClientTLS{CAFile: caFile, CertFile: clientCertFile, KeyFile: clientKeyFile, ServerName: "localhost"}, "req-tls-client-cert")
+		require.NoError(t, err)
+
+		var clientCertCN any
+		for _, entry := range hook.AllEntries() {
+			if entry.Data[logger.FieldRequestID] == "req-tls-client-cert" && entry.Message == "grpc request" {
+				clientCertCN = entry.Data["client_cert_cn"]
+			}
+		}
+		assert.Equal(t, "client-1", clientCertCN)
+	})
+	t.Run("client without certificate should be refused", func(t *testing.T) {
+		t.Parallel()
+
+		err := ping(t, gouser.ClientTLS{CAFile: caFile, ServerName: "localhost"}, "req-tls-no-client-cert")
+
+		require.Error(t, err)
+	})
+	t.Run("client not trusting server ca should be refused", func(t *testing.T) {
+		t.Parallel()
+
+		otherCAFile := tlsconfigtest.WriteFile(t, t.TempDir(), "other-ca.crt", tlsconfigtest.NewCA(t, "other-ca").CertPEM())
+
+		err := ping(t, gouser.ClientTLS{CAFile: otherCAFile, CertFile: clientCertFile, KeyFile: clientKeyFile, ServerName: "localhost"}, "req-tls-untrusted")
+
+		require.Error(t, err)
+	})
+}
+
+func TestUnitGetClientCertIdentity(t *testing.T) {
+	t.Parallel()
+
+	t.Run("context without tls peer should return false", func(t *testing.T) {
+		t.Parallel()
+
+		_, ok := getClientCertIdentity(context.Background())
+
+		assert.False(t, ok)
+	})
+}
@@ -0,0 +1,77 @@
+package grpc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Hidayathamir/go-user/internal/pkg/tracing/tracingtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestUnitTracingUnaryInterceptor(t *testing.T) {
+	t.Parallel()
+
+	tracingtest.Setup()
+
+	t.Run("request should continue trace context of traceparent metadata", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(
+			"traceparent", "00-22222222222222222222222222222222-00f067aa0ba902b7-01",
+		))
+		info := &grpc.UnaryServerInfo{FullMethod: "/test.Tracing/Unary"}
+		var handlerSpanContext trace.SpanContext
+		handler := func(ctx context.Context, _ any) (any, error) {
+			handlerSpanContext = trace.SpanContextFromContext(ctx)
+			return nil, status.Error(codes.NotFound, "not found")
+		}
+
+		_, err := tracingUnaryInterceptor(ctx, nil, info, handler)
+
+		require.Error(t, err)
+		traceID := handlerSpanContext.TraceID()
+		assert.Equal(t, "22222222222222222222222222222222", traceID.String())
+
+		span, ok := tracingtest.SpanByName(traceID, "test.Tracing/Unary")
+		require.True(t, ok)
+		assert.Equal(t, trace.SpanKindServer, span.SpanKind)
+		assert.Equal(t, "00f067aa0ba902b7", span.Parent.SpanID().String())
+		assert.Contains(t, span.Attributes, attribute.String("rpc.service", "test.Tracing"))
+		assert.Contains(t, span.Attributes, attribute.String("rpc.method", "Unary"))
+		assert.Contains(t, span.Attributes, attribute.Int("rpc.grpc.status_code", int(codes.NotFound)))
+		assert.Equal(t, otelcodes.Error, span.Status.Code)
+	})
+}
+
+func TestUnitTracingStreamInterceptor(t *testing.T) {
+	t.Parallel()
+
+	tracingtest.Setup()
+
+	t.Run("stream context should carry stream span", func(t *testing.T) {
+		t.Parallel()
+
+		info := &grpc.StreamServerInfo{FullMethod: "/test.Tracing/Stream"}
+		var handlerSpanContext trace.SpanContext
+		handler := func(_ any, stream grpc.ServerStream) error {
+			handlerSpanContext = trace.SpanContextFromContext(stream.Context())
+			return nil
+		}
+
+		err := tracingStreamInterceptor(nil, &mockWatchUsersServer{}, info, handler)
+
+		require.NoError(t, err)
+		span, ok := tracingtest.SpanByName(handlerSpanContext.TraceID(), "test.Tracing/Stream")
+		require.True(t, ok)
+		assert.Equal(t, handlerSpanContext.SpanID(), span.SpanContext.SpanID())
+		assert.Equal(t, otelcodes.Unset, span.Status.Code)
+	})
+}
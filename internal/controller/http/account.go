@@ -0,0 +1,129 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/Hidayathamir/go-user/config"
+	"github.com/Hidayathamir/go-user/internal/pkg/header"
+	"github.com/Hidayathamir/go-user/internal/usecase"
+	"github.com/Hidayathamir/go-user/pkg/gouser"
+	"github.com/gin-gonic/gin"
+)
+
+// Account is controller HTTP for account lifecycle related.
+type Account struct {
+	cfg            config.Config
+	usecaseAccount usecase.IAccount
+}
+
+func newAccount(cfg config.Config, usecaseAccount usecase.IAccount) *Account {
+	return &Account{
+		cfg:            cfg,
+		usecaseAccount: usecaseAccount,
+	}
+}
+
+func (a *Account) deleteAccount(c *gin.Context) {
+	req := gouser.ReqDeleteAccount{}
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		err := fmt.Errorf("gin.Context.ShouldBindJSON: %w", err)
+		c.JSON(http.StatusBadRequest, newResError(c, err))
+		return
+	}
+
+	req.UserJWT = c.GetHeader(header.Authorization)
+	req.UserAgent = c.Request.UserAgent()
+	req.IP = c.ClientIP()
+	req.RequestID = gouser.RequestIDFromContext(c.Request.Context())
+
+	err = a.usecaseAccount.DeleteAccount(c, req)
+	if err != nil {
+		err := fmt.Errorf("Account.usecaseAccount.DeleteAccount: %w", err)
+		c.JSON(http.StatusBadRequest, newResError(c, err))
+		return
+	}
+
+	c.JSON(http.StatusOK, ResString{Data: "ok"})
+}
+
+func (a *Account) restoreAccount(c *gin.Context) {
+	req := gouser.ReqRestoreAccount{}
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		err := fmt.Errorf("gin.Context.ShouldBindJSON: %w", err)
+		c.JSON(http.StatusBadRequest, newResError(c, err))
+		return
+	}
+
+	req.UserAgent = c.Request.UserAgent()
+	req.IP = c.ClientIP()
+	req.RequestID = gouser.RequestIDFromContext(c.Request.Context())
+
+	err = a.usecaseAccount.RestoreAccount(c, req)
+	if err != nil {
+		err := fmt.Errorf("Account.usecaseAccount.RestoreAccount: %w", err)
+		c.JSON(http.StatusBadRequest, newResError(c, err))
+		return
+	}
+
+	c.JSON(http.StatusOK, ResString{Data: "ok"})
+}
+
+func (a *Account) updateUserStatus(c *gin.Context) {
+	userID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		err := fmt.Errorf("strconv.ParseInt: %w", err)
+		c.JSON(http.StatusBadRequest, newResError(c, err))
+		return
+	}
+
+	req := gouser.ReqUpdateUserStatus{}
+	err = c.ShouldBindJSON(&req)
+	if err != nil {
+		err := fmt.Errorf("gin.Context.ShouldBindJSON: %w", err)
+		c.JSON(http.StatusBadRequest, newResError(c, err))
+		return
+	}
+
+	req.UserJWT = c.GetHeader(header.Authorization)
+	req.UserID = userID
+	req.UserAgent = c.Request.UserAgent()
+	req.IP = c.ClientIP()
+	req.RequestID = gouser.RequestIDFromContext(c.Request.Context())
+
+	err = a.usecaseAccount.UpdateUserStatus(c, req)
+	if err != nil {
+		err := fmt.Errorf("Account.usecaseAccount.UpdateUserStatus: %w", err)
+		c.JSON(http.StatusBadRequest, newResError(c, err))
+		return
+	}
+
+	c.JSON(http.StatusOK, ResString{Data: "ok"})
+}
+
+func (a *Account) changeUsername(c *gin.Context) {
+	req := gouser.ReqChangeUsername{}
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		err := fmt.Errorf("gin.Context.ShouldBindJSON: %w", err)
+		c.JSON(http.StatusBadRequest, newResError(c, err))
+		return
+	}
+
+	req.UserJWT = c.GetHeader(header.Authorization)
+	req.UserAgent = c.Request.UserAgent()
+	req.IP = c.ClientIP()
+	req.RequestID = gouser.RequestIDFromContext(c.Request.Context())
+
+	err = a.usecaseAccount.ChangeUsername(c, req)
+	if err != nil {
+		err := fmt.Errorf("Account.usecaseAccount.ChangeUsername: %w", err)
+		c.JSON(http.StatusBadRequest, newResError(c, err))
+		return
+	}
+
+	c.JSON(http.StatusOK, ResString{Data: "ok"})
+}
@@ -0,0 +1,76 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/Hidayathamir/go-user/internal/repo"
+	"github.com/Hidayathamir/go-user/internal/repo/db"
+	"github.com/Hidayathamir/go-user/internal/usecase"
+	"github.com/Hidayathamir/go-user/pkg/gouser"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntegrationAccountDeleteAccount(t *testing.T) {
+	t.Parallel()
+
+	t.Run("deleted user should not exist until restored", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := initTestIntegration(t)
+
+		pg, err := db.NewPGPoolConn(cfg)
+		require.NoError(t, err)
+
+		repoAuth := repo.NewAuth(cfg, pg)
+		repoProfile := repo.NewProfile(cfg, pg)
+		repoSession := repo.NewSession(cfg, pg)
+		repoAccount := repo.NewAccount(cfg, pg)
+		transactor := repo.NewTransactor(cfg, pg)
+		usecaseAuth := usecase.NewAuth(cfg, repoAuth, repoProfile, repoSession, repoAccount, repo.NewAuditLog(cfg, pg), transactor)
+		controllerAuth := newAuth(cfg, usecaseAuth)
+
+		usecaseProfile := usecase.NewProfile(cfg, repoProfile, repoSession, repo.NewOutbox(cfg, pg), repo.NewAuditLog(cfg, pg), transactor)
+		controllerProfile := newProfile(cfg, usecaseProfile)
+
+		usecaseAccount := usecase.NewAccount(cfg, repoAccount, repoProfile, repoSession, repo.NewOutbox(cfg, pg), repo.NewWebhook(cfg, pg), repo.NewAuditLog(cfg, pg), transactor)
+		controllerAccount := newAccount(cfg, usecaseAccount)
+
+		gin.SetMode(gin.TestMode)
+
+		username := uuid.NewString()
+		password := uuid.NewString()
+		registerUserWithAssertSuccess(t, controllerAuth, username, password)
+		resBodyLogin := loginUserWithAssertSuccess(t, cfg, controllerAuth, username, password)
+
+		resBodyByte, httpStatusCode := deleteAccount(controllerAccount, resBodyLogin.Data.UserJWT, password)
+		assert.Equal(t, http.StatusOK, httpStatusCode, string(resBodyByte))
+
+		resBodyByte, httpStatusCode = getProfileByUsername(controllerProfile, username)
+		assert.Equal(t, http.StatusBadRequest, httpStatusCode)
+		resBodyError := ResError{}
+		require.NoError(t, json.Unmarshal(resBodyByte, &resBodyError))
+		assert.Contains(t, resBodyError.Error, gouser.ErrUnknownUsername.Error())
+
+		resBodyByte, httpStatusCode = loginUser(controllerAuth, username, password)
+		assert.Equal(t, http.StatusBadRequest, httpStatusCode)
+		resBodyError = ResError{}
+		require.NoError(t, json.Unmarshal(resBodyByte, &resBodyError))
+		assert.Contains(t, resBodyError.Error, gouser.ErrUnknownUsername.Error())
+
+		resBodyByte, httpStatusCode = registerUser(controllerAuth, username, password)
+		assert.Equal(t, http.StatusBadRequest, httpStatusCode)
+		resBodyError = ResError{}
+		require.NoError(t, json.Unmarshal(resBodyByte, &resBodyError))
+		assert.Contains(t, resBodyError.Error, gouser.ErrDuplicateUsername.Error())
+
+		resBodyByte, httpStatusCode = restoreAccount(controllerAccount, username, password)
+		assert.Equal(t, http.StatusOK, httpStatusCode, string(resBodyByte))
+
+		loginUserWithAssertSuccess(t, cfg, controllerAuth, username, password)
+	})
+}
@@ -0,0 +1,344 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Hidayathamir/go-user/config"
+	"github.com/Hidayathamir/go-user/internal/pkg/header"
+	"github.com/Hidayathamir/go-user/internal/usecase/mockusecase"
+	"github.com/Hidayathamir/go-user/pkg/gouser"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUnitAccountDeleteAccount(t *testing.T) {
+	t.Parallel()
+
+	gin.SetMode(gin.TestMode)
+
+	t.Run("call usecase DeleteAccount success should return success", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		usecaseAccount := mockusecase.NewMockIAccount(ctrl)
+
+		a := &Account{
+			cfg:            config.Config{},
+			usecaseAccount: usecaseAccount,
+		}
+
+		rr := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(rr)
+		reqBody, _ := json.Marshal(gouser.ReqDeleteAccount{Password: "mypassword"})
+		req := httptest.NewRequest(http.MethodDelete, "/", bytes.NewReader(reqBody))
+		req.Header.Set(header.Authorization, "Bearer dummyUserJWT")
+		req.Header.Set("User-Agent", "Mozilla/5.0")
+		ctx.Request = req.WithContext(gouser.WithRequestID(req.Context(), "req-1"))
+
+		usecaseAccount.EXPECT().
+			DeleteAccount(gomock.Any(), gouser.ReqDeleteAccount{
+				UserJWT:   "Bearer dummyUserJWT",
+				Password:  "mypassword",
+				UserAgent: "Mozilla/5.0",
+				IP:        "192.0.2.1", // httptest.NewRequest remote address.
+				RequestID: "req-1",
+			}).Return(nil)
+
+		a.deleteAccount(ctx)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		resBody := ResString{}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resBody))
+		assert.Equal(t, "ok", resBody.Data)
+		assert.Nil(t, resBody.Error)
+	})
+	t.Run("call usecase DeleteAccount error should return error", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		usecaseAccount := mockusecase.NewMockIAccount(ctrl)
+
+		a := &Account{
+			cfg:            config.Config{},
+			usecaseAccount: usecaseAccount,
+		}
+
+		rr := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(rr)
+		reqBody, _ := json.Marshal(gouser.ReqDeleteAccount{Password: "mypassword"})
+		req := httptest.NewRequest(http.MethodDelete, "/", bytes.NewReader(reqBody))
+		req.Header.Set(header.Authorization, "Bearer dummyUserJWT")
+		ctx.Request = req
+
+		usecaseAccount.EXPECT().
+			DeleteAccount(gomock.Any(), gouser.ReqDeleteAccount{
+				UserJWT:  "Bearer dummyUserJWT",
+				Password: "mypassword",
+				IP:       "192.0.2.1", // httptest.NewRequest remote address.
+			}).Return(assert.AnError)
+
+		a.deleteAccount(ctx)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		resBody := ResError{}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resBody))
+		assert.Nil(t, resBody.Data)
+		assert.Contains(t, resBody.Error, assert.AnError.Error())
+	})
+}
+
+func TestUnitAccountRestoreAccount(t *testing.T) {
+	t.Parallel()
+
+	gin.SetMode(gin.TestMode)
+
+	t.Run("call usecase RestoreAccount success should return success", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		usecaseAccount := mockusecase.NewMockIAccount(ctrl)
+
+		a := &Account{
+			cfg:            config.Config{},
+			usecaseAccount: usecaseAccount,
+		}
+
+		reqRestoreAccount := gouser.ReqRestoreAccount{Username: "hidayat", Password: "mypassword"}
+
+		rr := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(rr)
+		reqBody, _ := json.Marshal(reqRestoreAccount)
+		ctx.Request = httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(reqBody))
+
+		reqRestoreAccount.IP = "192.0.2.1" // httptest.NewRequest remote address.
+		usecaseAccount.EXPECT().RestoreAccount(gomock.Any(), reqRestoreAccount).Return(nil)
+
+		a.restoreAccount(ctx)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		resBody := ResString{}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resBody))
+		assert.Equal(t, "ok", resBody.Data)
+		assert.Nil(t, resBody.Error)
+	})
+	t.Run("call usecase RestoreAccount error should return error", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		usecaseAccount := mockusecase.NewMockIAccount(ctrl)
+
+		a := &Account{
+			cfg:            config.Config{},
+			usecaseAccount: usecaseAccount,
+		}
+
+		reqRestoreAccount := gouser.ReqRestoreAccount{Username: "hidayat", Password: "mypassword"}
+
+		rr := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(rr)
+		reqBody, _ := json.Marshal(reqRestoreAccount)
+		ctx.Request = httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(reqBody))
+
+		reqRestoreAccount.IP = "192.0.2.1" // httptest.NewRequest remote address.
+		usecaseAccount.EXPECT().RestoreAccount(gomock.Any(), reqRestoreAccount).Return(assert.AnError)
+
+		a.restoreAccount(ctx)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		resBody := ResError{}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resBody))
+		assert.Nil(t, resBody.Data)
+		assert.Contains(t, resBody.Error, assert.AnError.Error())
+	})
+}
+
+func TestUnitAccountUpdateUserStatus(t *testing.T) {
+	t.Parallel()
+
+	gin.SetMode(gin.TestMode)
+
+	t.Run("call usecase UpdateUserStatus success should return success", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		usecaseAccount := mockusecase.NewMockIAccount(ctrl)
+
+		a := &Account{
+			cfg:            config.Config{},
+			usecaseAccount: usecaseAccount,
+		}
+
+		rr := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(rr)
+		reqBody := []byte(`{"status":"suspended","suspended_until":"2030-01-02T03:04:05Z","suspension_reason":"spam"}`)
+		req := httptest.NewRequest(http.MethodPut, "/", bytes.NewReader(reqBody))
+		req.Header.Set(header.Authorization, "Bearer dummyAdminJWT")
+		ctx.Request = req
+		ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: "44"})
+
+		suspendedUntil := time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC)
+		usecaseAccount.EXPECT().
+			UpdateUserStatus(gomock.Any(), gouser.ReqUpdateUserStatus{
+				UserJWT:          "Bearer dummyAdminJWT",
+				UserID:           44,
+				Status:           "suspended",
+				SuspendedUntil:   &suspendedUntil,
+				SuspensionReason: "spam",
+				IP:               "192.0.2.1", // httptest.NewRequest remote address.
+			}).Return(nil)
+
+		a.updateUserStatus(ctx)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		resBody := ResString{}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resBody))
+		assert.Equal(t, "ok", resBody.Data)
+		assert.Nil(t, resBody.Error)
+	})
+	t.Run("invalid user id should return error", func(t *testing.T) {
+		t.Parallel()
+
+		a := &Account{cfg: config.Config{}}
+
+		rr := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(rr)
+		ctx.Request = httptest.NewRequest(http.MethodPut, "/", bytes.NewReader([]byte(`{}`)))
+		ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: "abc"})
+
+		a.updateUserStatus(ctx)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		resBody := ResError{}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resBody))
+		assert.Contains(t, resBody.Error, "strconv.ParseInt")
+	})
+	t.Run("call usecase UpdateUserStatus error should return error", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		usecaseAccount := mockusecase.NewMockIAccount(ctrl)
+
+		a := &Account{
+			cfg:            config.Config{},
+			usecaseAccount: usecaseAccount,
+		}
+
+		rr := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(rr)
+		req := httptest.NewRequest(http.MethodPut, "/", bytes.NewReader([]byte(`{"status":"disabled"}`)))
+		req.Header.Set(header.Authorization, "Bearer dummyAdminJWT")
+		ctx.Request = req
+		ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: "44"})
+
+		usecaseAccount.EXPECT().
+			UpdateUserStatus(gomock.Any(), gouser.ReqUpdateUserStatus{
+				UserJWT: "Bearer dummyAdminJWT",
+				UserID:  44,
+				Status:  "disabled",
+				IP:      "192.0.2.1", // httptest.NewRequest remote address.
+			}).Return(assert.AnError)
+
+		a.updateUserStatus(ctx)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		resBody := ResError{}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resBody))
+		assert.Contains(t, resBody.Error, assert.AnError.Error())
+	})
+}
+
+func TestUnitAccountChangeUsername(t *testing.T) {
+	t.Parallel()
+
+	gin.SetMode(gin.TestMode)
+
+	t.Run("call usecase ChangeUsername success should return success", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		usecaseAccount := mockusecase.NewMockIAccount(ctrl)
+
+		a := &Account{
+			cfg:            config.Config{},
+			usecaseAccount: usecaseAccount,
+		}
+
+		rr := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(rr)
+		reqBody, _ := json.Marshal(gouser.ReqChangeUsername{Username: "hidayat2"})
+		req := httptest.NewRequest(http.MethodPut, "/", bytes.NewReader(reqBody))
+		req.Header.Set(header.Authorization, "Bearer dummyUserJWT")
+		ctx.Request = req
+
+		usecaseAccount.EXPECT().
+			ChangeUsername(gomock.Any(), gouser.ReqChangeUsername{
+				UserJWT:  "Bearer dummyUserJWT",
+				Username: "hidayat2",
+				IP:       "192.0.2.1", // httptest.NewRequest remote address.
+			}).Return(nil)
+
+		a.changeUsername(ctx)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		resBody := ResString{}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resBody))
+		assert.Equal(t, "ok", resBody.Data)
+		assert.Nil(t, resBody.Error)
+	})
+	t.Run("call usecase ChangeUsername error should return error", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		usecaseAccount := mockusecase.NewMockIAccount(ctrl)
+
+		a := &Account{
+			cfg:            config.Config{},
+			usecaseAccount: usecaseAccount,
+		}
+
+		rr := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(rr)
+		reqBody, _ := json.Marshal(gouser.ReqChangeUsername{Username: "hidayat2"})
+		req := httptest.NewRequest(http.MethodPut, "/", bytes.NewReader(reqBody))
+		req.Header.Set(header.Authorization, "Bearer dummyUserJWT")
+		ctx.Request = req
+
+		usecaseAccount.EXPECT().
+			ChangeUsername(gomock.Any(), gouser.ReqChangeUsername{
+				UserJWT:  "Bearer dummyUserJWT",
+				Username: "hidayat2",
+				IP:       "192.0.2.1", // httptest.NewRequest remote address.
+			}).Return(assert.AnError)
+
+		a.changeUsername(ctx)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		resBody := ResError{}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resBody))
+		assert.Nil(t, resBody.Data)
+		assert.Contains(t, resBody.Error, assert.AnError.Error())
+	})
+}
@@ -0,0 +1,46 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/Hidayathamir/go-user/config"
+	"github.com/Hidayathamir/go-user/internal/pkg/header"
+	"github.com/Hidayathamir/go-user/internal/usecase"
+	"github.com/Hidayathamir/go-user/pkg/gouser"
+	"github.com/gin-gonic/gin"
+)
+
+// AuditLog is controller HTTP for audit log related.
+type AuditLog struct {
+	cfg             config.Config
+	usecaseAuditLog usecase.IAuditLog
+}
+
+func newAuditLog(cfg config.Config, usecaseAuditLog usecase.IAuditLog) *AuditLog {
+	return &AuditLog{
+		cfg:             cfg,
+		usecaseAuditLog: usecaseAuditLog,
+	}
+}
+
+func (a *AuditLog) getAuditLogs(c *gin.Context) {
+	req := gouser.ReqGetAuditLogs{}
+	err := c.ShouldBindQuery(&req)
+	if err != nil {
+		err := fmt.Errorf("gin.Context.ShouldBindQuery: %w", err)
+		c.JSON(http.StatusBadRequest, newResError(c, err))
+		return
+	}
+
+	req.UserJWT = c.GetHeader(header.Authorization)
+
+	res, err := a.usecaseAuditLog.GetAuditLogs(c, req)
+	if err != nil {
+		err := fmt.Errorf("AuditLog.usecaseAuditLog.GetAuditLogs: %w", err)
+		c.JSON(http.StatusBadRequest, newResError(c, err))
+		return
+	}
+
+	c.JSON(http.StatusOK, ResGetAuditLogs{Data: res})
+}
@@ -0,0 +1,9 @@
+package http
+
+import "github.com/Hidayathamir/go-user/pkg/gouser"
+
+// ResGetAuditLogs -.
+type ResGetAuditLogs struct {
+	Data  gouser.ResGetAuditLogs `json:"data"`
+	Error any                    `json:"error"`
+}
@@ -0,0 +1,119 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Hidayathamir/go-user/config"
+	"github.com/Hidayathamir/go-user/internal/pkg/header"
+	"github.com/Hidayathamir/go-user/internal/repo/db/entity"
+	"github.com/Hidayathamir/go-user/internal/usecase/mockusecase"
+	"github.com/Hidayathamir/go-user/pkg/gouser"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUnitAuditLogGetAuditLogs(t *testing.T) {
+	t.Parallel()
+
+	gin.SetMode(gin.TestMode)
+
+	t.Run("call usecase GetAuditLogs success should return success", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		usecaseAuditLog := mockusecase.NewMockIAuditLog(ctrl)
+
+		a := &AuditLog{
+			cfg:             config.Config{},
+			usecaseAuditLog: usecaseAuditLog,
+		}
+
+		rr := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(rr)
+		req := httptest.NewRequest(http.MethodGet, "/?target_user_id=44&action=user.login&result=failure&limit=5", nil)
+		req.Header.Set(header.Authorization, "Bearer dummyUserJWT")
+		ctx.Request = req
+
+		targetUserID := int64(44)
+		resGetAuditLogs := gouser.ResGetAuditLogs{
+			AuditLogs:  []gouser.AuditLog{{ID: 3, TargetUserID: &targetUserID, Action: entity.AuditActionUserLogin, Result: entity.AuditResultFailure}},
+			NextCursor: "next",
+		}
+		usecaseAuditLog.EXPECT().
+			GetAuditLogs(gomock.Any(), gouser.ReqGetAuditLogs{
+				UserJWT:      "Bearer dummyUserJWT",
+				Limit:        5,
+				TargetUserID: &targetUserID,
+				Action:       entity.AuditActionUserLogin,
+				Result:       entity.AuditResultFailure,
+			}).
+			Return(resGetAuditLogs, nil)
+
+		a.getAuditLogs(ctx)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		resBody := ResGetAuditLogs{}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resBody))
+		assert.Equal(t, resGetAuditLogs, resBody.Data)
+		assert.Nil(t, resBody.Error)
+	})
+	t.Run("call usecase GetAuditLogs error should return error", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		usecaseAuditLog := mockusecase.NewMockIAuditLog(ctrl)
+
+		a := &AuditLog{
+			cfg:             config.Config{},
+			usecaseAuditLog: usecaseAuditLog,
+		}
+
+		rr := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(rr)
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set(header.Authorization, "Bearer dummyUserJWT")
+		ctx.Request = req
+
+		usecaseAuditLog.EXPECT().
+			GetAuditLogs(gomock.Any(), gomock.Any()).
+			Return(gouser.ResGetAuditLogs{}, gouser.ErrForbidden)
+
+		a.getAuditLogs(ctx)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		resBody := ResError{}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resBody))
+		assert.Contains(t, resBody.Error, gouser.ErrForbidden.Error())
+	})
+	t.Run("target user id not number should return error", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		a := &AuditLog{
+			cfg:             config.Config{},
+			usecaseAuditLog: mockusecase.NewMockIAuditLog(ctrl),
+		}
+
+		rr := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(rr)
+		ctx.Request = httptest.NewRequest(http.MethodGet, "/?target_user_id=abc", nil)
+
+		a.getAuditLogs(ctx)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		resBody := ResError{}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resBody))
+		assert.Contains(t, resBody.Error, "gin.Context.ShouldBindQuery")
+	})
+}
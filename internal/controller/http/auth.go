@@ -28,14 +28,18 @@ func (a *Auth) loginUser(c *gin.Context) {
 	err := c.ShouldBindJSON(&req)
 	if err != nil {
 		err := fmt.Errorf("gin.Context.ShouldBindJSON: %w", err)
-		c.JSON(http.StatusBadRequest, ResError{Error: err.Error()})
+		c.JSON(http.StatusBadRequest, newResError(c, err))
 		return
 	}
 
+	req.UserAgent = c.Request.UserAgent()
+	req.IP = c.ClientIP()
+	req.RequestID = gouser.RequestIDFromContext(c.Request.Context())
+
 	resLoginUser, err := a.usecaseAuth.LoginUser(c, req)
 	if err != nil {
 		err := fmt.Errorf("Auth.usecaseAuth.LoginUser: %w", err)
-		c.JSON(http.StatusBadRequest, ResError{Error: err.Error()})
+		c.JSON(http.StatusBadRequest, newResError(c, err))
 		return
 	}
 
@@ -47,14 +51,18 @@ func (a *Auth) registerUser(c *gin.Context) {
 	err := c.ShouldBindJSON(&req)
 	if err != nil {
 		err := fmt.Errorf("gin.Context.ShouldBindJSON: %w", err)
-		c.JSON(http.StatusBadRequest, ResError{Error: err.Error()})
+		c.JSON(http.StatusBadRequest, newResError(c, err))
 		return
 	}
 
+	req.UserAgent = c.Request.UserAgent()
+	req.IP = c.ClientIP()
+	req.RequestID = gouser.RequestIDFromContext(c.Request.Context())
+
 	resRegisterUser, err := a.usecaseAuth.RegisterUser(c, req)
 	if err != nil {
 		err := fmt.Errorf("Auth.usecaseAuth.RegisterUser: %w", err)
-		c.JSON(http.StatusBadRequest, ResError{Error: err.Error()})
+		c.JSON(http.StatusBadRequest, newResError(c, err))
 		return
 	}
 
@@ -29,7 +29,9 @@ func TestIntegrationAuthLoginUser(t *testing.T) {
 
 		repoAuth := repo.NewAuth(cfg, pg)
 		repoProfile := repo.NewProfile(cfg, pg)
-		usecaseAuth := usecase.NewAuth(cfg, repoAuth, repoProfile)
+		repoSession := repo.NewSession(cfg, pg)
+		repoAccount := repo.NewAccount(cfg, pg)
+		usecaseAuth := usecase.NewAuth(cfg, repoAuth, repoProfile, repoSession, repoAccount, repo.NewAuditLog(cfg, pg), repo.NewTransactor(cfg, pg))
 		controllerAuth := newAuth(cfg, usecaseAuth)
 
 		gin.SetMode(gin.TestMode)
@@ -55,7 +57,9 @@ func TestIntegrationAuthLoginUser(t *testing.T) {
 
 		repoAuth := repo.NewAuth(cfg, pg)
 		repoProfile := repo.NewProfile(cfg, pg)
-		usecaseAuth := usecase.NewAuth(cfg, repoAuth, repoProfile)
+		repoSession := repo.NewSession(cfg, pg)
+		repoAccount := repo.NewAccount(cfg, pg)
+		usecaseAuth := usecase.NewAuth(cfg, repoAuth, repoProfile, repoSession, repoAccount, repo.NewAuditLog(cfg, pg), repo.NewTransactor(cfg, pg))
 		controllerAuth := newAuth(cfg, usecaseAuth)
 
 		gin.SetMode(gin.TestMode)
@@ -81,7 +85,9 @@ func TestIntegrationAuthLoginUser(t *testing.T) {
 
 		repoAuth := repo.NewAuth(cfg, pg)
 		repoProfile := repo.NewProfile(cfg, pg)
-		usecaseAuth := usecase.NewAuth(cfg, repoAuth, repoProfile)
+		repoSession := repo.NewSession(cfg, pg)
+		repoAccount := repo.NewAccount(cfg, pg)
+		usecaseAuth := usecase.NewAuth(cfg, repoAuth, repoProfile, repoSession, repoAccount, repo.NewAuditLog(cfg, pg), repo.NewTransactor(cfg, pg))
 		controllerAuth := newAuth(cfg, usecaseAuth)
 
 		gin.SetMode(gin.TestMode)
@@ -104,7 +110,9 @@ func TestIntegrationAuthLoginUser(t *testing.T) {
 
 		repoAuth := repo.NewAuth(cfg, pg)
 		repoProfile := repo.NewProfile(cfg, pg)
-		usecaseAuth := usecase.NewAuth(cfg, repoAuth, repoProfile)
+		repoSession := repo.NewSession(cfg, pg)
+		repoAccount := repo.NewAccount(cfg, pg)
+		usecaseAuth := usecase.NewAuth(cfg, repoAuth, repoProfile, repoSession, repoAccount, repo.NewAuditLog(cfg, pg), repo.NewTransactor(cfg, pg))
 		controllerAuth := newAuth(cfg, usecaseAuth)
 
 		gin.SetMode(gin.TestMode)
@@ -143,7 +151,9 @@ func TestIntegrationAuthRegisterUser(t *testing.T) {
 
 		repoAuth := repo.NewAuth(cfg, pg)
 		repoProfile := repo.NewProfile(cfg, pg)
-		usecaseAuth := usecase.NewAuth(cfg, repoAuth, repoProfile)
+		repoSession := repo.NewSession(cfg, pg)
+		repoAccount := repo.NewAccount(cfg, pg)
+		usecaseAuth := usecase.NewAuth(cfg, repoAuth, repoProfile, repoSession, repoAccount, repo.NewAuditLog(cfg, pg), repo.NewTransactor(cfg, pg))
 		controllerAuth := newAuth(cfg, usecaseAuth)
 
 		gin.SetMode(gin.TestMode)
@@ -160,7 +170,9 @@ func TestIntegrationAuthRegisterUser(t *testing.T) {
 
 		repoAuth := repo.NewAuth(cfg, pg)
 		repoProfile := repo.NewProfile(cfg, pg)
-		usecaseAuth := usecase.NewAuth(cfg, repoAuth, repoProfile)
+		repoSession := repo.NewSession(cfg, pg)
+		repoAccount := repo.NewAccount(cfg, pg)
+		usecaseAuth := usecase.NewAuth(cfg, repoAuth, repoProfile, repoSession, repoAccount, repo.NewAuditLog(cfg, pg), repo.NewTransactor(cfg, pg))
 		controllerAuth := newAuth(cfg, usecaseAuth)
 
 		gin.SetMode(gin.TestMode)
@@ -186,7 +198,9 @@ func TestIntegrationAuthRegisterUser(t *testing.T) {
 
 		repoAuth := repo.NewAuth(cfg, pg)
 		repoProfile := repo.NewProfile(cfg, pg)
-		usecaseAuth := usecase.NewAuth(cfg, repoAuth, repoProfile)
+		repoSession := repo.NewSession(cfg, pg)
+		repoAccount := repo.NewAccount(cfg, pg)
+		usecaseAuth := usecase.NewAuth(cfg, repoAuth, repoProfile, repoSession, repoAccount, repo.NewAuditLog(cfg, pg), repo.NewTransactor(cfg, pg))
 		controllerAuth := newAuth(cfg, usecaseAuth)
 
 		gin.SetMode(gin.TestMode)
@@ -42,11 +42,15 @@ func TestUnitAuthLoginUser(t *testing.T) {
 		})
 		require.NoError(t, err)
 		req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(reqBody))
-		ctx.Request = req
+		req.Header.Set("User-Agent", "Mozilla/5.0")
+		ctx.Request = req.WithContext(gouser.WithRequestID(req.Context(), "req-1"))
 
 		usecaseAuth.EXPECT().LoginUser(gomock.Any(), gouser.ReqLoginUser{
-			Username: "hidayat",
-			Password: "mypassword",
+			Username:  "hidayat",
+			Password:  "mypassword",
+			UserAgent: "Mozilla/5.0",
+			IP:        "192.0.2.1", // httptest.NewRequest remote address.
+			RequestID: "req-1",
 		}).Return(gouser.ResLoginUser{UserJWT: "Bearer dummyUserJWT"}, nil)
 
 		a.loginUser(ctx)
@@ -79,11 +83,15 @@ func TestUnitAuthLoginUser(t *testing.T) {
 		})
 		require.NoError(t, err)
 		req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(reqBody))
-		ctx.Request = req
+		req.Header.Set("User-Agent", "Mozilla/5.0")
+		ctx.Request = req.WithContext(gouser.WithRequestID(req.Context(), "req-1"))
 
 		usecaseAuth.EXPECT().LoginUser(gomock.Any(), gouser.ReqLoginUser{
-			Username: "hidayat",
-			Password: "mypassword",
+			Username:  "hidayat",
+			Password:  "mypassword",
+			UserAgent: "Mozilla/5.0",
+			IP:        "192.0.2.1", // httptest.NewRequest remote address.
+			RequestID: "req-1",
 		}).Return(gouser.ResLoginUser{}, assert.AnError)
 
 		a.loginUser(ctx)
@@ -123,11 +131,15 @@ func TestUnitAuthRegisterUser(t *testing.T) {
 		})
 		require.NoError(t, err)
 		req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(reqBody))
-		ctx.Request = req
+		req.Header.Set("User-Agent", "Mozilla/5.0")
+		ctx.Request = req.WithContext(gouser.WithRequestID(req.Context(), "req-1"))
 
 		usecaseAuth.EXPECT().RegisterUser(gomock.Any(), gouser.ReqRegisterUser{
-			Username: "hidayat",
-			Password: "mypassword",
+			Username:  "hidayat",
+			Password:  "mypassword",
+			UserAgent: "Mozilla/5.0",
+			IP:        "192.0.2.1", // httptest.NewRequest remote address.
+			RequestID: "req-1",
 		}).Return(gouser.ResRegisterUser{UserID: 323}, nil)
 
 		a.registerUser(ctx)
@@ -165,6 +177,7 @@ func TestUnitAuthRegisterUser(t *testing.T) {
 		usecaseAuth.EXPECT().RegisterUser(gomock.Any(), gouser.ReqRegisterUser{
 			Username: "hidayat",
 			Password: "mypassword",
+			IP:       "192.0.2.1",
 		}).Return(gouser.ResRegisterUser{UserID: 0}, assert.AnError)
 
 		a.registerUser(ctx)
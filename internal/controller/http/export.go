@@ -0,0 +1,87 @@
+package http
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/Hidayathamir/go-user/config"
+	"github.com/Hidayathamir/go-user/internal/pkg/header"
+	"github.com/Hidayathamir/go-user/internal/pkg/logger"
+	"github.com/Hidayathamir/go-user/internal/usecase"
+	"github.com/Hidayathamir/go-user/pkg/gouser"
+	"github.com/gin-gonic/gin"
+)
+
+// Export is controller HTTP for personal data export related.
+type Export struct {
+	cfg           config.Config
+	usecaseExport usecase.IExport
+}
+
+func newExport(cfg config.Config, usecaseExport usecase.IExport) *Export {
+	return &Export{
+		cfg:           cfg,
+		usecaseExport: usecaseExport,
+	}
+}
+
+func (e *Export) exportMyData(c *gin.Context) {
+	req := gouser.ReqExportMyData{
+		UserJWT: c.GetHeader(header.Authorization),
+	}
+
+	streamExportArchive(c, func(w io.Writer) error {
+		err := e.usecaseExport.ExportMyData(c, req, w)
+		if err != nil {
+			return fmt.Errorf("Export.usecaseExport.ExportMyData: %w", err)
+		}
+		return nil
+	})
+}
+
+func (e *Export) exportUserData(c *gin.Context) {
+	userID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		err := fmt.Errorf("strconv.ParseInt: %w", err)
+		c.JSON(http.StatusBadRequest, newResError(c, err))
+		return
+	}
+
+	req := gouser.ReqExportUserData{
+		UserJWT: c.GetHeader(header.Authorization),
+		UserID:  userID,
+	}
+
+	streamExportArchive(c, func(w io.Writer) error {
+		err := e.usecaseExport.ExportUserData(c, req, w)
+		if err != nil {
+			return fmt.Errorf("Export.usecaseExport.ExportUserData: %w", err)
+		}
+		return nil
+	})
+}
+
+// streamExportArchive write the archive written by export to response body as
+// attachment.
+func streamExportArchive(c *gin.Context, export func(w io.Writer) error) {
+	c.Header(header.ContentType, "application/json; charset=utf-8")
+	c.Header(header.ContentDisposition, `attachment; filename="export.json"`)
+	c.Status(http.StatusOK)
+
+	err := export(c.Writer)
+	if err != nil {
+		// The archive is streamed, once it is started the status can not be
+		// changed anymore, abort so the client get truncated archive.
+		if c.Writer.Written() {
+			logger.FromContext(c).Warn(err)
+			c.Abort()
+			return
+		}
+
+		c.Writer.Header().Del(header.ContentDisposition)
+		c.JSON(http.StatusBadRequest, newResError(c, err))
+		return
+	}
+}
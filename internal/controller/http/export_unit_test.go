@@ -0,0 +1,183 @@
+package http
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Hidayathamir/go-user/config"
+	"github.com/Hidayathamir/go-user/internal/pkg/header"
+	"github.com/Hidayathamir/go-user/internal/usecase/mockusecase"
+	"github.com/Hidayathamir/go-user/pkg/gouser"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUnitExportExportMyData(t *testing.T) {
+	t.Parallel()
+
+	gin.SetMode(gin.TestMode)
+
+	t.Run("call usecase ExportMyData success should return archive", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		usecaseExport := mockusecase.NewMockIExport(ctrl)
+
+		e := &Export{
+			cfg:           config.Config{},
+			usecaseExport: usecaseExport,
+		}
+
+		rr := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(rr)
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set(header.Authorization, "Bearer dummyUserJWT")
+		ctx.Request = req
+
+		usecaseExport.EXPECT().
+			ExportMyData(gomock.Any(), gouser.ReqExportMyData{UserJWT: "Bearer dummyUserJWT"}, gomock.Any()).
+			DoAndReturn(func(_ any, _ gouser.ReqExportMyData, w io.Writer) error {
+				_, err := io.WriteString(w, `{"version":1}`)
+				return err
+			})
+
+		e.exportMyData(ctx)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, `{"version":1}`, rr.Body.String())
+		assert.Contains(t, rr.Header().Get(header.ContentDisposition), "attachment")
+	})
+	t.Run("call usecase ExportMyData error should return error", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		usecaseExport := mockusecase.NewMockIExport(ctrl)
+
+		e := &Export{
+			cfg:           config.Config{},
+			usecaseExport: usecaseExport,
+		}
+
+		rr := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(rr)
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set(header.Authorization, "Bearer dummyUserJWT")
+		ctx.Request = req
+
+		usecaseExport.EXPECT().
+			ExportMyData(gomock.Any(), gouser.ReqExportMyData{UserJWT: "Bearer dummyUserJWT"}, gomock.Any()).
+			Return(assert.AnError)
+
+		e.exportMyData(ctx)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Empty(t, rr.Header().Get(header.ContentDisposition))
+		resBody := ResError{}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resBody))
+		assert.Contains(t, resBody.Error, assert.AnError.Error())
+	})
+}
+
+func TestUnitExportExportUserData(t *testing.T) {
+	t.Parallel()
+
+	gin.SetMode(gin.TestMode)
+
+	t.Run("call usecase ExportUserData success should return archive", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		usecaseExport := mockusecase.NewMockIExport(ctrl)
+
+		e := &Export{
+			cfg:           config.Config{},
+			usecaseExport: usecaseExport,
+		}
+
+		rr := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(rr)
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set(header.Authorization, "Bearer dummyAdminJWT")
+		ctx.Request = req
+		ctx.Params = gin.Params{{Key: "id", Value: "44"}}
+
+		usecaseExport.EXPECT().
+			ExportUserData(gomock.Any(), gouser.ReqExportUserData{UserJWT: "Bearer dummyAdminJWT", UserID: 44}, gomock.Any()).
+			DoAndReturn(func(_ any, _ gouser.ReqExportUserData, w io.Writer) error {
+				_, err := io.WriteString(w, `{"version":1}`)
+				return err
+			})
+
+		e.exportUserData(ctx)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, `{"version":1}`, rr.Body.String())
+		assert.Contains(t, rr.Header().Get(header.ContentDisposition), "attachment")
+	})
+	t.Run("invalid user id should return error", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		usecaseExport := mockusecase.NewMockIExport(ctrl)
+
+		e := &Export{
+			cfg:           config.Config{},
+			usecaseExport: usecaseExport,
+		}
+
+		rr := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(rr)
+		ctx.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+		ctx.Params = gin.Params{{Key: "id", Value: "abc"}}
+
+		e.exportUserData(ctx)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Empty(t, rr.Header().Get(header.ContentDisposition))
+	})
+	t.Run("call usecase ExportUserData error should return error", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		usecaseExport := mockusecase.NewMockIExport(ctrl)
+
+		e := &Export{
+			cfg:           config.Config{},
+			usecaseExport: usecaseExport,
+		}
+
+		rr := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(rr)
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set(header.Authorization, "Bearer dummyAdminJWT")
+		ctx.Request = req
+		ctx.Params = gin.Params{{Key: "id", Value: "44"}}
+
+		usecaseExport.EXPECT().
+			ExportUserData(gomock.Any(), gouser.ReqExportUserData{UserJWT: "Bearer dummyAdminJWT", UserID: 44}, gomock.Any()).
+			Return(assert.AnError)
+
+		e.exportUserData(ctx)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Empty(t, rr.Header().Get(header.ContentDisposition))
+		resBody := ResError{}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resBody))
+		assert.Contains(t, resBody.Error, assert.AnError.Error())
+	})
+}
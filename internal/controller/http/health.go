@@ -0,0 +1,35 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/Hidayathamir/go-user/internal/pkg/health"
+	"github.com/gin-gonic/gin"
+)
+
+// Health is controller HTTP for app health related.
+type Health struct {
+	checker *health.Checker
+}
+
+func newHealth(checker *health.Checker) *Health {
+	return &Health{checker: checker}
+}
+
+// healthz is liveness probe, it does not check dependency so the app is not
+// restarted when e.g postgres is down.
+func (h *Health) healthz(c *gin.Context) {
+	c.JSON(http.StatusOK, ResString{Data: "ok"})
+}
+
+// readyz is readiness probe, it checks the app is serving and its
+// dependencies are up.
+func (h *Health) readyz(c *gin.Context) {
+	report := h.checker.Check(c)
+	if !report.IsUp() {
+		c.JSON(http.StatusServiceUnavailable, ResHealthReport{Data: report, Error: "not ready"})
+		return
+	}
+
+	c.JSON(http.StatusOK, ResHealthReport{Data: report})
+}
@@ -0,0 +1,9 @@
+package http
+
+import "github.com/Hidayathamir/go-user/internal/pkg/health"
+
+// ResHealthReport -.
+type ResHealthReport struct {
+	Data  health.Report `json:"data"`
+	Error any           `json:"error"`
+}
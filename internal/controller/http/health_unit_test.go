@@ -0,0 +1,114 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Hidayathamir/go-user/internal/pkg/health"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitHealthHealthz(t *testing.T) {
+	t.Parallel()
+
+	gin.SetMode(gin.TestMode)
+
+	t.Run("dependency down should still return ok", func(t *testing.T) {
+		t.Parallel()
+
+		h := newHealth(health.NewChecker(
+			health.Check{Name: "postgres", Timeout: time.Second, Check: func(context.Context) error { return assert.AnError }},
+		))
+
+		rr := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(rr)
+		ctx.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+
+		h.healthz(ctx)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		resBody := ResString{}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resBody))
+		assert.Equal(t, "ok", resBody.Data)
+	})
+}
+
+func TestUnitHealthReadyz(t *testing.T) {
+	t.Parallel()
+
+	gin.SetMode(gin.TestMode)
+
+	t.Run("ready and dependency up should return ok", func(t *testing.T) {
+		t.Parallel()
+
+		readiness := health.NewReadiness()
+		readiness.SetReady(true)
+		h := newHealth(health.NewChecker(
+			health.Check{Name: "app", Timeout: time.Second, Check: readiness.Check},
+			health.Check{Name: "postgres", Timeout: time.Second, Check: func(context.Context) error { return nil }},
+		))
+
+		rr := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(rr)
+		ctx.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+
+		h.readyz(ctx)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		resBody := ResHealthReport{}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resBody))
+		assert.Equal(t, health.StatusUp, resBody.Data.Status)
+		assert.Equal(t, health.StatusUp, resBody.Data.Checks["postgres"].Status)
+		assert.Nil(t, resBody.Error)
+	})
+	t.Run("not ready should return service unavailable", func(t *testing.T) {
+		t.Parallel()
+
+		readiness := health.NewReadiness()
+		h := newHealth(health.NewChecker(
+			health.Check{Name: "app", Timeout: time.Second, Check: readiness.Check},
+		))
+
+		rr := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(rr)
+		ctx.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+
+		h.readyz(ctx)
+
+		assert.Equal(t, http.StatusServiceUnavailable, rr.Code)
+		resBody := ResHealthReport{}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resBody))
+		assert.Equal(t, health.StatusDown, resBody.Data.Status)
+		assert.Equal(t, health.StatusDown, resBody.Data.Checks["app"].Status)
+		assert.Equal(t, "not ready", resBody.Error)
+	})
+	t.Run("dependency down should return service unavailable with detail", func(t *testing.T) {
+		t.Parallel()
+
+		readiness := health.NewReadiness()
+		readiness.SetReady(true)
+		h := newHealth(health.NewChecker(
+			health.Check{Name: "app", Timeout: time.Second, Check: readiness.Check},
+			health.Check{Name: "postgres", Timeout: time.Second, Check: func(context.Context) error { return assert.AnError }},
+		))
+
+		rr := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(rr)
+		ctx.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+
+		h.readyz(ctx)
+
+		assert.Equal(t, http.StatusServiceUnavailable, rr.Code)
+		resBody := ResHealthReport{}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resBody))
+		assert.Equal(t, health.StatusUp, resBody.Data.Checks["app"].Status)
+		assert.Equal(t, health.StatusDown, resBody.Data.Checks["postgres"].Status)
+		assert.Equal(t, assert.AnError.Error(), resBody.Data.Checks["postgres"].Error)
+	})
+}
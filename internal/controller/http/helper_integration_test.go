@@ -189,3 +189,58 @@ func getProfileByUsername(controllerProfile *Profile, username string) (resBody
 
 	return rr.Body.Bytes(), rr.Code
 }
+
+// getMySessions get sessions of user JWT owner return raw response and http status code.
+func getMySessions(controllerSession *Session, userJWT string) (resBody []byte, httpStatusCode int) {
+	rr := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(rr)
+	ctx.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+	ctx.Request.Header.Set(header.Authorization, userJWT)
+
+	controllerSession.getMySessions(ctx)
+
+	return rr.Body.Bytes(), rr.Code
+}
+
+// revokeMySession revoke session of user JWT owner return raw response and http status code.
+func revokeMySession(controllerSession *Session, userJWT string, sessionID string) (resBody []byte, httpStatusCode int) {
+	rr := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(rr)
+	ctx.Request = httptest.NewRequest(http.MethodDelete, "/", nil)
+	ctx.Request.Header.Set(header.Authorization, userJWT)
+	ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: sessionID})
+
+	controllerSession.revokeMySession(ctx)
+
+	return rr.Body.Bytes(), rr.Code
+}
+
+// deleteAccount delete account of user JWT owner return raw response and http status code.
+func deleteAccount(controllerAccount *Account, userJWT string, password string) (resBody []byte, httpStatusCode int) {
+	rr := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(rr)
+	reqBody := bytes.NewReader([]byte(jutil.ToJSONString(map[string]string{
+		"password": password,
+	})))
+	ctx.Request = httptest.NewRequest(http.MethodDelete, "/", reqBody)
+	ctx.Request.Header.Set(header.Authorization, userJWT)
+
+	controllerAccount.deleteAccount(ctx)
+
+	return rr.Body.Bytes(), rr.Code
+}
+
+// restoreAccount restore deleted account return raw response and http status code.
+func restoreAccount(controllerAccount *Account, username string, password string) (resBody []byte, httpStatusCode int) {
+	rr := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(rr)
+	reqBody := bytes.NewReader([]byte(jutil.ToJSONString(map[string]string{
+		"username": username,
+		"password": password,
+	})))
+	ctx.Request = httptest.NewRequest(http.MethodPost, "/", reqBody)
+
+	controllerAccount.restoreAccount(ctx)
+
+	return rr.Body.Bytes(), rr.Code
+}
@@ -1,7 +1,11 @@
 package http
 
 import (
+	"net/http"
+	"time"
+
 	"github.com/Hidayathamir/go-user/config"
+	"github.com/Hidayathamir/go-user/internal/pkg/webhook"
 	"github.com/Hidayathamir/go-user/internal/repo"
 	"github.com/Hidayathamir/go-user/internal/repo/db"
 	"github.com/Hidayathamir/go-user/internal/usecase"
@@ -10,14 +14,74 @@ import (
 func injectionAuth(cfg config.Config, db *db.Postgres) *Auth {
 	repoAuth := repo.NewAuth(cfg, db)
 	repoProfile := repo.NewProfile(cfg, db)
-	usecaseAuth := usecase.NewAuth(cfg, repoAuth, repoProfile)
+	repoSession := repo.NewSession(cfg, db)
+	repoAccount := repo.NewAccount(cfg, db)
+	repoAuditLog := repo.NewAuditLog(cfg, db)
+	transactor := repo.NewTransactor(cfg, db)
+	usecaseAuth := usecase.NewAuthTracing(usecase.NewAuth(cfg, repoAuth, repoProfile, repoSession, repoAccount, repoAuditLog, transactor))
 	controllerAuth := newAuth(cfg, usecaseAuth)
 	return controllerAuth
 }
 
 func injectionProfile(cfg config.Config, db *db.Postgres) *Profile {
 	repoProfile := repo.NewProfile(cfg, db)
-	usecaseProfile := usecase.NewProfile(cfg, repoProfile)
+	repoSession := repo.NewSession(cfg, db)
+	repoOutbox := repo.NewOutbox(cfg, db)
+	repoAuditLog := repo.NewAuditLog(cfg, db)
+	transactor := repo.NewTransactor(cfg, db)
+	usecaseProfile := usecase.NewProfileTracing(usecase.NewProfile(cfg, repoProfile, repoSession, repoOutbox, repoAuditLog, transactor))
 	controllerProfile := newProfile(cfg, usecaseProfile)
 	return controllerProfile
 }
+
+func injectionSession(cfg config.Config, db *db.Postgres) *Session {
+	repoProfile := repo.NewProfile(cfg, db)
+	repoSession := repo.NewSession(cfg, db)
+	repoAuditLog := repo.NewAuditLog(cfg, db)
+	transactor := repo.NewTransactor(cfg, db)
+	usecaseSession := usecase.NewSession(cfg, repoSession, repoProfile, repoAuditLog, transactor)
+	controllerSession := newSession(cfg, usecaseSession)
+	return controllerSession
+}
+
+func injectionAccount(cfg config.Config, db *db.Postgres) *Account {
+	repoAccount := repo.NewAccount(cfg, db)
+	repoProfile := repo.NewProfile(cfg, db)
+	repoSession := repo.NewSession(cfg, db)
+	repoOutbox := repo.NewOutbox(cfg, db)
+	repoWebhook := repo.NewWebhook(cfg, db)
+	repoAuditLog := repo.NewAuditLog(cfg, db)
+	transactor := repo.NewTransactor(cfg, db)
+	usecaseAccount := usecase.NewAccount(cfg, repoAccount, repoProfile, repoSession, repoOutbox, repoWebhook, repoAuditLog, transactor)
+	controllerAccount := newAccount(cfg, usecaseAccount)
+	return controllerAccount
+}
+
+func injectionExport(cfg config.Config, db *db.Postgres) *Export {
+	repoProfile := repo.NewProfile(cfg, db)
+	repoSession := repo.NewSession(cfg, db)
+	repoAuditLog := repo.NewAuditLog(cfg, db)
+	usecaseExport := usecase.NewExport(cfg, repoProfile, repoSession, repoAuditLog)
+	controllerExport := newExport(cfg, usecaseExport)
+	return controllerExport
+}
+
+func injectionWebhook(cfg config.Config, db *db.Postgres) *Webhook {
+	repoWebhook := repo.NewWebhook(cfg, db)
+	repoProfile := repo.NewProfile(cfg, db)
+	repoSession := repo.NewSession(cfg, db)
+	transactor := repo.NewTransactor(cfg, db)
+	sender := webhook.NewSender(&http.Client{Timeout: time.Duration(cfg.Webhook.TimeoutSecond) * time.Second})
+	usecaseWebhook := usecase.NewWebhook(cfg, repoWebhook, repoSession, repoProfile, transactor, sender)
+	controllerWebhook := newWebhook(cfg, usecaseWebhook)
+	return controllerWebhook
+}
+
+func injectionAuditLog(cfg config.Config, db *db.Postgres) *AuditLog {
+	repoAuditLog := repo.NewAuditLog(cfg, db)
+	repoSession := repo.NewSession(cfg, db)
+	repoProfile := repo.NewProfile(cfg, db)
+	usecaseAuditLog := usecase.NewAuditLog(cfg, repoAuditLog, repoSession, repoProfile)
+	controllerAuditLog := newAuditLog(cfg, usecaseAuditLog)
+	return controllerAuditLog
+}
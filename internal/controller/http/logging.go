@@ -0,0 +1,66 @@
+package http
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Hidayathamir/go-user/internal/pkg/header"
+	"github.com/Hidayathamir/go-user/internal/pkg/logger"
+	"github.com/Hidayathamir/go-user/internal/pkg/requestid"
+	"github.com/Hidayathamir/go-user/pkg/gouser"
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+)
+
+// requestIDMiddleware take request id from X-Request-ID header, or generate
+// one if absent or invalid, put it in request context and echo it in
+// X-Request-ID response header.
+func requestIDMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := requestid.Resolve(c.GetHeader(header.RequestID))
+
+		c.Request = c.Request.WithContext(gouser.WithRequestID(c.Request.Context(), requestID))
+		c.Header(header.RequestID, requestID)
+
+		c.Next()
+	}
+}
+
+// accessLogMiddleware put log and request logger fields in request context,
+// then log the request once handled, with errors recorded by handler via
+// gin.Context.Error. Server error is logged as error, client error as warning.
+func accessLogMiddleware(log *logrus.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		route := c.FullPath()
+		if route == "" {
+			route = routeUnmatched
+		}
+		ctx := logger.WithLogger(c.Request.Context(), log)
+		c.Request = c.Request.WithContext(logger.NewContext(ctx, logrus.Fields{logger.FieldRoute: route}))
+
+		c.Next()
+
+		status := c.Writer.Status()
+		entry := logger.FromContext(c.Request.Context()).WithFields(logrus.Fields{
+			"method":     c.Request.Method,
+			"status":     status,
+			"latency_ms": time.Since(start).Milliseconds(),
+			"client_ip":  c.ClientIP(),
+		})
+		if len(c.Errors) > 0 {
+			entry = entry.WithField(logrus.ErrorKey, strings.Join(c.Errors.Errors(), "; "))
+		}
+
+		switch {
+		case status >= http.StatusInternalServerError:
+			entry.Error("http request")
+		case status >= http.StatusBadRequest:
+			entry.Warn("http request")
+		default:
+			entry.Info("http request")
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Hidayathamir/go-user/internal/pkg/header"
+	"github.com/Hidayathamir/go-user/internal/pkg/logger"
+	"github.com/Hidayathamir/go-user/pkg/gouser"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	logrustest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitRequestIDMiddleware(t *testing.T) {
+	t.Parallel()
+
+	gin.SetMode(gin.TestMode)
+
+	t.Run("request id from header should be put in context and echoed", func(t *testing.T) {
+		t.Parallel()
+
+		ginEngine := gin.New()
+		ginEngine.Use(requestIDMiddleware())
+
+		var handlerRequestID string
+		ginEngine.GET("request-id-test", func(c *gin.Context) {
+			handlerRequestID = gouser.RequestIDFromContext(c.Request.Context())
+		})
+
+		rr := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/request-id-test", nil)
+		req.Header.Set(header.RequestID, "req-from-client")
+		ginEngine.ServeHTTP(rr, req)
+
+		assert.Equal(t, "req-from-client", handlerRequestID)
+		assert.Equal(t, "req-from-client", rr.Header().Get(header.RequestID))
+	})
+	t.Run("request without request id should get generated one", func(t *testing.T) {
+		t.Parallel()
+
+		ginEngine := gin.New()
+		ginEngine.Use(requestIDMiddleware())
+		ginEngine.GET("request-id-test", func(*gin.Context) {})
+
+		rr := httptest.NewRecorder()
+		ginEngine.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/request-id-test", nil))
+
+		_, err := uuid.Parse(rr.Header().Get(header.RequestID))
+		require.NoError(t, err)
+	})
+	t.Run("error response should echo request id", func(t *testing.T) {
+		t.Parallel()
+
+		ginEngine := gin.New()
+		ginEngine.Use(requestIDMiddleware())
+		ginEngine.GET("request-id-test", func(c *gin.Context) {
+			c.JSON(http.StatusBadRequest, newResError(c, assert.AnError))
+		})
+
+		rr := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/request-id-test", nil)
+		req.Header.Set(header.RequestID, "req-error")
+		ginEngine.ServeHTTP(rr, req)
+
+		resBody := ResError{}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resBody))
+		assert.Equal(t, "req-error", resBody.RequestID)
+		assert.Equal(t, assert.AnError.Error(), resBody.Error)
+	})
+}
+
+func TestUnitAccessLogMiddleware(t *testing.T) {
+	t.Parallel()
+
+	gin.SetMode(gin.TestMode)
+
+	t.Run("handler error should be logged as warning with request fields", func(t *testing.T) {
+		t.Parallel()
+
+		log, hook := logrustest.NewNullLogger()
+		ginEngine := gin.New()
+		ginEngine.ContextWithFallback = true
+		ginEngine.Use(requestIDMiddleware(), accessLogMiddleware(log))
+		ginEngine.POST("access-log-test/:id", func(c *gin.Context) {
+			logger.AddFields(c, logrus.Fields{logger.FieldUserID: int64(99)})
+			c.JSON(http.StatusBadRequest, newResError(c, errors.New("usecase failed")))
+		})
+
+		req := httptest.NewRequest(http.MethodPost, "/access-log-test/1", nil)
+		req.Header.Set(header.RequestID, "req-access-log-warn")
+		ginEngine.ServeHTTP(httptest.NewRecorder(), req)
+
+		entry := hook.LastEntry()
+		require.NotNil(t, entry)
+		assert.Equal(t, "http request", entry.Message)
+		assert.Equal(t, "req-access-log-warn", entry.Data[logger.FieldRequestID])
+		assert.Equal(t, logrus.WarnLevel, entry.Level)
+		assert.Equal(t, "/access-log-test/:id", entry.Data[logger.FieldRoute])
+		assert.Equal(t, int64(99), entry.Data[logger.FieldUserID])
+		assert.Equal(t, http.StatusBadRequest, entry.Data["status"])
+		assert.Equal(t, "usecase failed", entry.Data[logrus.ErrorKey])
+	})
+	t.Run("success should be logged as info", func(t *testing.T) {
+		t.Parallel()
+
+		log, hook := logrustest.NewNullLogger()
+		ginEngine := gin.New()
+		ginEngine.Use(requestIDMiddleware(), accessLogMiddleware(log))
+		ginEngine.GET("access-log-test", func(c *gin.Context) {
+			c.Status(http.StatusOK)
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/access-log-test", nil)
+		req.Header.Set(header.RequestID, "req-access-log-info")
+		ginEngine.ServeHTTP(httptest.NewRecorder(), req)
+
+		entry := hook.LastEntry()
+		require.NotNil(t, entry)
+		assert.Equal(t, "http request", entry.Message)
+		assert.Equal(t, "req-access-log-info", entry.Data[logger.FieldRequestID])
+		assert.Equal(t, logrus.InfoLevel, entry.Level)
+		assert.NotContains(t, entry.Data, logrus.ErrorKey)
+	})
+}
@@ -0,0 +1,64 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Hidayathamir/go-user/config"
+	"github.com/Hidayathamir/go-user/internal/pkg/metrics"
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnitMetricsMiddleware(t *testing.T) {
+	t.Parallel()
+
+	gin.SetMode(gin.TestMode)
+
+	t.Run("request should be counted by route template and status", func(t *testing.T) {
+		t.Parallel()
+
+		ginEngine := gin.New()
+		ginEngine.Use(metricsMiddleware())
+		ginEngine.GET("metrics-test/:id", func(c *gin.Context) {
+			c.Status(http.StatusTeapot)
+		})
+
+		for _, path := range []string{"/metrics-test/1", "/metrics-test/2"} {
+			ginEngine.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+		}
+
+		counter := metrics.HTTPRequestsTotal.WithLabelValues(http.MethodGet, "/metrics-test/:id", "418")
+		assert.Equal(t, float64(2), testutil.ToFloat64(counter))
+	})
+	t.Run("unmatched request should be counted as unmatched route", func(t *testing.T) {
+		t.Parallel()
+
+		ginEngine := gin.New()
+		ginEngine.Use(metricsMiddleware())
+
+		ginEngine.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPatch, "/unknown/path", nil))
+
+		counter := metrics.HTTPRequestsTotal.WithLabelValues(http.MethodPatch, routeUnmatched, "404")
+		assert.Equal(t, float64(1), testutil.ToFloat64(counter))
+	})
+}
+
+func TestUnitNewMetricsServer(t *testing.T) {
+	t.Parallel()
+
+	t.Run("metrics path should serve prometheus metrics", func(t *testing.T) {
+		t.Parallel()
+
+		server := NewMetricsServer(config.Config{Metrics: config.Metrics{Host: "localhost", Port: 12000}})
+
+		rr := httptest.NewRecorder()
+		server.Handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+		assert.Equal(t, "localhost:12000", server.Addr)
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Contains(t, rr.Body.String(), "go_goroutines")
+	})
+}
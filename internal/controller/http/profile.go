@@ -3,6 +3,7 @@ package http
 import (
 	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/Hidayathamir/go-user/config"
 	"github.com/Hidayathamir/go-user/internal/pkg/header"
@@ -30,30 +31,106 @@ func (p *Profile) getProfileByUsername(c *gin.Context) {
 	user, err := p.usecaseProfile.GetProfileByUsername(c, req)
 	if err != nil {
 		err := fmt.Errorf("Profile.usecaseProfile.GetProfileByUsername: %w", err)
-		c.JSON(http.StatusBadRequest, ResError{Error: err.Error()})
+		c.JSON(http.StatusBadRequest, newResError(c, err))
 		return
 	}
 
 	c.JSON(http.StatusOK, ResGetProfileByUsername{Data: user})
 }
 
+func (p *Profile) getProfileByUserID(c *gin.Context) {
+	userID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		err := fmt.Errorf("strconv.ParseInt: %w", err)
+		c.JSON(http.StatusBadRequest, newResError(c, err))
+		return
+	}
+
+	req := gouser.ReqGetProfileByUserID{UserID: userID}
+
+	user, err := p.usecaseProfile.GetProfileByUserID(c, req)
+	if err != nil {
+		err := fmt.Errorf("Profile.usecaseProfile.GetProfileByUserID: %w", err)
+		c.JSON(http.StatusBadRequest, newResError(c, err))
+		return
+	}
+
+	c.JSON(http.StatusOK, ResGetProfileByUsername{Data: user})
+}
+
+func (p *Profile) getMyProfile(c *gin.Context) {
+	req := gouser.ReqGetMyProfile{UserJWT: c.GetHeader(header.Authorization)}
+
+	user, err := p.usecaseProfile.GetMyProfile(c, req)
+	if err != nil {
+		err := fmt.Errorf("Profile.usecaseProfile.GetMyProfile: %w", err)
+		c.JSON(http.StatusBadRequest, newResError(c, err))
+		return
+	}
+
+	c.JSON(http.StatusOK, ResGetMyProfile{Data: user})
+}
+
 func (p *Profile) updateProfileByUserID(c *gin.Context) {
 	req := gouser.ReqUpdateProfileByUserID{}
 	err := c.ShouldBindJSON(&req)
 	if err != nil {
 		err := fmt.Errorf("gin.Context.ShouldBindJSON: %w", err)
-		c.JSON(http.StatusBadRequest, ResError{Error: err.Error()})
+		c.JSON(http.StatusBadRequest, newResError(c, err))
 		return
 	}
 
 	req.UserJWT = c.GetHeader(header.Authorization)
+	req.UserAgent = c.Request.UserAgent()
+	req.IP = c.ClientIP()
+	req.RequestID = gouser.RequestIDFromContext(c.Request.Context())
 
 	err = p.usecaseProfile.UpdateProfileByUserID(c, req)
 	if err != nil {
 		err := fmt.Errorf("Profile.usecaseProfile.UpdateProfileByUserID: %w", err)
-		c.JSON(http.StatusBadRequest, ResError{Error: err.Error()})
+		c.JSON(http.StatusBadRequest, newResError(c, err))
 		return
 	}
 
 	c.JSON(http.StatusOK, ResString{Data: "ok"})
 }
+
+func (p *Profile) listUsers(c *gin.Context) {
+	req := gouser.ReqListUsers{}
+	err := c.ShouldBindQuery(&req)
+	if err != nil {
+		err := fmt.Errorf("gin.Context.ShouldBindQuery: %w", err)
+		c.JSON(http.StatusBadRequest, newResError(c, err))
+		return
+	}
+
+	req.UserJWT = c.GetHeader(header.Authorization)
+
+	res, err := p.usecaseProfile.ListUsers(c, req)
+	if err != nil {
+		err := fmt.Errorf("Profile.usecaseProfile.ListUsers: %w", err)
+		c.JSON(http.StatusBadRequest, newResError(c, err))
+		return
+	}
+
+	c.JSON(http.StatusOK, ResListUsers{Data: res})
+}
+
+func (p *Profile) batchGetProfiles(c *gin.Context) {
+	req := gouser.ReqBatchGetProfiles{}
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		err := fmt.Errorf("gin.Context.ShouldBindJSON: %w", err)
+		c.JSON(http.StatusBadRequest, newResError(c, err))
+		return
+	}
+
+	res, err := p.usecaseProfile.BatchGetProfiles(c, req)
+	if err != nil {
+		err := fmt.Errorf("Profile.usecaseProfile.BatchGetProfiles: %w", err)
+		c.JSON(http.StatusBadRequest, newResError(c, err))
+		return
+	}
+
+	c.JSON(http.StatusOK, ResBatchGetProfiles{Data: res})
+}
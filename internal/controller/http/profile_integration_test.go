@@ -28,10 +28,12 @@ func TestIntegrationProfileUpdateProfileByUserID(t *testing.T) {
 
 		repoAuth := repo.NewAuth(cfg, pg)
 		repoProfile := repo.NewProfile(cfg, pg)
-		usecaseAuth := usecase.NewAuth(cfg, repoAuth, repoProfile)
+		repoSession := repo.NewSession(cfg, pg)
+		repoAccount := repo.NewAccount(cfg, pg)
+		usecaseAuth := usecase.NewAuth(cfg, repoAuth, repoProfile, repoSession, repoAccount, repo.NewAuditLog(cfg, pg), repo.NewTransactor(cfg, pg))
 		controllerAuth := newAuth(cfg, usecaseAuth)
 
-		usecaseProfile := usecase.NewProfile(cfg, repoProfile)
+		usecaseProfile := usecase.NewProfile(cfg, repoProfile, repoSession, repo.NewOutbox(cfg, pg), repo.NewAuditLog(cfg, pg), repo.NewTransactor(cfg, pg))
 		controllerProfile := newProfile(cfg, usecaseProfile)
 
 		gin.SetMode(gin.TestMode)
@@ -77,7 +79,10 @@ func TestIntegrationProfileUpdateProfileByUserID(t *testing.T) {
 		require.NoError(t, err)
 
 		repoProfile := repo.NewProfile(cfg, pg)
-		usecaseProfile := usecase.NewProfile(cfg, repoProfile)
+
+		repoSession := repo.NewSession(cfg, pg)
+		repoAccount := repo.NewAccount(cfg, pg)
+		usecaseProfile := usecase.NewProfile(cfg, repoProfile, repoSession, repo.NewOutbox(cfg, pg), repo.NewAuditLog(cfg, pg), repo.NewTransactor(cfg, pg))
 		controllerProfile := newProfile(cfg, usecaseProfile)
 
 		gin.SetMode(gin.TestMode)
@@ -102,7 +107,7 @@ func TestIntegrationProfileUpdateProfileByUserID(t *testing.T) {
 		})
 		t.Run("request password empty should error", func(t *testing.T) {
 			repoAuth := repo.NewAuth(cfg, pg)
-			usecaseAuth := usecase.NewAuth(cfg, repoAuth, repoProfile)
+			usecaseAuth := usecase.NewAuth(cfg, repoAuth, repoProfile, repoSession, repoAccount, repo.NewAuditLog(cfg, pg), repo.NewTransactor(cfg, pg))
 			controllerAuth := newAuth(cfg, usecaseAuth)
 
 			username := uuid.NewString()
@@ -133,10 +138,12 @@ func TestIntegrationProfileGetProfileByUsername(t *testing.T) {
 
 		repoAuth := repo.NewAuth(cfg, pg)
 		repoProfile := repo.NewProfile(cfg, pg)
-		usecaseAuth := usecase.NewAuth(cfg, repoAuth, repoProfile)
+		repoSession := repo.NewSession(cfg, pg)
+		repoAccount := repo.NewAccount(cfg, pg)
+		usecaseAuth := usecase.NewAuth(cfg, repoAuth, repoProfile, repoSession, repoAccount, repo.NewAuditLog(cfg, pg), repo.NewTransactor(cfg, pg))
 		controllerAuth := newAuth(cfg, usecaseAuth)
 
-		usecaseProfile := usecase.NewProfile(cfg, repoProfile)
+		usecaseProfile := usecase.NewProfile(cfg, repoProfile, repoSession, repo.NewOutbox(cfg, pg), repo.NewAuditLog(cfg, pg), repo.NewTransactor(cfg, pg))
 		controllerProfile := newProfile(cfg, usecaseProfile)
 
 		gin.SetMode(gin.TestMode)
@@ -162,7 +169,9 @@ func TestIntegrationProfileGetProfileByUsername(t *testing.T) {
 		require.NoError(t, err)
 
 		repoProfile := repo.NewProfile(cfg, pg)
-		usecaseProfile := usecase.NewProfile(cfg, repoProfile)
+
+		repoSession := repo.NewSession(cfg, pg)
+		usecaseProfile := usecase.NewProfile(cfg, repoProfile, repoSession, repo.NewOutbox(cfg, pg), repo.NewAuditLog(cfg, pg), repo.NewTransactor(cfg, pg))
 		controllerProfile := newProfile(cfg, usecaseProfile)
 
 		gin.SetMode(gin.TestMode)
@@ -13,3 +13,21 @@ type ResGetProfileByUsername struct {
 	Data  gouser.ResGetProfileByUsername `json:"data"`
 	Error any                            `json:"error"`
 }
+
+// ResListUsers -.
+type ResListUsers struct {
+	Data  gouser.ResListUsers `json:"data"`
+	Error any                 `json:"error"`
+}
+
+// ResBatchGetProfiles -.
+type ResBatchGetProfiles struct {
+	Data  gouser.ResBatchGetProfiles `json:"data"`
+	Error any                        `json:"error"`
+}
+
+// ResGetMyProfile -.
+type ResGetMyProfile struct {
+	Data  gouser.ResGetMyProfile `json:"data"`
+	Error any                    `json:"error"`
+}
@@ -126,12 +126,16 @@ func TestProfileUpdateProfileByUserID(t *testing.T) {
 		})
 		req := httptest.NewRequest(http.MethodGet, "/", bytes.NewReader(reqBody))
 		req.Header.Set(header.Authorization, "Bearer dummyUserJWT")
-		ctx.Request = req
+		req.Header.Set("User-Agent", "Mozilla/5.0")
+		ctx.Request = req.WithContext(gouser.WithRequestID(req.Context(), "req-1"))
 
 		usecaseProfile.EXPECT().
 			UpdateProfileByUserID(gomock.Any(), gouser.ReqUpdateProfileByUserID{
-				UserJWT:  "Bearer dummyUserJWT",
-				Password: "newpassword",
+				UserJWT:   "Bearer dummyUserJWT",
+				Password:  "newpassword",
+				UserAgent: "Mozilla/5.0",
+				IP:        "192.0.2.1", // httptest.NewRequest remote address.
+				RequestID: "req-1",
 			}).Return(nil)
 
 		p.updateProfileByUserID(ctx)
@@ -163,12 +167,16 @@ func TestProfileUpdateProfileByUserID(t *testing.T) {
 		})
 		req := httptest.NewRequest(http.MethodGet, "/", bytes.NewReader(reqBody))
 		req.Header.Set(header.Authorization, "Bearer dummyUserJWT")
-		ctx.Request = req
+		req.Header.Set("User-Agent", "Mozilla/5.0")
+		ctx.Request = req.WithContext(gouser.WithRequestID(req.Context(), "req-1"))
 
 		usecaseProfile.EXPECT().
 			UpdateProfileByUserID(gomock.Any(), gouser.ReqUpdateProfileByUserID{
-				UserJWT:  "Bearer dummyUserJWT",
-				Password: "newpassword",
+				UserJWT:   "Bearer dummyUserJWT",
+				Password:  "newpassword",
+				UserAgent: "Mozilla/5.0",
+				IP:        "192.0.2.1", // httptest.NewRequest remote address.
+				RequestID: "req-1",
 			}).Return(assert.AnError)
 
 		p.updateProfileByUserID(ctx)
@@ -181,3 +189,330 @@ func TestProfileUpdateProfileByUserID(t *testing.T) {
 		assert.Contains(t, resBody.Error, assert.AnError.Error())
 	})
 }
+
+func TestUnitProfileListUsers(t *testing.T) {
+	t.Parallel()
+
+	gin.SetMode(gin.TestMode)
+
+	t.Run("call usecase ListUsers success should return success", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		usecaseProfile := mockusecase.NewMockIProfile(ctrl)
+
+		p := &Profile{
+			cfg:            config.Config{},
+			usecaseProfile: usecaseProfile,
+		}
+
+		rr := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(rr)
+		req := httptest.NewRequest(http.MethodGet, "/?limit=2&status=active&username_prefix=hid&created_from=2024-01-02T03:04:05Z&sort_by=created_at&sort_order=desc&with_total_count=true&cursor=abc", nil)
+		req.Header.Set(header.Authorization, "Bearer dummyAdminJWT")
+		ctx.Request = req
+
+		createdFrom := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+		totalCount := int64(3)
+		resListUsers := gouser.ResListUsers{
+			Users:      []gouser.User{{ID: 9, Username: "hidayat", Status: "active"}},
+			NextCursor: "def",
+			TotalCount: &totalCount,
+		}
+		usecaseProfile.EXPECT().
+			ListUsers(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ any, got gouser.ReqListUsers) (gouser.ResListUsers, error) {
+				assert.Equal(t, "Bearer dummyAdminJWT", got.UserJWT)
+				assert.Equal(t, "abc", got.Cursor)
+				assert.Equal(t, 2, got.Limit)
+				assert.Equal(t, "active", got.Status)
+				assert.Equal(t, "hid", got.UsernamePrefix)
+				require.NotNil(t, got.CreatedFrom)
+				assert.True(t, createdFrom.Equal(*got.CreatedFrom))
+				assert.Nil(t, got.CreatedTo)
+				assert.Equal(t, gouser.UserSortByCreatedAt, got.SortBy)
+				assert.Equal(t, gouser.SortOrderDesc, got.SortOrder)
+				assert.True(t, got.WithTotalCount)
+				return resListUsers, nil
+			})
+
+		p.listUsers(ctx)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		resBody := ResListUsers{}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resBody))
+		assert.Equal(t, resListUsers, resBody.Data)
+		assert.Nil(t, resBody.Error)
+	})
+	t.Run("invalid query should return error", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		usecaseProfile := mockusecase.NewMockIProfile(ctrl)
+
+		p := &Profile{
+			cfg:            config.Config{},
+			usecaseProfile: usecaseProfile,
+		}
+
+		rr := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(rr)
+		req := httptest.NewRequest(http.MethodGet, "/?limit=abc", nil)
+		ctx.Request = req
+
+		p.listUsers(ctx)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		resBody := ResError{}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resBody))
+		assert.NotEmpty(t, resBody.Error)
+	})
+	t.Run("call usecase ListUsers error should return error", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		usecaseProfile := mockusecase.NewMockIProfile(ctrl)
+
+		p := &Profile{
+			cfg:            config.Config{},
+			usecaseProfile: usecaseProfile,
+		}
+
+		rr := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(rr)
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set(header.Authorization, "Bearer dummyAdminJWT")
+		ctx.Request = req
+
+		usecaseProfile.EXPECT().
+			ListUsers(gomock.Any(), gouser.ReqListUsers{UserJWT: "Bearer dummyAdminJWT"}).
+			Return(gouser.ResListUsers{}, assert.AnError)
+
+		p.listUsers(ctx)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		resBody := ResError{}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resBody))
+		assert.Contains(t, resBody.Error, assert.AnError.Error())
+	})
+}
+
+func TestUnitProfileBatchGetProfiles(t *testing.T) {
+	t.Parallel()
+
+	gin.SetMode(gin.TestMode)
+
+	t.Run("call usecase BatchGetProfiles success should return success", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		usecaseProfile := mockusecase.NewMockIProfile(ctrl)
+
+		p := &Profile{
+			cfg:            config.Config{},
+			usecaseProfile: usecaseProfile,
+		}
+
+		rr := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(rr)
+		reqBody := gouser.ReqBatchGetProfiles{UserIDs: []int64{1, 2}, Usernames: []string{"hidayat"}}
+		reqBodyByte, err := json.Marshal(reqBody)
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodPost, "/", bytes.NewBuffer(reqBodyByte))
+		ctx.Request = req
+
+		resBatchGetProfiles := gouser.ResBatchGetProfiles{
+			Profiles:         []gouser.ResGetProfileByUsername{{ID: 1, Username: "hidayat1"}, {ID: 3, Username: "hidayat"}},
+			MissingUserIDs:   []int64{2},
+			MissingUsernames: []string{},
+		}
+		usecaseProfile.EXPECT().
+			BatchGetProfiles(gomock.Any(), reqBody).
+			Return(resBatchGetProfiles, nil)
+
+		p.batchGetProfiles(ctx)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		resBody := ResBatchGetProfiles{}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resBody))
+		assert.Equal(t, resBatchGetProfiles, resBody.Data)
+		assert.Nil(t, resBody.Error)
+	})
+	t.Run("call usecase BatchGetProfiles error should return error", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		usecaseProfile := mockusecase.NewMockIProfile(ctrl)
+
+		p := &Profile{
+			cfg:            config.Config{},
+			usecaseProfile: usecaseProfile,
+		}
+
+		rr := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(rr)
+		req := httptest.NewRequest(http.MethodPost, "/", bytes.NewBufferString(`{"user_ids":[1]}`))
+		ctx.Request = req
+
+		usecaseProfile.EXPECT().
+			BatchGetProfiles(gomock.Any(), gouser.ReqBatchGetProfiles{UserIDs: []int64{1}}).
+			Return(gouser.ResBatchGetProfiles{}, assert.AnError)
+
+		p.batchGetProfiles(ctx)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		resBody := ResError{}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resBody))
+		assert.Contains(t, resBody.Error, assert.AnError.Error())
+	})
+}
+
+func TestUnitProfileGetProfileByUserID(t *testing.T) {
+	t.Parallel()
+
+	gin.SetMode(gin.TestMode)
+
+	t.Run("call usecase GetProfileByUserID success should return success", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		usecaseProfile := mockusecase.NewMockIProfile(ctrl)
+
+		p := &Profile{
+			cfg:            config.Config{},
+			usecaseProfile: usecaseProfile,
+		}
+
+		rr := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(rr)
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		ctx.Request = req
+		ctx.Params = append(ctx.Params, gin.Param{
+			Key:   "id",
+			Value: "23",
+		})
+
+		user := gouser.ResGetProfileByUsername{ID: 23, Username: "hidayat"}
+		usecaseProfile.EXPECT().
+			GetProfileByUserID(gomock.Any(), gouser.ReqGetProfileByUserID{UserID: 23}).
+			Return(user, nil)
+
+		p.getProfileByUserID(ctx)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		resBody := ResGetProfileByUsername{}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resBody))
+		assert.Equal(t, user, resBody.Data)
+		assert.Nil(t, resBody.Error)
+	})
+	t.Run("invalid user id should return error", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		usecaseProfile := mockusecase.NewMockIProfile(ctrl)
+
+		p := &Profile{
+			cfg:            config.Config{},
+			usecaseProfile: usecaseProfile,
+		}
+
+		rr := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(rr)
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		ctx.Request = req
+		ctx.Params = append(ctx.Params, gin.Param{
+			Key:   "id",
+			Value: "abc",
+		})
+
+		p.getProfileByUserID(ctx)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		resBody := ResError{}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resBody))
+		assert.NotEmpty(t, resBody.Error)
+	})
+}
+
+func TestUnitProfileGetMyProfile(t *testing.T) {
+	t.Parallel()
+
+	gin.SetMode(gin.TestMode)
+
+	t.Run("call usecase GetMyProfile success should return success", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		usecaseProfile := mockusecase.NewMockIProfile(ctrl)
+
+		p := &Profile{
+			cfg:            config.Config{},
+			usecaseProfile: usecaseProfile,
+		}
+
+		rr := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(rr)
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set(header.Authorization, "Bearer dummyUserJWT")
+		ctx.Request = req
+
+		user := gouser.ResGetMyProfile{ID: 23, Username: "hidayat", Role: "user", Status: "active"}
+		usecaseProfile.EXPECT().
+			GetMyProfile(gomock.Any(), gouser.ReqGetMyProfile{UserJWT: "Bearer dummyUserJWT"}).
+			Return(user, nil)
+
+		p.getMyProfile(ctx)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		resBody := ResGetMyProfile{}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resBody))
+		assert.Equal(t, user, resBody.Data)
+		assert.Nil(t, resBody.Error)
+	})
+	t.Run("call usecase GetMyProfile error should return error", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		usecaseProfile := mockusecase.NewMockIProfile(ctrl)
+
+		p := &Profile{
+			cfg:            config.Config{},
+			usecaseProfile: usecaseProfile,
+		}
+
+		rr := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(rr)
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set(header.Authorization, "Bearer dummyUserJWT")
+		ctx.Request = req
+
+		usecaseProfile.EXPECT().
+			GetMyProfile(gomock.Any(), gouser.ReqGetMyProfile{UserJWT: "Bearer dummyUserJWT"}).
+			Return(gouser.ResGetMyProfile{}, assert.AnError)
+
+		p.getMyProfile(ctx)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		resBody := ResError{}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resBody))
+		assert.Contains(t, resBody.Error, assert.AnError.Error())
+	})
+}
@@ -0,0 +1,48 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/Hidayathamir/go-user/internal/pkg/header"
+	"github.com/Hidayathamir/go-user/internal/pkg/logger"
+	"github.com/Hidayathamir/go-user/internal/pkg/metrics"
+	"github.com/Hidayathamir/go-user/internal/pkg/ratelimit"
+	"github.com/Hidayathamir/go-user/pkg/gouser"
+	"github.com/gin-gonic/gin"
+)
+
+// rateLimitMiddleware take a token from bucket of the client in group, client
+// is identified by X-API-Key header, Authorization header JWT or ip by group
+// rule. Rate limited request gets 429 with Retry-After header. Request is let
+// through if limiter store fails, so shared store outage does not take the API
+// down.
+func rateLimitMiddleware(limiter *ratelimit.Limiter, group string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		res, err := limiter.Allow(c.Request.Context(), group, ratelimit.Client{
+			IP:      c.ClientIP(),
+			APIKey:  c.GetHeader(header.APIKey),
+			UserJWT: c.GetHeader(header.Authorization),
+		})
+		if err != nil {
+			logger.FromContext(c.Request.Context()).WithError(err).Warn("rate limiter failed, request is let through")
+			c.Next()
+			return
+		}
+
+		if res.Limit > 0 {
+			c.Header(header.RateLimitLimit, strconv.Itoa(res.Limit))
+			c.Header(header.RateLimitRemaining, strconv.Itoa(res.Remaining))
+			c.Header(header.RateLimitReset, strconv.Itoa(res.ResetSecond()))
+		}
+
+		if !res.Allowed {
+			metrics.RateLimitedTotal.WithLabelValues(group).Inc()
+			c.Header(header.RetryAfter, strconv.Itoa(res.RetryAfterSecond()))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, newResError(c, gouser.ErrTooManyRequests))
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,144 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Hidayathamir/go-user/config"
+	"github.com/Hidayathamir/go-user/internal/pkg/header"
+	"github.com/Hidayathamir/go-user/internal/pkg/health"
+	"github.com/Hidayathamir/go-user/internal/pkg/ratelimit"
+	"github.com/Hidayathamir/go-user/internal/repo/db"
+	"github.com/Hidayathamir/go-user/pkg/gouser"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type errorStore struct{}
+
+func (errorStore) Take(context.Context, string, ratelimit.Rule, time.Time) (ratelimit.Result, error) {
+	return ratelimit.Result{}, errors.New("store down")
+}
+
+func TestUnitRateLimitMiddleware(t *testing.T) {
+	t.Parallel()
+
+	gin.SetMode(gin.TestMode)
+
+	cfg := config.Config{RateLimit: config.RateLimit{
+		Enabled: true,
+		Auth:    config.RateLimitRule{RequestsPerSecond: 0.1, Burst: 2, Key: config.RateLimitKeyIP},
+	}}
+
+	newEngine := func(store ratelimit.Store) *gin.Engine {
+		ginEngine := gin.New()
+		ginEngine.POST("login", rateLimitMiddleware(ratelimit.NewLimiter(cfg, store), ratelimit.GroupAuth), func(c *gin.Context) {
+			c.Status(http.StatusOK)
+		})
+		return ginEngine
+	}
+
+	t.Run("request over burst should get 429 with rate limit headers", func(t *testing.T) {
+		t.Parallel()
+
+		ginEngine := newEngine(ratelimit.NewMemoryStore())
+
+		var rr *httptest.ResponseRecorder
+		for _, wantStatus := range []int{http.StatusOK, http.StatusOK, http.StatusTooManyRequests} {
+			rr = httptest.NewRecorder()
+			ginEngine.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/login", nil))
+			assert.Equal(t, wantStatus, rr.Code)
+		}
+
+		assert.Equal(t, "2", rr.Header().Get(header.RateLimitLimit))
+		assert.Equal(t, "0", rr.Header().Get(header.RateLimitRemaining))
+		assert.Equal(t, "20", rr.Header().Get(header.RateLimitReset))
+		assert.Equal(t, "10", rr.Header().Get(header.RetryAfter))
+
+		resBody := ResError{}
+		err := json.NewDecoder(rr.Body).Decode(&resBody)
+		require.NoError(t, err)
+		assert.Equal(t, gouser.ErrTooManyRequests.Error(), resBody.Error)
+	})
+	t.Run("allowed request should get remaining tokens", func(t *testing.T) {
+		t.Parallel()
+
+		ginEngine := newEngine(ratelimit.NewMemoryStore())
+
+		rr := httptest.NewRecorder()
+		ginEngine.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/login", nil))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "1", rr.Header().Get(header.RateLimitRemaining))
+		assert.Empty(t, rr.Header().Get(header.RetryAfter))
+	})
+	t.Run("store error should let request through", func(t *testing.T) {
+		t.Parallel()
+
+		ginEngine := newEngine(errorStore{})
+
+		rr := httptest.NewRecorder()
+		ginEngine.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/login", nil))
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Empty(t, rr.Header().Get(header.RateLimitLimit))
+	})
+}
+
+func TestUnitNewServerTrustedProxies(t *testing.T) {
+	t.Parallel()
+
+	gin.SetMode(gin.TestMode)
+
+	// loginStatuses return status of login requests sent from 192.0.2.1 with
+	// each X-Forwarded-For, by server trusting trustedProxies.
+	loginStatuses := func(t *testing.T, trustedProxies []string, forwardedFors ...string) []int {
+		t.Helper()
+
+		cfg := config.Config{
+			HTTP: config.HTTP{TrustedProxies: trustedProxies},
+			JWT:  config.JWT{ExpireHour: 1, SignedKey: "secretjwtkey"},
+			RateLimit: config.RateLimit{
+				Enabled: true,
+				Auth:    config.RateLimitRule{RequestsPerSecond: 0.1, Burst: 1, Key: config.RateLimitKeyIP},
+			},
+		}
+		server, err := NewServer(cfg, &db.Postgres{}, health.NewChecker())
+		require.NoError(t, err)
+
+		statuses := []int{}
+		for _, forwardedFor := range forwardedFors {
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/login", nil)
+			req.RemoteAddr = "192.0.2.1:40000"
+			req.Header.Set("X-Forwarded-For", forwardedFor)
+
+			rr := httptest.NewRecorder()
+			server.Handler.ServeHTTP(rr, req)
+			statuses = append(statuses, rr.Code)
+		}
+		return statuses
+	}
+
+	t.Run("spoofed X-Forwarded-For should share bucket of the sender ip", func(t *testing.T) {
+		t.Parallel()
+
+		statuses := loginStatuses(t, nil, "198.51.100.1", "198.51.100.2")
+
+		assert.NotEqual(t, http.StatusTooManyRequests, statuses[0])
+		assert.Equal(t, http.StatusTooManyRequests, statuses[1])
+	})
+	t.Run("X-Forwarded-For of trusted proxy should get bucket per client", func(t *testing.T) {
+		t.Parallel()
+
+		statuses := loginStatuses(t, []string{"192.0.2.0/24"}, "198.51.100.1", "198.51.100.2")
+
+		assert.NotEqual(t, http.StatusTooManyRequests, statuses[0])
+		assert.NotEqual(t, http.StatusTooManyRequests, statuses[1])
+	})
+}
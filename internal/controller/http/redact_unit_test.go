@@ -0,0 +1,116 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/Hidayathamir/go-user/config"
+	"github.com/Hidayathamir/go-user/internal/pkg/auth"
+	"github.com/Hidayathamir/go-user/internal/pkg/header"
+	"github.com/Hidayathamir/go-user/internal/pkg/health"
+	"github.com/Hidayathamir/go-user/internal/pkg/logger"
+	"github.com/Hidayathamir/go-user/internal/repo/db"
+	"github.com/Masterminds/squirrel"
+	"github.com/gin-gonic/gin"
+	"github.com/pashagolub/pgxmock/v3"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// formattedLogHook capture every log entry formatted by text and json
+// formatter, as it would be written.
+type formattedLogHook struct {
+	mu    sync.Mutex
+	lines []string
+}
+
+func (h *formattedLogHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (h *formattedLogHook) Fire(entry *logrus.Entry) error {
+	text, err := (&logrus.TextFormatter{}).Format(entry)
+	if err != nil {
+		return err
+	}
+	jsonLine, err := (&logrus.JSONFormatter{}).Format(entry)
+	if err != nil {
+		return err
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.lines = append(h.lines, string(text), string(jsonLine))
+
+	return nil
+}
+
+func (h *formattedLogHook) String() string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return strings.Join(h.lines, "")
+}
+
+func TestUnitNoSecretInLogs(t *testing.T) {
+	t.Parallel()
+
+	gin.SetMode(gin.TestMode)
+
+	logHook := &formattedLogHook{}
+	log := logrus.New()
+	log.SetOutput(io.Discard)
+	log.AddHook(logger.NewRedactHook())
+	log.AddHook(logHook)
+
+	mockpool, err := pgxmock.NewPool()
+	require.NoError(t, err)
+	pg := &db.Postgres{Builder: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar), Pool: mockpool}
+
+	cfg := config.Config{JWT: config.JWT{ExpireHour: 1, SignedKey: "secretjwtkey"}}
+	server, err := newServer(cfg, pg, health.NewChecker(), log)
+	require.NoError(t, err)
+	ginEngine, ok := server.Handler.(*gin.Engine)
+	require.True(t, ok)
+
+	const password = "pl41nt3xt-p4ssw0rd"
+	const secret = "w3bh00k-s1gn1ng-s3cr3t"
+	userJWT := auth.GenerateUserJWTToken(99, "jti-redact-test", cfg)
+
+	reqBody, err := json.Marshal(map[string]any{
+		"username":     "hidayat",
+		"password":     password,
+		"new_password": password,
+		"secret":       secret,
+		"user_jwt":     userJWT,
+		"url":          "https://example.com/webhook",
+		"event_types":  []string{"user.registered"},
+		"status":       "suspended",
+		"user_ids":     []int64{1},
+	})
+	require.NoError(t, err)
+
+	routes := ginEngine.Routes()
+	require.NotEmpty(t, routes)
+
+	for _, route := range routes {
+		path := strings.NewReplacer(":username", "hidayat", ":id", "1").Replace(route.Path)
+
+		req := httptest.NewRequest(route.Method, path+"?password="+password, bytes.NewReader(reqBody))
+		req.Header.Set(header.ContentType, header.AppJSON)
+		req.Header.Set(header.Authorization, userJWT)
+
+		ginEngine.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	logs := logHook.String()
+	assert.NotEmpty(t, logs)
+	assert.NotContains(t, logs, password)
+	assert.NotContains(t, logs, secret)
+	assert.NotContains(t, logs, userJWT)
+}
@@ -1,9 +1,23 @@
 package http
 
-// ResError -.
+import (
+	"github.com/Hidayathamir/go-user/pkg/gouser"
+	"github.com/gin-gonic/gin"
+)
+
+// ResError -. RequestID echoes request id, so client can report failed
+// request.
 type ResError struct {
-	Data  any    `json:"data"`
-	Error string `json:"error"`
+	Data      any    `json:"data"`
+	Error     string `json:"error"`
+	RequestID string `json:"request_id,omitempty"`
+}
+
+// newResError record err on c to be logged by access log, return ResError of
+// err echoing request id.
+func newResError(c *gin.Context, err error) ResError {
+	_ = c.Error(err)
+	return ResError{Error: err.Error(), RequestID: gouser.RequestIDFromContext(c.Request.Context())}
 }
 
 // ResString -.
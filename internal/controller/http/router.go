@@ -2,6 +2,8 @@ package http
 
 import (
 	"github.com/Hidayathamir/go-user/config"
+	"github.com/Hidayathamir/go-user/internal/pkg/health"
+	"github.com/Hidayathamir/go-user/internal/pkg/ratelimit"
 	"github.com/Hidayathamir/go-user/internal/repo/db"
 	"github.com/gin-gonic/gin"
 )
@@ -9,25 +11,63 @@ import (
 // This file contains all available routers. It can be useful when you want to
 // search for the API you want to debug. Think of it like an index in a dictionary.
 
-func registerRouter(cfg config.Config, ginEngine *gin.Engine, db *db.Postgres) {
+func registerRouter(cfg config.Config, ginEngine *gin.Engine, db *db.Postgres, checker *health.Checker, limiter *ratelimit.Limiter) {
+	cHealth := newHealth(checker)
+
 	ginEngine.GET("ping", ping)
+	ginEngine.GET("healthz", cHealth.healthz)
+	ginEngine.GET("readyz", cHealth.readyz)
 
-	registerRouterV1(cfg, ginEngine.Group("api/v1"), db)
+	registerRouterV1(cfg, ginEngine.Group("api/v1"), db, limiter)
 }
 
-func registerRouterV1(cfg config.Config, routerV1 *gin.RouterGroup, db *db.Postgres) {
+func registerRouterV1(cfg config.Config, routerV1 *gin.RouterGroup, db *db.Postgres, limiter *ratelimit.Limiter) {
 	cAuth := injectionAuth(cfg, db)
 	cProfile := injectionProfile(cfg, db)
+	cSession := injectionSession(cfg, db)
+	cAccount := injectionAccount(cfg, db)
+	cExport := injectionExport(cfg, db)
+	cWebhook := injectionWebhook(cfg, db)
+	cAuditLog := injectionAuditLog(cfg, db)
 
-	authGroup := routerV1.Group("auth")
+	authGroup := routerV1.Group("auth", rateLimitMiddleware(limiter, ratelimit.GroupAuth))
 	{
 		authGroup.POST("login", cAuth.loginUser)
 		authGroup.POST("register", cAuth.registerUser)
+		authGroup.POST("restore", cAccount.restoreAccount)
 	}
 
-	userGroup := routerV1.Group("users")
+	userGroup := routerV1.Group("users", rateLimitMiddleware(limiter, ratelimit.GroupUsers))
 	{
+		userGroup.GET("", cProfile.listUsers)
 		userGroup.GET(":username", cProfile.getProfileByUsername)
+		userGroup.GET("me", cProfile.getMyProfile)
+		userGroup.GET("id/:id", cProfile.getProfileByUserID)
+		userGroup.GET("me/export", cExport.exportMyData)
+		userGroup.POST("batch", cProfile.batchGetProfiles)
 		userGroup.PUT("", cProfile.updateProfileByUserID)
+		userGroup.PUT("username", cAccount.changeUsername)
+		userGroup.DELETE("", cAccount.deleteAccount)
+	}
+
+	sessionGroup := routerV1.Group("sessions", rateLimitMiddleware(limiter, ratelimit.GroupSessions))
+	{
+		sessionGroup.GET("", cSession.getMySessions)
+		sessionGroup.DELETE(":id", cSession.revokeMySession)
+	}
+
+	adminGroup := routerV1.Group("admin", rateLimitMiddleware(limiter, ratelimit.GroupAdmin))
+	{
+		adminGroup.GET("users/:id/sessions", cSession.getSessionsByUserID)
+		adminGroup.DELETE("sessions/:id", cSession.revokeSessionByID)
+		adminGroup.PUT("users/:id/status", cAccount.updateUserStatus)
+		adminGroup.GET("users/:id/export", cExport.exportUserData)
+		adminGroup.POST("webhooks", cWebhook.createWebhookSubscription)
+		adminGroup.GET("webhooks", cWebhook.getWebhookSubscriptions)
+		adminGroup.PUT("webhooks/:id", cWebhook.updateWebhookSubscription)
+		adminGroup.DELETE("webhooks/:id", cWebhook.deleteWebhookSubscription)
+		adminGroup.GET("webhooks/:id/deliveries", cWebhook.getWebhookDeliveries)
+		adminGroup.POST("webhooks/deliveries/:id/redeliver", cWebhook.redeliverWebhook)
+		adminGroup.GET("audit-logs", cAuditLog.getAuditLogs)
 	}
 }
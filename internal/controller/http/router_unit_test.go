@@ -0,0 +1,42 @@
+package http
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Hidayathamir/go-user/config"
+	"github.com/Hidayathamir/go-user/internal/pkg/health"
+	"github.com/Hidayathamir/go-user/internal/pkg/ratelimit"
+	"github.com/Hidayathamir/go-user/internal/repo/db"
+	"github.com/Hidayathamir/go-user/pkg/gouser"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnitRegisterRouterUsernameReserved(t *testing.T) {
+	t.Parallel()
+
+	gin.SetMode(gin.TestMode)
+
+	t.Run("every path segment under users should not be registrable username", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := config.Config{}
+		ginEngine := gin.New()
+		registerRouter(cfg, ginEngine, &db.Postgres{}, health.NewChecker(), ratelimit.NewLimiter(cfg, ratelimit.NewMemoryStore()))
+
+		const usersPath = "/api/v1/users/"
+		checked := 0
+		for _, route := range ginEngine.Routes() {
+			segment, _, _ := strings.Cut(strings.TrimPrefix(route.Path, usersPath), "/")
+			if !strings.HasPrefix(route.Path, usersPath) || strings.HasPrefix(segment, ":") {
+				continue
+			}
+
+			err := gouser.ReqRegisterUser{Username: segment, Password: "mypassword"}.Validate()
+			assert.Error(t, err, route.Path)
+			checked++
+		}
+		assert.NotZero(t, checked)
+	})
+}
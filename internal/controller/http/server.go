@@ -3,26 +3,56 @@ package http
 import (
 	"fmt"
 	"net"
+	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/Hidayathamir/go-user/config"
+	"github.com/Hidayathamir/go-user/internal/pkg/health"
+	"github.com/Hidayathamir/go-user/internal/pkg/ratelimit"
+	"github.com/Hidayathamir/go-user/internal/pkg/tlsconfig"
 	"github.com/Hidayathamir/go-user/internal/repo/db"
 	"github.com/gin-gonic/gin"
 	"github.com/sirupsen/logrus"
 )
 
-// RunServer run http server.
-func RunServer(cfg config.Config, db *db.Postgres) error {
-	ginEngine := gin.New()
+const readHeaderTimeout = 10 * time.Second
+
+// NewServer return http server with all routes registered. Caller runs it
+// with Serve or ListenAndServe, or ServeTLS with empty files if TLSConfig is
+// set by cfg.HTTP.TLS, and stops it with Shutdown. readyz route reports
+// checker result. API routes are rate limited per route group by
+// cfg.RateLimit, with buckets kept in memory.
+func NewServer(cfg config.Config, db *db.Postgres, checker *health.Checker) (*http.Server, error) {
+	return newServer(cfg, db, checker, logrus.StandardLogger())
+}
 
-	registerRouter(cfg, ginEngine, db)
+// newServer is like NewServer, request logs are written by log.
+func newServer(cfg config.Config, db *db.Postgres, checker *health.Checker, log *logrus.Logger) (*http.Server, error) {
+	tlsConfig, err := tlsconfig.NewServerConfig(cfg.HTTP.TLS)
+	if err != nil {
+		return nil, fmt.Errorf("tlsconfig.NewServerConfig: %w", err)
+	}
 
-	addr := net.JoinHostPort(cfg.HTTP.Host, strconv.Itoa(cfg.HTTP.Port))
-	logrus.WithField("address", addr).Info("run http server")
-	err := ginEngine.Run(addr)
+	ginEngine := gin.New()
+	// let *gin.Context passed as ctx carry span of tracingMiddleware.
+	ginEngine.ContextWithFallback = true
+	// client ip is taken from X-Forwarded-For only if sent by trusted proxy,
+	// so client can not pick its own rate limit bucket.
+	err = ginEngine.SetTrustedProxies(cfg.HTTP.TrustedProxies)
 	if err != nil {
-		return fmt.Errorf("gin.Engine.Run: %w", err)
+		return nil, fmt.Errorf("gin.Engine.SetTrustedProxies: %w", err)
 	}
+	ginEngine.Use(tracingMiddleware(), requestIDMiddleware(), accessLogMiddleware(log), metricsMiddleware())
+
+	limiter := ratelimit.NewLimiter(cfg, ratelimit.NewMemoryStore())
+
+	registerRouter(cfg, ginEngine, db, checker, limiter)
 
-	return nil
+	return &http.Server{
+		Addr:              net.JoinHostPort(cfg.HTTP.Host, strconv.Itoa(cfg.HTTP.Port)),
+		Handler:           ginEngine,
+		ReadHeaderTimeout: readHeaderTimeout,
+		TLSConfig:         tlsConfig,
+	}, nil
 }
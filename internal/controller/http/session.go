@@ -0,0 +1,114 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/Hidayathamir/go-user/config"
+	"github.com/Hidayathamir/go-user/internal/pkg/header"
+	"github.com/Hidayathamir/go-user/internal/usecase"
+	"github.com/Hidayathamir/go-user/pkg/gouser"
+	"github.com/gin-gonic/gin"
+)
+
+// Session is controller HTTP for session related.
+type Session struct {
+	cfg            config.Config
+	usecaseSession usecase.ISession
+}
+
+func newSession(cfg config.Config, usecaseSession usecase.ISession) *Session {
+	return &Session{
+		cfg:            cfg,
+		usecaseSession: usecaseSession,
+	}
+}
+
+func (s *Session) getMySessions(c *gin.Context) {
+	req := gouser.ReqGetMySessions{UserJWT: c.GetHeader(header.Authorization)}
+
+	resGetSessions, err := s.usecaseSession.GetMySessions(c, req)
+	if err != nil {
+		err := fmt.Errorf("Session.usecaseSession.GetMySessions: %w", err)
+		c.JSON(http.StatusBadRequest, newResError(c, err))
+		return
+	}
+
+	c.JSON(http.StatusOK, ResGetSessions{Data: resGetSessions})
+}
+
+func (s *Session) revokeMySession(c *gin.Context) {
+	sessionID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		err := fmt.Errorf("strconv.ParseInt: %w", err)
+		c.JSON(http.StatusBadRequest, newResError(c, err))
+		return
+	}
+
+	req := gouser.ReqRevokeMySession{
+		UserJWT:   c.GetHeader(header.Authorization),
+		SessionID: sessionID,
+		UserAgent: c.Request.UserAgent(),
+		IP:        c.ClientIP(),
+		RequestID: gouser.RequestIDFromContext(c.Request.Context()),
+	}
+
+	err = s.usecaseSession.RevokeMySession(c, req)
+	if err != nil {
+		err := fmt.Errorf("Session.usecaseSession.RevokeMySession: %w", err)
+		c.JSON(http.StatusBadRequest, newResError(c, err))
+		return
+	}
+
+	c.JSON(http.StatusOK, ResString{Data: "ok"})
+}
+
+func (s *Session) getSessionsByUserID(c *gin.Context) {
+	userID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		err := fmt.Errorf("strconv.ParseInt: %w", err)
+		c.JSON(http.StatusBadRequest, newResError(c, err))
+		return
+	}
+
+	req := gouser.ReqGetSessionsByUserID{
+		UserJWT: c.GetHeader(header.Authorization),
+		UserID:  userID,
+	}
+
+	resGetSessions, err := s.usecaseSession.GetSessionsByUserID(c, req)
+	if err != nil {
+		err := fmt.Errorf("Session.usecaseSession.GetSessionsByUserID: %w", err)
+		c.JSON(http.StatusBadRequest, newResError(c, err))
+		return
+	}
+
+	c.JSON(http.StatusOK, ResGetSessions{Data: resGetSessions})
+}
+
+func (s *Session) revokeSessionByID(c *gin.Context) {
+	sessionID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		err := fmt.Errorf("strconv.ParseInt: %w", err)
+		c.JSON(http.StatusBadRequest, newResError(c, err))
+		return
+	}
+
+	req := gouser.ReqRevokeSessionByID{
+		UserJWT:   c.GetHeader(header.Authorization),
+		SessionID: sessionID,
+		UserAgent: c.Request.UserAgent(),
+		IP:        c.ClientIP(),
+		RequestID: gouser.RequestIDFromContext(c.Request.Context()),
+	}
+
+	err = s.usecaseSession.RevokeSessionByID(c, req)
+	if err != nil {
+		err := fmt.Errorf("Session.usecaseSession.RevokeSessionByID: %w", err)
+		c.JSON(http.StatusBadRequest, newResError(c, err))
+		return
+	}
+
+	c.JSON(http.StatusOK, ResString{Data: "ok"})
+}
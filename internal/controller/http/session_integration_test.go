@@ -0,0 +1,77 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/Hidayathamir/go-user/internal/repo"
+	"github.com/Hidayathamir/go-user/internal/repo/db"
+	"github.com/Hidayathamir/go-user/internal/usecase"
+	"github.com/Hidayathamir/go-user/pkg/gouser"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntegrationSessionRevokeMySession(t *testing.T) {
+	t.Parallel()
+
+	t.Run("revoked session token should not be accepted", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := initTestIntegration(t)
+
+		pg, err := db.NewPGPoolConn(cfg)
+		require.NoError(t, err)
+
+		repoAuth := repo.NewAuth(cfg, pg)
+		repoProfile := repo.NewProfile(cfg, pg)
+		repoSession := repo.NewSession(cfg, pg)
+		repoAccount := repo.NewAccount(cfg, pg)
+		usecaseAuth := usecase.NewAuth(cfg, repoAuth, repoProfile, repoSession, repoAccount, repo.NewAuditLog(cfg, pg), repo.NewTransactor(cfg, pg))
+		controllerAuth := newAuth(cfg, usecaseAuth)
+
+		usecaseProfile := usecase.NewProfile(cfg, repoProfile, repoSession, repo.NewOutbox(cfg, pg), repo.NewAuditLog(cfg, pg), repo.NewTransactor(cfg, pg))
+		controllerProfile := newProfile(cfg, usecaseProfile)
+
+		usecaseSession := usecase.NewSession(cfg, repoSession, repoProfile, repo.NewAuditLog(cfg, pg), repo.NewTransactor(cfg, pg))
+		controllerSession := newSession(cfg, usecaseSession)
+
+		gin.SetMode(gin.TestMode)
+
+		username := uuid.NewString()
+		password := uuid.NewString()
+		registerUserWithAssertSuccess(t, controllerAuth, username, password)
+		resBodyLoginLaptop := loginUserWithAssertSuccess(t, cfg, controllerAuth, username, password)
+		resBodyLoginPhone := loginUserWithAssertSuccess(t, cfg, controllerAuth, username, password)
+
+		resBodyByte, httpStatusCode := getMySessions(controllerSession, resBodyLoginLaptop.Data.UserJWT)
+		assert.Equal(t, http.StatusOK, httpStatusCode)
+		resBodySessions := ResGetSessions{}
+		require.NoError(t, json.Unmarshal(resBodyByte, &resBodySessions))
+		require.Len(t, resBodySessions.Data.Sessions, 2)
+
+		var phoneSessionID int64
+		for _, session := range resBodySessions.Data.Sessions {
+			if !session.IsCurrent {
+				phoneSessionID = session.ID
+			}
+		}
+		require.NotZero(t, phoneSessionID)
+
+		resBodyByte, httpStatusCode = revokeMySession(controllerSession, resBodyLoginLaptop.Data.UserJWT, strconv.FormatInt(phoneSessionID, 10))
+		assert.Equal(t, http.StatusOK, httpStatusCode, string(resBodyByte))
+
+		resBodyByte, httpStatusCode = updateProfileByUserID(controllerProfile, resBodyLoginPhone.Data.UserJWT, uuid.NewString())
+		assert.Equal(t, http.StatusBadRequest, httpStatusCode)
+		resBodyUpdate := ResError{}
+		require.NoError(t, json.Unmarshal(resBodyByte, &resBodyUpdate))
+		assert.Contains(t, resBodyUpdate.Error, gouser.ErrSessionRevoked.Error())
+
+		resBodyByte, httpStatusCode = updateProfileByUserID(controllerProfile, resBodyLoginLaptop.Data.UserJWT, uuid.NewString())
+		assert.Equal(t, http.StatusOK, httpStatusCode, string(resBodyByte))
+	})
+}
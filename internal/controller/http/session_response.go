@@ -0,0 +1,9 @@
+package http
+
+import "github.com/Hidayathamir/go-user/pkg/gouser"
+
+// ResGetSessions -.
+type ResGetSessions struct {
+	Data  gouser.ResGetSessions `json:"data"`
+	Error any                   `json:"error"`
+}
@@ -0,0 +1,149 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Hidayathamir/go-user/config"
+	"github.com/Hidayathamir/go-user/internal/pkg/header"
+	"github.com/Hidayathamir/go-user/internal/usecase/mockusecase"
+	"github.com/Hidayathamir/go-user/pkg/gouser"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUnitSessionGetMySessions(t *testing.T) {
+	t.Parallel()
+
+	gin.SetMode(gin.TestMode)
+
+	t.Run("call usecase GetMySessions success should return success", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		usecaseSession := mockusecase.NewMockISession(ctrl)
+
+		s := &Session{
+			cfg:            config.Config{},
+			usecaseSession: usecaseSession,
+		}
+
+		rr := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(rr)
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set(header.Authorization, "Bearer dummyUserJWT")
+		ctx.Request = req
+
+		resGetSessions := gouser.ResGetSessions{Sessions: []gouser.Session{{ID: 3, UserAgent: "Mozilla/5.0", IsCurrent: true}}}
+		usecaseSession.EXPECT().
+			GetMySessions(gomock.Any(), gouser.ReqGetMySessions{UserJWT: "Bearer dummyUserJWT"}).
+			Return(resGetSessions, nil)
+
+		s.getMySessions(ctx)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		resBody := ResGetSessions{}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resBody))
+		assert.Equal(t, resGetSessions, resBody.Data)
+		assert.Nil(t, resBody.Error)
+	})
+	t.Run("call usecase GetMySessions error should return error", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		usecaseSession := mockusecase.NewMockISession(ctrl)
+
+		s := &Session{
+			cfg:            config.Config{},
+			usecaseSession: usecaseSession,
+		}
+
+		rr := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(rr)
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set(header.Authorization, "Bearer dummyUserJWT")
+		ctx.Request = req
+
+		usecaseSession.EXPECT().
+			GetMySessions(gomock.Any(), gouser.ReqGetMySessions{UserJWT: "Bearer dummyUserJWT"}).
+			Return(gouser.ResGetSessions{}, assert.AnError)
+
+		s.getMySessions(ctx)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		resBody := ResError{}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resBody))
+		assert.Nil(t, resBody.Data)
+		assert.Contains(t, resBody.Error, assert.AnError.Error())
+	})
+}
+
+func TestUnitSessionRevokeMySession(t *testing.T) {
+	t.Parallel()
+
+	gin.SetMode(gin.TestMode)
+
+	t.Run("call usecase RevokeMySession success should return success", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		usecaseSession := mockusecase.NewMockISession(ctrl)
+
+		s := &Session{
+			cfg:            config.Config{},
+			usecaseSession: usecaseSession,
+		}
+
+		rr := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(rr)
+		req := httptest.NewRequest(http.MethodDelete, "/", nil)
+		req.Header.Set(header.Authorization, "Bearer dummyUserJWT")
+		ctx.Request = req
+		ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: "12"})
+
+		usecaseSession.EXPECT().
+			RevokeMySession(gomock.Any(), gouser.ReqRevokeMySession{UserJWT: "Bearer dummyUserJWT", SessionID: 12, IP: "192.0.2.1"}).
+			Return(nil)
+
+		s.revokeMySession(ctx)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		resBody := ResString{}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resBody))
+		assert.Equal(t, "ok", resBody.Data)
+		assert.Nil(t, resBody.Error)
+	})
+	t.Run("session id not number should return error", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		s := &Session{
+			cfg:            config.Config{},
+			usecaseSession: mockusecase.NewMockISession(ctrl),
+		}
+
+		rr := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(rr)
+		ctx.Request = httptest.NewRequest(http.MethodDelete, "/", nil)
+		ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: "abc"})
+
+		s.revokeMySession(ctx)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		resBody := ResError{}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resBody))
+		assert.Contains(t, resBody.Error, "strconv.ParseInt")
+	})
+}
@@ -0,0 +1,71 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Hidayathamir/go-user/internal/pkg/tracing/tracingtest"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestUnitTracingMiddleware(t *testing.T) {
+	t.Parallel()
+
+	gin.SetMode(gin.TestMode)
+	tracingtest.Setup()
+
+	t.Run("request should continue trace context of traceparent header", func(t *testing.T) {
+		t.Parallel()
+
+		ginEngine := gin.New()
+		ginEngine.ContextWithFallback = true
+		ginEngine.Use(tracingMiddleware())
+
+		var handlerSpanContext trace.SpanContext
+		ginEngine.GET("tracing-test/:id", func(c *gin.Context) {
+			handlerSpanContext = trace.SpanContextFromContext(c)
+			c.Status(http.StatusTeapot)
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/tracing-test/1", nil)
+		req.Header.Set("traceparent", "00-11111111111111111111111111111111-00f067aa0ba902b7-01")
+		ginEngine.ServeHTTP(httptest.NewRecorder(), req)
+
+		traceID := handlerSpanContext.TraceID()
+		assert.Equal(t, "11111111111111111111111111111111", traceID.String())
+
+		span, ok := tracingtest.SpanByName(traceID, "GET /tracing-test/:id")
+		require.True(t, ok)
+		assert.Equal(t, trace.SpanKindServer, span.SpanKind)
+		assert.Equal(t, "00f067aa0ba902b7", span.Parent.SpanID().String())
+		assert.Equal(t, handlerSpanContext.SpanID(), span.SpanContext.SpanID())
+		assert.Contains(t, span.Attributes, attribute.String("http.route", "/tracing-test/:id"))
+		assert.Contains(t, span.Attributes, attribute.Int("http.status_code", http.StatusTeapot))
+		assert.Equal(t, codes.Unset, span.Status.Code)
+	})
+	t.Run("server error should set error status", func(t *testing.T) {
+		t.Parallel()
+
+		ginEngine := gin.New()
+		ginEngine.Use(tracingMiddleware())
+
+		var traceID trace.TraceID
+		ginEngine.POST("tracing-test", func(c *gin.Context) {
+			traceID = trace.SpanContextFromContext(c.Request.Context()).TraceID()
+			c.Status(http.StatusInternalServerError)
+		})
+
+		ginEngine.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/tracing-test", nil))
+
+		span, ok := tracingtest.SpanByName(traceID, "POST /tracing-test")
+		require.True(t, ok)
+		assert.False(t, span.Parent.IsValid())
+		assert.Equal(t, codes.Error, span.Status.Code)
+	})
+}
@@ -0,0 +1,164 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/Hidayathamir/go-user/config"
+	"github.com/Hidayathamir/go-user/internal/pkg/header"
+	"github.com/Hidayathamir/go-user/internal/usecase"
+	"github.com/Hidayathamir/go-user/pkg/gouser"
+	"github.com/gin-gonic/gin"
+)
+
+// Webhook is controller HTTP for outgoing webhook related.
+type Webhook struct {
+	cfg            config.Config
+	usecaseWebhook usecase.IWebhook
+}
+
+func newWebhook(cfg config.Config, usecaseWebhook usecase.IWebhook) *Webhook {
+	return &Webhook{
+		cfg:            cfg,
+		usecaseWebhook: usecaseWebhook,
+	}
+}
+
+func (w *Webhook) createWebhookSubscription(c *gin.Context) {
+	req := gouser.ReqCreateWebhookSubscription{}
+	err := c.ShouldBindJSON(&req)
+	if err != nil {
+		err := fmt.Errorf("gin.Context.ShouldBindJSON: %w", err)
+		c.JSON(http.StatusBadRequest, newResError(c, err))
+		return
+	}
+
+	req.UserJWT = c.GetHeader(header.Authorization)
+
+	res, err := w.usecaseWebhook.CreateWebhookSubscription(c, req)
+	if err != nil {
+		err := fmt.Errorf("Webhook.usecaseWebhook.CreateWebhookSubscription: %w", err)
+		c.JSON(http.StatusBadRequest, newResError(c, err))
+		return
+	}
+
+	c.JSON(http.StatusOK, ResCreateWebhookSubscription{Data: res})
+}
+
+func (w *Webhook) getWebhookSubscriptions(c *gin.Context) {
+	req := gouser.ReqGetWebhookSubscriptions{UserJWT: c.GetHeader(header.Authorization)}
+
+	res, err := w.usecaseWebhook.GetWebhookSubscriptions(c, req)
+	if err != nil {
+		err := fmt.Errorf("Webhook.usecaseWebhook.GetWebhookSubscriptions: %w", err)
+		c.JSON(http.StatusBadRequest, newResError(c, err))
+		return
+	}
+
+	c.JSON(http.StatusOK, ResGetWebhookSubscriptions{Data: res})
+}
+
+func (w *Webhook) updateWebhookSubscription(c *gin.Context) {
+	subscriptionID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		err := fmt.Errorf("strconv.ParseInt: %w", err)
+		c.JSON(http.StatusBadRequest, newResError(c, err))
+		return
+	}
+
+	req := gouser.ReqUpdateWebhookSubscription{}
+	err = c.ShouldBindJSON(&req)
+	if err != nil {
+		err := fmt.Errorf("gin.Context.ShouldBindJSON: %w", err)
+		c.JSON(http.StatusBadRequest, newResError(c, err))
+		return
+	}
+
+	req.UserJWT = c.GetHeader(header.Authorization)
+	req.SubscriptionID = subscriptionID
+
+	err = w.usecaseWebhook.UpdateWebhookSubscription(c, req)
+	if err != nil {
+		err := fmt.Errorf("Webhook.usecaseWebhook.UpdateWebhookSubscription: %w", err)
+		c.JSON(http.StatusBadRequest, newResError(c, err))
+		return
+	}
+
+	c.JSON(http.StatusOK, ResString{Data: "ok"})
+}
+
+func (w *Webhook) deleteWebhookSubscription(c *gin.Context) {
+	subscriptionID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		err := fmt.Errorf("strconv.ParseInt: %w", err)
+		c.JSON(http.StatusBadRequest, newResError(c, err))
+		return
+	}
+
+	req := gouser.ReqDeleteWebhookSubscription{
+		UserJWT:        c.GetHeader(header.Authorization),
+		SubscriptionID: subscriptionID,
+	}
+
+	err = w.usecaseWebhook.DeleteWebhookSubscription(c, req)
+	if err != nil {
+		err := fmt.Errorf("Webhook.usecaseWebhook.DeleteWebhookSubscription: %w", err)
+		c.JSON(http.StatusBadRequest, newResError(c, err))
+		return
+	}
+
+	c.JSON(http.StatusOK, ResString{Data: "ok"})
+}
+
+func (w *Webhook) getWebhookDeliveries(c *gin.Context) {
+	subscriptionID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		err := fmt.Errorf("strconv.ParseInt: %w", err)
+		c.JSON(http.StatusBadRequest, newResError(c, err))
+		return
+	}
+
+	req := gouser.ReqGetWebhookDeliveries{}
+	err = c.ShouldBindQuery(&req)
+	if err != nil {
+		err := fmt.Errorf("gin.Context.ShouldBindQuery: %w", err)
+		c.JSON(http.StatusBadRequest, newResError(c, err))
+		return
+	}
+
+	req.UserJWT = c.GetHeader(header.Authorization)
+	req.SubscriptionID = subscriptionID
+
+	res, err := w.usecaseWebhook.GetWebhookDeliveries(c, req)
+	if err != nil {
+		err := fmt.Errorf("Webhook.usecaseWebhook.GetWebhookDeliveries: %w", err)
+		c.JSON(http.StatusBadRequest, newResError(c, err))
+		return
+	}
+
+	c.JSON(http.StatusOK, ResGetWebhookDeliveries{Data: res})
+}
+
+func (w *Webhook) redeliverWebhook(c *gin.Context) {
+	deliveryID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		err := fmt.Errorf("strconv.ParseInt: %w", err)
+		c.JSON(http.StatusBadRequest, newResError(c, err))
+		return
+	}
+
+	req := gouser.ReqRedeliverWebhook{
+		UserJWT:    c.GetHeader(header.Authorization),
+		DeliveryID: deliveryID,
+	}
+
+	err = w.usecaseWebhook.RedeliverWebhook(c, req)
+	if err != nil {
+		err := fmt.Errorf("Webhook.usecaseWebhook.RedeliverWebhook: %w", err)
+		c.JSON(http.StatusBadRequest, newResError(c, err))
+		return
+	}
+
+	c.JSON(http.StatusOK, ResString{Data: "ok"})
+}
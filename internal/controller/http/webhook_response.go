@@ -0,0 +1,21 @@
+package http
+
+import "github.com/Hidayathamir/go-user/pkg/gouser"
+
+// ResCreateWebhookSubscription -.
+type ResCreateWebhookSubscription struct {
+	Data  gouser.ResCreateWebhookSubscription `json:"data"`
+	Error any                                 `json:"error"`
+}
+
+// ResGetWebhookSubscriptions -.
+type ResGetWebhookSubscriptions struct {
+	Data  gouser.ResGetWebhookSubscriptions `json:"data"`
+	Error any                               `json:"error"`
+}
+
+// ResGetWebhookDeliveries -.
+type ResGetWebhookDeliveries struct {
+	Data  gouser.ResGetWebhookDeliveries `json:"data"`
+	Error any                            `json:"error"`
+}
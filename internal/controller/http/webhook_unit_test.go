@@ -0,0 +1,309 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Hidayathamir/go-user/config"
+	"github.com/Hidayathamir/go-user/internal/pkg/header"
+	"github.com/Hidayathamir/go-user/internal/usecase/mockusecase"
+	"github.com/Hidayathamir/go-user/pkg/gouser"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUnitWebhookCreateWebhookSubscription(t *testing.T) {
+	t.Parallel()
+
+	gin.SetMode(gin.TestMode)
+
+	t.Run("call usecase CreateWebhookSubscription success should return success", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		usecaseWebhook := mockusecase.NewMockIWebhook(ctrl)
+
+		w := &Webhook{
+			cfg:            config.Config{},
+			usecaseWebhook: usecaseWebhook,
+		}
+
+		rr := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(rr)
+		reqBody := gouser.ReqCreateWebhookSubscription{
+			URL:        "https://example.com/hook",
+			EventTypes: []string{gouser.EventTypeUserRegistered},
+			Secret:     "0123456789abcdef",
+		}
+		reqBodyByte, err := json.Marshal(reqBody)
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodPost, "/", bytes.NewBuffer(reqBodyByte))
+		req.Header.Set(header.Authorization, "Bearer dummyUserJWT")
+		ctx.Request = req
+
+		reqBody.UserJWT = "Bearer dummyUserJWT"
+		usecaseWebhook.EXPECT().
+			CreateWebhookSubscription(gomock.Any(), reqBody).
+			Return(gouser.ResCreateWebhookSubscription{ID: 3}, nil)
+
+		w.createWebhookSubscription(ctx)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		resBody := ResCreateWebhookSubscription{}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resBody))
+		assert.Equal(t, int64(3), resBody.Data.ID)
+		assert.Nil(t, resBody.Error)
+	})
+	t.Run("call usecase CreateWebhookSubscription error should return error", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		usecaseWebhook := mockusecase.NewMockIWebhook(ctrl)
+
+		w := &Webhook{
+			cfg:            config.Config{},
+			usecaseWebhook: usecaseWebhook,
+		}
+
+		rr := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(rr)
+		req := httptest.NewRequest(http.MethodPost, "/", bytes.NewBufferString(`{"url":"https://example.com/hook"}`))
+		req.Header.Set(header.Authorization, "Bearer dummyUserJWT")
+		ctx.Request = req
+
+		usecaseWebhook.EXPECT().
+			CreateWebhookSubscription(gomock.Any(), gomock.Any()).
+			Return(gouser.ResCreateWebhookSubscription{}, assert.AnError)
+
+		w.createWebhookSubscription(ctx)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		resBody := ResError{}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resBody))
+		assert.Nil(t, resBody.Data)
+		assert.Contains(t, resBody.Error, assert.AnError.Error())
+	})
+}
+
+func TestUnitWebhookUpdateWebhookSubscription(t *testing.T) {
+	t.Parallel()
+
+	gin.SetMode(gin.TestMode)
+
+	t.Run("call usecase UpdateWebhookSubscription success should return success", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		usecaseWebhook := mockusecase.NewMockIWebhook(ctrl)
+
+		w := &Webhook{
+			cfg:            config.Config{},
+			usecaseWebhook: usecaseWebhook,
+		}
+
+		rr := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(rr)
+		req := httptest.NewRequest(http.MethodPut, "/", bytes.NewBufferString(`{"url":"https://example.com/hook","event_types":["user.deleted"],"is_active":false}`))
+		req.Header.Set(header.Authorization, "Bearer dummyUserJWT")
+		ctx.Request = req
+		ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: "3"})
+
+		usecaseWebhook.EXPECT().
+			UpdateWebhookSubscription(gomock.Any(), gouser.ReqUpdateWebhookSubscription{
+				UserJWT:        "Bearer dummyUserJWT",
+				SubscriptionID: 3,
+				URL:            "https://example.com/hook",
+				EventTypes:     []string{gouser.EventTypeUserDeleted},
+				IsActive:       false,
+			}).
+			Return(nil)
+
+		w.updateWebhookSubscription(ctx)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		resBody := ResString{}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resBody))
+		assert.Equal(t, "ok", resBody.Data)
+	})
+	t.Run("subscription id not number should return error", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		w := &Webhook{
+			cfg:            config.Config{},
+			usecaseWebhook: mockusecase.NewMockIWebhook(ctrl),
+		}
+
+		rr := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(rr)
+		ctx.Request = httptest.NewRequest(http.MethodPut, "/", nil)
+		ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: "abc"})
+
+		w.updateWebhookSubscription(ctx)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		resBody := ResError{}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resBody))
+		assert.Contains(t, resBody.Error, "strconv.ParseInt")
+	})
+}
+
+func TestUnitWebhookGetWebhookDeliveries(t *testing.T) {
+	t.Parallel()
+
+	gin.SetMode(gin.TestMode)
+
+	t.Run("call usecase GetWebhookDeliveries success should return success", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		usecaseWebhook := mockusecase.NewMockIWebhook(ctrl)
+
+		w := &Webhook{
+			cfg:            config.Config{},
+			usecaseWebhook: usecaseWebhook,
+		}
+
+		rr := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(rr)
+		req := httptest.NewRequest(http.MethodGet, "/?status=dead&limit=5", nil)
+		req.Header.Set(header.Authorization, "Bearer dummyUserJWT")
+		ctx.Request = req
+		ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: "3"})
+
+		resGetWebhookDeliveries := gouser.ResGetWebhookDeliveries{Deliveries: []gouser.WebhookDelivery{
+			{ID: 11, SubscriptionID: 3, Status: "dead", Attempts: []gouser.WebhookDeliveryAttempt{{StatusCode: 500}}},
+		}}
+		usecaseWebhook.EXPECT().
+			GetWebhookDeliveries(gomock.Any(), gouser.ReqGetWebhookDeliveries{
+				UserJWT:        "Bearer dummyUserJWT",
+				SubscriptionID: 3,
+				Status:         "dead",
+				Limit:          5,
+			}).
+			Return(resGetWebhookDeliveries, nil)
+
+		w.getWebhookDeliveries(ctx)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		resBody := ResGetWebhookDeliveries{}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resBody))
+		assert.Equal(t, resGetWebhookDeliveries, resBody.Data)
+		assert.Nil(t, resBody.Error)
+	})
+	t.Run("call usecase GetWebhookDeliveries error should return error", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		usecaseWebhook := mockusecase.NewMockIWebhook(ctrl)
+
+		w := &Webhook{
+			cfg:            config.Config{},
+			usecaseWebhook: usecaseWebhook,
+		}
+
+		rr := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(rr)
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set(header.Authorization, "Bearer dummyUserJWT")
+		ctx.Request = req
+		ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: "3"})
+
+		usecaseWebhook.EXPECT().
+			GetWebhookDeliveries(gomock.Any(), gomock.Any()).
+			Return(gouser.ResGetWebhookDeliveries{}, assert.AnError)
+
+		w.getWebhookDeliveries(ctx)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		resBody := ResError{}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resBody))
+		assert.Contains(t, resBody.Error, assert.AnError.Error())
+	})
+}
+
+func TestUnitWebhookRedeliverWebhook(t *testing.T) {
+	t.Parallel()
+
+	gin.SetMode(gin.TestMode)
+
+	t.Run("call usecase RedeliverWebhook success should return success", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		usecaseWebhook := mockusecase.NewMockIWebhook(ctrl)
+
+		w := &Webhook{
+			cfg:            config.Config{},
+			usecaseWebhook: usecaseWebhook,
+		}
+
+		rr := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(rr)
+		req := httptest.NewRequest(http.MethodPost, "/", nil)
+		req.Header.Set(header.Authorization, "Bearer dummyUserJWT")
+		ctx.Request = req
+		ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: "11"})
+
+		usecaseWebhook.EXPECT().
+			RedeliverWebhook(gomock.Any(), gouser.ReqRedeliverWebhook{UserJWT: "Bearer dummyUserJWT", DeliveryID: 11}).
+			Return(nil)
+
+		w.redeliverWebhook(ctx)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		resBody := ResString{}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resBody))
+		assert.Equal(t, "ok", resBody.Data)
+	})
+	t.Run("call usecase RedeliverWebhook error should return error", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		usecaseWebhook := mockusecase.NewMockIWebhook(ctrl)
+
+		w := &Webhook{
+			cfg:            config.Config{},
+			usecaseWebhook: usecaseWebhook,
+		}
+
+		rr := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(rr)
+		req := httptest.NewRequest(http.MethodPost, "/", nil)
+		req.Header.Set(header.Authorization, "Bearer dummyUserJWT")
+		ctx.Request = req
+		ctx.Params = append(ctx.Params, gin.Param{Key: "id", Value: "11"})
+
+		usecaseWebhook.EXPECT().
+			RedeliverWebhook(gomock.Any(), gomock.Any()).
+			Return(gouser.ErrUnknownWebhookDelivery)
+
+		w.redeliverWebhook(ctx)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		resBody := ResError{}
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resBody))
+		assert.Contains(t, resBody.Error, gouser.ErrUnknownWebhookDelivery.Error())
+	})
+}
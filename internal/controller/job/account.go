@@ -0,0 +1,36 @@
+package job
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Hidayathamir/go-user/config"
+	"github.com/Hidayathamir/go-user/internal/usecase"
+	"github.com/sirupsen/logrus"
+)
+
+// Account is controller job for account lifecycle related.
+type Account struct {
+	cfg            config.Config
+	usecaseAccount usecase.IAccount
+}
+
+func newAccount(cfg config.Config, usecaseAccount usecase.IAccount) *Account {
+	return &Account{
+		cfg:            cfg,
+		usecaseAccount: usecaseAccount,
+	}
+}
+
+func (a *Account) purgeDeletedAccounts(ctx context.Context) error {
+	count, err := a.usecaseAccount.PurgeDeletedAccounts(ctx)
+	if err != nil {
+		return fmt.Errorf("Account.usecaseAccount.PurgeDeletedAccounts: %w", err)
+	}
+
+	if count > 0 {
+		logrus.WithField("total_purged", count).Info("purge deleted accounts")
+	}
+
+	return nil
+}
@@ -0,0 +1,56 @@
+package job
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Hidayathamir/go-user/config"
+	"github.com/Hidayathamir/go-user/internal/usecase/mockusecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUnitAccountPurgeDeletedAccounts(t *testing.T) {
+	t.Parallel()
+
+	t.Run("call usecase PurgeDeletedAccounts success should return success", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		usecaseAccount := mockusecase.NewMockIAccount(ctrl)
+
+		a := &Account{
+			cfg:            config.Config{},
+			usecaseAccount: usecaseAccount,
+		}
+
+		usecaseAccount.EXPECT().PurgeDeletedAccounts(gomock.Any()).Return(int64(2), nil)
+
+		err := a.purgeDeletedAccounts(context.Background())
+
+		require.NoError(t, err)
+	})
+	t.Run("call usecase PurgeDeletedAccounts error should return error", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		usecaseAccount := mockusecase.NewMockIAccount(ctrl)
+
+		a := &Account{
+			cfg:            config.Config{},
+			usecaseAccount: usecaseAccount,
+		}
+
+		usecaseAccount.EXPECT().PurgeDeletedAccounts(gomock.Any()).Return(int64(0), assert.AnError)
+
+		err := a.purgeDeletedAccounts(context.Background())
+
+		require.Error(t, err)
+		require.ErrorIs(t, err, assert.AnError)
+	})
+}
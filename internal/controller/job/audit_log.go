@@ -0,0 +1,36 @@
+package job
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Hidayathamir/go-user/config"
+	"github.com/Hidayathamir/go-user/internal/usecase"
+	"github.com/sirupsen/logrus"
+)
+
+// AuditLog is controller job for audit log related.
+type AuditLog struct {
+	cfg             config.Config
+	usecaseAuditLog usecase.IAuditLog
+}
+
+func newAuditLog(cfg config.Config, usecaseAuditLog usecase.IAuditLog) *AuditLog {
+	return &AuditLog{
+		cfg:             cfg,
+		usecaseAuditLog: usecaseAuditLog,
+	}
+}
+
+func (a *AuditLog) purgeAuditLogs(ctx context.Context) error {
+	count, err := a.usecaseAuditLog.PurgeAuditLogs(ctx)
+	if err != nil {
+		return fmt.Errorf("AuditLog.usecaseAuditLog.PurgeAuditLogs: %w", err)
+	}
+
+	if count > 0 {
+		logrus.WithField("total_purged", count).Info("purge audit logs")
+	}
+
+	return nil
+}
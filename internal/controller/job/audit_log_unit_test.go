@@ -0,0 +1,56 @@
+package job
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Hidayathamir/go-user/config"
+	"github.com/Hidayathamir/go-user/internal/usecase/mockusecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUnitAuditLogPurgeAuditLogs(t *testing.T) {
+	t.Parallel()
+
+	t.Run("call usecase PurgeAuditLogs success should return success", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		usecaseAuditLog := mockusecase.NewMockIAuditLog(ctrl)
+
+		a := &AuditLog{
+			cfg:             config.Config{},
+			usecaseAuditLog: usecaseAuditLog,
+		}
+
+		usecaseAuditLog.EXPECT().PurgeAuditLogs(gomock.Any()).Return(int64(2), nil)
+
+		err := a.purgeAuditLogs(context.Background())
+
+		require.NoError(t, err)
+	})
+	t.Run("call usecase PurgeAuditLogs error should return error", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		usecaseAuditLog := mockusecase.NewMockIAuditLog(ctrl)
+
+		a := &AuditLog{
+			cfg:             config.Config{},
+			usecaseAuditLog: usecaseAuditLog,
+		}
+
+		usecaseAuditLog.EXPECT().PurgeAuditLogs(gomock.Any()).Return(int64(0), assert.AnError)
+
+		err := a.purgeAuditLogs(context.Background())
+
+		require.Error(t, err)
+		require.ErrorIs(t, err, assert.AnError)
+	})
+}
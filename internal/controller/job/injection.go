@@ -0,0 +1,55 @@
+package job
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/Hidayathamir/go-user/config"
+	"github.com/Hidayathamir/go-user/internal/pkg/publisher"
+	"github.com/Hidayathamir/go-user/internal/pkg/webhook"
+	"github.com/Hidayathamir/go-user/internal/repo"
+	"github.com/Hidayathamir/go-user/internal/repo/db"
+	"github.com/Hidayathamir/go-user/internal/usecase"
+)
+
+func injectionAccount(cfg config.Config, db *db.Postgres) *Account {
+	repoAccount := repo.NewAccount(cfg, db)
+	repoProfile := repo.NewProfile(cfg, db)
+	repoSession := repo.NewSession(cfg, db)
+	repoOutbox := repo.NewOutbox(cfg, db)
+	repoWebhook := repo.NewWebhook(cfg, db)
+	repoAuditLog := repo.NewAuditLog(cfg, db)
+	transactor := repo.NewTransactor(cfg, db)
+	usecaseAccount := usecase.NewAccount(cfg, repoAccount, repoProfile, repoSession, repoOutbox, repoWebhook, repoAuditLog, transactor)
+	controllerAccount := newAccount(cfg, usecaseAccount)
+	return controllerAccount
+}
+
+func injectionOutbox(cfg config.Config, db *db.Postgres) *Outbox {
+	repoOutbox := repo.NewOutbox(cfg, db)
+	repoWebhook := repo.NewWebhook(cfg, db)
+	pub := publisher.New(cfg)
+	usecaseOutbox := usecase.NewOutbox(cfg, repoOutbox, repoWebhook, pub)
+	controllerOutbox := newOutbox(cfg, usecaseOutbox)
+	return controllerOutbox
+}
+
+func injectionWebhook(cfg config.Config, db *db.Postgres) *Webhook {
+	repoWebhook := repo.NewWebhook(cfg, db)
+	repoProfile := repo.NewProfile(cfg, db)
+	repoSession := repo.NewSession(cfg, db)
+	transactor := repo.NewTransactor(cfg, db)
+	sender := webhook.NewSender(&http.Client{Timeout: time.Duration(cfg.Webhook.TimeoutSecond) * time.Second})
+	usecaseWebhook := usecase.NewWebhook(cfg, repoWebhook, repoSession, repoProfile, transactor, sender)
+	controllerWebhook := newWebhook(cfg, usecaseWebhook)
+	return controllerWebhook
+}
+
+func injectionAuditLog(cfg config.Config, db *db.Postgres) *AuditLog {
+	repoAuditLog := repo.NewAuditLog(cfg, db)
+	repoSession := repo.NewSession(cfg, db)
+	repoProfile := repo.NewProfile(cfg, db)
+	usecaseAuditLog := usecase.NewAuditLog(cfg, repoAuditLog, repoSession, repoProfile)
+	controllerAuditLog := newAuditLog(cfg, usecaseAuditLog)
+	return controllerAuditLog
+}
@@ -0,0 +1,2 @@
+// Package job contains background jobs as the presentation layer, they are triggered by time instead of requests from clients.
+package job
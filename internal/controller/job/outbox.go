@@ -0,0 +1,36 @@
+package job
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Hidayathamir/go-user/config"
+	"github.com/Hidayathamir/go-user/internal/usecase"
+	"github.com/sirupsen/logrus"
+)
+
+// Outbox is controller job for domain event outbox related.
+type Outbox struct {
+	cfg           config.Config
+	usecaseOutbox usecase.IOutbox
+}
+
+func newOutbox(cfg config.Config, usecaseOutbox usecase.IOutbox) *Outbox {
+	return &Outbox{
+		cfg:           cfg,
+		usecaseOutbox: usecaseOutbox,
+	}
+}
+
+func (o *Outbox) relayEvents(ctx context.Context) error {
+	count, err := o.usecaseOutbox.RelayEvents(ctx)
+	if err != nil {
+		return fmt.Errorf("Outbox.usecaseOutbox.RelayEvents: %w", err)
+	}
+
+	if count > 0 {
+		logrus.WithField("total_published", count).Info("relay outbox events")
+	}
+
+	return nil
+}
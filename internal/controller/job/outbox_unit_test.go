@@ -0,0 +1,56 @@
+package job
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Hidayathamir/go-user/config"
+	"github.com/Hidayathamir/go-user/internal/usecase/mockusecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUnitOutboxRelayEvents(t *testing.T) {
+	t.Parallel()
+
+	t.Run("call usecase RelayEvents success should return success", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		usecaseOutbox := mockusecase.NewMockIOutbox(ctrl)
+
+		o := &Outbox{
+			cfg:           config.Config{},
+			usecaseOutbox: usecaseOutbox,
+		}
+
+		usecaseOutbox.EXPECT().RelayEvents(gomock.Any()).Return(int64(3), nil)
+
+		err := o.relayEvents(context.Background())
+
+		require.NoError(t, err)
+	})
+	t.Run("call usecase RelayEvents error should return error", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		usecaseOutbox := mockusecase.NewMockIOutbox(ctrl)
+
+		o := &Outbox{
+			cfg:           config.Config{},
+			usecaseOutbox: usecaseOutbox,
+		}
+
+		usecaseOutbox.EXPECT().RelayEvents(gomock.Any()).Return(int64(0), assert.AnError)
+
+		err := o.relayEvents(context.Background())
+
+		require.Error(t, err)
+		require.ErrorIs(t, err, assert.AnError)
+	})
+}
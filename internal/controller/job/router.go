@@ -0,0 +1,40 @@
+package job
+
+import (
+	"time"
+
+	"github.com/Hidayathamir/go-user/config"
+	"github.com/Hidayathamir/go-user/internal/repo/db"
+)
+
+// This file contains all available jobs.
+
+func registerJob(cfg config.Config, db *db.Postgres) []job {
+	cAccount := injectionAccount(cfg, db)
+	cOutbox := injectionOutbox(cfg, db)
+	cWebhook := injectionWebhook(cfg, db)
+	cAuditLog := injectionAuditLog(cfg, db)
+
+	return []job{
+		{
+			name:     "purge deleted accounts",
+			interval: time.Duration(cfg.Account.PurgeIntervalMinute) * time.Minute,
+			run:      cAccount.purgeDeletedAccounts,
+		},
+		{
+			name:     "relay outbox events",
+			interval: time.Duration(cfg.Outbox.RelayIntervalSecond) * time.Second,
+			run:      cOutbox.relayEvents,
+		},
+		{
+			name:     "deliver webhooks",
+			interval: time.Duration(cfg.Webhook.DeliveryIntervalSecond) * time.Second,
+			run:      cWebhook.deliverWebhooks,
+		},
+		{
+			name:     "purge audit logs",
+			interval: time.Duration(cfg.AuditLog.PurgeIntervalMinute) * time.Minute,
+			run:      cAuditLog.purgeAuditLogs,
+		},
+	}
+}
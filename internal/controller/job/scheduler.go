@@ -0,0 +1,55 @@
+package job
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/Hidayathamir/go-user/config"
+	"github.com/Hidayathamir/go-user/internal/repo/db"
+	"github.com/sirupsen/logrus"
+)
+
+// job is a function run periodically every interval.
+type job struct {
+	name     string
+	interval time.Duration
+	run      func(ctx context.Context) error
+}
+
+// RunScheduler run all registered jobs periodically, block until ctx is done.
+func RunScheduler(ctx context.Context, cfg config.Config, db *db.Postgres) error {
+	jobs := registerJob(cfg, db)
+
+	wg := sync.WaitGroup{}
+	for _, j := range jobs {
+		wg.Add(1)
+		go func(j job) {
+			defer wg.Done()
+			runJob(ctx, j)
+		}(j)
+	}
+
+	logrus.WithField("total_job", len(jobs)).Info("run job scheduler")
+	wg.Wait()
+
+	return nil
+}
+
+func runJob(ctx context.Context, j job) {
+	ticker := time.NewTicker(j.interval)
+	defer ticker.Stop()
+
+	for {
+		err := j.run(ctx)
+		if err != nil {
+			logrus.WithField("job", j.name).Errorf("job.run: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package job
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Hidayathamir/go-user/config"
+	"github.com/Hidayathamir/go-user/internal/usecase"
+	"github.com/sirupsen/logrus"
+)
+
+// Webhook is controller job for outgoing webhook related.
+type Webhook struct {
+	cfg            config.Config
+	usecaseWebhook usecase.IWebhook
+}
+
+func newWebhook(cfg config.Config, usecaseWebhook usecase.IWebhook) *Webhook {
+	return &Webhook{
+		cfg:            cfg,
+		usecaseWebhook: usecaseWebhook,
+	}
+}
+
+func (w *Webhook) deliverWebhooks(ctx context.Context) error {
+	count, err := w.usecaseWebhook.DeliverWebhooks(ctx)
+	if err != nil {
+		return fmt.Errorf("Webhook.usecaseWebhook.DeliverWebhooks: %w", err)
+	}
+
+	if count > 0 {
+		logrus.WithField("total_delivered", count).Info("deliver webhooks")
+	}
+
+	return nil
+}
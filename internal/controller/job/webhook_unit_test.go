@@ -0,0 +1,56 @@
+package job
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Hidayathamir/go-user/config"
+	"github.com/Hidayathamir/go-user/internal/usecase/mockusecase"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUnitWebhookDeliverWebhooks(t *testing.T) {
+	t.Parallel()
+
+	t.Run("call usecase DeliverWebhooks success should return success", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		usecaseWebhook := mockusecase.NewMockIWebhook(ctrl)
+
+		w := &Webhook{
+			cfg:            config.Config{},
+			usecaseWebhook: usecaseWebhook,
+		}
+
+		usecaseWebhook.EXPECT().DeliverWebhooks(gomock.Any()).Return(int64(3), nil)
+
+		err := w.deliverWebhooks(context.Background())
+
+		require.NoError(t, err)
+	})
+	t.Run("call usecase DeliverWebhooks error should return error", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		usecaseWebhook := mockusecase.NewMockIWebhook(ctrl)
+
+		w := &Webhook{
+			cfg:            config.Config{},
+			usecaseWebhook: usecaseWebhook,
+		}
+
+		usecaseWebhook.EXPECT().DeliverWebhooks(gomock.Any()).Return(int64(0), assert.AnError)
+
+		err := w.deliverWebhooks(context.Background())
+
+		require.Error(t, err)
+		require.ErrorIs(t, err, assert.AnError)
+	})
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/Hidayathamir/go-user/config"
+	"github.com/Hidayathamir/go-user/internal/pkg/metrics"
 	"github.com/Hidayathamir/go-user/pkg/gouser"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/pkg/errors"
@@ -16,17 +17,26 @@ import (
 
 const (
 	keyUserID = "user_id"
+	keyJTI    = "jti"
 )
 
-// GenerateUserJWTToken return jwt string.
-func GenerateUserJWTToken(userID int64, cfg config.Config) string {
-	expireIn := time.Hour * time.Duration(cfg.JWT.ExpireHour)
+// UserJWTClaims hold claims inside user JWT.
+type UserJWTClaims struct {
+	UserID int64
+	// JTI is JWT ID, it binds the token to a session.
+	JTI string
+}
+
+// GenerateUserJWTToken return jwt string. jti is the session JWT ID the token
+// is bound to.
+func GenerateUserJWTToken(userID int64, jti string, cfg config.Config) string {
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
 		keyUserID: userID,
-		"exp":     time.Now().Add(expireIn).Unix(),
+		keyJTI:    jti,
+		"exp":     GetJWTExpiredAt(cfg, time.Now()).Unix(),
 	})
 
-	tokenString, err := token.SignedString([]byte(cfg.JWT.SignedKey))
+	tokenString, err := token.SignedString([]byte(cfg.Live().JWT.SignedKey))
 	if err != nil {
 		logrus.Warnf("jwt.Token.SigndString: %v", err)
 	}
@@ -36,6 +46,11 @@ func GenerateUserJWTToken(userID int64, cfg config.Config) string {
 	return tokenString
 }
 
+// GetJWTExpiredAt return expired time of JWT generated at now.
+func GetJWTExpiredAt(cfg config.Config, now time.Time) time.Time {
+	return now.Add(time.Hour * time.Duration(cfg.Live().JWT.ExpireHour))
+}
+
 // validateUserJWTToken parses and validates and verifies JWT token string.
 func validateUserJWTToken(cfg config.Config, tokenString string) (jwt.MapClaims, error) {
 	tokenString = strings.ReplaceAll(tokenString, "Bearer ", "")
@@ -43,7 +58,7 @@ func validateUserJWTToken(cfg config.Config, tokenString string) (jwt.MapClaims,
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, fmt.Errorf("token.Method.(*jwt.SigningMethodHMAC): %v", token.Header["alg"])
 		}
-		return []byte(cfg.JWT.SignedKey), nil
+		return []byte(cfg.Live().JWT.SignedKey), nil
 	}
 
 	token, err := jwt.Parse(tokenString, keyFunc)
@@ -81,6 +96,21 @@ func getUserIDFromJWTClaims(claims jwt.MapClaims) (int64, error) {
 	return 0, errors.New("type assert user id in jwt map claims as int, int64, int32, float64, float32")
 }
 
+// getJTIFromJWTClaims return jti from jwt.MapClaims.
+func getJTIFromJWTClaims(claims jwt.MapClaims) (string, error) {
+	jtiAny, ok := claims[keyJTI]
+	if !ok {
+		return "", errors.New("jwt.MapClaims[keyJTI]")
+	}
+
+	jti, ok := jtiAny.(string)
+	if !ok || jti == "" {
+		return "", errors.New("type assert jti in jwt map claims as non empty string")
+	}
+
+	return jti, nil
+}
+
 // GetUserIDFromJWTTokenString return userID from JWT token string.
 func GetUserIDFromJWTTokenString(cfg config.Config, tokenString string) (int64, error) {
 	claims, err := validateUserJWTToken(cfg, tokenString)
@@ -97,8 +127,39 @@ func GetUserIDFromJWTTokenString(cfg config.Config, tokenString string) (int64,
 	return userID, nil
 }
 
+// GetUserJWTClaimsFromJWTTokenString return user id and jti from JWT token
+// string.
+func GetUserJWTClaimsFromJWTTokenString(cfg config.Config, tokenString string) (UserJWTClaims, error) {
+	claims, err := validateUserJWTToken(cfg, tokenString)
+	if err != nil {
+		err := fmt.Errorf("ValidateUserJWTToken: %w", err)
+		return UserJWTClaims{}, fmt.Errorf("%w: %w", gouser.ErrJWTAuth, err)
+	}
+
+	userID, err := getUserIDFromJWTClaims(claims)
+	if err != nil {
+		err := fmt.Errorf("GetUserIDFromJWTClaims: %w", err)
+		return UserJWTClaims{}, fmt.Errorf("%w: %w", gouser.ErrJWTAuth, err)
+	}
+
+	jti, err := getJTIFromJWTClaims(claims)
+	if err != nil {
+		err := fmt.Errorf("getJTIFromJWTClaims: %w", err)
+		return UserJWTClaims{}, fmt.Errorf("%w: %w", gouser.ErrJWTAuth, err)
+	}
+
+	userJWTClaims := UserJWTClaims{
+		UserID: userID,
+		JTI:    jti,
+	}
+
+	return userJWTClaims, nil
+}
+
 // GenerateHashPassword generate hashed password.
 func GenerateHashPassword(password string) (string, error) {
+	defer observeBcryptDuration(metrics.BcryptOperationHash, time.Now())
+
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
 	if err != nil {
 		return "", fmt.Errorf("bcrypt.GenerateFromPassword: %w", err)
@@ -109,9 +170,15 @@ func GenerateHashPassword(password string) (string, error) {
 // CompareHashAndPassword compares hashed password with password, return error
 // on failure.
 func CompareHashAndPassword(hashedPassword string, password string) error {
+	defer observeBcryptDuration(metrics.BcryptOperationCompare, time.Now())
+
 	err := bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(password))
 	if err != nil {
 		return fmt.Errorf("bcrypt.CompareHashAndPassword: %w", err)
 	}
 	return nil
 }
+
+func observeBcryptDuration(operation string, start time.Time) {
+	metrics.BcryptDuration.WithLabelValues(operation).Observe(time.Since(start).Seconds())
+}
@@ -3,8 +3,15 @@ package header
 
 // http header key.
 const (
-	ContentType   = "Content-Type"
-	Authorization = "Authorization"
+	ContentType        = "Content-Type"
+	ContentDisposition = "Content-Disposition"
+	Authorization      = "Authorization"
+	RequestID          = "X-Request-ID"
+	APIKey             = "X-API-Key"
+	RateLimitLimit     = "RateLimit-Limit"
+	RateLimitRemaining = "RateLimit-Remaining"
+	RateLimitReset     = "RateLimit-Reset"
+	RetryAfter         = "Retry-After"
 )
 
 // http header value.
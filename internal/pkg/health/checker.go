@@ -0,0 +1,110 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Status of check and report.
+const (
+	StatusUp   = "up"
+	StatusDown = "down"
+)
+
+// Check is a check of app dependency, e.g postgres.
+type Check struct {
+	Name string
+	// Timeout of one Check call, Check is failed when it is exceeded.
+	Timeout time.Duration
+	Check   func(ctx context.Context) error
+}
+
+// CheckResult is result of a Check.
+type CheckResult struct {
+	Status     string `json:"status"`
+	Error      string `json:"error,omitempty"`
+	DurationMS int64  `json:"duration_ms"`
+}
+
+// Report is result of all checks, Status is up only when every check is up.
+type Report struct {
+	Status string                 `json:"status"`
+	Checks map[string]CheckResult `json:"checks"`
+}
+
+// IsUp return true when every check is up.
+func (r Report) IsUp() bool {
+	return r.Status == StatusUp
+}
+
+// Checker run checks.
+type Checker struct {
+	checks []Check
+}
+
+// NewChecker return *Checker running checks.
+func NewChecker(checks ...Check) *Checker {
+	return &Checker{checks: checks}
+}
+
+// Check run all checks concurrently, each within its own timeout.
+func (c *Checker) Check(ctx context.Context) Report {
+	report := Report{
+		Status: StatusUp,
+		Checks: make(map[string]CheckResult, len(c.checks)),
+	}
+
+	mu := sync.Mutex{}
+	wg := sync.WaitGroup{}
+	for _, check := range c.checks {
+		wg.Add(1)
+		go func(check Check) {
+			defer wg.Done()
+
+			checkResult := runCheck(ctx, check)
+
+			mu.Lock()
+			defer mu.Unlock()
+			report.Checks[check.Name] = checkResult
+			if checkResult.Status != StatusUp {
+				report.Status = StatusDown
+			}
+		}(check)
+	}
+	wg.Wait()
+
+	return report
+}
+
+func runCheck(ctx context.Context, check Check) CheckResult {
+	ctx, cancel := context.WithTimeout(ctx, check.Timeout)
+	defer cancel()
+
+	start := time.Now()
+
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- check.Check(ctx)
+	}()
+
+	var err error
+	select {
+	case err = <-errCh:
+	case <-ctx.Done():
+		// check which ignores ctx must not block the report.
+		err = fmt.Errorf("timeout after %s: %w", check.Timeout, ctx.Err())
+	}
+
+	checkResult := CheckResult{
+		Status:     StatusUp,
+		DurationMS: time.Since(start).Milliseconds(),
+	}
+	if err != nil {
+		checkResult.Status = StatusDown
+		checkResult.Error = err.Error()
+	}
+
+	return checkResult
+}
@@ -0,0 +1,71 @@
+package health
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitCheckerCheck(t *testing.T) {
+	t.Parallel()
+
+	t.Run("all checks up should return up", func(t *testing.T) {
+		t.Parallel()
+
+		readiness := NewReadiness()
+		readiness.SetReady(true)
+
+		checker := NewChecker(
+			Check{Name: "app", Timeout: time.Second, Check: readiness.Check},
+			Check{Name: "postgres", Timeout: time.Second, Check: func(context.Context) error { return nil }},
+		)
+
+		report := checker.Check(context.Background())
+
+		assert.True(t, report.IsUp())
+		require.Len(t, report.Checks, 2)
+		assert.Equal(t, StatusUp, report.Checks["app"].Status)
+		assert.Equal(t, StatusUp, report.Checks["postgres"].Status)
+		assert.Empty(t, report.Checks["postgres"].Error)
+	})
+	t.Run("a check down should return down with error", func(t *testing.T) {
+		t.Parallel()
+
+		checker := NewChecker(
+			Check{Name: "app", Timeout: time.Second, Check: NewReadiness().Check},
+			Check{Name: "postgres", Timeout: time.Second, Check: func(context.Context) error { return assert.AnError }},
+			Check{Name: "migration", Timeout: time.Second, Check: func(context.Context) error { return nil }},
+		)
+
+		report := checker.Check(context.Background())
+
+		assert.False(t, report.IsUp())
+		assert.Equal(t, StatusDown, report.Status)
+		assert.Equal(t, StatusDown, report.Checks["app"].Status)
+		assert.Equal(t, StatusDown, report.Checks["postgres"].Status)
+		assert.Equal(t, assert.AnError.Error(), report.Checks["postgres"].Error)
+		assert.Equal(t, StatusUp, report.Checks["migration"].Status)
+	})
+	t.Run("check exceeding timeout should return down", func(t *testing.T) {
+		t.Parallel()
+
+		release := make(chan struct{})
+		defer close(release)
+
+		checker := NewChecker(
+			Check{Name: "postgres", Timeout: 10 * time.Millisecond, Check: func(context.Context) error {
+				<-release // ignore ctx.
+				return nil
+			}},
+		)
+
+		report := checker.Check(context.Background())
+
+		assert.False(t, report.IsUp())
+		assert.Equal(t, StatusDown, report.Checks["postgres"].Status)
+		assert.Contains(t, report.Checks["postgres"].Error, context.DeadlineExceeded.Error())
+	})
+}
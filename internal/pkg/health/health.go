@@ -0,0 +1,38 @@
+// Package health contains app readiness and dependency check related.
+package health
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+)
+
+// Readiness hold whether app is ready to serve traffic. It is false until all
+// servers run and becomes false again once shutdown starts, so load balancer
+// stops routing new requests before servers stop.
+type Readiness struct {
+	ready atomic.Bool
+}
+
+// NewReadiness return not ready *Readiness.
+func NewReadiness() *Readiness {
+	return &Readiness{}
+}
+
+// SetReady set readiness.
+func (r *Readiness) SetReady(ready bool) {
+	r.ready.Store(ready)
+}
+
+// IsReady return readiness.
+func (r *Readiness) IsReady() bool {
+	return r.ready.Load()
+}
+
+// Check return error when app is not ready, use it as Check.Check.
+func (r *Readiness) Check(context.Context) error {
+	if !r.IsReady() {
+		return errors.New("app is starting or shutting down")
+	}
+	return nil
+}
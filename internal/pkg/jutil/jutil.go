@@ -4,11 +4,17 @@ package jutil
 import (
 	"encoding/json"
 
+	"github.com/Hidayathamir/go-user/pkg/gouser"
 	"github.com/sirupsen/logrus"
 )
 
-// ToJSONString return JSON string of v, if err return "" and do logging.
+// ToJSONString return JSON string of v, if err return "" and do logging. If v
+// is gouser.Redactor its secret is redacted, so the string is safe to log.
 func ToJSONString(v any) string {
+	if redactor, ok := v.(gouser.Redactor); ok {
+		v = redactor.Redact()
+	}
+
 	jsonByte, err := json.Marshal(v)
 	if err != nil {
 		logrus.Warnf("json.Marshal: %v", err)
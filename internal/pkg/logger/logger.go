@@ -0,0 +1,93 @@
+// Package logger contains context carried logger, so logs of a request are
+// correlated by request id, user id, route and trace id.
+package logger
+
+import (
+	"context"
+	"sync"
+
+	"github.com/Hidayathamir/go-user/pkg/gouser"
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Log field list.
+const (
+	FieldRequestID = "request_id"
+	FieldUserID    = "user_id"
+	FieldRoute     = "route"
+	FieldTraceID   = "trace_id"
+)
+
+type fieldsKey struct{}
+
+type loggerKey struct{}
+
+// fields is log fields of a request. It is mutable so fields known late, e.g
+// user id after authentication, are visible to access log of the request.
+type fields struct {
+	mu     sync.Mutex
+	fields logrus.Fields
+}
+
+// NewContext return ctx carrying log fields f, replacing fields carried by
+// parent.
+func NewContext(ctx context.Context, f logrus.Fields) context.Context {
+	copied := logrus.Fields{}
+	for k, v := range f {
+		copied[k] = v
+	}
+	return context.WithValue(ctx, fieldsKey{}, &fields{fields: copied})
+}
+
+// WithLogger return ctx carrying l, FromContext log with l instead of
+// standard logger.
+func WithLogger(ctx context.Context, l *logrus.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, l)
+}
+
+// AddFields add f to log fields carried by ctx, visible to every logger of
+// the request. No op if ctx carries no fields.
+func AddFields(ctx context.Context, f logrus.Fields) {
+	carried, ok := ctx.Value(fieldsKey{}).(*fields)
+	if !ok {
+		return
+	}
+
+	carried.mu.Lock()
+	defer carried.mu.Unlock()
+
+	for k, v := range f {
+		carried.fields[k] = v
+	}
+}
+
+// FromContext return logger with log fields carried by ctx, request id and
+// trace id of span in ctx. It is logger carried by ctx, see WithLogger, or
+// standard logger if ctx carries none.
+func FromContext(ctx context.Context) *logrus.Entry {
+	f := logrus.Fields{}
+
+	if carried, ok := ctx.Value(fieldsKey{}).(*fields); ok {
+		carried.mu.Lock()
+		for k, v := range carried.fields {
+			f[k] = v
+		}
+		carried.mu.Unlock()
+	}
+
+	if requestID := gouser.RequestIDFromContext(ctx); requestID != "" {
+		f[FieldRequestID] = requestID
+	}
+
+	if spanContext := trace.SpanContextFromContext(ctx); spanContext.HasTraceID() {
+		f[FieldTraceID] = spanContext.TraceID().String()
+	}
+
+	l, ok := ctx.Value(loggerKey{}).(*logrus.Logger)
+	if !ok {
+		l = logrus.StandardLogger()
+	}
+
+	return l.WithContext(ctx).WithFields(f)
+}
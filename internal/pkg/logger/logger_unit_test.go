@@ -0,0 +1,68 @@
+package logger
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Hidayathamir/go-user/pkg/gouser"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestUnitFromContext(t *testing.T) {
+	t.Parallel()
+
+	t.Run("logger should carry fields, request id and trace id", func(t *testing.T) {
+		t.Parallel()
+
+		traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+		spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+		ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID: traceID,
+			SpanID:  spanID,
+		}))
+		ctx = gouser.WithRequestID(ctx, "req-1")
+		ctx = NewContext(ctx, logrus.Fields{FieldRoute: "/api/v1/users/:username"})
+
+		entry := FromContext(ctx)
+
+		assert.Equal(t, "req-1", entry.Data[FieldRequestID])
+		assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", entry.Data[FieldTraceID])
+		assert.Equal(t, "/api/v1/users/:username", entry.Data[FieldRoute])
+	})
+	t.Run("added fields should be visible to logger of parent context", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := NewContext(context.Background(), logrus.Fields{FieldRoute: "/login"})
+		childCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		AddFields(childCtx, logrus.Fields{FieldUserID: int64(99)})
+
+		assert.Equal(t, int64(99), FromContext(ctx).Data[FieldUserID])
+	})
+	t.Run("context without fields should return standard logger", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		AddFields(ctx, logrus.Fields{FieldUserID: int64(99)})
+
+		entry := FromContext(ctx)
+
+		assert.Empty(t, entry.Data)
+		assert.Equal(t, logrus.StandardLogger(), entry.Logger)
+	})
+	t.Run("context with logger should return that logger", func(t *testing.T) {
+		t.Parallel()
+
+		l := logrus.New()
+		ctx := WithLogger(context.Background(), l)
+		ctx = NewContext(ctx, logrus.Fields{FieldRoute: "/login"})
+
+		entry := FromContext(ctx)
+
+		assert.Equal(t, l, entry.Logger)
+		assert.Equal(t, "/login", entry.Data[FieldRoute])
+	})
+}
@@ -0,0 +1,56 @@
+package logger
+
+import (
+	"strings"
+
+	"github.com/Hidayathamir/go-user/pkg/gouser"
+	"github.com/sirupsen/logrus"
+)
+
+// secretFieldNames is substring of log field name whose value is secret,
+// matched case insensitively.
+var secretFieldNames = []string{"password", "token", "authorization", "secret", "jwt"}
+
+// RedactHook is logrus hook scrubbing secret from log fields, so it is never
+// written whatever the formatter is. Value of field named like password,
+// token, authorization, secret or jwt is replaced by gouser.Redacted, value
+// implementing gouser.Redactor is replaced by its redacted copy.
+type RedactHook struct{}
+
+var _ logrus.Hook = RedactHook{}
+
+// NewRedactHook return RedactHook, add it with logrus.AddHook before other
+// hooks so they see redacted fields.
+func NewRedactHook() RedactHook {
+	return RedactHook{}
+}
+
+// Levels implements logrus.Hook.
+func (RedactHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+// Fire implements logrus.Hook. Entry data is copy owned by the entry being
+// logged, so it is safe to modify.
+func (RedactHook) Fire(entry *logrus.Entry) error {
+	for key, value := range entry.Data {
+		if isSecretFieldName(key) {
+			entry.Data[key] = gouser.Redacted
+			continue
+		}
+		if redactor, ok := value.(gouser.Redactor); ok {
+			entry.Data[key] = redactor.Redact()
+		}
+	}
+	return nil
+}
+
+func isSecretFieldName(key string) bool {
+	key = strings.ToLower(key)
+	for _, name := range secretFieldNames {
+		if strings.Contains(key, name) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,59 @@
+package logger
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/Hidayathamir/go-user/pkg/gouser"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnitRedactHook(t *testing.T) {
+	t.Parallel()
+
+	newLogger := func(formatter logrus.Formatter) (*logrus.Logger, *bytes.Buffer) {
+		buf := &bytes.Buffer{}
+		l := logrus.New()
+		l.SetOutput(buf)
+		l.SetFormatter(formatter)
+		l.AddHook(NewRedactHook())
+		return l, buf
+	}
+
+	for name, formatter := range map[string]logrus.Formatter{
+		"text": &logrus.TextFormatter{},
+		"json": &logrus.JSONFormatter{},
+	} {
+		t.Run(name+" formatter should not write secret field", func(t *testing.T) {
+			t.Parallel()
+
+			l, buf := newLogger(formatter)
+
+			l.WithFields(logrus.Fields{
+				"password":      "mypassword",
+				"Authorization": "Bearer myjwt",
+				"refresh_token": "mytoken",
+				"user_jwt":      "myuserjwt",
+				"username":      "hidayat",
+			}).Info("login")
+
+			assert.NotContains(t, buf.String(), "mypassword")
+			assert.NotContains(t, buf.String(), "myjwt")
+			assert.NotContains(t, buf.String(), "mytoken")
+			assert.NotContains(t, buf.String(), "myuserjwt")
+			assert.Contains(t, buf.String(), "hidayat")
+			assert.Contains(t, buf.String(), gouser.Redacted)
+		})
+		t.Run(name+" formatter should redact redactor value", func(t *testing.T) {
+			t.Parallel()
+
+			l, buf := newLogger(formatter)
+
+			l.WithField("req", gouser.ReqLoginUser{Username: "hidayat", Password: "mypassword"}).Info("login")
+
+			assert.NotContains(t, buf.String(), "mypassword")
+			assert.Contains(t, buf.String(), "hidayat")
+		})
+	}
+}
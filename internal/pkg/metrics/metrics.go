@@ -0,0 +1,96 @@
+// Package metrics contains prometheus metrics of the app.
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const namespace = "gouser"
+
+// Registry hold every metric of the app, serve it with promhttp.HandlerFor.
+var Registry = prometheus.NewRegistry()
+
+var factory = promauto.With(Registry)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+}
+
+// Result label value.
+const (
+	ResultSuccess = "success"
+	ResultFailure = "failure"
+)
+
+// HTTP metrics, route is gin route template, e.g "/api/v1/users/:username".
+var (
+	HTTPRequestsTotal = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "Total HTTP requests.",
+	}, []string{"method", "route", "status"})
+
+	HTTPRequestDuration = factory.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "HTTP request latency.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+)
+
+// GRPC metrics, method is full method name, e.g "/gousergrpc.Auth/LoginUser".
+var (
+	GRPCRequestsTotal = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "grpc_requests_total",
+		Help:      "Total GRPC requests.",
+	}, []string{"method", "code"})
+
+	GRPCRequestDuration = factory.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "grpc_request_duration_seconds",
+		Help:      "GRPC request latency, of stream it is the stream lifetime.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "code"})
+)
+
+// Auth metrics, reason is empty on success.
+var (
+	LoginTotal = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "login_total",
+		Help:      "Total login attempts by result and failure reason.",
+	}, []string{"result", "reason"})
+
+	RegisterTotal = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "register_total",
+		Help:      "Total registration attempts by result and failure reason.",
+	}, []string{"result", "reason"})
+
+	BcryptDuration = factory.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "bcrypt_duration_seconds",
+		Help:      "Bcrypt duration by operation, \"hash\" or \"compare\".",
+		Buckets:   []float64{.01, .025, .05, .1, .25, .5, 1, 2.5},
+	}, []string{"operation"})
+)
+
+// Bcrypt operation label value.
+const (
+	BcryptOperationHash    = "hash"
+	BcryptOperationCompare = "compare"
+)
+
+// RateLimitedTotal count requests refused by rate limiter, group is route
+// group, e.g "auth".
+var RateLimitedTotal = factory.NewCounterVec(prometheus.CounterOpts{
+	Namespace: namespace,
+	Name:      "rate_limited_total",
+	Help:      "Total requests refused by rate limiter by route group.",
+}, []string{"group"})
@@ -0,0 +1,57 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+type fakePGPoolStat struct{}
+
+func (fakePGPoolStat) AcquireCount() int64            { return 10 }
+func (fakePGPoolStat) AcquireDuration() time.Duration { return 1500 * time.Millisecond }
+func (fakePGPoolStat) AcquiredConns() int32           { return 3 }
+func (fakePGPoolStat) CanceledAcquireCount() int64    { return 1 }
+func (fakePGPoolStat) EmptyAcquireCount() int64       { return 2 }
+func (fakePGPoolStat) IdleConns() int32               { return 4 }
+func (fakePGPoolStat) MaxConns() int32                { return 10 }
+func (fakePGPoolStat) TotalConns() int32              { return 7 }
+
+func TestUnitPGPoolCollector(t *testing.T) {
+	t.Parallel()
+
+	t.Run("collect should expose pool stat", func(t *testing.T) {
+		t.Parallel()
+
+		registry := prometheus.NewRegistry()
+		registry.MustRegister(NewPGPoolCollector(func() PGPoolStat { return fakePGPoolStat{} }))
+
+		expected := `
+# HELP gouser_pgxpool_acquire_duration_seconds_total Total time spent acquiring connection, including waiting for a free one.
+# TYPE gouser_pgxpool_acquire_duration_seconds_total counter
+gouser_pgxpool_acquire_duration_seconds_total 1.5
+# HELP gouser_pgxpool_acquired_conns Connections currently acquired.
+# TYPE gouser_pgxpool_acquired_conns gauge
+gouser_pgxpool_acquired_conns 3
+# HELP gouser_pgxpool_empty_acquire_total Total acquires that waited because the pool was empty.
+# TYPE gouser_pgxpool_empty_acquire_total counter
+gouser_pgxpool_empty_acquire_total 2
+# HELP gouser_pgxpool_idle_conns Connections currently idle.
+# TYPE gouser_pgxpool_idle_conns gauge
+gouser_pgxpool_idle_conns 4
+`
+		err := testutil.GatherAndCompare(registry, strings.NewReader(expected),
+			"gouser_pgxpool_acquire_duration_seconds_total",
+			"gouser_pgxpool_acquired_conns",
+			"gouser_pgxpool_empty_acquire_total",
+			"gouser_pgxpool_idle_conns",
+		)
+
+		require.NoError(t, err)
+		require.Equal(t, 8, testutil.CollectAndCount(NewPGPoolCollector(func() PGPoolStat { return fakePGPoolStat{} })))
+	})
+}
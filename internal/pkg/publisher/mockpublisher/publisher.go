@@ -0,0 +1,55 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: publisher.go
+//
+// Generated by this command:
+//
+//	mockgen -source=publisher.go -destination=mockpublisher/publisher.go -package=mockpublisher
+//
+
+// Package mockpublisher is a generated GoMock package.
+package mockpublisher
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gouser "github.com/Hidayathamir/go-user/pkg/gouser"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIPublisher is a mock of IPublisher interface.
+type MockIPublisher struct {
+	ctrl     *gomock.Controller
+	recorder *MockIPublisherMockRecorder
+}
+
+// MockIPublisherMockRecorder is the mock recorder for MockIPublisher.
+type MockIPublisherMockRecorder struct {
+	mock *MockIPublisher
+}
+
+// NewMockIPublisher creates a new mock instance.
+func NewMockIPublisher(ctrl *gomock.Controller) *MockIPublisher {
+	mock := &MockIPublisher{ctrl: ctrl}
+	mock.recorder = &MockIPublisherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIPublisher) EXPECT() *MockIPublisherMockRecorder {
+	return m.recorder
+}
+
+// Publish mocks base method.
+func (m *MockIPublisher) Publish(ctx context.Context, event gouser.Event) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Publish", ctx, event)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockIPublisherMockRecorder) Publish(ctx, event any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockIPublisher)(nil).Publish), ctx, event)
+}
@@ -0,0 +1,149 @@
+// Package publisher contains domain event publisher.
+package publisher
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/Hidayathamir/go-user/config"
+	"github.com/Hidayathamir/go-user/internal/pkg/header"
+	"github.com/Hidayathamir/go-user/pkg/gouser"
+	"github.com/sirupsen/logrus"
+)
+
+//go:generate mockgen -source=publisher.go -destination=mockpublisher/publisher.go -package=mockpublisher
+
+// webhookTimeout is how long webhook publisher waits for the receiver.
+const webhookTimeout = 10 * time.Second
+
+// IPublisher contains abstraction of domain event publisher.
+type IPublisher interface {
+	// Publish deliver event. Error means the event is not delivered and will
+	// be published again later.
+	Publish(ctx context.Context, event gouser.Event) error
+}
+
+// New return IPublisher chosen by cfg.Outbox.Publisher.
+func New(cfg config.Config) IPublisher {
+	switch cfg.Outbox.Publisher {
+	case config.OutboxPublisherFile:
+		return NewFile(cfg.Outbox.FilePath)
+	case config.OutboxPublisherWebhook:
+		return NewWebhook(cfg.Outbox.WebhookURL, &http.Client{Timeout: webhookTimeout})
+	default:
+		return NewLog()
+	}
+}
+
+// Log implement IPublisher, it writes event to application log.
+type Log struct{}
+
+var _ IPublisher = &Log{}
+
+// NewLog return *Log which implement IPublisher.
+func NewLog() *Log {
+	return &Log{}
+}
+
+// Publish write event to application log.
+func (l *Log) Publish(_ context.Context, event gouser.Event) error {
+	logrus.
+		WithField("event id", event.ID).
+		WithField("event type", event.Type).
+		WithField("user id", event.UserID).
+		WithField("data", string(event.Data)).
+		Info("publish event")
+	return nil
+}
+
+// File implement IPublisher, it appends event as one JSON line to a file.
+type File struct {
+	path string
+}
+
+var _ IPublisher = &File{}
+
+// NewFile return *File which implement IPublisher.
+func NewFile(path string) *File {
+	return &File{path: path}
+}
+
+// Publish append event as one JSON line to the file.
+func (f *File) Publish(_ context.Context, event gouser.Event) error {
+	line, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("json.Marshal: %w", err)
+	}
+	line = append(line, '\n')
+
+	file, err := os.OpenFile(f.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("os.OpenFile: %w", err)
+	}
+
+	_, err = file.Write(line)
+	if err != nil {
+		_ = file.Close()
+		return fmt.Errorf("os.File.Write: %w", err)
+	}
+
+	err = file.Close()
+	if err != nil {
+		return fmt.Errorf("os.File.Close: %w", err)
+	}
+
+	return nil
+}
+
+// Webhook implement IPublisher, it POST event as JSON to an URL.
+type Webhook struct {
+	url    string
+	client *http.Client
+}
+
+var _ IPublisher = &Webhook{}
+
+// NewWebhook return *Webhook which implement IPublisher.
+func NewWebhook(url string, client *http.Client) *Webhook {
+	return &Webhook{
+		url:    url,
+		client: client,
+	}
+}
+
+// Publish POST event as JSON to the URL. Response status other than 2xx is
+// error.
+func (w *Webhook) Publish(ctx context.Context, event gouser.Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("json.Marshal: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("http.NewRequestWithContext: %w", err)
+	}
+	httpReq.Header.Add(header.ContentType, header.AppJSON)
+
+	httpRes, err := w.client.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf("http.Client.Do: %w", err)
+	}
+	defer func() {
+		err := httpRes.Body.Close()
+		if err != nil {
+			logrus.Warnf("http.Response.Body.Close: %v", err)
+		}
+	}()
+
+	if httpRes.StatusCode < 200 || httpRes.StatusCode >= 300 {
+		return fmt.Errorf("webhook response status code %d", httpRes.StatusCode)
+	}
+
+	return nil
+}
@@ -0,0 +1,118 @@
+package publisher
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Hidayathamir/go-user/config"
+	"github.com/Hidayathamir/go-user/pkg/gouser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitNew(t *testing.T) {
+	t.Parallel()
+
+	t.Run("publisher file should return *File", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := config.Config{Outbox: config.Outbox{Publisher: config.OutboxPublisherFile, FilePath: "events.jsonl"}}
+
+		assert.IsType(t, &File{}, New(cfg))
+	})
+	t.Run("publisher webhook should return *Webhook", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := config.Config{Outbox: config.Outbox{Publisher: config.OutboxPublisherWebhook, WebhookURL: "http://localhost"}}
+
+		assert.IsType(t, &Webhook{}, New(cfg))
+	})
+	t.Run("publisher log should return *Log", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := config.Config{Outbox: config.Outbox{Publisher: config.OutboxPublisherLog}}
+
+		assert.IsType(t, &Log{}, New(cfg))
+	})
+}
+
+func TestUnitFilePublish(t *testing.T) {
+	t.Parallel()
+
+	t.Run("publish should append one JSON line per event", func(t *testing.T) {
+		t.Parallel()
+
+		path := filepath.Join(t.TempDir(), "events.jsonl")
+		f := NewFile(path)
+
+		err := f.Publish(context.Background(), gouser.Event{ID: 1, Type: gouser.EventTypeUserRegistered, Data: json.RawMessage(`{}`)})
+		require.NoError(t, err)
+		err = f.Publish(context.Background(), gouser.Event{ID: 2, Type: gouser.EventTypeUserDeleted, Data: json.RawMessage(`{}`)})
+		require.NoError(t, err)
+
+		content, err := os.ReadFile(path)
+		require.NoError(t, err)
+
+		lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+		require.Len(t, lines, 2)
+
+		event := gouser.Event{}
+		require.NoError(t, json.Unmarshal([]byte(lines[1]), &event))
+		assert.Equal(t, int64(2), event.ID)
+		assert.Equal(t, gouser.EventTypeUserDeleted, event.Type)
+	})
+	t.Run("unwritable path should return error", func(t *testing.T) {
+		t.Parallel()
+
+		f := NewFile(filepath.Join(t.TempDir(), "not-exist", "events.jsonl"))
+
+		err := f.Publish(context.Background(), gouser.Event{ID: 1, Data: json.RawMessage(`{}`)})
+
+		require.Error(t, err)
+	})
+}
+
+func TestUnitWebhookPublish(t *testing.T) {
+	t.Parallel()
+
+	t.Run("receiver response 2xx should return nil", func(t *testing.T) {
+		t.Parallel()
+
+		received := gouser.Event{}
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+
+		w := NewWebhook(server.URL, server.Client())
+
+		err := w.Publish(context.Background(), gouser.Event{ID: 7, Type: gouser.EventTypePasswordChanged, Data: json.RawMessage(`{}`)})
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(7), received.ID)
+		assert.Equal(t, gouser.EventTypePasswordChanged, received.Type)
+	})
+	t.Run("receiver response non 2xx should return error", func(t *testing.T) {
+		t.Parallel()
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+
+		w := NewWebhook(server.URL, server.Client())
+
+		err := w.Publish(context.Background(), gouser.Event{ID: 7, Data: json.RawMessage(`{}`)})
+
+		require.Error(t, err)
+		require.ErrorContains(t, err, "500")
+	})
+}
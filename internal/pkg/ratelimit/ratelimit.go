@@ -0,0 +1,156 @@
+// Package ratelimit contains token bucket rate limiting related.
+package ratelimit
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Hidayathamir/go-user/config"
+	"github.com/Hidayathamir/go-user/internal/pkg/auth"
+)
+
+// Route group list, matching rules in config.RateLimit.
+const (
+	GroupAuth     = "auth"
+	GroupUsers    = "users"
+	GroupSessions = "sessions"
+	GroupAdmin    = "admin"
+)
+
+// Rule is token bucket rule. Bucket hold at most Burst tokens, refilled Rate
+// tokens per second, each request takes one token.
+type Rule struct {
+	Rate  float64
+	Burst int
+}
+
+// Result is result of taking a token. Limit zero means request is not rate
+// limited at all.
+type Result struct {
+	Allowed   bool
+	Limit     int
+	Remaining int
+	// Reset is time until bucket is full again.
+	Reset time.Duration
+	// RetryAfter is time until next token, zero if allowed.
+	RetryAfter time.Duration
+}
+
+// ResetSecond return Reset rounded up to second.
+func (r Result) ResetSecond() int {
+	return int(math.Ceil(r.Reset.Seconds()))
+}
+
+// RetryAfterSecond return RetryAfter rounded up to second.
+func (r Result) RetryAfterSecond() int {
+	return int(math.Ceil(r.RetryAfter.Seconds()))
+}
+
+// Client identify who sent the request, controller fills what it knows.
+type Client struct {
+	IP      string
+	APIKey  string
+	UserJWT string
+}
+
+// Limiter limit requests per route group and client by config.RateLimit,
+// rules follow config reloaded live.
+type Limiter struct {
+	cfg   config.Config
+	store Store
+	now   func() time.Time
+}
+
+// NewLimiter return *Limiter keeping buckets in store. Every request is
+// allowed while cfg.RateLimit.Enabled is false.
+func NewLimiter(cfg config.Config, store Store) *Limiter {
+	return &Limiter{
+		cfg:   cfg,
+		store: store,
+		now:   time.Now,
+	}
+}
+
+// Allow take a token from bucket of client in group. Request of group without
+// rule is allowed with zero Result.Limit.
+func (l *Limiter) Allow(ctx context.Context, group string, client Client) (Result, error) {
+	rule, ok := groupRule(l.cfg.Live().RateLimit, group)
+	if !ok {
+		return Result{Allowed: true}, nil
+	}
+
+	key := group + ":" + l.clientKey(rule.Key, client)
+
+	res, err := l.store.Take(ctx, key, Rule{Rate: rule.RequestsPerSecond, Burst: rule.Burst}, l.now())
+	if err != nil {
+		return Result{}, fmt.Errorf("Limiter.store.Take: %w", err)
+	}
+
+	return res, nil
+}
+
+// groupRule return rule of group, false if rate limit is disabled or group is
+// unknown.
+func groupRule(rateLimit config.RateLimit, group string) (config.RateLimitRule, bool) {
+	if !rateLimit.Enabled {
+		return config.RateLimitRule{}, false
+	}
+
+	switch group {
+	case GroupAuth:
+		return rateLimit.Auth, true
+	case GroupUsers:
+		return rateLimit.Users, true
+	case GroupSessions:
+		return rateLimit.Sessions, true
+	case GroupAdmin:
+		return rateLimit.Admin, true
+	default:
+		return config.RateLimitRule{}, false
+	}
+}
+
+// clientKey return bucket key of client by kind, falls back to client ip if
+// client has no known api key or valid jwt, so made up api keys do not get
+// fresh buckets. API key is hashed so it is not kept in store as is.
+func (l *Limiter) clientKey(kind string, client Client) string {
+	switch kind {
+	case config.RateLimitKeyAPIKey:
+		if apiKeySHA256, ok := l.knownAPIKeySHA256(client.APIKey); ok {
+			return "api_key:" + apiKeySHA256
+		}
+	case config.RateLimitKeyUserID:
+		if client.UserJWT != "" {
+			userID, err := auth.GetUserIDFromJWTTokenString(l.cfg, client.UserJWT)
+			if err == nil {
+				return "user_id:" + strconv.FormatInt(userID, 10)
+			}
+		}
+	}
+	return "ip:" + client.IP
+}
+
+// knownAPIKeySHA256 return hex sha256 of apiKey, false if it is not in
+// config.RateLimit.APIKeySHA256.
+func (l *Limiter) knownAPIKeySHA256(apiKey string) (string, bool) {
+	if apiKey == "" {
+		return "", false
+	}
+
+	sum := sha256.Sum256([]byte(apiKey))
+	apiKeySHA256 := hex.EncodeToString(sum[:])
+
+	for _, known := range l.cfg.Live().RateLimit.APIKeySHA256 {
+		if strings.EqualFold(known, apiKeySHA256) {
+			return apiKeySHA256, true
+		}
+	}
+
+	return "", false
+}
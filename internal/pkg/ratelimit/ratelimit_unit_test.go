@@ -0,0 +1,130 @@
+package ratelimit
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/Hidayathamir/go-user/config"
+	"github.com/Hidayathamir/go-user/internal/pkg/auth"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestConfig(key string) config.Config {
+	rule := config.RateLimitRule{RequestsPerSecond: 1, Burst: 1, Key: key}
+	return config.Config{
+		JWT: config.JWT{ExpireHour: 1, SignedKey: "ratelimit-test"},
+		RateLimit: config.RateLimit{
+			Enabled:  true,
+			Auth:     rule,
+			Users:    rule,
+			Sessions: rule,
+			Admin:    rule,
+		},
+	}
+}
+
+func TestUnitLimiterAllow(t *testing.T) {
+	t.Parallel()
+
+	t.Run("disabled limiter should allow every request", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := newTestConfig(config.RateLimitKeyIP)
+		cfg.RateLimit.Enabled = false
+		limiter := NewLimiter(cfg, NewMemoryStore())
+
+		for range 3 {
+			res, err := limiter.Allow(context.Background(), GroupAuth, Client{IP: "1.1.1.1"})
+			require.NoError(t, err)
+			assert.Equal(t, Result{Allowed: true}, res)
+		}
+	})
+	t.Run("unknown group should not be limited", func(t *testing.T) {
+		t.Parallel()
+
+		limiter := NewLimiter(newTestConfig(config.RateLimitKeyIP), NewMemoryStore())
+
+		res, err := limiter.Allow(context.Background(), "unknown", Client{IP: "1.1.1.1"})
+		require.NoError(t, err)
+		assert.Equal(t, Result{Allowed: true}, res)
+	})
+	t.Run("groups should have separate buckets", func(t *testing.T) {
+		t.Parallel()
+
+		limiter := NewLimiter(newTestConfig(config.RateLimitKeyIP), NewMemoryStore())
+
+		res, err := limiter.Allow(context.Background(), GroupAuth, Client{IP: "1.1.1.1"})
+		require.NoError(t, err)
+		assert.True(t, res.Allowed)
+
+		res, err = limiter.Allow(context.Background(), GroupUsers, Client{IP: "1.1.1.1"})
+		require.NoError(t, err)
+		assert.True(t, res.Allowed)
+
+		res, err = limiter.Allow(context.Background(), GroupAuth, Client{IP: "1.1.1.1"})
+		require.NoError(t, err)
+		assert.False(t, res.Allowed)
+	})
+}
+
+func TestUnitLimiterClientKey(t *testing.T) {
+	t.Parallel()
+
+	t.Run("ip key should use client ip", func(t *testing.T) {
+		t.Parallel()
+
+		limiter := NewLimiter(newTestConfig(config.RateLimitKeyIP), NewMemoryStore())
+
+		key := limiter.clientKey(config.RateLimitKeyIP, Client{IP: "1.1.1.1", APIKey: "k"})
+
+		assert.Equal(t, "ip:1.1.1.1", key)
+	})
+	t.Run("known api key should be hashed", func(t *testing.T) {
+		t.Parallel()
+
+		sum := sha256.Sum256([]byte("secret-api-key"))
+		cfg := newTestConfig(config.RateLimitKeyAPIKey)
+		cfg.RateLimit.APIKeySHA256 = []string{strings.ToUpper(hex.EncodeToString(sum[:]))}
+		limiter := NewLimiter(cfg, NewMemoryStore())
+
+		key := limiter.clientKey(config.RateLimitKeyAPIKey, Client{IP: "1.1.1.1", APIKey: "secret-api-key"})
+
+		assert.Equal(t, "api_key:"+hex.EncodeToString(sum[:]), key)
+		assert.NotContains(t, key, "secret-api-key")
+	})
+	t.Run("unknown api key should fall back to ip", func(t *testing.T) {
+		t.Parallel()
+
+		sum := sha256.Sum256([]byte("secret-api-key"))
+		cfg := newTestConfig(config.RateLimitKeyAPIKey)
+		cfg.RateLimit.APIKeySHA256 = []string{hex.EncodeToString(sum[:])}
+		limiter := NewLimiter(cfg, NewMemoryStore())
+
+		key := limiter.clientKey(config.RateLimitKeyAPIKey, Client{IP: "1.1.1.1", APIKey: "made-up-api-key"})
+
+		assert.Equal(t, "ip:1.1.1.1", key)
+	})
+	t.Run("valid jwt should be keyed by user id", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := newTestConfig(config.RateLimitKeyUserID)
+		limiter := NewLimiter(cfg, NewMemoryStore())
+		userJWT := auth.GenerateUserJWTToken(99, "jti", cfg)
+
+		key := limiter.clientKey(config.RateLimitKeyUserID, Client{IP: "1.1.1.1", UserJWT: "Bearer " + userJWT})
+
+		assert.Equal(t, "user_id:99", key)
+	})
+	t.Run("missing api key or invalid jwt should fall back to ip", func(t *testing.T) {
+		t.Parallel()
+
+		limiter := NewLimiter(newTestConfig(config.RateLimitKeyUserID), NewMemoryStore())
+
+		assert.Equal(t, "ip:1.1.1.1", limiter.clientKey(config.RateLimitKeyAPIKey, Client{IP: "1.1.1.1"}))
+		assert.Equal(t, "ip:1.1.1.1", limiter.clientKey(config.RateLimitKeyUserID, Client{IP: "1.1.1.1", UserJWT: "invalid"}))
+	})
+}
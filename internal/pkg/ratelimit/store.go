@@ -0,0 +1,95 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// Store keep token buckets by key. MemoryStore keep them in process memory,
+// implement Store with shared backend, e.g redis, to limit across instances.
+type Store interface {
+	// Take refill bucket of key by rule up to now, then take one token from
+	// it if any.
+	Take(ctx context.Context, key string, rule Rule, now time.Time) (Result, error)
+}
+
+// sweepInterval is how often MemoryStore drop full buckets, a full bucket is
+// the same as no bucket.
+const sweepInterval = time.Minute
+
+// MemoryStore is Store keeping buckets in process memory.
+type MemoryStore struct {
+	mu        sync.Mutex
+	buckets   map[string]bucket
+	lastSweep time.Time
+}
+
+var _ Store = &MemoryStore{}
+
+type bucket struct {
+	tokens    float64
+	updatedAt time.Time
+	fullAt    time.Time
+}
+
+// NewMemoryStore return *MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{buckets: map[string]bucket{}}
+}
+
+// Take implement Store.
+func (m *MemoryStore) Take(_ context.Context, key string, rule Rule, now time.Time) (Result, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.sweep(now)
+
+	burst := float64(rule.Burst)
+
+	b, ok := m.buckets[key]
+	if !ok {
+		b = bucket{tokens: burst, updatedAt: now}
+	}
+
+	elapsed := now.Sub(b.updatedAt).Seconds()
+	if elapsed > 0 {
+		b.tokens = math.Min(burst, b.tokens+elapsed*rule.Rate)
+		b.updatedAt = now
+	}
+
+	res := Result{Limit: rule.Burst}
+	if b.tokens >= 1 {
+		b.tokens--
+		res.Allowed = true
+	} else {
+		res.RetryAfter = secondsToDuration((1 - b.tokens) / rule.Rate)
+	}
+
+	res.Remaining = int(b.tokens)
+	res.Reset = secondsToDuration((burst - b.tokens) / rule.Rate)
+
+	b.fullAt = now.Add(res.Reset)
+	m.buckets[key] = b
+
+	return res, nil
+}
+
+// sweep drop buckets already full by now, at most once per sweepInterval.
+func (m *MemoryStore) sweep(now time.Time) {
+	if now.Sub(m.lastSweep) < sweepInterval {
+		return
+	}
+	m.lastSweep = now
+
+	for key, b := range m.buckets {
+		if !now.Before(b.fullAt) {
+			delete(m.buckets, key)
+		}
+	}
+}
+
+func secondsToDuration(seconds float64) time.Duration {
+	return time.Duration(math.Ceil(seconds * float64(time.Second)))
+}
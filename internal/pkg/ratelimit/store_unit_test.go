@@ -0,0 +1,90 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitMemoryStoreTake(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	rule := Rule{Rate: 0.5, Burst: 2}
+
+	t.Run("burst should be allowed then request should be refused", func(t *testing.T) {
+		t.Parallel()
+
+		store := NewMemoryStore()
+
+		res, err := store.Take(context.Background(), "k", rule, now)
+		require.NoError(t, err)
+		assert.Equal(t, Result{Allowed: true, Limit: 2, Remaining: 1, Reset: 2 * time.Second}, res)
+
+		res, err = store.Take(context.Background(), "k", rule, now)
+		require.NoError(t, err)
+		assert.Equal(t, Result{Allowed: true, Limit: 2, Remaining: 0, Reset: 4 * time.Second}, res)
+
+		res, err = store.Take(context.Background(), "k", rule, now.Add(time.Second))
+		require.NoError(t, err)
+		assert.Equal(t, Result{Allowed: false, Limit: 2, Remaining: 0, Reset: 3 * time.Second, RetryAfter: time.Second}, res)
+	})
+	t.Run("bucket should be refilled by rate", func(t *testing.T) {
+		t.Parallel()
+
+		store := NewMemoryStore()
+
+		for range 2 {
+			_, err := store.Take(context.Background(), "k", rule, now)
+			require.NoError(t, err)
+		}
+
+		res, err := store.Take(context.Background(), "k", rule, now.Add(2*time.Second))
+		require.NoError(t, err)
+		assert.True(t, res.Allowed)
+		assert.Equal(t, 0, res.Remaining)
+	})
+	t.Run("bucket of other key should not be affected", func(t *testing.T) {
+		t.Parallel()
+
+		store := NewMemoryStore()
+
+		for range 3 {
+			_, err := store.Take(context.Background(), "k1", rule, now)
+			require.NoError(t, err)
+		}
+
+		res, err := store.Take(context.Background(), "k2", rule, now)
+		require.NoError(t, err)
+		assert.True(t, res.Allowed)
+	})
+	t.Run("full bucket should be swept", func(t *testing.T) {
+		t.Parallel()
+
+		store := NewMemoryStore()
+
+		_, err := store.Take(context.Background(), "k1", rule, now)
+		require.NoError(t, err)
+		_, err = store.Take(context.Background(), "k2", rule, now.Add(sweepInterval))
+		require.NoError(t, err)
+
+		assert.NotContains(t, store.buckets, "k1")
+		assert.Contains(t, store.buckets, "k2")
+	})
+}
+
+func TestUnitResultSecond(t *testing.T) {
+	t.Parallel()
+
+	t.Run("duration should be rounded up to second", func(t *testing.T) {
+		t.Parallel()
+
+		res := Result{Reset: 1500 * time.Millisecond, RetryAfter: 100 * time.Millisecond}
+
+		assert.Equal(t, 2, res.ResetSecond())
+		assert.Equal(t, 1, res.RetryAfterSecond())
+	})
+}
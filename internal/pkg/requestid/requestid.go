@@ -0,0 +1,29 @@
+// Package requestid contains request id related.
+package requestid
+
+import "github.com/google/uuid"
+
+// maxLen is max length of request id accepted from client.
+const maxLen = 128
+
+// Resolve return requestID sent by client if it is valid, else new random
+// one. Valid request id is 1 to 128 visible ASCII characters, so it is safe to
+// log and echo.
+func Resolve(requestID string) string {
+	if isValid(requestID) {
+		return requestID
+	}
+	return uuid.NewString()
+}
+
+func isValid(requestID string) bool {
+	if requestID == "" || len(requestID) > maxLen {
+		return false
+	}
+	for _, r := range requestID {
+		if r < '!' || r > '~' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,39 @@
+package requestid
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitResolve(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid request id should be kept", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, "req-123_abc", Resolve("req-123_abc"))
+	})
+	t.Run("empty request id should be generated", func(t *testing.T) {
+		t.Parallel()
+
+		requestID := Resolve("")
+
+		_, err := uuid.Parse(requestID)
+		require.NoError(t, err)
+	})
+	t.Run("invalid request id should be replaced", func(t *testing.T) {
+		t.Parallel()
+
+		for _, invalid := range []string{"has space", "new\nline", "ünicode", strings.Repeat("a", maxLen+1)} {
+			requestID := Resolve(invalid)
+
+			assert.NotEqual(t, invalid, requestID)
+			_, err := uuid.Parse(requestID)
+			require.NoError(t, err)
+		}
+	})
+}
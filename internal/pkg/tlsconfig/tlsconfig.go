@@ -0,0 +1,213 @@
+// Package tlsconfig contains server TLS config related.
+package tlsconfig
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/Hidayathamir/go-user/config"
+	"github.com/sirupsen/logrus"
+)
+
+// reloadCheckInterval is how often files are checked for change, on
+// handshake.
+const reloadCheckInterval = time.Second
+
+// NewServerConfig return *tls.Config of cfg, nil if cfg is not enabled.
+// Certificate and client CA are reloaded when their files change, checked on
+// handshake at most once per reloadCheckInterval. Failed reload keeps
+// previous certificate and client CA.
+//
+// If client CA is set, client certificate is requested and verified against
+// it, and required if cfg.RequireClientCert is true. Verified certificate is
+// in tls.ConnectionState.PeerCertificates.
+func NewServerConfig(cfg config.TLS) (*tls.Config, error) {
+	if !cfg.Enabled() {
+		return nil, nil
+	}
+
+	r := &reloader{cfg: cfg}
+
+	err := r.load()
+	if err != nil {
+		return nil, fmt.Errorf("reloader.load: %w", err)
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion:     minVersion(cfg.MinVersion),
+		GetCertificate: r.getCertificate,
+	}
+
+	if cfg.ClientCAFile != "" {
+		// Client certificate is verified by verifyClientCert instead of
+		// tls.Config.ClientCAs, so client CA can be reloaded.
+		tlsConfig.ClientAuth = tls.RequestClientCert
+		if cfg.RequireClientCert {
+			tlsConfig.ClientAuth = tls.RequireAnyClientCert
+		}
+		tlsConfig.VerifyPeerCertificate = r.verifyClientCert
+	}
+
+	return tlsConfig, nil
+}
+
+func minVersion(version string) uint16 {
+	if version == config.TLSVersion13 {
+		return tls.VersionTLS13
+	}
+	return tls.VersionTLS12
+}
+
+// reloader keep certificate and client CA loaded from files.
+type reloader struct {
+	cfg config.TLS
+
+	mu        sync.Mutex
+	cert      *tls.Certificate
+	clientCAs *x509.CertPool
+	modTimes  map[string]time.Time
+	checkedAt time.Time
+}
+
+func (r *reloader) files() []string {
+	files := []string{r.cfg.CertFile, r.cfg.KeyFile}
+	if r.cfg.ClientCAFile != "" {
+		files = append(files, r.cfg.ClientCAFile)
+	}
+	return files
+}
+
+// load read certificate and client CA from files. Caller must hold r.mu once
+// r is in use.
+func (r *reloader) load() error {
+	modTimes, err := statFiles(r.files())
+	if err != nil {
+		return fmt.Errorf("statFiles: %w", err)
+	}
+
+	cert, err := tls.LoadX509KeyPair(r.cfg.CertFile, r.cfg.KeyFile)
+	if err != nil {
+		return fmt.Errorf("tls.LoadX509KeyPair: %w", err)
+	}
+
+	var clientCAs *x509.CertPool
+	if r.cfg.ClientCAFile != "" {
+		clientCAs, err = loadCertPool(r.cfg.ClientCAFile)
+		if err != nil {
+			return fmt.Errorf("loadCertPool: %w", err)
+		}
+	}
+
+	r.cert = &cert
+	r.clientCAs = clientCAs
+	r.modTimes = modTimes
+
+	return nil
+}
+
+// reloadIfChanged load files again if any of them changed since last load.
+func (r *reloader) reloadIfChanged() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(r.checkedAt) < reloadCheckInterval {
+		return
+	}
+	r.checkedAt = now
+
+	modTimes, err := statFiles(r.files())
+	if err != nil {
+		logrus.WithError(err).Warn("check tls files, keep previous certificate")
+		return
+	}
+	if maps.Equal(modTimes, r.modTimes) {
+		return
+	}
+
+	err = r.load()
+	if err != nil {
+		logrus.WithError(err).Warn("reload tls files, keep previous certificate")
+		return
+	}
+
+	logrus.WithField("cert_file", r.cfg.CertFile).Info("tls files reloaded")
+}
+
+func (r *reloader) getCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.reloadIfChanged()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.cert, nil
+}
+
+// verifyClientCert verify client certificate chain against client CA.
+// Missing certificate is refused by tls.Config.ClientAuth if required.
+func (r *reloader) verifyClientCert(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+	if len(rawCerts) == 0 {
+		return nil
+	}
+
+	certs := make([]*x509.Certificate, 0, len(rawCerts))
+	for _, rawCert := range rawCerts {
+		cert, err := x509.ParseCertificate(rawCert)
+		if err != nil {
+			return fmt.Errorf("x509.ParseCertificate: %w", err)
+		}
+		certs = append(certs, cert)
+	}
+
+	intermediates := x509.NewCertPool()
+	for _, cert := range certs[1:] {
+		intermediates.AddCert(cert)
+	}
+
+	r.mu.Lock()
+	clientCAs := r.clientCAs
+	r.mu.Unlock()
+
+	_, err := certs[0].Verify(x509.VerifyOptions{
+		Roots:         clientCAs,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	})
+	if err != nil {
+		return fmt.Errorf("x509.Certificate.Verify: %w", err)
+	}
+
+	return nil
+}
+
+func loadCertPool(file string) (*x509.CertPool, error) {
+	pemBytes, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("os.ReadFile: %w", err)
+	}
+
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pemBytes) {
+		return nil, errors.New("no certificate found in PEM file")
+	}
+
+	return pool, nil
+}
+
+func statFiles(files []string) (map[string]time.Time, error) {
+	modTimes := make(map[string]time.Time, len(files))
+	for _, file := range files {
+		info, err := os.Stat(file)
+		if err != nil {
+			return nil, fmt.Errorf("os.Stat: %w", err)
+		}
+		modTimes[file] = info.ModTime()
+	}
+	return modTimes, nil
+}
@@ -0,0 +1,240 @@
+package tlsconfig
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/Hidayathamir/go-user/config"
+	"github.com/Hidayathamir/go-user/internal/pkg/tlsconfig/tlsconfigtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// handshake run TLS handshake between serverConfig and clientConfig over
+// loopback, return server side connection state and client side error. Client
+// reads a byte written by server once handshake is done, so client certificate
+// refused by server after TLS 1.3 client handshake is reported too.
+func handshake(t *testing.T, serverConfig, clientConfig *tls.Config) (tls.ConnectionState, error) {
+	t.Helper()
+
+	listener, err := tls.Listen("tcp", "127.0.0.1:0", serverConfig)
+	require.NoError(t, err)
+	defer listener.Close()
+
+	stateCh := make(chan tls.ConnectionState, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			stateCh <- tls.ConnectionState{}
+			return
+		}
+		defer conn.Close()
+
+		tlsConn, ok := conn.(*tls.Conn)
+		if !ok {
+			stateCh <- tls.ConnectionState{}
+			return
+		}
+		if tlsConn.Handshake() == nil {
+			_, _ = tlsConn.Write([]byte{1})
+		}
+		stateCh <- tlsConn.ConnectionState()
+	}()
+
+	conn, err := tls.Dial("tcp", listener.Addr().String(), clientConfig)
+	if err == nil {
+		_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+		_, err = conn.Read(make([]byte, 1))
+		_ = conn.Close()
+	}
+
+	return <-stateCh, err
+}
+
+func newTestTLS(t *testing.T, ca *tlsconfigtest.CA) config.TLS {
+	t.Helper()
+
+	certFile, keyFile := ca.IssueFiles(t, t.TempDir(), "server")
+
+	return config.TLS{CertFile: certFile, KeyFile: keyFile}
+}
+
+func TestUnitNewServerConfig(t *testing.T) {
+	t.Parallel()
+
+	ca := tlsconfigtest.NewCA(t, "test-ca")
+
+	t.Run("disabled tls should return nil config", func(t *testing.T) {
+		t.Parallel()
+
+		tlsConfig, err := NewServerConfig(config.TLS{})
+
+		require.NoError(t, err)
+		assert.Nil(t, tlsConfig)
+	})
+	t.Run("missing cert file should return error", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := NewServerConfig(config.TLS{CertFile: "not-exist.crt", KeyFile: "not-exist.key"})
+
+		require.Error(t, err)
+	})
+	t.Run("client should verify server certificate", func(t *testing.T) {
+		t.Parallel()
+
+		tlsConfig, err := NewServerConfig(newTestTLS(t, ca))
+		require.NoError(t, err)
+
+		state, err := handshake(t, tlsConfig, &tls.Config{RootCAs: ca.Pool(), ServerName: "localhost", MinVersion: tls.VersionTLS12})
+
+		require.NoError(t, err)
+		assert.True(t, state.HandshakeComplete)
+		assert.Empty(t, state.PeerCertificates)
+	})
+	t.Run("client below min version should be refused", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := newTestTLS(t, ca)
+		cfg.MinVersion = config.TLSVersion13
+		tlsConfig, err := NewServerConfig(cfg)
+		require.NoError(t, err)
+
+		_, err = handshake(t, tlsConfig, &tls.Config{RootCAs: ca.Pool(), ServerName: "localhost", MinVersion: tls.VersionTLS12, MaxVersion: tls.VersionTLS12})
+
+		require.Error(t, err)
+	})
+}
+
+func TestUnitNewServerConfigClientCert(t *testing.T) {
+	t.Parallel()
+
+	ca := tlsconfigtest.NewCA(t, "test-ca")
+	otherCA := tlsconfigtest.NewCA(t, "other-ca")
+
+	newConfig := func(t *testing.T, require bool) *tls.Config {
+		t.Helper()
+		cfg := newTestTLS(t, ca)
+		cfg.ClientCAFile = tlsconfigtest.WriteFile(t, t.TempDir(), "client-ca.crt", ca.CertPEM())
+		cfg.RequireClientCert = require
+		tlsConfig, err := NewServerConfig(cfg)
+		assert.NoError(t, err)
+		return tlsConfig
+	}
+	clientConfig := func(t *testing.T, ca *tlsconfigtest.CA, commonName string) *tls.Config {
+		t.Helper()
+		tlsConfig := &tls.Config{RootCAs: ca.Pool(), ServerName: "localhost", MinVersion: tls.VersionTLS12}
+		if commonName != "" {
+			certPEM, keyPEM := ca.Issue(t, commonName)
+			cert, err := tls.X509KeyPair(certPEM, keyPEM)
+			assert.NoError(t, err)
+			tlsConfig.Certificates = []tls.Certificate{cert}
+		}
+		return tlsConfig
+	}
+
+	t.Run("client certificate signed by client ca should be verified", func(t *testing.T) {
+		t.Parallel()
+
+		state, err := handshake(t, newConfig(t, true), clientConfig(t, ca, "client-1"))
+
+		require.NoError(t, err)
+		require.Len(t, state.PeerCertificates, 1)
+		assert.Equal(t, "client-1", state.PeerCertificates[0].Subject.CommonName)
+	})
+	t.Run("client without certificate should be refused if required", func(t *testing.T) {
+		t.Parallel()
+
+		state, err := handshake(t, newConfig(t, true), clientConfig(t, ca, ""))
+
+		require.Error(t, err)
+		assert.False(t, state.HandshakeComplete)
+	})
+	t.Run("client without certificate should be allowed if not required", func(t *testing.T) {
+		t.Parallel()
+
+		state, err := handshake(t, newConfig(t, false), clientConfig(t, ca, ""))
+
+		require.NoError(t, err)
+		assert.Empty(t, state.PeerCertificates)
+	})
+	t.Run("client certificate of other ca should be refused", func(t *testing.T) {
+		t.Parallel()
+
+		tlsConfig := clientConfig(t, otherCA, "client-1")
+		tlsConfig.RootCAs = ca.Pool()
+
+		state, err := handshake(t, newConfig(t, false), tlsConfig)
+
+		require.Error(t, err)
+		assert.False(t, state.HandshakeComplete)
+	})
+}
+
+func TestUnitReloaderReloadIfChanged(t *testing.T) {
+	t.Parallel()
+
+	ca := tlsconfigtest.NewCA(t, "test-ca")
+
+	commonNameOf := func(t *testing.T, r *reloader) string {
+		t.Helper()
+		cert, err := r.getCertificate(nil)
+		require.NoError(t, err)
+		leaf, err := x509.ParseCertificate(cert.Certificate[0])
+		require.NoError(t, err)
+		return leaf.Subject.CommonName
+	}
+	// touch make file look changed, mod time may not move between quick
+	// writes.
+	touch := func(t *testing.T, file string) {
+		t.Helper()
+		future := time.Now().Add(time.Hour)
+		require.NoError(t, os.Chtimes(file, future, future))
+	}
+
+	t.Run("changed files should be reloaded", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		certFile, keyFile := ca.IssueFiles(t, dir, "server")
+		r := &reloader{cfg: config.TLS{CertFile: certFile, KeyFile: keyFile}}
+		require.NoError(t, r.load())
+
+		certPEM, keyPEM := ca.Issue(t, "server-renewed")
+		tlsconfigtest.WriteFile(t, dir, "server.crt", certPEM)
+		tlsconfigtest.WriteFile(t, dir, "server.key", keyPEM)
+		touch(t, certFile)
+
+		assert.Equal(t, "server-renewed", commonNameOf(t, r))
+	})
+	t.Run("invalid files should keep previous certificate", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		certFile, keyFile := ca.IssueFiles(t, dir, "server")
+		r := &reloader{cfg: config.TLS{CertFile: certFile, KeyFile: keyFile}}
+		require.NoError(t, r.load())
+
+		tlsconfigtest.WriteFile(t, dir, "server.crt", []byte("broken"))
+		touch(t, certFile)
+
+		assert.Equal(t, "server", commonNameOf(t, r))
+	})
+	t.Run("files should be checked at most once per interval", func(t *testing.T) {
+		t.Parallel()
+
+		dir := t.TempDir()
+		certFile, keyFile := ca.IssueFiles(t, dir, "server")
+		r := &reloader{cfg: config.TLS{CertFile: certFile, KeyFile: keyFile}, checkedAt: time.Now()}
+		require.NoError(t, r.load())
+
+		certPEM, keyPEM := ca.Issue(t, "server-renewed")
+		tlsconfigtest.WriteFile(t, dir, "server.crt", certPEM)
+		tlsconfigtest.WriteFile(t, dir, "server.key", keyPEM)
+		touch(t, certFile)
+
+		assert.Equal(t, "server", commonNameOf(t, r))
+	})
+}
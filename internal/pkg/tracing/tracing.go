@@ -0,0 +1,112 @@
+// Package tracing contains OpenTelemetry tracing related.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/Hidayathamir/go-user/config"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/Hidayathamir/go-user"
+
+// propagator read and write W3C trace context and baggage.
+var propagator = propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{})
+
+// Init set global tracer provider exporting spans to cfg.Tracing.Exporter.
+// Caller should Shutdown the returned provider on stop to flush spans.
+func Init(ctx context.Context, cfg config.Config) (*sdktrace.TracerProvider, error) {
+	exporter, err := NewExporter(ctx, cfg)
+	if err != nil {
+		return nil, fmt.Errorf("NewExporter: %w", err)
+	}
+
+	tracerProvider := NewTracerProvider(cfg, exporter)
+
+	otel.SetTracerProvider(tracerProvider)
+	otel.SetTextMapPropagator(propagator)
+
+	return tracerProvider, nil
+}
+
+// NewExporter return span exporter by cfg.Tracing.Exporter, nil when it is
+// "none".
+func NewExporter(ctx context.Context, cfg config.Config) (sdktrace.SpanExporter, error) {
+	switch cfg.Tracing.Exporter {
+	case config.TracingExporterOTLP:
+		opts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(cfg.Tracing.OTLPEndpoint)}
+		if cfg.Tracing.OTLPInsecure {
+			opts = append(opts, otlptracegrpc.WithInsecure())
+		}
+		exporter, err := otlptracegrpc.New(ctx, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("otlptracegrpc.New: %w", err)
+		}
+		return exporter, nil
+	case config.TracingExporterStdout:
+		exporter, err := stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+		if err != nil {
+			return nil, fmt.Errorf("stdouttrace.New: %w", err)
+		}
+		return exporter, nil
+	case config.TracingExporterNone:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unknown tracing exporter '%s'", cfg.Tracing.Exporter)
+	}
+}
+
+// NewTracerProvider return tracer provider sampling new traces by
+// cfg.Tracing.SampleRatio and exporting spans to exporter in batch. Nil
+// exporter means spans are not exported, tests can pass in memory exporter.
+func NewTracerProvider(cfg config.Config, exporter sdktrace.SpanExporter) *sdktrace.TracerProvider {
+	opts := []sdktrace.TracerProviderOption{
+		sdktrace.WithResource(resource.NewWithAttributes(
+			semconv.SchemaURL,
+			semconv.ServiceName(cfg.App.Name),
+			semconv.ServiceVersion(cfg.App.Version),
+			semconv.DeploymentEnvironment(string(cfg.App.Environment)),
+		)),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.Tracing.SampleRatio))),
+	}
+	if exporter != nil {
+		opts = append(opts, sdktrace.WithBatcher(exporter))
+	}
+
+	return sdktrace.NewTracerProvider(opts...)
+}
+
+// Start start span as child of span in ctx, using global tracer provider.
+func Start(ctx context.Context, spanName string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, spanName, opts...)
+}
+
+// End record err on span if any, then end span.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// Extract return ctx with remote span context read from W3C trace context in
+// carrier, e.g HTTP header.
+func Extract(ctx context.Context, carrier propagation.TextMapCarrier) context.Context {
+	return propagator.Extract(ctx, carrier)
+}
+
+// Inject write W3C trace context of span in ctx to carrier.
+func Inject(ctx context.Context, carrier propagation.TextMapCarrier) {
+	propagator.Inject(ctx, carrier)
+}
@@ -0,0 +1,118 @@
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/Hidayathamir/go-user/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestUnitNewExporter(t *testing.T) {
+	t.Parallel()
+
+	t.Run("exporter none should return nil exporter", func(t *testing.T) {
+		t.Parallel()
+
+		exporter, err := NewExporter(context.Background(), config.Config{Tracing: config.Tracing{Exporter: config.TracingExporterNone}})
+
+		require.NoError(t, err)
+		assert.Nil(t, exporter)
+	})
+	t.Run("exporter stdout should return exporter", func(t *testing.T) {
+		t.Parallel()
+
+		exporter, err := NewExporter(context.Background(), config.Config{Tracing: config.Tracing{Exporter: config.TracingExporterStdout}})
+
+		require.NoError(t, err)
+		assert.NotNil(t, exporter)
+	})
+	t.Run("unknown exporter should return error", func(t *testing.T) {
+		t.Parallel()
+
+		exporter, err := NewExporter(context.Background(), config.Config{Tracing: config.Tracing{Exporter: "zipkin"}})
+
+		require.Error(t, err)
+		assert.Nil(t, exporter)
+	})
+}
+
+func TestUnitNewTracerProvider(t *testing.T) {
+	t.Parallel()
+
+	t.Run("ended span should be exported with error status", func(t *testing.T) {
+		t.Parallel()
+
+		exporter := tracetest.NewInMemoryExporter()
+		tracerProvider := NewTracerProvider(config.Config{Tracing: config.Tracing{SampleRatio: 1}}, exporter)
+
+		_, span := tracerProvider.Tracer(tracerName).Start(context.Background(), "do")
+		End(span, assert.AnError)
+		require.NoError(t, tracerProvider.ForceFlush(context.Background()))
+
+		spans := exporter.GetSpans()
+		require.Len(t, spans, 1)
+		assert.Equal(t, "do", spans[0].Name)
+		assert.Equal(t, codes.Error, spans[0].Status.Code)
+		assert.Equal(t, assert.AnError.Error(), spans[0].Status.Description)
+	})
+	t.Run("sample ratio 0 should not export new trace", func(t *testing.T) {
+		t.Parallel()
+
+		exporter := tracetest.NewInMemoryExporter()
+		tracerProvider := NewTracerProvider(config.Config{Tracing: config.Tracing{SampleRatio: 0}}, exporter)
+
+		_, span := tracerProvider.Tracer(tracerName).Start(context.Background(), "do")
+		End(span, nil)
+		require.NoError(t, tracerProvider.ForceFlush(context.Background()))
+
+		assert.Empty(t, exporter.GetSpans())
+	})
+	t.Run("sample ratio 0 should follow sampled remote parent", func(t *testing.T) {
+		t.Parallel()
+
+		exporter := tracetest.NewInMemoryExporter()
+		tracerProvider := NewTracerProvider(config.Config{Tracing: config.Tracing{SampleRatio: 0}}, exporter)
+
+		ctx := Extract(context.Background(), propagation.MapCarrier{
+			"traceparent": "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		})
+		_, span := tracerProvider.Tracer(tracerName).Start(ctx, "do")
+		End(span, nil)
+		require.NoError(t, tracerProvider.ForceFlush(context.Background()))
+
+		spans := exporter.GetSpans()
+		require.Len(t, spans, 1)
+		assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", spans[0].SpanContext.TraceID().String())
+		assert.Equal(t, "00f067aa0ba902b7", spans[0].Parent.SpanID().String())
+	})
+}
+
+func TestUnitInject(t *testing.T) {
+	t.Parallel()
+
+	t.Run("inject should write traceparent of span in context", func(t *testing.T) {
+		t.Parallel()
+
+		traceID, err := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+		require.NoError(t, err)
+		spanID, err := trace.SpanIDFromHex("00f067aa0ba902b7")
+		require.NoError(t, err)
+		ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+			TraceID:    traceID,
+			SpanID:     spanID,
+			TraceFlags: trace.FlagsSampled,
+		}))
+
+		header := http.Header{}
+		Inject(ctx, propagation.HeaderCarrier(header))
+
+		assert.Equal(t, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", header.Get("traceparent"))
+	})
+}
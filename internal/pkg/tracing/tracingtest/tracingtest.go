@@ -0,0 +1,57 @@
+// Package tracingtest export spans of global tracer provider to in memory
+// exporter, for tests asserting spans.
+package tracingtest
+
+import (
+	"context"
+	"sync"
+
+	"github.com/Hidayathamir/go-user/config"
+	"github.com/Hidayathamir/go-user/internal/pkg/tracing"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+var (
+	once           sync.Once
+	exporter       *tracetest.InMemoryExporter
+	tracerProvider *sdktrace.TracerProvider
+)
+
+// Setup set global tracer provider sampling every trace and exporting to in
+// memory exporter, only first call takes effect. Call it before starting span.
+func Setup() {
+	once.Do(func() {
+		exporter = tracetest.NewInMemoryExporter()
+		tracerProvider = tracing.NewTracerProvider(config.Config{Tracing: config.Tracing{SampleRatio: 1}}, exporter)
+		otel.SetTracerProvider(tracerProvider)
+	})
+}
+
+// Spans return ended spans of trace. Tests run in parallel share the
+// exporter, so filter by trace id of span started by the test.
+func Spans(traceID trace.TraceID) tracetest.SpanStubs {
+	Setup()
+
+	_ = tracerProvider.ForceFlush(context.Background())
+
+	spans := tracetest.SpanStubs{}
+	for _, span := range exporter.GetSpans() {
+		if span.SpanContext.TraceID() == traceID {
+			spans = append(spans, span)
+		}
+	}
+	return spans
+}
+
+// SpanByName return first ended span of trace with name, false if none.
+func SpanByName(traceID trace.TraceID, name string) (tracetest.SpanStub, bool) {
+	for _, span := range Spans(traceID) {
+		if span.Name == name {
+			return span, true
+		}
+	}
+	return tracetest.SpanStub{}, false
+}
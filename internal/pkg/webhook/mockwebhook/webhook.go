@@ -0,0 +1,55 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: webhook.go
+//
+// Generated by this command:
+//
+//	mockgen -source=webhook.go -destination=mockwebhook/webhook.go -package=mockwebhook
+//
+
+// Package mockwebhook is a generated GoMock package.
+package mockwebhook
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockISender is a mock of ISender interface.
+type MockISender struct {
+	ctrl     *gomock.Controller
+	recorder *MockISenderMockRecorder
+}
+
+// MockISenderMockRecorder is the mock recorder for MockISender.
+type MockISenderMockRecorder struct {
+	mock *MockISender
+}
+
+// NewMockISender creates a new mock instance.
+func NewMockISender(ctrl *gomock.Controller) *MockISender {
+	mock := &MockISender{ctrl: ctrl}
+	mock.recorder = &MockISenderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockISender) EXPECT() *MockISenderMockRecorder {
+	return m.recorder
+}
+
+// Send mocks base method.
+func (m *MockISender) Send(ctx context.Context, url, secret string, deliveryID int64, eventType string, payload []byte) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", ctx, url, secret, deliveryID, eventType, payload)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockISenderMockRecorder) Send(ctx, url, secret, deliveryID, eventType, payload any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockISender)(nil).Send), ctx, url, secret, deliveryID, eventType, payload)
+}
@@ -0,0 +1,77 @@
+// Package webhook contains outgoing webhook sender.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Hidayathamir/go-user/internal/pkg/header"
+	"github.com/Hidayathamir/go-user/pkg/gouser"
+	"github.com/sirupsen/logrus"
+)
+
+//go:generate mockgen -source=webhook.go -destination=mockwebhook/webhook.go -package=mockwebhook
+
+// maxErrorBodySize is how many bytes of failed response body is kept in the
+// error, so the delivery log shows why the receiver refused.
+const maxErrorBodySize = 256
+
+// ISender contains abstraction of webhook sender.
+type ISender interface {
+	// Send POST payload to url signed with secret, return response status
+	// code, 0 if receiver does not respond. Error means the receiver does
+	// not respond or respond status other than 2xx.
+	Send(ctx context.Context, url string, secret string, deliveryID int64, eventType string, payload []byte) (int, error)
+}
+
+// Sender implement ISender.
+type Sender struct {
+	client *http.Client
+}
+
+var _ ISender = &Sender{}
+
+// NewSender return *Sender which implement ISender.
+func NewSender(client *http.Client) *Sender {
+	return &Sender{client: client}
+}
+
+// Send POST payload to url signed with secret, return response status code, 0
+// if receiver does not respond. Error means the receiver does not respond or
+// respond status other than 2xx.
+func (s *Sender) Send(ctx context.Context, url string, secret string, deliveryID int64, eventType string, payload []byte) (int, error) {
+	timestamp := time.Now().Unix()
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return 0, fmt.Errorf("http.NewRequestWithContext: %w", err)
+	}
+	httpReq.Header.Add(header.ContentType, header.AppJSON)
+	httpReq.Header.Add(gouser.HeaderWebhookDelivery, strconv.FormatInt(deliveryID, 10))
+	httpReq.Header.Add(gouser.HeaderWebhookEvent, eventType)
+	httpReq.Header.Add(gouser.HeaderWebhookTimestamp, strconv.FormatInt(timestamp, 10))
+	httpReq.Header.Add(gouser.HeaderWebhookSignature, gouser.SignWebhookPayload(secret, timestamp, payload))
+
+	httpRes, err := s.client.Do(httpReq)
+	if err != nil {
+		return 0, fmt.Errorf("http.Client.Do: %w", err)
+	}
+	defer func() {
+		err := httpRes.Body.Close()
+		if err != nil {
+			logrus.Warnf("http.Response.Body.Close: %v", err)
+		}
+	}()
+
+	if httpRes.StatusCode < 200 || httpRes.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(httpRes.Body, maxErrorBodySize))
+		return httpRes.StatusCode, fmt.Errorf("webhook response status code %d: %s", httpRes.StatusCode, body)
+	}
+
+	return httpRes.StatusCode, nil
+}
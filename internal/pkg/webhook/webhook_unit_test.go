@@ -0,0 +1,87 @@
+package webhook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/Hidayathamir/go-user/pkg/gouser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitSenderSend(t *testing.T) {
+	t.Parallel()
+
+	t.Run("receiver response 2xx should return status code", func(t *testing.T) {
+		t.Parallel()
+
+		payload := []byte(`{"id":1,"type":"user.registered"}`)
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			assert.Equal(t, payload, body)
+			assert.Equal(t, "7", r.Header.Get(gouser.HeaderWebhookDelivery))
+			assert.Equal(t, gouser.EventTypeUserRegistered, r.Header.Get(gouser.HeaderWebhookEvent))
+
+			timestamp, err := strconv.ParseInt(r.Header.Get(gouser.HeaderWebhookTimestamp), 10, 64)
+			assert.NoError(t, err)
+			isValid := gouser.VerifyWebhookSignature("mysecretmysecret", timestamp, body, r.Header.Get(gouser.HeaderWebhookSignature))
+			assert.True(t, isValid)
+
+			w.WriteHeader(http.StatusAccepted)
+		}))
+		defer server.Close()
+
+		s := NewSender(server.Client())
+
+		statusCode, err := s.Send(context.Background(), server.URL, "mysecretmysecret", 7, gouser.EventTypeUserRegistered, payload)
+
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusAccepted, statusCode)
+	})
+	t.Run("receiver response non 2xx should return error with response body", func(t *testing.T) {
+		t.Parallel()
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = w.Write([]byte("maintenance"))
+		}))
+		defer server.Close()
+
+		s := NewSender(server.Client())
+
+		statusCode, err := s.Send(context.Background(), server.URL, "mysecretmysecret", 7, gouser.EventTypeUserRegistered, []byte(`{}`))
+
+		require.Error(t, err)
+		require.ErrorContains(t, err, "maintenance")
+		assert.Equal(t, http.StatusServiceUnavailable, statusCode)
+	})
+	t.Run("receiver not respond should return status code 0", func(t *testing.T) {
+		t.Parallel()
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		url := server.URL
+		server.Close()
+
+		s := NewSender(http.DefaultClient)
+
+		statusCode, err := s.Send(context.Background(), url, "mysecretmysecret", 7, gouser.EventTypeUserRegistered, []byte(`{}`))
+
+		require.Error(t, err)
+		assert.Equal(t, 0, statusCode)
+	})
+	t.Run("signature with other secret should not be valid", func(t *testing.T) {
+		t.Parallel()
+
+		signature := gouser.SignWebhookPayload("mysecretmysecret", 1700000000, []byte(`{}`))
+
+		assert.True(t, gouser.VerifyWebhookSignature("mysecretmysecret", 1700000000, []byte(`{}`), signature))
+		assert.False(t, gouser.VerifyWebhookSignature("othersecretother", 1700000000, []byte(`{}`), signature))
+		assert.False(t, gouser.VerifyWebhookSignature("mysecretmysecret", 1700000001, []byte(`{}`), signature))
+	})
+}
@@ -0,0 +1,356 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Hidayathamir/go-user/config"
+	"github.com/Hidayathamir/go-user/internal/pkg/query"
+	"github.com/Hidayathamir/go-user/internal/repo/db"
+	"github.com/Hidayathamir/go-user/internal/repo/db/entity"
+	"github.com/Hidayathamir/go-user/internal/repo/db/entity/table"
+	"github.com/Hidayathamir/go-user/pkg/gouser"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+//go:generate mockgen -source=account.go -destination=mockrepo/account.go -package=mockrepo
+
+// IAccount contains abstraction of repo account lifecycle.
+type IAccount interface {
+	// SoftDeleteUser mark user as deleted. Event user.deleted is written to
+	// outbox in the transaction bound to ctx, it must be called within
+	// ITransactor.WithinTx.
+	SoftDeleteUser(ctx context.Context, userID int64, deletedAt time.Time) error
+	// GetDeletedProfileByUsername return the latest deleted user profile by
+	// username which is deleted after deletedAfter.
+	GetDeletedProfileByUsername(ctx context.Context, username string, deletedAfter time.Time) (entity.User, error)
+	// RestoreUser unmark deleted user.
+	RestoreUser(ctx context.Context, userID int64) error
+	// IsUsernameHeldByDeletedUser return true if username belongs to a
+	// deleted user which is not purged yet.
+	IsUsernameHeldByDeletedUser(ctx context.Context, username string) (bool, error)
+	// LockUsername lock username until the transaction bound to ctx ends, so
+	// checking username is claimable and claiming it is not interleaved with
+	// other transaction on the same username.
+	LockUsername(ctx context.Context, username string) error
+	// LockUser lock not deleted user row until the transaction bound to ctx
+	// ends, so checking and changing the user is not interleaved with other
+	// transaction on the same user.
+	LockUser(ctx context.Context, userID int64) error
+	// PurgeDeletedUsers hard delete at most limit users which are deleted
+	// before deletedBefore, return ids of purged users.
+	PurgeDeletedUsers(ctx context.Context, deletedBefore time.Time, limit uint64) ([]int64, error)
+	// UpdateUserStatus update user status, suspended until and suspension
+	// reason.
+	UpdateUserStatus(ctx context.Context, user entity.User) error
+}
+
+// Account implement IAccount.
+type Account struct {
+	cfg config.Config
+	db  *db.Postgres
+}
+
+var _ IAccount = &Account{}
+
+// NewAccount return *Account which implement repo.IAccount.
+func NewAccount(cfg config.Config, db *db.Postgres) *Account {
+	return &Account{
+		cfg: cfg,
+		db:  db,
+	}
+}
+
+// SoftDeleteUser mark user as deleted. Event user.deleted is written to outbox
+// in the transaction bound to ctx, it must be called within
+// ITransactor.WithinTx.
+func (a *Account) SoftDeleteUser(ctx context.Context, userID int64, deletedAt time.Time) error {
+	err := checkWithinTx(ctx)
+	if err != nil {
+		return fmt.Errorf("checkWithinTx: %w", err)
+	}
+
+	sql, args, err := a.db.Builder.
+		Update(table.User.String()).
+		Set(table.User.DeletedAt, deletedAt).
+		Set(table.User.UpdatedAt, deletedAt).
+		Where(sq.Eq{
+			table.User.ID:        userID,
+			table.User.DeletedAt: nil,
+		}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("Account.db.Builder.ToSql: %w", err)
+	}
+
+	commandTag, err := a.db.Pool.Exec(ctx, sql, args...)
+	if err != nil {
+		return fmt.Errorf("Account.db.Pool.Exec: %w", err)
+	}
+
+	if commandTag.RowsAffected() == 0 {
+		return fmt.Errorf("%w: pgconn.CommandTag.RowsAffected == 0: %w", gouser.ErrUnknownUserID, pgx.ErrNoRows)
+	}
+
+	data := gouser.EventUserDeleted{UserID: userID}
+	err = insertOutboxEvent(ctx, a.db, gouser.EventTypeUserDeleted, userID, data)
+	if err != nil {
+		return fmt.Errorf("insertOutboxEvent: %w", err)
+	}
+
+	return nil
+}
+
+// GetDeletedProfileByUsername return the latest deleted user profile by
+// username which is deleted after deletedAfter.
+func (a *Account) GetDeletedProfileByUsername(ctx context.Context, username string, deletedAfter time.Time) (entity.User, error) {
+	sql, args, err := a.db.Builder.
+		Select(
+			table.User.ID, table.User.Username, table.User.Password,
+			table.User.Role, table.User.CreatedAt, table.User.UpdatedAt,
+			table.User.DeletedAt,
+		).
+		From(table.User.String()).
+		Where(sq.Eq{
+			table.User.Username: username,
+		}).
+		Where(sq.Gt{
+			table.User.DeletedAt: deletedAfter,
+		}).
+		OrderBy(table.User.DeletedAt + " DESC").
+		Limit(1).
+		ToSql()
+	if err != nil {
+		return entity.User{}, fmt.Errorf("Account.db.Builder.ToSql: %w", err)
+	}
+
+	user := entity.User{}
+	err = a.db.Pool.QueryRow(ctx, sql, args...).Scan(
+		&user.ID, &user.Username, &user.Password,
+		&user.Role, &user.CreatedAt, &user.UpdatedAt,
+		&user.DeletedAt,
+	)
+	if err != nil {
+		err := fmt.Errorf("Account.db.Pool.QueryRow: %w", err)
+		if errors.Is(err, pgx.ErrNoRows) {
+			err = fmt.Errorf("%w: %w", gouser.ErrUnknownUsername, err)
+		}
+		return entity.User{}, err
+	}
+
+	return user, nil
+}
+
+// RestoreUser unmark deleted user. It fails with gouser.ErrDuplicateUsername
+// when the username has been taken by another user meanwhile.
+func (a *Account) RestoreUser(ctx context.Context, userID int64) error {
+	sql, args, err := a.db.Builder.
+		Update(table.User.String()).
+		Set(table.User.DeletedAt, nil).
+		Set(table.User.UpdatedAt, time.Now()).
+		Where(sq.Eq{
+			table.User.ID: userID,
+		}).
+		Where(sq.NotEq{
+			table.User.DeletedAt: nil,
+		}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("Account.db.Builder.ToSql: %w", err)
+	}
+
+	commandTag, err := a.db.Pool.Exec(ctx, sql, args...)
+	if err != nil {
+		err := fmt.Errorf("Account.db.Pool.Exec: %w", err)
+
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			isErrDuplicateUsername := pgErr.Code == pgerrcode.UniqueViolation &&
+				pgErr.ConstraintName == table.User.Constraint.UserUn
+			if isErrDuplicateUsername {
+				return fmt.Errorf("%w: %w", gouser.ErrDuplicateUsername, err)
+			}
+		}
+
+		return err
+	}
+
+	if commandTag.RowsAffected() == 0 {
+		return fmt.Errorf("%w: pgconn.CommandTag.RowsAffected == 0: %w", gouser.ErrUnknownUserID, pgx.ErrNoRows)
+	}
+
+	return nil
+}
+
+// IsUsernameHeldByDeletedUser return true if username belongs to a deleted
+// user which is not purged yet.
+func (a *Account) IsUsernameHeldByDeletedUser(ctx context.Context, username string) (bool, error) {
+	sql, args, err := a.db.Builder.
+		Select("1").
+		From(table.User.String()).
+		Where(sq.Eq{
+			table.User.Username: username,
+		}).
+		Where(sq.NotEq{
+			table.User.DeletedAt: nil,
+		}).
+		Prefix("SELECT EXISTS (").
+		Suffix(")").
+		ToSql()
+	if err != nil {
+		return false, fmt.Errorf("Account.db.Builder.ToSql: %w", err)
+	}
+
+	var isHeld bool
+	err = a.db.Pool.QueryRow(ctx, sql, args...).Scan(&isHeld)
+	if err != nil {
+		return false, fmt.Errorf("Account.db.Pool.QueryRow.Scan: %w", err)
+	}
+
+	return isHeld, nil
+}
+
+// LockUsername lock username until the transaction bound to ctx ends, so
+// checking username is claimable and claiming it is not interleaved with other
+// transaction registering, restoring, changing to or deleting the same
+// username. It is transaction level advisory lock, ctx must be bound to
+// transaction.
+func (a *Account) LockUsername(ctx context.Context, username string) error {
+	err := checkWithinTx(ctx)
+	if err != nil {
+		return fmt.Errorf("checkWithinTx: %w", err)
+	}
+
+	sql, args, err := a.db.Builder.
+		Select().
+		Column(sq.Expr("pg_advisory_xact_lock(hashtextextended(?, 0))", username)).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("Account.db.Builder.ToSql: %w", err)
+	}
+
+	_, err = a.db.Pool.Exec(ctx, sql, args...)
+	if err != nil {
+		return fmt.Errorf("Account.db.Pool.Exec: %w", err)
+	}
+
+	return nil
+}
+
+// LockUser lock not deleted user row until the transaction bound to ctx ends,
+// so checking and changing the user is not interleaved with other transaction
+// on the same user. Lock usernames first, transactions changing user row hold
+// username lock before row lock.
+func (a *Account) LockUser(ctx context.Context, userID int64) error {
+	err := checkWithinTx(ctx)
+	if err != nil {
+		return fmt.Errorf("checkWithinTx: %w", err)
+	}
+
+	sql, args, err := a.db.Builder.
+		Select(table.User.ID).
+		From(table.User.String()).
+		Where(sq.Eq{
+			table.User.ID:        userID,
+			table.User.DeletedAt: nil,
+		}).
+		Suffix("FOR UPDATE").
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("Account.db.Builder.ToSql: %w", err)
+	}
+
+	var lockedUserID int64
+	err = a.db.Pool.QueryRow(ctx, sql, args...).Scan(&lockedUserID)
+	if err != nil {
+		err := fmt.Errorf("Account.db.Pool.QueryRow.Scan: %w", err)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("%w: %w", gouser.ErrUnknownUserID, err)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// PurgeDeletedUsers hard delete at most limit users which are deleted before
+// deletedBefore, return ids of purged users. User sessions and username
+// history are deleted by cascade.
+func (a *Account) PurgeDeletedUsers(ctx context.Context, deletedBefore time.Time, limit uint64) ([]int64, error) {
+	// Subquery use question placeholder, the outer builder renumbers them.
+	deleted := sq.
+		Select(table.User.ID).
+		From(table.User.String()).
+		Where(sq.Lt{
+			table.User.DeletedAt: deletedBefore,
+		}).
+		OrderBy(table.User.ID).
+		Limit(limit).
+		Suffix("FOR UPDATE SKIP LOCKED")
+
+	sql, args, err := a.db.Builder.
+		Delete(table.User.String()).
+		Where(sq.Expr(table.User.ID+" IN (?)", deleted)).
+		Suffix(query.Returning(table.User.ID)).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("Account.db.Builder.ToSql: %w", err)
+	}
+
+	rows, err := a.db.Pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("Account.db.Pool.Query: %w", err)
+	}
+	defer rows.Close()
+
+	userIDs := []int64{}
+	for rows.Next() {
+		var userID int64
+		err := rows.Scan(&userID)
+		if err != nil {
+			return nil, fmt.Errorf("pgx.Rows.Scan: %w", err)
+		}
+		userIDs = append(userIDs, userID)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("pgx.Rows.Err: %w", err)
+	}
+
+	return userIDs, nil
+}
+
+// UpdateUserStatus update user status, suspended until and suspension reason.
+func (a *Account) UpdateUserStatus(ctx context.Context, user entity.User) error {
+	sql, args, err := a.db.Builder.
+		Update(table.User.String()).
+		Set(table.User.Status, user.Status).
+		Set(table.User.SuspendedUntil, user.SuspendedUntil).
+		Set(table.User.SuspensionReason, user.SuspensionReason).
+		Set(table.User.UpdatedAt, time.Now()).
+		Where(sq.Eq{
+			table.User.ID:        user.ID,
+			table.User.DeletedAt: nil,
+		}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("Account.db.Builder.ToSql: %w", err)
+	}
+
+	commandTag, err := a.db.Pool.Exec(ctx, sql, args...)
+	if err != nil {
+		return fmt.Errorf("Account.db.Pool.Exec: %w", err)
+	}
+
+	if commandTag.RowsAffected() == 0 {
+		return fmt.Errorf("%w: pgconn.CommandTag.RowsAffected == 0: %w", gouser.ErrUnknownUserID, pgx.ErrNoRows)
+	}
+
+	return nil
+}
@@ -0,0 +1,600 @@
+package repo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Hidayathamir/go-user/config"
+	"github.com/Hidayathamir/go-user/internal/repo/db"
+	"github.com/Hidayathamir/go-user/internal/repo/db/entity"
+	"github.com/Hidayathamir/go-user/pkg/gouser"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pashagolub/pgxmock/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitAccountSoftDeleteUser(t *testing.T) {
+	t.Parallel()
+
+	t.Run("soft delete user success", func(t *testing.T) {
+		t.Parallel()
+
+		mockpool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+
+		a := &Account{
+			cfg: config.Config{},
+			db: &db.Postgres{
+				Builder: builder,
+				Pool:    db.NewContextPool(mockpool),
+			},
+		}
+
+		now := time.Now()
+		mockpool.ExpectBegin()
+		tx, err := mockpool.Begin(context.Background())
+		require.NoError(t, err)
+		ctx := db.ContextWithTx(context.Background(), tx)
+
+		mockpool.
+			ExpectExec("UPDATE").WithArgs(now, now, int64(44)).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+		mockpool.
+			ExpectExec("INSERT INTO \"outbox_event\"").
+			WithArgs(
+				gouser.EventTypeUserDeleted, int64(44), []byte(`{"user_id":44}`),
+				anyTime{}, anyTime{},
+			).
+			WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+		err = a.SoftDeleteUser(ctx, 44, now)
+
+		require.NoError(t, err)
+		require.NoError(t, mockpool.ExpectationsWereMet())
+	})
+	t.Run("user not found or already deleted should return error", func(t *testing.T) {
+		t.Parallel()
+
+		mockpool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+
+		a := &Account{
+			cfg: config.Config{},
+			db: &db.Postgres{
+				Builder: builder,
+				Pool:    db.NewContextPool(mockpool),
+			},
+		}
+
+		now := time.Now()
+		mockpool.ExpectBegin()
+		tx, err := mockpool.Begin(context.Background())
+		require.NoError(t, err)
+		ctx := db.ContextWithTx(context.Background(), tx)
+
+		mockpool.
+			ExpectExec("UPDATE").WithArgs(now, now, int64(44)).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+
+		err = a.SoftDeleteUser(ctx, 44, now)
+
+		require.Error(t, err)
+		require.ErrorIs(t, err, gouser.ErrUnknownUserID)
+		require.ErrorIs(t, err, pgx.ErrNoRows)
+	})
+	t.Run("Exec error should return error", func(t *testing.T) {
+		t.Parallel()
+
+		mockpool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+
+		a := &Account{
+			cfg: config.Config{},
+			db: &db.Postgres{
+				Builder: builder,
+				Pool:    db.NewContextPool(mockpool),
+			},
+		}
+
+		now := time.Now()
+		mockpool.ExpectBegin()
+		tx, err := mockpool.Begin(context.Background())
+		require.NoError(t, err)
+		ctx := db.ContextWithTx(context.Background(), tx)
+
+		mockpool.
+			ExpectExec("UPDATE").WithArgs(now, now, int64(44)).
+			WillReturnError(assert.AnError)
+
+		err = a.SoftDeleteUser(ctx, 44, now)
+
+		require.Error(t, err)
+		require.ErrorIs(t, err, assert.AnError)
+	})
+	t.Run("ctx without transaction should return error", func(t *testing.T) {
+		t.Parallel()
+
+		mockpool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+
+		a := &Account{
+			cfg: config.Config{},
+			db: &db.Postgres{
+				Builder: builder,
+				Pool:    db.NewContextPool(mockpool),
+			},
+		}
+
+		err = a.SoftDeleteUser(context.Background(), 44, time.Now())
+
+		require.ErrorIs(t, err, errNotWithinTx)
+		require.NoError(t, mockpool.ExpectationsWereMet())
+	})
+}
+
+func TestUnitAccountGetDeletedProfileByUsername(t *testing.T) {
+	t.Parallel()
+
+	t.Run("get deleted profile by username success", func(t *testing.T) {
+		t.Parallel()
+
+		mockpool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+
+		a := &Account{
+			cfg: config.Config{},
+			db: &db.Postgres{
+				Builder: builder,
+				Pool:    mockpool,
+			},
+		}
+
+		now := time.Now()
+		deletedAfter := now.Add(-time.Hour)
+		mockpool.ExpectQuery("SELECT").WithArgs("hidayat", deletedAfter).
+			WillReturnRows(
+				pgxmock.NewRows(
+					[]string{"id", "username", "password", "role", "created_at", "updated_at", "deleted_at"},
+				).AddRow(
+					int64(441), "hidayat", "dummyhashedpassword", "user", now, now, &now,
+				),
+			)
+
+		user, err := a.GetDeletedProfileByUsername(context.Background(), "hidayat", deletedAfter)
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(441), user.ID)
+		assert.Equal(t, "hidayat", user.Username)
+		require.NotNil(t, user.DeletedAt)
+		assert.Equal(t, now, *user.DeletedAt)
+	})
+	t.Run("no deleted user should return error unknown username", func(t *testing.T) {
+		t.Parallel()
+
+		mockpool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+
+		a := &Account{
+			cfg: config.Config{},
+			db: &db.Postgres{
+				Builder: builder,
+				Pool:    mockpool,
+			},
+		}
+
+		deletedAfter := time.Now()
+		mockpool.ExpectQuery("SELECT").WithArgs("hidayat", deletedAfter).
+			WillReturnError(pgx.ErrNoRows)
+
+		user, err := a.GetDeletedProfileByUsername(context.Background(), "hidayat", deletedAfter)
+
+		assert.Empty(t, user)
+		require.Error(t, err)
+		require.ErrorIs(t, err, gouser.ErrUnknownUsername)
+		require.ErrorIs(t, err, pgx.ErrNoRows)
+	})
+}
+
+func TestUnitAccountRestoreUser(t *testing.T) {
+	t.Parallel()
+
+	t.Run("restore user success", func(t *testing.T) {
+		t.Parallel()
+
+		mockpool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+
+		a := &Account{
+			cfg: config.Config{},
+			db: &db.Postgres{
+				Builder: builder,
+				Pool:    mockpool,
+			},
+		}
+
+		mockpool.
+			ExpectExec("UPDATE").WithArgs(nil, anyTime{}, int64(44)).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+		err = a.RestoreUser(context.Background(), 44)
+
+		require.NoError(t, err)
+	})
+	t.Run("username taken by another user should return error duplicate username", func(t *testing.T) {
+		t.Parallel()
+
+		mockpool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+
+		a := &Account{
+			cfg: config.Config{},
+			db: &db.Postgres{
+				Builder: builder,
+				Pool:    mockpool,
+			},
+		}
+
+		mockpool.
+			ExpectExec("UPDATE").WithArgs(nil, anyTime{}, int64(44)).
+			WillReturnError(&pgconn.PgError{
+				Code:           pgerrcode.UniqueViolation,
+				ConstraintName: "user_un",
+			})
+
+		err = a.RestoreUser(context.Background(), 44)
+
+		require.Error(t, err)
+		require.ErrorIs(t, err, gouser.ErrDuplicateUsername)
+	})
+	t.Run("user not deleted should return error", func(t *testing.T) {
+		t.Parallel()
+
+		mockpool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+
+		a := &Account{
+			cfg: config.Config{},
+			db: &db.Postgres{
+				Builder: builder,
+				Pool:    mockpool,
+			},
+		}
+
+		mockpool.
+			ExpectExec("UPDATE").WithArgs(nil, anyTime{}, int64(44)).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+
+		err = a.RestoreUser(context.Background(), 44)
+
+		require.Error(t, err)
+		require.ErrorIs(t, err, gouser.ErrUnknownUserID)
+	})
+}
+
+func TestUnitAccountIsUsernameHeldByDeletedUser(t *testing.T) {
+	t.Parallel()
+
+	t.Run("username held should return true", func(t *testing.T) {
+		t.Parallel()
+
+		mockpool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+
+		a := &Account{
+			cfg: config.Config{},
+			db: &db.Postgres{
+				Builder: builder,
+				Pool:    mockpool,
+			},
+		}
+
+		mockpool.ExpectQuery("SELECT EXISTS").WithArgs("hidayat").
+			WillReturnRows(pgxmock.NewRows([]string{"exists"}).AddRow(true))
+
+		isHeld, err := a.IsUsernameHeldByDeletedUser(context.Background(), "hidayat")
+
+		require.NoError(t, err)
+		assert.True(t, isHeld)
+	})
+	t.Run("QueryRow Scan error should return error", func(t *testing.T) {
+		t.Parallel()
+
+		mockpool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+
+		a := &Account{
+			cfg: config.Config{},
+			db: &db.Postgres{
+				Builder: builder,
+				Pool:    mockpool,
+			},
+		}
+
+		mockpool.ExpectQuery("SELECT EXISTS").WithArgs("hidayat").
+			WillReturnError(assert.AnError)
+
+		isHeld, err := a.IsUsernameHeldByDeletedUser(context.Background(), "hidayat")
+
+		require.Error(t, err)
+		require.ErrorIs(t, err, assert.AnError)
+		assert.False(t, isHeld)
+	})
+}
+
+func TestUnitAccountLockUsername(t *testing.T) {
+	t.Parallel()
+
+	t.Run("lock username within transaction success", func(t *testing.T) {
+		t.Parallel()
+
+		mockpool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+		mocktx, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+
+		a := &Account{
+			cfg: config.Config{},
+			db: &db.Postgres{
+				Builder: builder,
+				Pool:    db.NewContextPool(mockpool),
+			},
+		}
+
+		mocktx.ExpectBegin()
+		tx, err := mocktx.Begin(context.Background())
+		require.NoError(t, err)
+
+		mocktx.
+			ExpectExec("SELECT pg_advisory_xact_lock").WithArgs("hidayat").
+			WillReturnResult(pgxmock.NewResult("SELECT", 1))
+
+		ctx := db.ContextWithTx(context.Background(), tx)
+		err = a.LockUsername(ctx, "hidayat")
+
+		require.NoError(t, err)
+		require.NoError(t, mocktx.ExpectationsWereMet())
+		require.NoError(t, mockpool.ExpectationsWereMet())
+	})
+	t.Run("ctx without transaction should return error", func(t *testing.T) {
+		t.Parallel()
+
+		mockpool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+
+		a := &Account{
+			cfg: config.Config{},
+			db: &db.Postgres{
+				Builder: builder,
+				Pool:    db.NewContextPool(mockpool),
+			},
+		}
+
+		err = a.LockUsername(context.Background(), "hidayat")
+
+		require.ErrorIs(t, err, errNotWithinTx)
+		require.NoError(t, mockpool.ExpectationsWereMet())
+	})
+	t.Run("Exec error should return error", func(t *testing.T) {
+		t.Parallel()
+
+		mocktx, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+
+		a := &Account{
+			cfg: config.Config{},
+			db: &db.Postgres{
+				Builder: builder,
+				Pool:    db.NewContextPool(mocktx),
+			},
+		}
+
+		mocktx.ExpectBegin()
+		tx, err := mocktx.Begin(context.Background())
+		require.NoError(t, err)
+
+		mocktx.
+			ExpectExec("SELECT pg_advisory_xact_lock").WithArgs("hidayat").
+			WillReturnError(assert.AnError)
+
+		ctx := db.ContextWithTx(context.Background(), tx)
+		err = a.LockUsername(ctx, "hidayat")
+
+		require.Error(t, err)
+		require.ErrorIs(t, err, assert.AnError)
+	})
+}
+
+func TestUnitAccountLockUser(t *testing.T) {
+	t.Parallel()
+
+	t.Run("lock user within transaction success", func(t *testing.T) {
+		t.Parallel()
+
+		mockpool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+		mocktx, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+
+		a := &Account{
+			cfg: config.Config{},
+			db: &db.Postgres{
+				Builder: builder,
+				Pool:    db.NewContextPool(mockpool),
+			},
+		}
+
+		mocktx.ExpectBegin()
+		tx, err := mocktx.Begin(context.Background())
+		require.NoError(t, err)
+
+		mocktx.
+			ExpectQuery(`SELECT id FROM "user" WHERE deleted_at IS NULL AND id = \$1 FOR UPDATE`).WithArgs(int64(44)).
+			WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(int64(44)))
+
+		ctx := db.ContextWithTx(context.Background(), tx)
+		err = a.LockUser(ctx, 44)
+
+		require.NoError(t, err)
+		require.NoError(t, mocktx.ExpectationsWereMet())
+		require.NoError(t, mockpool.ExpectationsWereMet())
+	})
+	t.Run("ctx without transaction should return error", func(t *testing.T) {
+		t.Parallel()
+
+		mockpool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+
+		a := &Account{
+			cfg: config.Config{},
+			db: &db.Postgres{
+				Builder: builder,
+				Pool:    db.NewContextPool(mockpool),
+			},
+		}
+
+		err = a.LockUser(context.Background(), 44)
+
+		require.ErrorIs(t, err, errNotWithinTx)
+		require.NoError(t, mockpool.ExpectationsWereMet())
+	})
+	t.Run("unknown or deleted user should return error unknown user id", func(t *testing.T) {
+		t.Parallel()
+
+		mocktx, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+
+		a := &Account{
+			cfg: config.Config{},
+			db: &db.Postgres{
+				Builder: builder,
+				Pool:    db.NewContextPool(mocktx),
+			},
+		}
+
+		mocktx.ExpectBegin()
+		tx, err := mocktx.Begin(context.Background())
+		require.NoError(t, err)
+
+		mocktx.ExpectQuery("SELECT").WithArgs(int64(44)).WillReturnError(pgx.ErrNoRows)
+
+		ctx := db.ContextWithTx(context.Background(), tx)
+		err = a.LockUser(ctx, 44)
+
+		require.ErrorIs(t, err, gouser.ErrUnknownUserID)
+	})
+}
+
+func TestUnitAccountPurgeDeletedUsers(t *testing.T) {
+	t.Parallel()
+
+	t.Run("purge deleted users success", func(t *testing.T) {
+		t.Parallel()
+
+		mockpool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+
+		a := &Account{
+			cfg: config.Config{},
+			db: &db.Postgres{
+				Builder: builder,
+				Pool:    mockpool,
+			},
+		}
+
+		deletedBefore := time.Now()
+		mockpool.
+			ExpectQuery(`DELETE FROM "user" WHERE id IN \(SELECT id FROM "user" WHERE deleted_at < \$1 ORDER BY id LIMIT 2 FOR UPDATE SKIP LOCKED\) RETURNING id`).
+			WithArgs(deletedBefore).
+			WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(int64(44)).AddRow(int64(45)))
+
+		userIDs, err := a.PurgeDeletedUsers(context.Background(), deletedBefore, 2)
+
+		require.NoError(t, err)
+		assert.Equal(t, []int64{44, 45}, userIDs)
+	})
+	t.Run("Query error should return error", func(t *testing.T) {
+		t.Parallel()
+
+		mockpool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+
+		a := &Account{
+			cfg: config.Config{},
+			db: &db.Postgres{
+				Builder: builder,
+				Pool:    mockpool,
+			},
+		}
+
+		deletedBefore := time.Now()
+		mockpool.
+			ExpectQuery("DELETE").WithArgs(deletedBefore).
+			WillReturnError(assert.AnError)
+
+		userIDs, err := a.PurgeDeletedUsers(context.Background(), deletedBefore, 2)
+
+		require.Error(t, err)
+		require.ErrorIs(t, err, assert.AnError)
+		assert.Nil(t, userIDs)
+	})
+}
+
+func TestUnitAccountUpdateUserStatus(t *testing.T) {
+	t.Parallel()
+
+	t.Run("update user status success", func(t *testing.T) {
+		t.Parallel()
+
+		mockpool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+
+		a := &Account{
+			cfg: config.Config{},
+			db: &db.Postgres{
+				Builder: builder,
+				Pool:    mockpool,
+			},
+		}
+
+		suspendedUntil := time.Now().Add(time.Hour)
+		mockpool.
+			ExpectExec("UPDATE").WithArgs("suspended", &suspendedUntil, "spam", anyTime{}, int64(44)).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+		err = a.UpdateUserStatus(context.Background(), entity.User{
+			ID:               44,
+			Status:           "suspended",
+			SuspendedUntil:   &suspendedUntil,
+			SuspensionReason: "spam",
+		})
+
+		require.NoError(t, err)
+	})
+	t.Run("user not found should return error", func(t *testing.T) {
+		t.Parallel()
+
+		mockpool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+
+		a := &Account{
+			cfg: config.Config{},
+			db: &db.Postgres{
+				Builder: builder,
+				Pool:    mockpool,
+			},
+		}
+
+		mockpool.
+			ExpectExec("UPDATE").WithArgs("disabled", (*time.Time)(nil), "", anyTime{}, int64(44)).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+
+		err = a.UpdateUserStatus(context.Background(), entity.User{ID: 44, Status: "disabled"})
+
+		require.Error(t, err)
+		require.ErrorIs(t, err, gouser.ErrUnknownUserID)
+	})
+}
@@ -0,0 +1,280 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Hidayathamir/go-user/config"
+	"github.com/Hidayathamir/go-user/internal/repo/db"
+	"github.com/Hidayathamir/go-user/internal/repo/db/entity"
+	"github.com/Hidayathamir/go-user/internal/repo/db/entity/table"
+	sq "github.com/Masterminds/squirrel"
+)
+
+//go:generate mockgen -source=audit_log.go -destination=mockrepo/audit_log.go -package=mockrepo
+
+// IAuditLog contains abstraction of repo audit log.
+type IAuditLog interface {
+	// CreateAuditLog append audit log entry.
+	CreateAuditLog(ctx context.Context, auditLog entity.AuditLog) error
+	// GetAuditLogs return one page of audit log entries matching the
+	// filter, newest first.
+	GetAuditLogs(ctx context.Context, filter AuditLogFilter) ([]entity.AuditLog, error)
+	// PurgeAuditLogs delete audit log entries created before createdBefore,
+	// return number of purged entries.
+	PurgeAuditLogs(ctx context.Context, createdBefore time.Time) (int64, error)
+	// IterateAuditLogsByUserID call fn for every audit log entry the user is
+	// actor or target of, ordered by id. Entries are not buffered.
+	IterateAuditLogsByUserID(ctx context.Context, userID int64, fn func(entity.AuditLog) error) error
+	// DeleteAuditLogsByUserIDs delete audit log entries the users are actor
+	// or target of, return number of deleted entries.
+	DeleteAuditLogsByUserIDs(ctx context.Context, userIDs []int64) (int64, error)
+}
+
+// AuditLogFilter is filter and page of GetAuditLogs.
+type AuditLogFilter struct {
+	ActorUserID  *int64
+	TargetUserID *int64
+	Action       string
+	Result       string
+	// CreatedFrom is inclusive, CreatedTo is exclusive.
+	CreatedFrom *time.Time
+	CreatedTo   *time.Time
+	// BeforeID is id of the last entry of the previous page, 0 for the first
+	// page.
+	BeforeID int64
+	Limit    uint64
+}
+
+// AuditLog implement IAuditLog.
+type AuditLog struct {
+	cfg config.Config
+	db  *db.Postgres
+}
+
+var _ IAuditLog = &AuditLog{}
+
+// NewAuditLog return *AuditLog which implement repo.IAuditLog.
+func NewAuditLog(cfg config.Config, db *db.Postgres) *AuditLog {
+	return &AuditLog{
+		cfg: cfg,
+		db:  db,
+	}
+}
+
+// CreateAuditLog append audit log entry.
+func (a *AuditLog) CreateAuditLog(ctx context.Context, auditLog entity.AuditLog) error {
+	sql, args, err := a.db.Builder.
+		Insert(table.AuditLog.String()).
+		Columns(
+			table.AuditLog.ActorUserID, table.AuditLog.TargetUserID,
+			table.AuditLog.Action, table.AuditLog.Result,
+			table.AuditLog.Detail, table.AuditLog.IP,
+			table.AuditLog.UserAgent, table.AuditLog.RequestID,
+			table.AuditLog.CreatedAt,
+		).
+		Values(
+			auditLog.ActorUserID, auditLog.TargetUserID,
+			auditLog.Action, auditLog.Result,
+			auditLog.Detail, auditLog.IP,
+			auditLog.UserAgent, auditLog.RequestID,
+			auditLog.CreatedAt,
+		).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("AuditLog.db.Builder.ToSql: %w", err)
+	}
+
+	_, err = a.db.Pool.Exec(ctx, sql, args...)
+	if err != nil {
+		return fmt.Errorf("AuditLog.db.Pool.Exec: %w", err)
+	}
+
+	return nil
+}
+
+// GetAuditLogs return one page of audit log entries matching the filter,
+// newest first. Paging is keyset on id, so page is stable while entries are
+// appended.
+func (a *AuditLog) GetAuditLogs(ctx context.Context, filter AuditLogFilter) ([]entity.AuditLog, error) {
+	sql, args, err := a.db.Builder.
+		Select(
+			table.AuditLog.ID, table.AuditLog.ActorUserID,
+			table.AuditLog.TargetUserID, table.AuditLog.Action,
+			table.AuditLog.Result, table.AuditLog.Detail,
+			table.AuditLog.IP, table.AuditLog.UserAgent,
+			table.AuditLog.RequestID, table.AuditLog.CreatedAt,
+		).
+		From(table.AuditLog.String()).
+		Where(auditLogWhere(filter)).
+		OrderBy(table.AuditLog.ID + " DESC").
+		Limit(filter.Limit).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("AuditLog.db.Builder.ToSql: %w", err)
+	}
+
+	rows, err := a.db.Pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("AuditLog.db.Pool.Query: %w", err)
+	}
+	defer rows.Close()
+
+	auditLogs := []entity.AuditLog{}
+	for rows.Next() {
+		auditLog := entity.AuditLog{}
+		err := rows.Scan(
+			&auditLog.ID, &auditLog.ActorUserID,
+			&auditLog.TargetUserID, &auditLog.Action,
+			&auditLog.Result, &auditLog.Detail,
+			&auditLog.IP, &auditLog.UserAgent,
+			&auditLog.RequestID, &auditLog.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("pgx.Rows.Scan: %w", err)
+		}
+		auditLogs = append(auditLogs, auditLog)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("pgx.Rows.Err: %w", err)
+	}
+
+	return auditLogs, nil
+}
+
+// PurgeAuditLogs delete audit log entries created before createdBefore,
+// return number of purged entries.
+func (a *AuditLog) PurgeAuditLogs(ctx context.Context, createdBefore time.Time) (int64, error) {
+	sql, args, err := a.db.Builder.
+		Delete(table.AuditLog.String()).
+		Where(sq.Lt{
+			table.AuditLog.CreatedAt: createdBefore,
+		}).
+		ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("AuditLog.db.Builder.ToSql: %w", err)
+	}
+
+	commandTag, err := a.db.Pool.Exec(ctx, sql, args...)
+	if err != nil {
+		return 0, fmt.Errorf("AuditLog.db.Pool.Exec: %w", err)
+	}
+
+	return commandTag.RowsAffected(), nil
+}
+
+// DeleteAuditLogsByUserIDs delete audit log entries the users are actor or
+// target of, return number of deleted entries. Entries are append only so they
+// are deleted instead of anonymized.
+func (a *AuditLog) DeleteAuditLogsByUserIDs(ctx context.Context, userIDs []int64) (int64, error) {
+	sql, args, err := a.db.Builder.
+		Delete(table.AuditLog.String()).
+		Where(sq.Or{
+			sq.Eq{table.AuditLog.ActorUserID: userIDs},
+			sq.Eq{table.AuditLog.TargetUserID: userIDs},
+		}).
+		ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("AuditLog.db.Builder.ToSql: %w", err)
+	}
+
+	commandTag, err := a.db.Pool.Exec(ctx, sql, args...)
+	if err != nil {
+		return 0, fmt.Errorf("AuditLog.db.Pool.Exec: %w", err)
+	}
+
+	return commandTag.RowsAffected(), nil
+}
+
+func auditLogWhere(filter AuditLogFilter) sq.And {
+	where := sq.And{}
+
+	if filter.ActorUserID != nil {
+		where = append(where, sq.Eq{table.AuditLog.ActorUserID: *filter.ActorUserID})
+	}
+
+	if filter.TargetUserID != nil {
+		where = append(where, sq.Eq{table.AuditLog.TargetUserID: *filter.TargetUserID})
+	}
+
+	if filter.Action != "" {
+		where = append(where, sq.Eq{table.AuditLog.Action: filter.Action})
+	}
+
+	if filter.Result != "" {
+		where = append(where, sq.Eq{table.AuditLog.Result: filter.Result})
+	}
+
+	if filter.CreatedFrom != nil {
+		where = append(where, sq.GtOrEq{table.AuditLog.CreatedAt: *filter.CreatedFrom})
+	}
+
+	if filter.CreatedTo != nil {
+		where = append(where, sq.Lt{table.AuditLog.CreatedAt: *filter.CreatedTo})
+	}
+
+	if filter.BeforeID > 0 {
+		where = append(where, sq.Lt{table.AuditLog.ID: filter.BeforeID})
+	}
+
+	return where
+}
+
+// IterateAuditLogsByUserID call fn for every audit log entry the user is actor
+// or target of, ordered by id. Entries are not buffered, iteration stops at the
+// first error returned by fn.
+func (a *AuditLog) IterateAuditLogsByUserID(ctx context.Context, userID int64, fn func(entity.AuditLog) error) error {
+	sql, args, err := a.db.Builder.
+		Select(
+			table.AuditLog.ID, table.AuditLog.ActorUserID,
+			table.AuditLog.TargetUserID, table.AuditLog.Action,
+			table.AuditLog.Result, table.AuditLog.Detail,
+			table.AuditLog.IP, table.AuditLog.UserAgent,
+			table.AuditLog.RequestID, table.AuditLog.CreatedAt,
+		).
+		From(table.AuditLog.String()).
+		Where(sq.Or{
+			sq.Eq{table.AuditLog.ActorUserID: userID},
+			sq.Eq{table.AuditLog.TargetUserID: userID},
+		}).
+		OrderBy(table.AuditLog.ID).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("AuditLog.db.Builder.ToSql: %w", err)
+	}
+
+	rows, err := a.db.Pool.Query(ctx, sql, args...)
+	if err != nil {
+		return fmt.Errorf("AuditLog.db.Pool.Query: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		auditLog := entity.AuditLog{}
+		err := rows.Scan(
+			&auditLog.ID, &auditLog.ActorUserID,
+			&auditLog.TargetUserID, &auditLog.Action,
+			&auditLog.Result, &auditLog.Detail,
+			&auditLog.IP, &auditLog.UserAgent,
+			&auditLog.RequestID, &auditLog.CreatedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("pgx.Rows.Scan: %w", err)
+		}
+
+		err = fn(auditLog)
+		if err != nil {
+			return fmt.Errorf("fn: %w", err)
+		}
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return fmt.Errorf("pgx.Rows.Err: %w", err)
+	}
+
+	return nil
+}
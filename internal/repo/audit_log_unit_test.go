@@ -0,0 +1,358 @@
+package repo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Hidayathamir/go-user/config"
+	"github.com/Hidayathamir/go-user/internal/repo/db"
+	"github.com/Hidayathamir/go-user/internal/repo/db/entity"
+	"github.com/pashagolub/pgxmock/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var auditLogColumns = []string{
+	"id", "actor_user_id", "target_user_id", "action", "result", "detail",
+	"ip", "user_agent", "request_id", "created_at",
+}
+
+func TestUnitAuditLogCreateAuditLog(t *testing.T) {
+	t.Parallel()
+
+	t.Run("create audit log success", func(t *testing.T) {
+		t.Parallel()
+
+		mockpool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+
+		a := &AuditLog{
+			cfg: config.Config{},
+			db: &db.Postgres{
+				Builder: builder,
+				Pool:    mockpool,
+			},
+		}
+
+		userID := int64(7)
+		now := time.Now()
+		mockpool.
+			ExpectExec(`INSERT INTO \"audit_log\"`).
+			WithArgs(
+				&userID, &userID, entity.AuditActionUserLogin, entity.AuditResultSuccess,
+				"username 'hidayat'", "10.0.0.1", "Mozilla/5.0", "req-1", now,
+			).
+			WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+		err = a.CreateAuditLog(context.Background(), entity.AuditLog{
+			ActorUserID:  &userID,
+			TargetUserID: &userID,
+			Action:       entity.AuditActionUserLogin,
+			Result:       entity.AuditResultSuccess,
+			Detail:       "username 'hidayat'",
+			IP:           "10.0.0.1",
+			UserAgent:    "Mozilla/5.0",
+			RequestID:    "req-1",
+			CreatedAt:    now,
+		})
+
+		require.NoError(t, err)
+		require.NoError(t, mockpool.ExpectationsWereMet())
+	})
+	t.Run("Exec error should return error", func(t *testing.T) {
+		t.Parallel()
+
+		mockpool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+
+		a := &AuditLog{
+			cfg: config.Config{},
+			db: &db.Postgres{
+				Builder: builder,
+				Pool:    mockpool,
+			},
+		}
+
+		now := time.Now()
+		mockpool.
+			ExpectExec("INSERT").
+			WithArgs(
+				(*int64)(nil), (*int64)(nil), entity.AuditActionUserLogin, entity.AuditResultFailure,
+				"", "", "", "", now,
+			).
+			WillReturnError(assert.AnError)
+
+		err = a.CreateAuditLog(context.Background(), entity.AuditLog{
+			Action:    entity.AuditActionUserLogin,
+			Result:    entity.AuditResultFailure,
+			CreatedAt: now,
+		})
+
+		require.Error(t, err)
+		require.ErrorIs(t, err, assert.AnError)
+	})
+}
+
+func TestUnitAuditLogGetAuditLogs(t *testing.T) {
+	t.Parallel()
+
+	t.Run("get audit logs with filter success", func(t *testing.T) {
+		t.Parallel()
+
+		mockpool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+
+		a := &AuditLog{
+			cfg: config.Config{},
+			db: &db.Postgres{
+				Builder: builder,
+				Pool:    mockpool,
+			},
+		}
+
+		userID := int64(7)
+		createdFrom := time.Now().Add(-time.Hour)
+		now := time.Now()
+		mockpool.
+			ExpectQuery(`SELECT .* FROM \"audit_log\" WHERE \(target_user_id = .* AND action = .* AND created_at >= .* AND id < .*\) ORDER BY id DESC LIMIT 11`).
+			WithArgs(userID, entity.AuditActionUserLogin, createdFrom, int64(30)).
+			WillReturnRows(pgxmock.NewRows(auditLogColumns).
+				AddRow(int64(21), &userID, &userID, entity.AuditActionUserLogin, entity.AuditResultFailure, "", "10.0.0.1", "", "", now).
+				AddRow(int64(20), (*int64)(nil), (*int64)(nil), entity.AuditActionUserLogin, entity.AuditResultFailure, "", "10.0.0.1", "", "", now),
+			)
+
+		auditLogs, err := a.GetAuditLogs(context.Background(), AuditLogFilter{
+			TargetUserID: &userID,
+			Action:       entity.AuditActionUserLogin,
+			CreatedFrom:  &createdFrom,
+			BeforeID:     30,
+			Limit:        11,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, auditLogs, 2)
+		assert.Equal(t, int64(21), auditLogs[0].ID)
+		require.NotNil(t, auditLogs[0].TargetUserID)
+		assert.Equal(t, userID, *auditLogs[0].TargetUserID)
+		assert.Nil(t, auditLogs[1].ActorUserID)
+	})
+	t.Run("Query error should return error", func(t *testing.T) {
+		t.Parallel()
+
+		mockpool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+
+		a := &AuditLog{
+			cfg: config.Config{},
+			db: &db.Postgres{
+				Builder: builder,
+				Pool:    mockpool,
+			},
+		}
+
+		mockpool.ExpectQuery("SELECT").WillReturnError(assert.AnError)
+
+		auditLogs, err := a.GetAuditLogs(context.Background(), AuditLogFilter{Limit: 10})
+
+		assert.Nil(t, auditLogs)
+		require.Error(t, err)
+		require.ErrorIs(t, err, assert.AnError)
+	})
+}
+
+func TestUnitAuditLogPurgeAuditLogs(t *testing.T) {
+	t.Parallel()
+
+	t.Run("purge audit logs success", func(t *testing.T) {
+		t.Parallel()
+
+		mockpool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+
+		a := &AuditLog{
+			cfg: config.Config{},
+			db: &db.Postgres{
+				Builder: builder,
+				Pool:    mockpool,
+			},
+		}
+
+		createdBefore := time.Now()
+		mockpool.
+			ExpectExec(`DELETE FROM \"audit_log\" WHERE created_at < .*`).
+			WithArgs(createdBefore).
+			WillReturnResult(pgxmock.NewResult("DELETE", 4))
+
+		count, err := a.PurgeAuditLogs(context.Background(), createdBefore)
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(4), count)
+	})
+	t.Run("Exec error should return error", func(t *testing.T) {
+		t.Parallel()
+
+		mockpool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+
+		a := &AuditLog{
+			cfg: config.Config{},
+			db: &db.Postgres{
+				Builder: builder,
+				Pool:    mockpool,
+			},
+		}
+
+		createdBefore := time.Now()
+		mockpool.ExpectExec("DELETE").WithArgs(createdBefore).WillReturnError(assert.AnError)
+
+		count, err := a.PurgeAuditLogs(context.Background(), createdBefore)
+
+		require.Error(t, err)
+		require.ErrorIs(t, err, assert.AnError)
+		assert.Equal(t, int64(0), count)
+	})
+}
+
+func TestUnitAuditLogIterateAuditLogsByUserID(t *testing.T) {
+	t.Parallel()
+
+	t.Run("iterate audit logs success", func(t *testing.T) {
+		t.Parallel()
+
+		mockpool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+
+		a := &AuditLog{
+			cfg: config.Config{},
+			db: &db.Postgres{
+				Builder: builder,
+				Pool:    mockpool,
+			},
+		}
+
+		userID, adminID := int64(12), int64(1)
+		now := time.Now()
+		mockpool.ExpectQuery("SELECT .* WHERE \\(actor_user_id = \\$1 OR target_user_id = \\$2\\) ORDER BY id").
+			WithArgs(int64(12), int64(12)).
+			WillReturnRows(pgxmock.NewRows(auditLogColumns).
+				AddRow(int64(5), &userID, &userID, entity.AuditActionUserLogin, entity.AuditResultSuccess, "", "10.0.0.1", "curl/8.0", "req1", now).
+				AddRow(int64(6), &adminID, &userID, entity.AuditActionUserPasswordChange, entity.AuditResultSuccess, "", "10.0.0.2", "curl/8.0", "req2", now),
+			)
+
+		ids := []int64{}
+		err = a.IterateAuditLogsByUserID(context.Background(), 12, func(auditLog entity.AuditLog) error {
+			ids = append(ids, auditLog.ID)
+			return nil
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []int64{5, 6}, ids)
+	})
+	t.Run("fn error should stop iteration and return error", func(t *testing.T) {
+		t.Parallel()
+
+		mockpool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+
+		a := &AuditLog{
+			cfg: config.Config{},
+			db: &db.Postgres{
+				Builder: builder,
+				Pool:    mockpool,
+			},
+		}
+
+		userID := int64(12)
+		now := time.Now()
+		mockpool.ExpectQuery("SELECT").WithArgs(int64(12), int64(12)).
+			WillReturnRows(pgxmock.NewRows(auditLogColumns).
+				AddRow(int64(5), &userID, &userID, entity.AuditActionUserLogin, entity.AuditResultSuccess, "", "10.0.0.1", "curl/8.0", "req1", now).
+				AddRow(int64(6), &userID, &userID, entity.AuditActionUserLogin, entity.AuditResultSuccess, "", "10.0.0.1", "curl/8.0", "req2", now),
+			)
+
+		callCount := 0
+		err = a.IterateAuditLogsByUserID(context.Background(), 12, func(entity.AuditLog) error {
+			callCount++
+			return assert.AnError
+		})
+
+		require.Error(t, err)
+		require.ErrorIs(t, err, assert.AnError)
+		assert.Equal(t, 1, callCount)
+	})
+	t.Run("Query error should return error", func(t *testing.T) {
+		t.Parallel()
+
+		mockpool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+
+		a := &AuditLog{
+			cfg: config.Config{},
+			db: &db.Postgres{
+				Builder: builder,
+				Pool:    mockpool,
+			},
+		}
+
+		mockpool.ExpectQuery("SELECT").WithArgs(int64(12), int64(12)).WillReturnError(assert.AnError)
+
+		err = a.IterateAuditLogsByUserID(context.Background(), 12, func(entity.AuditLog) error {
+			return nil
+		})
+
+		require.Error(t, err)
+		require.ErrorIs(t, err, assert.AnError)
+	})
+}
+
+func TestUnitAuditLogDeleteAuditLogsByUserIDs(t *testing.T) {
+	t.Parallel()
+
+	t.Run("delete audit logs by user ids success", func(t *testing.T) {
+		t.Parallel()
+
+		mockpool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+
+		a := &AuditLog{
+			cfg: config.Config{},
+			db: &db.Postgres{
+				Builder: builder,
+				Pool:    mockpool,
+			},
+		}
+
+		mockpool.
+			ExpectExec(`DELETE FROM \"audit_log\" WHERE \(actor_user_id IN \(\$1,\$2\) OR target_user_id IN \(\$3,\$4\)\)`).
+			WithArgs(int64(44), int64(45), int64(44), int64(45)).
+			WillReturnResult(pgxmock.NewResult("DELETE", 3))
+
+		count, err := a.DeleteAuditLogsByUserIDs(context.Background(), []int64{44, 45})
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), count)
+	})
+	t.Run("Exec error should return error", func(t *testing.T) {
+		t.Parallel()
+
+		mockpool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+
+		a := &AuditLog{
+			cfg: config.Config{},
+			db: &db.Postgres{
+				Builder: builder,
+				Pool:    mockpool,
+			},
+		}
+
+		mockpool.ExpectExec("DELETE").WithArgs(int64(44), int64(45), int64(44), int64(45)).WillReturnError(assert.AnError)
+
+		count, err := a.DeleteAuditLogsByUserIDs(context.Background(), []int64{44, 45})
+
+		require.Error(t, err)
+		require.ErrorIs(t, err, assert.AnError)
+		assert.Equal(t, int64(0), count)
+	})
+}
@@ -20,7 +20,9 @@ import (
 
 // IAuth contains abstraction of repo authentication.
 type IAuth interface {
-	// RegisterUser register new user.
+	// RegisterUser register new user. Event user.registered is written to
+	// outbox in the transaction bound to ctx, it must be called within
+	// ITransactor.WithinTx.
 	RegisterUser(ctx context.Context, user entity.User) (int64, error)
 }
 
@@ -40,8 +42,30 @@ func NewAuth(cfg config.Config, db *db.Postgres) *Auth {
 	}
 }
 
-// RegisterUser register new user.
+// RegisterUser register new user. Event user.registered is written to outbox
+// in the transaction bound to ctx, it must be called within
+// ITransactor.WithinTx.
 func (a *Auth) RegisterUser(ctx context.Context, user entity.User) (int64, error) {
+	err := checkWithinTx(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("checkWithinTx: %w", err)
+	}
+
+	userID, err := a.insertUser(ctx, user)
+	if err != nil {
+		return 0, fmt.Errorf("Auth.insertUser: %w", err)
+	}
+
+	data := gouser.EventUserRegistered{UserID: userID, Username: user.Username}
+	err = insertOutboxEvent(ctx, a.db, gouser.EventTypeUserRegistered, userID, data)
+	if err != nil {
+		return 0, fmt.Errorf("insertOutboxEvent: %w", err)
+	}
+
+	return userID, nil
+}
+
+func (a *Auth) insertUser(ctx context.Context, user entity.User) (int64, error) {
 	now := time.Now()
 
 	sql, args, err := a.db.Builder.
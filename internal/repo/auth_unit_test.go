@@ -30,15 +30,27 @@ func TestUnitAuthRegisterUser(t *testing.T) {
 			cfg: config.Config{},
 			db: &db.Postgres{
 				Builder: builder,
-				Pool:    mockpool,
+				Pool:    db.NewContextPool(mockpool),
 			},
 		}
 
+		mockpool.ExpectBegin()
+		tx, err := mockpool.Begin(context.Background())
+		require.NoError(t, err)
+		ctx := db.ContextWithTx(context.Background(), tx)
+
 		mockpool.
 			ExpectQuery("INSERT").WithArgs("hidayat", "mypassword", anyTime{}, anyTime{}).
 			WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(int64(334)))
-
-		userID, err := a.RegisterUser(context.Background(), entity.User{
+		mockpool.
+			ExpectExec("INSERT INTO \"outbox_event\"").
+			WithArgs(
+				gouser.EventTypeUserRegistered, int64(334), []byte(`{"user_id":334,"username":"hidayat"}`),
+				anyTime{}, anyTime{},
+			).
+			WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+		userID, err := a.RegisterUser(ctx, entity.User{
 			ID:        0,
 			Username:  "hidayat",
 			Password:  "mypassword",
@@ -48,6 +60,7 @@ func TestUnitAuthRegisterUser(t *testing.T) {
 
 		assert.Equal(t, int64(334), userID)
 		require.NoError(t, err)
+		require.NoError(t, mockpool.ExpectationsWereMet())
 	})
 	t.Run("QueryRow Scan error should return error", func(t *testing.T) {
 		t.Parallel()
@@ -59,15 +72,20 @@ func TestUnitAuthRegisterUser(t *testing.T) {
 			cfg: config.Config{},
 			db: &db.Postgres{
 				Builder: builder,
-				Pool:    mockpool,
+				Pool:    db.NewContextPool(mockpool),
 			},
 		}
 
+		mockpool.ExpectBegin()
+		tx, err := mockpool.Begin(context.Background())
+		require.NoError(t, err)
+		ctx := db.ContextWithTx(context.Background(), tx)
+
 		mockpool.
 			ExpectQuery("INSERT").WithArgs("hidayat", "mypassword", anyTime{}, anyTime{}).
 			WillReturnError(assert.AnError)
 
-		userID, err := a.RegisterUser(context.Background(), entity.User{
+		userID, err := a.RegisterUser(ctx, entity.User{
 			ID:        0,
 			Username:  "hidayat",
 			Password:  "mypassword",
@@ -89,17 +107,22 @@ func TestUnitAuthRegisterUser(t *testing.T) {
 			cfg: config.Config{},
 			db: &db.Postgres{
 				Builder: builder,
-				Pool:    mockpool,
+				Pool:    db.NewContextPool(mockpool),
 			},
 		}
 
+		mockpool.ExpectBegin()
+		tx, err := mockpool.Begin(context.Background())
+		require.NoError(t, err)
+		ctx := db.ContextWithTx(context.Background(), tx)
+
 		mockpool.
 			ExpectQuery("INSERT").WithArgs("hidayat", "mypassword", anyTime{}, anyTime{}).
 			WillReturnError(
 				&pgconn.PgError{Code: pgerrcode.UniqueViolation, ConstraintName: table.User.Constraint.UserUn},
 			)
 
-		userID, err := a.RegisterUser(context.Background(), entity.User{
+		userID, err := a.RegisterUser(ctx, entity.User{
 			ID:        0,
 			Username:  "hidayat",
 			Password:  "mypassword",
@@ -111,4 +134,67 @@ func TestUnitAuthRegisterUser(t *testing.T) {
 		require.Error(t, err)
 		require.ErrorIs(t, err, gouser.ErrDuplicateUsername)
 	})
+	t.Run("insert outbox event error should return error", func(t *testing.T) {
+		t.Parallel()
+
+		mockpool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+
+		a := &Auth{
+			cfg: config.Config{},
+			db: &db.Postgres{
+				Builder: builder,
+				Pool:    db.NewContextPool(mockpool),
+			},
+		}
+
+		mockpool.ExpectBegin()
+		tx, err := mockpool.Begin(context.Background())
+		require.NoError(t, err)
+		ctx := db.ContextWithTx(context.Background(), tx)
+
+		mockpool.
+			ExpectQuery("INSERT").WithArgs("hidayat", "mypassword", anyTime{}, anyTime{}).
+			WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(int64(334)))
+		mockpool.
+			ExpectExec("INSERT INTO \"outbox_event\"").
+			WithArgs(
+				gouser.EventTypeUserRegistered, int64(334), []byte(`{"user_id":334,"username":"hidayat"}`),
+				anyTime{}, anyTime{},
+			).
+			WillReturnError(assert.AnError)
+
+		userID, err := a.RegisterUser(ctx, entity.User{
+			ID:        0,
+			Username:  "hidayat",
+			Password:  "mypassword",
+			CreatedAt: time.Time{},
+			UpdatedAt: time.Time{},
+		})
+
+		assert.Equal(t, int64(0), userID)
+		require.Error(t, err)
+		require.ErrorIs(t, err, assert.AnError)
+		require.NoError(t, mockpool.ExpectationsWereMet())
+	})
+	t.Run("ctx without transaction should return error", func(t *testing.T) {
+		t.Parallel()
+
+		mockpool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+
+		a := &Auth{
+			cfg: config.Config{},
+			db: &db.Postgres{
+				Builder: builder,
+				Pool:    db.NewContextPool(mockpool),
+			},
+		}
+
+		userID, err := a.RegisterUser(context.Background(), entity.User{Username: "hidayat", Password: "mypassword"})
+
+		assert.Equal(t, int64(0), userID)
+		require.ErrorIs(t, err, errNotWithinTx)
+		require.NoError(t, mockpool.ExpectationsWereMet())
+	})
 }
@@ -0,0 +1,55 @@
+package db
+
+import (
+	"net"
+	"net/url"
+	"strconv"
+
+	"github.com/Hidayathamir/go-user/config"
+)
+
+// ConnString return postgres connection string of cfg, used by both pool and
+// migration. Postgres DSN is returned as is if set, else it is built from
+// connection fields with user, password and database name escaped.
+func ConnString(cfg config.Config) string {
+	if cfg.PG.DSN != "" {
+		return cfg.PG.DSN
+	}
+
+	query := url.Values{}
+	if cfg.PG.SSLMode != "" {
+		query.Set("sslmode", cfg.PG.SSLMode)
+	}
+	if cfg.PG.SSLRootCertFile != "" {
+		query.Set("sslrootcert", cfg.PG.SSLRootCertFile)
+	}
+	if cfg.PG.SSLCertFile != "" {
+		query.Set("sslcert", cfg.PG.SSLCertFile)
+	}
+	if cfg.PG.SSLKeyFile != "" {
+		query.Set("sslkey", cfg.PG.SSLKeyFile)
+	}
+	if cfg.PG.ConnectTimeoutSecond > 0 {
+		query.Set("connect_timeout", strconv.Itoa(cfg.PG.ConnectTimeoutSecond))
+	}
+	if cfg.PG.StatementTimeoutMillisecond > 0 {
+		query.Set("statement_timeout", strconv.Itoa(cfg.PG.StatementTimeoutMillisecond))
+	}
+	applicationName := cfg.PG.ApplicationName
+	if applicationName == "" {
+		applicationName = cfg.App.Name
+	}
+	if applicationName != "" {
+		query.Set("application_name", applicationName)
+	}
+
+	u := url.URL{
+		Scheme:   "postgres",
+		User:     url.UserPassword(cfg.PG.Username, cfg.PG.Password),
+		Host:     net.JoinHostPort(cfg.PG.Host, strconv.Itoa(cfg.PG.Port)),
+		Path:     "/" + cfg.PG.DBName,
+		RawQuery: query.Encode(),
+	}
+
+	return u.String()
+}
@@ -0,0 +1,96 @@
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Hidayathamir/go-user/config"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestPGConfig() config.Config {
+	return config.Config{
+		App: config.App{Name: "go-user"},
+		PG: config.PG{
+			Username:             "user",
+			Password:             "password",
+			Host:                 "localhost",
+			Port:                 5432,
+			DBName:               "playground",
+			SSLMode:              config.PGSSLModeDisable,
+			ConnectTimeoutSecond: 5,
+			PoolMax:              10,
+		},
+	}
+}
+
+func TestUnitConnString(t *testing.T) {
+	t.Parallel()
+
+	t.Run("special characters should be escaped", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := newTestPGConfig()
+		cfg.PG.Username = "us@er"
+		cfg.PG.Password = "p@ss:w/o?r#d%20"
+		cfg.PG.DBName = "play ground"
+
+		pgConfig, err := pgconn.ParseConfig(ConnString(cfg))
+
+		require.NoError(t, err)
+		assert.Equal(t, "us@er", pgConfig.User)
+		assert.Equal(t, "p@ss:w/o?r#d%20", pgConfig.Password)
+		assert.Equal(t, "play ground", pgConfig.Database)
+		assert.Equal(t, "localhost", pgConfig.Host)
+		assert.Equal(t, uint16(5432), pgConfig.Port)
+	})
+	t.Run("connection settings should be applied", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := newTestPGConfig()
+		cfg.PG.StatementTimeoutMillisecond = 3000
+
+		pgConfig, err := pgconn.ParseConfig(ConnString(cfg))
+
+		require.NoError(t, err)
+		assert.Nil(t, pgConfig.TLSConfig)
+		assert.Equal(t, 5*time.Second, pgConfig.ConnectTimeout)
+		assert.Equal(t, "3000", pgConfig.RuntimeParams["statement_timeout"])
+		assert.Equal(t, "go-user", pgConfig.RuntimeParams["application_name"])
+	})
+	t.Run("application name should override app name", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := newTestPGConfig()
+		cfg.PG.ApplicationName = "go-user-worker"
+		cfg.PG.SSLMode = config.PGSSLModeRequire
+
+		pgConfig, err := pgconn.ParseConfig(ConnString(cfg))
+
+		require.NoError(t, err)
+		assert.NotNil(t, pgConfig.TLSConfig)
+		assert.Equal(t, "go-user-worker", pgConfig.RuntimeParams["application_name"])
+		assert.NotContains(t, pgConfig.RuntimeParams, "statement_timeout")
+	})
+	t.Run("ipv6 host should be bracketed", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := newTestPGConfig()
+		cfg.PG.Host = "::1"
+
+		pgConfig, err := pgconn.ParseConfig(ConnString(cfg))
+
+		require.NoError(t, err)
+		assert.Equal(t, "::1", pgConfig.Host)
+	})
+	t.Run("dsn should override connection fields", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := newTestPGConfig()
+		cfg.PG.DSN = "host=db.internal user=app dbname=gouser sslmode=verify-full"
+
+		assert.Equal(t, cfg.PG.DSN, ConnString(cfg))
+	})
+}
@@ -0,0 +1,40 @@
+package entity
+
+import "time"
+
+// Audit log action.
+const (
+	AuditActionUserRegister       = "user.register"
+	AuditActionUserLogin          = "user.login"
+	AuditActionUserPasswordChange = "user.password_change"
+	AuditActionUserDelete         = "user.delete"
+	AuditActionUserRestore        = "user.restore"
+	AuditActionUsernameChange     = "user.username_change"
+	AuditActionUserStatusUpdate   = "user.status_update"
+	AuditActionSessionRevoke      = "session.revoke"
+)
+
+// Audit log result.
+const (
+	AuditResultSuccess = "success"
+	AuditResultFailure = "failure"
+)
+
+// AuditLog is entity audit log, in db it's table `audit_log`. One row is
+// appended for each security relevant action, it is never updated.
+type AuditLog struct {
+	ID int64
+	// ActorUserID is who did the action, nil if unknown, e.g login with
+	// unknown username.
+	ActorUserID *int64
+	// TargetUserID is whose account the action is on, nil if unknown.
+	TargetUserID *int64
+	Action       string
+	Result       string
+	// Detail is free text, e.g reason of failure.
+	Detail    string
+	IP        string
+	UserAgent string
+	RequestID string
+	CreatedAt time.Time
+}
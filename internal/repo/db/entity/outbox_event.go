@@ -0,0 +1,22 @@
+package entity
+
+import "time"
+
+// OutboxEvent is entity outbox event, in db it's table `outbox_event`. Event is
+// written in the same transaction as the change it describes, then published
+// by relay.
+type OutboxEvent struct {
+	ID        int64
+	EventType string
+	UserID    int64
+	// Payload is JSON encoded event data.
+	Payload   []byte
+	CreatedAt time.Time
+	// PublishedAt is nil until the event is published.
+	PublishedAt   *time.Time
+	Attempt       int
+	NextAttemptAt time.Time
+	LastError     string
+	// XactID is id of the transaction which wrote the event.
+	XactID int64
+}
@@ -0,0 +1,17 @@
+package entity
+
+import "time"
+
+// Session is entity session, in db it's table `session`. One session is
+// created for each successful login and bound to the JWT by JTI.
+type Session struct {
+	ID         int64
+	UserID     int64
+	JTI        string
+	UserAgent  string
+	IP         string
+	CreatedAt  time.Time
+	LastSeenAt time.Time
+	ExpiredAt  time.Time
+	RevokedAt  *time.Time
+}
@@ -0,0 +1,75 @@
+package table
+
+import "github.com/sirupsen/logrus"
+
+// AuditLog is table `audit_log`. Use this to get table name and column name when query to database.
+// Got panic? did you run Init which run initTableAuditLog?
+var AuditLog *auditLog
+
+type auditLog struct {
+	tableName  string
+	Dot        *auditLog
+	Constraint auditLogConstraint
+
+	ID           string
+	ActorUserID  string
+	TargetUserID string
+	Action       string
+	Result       string
+	Detail       string
+	IP           string
+	UserAgent    string
+	RequestID    string
+	CreatedAt    string
+}
+
+type auditLogConstraint struct {
+	AuditLogPk string
+}
+
+func (a *auditLog) String() string {
+	return a.tableName
+}
+
+func initTableAuditLog() {
+	if AuditLog != nil {
+		logrus.Warn("table AuditLog already initialized")
+		return
+	}
+
+	AuditLog = &auditLog{
+		tableName: "\"audit_log\"",
+		Dot:       &auditLog{},
+		Constraint: auditLogConstraint{
+			AuditLogPk: "audit_log_pk",
+		},
+		ID:           "id",
+		ActorUserID:  "actor_user_id",
+		TargetUserID: "target_user_id",
+		Action:       "action",
+		Result:       "result",
+		Detail:       "detail",
+		IP:           "ip",
+		UserAgent:    "user_agent",
+		RequestID:    "request_id",
+		CreatedAt:    "created_at",
+	}
+
+	AuditLog.Dot = &auditLog{
+		tableName: AuditLog.tableName,
+		Dot:       &auditLog{},
+		Constraint: auditLogConstraint{
+			AuditLogPk: AuditLog.Constraint.AuditLogPk,
+		},
+		ID:           AuditLog.tableName + "." + AuditLog.ID,
+		ActorUserID:  AuditLog.tableName + "." + AuditLog.ActorUserID,
+		TargetUserID: AuditLog.tableName + "." + AuditLog.TargetUserID,
+		Action:       AuditLog.tableName + "." + AuditLog.Action,
+		Result:       AuditLog.tableName + "." + AuditLog.Result,
+		Detail:       AuditLog.tableName + "." + AuditLog.Detail,
+		IP:           AuditLog.tableName + "." + AuditLog.IP,
+		UserAgent:    AuditLog.tableName + "." + AuditLog.UserAgent,
+		RequestID:    AuditLog.tableName + "." + AuditLog.RequestID,
+		CreatedAt:    AuditLog.tableName + "." + AuditLog.CreatedAt,
+	}
+}
@@ -0,0 +1,78 @@
+package table
+
+import "github.com/sirupsen/logrus"
+
+// OutboxEvent is table `outbox_event`. Use this to get table name and column name when query to database.
+// Got panic? did you run Init which run initTableOutboxEvent?
+var OutboxEvent *outboxEvent
+
+type outboxEvent struct {
+	tableName  string
+	Dot        *outboxEvent
+	Constraint outboxEventConstraint
+
+	ID            string
+	EventType     string
+	UserID        string
+	Payload       string
+	CreatedAt     string
+	PublishedAt   string
+	Attempt       string
+	NextAttemptAt string
+	LastError     string
+	LockedUntil   string
+	XactID        string
+}
+
+type outboxEventConstraint struct {
+	OutboxEventPk string
+}
+
+func (o *outboxEvent) String() string {
+	return o.tableName
+}
+
+func initTableOutboxEvent() {
+	if OutboxEvent != nil {
+		logrus.Warn("table OutboxEvent already initialized")
+		return
+	}
+
+	OutboxEvent = &outboxEvent{
+		tableName: "\"outbox_event\"",
+		Dot:       &outboxEvent{},
+		Constraint: outboxEventConstraint{
+			OutboxEventPk: "outbox_event_pk",
+		},
+		ID:            "id",
+		EventType:     "event_type",
+		UserID:        "user_id",
+		Payload:       "payload",
+		CreatedAt:     "created_at",
+		PublishedAt:   "published_at",
+		Attempt:       "attempt",
+		NextAttemptAt: "next_attempt_at",
+		LastError:     "last_error",
+		LockedUntil:   "locked_until",
+		XactID:        "xact_id",
+	}
+
+	OutboxEvent.Dot = &outboxEvent{
+		tableName: OutboxEvent.tableName,
+		Dot:       &outboxEvent{},
+		Constraint: outboxEventConstraint{
+			OutboxEventPk: OutboxEvent.Constraint.OutboxEventPk,
+		},
+		ID:            OutboxEvent.tableName + "." + OutboxEvent.ID,
+		EventType:     OutboxEvent.tableName + "." + OutboxEvent.EventType,
+		UserID:        OutboxEvent.tableName + "." + OutboxEvent.UserID,
+		Payload:       OutboxEvent.tableName + "." + OutboxEvent.Payload,
+		CreatedAt:     OutboxEvent.tableName + "." + OutboxEvent.CreatedAt,
+		PublishedAt:   OutboxEvent.tableName + "." + OutboxEvent.PublishedAt,
+		Attempt:       OutboxEvent.tableName + "." + OutboxEvent.Attempt,
+		NextAttemptAt: OutboxEvent.tableName + "." + OutboxEvent.NextAttemptAt,
+		LastError:     OutboxEvent.tableName + "." + OutboxEvent.LastError,
+		LockedUntil:   OutboxEvent.tableName + "." + OutboxEvent.LockedUntil,
+		XactID:        OutboxEvent.tableName + "." + OutboxEvent.XactID,
+	}
+}
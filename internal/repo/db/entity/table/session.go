@@ -0,0 +1,78 @@
+package table
+
+import "github.com/sirupsen/logrus"
+
+// Session is table `session`. Use this to get table name and column name when query to database.
+// Got panic? did you run Init which run initTableSession?
+var Session *session
+
+type session struct {
+	tableName  string
+	Dot        *session
+	Constraint sessionConstraint
+
+	ID         string
+	UserID     string
+	JTI        string
+	UserAgent  string
+	IP         string
+	CreatedAt  string
+	LastSeenAt string
+	ExpiredAt  string
+	RevokedAt  string
+}
+
+type sessionConstraint struct {
+	SessionPk     string
+	SessionJTIUn  string
+	SessionUserFk string
+}
+
+func (s *session) String() string {
+	return s.tableName
+}
+
+func initTableSession() {
+	if Session != nil {
+		logrus.Warn("table Session already initialized")
+		return
+	}
+
+	Session = &session{
+		tableName: "\"session\"",
+		Dot:       &session{},
+		Constraint: sessionConstraint{
+			SessionPk:     "session_pk",
+			SessionJTIUn:  "session_jti_un",
+			SessionUserFk: "session_user_fk",
+		},
+		ID:         "id",
+		UserID:     "user_id",
+		JTI:        "jti",
+		UserAgent:  "user_agent",
+		IP:         "ip",
+		CreatedAt:  "created_at",
+		LastSeenAt: "last_seen_at",
+		ExpiredAt:  "expired_at",
+		RevokedAt:  "revoked_at",
+	}
+
+	Session.Dot = &session{
+		tableName: Session.tableName,
+		Dot:       &session{},
+		Constraint: sessionConstraint{
+			SessionPk:     Session.Constraint.SessionPk,
+			SessionJTIUn:  Session.Constraint.SessionJTIUn,
+			SessionUserFk: Session.Constraint.SessionUserFk,
+		},
+		ID:         Session.tableName + "." + Session.ID,
+		UserID:     Session.tableName + "." + Session.UserID,
+		JTI:        Session.tableName + "." + Session.JTI,
+		UserAgent:  Session.tableName + "." + Session.UserAgent,
+		IP:         Session.tableName + "." + Session.IP,
+		CreatedAt:  Session.tableName + "." + Session.CreatedAt,
+		LastSeenAt: Session.tableName + "." + Session.LastSeenAt,
+		ExpiredAt:  Session.tableName + "." + Session.ExpiredAt,
+		RevokedAt:  Session.tableName + "." + Session.RevokedAt,
+	}
+}
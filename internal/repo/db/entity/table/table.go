@@ -5,4 +5,11 @@ package table
 // because it's just initialize table and column name.
 func init() { //nolint:gochecknoinits
 	initTableUser()
+	initTableSession()
+	initTableUsernameHistory()
+	initTableOutboxEvent()
+	initTableWebhookSubscription()
+	initTableWebhookDelivery()
+	initTableWebhookDeliveryAttempt()
+	initTableAuditLog()
 }
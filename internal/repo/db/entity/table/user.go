@@ -14,8 +14,14 @@ type user struct {
 	ID        string
 	Username  string
 	Password  string
+	Role      string
 	CreatedAt string
 	UpdatedAt string
+	DeletedAt string
+
+	Status           string
+	SuspendedUntil   string
+	SuspensionReason string
 }
 
 type userConstraint struct {
@@ -43,8 +49,14 @@ func initTableUser() {
 		ID:        "id",
 		Username:  "username",
 		Password:  "password",
+		Role:      "role",
 		CreatedAt: "created_at",
 		UpdatedAt: "updated_at",
+		DeletedAt: "deleted_at",
+
+		Status:           "status",
+		SuspendedUntil:   "suspended_until",
+		SuspensionReason: "suspension_reason",
 	}
 
 	User.Dot = &user{
@@ -57,7 +69,13 @@ func initTableUser() {
 		ID:        User.tableName + "." + User.ID,
 		Username:  User.tableName + "." + User.Username,
 		Password:  User.tableName + "." + User.Password,
+		Role:      User.tableName + "." + User.Role,
 		CreatedAt: User.tableName + "." + User.CreatedAt,
 		UpdatedAt: User.tableName + "." + User.UpdatedAt,
+		DeletedAt: User.tableName + "." + User.DeletedAt,
+
+		Status:           User.tableName + "." + User.Status,
+		SuspendedUntil:   User.tableName + "." + User.SuspendedUntil,
+		SuspensionReason: User.tableName + "." + User.SuspensionReason,
 	}
 }
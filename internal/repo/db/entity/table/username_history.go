@@ -0,0 +1,60 @@
+package table
+
+import "github.com/sirupsen/logrus"
+
+// UsernameHistory is table `username_history`. Use this to get table name and column name when query to database.
+// Got panic? did you run Init which run initTableUsernameHistory?
+var UsernameHistory *usernameHistory
+
+type usernameHistory struct {
+	tableName  string
+	Dot        *usernameHistory
+	Constraint usernameHistoryConstraint
+
+	ID        string
+	UserID    string
+	Username  string
+	ChangedAt string
+}
+
+type usernameHistoryConstraint struct {
+	UsernameHistoryPk     string
+	UsernameHistoryUserFk string
+}
+
+func (u *usernameHistory) String() string {
+	return u.tableName
+}
+
+func initTableUsernameHistory() {
+	if UsernameHistory != nil {
+		logrus.Warn("table UsernameHistory already initialized")
+		return
+	}
+
+	UsernameHistory = &usernameHistory{
+		tableName: "\"username_history\"",
+		Dot:       &usernameHistory{},
+		Constraint: usernameHistoryConstraint{
+			UsernameHistoryPk:     "username_history_pk",
+			UsernameHistoryUserFk: "username_history_user_fk",
+		},
+		ID:        "id",
+		UserID:    "user_id",
+		Username:  "username",
+		ChangedAt: "changed_at",
+	}
+
+	UsernameHistory.Dot = &usernameHistory{
+		tableName: UsernameHistory.tableName,
+		Dot:       &usernameHistory{},
+		Constraint: usernameHistoryConstraint{
+			UsernameHistoryPk:     UsernameHistory.Constraint.UsernameHistoryPk,
+			UsernameHistoryUserFk: UsernameHistory.Constraint.UsernameHistoryUserFk,
+		},
+		ID:        UsernameHistory.tableName + "." + UsernameHistory.ID,
+		UserID:    UsernameHistory.tableName + "." + UsernameHistory.UserID,
+		Username:  UsernameHistory.tableName + "." + UsernameHistory.Username,
+		ChangedAt: UsernameHistory.tableName + "." + UsernameHistory.ChangedAt,
+	}
+}
@@ -0,0 +1,81 @@
+package table
+
+import "github.com/sirupsen/logrus"
+
+// WebhookDelivery is table `webhook_delivery`. Use this to get table name and column name when query to database.
+// Got panic? did you run Init which run initTableWebhookDelivery?
+var WebhookDelivery *webhookDelivery
+
+type webhookDelivery struct {
+	tableName  string
+	Dot        *webhookDelivery
+	Constraint webhookDeliveryConstraint
+
+	ID             string
+	SubscriptionID string
+	EventID        string
+	EventType      string
+	Payload        string
+	Status         string
+	Attempt        string
+	NextAttemptAt  string
+	CreatedAt      string
+	UpdatedAt      string
+}
+
+type webhookDeliveryConstraint struct {
+	WebhookDeliveryPk                  string
+	WebhookDeliverySubscriptionEventUn string
+	WebhookDeliverySubscriptionFk      string
+}
+
+func (w *webhookDelivery) String() string {
+	return w.tableName
+}
+
+func initTableWebhookDelivery() {
+	if WebhookDelivery != nil {
+		logrus.Warn("table WebhookDelivery already initialized")
+		return
+	}
+
+	WebhookDelivery = &webhookDelivery{
+		tableName: "\"webhook_delivery\"",
+		Dot:       &webhookDelivery{},
+		Constraint: webhookDeliveryConstraint{
+			WebhookDeliveryPk:                  "webhook_delivery_pk",
+			WebhookDeliverySubscriptionEventUn: "webhook_delivery_subscription_event_un",
+			WebhookDeliverySubscriptionFk:      "webhook_delivery_subscription_fk",
+		},
+		ID:             "id",
+		SubscriptionID: "subscription_id",
+		EventID:        "event_id",
+		EventType:      "event_type",
+		Payload:        "payload",
+		Status:         "status",
+		Attempt:        "attempt",
+		NextAttemptAt:  "next_attempt_at",
+		CreatedAt:      "created_at",
+		UpdatedAt:      "updated_at",
+	}
+
+	WebhookDelivery.Dot = &webhookDelivery{
+		tableName: WebhookDelivery.tableName,
+		Dot:       &webhookDelivery{},
+		Constraint: webhookDeliveryConstraint{
+			WebhookDeliveryPk:                  WebhookDelivery.Constraint.WebhookDeliveryPk,
+			WebhookDeliverySubscriptionEventUn: WebhookDelivery.Constraint.WebhookDeliverySubscriptionEventUn,
+			WebhookDeliverySubscriptionFk:      WebhookDelivery.Constraint.WebhookDeliverySubscriptionFk,
+		},
+		ID:             WebhookDelivery.tableName + "." + WebhookDelivery.ID,
+		SubscriptionID: WebhookDelivery.tableName + "." + WebhookDelivery.SubscriptionID,
+		EventID:        WebhookDelivery.tableName + "." + WebhookDelivery.EventID,
+		EventType:      WebhookDelivery.tableName + "." + WebhookDelivery.EventType,
+		Payload:        WebhookDelivery.tableName + "." + WebhookDelivery.Payload,
+		Status:         WebhookDelivery.tableName + "." + WebhookDelivery.Status,
+		Attempt:        WebhookDelivery.tableName + "." + WebhookDelivery.Attempt,
+		NextAttemptAt:  WebhookDelivery.tableName + "." + WebhookDelivery.NextAttemptAt,
+		CreatedAt:      WebhookDelivery.tableName + "." + WebhookDelivery.CreatedAt,
+		UpdatedAt:      WebhookDelivery.tableName + "." + WebhookDelivery.UpdatedAt,
+	}
+}
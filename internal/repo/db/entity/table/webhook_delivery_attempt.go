@@ -0,0 +1,66 @@
+package table
+
+import "github.com/sirupsen/logrus"
+
+// WebhookDeliveryAttempt is table `webhook_delivery_attempt`. Use this to get table name and column name when query to database.
+// Got panic? did you run Init which run initTableWebhookDeliveryAttempt?
+var WebhookDeliveryAttempt *webhookDeliveryAttempt
+
+type webhookDeliveryAttempt struct {
+	tableName  string
+	Dot        *webhookDeliveryAttempt
+	Constraint webhookDeliveryAttemptConstraint
+
+	ID          string
+	DeliveryID  string
+	AttemptedAt string
+	StatusCode  string
+	Error       string
+	DurationMS  string
+}
+
+type webhookDeliveryAttemptConstraint struct {
+	WebhookDeliveryAttemptPk         string
+	WebhookDeliveryAttemptDeliveryFk string
+}
+
+func (w *webhookDeliveryAttempt) String() string {
+	return w.tableName
+}
+
+func initTableWebhookDeliveryAttempt() {
+	if WebhookDeliveryAttempt != nil {
+		logrus.Warn("table WebhookDeliveryAttempt already initialized")
+		return
+	}
+
+	WebhookDeliveryAttempt = &webhookDeliveryAttempt{
+		tableName: "\"webhook_delivery_attempt\"",
+		Dot:       &webhookDeliveryAttempt{},
+		Constraint: webhookDeliveryAttemptConstraint{
+			WebhookDeliveryAttemptPk:         "webhook_delivery_attempt_pk",
+			WebhookDeliveryAttemptDeliveryFk: "webhook_delivery_attempt_delivery_fk",
+		},
+		ID:          "id",
+		DeliveryID:  "delivery_id",
+		AttemptedAt: "attempted_at",
+		StatusCode:  "status_code",
+		Error:       "error",
+		DurationMS:  "duration_ms",
+	}
+
+	WebhookDeliveryAttempt.Dot = &webhookDeliveryAttempt{
+		tableName: WebhookDeliveryAttempt.tableName,
+		Dot:       &webhookDeliveryAttempt{},
+		Constraint: webhookDeliveryAttemptConstraint{
+			WebhookDeliveryAttemptPk:         WebhookDeliveryAttempt.Constraint.WebhookDeliveryAttemptPk,
+			WebhookDeliveryAttemptDeliveryFk: WebhookDeliveryAttempt.Constraint.WebhookDeliveryAttemptDeliveryFk,
+		},
+		ID:          WebhookDeliveryAttempt.tableName + "." + WebhookDeliveryAttempt.ID,
+		DeliveryID:  WebhookDeliveryAttempt.tableName + "." + WebhookDeliveryAttempt.DeliveryID,
+		AttemptedAt: WebhookDeliveryAttempt.tableName + "." + WebhookDeliveryAttempt.AttemptedAt,
+		StatusCode:  WebhookDeliveryAttempt.tableName + "." + WebhookDeliveryAttempt.StatusCode,
+		Error:       WebhookDeliveryAttempt.tableName + "." + WebhookDeliveryAttempt.Error,
+		DurationMS:  WebhookDeliveryAttempt.tableName + "." + WebhookDeliveryAttempt.DurationMS,
+	}
+}
@@ -0,0 +1,66 @@
+package table
+
+import "github.com/sirupsen/logrus"
+
+// WebhookSubscription is table `webhook_subscription`. Use this to get table name and column name when query to database.
+// Got panic? did you run Init which run initTableWebhookSubscription?
+var WebhookSubscription *webhookSubscription
+
+type webhookSubscription struct {
+	tableName  string
+	Dot        *webhookSubscription
+	Constraint webhookSubscriptionConstraint
+
+	ID         string
+	URL        string
+	EventTypes string
+	Secret     string
+	IsActive   string
+	CreatedAt  string
+	UpdatedAt  string
+}
+
+type webhookSubscriptionConstraint struct {
+	WebhookSubscriptionPk string
+}
+
+func (w *webhookSubscription) String() string {
+	return w.tableName
+}
+
+func initTableWebhookSubscription() {
+	if WebhookSubscription != nil {
+		logrus.Warn("table WebhookSubscription already initialized")
+		return
+	}
+
+	WebhookSubscription = &webhookSubscription{
+		tableName: "\"webhook_subscription\"",
+		Dot:       &webhookSubscription{},
+		Constraint: webhookSubscriptionConstraint{
+			WebhookSubscriptionPk: "webhook_subscription_pk",
+		},
+		ID:         "id",
+		URL:        "url",
+		EventTypes: "event_types",
+		Secret:     "secret",
+		IsActive:   "is_active",
+		CreatedAt:  "created_at",
+		UpdatedAt:  "updated_at",
+	}
+
+	WebhookSubscription.Dot = &webhookSubscription{
+		tableName: WebhookSubscription.tableName,
+		Dot:       &webhookSubscription{},
+		Constraint: webhookSubscriptionConstraint{
+			WebhookSubscriptionPk: WebhookSubscription.Constraint.WebhookSubscriptionPk,
+		},
+		ID:         WebhookSubscription.tableName + "." + WebhookSubscription.ID,
+		URL:        WebhookSubscription.tableName + "." + WebhookSubscription.URL,
+		EventTypes: WebhookSubscription.tableName + "." + WebhookSubscription.EventTypes,
+		Secret:     WebhookSubscription.tableName + "." + WebhookSubscription.Secret,
+		IsActive:   WebhookSubscription.tableName + "." + WebhookSubscription.IsActive,
+		CreatedAt:  WebhookSubscription.tableName + "." + WebhookSubscription.CreatedAt,
+		UpdatedAt:  WebhookSubscription.tableName + "." + WebhookSubscription.UpdatedAt,
+	}
+}
@@ -2,11 +2,46 @@ package entity
 
 import "time"
 
+// User role list.
+const (
+	UserRoleUser  = "user"
+	UserRoleAdmin = "admin"
+)
+
+// User status list.
+const (
+	UserStatusActive    = "active"
+	UserStatusSuspended = "suspended"
+	UserStatusDisabled  = "disabled"
+)
+
 // User is entity user, in db it's table `user`.
 type User struct {
 	ID        int64
 	Username  string
 	Password  string
+	Role      string
 	CreatedAt time.Time
 	UpdatedAt time.Time
+	// DeletedAt is not nil when user is soft deleted.
+	DeletedAt *time.Time
+
+	Status string
+	// SuspendedUntil is when suspension expires, only used when status is
+	// suspended.
+	SuspendedUntil   *time.Time
+	SuspensionReason string
+}
+
+// IsActive return true if user is active at time now. Suspended user becomes
+// active once suspension expires.
+func (u User) IsActive(now time.Time) bool {
+	switch u.Status {
+	case UserStatusActive:
+		return true
+	case UserStatusSuspended:
+		return u.SuspendedUntil != nil && !now.Before(*u.SuspendedUntil)
+	default:
+		return false
+	}
 }
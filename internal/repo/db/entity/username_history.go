@@ -0,0 +1,13 @@
+package entity
+
+import "time"
+
+// UsernameHistory is entity username history, in db it's table
+// `username_history`. One row is created for each username change and holds
+// the old username.
+type UsernameHistory struct {
+	ID        int64
+	UserID    int64
+	Username  string
+	ChangedAt time.Time
+}
@@ -0,0 +1,54 @@
+package entity
+
+import "time"
+
+// WebhookSubscription is entity webhook subscription, in db it's table
+// `webhook_subscription`.
+type WebhookSubscription struct {
+	ID  int64
+	URL string
+	// EventTypes is list of gouser.EventType* the receiver subscribes to.
+	EventTypes []string
+	// Secret is key of HMAC-SHA256 signature of delivered payload.
+	Secret    string
+	IsActive  bool
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// Webhook delivery status list.
+const (
+	WebhookDeliveryStatusPending   = "pending"
+	WebhookDeliveryStatusSucceeded = "succeeded"
+	// WebhookDeliveryStatusDead is delivery which failed max attempt times,
+	// it is not retried unless redelivered manually.
+	WebhookDeliveryStatusDead = "dead"
+)
+
+// WebhookDelivery is entity webhook delivery, in db it's table
+// `webhook_delivery`. It is one event to be delivered to one subscription.
+type WebhookDelivery struct {
+	ID             int64
+	SubscriptionID int64
+	EventID        int64
+	EventType      string
+	// Payload is the JSON request body sent to the receiver.
+	Payload       []byte
+	Status        string
+	Attempt       int
+	NextAttemptAt time.Time
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// WebhookDeliveryAttempt is entity webhook delivery attempt, in db it's table
+// `webhook_delivery_attempt`. It is the log of one HTTP call to the receiver.
+type WebhookDeliveryAttempt struct {
+	ID          int64
+	DeliveryID  int64
+	AttemptedAt time.Time
+	// StatusCode is 0 when receiver does not respond.
+	StatusCode int
+	Error      string
+	DurationMS int64
+}
@@ -1,27 +1,25 @@
 package db
 
 import (
-	"database/sql"
+	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/Hidayathamir/go-user/config"
-	_ "github.com/jackc/pgx/v5/stdlib" // don't really understand, remove if you know what you do, i just following this article about pgx to sql.DB. https://github.com/jackc/pgx/wiki/Getting-started-with-pgx-through-database-sql#hello-world-from-postgresql
+	"github.com/jackc/pgx/v5/stdlib"
 	migrate "github.com/rubenv/sql-migrate"
 	"github.com/sirupsen/logrus"
 )
 
 // MigrateUp migrate database using schemaMigrationPath.
 func MigrateUp(cfg config.Config, schemaMigrationPath string) error {
-	url := fmt.Sprintf(
-		"postgres://%s:%s@%s:%d/%s",
-		cfg.PG.Username, cfg.PG.Password, cfg.PG.Host, cfg.PG.Port, cfg.PG.DBName,
-	)
-
-	db, err := sql.Open("pgx", url)
+	poolConfig, err := newPoolConfig(cfg)
 	if err != nil {
-		return fmt.Errorf("sql.Open: %w", err)
+		return fmt.Errorf("newPoolConfig: %w", err)
 	}
+
+	db := stdlib.OpenDB(*poolConfig.ConnConfig)
 	defer func() {
 		err := db.Close()
 		if err != nil {
@@ -56,3 +54,52 @@ func MigrateUp(cfg config.Config, schemaMigrationPath string) error {
 
 	return nil
 }
+
+// CheckMigration return error when a migration in schemaMigrationPath is not
+// applied to database yet.
+func CheckMigration(ctx context.Context, pg *Postgres, schemaMigrationPath string) error {
+	migrations, err := (&migrate.FileMigrationSource{Dir: schemaMigrationPath}).FindMigrations()
+	if err != nil {
+		return fmt.Errorf("migrate.FileMigrationSource.FindMigrations: %w", err)
+	}
+
+	sql, args, err := pg.Builder.Select("id").From("migrations").ToSql()
+	if err != nil {
+		return fmt.Errorf("Postgres.Builder.ToSql: %w", err)
+	}
+
+	rows, err := pg.Pool.Query(ctx, sql, args...)
+	if err != nil {
+		return fmt.Errorf("Postgres.Pool.Query: %w", err)
+	}
+
+	defer rows.Close()
+
+	isApplied := map[string]bool{}
+	for rows.Next() {
+		var id string
+		err := rows.Scan(&id)
+		if err != nil {
+			return fmt.Errorf("pgx.Rows.Scan: %w", err)
+		}
+		isApplied[id] = true
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return fmt.Errorf("pgx.Rows.Err: %w", err)
+	}
+
+	pendingIDs := []string{}
+	for _, migration := range migrations {
+		if !isApplied[migration.Id] {
+			pendingIDs = append(pendingIDs, migration.Id)
+		}
+	}
+
+	if len(pendingIDs) > 0 {
+		return fmt.Errorf("%d migration not applied: %s", len(pendingIDs), strings.Join(pendingIDs, ", "))
+	}
+
+	return nil
+}
@@ -0,0 +1,96 @@
+package db
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/pashagolub/pgxmock/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitCheckMigration(t *testing.T) {
+	t.Parallel()
+
+	migrationIDs := func(t *testing.T) []string {
+		t.Helper()
+		entries, err := os.ReadDir("schema_migration")
+		require.NoError(t, err)
+		ids := []string{}
+		for _, entry := range entries {
+			if strings.HasSuffix(entry.Name(), ".sql") {
+				ids = append(ids, entry.Name())
+			}
+		}
+		require.NotEmpty(t, ids)
+		return ids
+	}
+
+	t.Run("all migration applied should return nil", func(t *testing.T) {
+		t.Parallel()
+
+		mockpool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+
+		pg := &Postgres{
+			Builder: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+			Pool:    mockpool,
+		}
+
+		rows := pgxmock.NewRows([]string{"id"})
+		for _, id := range migrationIDs(t) {
+			rows.AddRow(id)
+		}
+		mockpool.ExpectQuery(`SELECT id FROM migrations`).WillReturnRows(rows)
+
+		err = CheckMigration(context.Background(), pg, "schema_migration")
+
+		require.NoError(t, err)
+		require.NoError(t, mockpool.ExpectationsWereMet())
+	})
+	t.Run("migration not applied should return error", func(t *testing.T) {
+		t.Parallel()
+
+		mockpool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+
+		pg := &Postgres{
+			Builder: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+			Pool:    mockpool,
+		}
+
+		ids := migrationIDs(t)
+		rows := pgxmock.NewRows([]string{"id"})
+		for _, id := range ids[:len(ids)-1] {
+			rows.AddRow(id)
+		}
+		mockpool.ExpectQuery(`SELECT id FROM migrations`).WillReturnRows(rows)
+
+		err = CheckMigration(context.Background(), pg, "schema_migration")
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "1 migration not applied")
+		assert.Contains(t, err.Error(), ids[len(ids)-1])
+	})
+	t.Run("Query error should return error", func(t *testing.T) {
+		t.Parallel()
+
+		mockpool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+
+		pg := &Postgres{
+			Builder: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
+			Pool:    mockpool,
+		}
+
+		mockpool.ExpectQuery("SELECT").WillReturnError(assert.AnError)
+
+		err = CheckMigration(context.Background(), pg, "schema_migration")
+
+		require.Error(t, err)
+		require.ErrorIs(t, err, assert.AnError)
+	})
+}
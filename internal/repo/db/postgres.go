@@ -2,6 +2,7 @@ package db
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -17,35 +18,37 @@ import (
 // unit test. Add method when needed.
 type IPgxPool interface {
 	Ping(ctx context.Context) error
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
 	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
 	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	Begin(ctx context.Context) (pgx.Tx, error)
+	BeginTx(ctx context.Context, txOptions pgx.TxOptions) (pgx.Tx, error)
+	Stat() *pgxpool.Stat
+	Close()
 }
 
 // Postgres -.
 type Postgres struct {
 	Builder squirrel.StatementBuilderType
-	Pool    IPgxPool // use IPgxPool instead *pgxpool.Pool
+	// Pool run query in the transaction bound to ctx if any, see
+	// NewContextPool.
+	Pool IPgxPool // use IPgxPool instead *pgxpool.Pool
 }
 
 // NewPGPoolConn return postgres pool connection.
 func NewPGPoolConn(cfg config.Config) (*Postgres, error) {
-	url := fmt.Sprintf(
-		"postgres://%s:%s@%s:%d/%s",
-		cfg.PG.Username, cfg.PG.Password, cfg.PG.Host, cfg.PG.Port, cfg.PG.DBName,
-	)
-
-	poolConfig, err := pgxpool.ParseConfig(url)
+	poolConfig, err := newPoolConfig(cfg)
 	if err != nil {
-		return nil, fmt.Errorf("pgxpool.ParseConfig: %w", err)
+		return nil, fmt.Errorf("newPoolConfig: %w", err)
 	}
-	poolConfig.MaxConns = int32(cfg.PG.PoolMax)
 
 	pg := &Postgres{
 		Builder: squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar),
 	}
 
 	for i := 0; i < 10; i++ {
-		pg.Pool, err = pgxpool.NewWithConfig(context.Background(), poolConfig)
+		var pool *pgxpool.Pool
+		pool, err = pgxpool.NewWithConfig(context.Background(), poolConfig)
 		if err != nil {
 			logrus.
 				WithField("attempt count", i+1).
@@ -56,6 +59,8 @@ func NewPGPoolConn(cfg config.Config) (*Postgres, error) {
 			continue
 		}
 
+		pg.Pool = NewContextPool(pool)
+
 		err = pg.Pool.Ping(context.Background())
 		if err != nil {
 			logrus.
@@ -78,3 +83,22 @@ func NewPGPoolConn(cfg config.Config) (*Postgres, error) {
 
 	return pg, nil
 }
+
+// newPoolConfig return pool config of connection string and pool fields of
+// cfg.
+func newPoolConfig(cfg config.Config) (*pgxpool.Config, error) {
+	poolConfig, err := pgxpool.ParseConfig(ConnString(cfg))
+	if err != nil {
+		// error of pgxpool.ParseConfig may hold password in url parse error.
+		return nil, errors.New("pgxpool.ParseConfig: postgres connection string is invalid")
+	}
+
+	poolConfig.MaxConns = int32(cfg.PG.PoolMax)
+	poolConfig.MinConns = int32(cfg.PG.MinConns)
+	poolConfig.MaxConnLifetime = time.Duration(cfg.PG.MaxConnLifetimeMinute) * time.Minute
+	poolConfig.MaxConnIdleTime = time.Duration(cfg.PG.MaxConnIdleTimeMinute) * time.Minute
+	poolConfig.HealthCheckPeriod = time.Duration(cfg.PG.HealthCheckPeriodSecond) * time.Second
+	poolConfig.ConnConfig.Tracer = queryTracer{}
+
+	return poolConfig, nil
+}
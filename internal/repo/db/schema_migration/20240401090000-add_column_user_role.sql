@@ -0,0 +1,4 @@
+-- +migrate Up
+ALTER TABLE "user" ADD COLUMN IF NOT EXISTS "role" varchar NOT NULL DEFAULT 'user';
+
+-- +migrate Down
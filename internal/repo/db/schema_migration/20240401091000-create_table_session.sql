@@ -0,0 +1,19 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS "session" (
+    id bigserial NOT NULL,
+    user_id bigint NOT NULL,
+    jti varchar NOT NULL,
+    user_agent varchar NOT NULL,
+    ip varchar NOT NULL,
+    created_at timestamptz NOT NULL,
+    last_seen_at timestamptz NOT NULL,
+    expired_at timestamptz NOT NULL,
+    revoked_at timestamptz NULL,
+    CONSTRAINT session_pk PRIMARY KEY (id),
+    CONSTRAINT session_jti_un UNIQUE (jti),
+    CONSTRAINT session_user_fk FOREIGN KEY (user_id) REFERENCES "user" (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS session_user_id_idx ON "session" (user_id);
+
+-- +migrate Down
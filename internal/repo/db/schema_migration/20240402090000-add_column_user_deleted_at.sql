@@ -0,0 +1,11 @@
+-- +migrate Up
+ALTER TABLE "user" ADD COLUMN IF NOT EXISTS deleted_at timestamptz NULL;
+
+-- Username uniqueness only applies to not deleted user, so deleted user
+-- username can be freed. The index keep the constraint name user_un.
+ALTER TABLE "user" DROP CONSTRAINT IF EXISTS user_un;
+CREATE UNIQUE INDEX IF NOT EXISTS user_un ON "user" (username) WHERE deleted_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS user_deleted_at_idx ON "user" (deleted_at) WHERE deleted_at IS NOT NULL;
+
+-- +migrate Down
@@ -0,0 +1,6 @@
+-- +migrate Up
+ALTER TABLE "user" ADD COLUMN IF NOT EXISTS "status" varchar NOT NULL DEFAULT 'active';
+ALTER TABLE "user" ADD COLUMN IF NOT EXISTS suspended_until timestamptz NULL;
+ALTER TABLE "user" ADD COLUMN IF NOT EXISTS suspension_reason varchar NOT NULL DEFAULT '';
+
+-- +migrate Down
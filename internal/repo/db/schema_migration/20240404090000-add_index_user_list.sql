@@ -0,0 +1,9 @@
+-- +migrate Up
+-- Keyset paging of ListUsers sort by created_at then id.
+CREATE INDEX IF NOT EXISTS user_created_at_id_idx ON "user" (created_at, id) WHERE deleted_at IS NULL;
+
+-- Username prefix search, LIKE 'prefix%' can not use user_un unless the
+-- collation is C.
+CREATE INDEX IF NOT EXISTS user_username_pattern_idx ON "user" (username text_pattern_ops) WHERE deleted_at IS NULL;
+
+-- +migrate Down
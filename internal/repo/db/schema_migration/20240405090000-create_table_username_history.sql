@@ -0,0 +1,17 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS "username_history" (
+    id bigserial NOT NULL,
+    user_id bigint NOT NULL,
+    username varchar NOT NULL,
+    changed_at timestamptz NOT NULL,
+    CONSTRAINT username_history_pk PRIMARY KEY (id),
+    CONSTRAINT username_history_user_fk FOREIGN KEY (user_id) REFERENCES "user" (id) ON DELETE CASCADE
+);
+
+-- Resolve old username to the current user.
+CREATE INDEX IF NOT EXISTS username_history_username_changed_at_idx ON "username_history" (username, changed_at);
+
+-- Cooldown check of the latest change of the user.
+CREATE INDEX IF NOT EXISTS username_history_user_id_changed_at_idx ON "username_history" (user_id, changed_at);
+
+-- +migrate Down
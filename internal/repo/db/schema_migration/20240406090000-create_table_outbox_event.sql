@@ -0,0 +1,19 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS "outbox_event" (
+    id bigserial NOT NULL,
+    event_type varchar NOT NULL,
+    user_id bigint NOT NULL,
+    payload jsonb NOT NULL,
+    created_at timestamptz NOT NULL,
+    published_at timestamptz NULL,
+    attempt int NOT NULL DEFAULT 0,
+    next_attempt_at timestamptz NOT NULL,
+    last_error varchar NOT NULL DEFAULT '',
+    CONSTRAINT outbox_event_pk PRIMARY KEY (id)
+);
+
+-- Relay pick pending events which are due, oldest first. user_id has no
+-- foreign key so events outlive purged users.
+CREATE INDEX IF NOT EXISTS outbox_event_pending_idx ON "outbox_event" (next_attempt_at, id) WHERE published_at IS NULL;
+
+-- +migrate Down
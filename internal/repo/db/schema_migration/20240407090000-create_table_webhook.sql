@@ -0,0 +1,45 @@
+-- +migrate Up
+CREATE TABLE IF NOT EXISTS "webhook_subscription" (
+    id bigserial NOT NULL,
+    url varchar NOT NULL,
+    event_types varchar[] NOT NULL,
+    secret varchar NOT NULL,
+    is_active boolean NOT NULL DEFAULT true,
+    created_at timestamptz NOT NULL,
+    updated_at timestamptz NOT NULL,
+    CONSTRAINT webhook_subscription_pk PRIMARY KEY (id)
+);
+
+CREATE TABLE IF NOT EXISTS "webhook_delivery" (
+    id bigserial NOT NULL,
+    subscription_id bigint NOT NULL,
+    event_id bigint NOT NULL,
+    event_type varchar NOT NULL,
+    payload jsonb NOT NULL,
+    status varchar NOT NULL,
+    attempt int NOT NULL DEFAULT 0,
+    next_attempt_at timestamptz NOT NULL,
+    created_at timestamptz NOT NULL,
+    updated_at timestamptz NOT NULL,
+    CONSTRAINT webhook_delivery_pk PRIMARY KEY (id),
+    CONSTRAINT webhook_delivery_subscription_event_un UNIQUE (subscription_id, event_id),
+    CONSTRAINT webhook_delivery_subscription_fk FOREIGN KEY (subscription_id) REFERENCES "webhook_subscription" (id) ON DELETE CASCADE
+);
+
+-- Worker pick pending deliveries which are due, oldest first.
+CREATE INDEX IF NOT EXISTS webhook_delivery_pending_idx ON "webhook_delivery" (next_attempt_at, id) WHERE status = 'pending';
+
+CREATE TABLE IF NOT EXISTS "webhook_delivery_attempt" (
+    id bigserial NOT NULL,
+    delivery_id bigint NOT NULL,
+    attempted_at timestamptz NOT NULL,
+    status_code int NOT NULL DEFAULT 0,
+    error varchar NOT NULL DEFAULT '',
+    duration_ms bigint NOT NULL,
+    CONSTRAINT webhook_delivery_attempt_pk PRIMARY KEY (id),
+    CONSTRAINT webhook_delivery_attempt_delivery_fk FOREIGN KEY (delivery_id) REFERENCES "webhook_delivery" (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS webhook_delivery_attempt_delivery_id_idx ON "webhook_delivery_attempt" (delivery_id, attempted_at);
+
+-- +migrate Down
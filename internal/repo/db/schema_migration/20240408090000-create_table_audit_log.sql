@@ -0,0 +1,37 @@
+-- +migrate Up
+-- audit_log has no foreign key, entries outlive the users they are about.
+CREATE TABLE IF NOT EXISTS "audit_log" (
+    id bigserial NOT NULL,
+    actor_user_id bigint NULL,
+    target_user_id bigint NULL,
+    action varchar NOT NULL,
+    result varchar NOT NULL,
+    detail varchar NOT NULL,
+    ip varchar NOT NULL,
+    user_agent varchar NOT NULL,
+    request_id varchar NOT NULL,
+    created_at timestamptz NOT NULL,
+    CONSTRAINT audit_log_pk PRIMARY KEY (id)
+);
+
+-- Purge by retention.
+CREATE INDEX IF NOT EXISTS audit_log_created_at_idx ON "audit_log" (created_at);
+
+-- Admin query by actor or target user, newest first.
+CREATE INDEX IF NOT EXISTS audit_log_actor_user_id_id_idx ON "audit_log" (actor_user_id, id);
+CREATE INDEX IF NOT EXISTS audit_log_target_user_id_id_idx ON "audit_log" (target_user_id, id);
+
+-- Audit log is append only, entries are only inserted and purged.
+-- +migrate StatementBegin
+CREATE OR REPLACE FUNCTION audit_log_prevent_update() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_log is append only';
+END;
+$$ LANGUAGE plpgsql;
+-- +migrate StatementEnd
+
+DROP TRIGGER IF EXISTS audit_log_prevent_update_trg ON "audit_log";
+CREATE TRIGGER audit_log_prevent_update_trg BEFORE UPDATE ON "audit_log"
+    FOR EACH ROW EXECUTE FUNCTION audit_log_prevent_update();
+
+-- +migrate Down
@@ -0,0 +1,6 @@
+-- +migrate Up
+-- Relay claim events by setting locked_until, then publish them without
+-- holding row lock. Concurrent relay skip claimed events until the lease ends.
+ALTER TABLE "outbox_event" ADD COLUMN IF NOT EXISTS locked_until timestamptz NULL;
+
+-- +migrate Down
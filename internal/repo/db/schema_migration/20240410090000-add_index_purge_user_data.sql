@@ -0,0 +1,7 @@
+-- +migrate Up
+-- Purging deleted user also delete its outbox events and their webhook
+-- deliveries, which have no foreign key to cascade.
+CREATE INDEX IF NOT EXISTS outbox_event_user_id_idx ON "outbox_event" (user_id);
+CREATE INDEX IF NOT EXISTS webhook_delivery_event_id_idx ON "webhook_delivery" (event_id);
+
+-- +migrate Down
@@ -0,0 +1,11 @@
+-- +migrate Up
+-- Id of the transaction which wrote the event. Event id is taken before commit
+-- so it does not follow commit order, WatchUsers stream events by transaction
+-- id then id, only of transactions older than every running one. Existing
+-- events get 0 so they come before any new event.
+ALTER TABLE "outbox_event" ADD COLUMN IF NOT EXISTS xact_id bigint NOT NULL DEFAULT 0;
+ALTER TABLE "outbox_event" ALTER COLUMN xact_id SET DEFAULT pg_current_xact_id()::text::bigint;
+
+CREATE INDEX IF NOT EXISTS outbox_event_xact_id_idx ON "outbox_event" (xact_id, id);
+
+-- +migrate Down
@@ -0,0 +1,58 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Hidayathamir/go-user/internal/pkg/tracing"
+	"github.com/Hidayathamir/go-user/internal/pkg/tracing/tracingtest"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestUnitQueryTracer(t *testing.T) {
+	t.Parallel()
+
+	tracingtest.Setup()
+
+	t.Run("query should be traced with sql statement", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, root := tracing.Start(context.Background(), t.Name())
+
+		sql := "SELECT id, username FROM users WHERE username = $1"
+		ctx = queryTracer{}.TraceQueryStart(ctx, nil, pgx.TraceQueryStartData{SQL: sql, Args: []any{"hidayat"}})
+		queryTracer{}.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{CommandTag: pgconn.NewCommandTag("SELECT 1")})
+		root.End()
+
+		span, ok := tracingtest.SpanByName(root.SpanContext().TraceID(), "postgres SELECT")
+		require.True(t, ok)
+		assert.Equal(t, trace.SpanKindClient, span.SpanKind)
+		assert.Equal(t, root.SpanContext().SpanID(), span.Parent.SpanID())
+		assert.Contains(t, span.Attributes, attribute.String("db.system", "postgresql"))
+		assert.Contains(t, span.Attributes, attribute.String("db.statement", sql))
+		assert.Contains(t, span.Attributes, attribute.Int64("db.rows_affected", 1))
+		assert.Equal(t, codes.Unset, span.Status.Code)
+		for _, attr := range span.Attributes {
+			assert.NotEqual(t, "hidayat", attr.Value.Emit())
+		}
+	})
+	t.Run("query error should be recorded on span", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, root := tracing.Start(context.Background(), t.Name())
+
+		ctx = queryTracer{}.TraceQueryStart(ctx, nil, pgx.TraceQueryStartData{SQL: "\n\tupdate users SET password = $1"})
+		queryTracer{}.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{Err: assert.AnError})
+		root.End()
+
+		span, ok := tracingtest.SpanByName(root.SpanContext().TraceID(), "postgres UPDATE")
+		require.True(t, ok)
+		assert.Equal(t, codes.Error, span.Status.Code)
+	})
+}
@@ -0,0 +1,88 @@
+package db
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type txKey struct{}
+
+// ContextWithTx return ctx bound to tx. Repo query using the returned ctx run
+// in tx.
+func ContextWithTx(ctx context.Context, tx pgx.Tx) context.Context {
+	return context.WithValue(ctx, txKey{}, tx)
+}
+
+// TxFromContext return transaction bound to ctx by ContextWithTx.
+func TxFromContext(ctx context.Context) (pgx.Tx, bool) {
+	tx, ok := ctx.Value(txKey{}).(pgx.Tx)
+	return tx, ok
+}
+
+// contextPool implement IPgxPool, it run query in the transaction bound to ctx
+// if any, else in pool.
+type contextPool struct {
+	pool IPgxPool
+}
+
+var _ IPgxPool = &contextPool{}
+
+// NewContextPool return IPgxPool which run query in the transaction bound to
+// ctx by ContextWithTx, or in pool when there is none. So repo does not need
+// to know whether it is called inside a transaction.
+func NewContextPool(pool IPgxPool) IPgxPool {
+	return &contextPool{pool: pool}
+}
+
+func (c *contextPool) Ping(ctx context.Context) error {
+	return c.pool.Ping(ctx)
+}
+
+func (c *contextPool) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	if tx, ok := TxFromContext(ctx); ok {
+		return tx.Query(ctx, sql, args...)
+	}
+	return c.pool.Query(ctx, sql, args...)
+}
+
+func (c *contextPool) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	if tx, ok := TxFromContext(ctx); ok {
+		return tx.QueryRow(ctx, sql, args...)
+	}
+	return c.pool.QueryRow(ctx, sql, args...)
+}
+
+func (c *contextPool) Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error) {
+	if tx, ok := TxFromContext(ctx); ok {
+		return tx.Exec(ctx, sql, arguments...)
+	}
+	return c.pool.Exec(ctx, sql, arguments...)
+}
+
+// Begin start a savepoint when ctx is bound to a transaction.
+func (c *contextPool) Begin(ctx context.Context) (pgx.Tx, error) {
+	if tx, ok := TxFromContext(ctx); ok {
+		return tx.Begin(ctx)
+	}
+	return c.pool.Begin(ctx)
+}
+
+// BeginTx start a savepoint when ctx is bound to a transaction, txOptions is
+// ignored then because it can only be set on the outermost transaction.
+func (c *contextPool) BeginTx(ctx context.Context, txOptions pgx.TxOptions) (pgx.Tx, error) {
+	if tx, ok := TxFromContext(ctx); ok {
+		return tx.Begin(ctx)
+	}
+	return c.pool.BeginTx(ctx, txOptions)
+}
+
+func (c *contextPool) Stat() *pgxpool.Stat {
+	return c.pool.Stat()
+}
+
+func (c *contextPool) Close() {
+	c.pool.Close()
+}
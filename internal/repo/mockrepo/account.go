@@ -0,0 +1,157 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: account.go
+//
+// Generated by this command:
+//
+//	mockgen -source=account.go -destination=mockrepo/account.go -package=mockrepo
+//
+
+// Package mockrepo is a generated GoMock package.
+package mockrepo
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	entity "github.com/Hidayathamir/go-user/internal/repo/db/entity"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIAccount is a mock of IAccount interface.
+type MockIAccount struct {
+	ctrl     *gomock.Controller
+	recorder *MockIAccountMockRecorder
+}
+
+// MockIAccountMockRecorder is the mock recorder for MockIAccount.
+type MockIAccountMockRecorder struct {
+	mock *MockIAccount
+}
+
+// NewMockIAccount creates a new mock instance.
+func NewMockIAccount(ctrl *gomock.Controller) *MockIAccount {
+	mock := &MockIAccount{ctrl: ctrl}
+	mock.recorder = &MockIAccountMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIAccount) EXPECT() *MockIAccountMockRecorder {
+	return m.recorder
+}
+
+// GetDeletedProfileByUsername mocks base method.
+func (m *MockIAccount) GetDeletedProfileByUsername(ctx context.Context, username string, deletedAfter time.Time) (entity.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeletedProfileByUsername", ctx, username, deletedAfter)
+	ret0, _ := ret[0].(entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeletedProfileByUsername indicates an expected call of GetDeletedProfileByUsername.
+func (mr *MockIAccountMockRecorder) GetDeletedProfileByUsername(ctx, username, deletedAfter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeletedProfileByUsername", reflect.TypeOf((*MockIAccount)(nil).GetDeletedProfileByUsername), ctx, username, deletedAfter)
+}
+
+// IsUsernameHeldByDeletedUser mocks base method.
+func (m *MockIAccount) IsUsernameHeldByDeletedUser(ctx context.Context, username string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsUsernameHeldByDeletedUser", ctx, username)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsUsernameHeldByDeletedUser indicates an expected call of IsUsernameHeldByDeletedUser.
+func (mr *MockIAccountMockRecorder) IsUsernameHeldByDeletedUser(ctx, username any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsUsernameHeldByDeletedUser", reflect.TypeOf((*MockIAccount)(nil).IsUsernameHeldByDeletedUser), ctx, username)
+}
+
+// LockUser mocks base method.
+func (m *MockIAccount) LockUser(ctx context.Context, userID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockUser", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LockUser indicates an expected call of LockUser.
+func (mr *MockIAccountMockRecorder) LockUser(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockUser", reflect.TypeOf((*MockIAccount)(nil).LockUser), ctx, userID)
+}
+
+// LockUsername mocks base method.
+func (m *MockIAccount) LockUsername(ctx context.Context, username string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockUsername", ctx, username)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LockUsername indicates an expected call of LockUsername.
+func (mr *MockIAccountMockRecorder) LockUsername(ctx, username any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockUsername", reflect.TypeOf((*MockIAccount)(nil).LockUsername), ctx, username)
+}
+
+// PurgeDeletedUsers mocks base method.
+func (m *MockIAccount) PurgeDeletedUsers(ctx context.Context, deletedBefore time.Time, limit uint64) ([]int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeDeletedUsers", ctx, deletedBefore, limit)
+	ret0, _ := ret[0].([]int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeDeletedUsers indicates an expected call of PurgeDeletedUsers.
+func (mr *MockIAccountMockRecorder) PurgeDeletedUsers(ctx, deletedBefore, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeDeletedUsers", reflect.TypeOf((*MockIAccount)(nil).PurgeDeletedUsers), ctx, deletedBefore, limit)
+}
+
+// RestoreUser mocks base method.
+func (m *MockIAccount) RestoreUser(ctx context.Context, userID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreUser", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreUser indicates an expected call of RestoreUser.
+func (mr *MockIAccountMockRecorder) RestoreUser(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreUser", reflect.TypeOf((*MockIAccount)(nil).RestoreUser), ctx, userID)
+}
+
+// SoftDeleteUser mocks base method.
+func (m *MockIAccount) SoftDeleteUser(ctx context.Context, userID int64, deletedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SoftDeleteUser", ctx, userID, deletedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SoftDeleteUser indicates an expected call of SoftDeleteUser.
+func (mr *MockIAccountMockRecorder) SoftDeleteUser(ctx, userID, deletedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SoftDeleteUser", reflect.TypeOf((*MockIAccount)(nil).SoftDeleteUser), ctx, userID, deletedAt)
+}
+
+// UpdateUserStatus mocks base method.
+func (m *MockIAccount) UpdateUserStatus(ctx context.Context, user entity.User) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserStatus", ctx, user)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUserStatus indicates an expected call of UpdateUserStatus.
+func (mr *MockIAccountMockRecorder) UpdateUserStatus(ctx, user any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserStatus", reflect.TypeOf((*MockIAccount)(nil).UpdateUserStatus), ctx, user)
+}
@@ -0,0 +1,116 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: audit_log.go
+//
+// Generated by this command:
+//
+//	mockgen -source=audit_log.go -destination=mockrepo/audit_log.go -package=mockrepo
+//
+
+// Package mockrepo is a generated GoMock package.
+package mockrepo
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	repo "github.com/Hidayathamir/go-user/internal/repo"
+	entity "github.com/Hidayathamir/go-user/internal/repo/db/entity"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIAuditLog is a mock of IAuditLog interface.
+type MockIAuditLog struct {
+	ctrl     *gomock.Controller
+	recorder *MockIAuditLogMockRecorder
+}
+
+// MockIAuditLogMockRecorder is the mock recorder for MockIAuditLog.
+type MockIAuditLogMockRecorder struct {
+	mock *MockIAuditLog
+}
+
+// NewMockIAuditLog creates a new mock instance.
+func NewMockIAuditLog(ctrl *gomock.Controller) *MockIAuditLog {
+	mock := &MockIAuditLog{ctrl: ctrl}
+	mock.recorder = &MockIAuditLogMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIAuditLog) EXPECT() *MockIAuditLogMockRecorder {
+	return m.recorder
+}
+
+// CreateAuditLog mocks base method.
+func (m *MockIAuditLog) CreateAuditLog(ctx context.Context, auditLog entity.AuditLog) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAuditLog", ctx, auditLog)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateAuditLog indicates an expected call of CreateAuditLog.
+func (mr *MockIAuditLogMockRecorder) CreateAuditLog(ctx, auditLog any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAuditLog", reflect.TypeOf((*MockIAuditLog)(nil).CreateAuditLog), ctx, auditLog)
+}
+
+// DeleteAuditLogsByUserIDs mocks base method.
+func (m *MockIAuditLog) DeleteAuditLogsByUserIDs(ctx context.Context, userIDs []int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAuditLogsByUserIDs", ctx, userIDs)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteAuditLogsByUserIDs indicates an expected call of DeleteAuditLogsByUserIDs.
+func (mr *MockIAuditLogMockRecorder) DeleteAuditLogsByUserIDs(ctx, userIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAuditLogsByUserIDs", reflect.TypeOf((*MockIAuditLog)(nil).DeleteAuditLogsByUserIDs), ctx, userIDs)
+}
+
+// GetAuditLogs mocks base method.
+func (m *MockIAuditLog) GetAuditLogs(ctx context.Context, filter repo.AuditLogFilter) ([]entity.AuditLog, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuditLogs", ctx, filter)
+	ret0, _ := ret[0].([]entity.AuditLog)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAuditLogs indicates an expected call of GetAuditLogs.
+func (mr *MockIAuditLogMockRecorder) GetAuditLogs(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuditLogs", reflect.TypeOf((*MockIAuditLog)(nil).GetAuditLogs), ctx, filter)
+}
+
+// IterateAuditLogsByUserID mocks base method.
+func (m *MockIAuditLog) IterateAuditLogsByUserID(ctx context.Context, userID int64, fn func(entity.AuditLog) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IterateAuditLogsByUserID", ctx, userID, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IterateAuditLogsByUserID indicates an expected call of IterateAuditLogsByUserID.
+func (mr *MockIAuditLogMockRecorder) IterateAuditLogsByUserID(ctx, userID, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IterateAuditLogsByUserID", reflect.TypeOf((*MockIAuditLog)(nil).IterateAuditLogsByUserID), ctx, userID, fn)
+}
+
+// PurgeAuditLogs mocks base method.
+func (m *MockIAuditLog) PurgeAuditLogs(ctx context.Context, createdBefore time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeAuditLogs", ctx, createdBefore)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeAuditLogs indicates an expected call of PurgeAuditLogs.
+func (mr *MockIAuditLogMockRecorder) PurgeAuditLogs(ctx, createdBefore any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeAuditLogs", reflect.TypeOf((*MockIAuditLog)(nil).PurgeAuditLogs), ctx, createdBefore)
+}
@@ -0,0 +1,130 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: outbox.go
+//
+// Generated by this command:
+//
+//	mockgen -source=outbox.go -destination=mockrepo/outbox.go -package=mockrepo
+//
+
+// Package mockrepo is a generated GoMock package.
+package mockrepo
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	entity "github.com/Hidayathamir/go-user/internal/repo/db/entity"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIOutbox is a mock of IOutbox interface.
+type MockIOutbox struct {
+	ctrl     *gomock.Controller
+	recorder *MockIOutboxMockRecorder
+}
+
+// MockIOutboxMockRecorder is the mock recorder for MockIOutbox.
+type MockIOutboxMockRecorder struct {
+	mock *MockIOutbox
+}
+
+// NewMockIOutbox creates a new mock instance.
+func NewMockIOutbox(ctrl *gomock.Controller) *MockIOutbox {
+	mock := &MockIOutbox{ctrl: ctrl}
+	mock.recorder = &MockIOutboxMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIOutbox) EXPECT() *MockIOutboxMockRecorder {
+	return m.recorder
+}
+
+// ClaimPendingEvents mocks base method.
+func (m *MockIOutbox) ClaimPendingEvents(ctx context.Context, now, lockedUntil time.Time, limit uint64) ([]entity.OutboxEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimPendingEvents", ctx, now, lockedUntil, limit)
+	ret0, _ := ret[0].([]entity.OutboxEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimPendingEvents indicates an expected call of ClaimPendingEvents.
+func (mr *MockIOutboxMockRecorder) ClaimPendingEvents(ctx, now, lockedUntil, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimPendingEvents", reflect.TypeOf((*MockIOutbox)(nil).ClaimPendingEvents), ctx, now, lockedUntil, limit)
+}
+
+// DeleteEventsByUserIDs mocks base method.
+func (m *MockIOutbox) DeleteEventsByUserIDs(ctx context.Context, userIDs []int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteEventsByUserIDs", ctx, userIDs)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteEventsByUserIDs indicates an expected call of DeleteEventsByUserIDs.
+func (mr *MockIOutboxMockRecorder) DeleteEventsByUserIDs(ctx, userIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteEventsByUserIDs", reflect.TypeOf((*MockIOutbox)(nil).DeleteEventsByUserIDs), ctx, userIDs)
+}
+
+// GetEventsAfter mocks base method.
+func (m *MockIOutbox) GetEventsAfter(ctx context.Context, afterXactID, afterID int64, eventTypes []string, limit uint64) ([]entity.OutboxEvent, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEventsAfter", ctx, afterXactID, afterID, eventTypes, limit)
+	ret0, _ := ret[0].([]entity.OutboxEvent)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEventsAfter indicates an expected call of GetEventsAfter.
+func (mr *MockIOutboxMockRecorder) GetEventsAfter(ctx, afterXactID, afterID, eventTypes, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEventsAfter", reflect.TypeOf((*MockIOutbox)(nil).GetEventsAfter), ctx, afterXactID, afterID, eventTypes, limit)
+}
+
+// GetOldestRunningXactID mocks base method.
+func (m *MockIOutbox) GetOldestRunningXactID(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOldestRunningXactID", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOldestRunningXactID indicates an expected call of GetOldestRunningXactID.
+func (mr *MockIOutboxMockRecorder) GetOldestRunningXactID(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOldestRunningXactID", reflect.TypeOf((*MockIOutbox)(nil).GetOldestRunningXactID), ctx)
+}
+
+// MarkEventFailed mocks base method.
+func (m *MockIOutbox) MarkEventFailed(ctx context.Context, eventID int64, lockedUntil, nextAttemptAt time.Time, lastError string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkEventFailed", ctx, eventID, lockedUntil, nextAttemptAt, lastError)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkEventFailed indicates an expected call of MarkEventFailed.
+func (mr *MockIOutboxMockRecorder) MarkEventFailed(ctx, eventID, lockedUntil, nextAttemptAt, lastError any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkEventFailed", reflect.TypeOf((*MockIOutbox)(nil).MarkEventFailed), ctx, eventID, lockedUntil, nextAttemptAt, lastError)
+}
+
+// MarkEventPublished mocks base method.
+func (m *MockIOutbox) MarkEventPublished(ctx context.Context, eventID int64, lockedUntil, publishedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkEventPublished", ctx, eventID, lockedUntil, publishedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkEventPublished indicates an expected call of MarkEventPublished.
+func (mr *MockIOutboxMockRecorder) MarkEventPublished(ctx, eventID, lockedUntil, publishedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkEventPublished", reflect.TypeOf((*MockIOutbox)(nil).MarkEventPublished), ctx, eventID, lockedUntil, publishedAt)
+}
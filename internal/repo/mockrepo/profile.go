@@ -12,7 +12,9 @@ package mockrepo
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
+	repo "github.com/Hidayathamir/go-user/internal/repo"
 	entity "github.com/Hidayathamir/go-user/internal/repo/db/entity"
 	gomock "go.uber.org/mock/gomock"
 )
@@ -40,6 +42,95 @@ func (m *MockIProfile) EXPECT() *MockIProfileMockRecorder {
 	return m.recorder
 }
 
+// BatchGetProfiles mocks base method.
+func (m *MockIProfile) BatchGetProfiles(ctx context.Context, userIDs []int64, usernames []string) ([]entity.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchGetProfiles", ctx, userIDs, usernames)
+	ret0, _ := ret[0].([]entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BatchGetProfiles indicates an expected call of BatchGetProfiles.
+func (mr *MockIProfileMockRecorder) BatchGetProfiles(ctx, userIDs, usernames any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchGetProfiles", reflect.TypeOf((*MockIProfile)(nil).BatchGetProfiles), ctx, userIDs, usernames)
+}
+
+// ChangeUsername mocks base method.
+func (m *MockIProfile) ChangeUsername(ctx context.Context, userID int64, newUsername string, changedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeUsername", ctx, userID, newUsername, changedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ChangeUsername indicates an expected call of ChangeUsername.
+func (mr *MockIProfileMockRecorder) ChangeUsername(ctx, userID, newUsername, changedAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeUsername", reflect.TypeOf((*MockIProfile)(nil).ChangeUsername), ctx, userID, newUsername, changedAt)
+}
+
+// CountUsers mocks base method.
+func (m *MockIProfile) CountUsers(ctx context.Context, filter repo.ListUsersFilter) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountUsers", ctx, filter)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountUsers indicates an expected call of CountUsers.
+func (mr *MockIProfileMockRecorder) CountUsers(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUsers", reflect.TypeOf((*MockIProfile)(nil).CountUsers), ctx, filter)
+}
+
+// GetLastUsernameChangedAt mocks base method.
+func (m *MockIProfile) GetLastUsernameChangedAt(ctx context.Context, userID int64) (*time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLastUsernameChangedAt", ctx, userID)
+	ret0, _ := ret[0].(*time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLastUsernameChangedAt indicates an expected call of GetLastUsernameChangedAt.
+func (mr *MockIProfileMockRecorder) GetLastUsernameChangedAt(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastUsernameChangedAt", reflect.TypeOf((*MockIProfile)(nil).GetLastUsernameChangedAt), ctx, userID)
+}
+
+// GetProfileByOldUsername mocks base method.
+func (m *MockIProfile) GetProfileByOldUsername(ctx context.Context, username string, changedAfter time.Time) (entity.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProfileByOldUsername", ctx, username, changedAfter)
+	ret0, _ := ret[0].(entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProfileByOldUsername indicates an expected call of GetProfileByOldUsername.
+func (mr *MockIProfileMockRecorder) GetProfileByOldUsername(ctx, username, changedAfter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProfileByOldUsername", reflect.TypeOf((*MockIProfile)(nil).GetProfileByOldUsername), ctx, username, changedAfter)
+}
+
+// GetProfileByUserID mocks base method.
+func (m *MockIProfile) GetProfileByUserID(ctx context.Context, userID int64) (entity.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProfileByUserID", ctx, userID)
+	ret0, _ := ret[0].(entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProfileByUserID indicates an expected call of GetProfileByUserID.
+func (mr *MockIProfileMockRecorder) GetProfileByUserID(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProfileByUserID", reflect.TypeOf((*MockIProfile)(nil).GetProfileByUserID), ctx, userID)
+}
+
 // GetProfileByUsername mocks base method.
 func (m *MockIProfile) GetProfileByUsername(ctx context.Context, username string) (entity.User, error) {
 	m.ctrl.T.Helper()
@@ -55,6 +146,35 @@ func (mr *MockIProfileMockRecorder) GetProfileByUsername(ctx, username any) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProfileByUsername", reflect.TypeOf((*MockIProfile)(nil).GetProfileByUsername), ctx, username)
 }
 
+// IterateUsernameHistoryByUserID mocks base method.
+func (m *MockIProfile) IterateUsernameHistoryByUserID(ctx context.Context, userID int64, fn func(entity.UsernameHistory) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IterateUsernameHistoryByUserID", ctx, userID, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IterateUsernameHistoryByUserID indicates an expected call of IterateUsernameHistoryByUserID.
+func (mr *MockIProfileMockRecorder) IterateUsernameHistoryByUserID(ctx, userID, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IterateUsernameHistoryByUserID", reflect.TypeOf((*MockIProfile)(nil).IterateUsernameHistoryByUserID), ctx, userID, fn)
+}
+
+// ListUsers mocks base method.
+func (m *MockIProfile) ListUsers(ctx context.Context, filter repo.ListUsersFilter) ([]entity.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUsers", ctx, filter)
+	ret0, _ := ret[0].([]entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUsers indicates an expected call of ListUsers.
+func (mr *MockIProfileMockRecorder) ListUsers(ctx, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockIProfile)(nil).ListUsers), ctx, filter)
+}
+
 // UpdateProfileByUserID mocks base method.
 func (m *MockIProfile) UpdateProfileByUserID(ctx context.Context, user entity.User) error {
 	m.ctrl.T.Helper()
@@ -0,0 +1,158 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: session.go
+//
+// Generated by this command:
+//
+//	mockgen -source=session.go -destination=mockrepo/session.go -package=mockrepo
+//
+
+// Package mockrepo is a generated GoMock package.
+package mockrepo
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	entity "github.com/Hidayathamir/go-user/internal/repo/db/entity"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockISession is a mock of ISession interface.
+type MockISession struct {
+	ctrl     *gomock.Controller
+	recorder *MockISessionMockRecorder
+}
+
+// MockISessionMockRecorder is the mock recorder for MockISession.
+type MockISessionMockRecorder struct {
+	mock *MockISession
+}
+
+// NewMockISession creates a new mock instance.
+func NewMockISession(ctrl *gomock.Controller) *MockISession {
+	mock := &MockISession{ctrl: ctrl}
+	mock.recorder = &MockISessionMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockISession) EXPECT() *MockISessionMockRecorder {
+	return m.recorder
+}
+
+// CreateSession mocks base method.
+func (m *MockISession) CreateSession(ctx context.Context, session entity.Session) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSession", ctx, session)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSession indicates an expected call of CreateSession.
+func (mr *MockISessionMockRecorder) CreateSession(ctx, session any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSession", reflect.TypeOf((*MockISession)(nil).CreateSession), ctx, session)
+}
+
+// GetActiveSessionsByUserID mocks base method.
+func (m *MockISession) GetActiveSessionsByUserID(ctx context.Context, userID int64) ([]entity.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActiveSessionsByUserID", ctx, userID)
+	ret0, _ := ret[0].([]entity.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActiveSessionsByUserID indicates an expected call of GetActiveSessionsByUserID.
+func (mr *MockISessionMockRecorder) GetActiveSessionsByUserID(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveSessionsByUserID", reflect.TypeOf((*MockISession)(nil).GetActiveSessionsByUserID), ctx, userID)
+}
+
+// GetSessionByID mocks base method.
+func (m *MockISession) GetSessionByID(ctx context.Context, sessionID int64) (entity.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSessionByID", ctx, sessionID)
+	ret0, _ := ret[0].(entity.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSessionByID indicates an expected call of GetSessionByID.
+func (mr *MockISessionMockRecorder) GetSessionByID(ctx, sessionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSessionByID", reflect.TypeOf((*MockISession)(nil).GetSessionByID), ctx, sessionID)
+}
+
+// GetSessionByJTI mocks base method.
+func (m *MockISession) GetSessionByJTI(ctx context.Context, jti string) (entity.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSessionByJTI", ctx, jti)
+	ret0, _ := ret[0].(entity.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSessionByJTI indicates an expected call of GetSessionByJTI.
+func (mr *MockISessionMockRecorder) GetSessionByJTI(ctx, jti any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSessionByJTI", reflect.TypeOf((*MockISession)(nil).GetSessionByJTI), ctx, jti)
+}
+
+// IterateSessionsByUserID mocks base method.
+func (m *MockISession) IterateSessionsByUserID(ctx context.Context, userID int64, fn func(entity.Session) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IterateSessionsByUserID", ctx, userID, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// IterateSessionsByUserID indicates an expected call of IterateSessionsByUserID.
+func (mr *MockISessionMockRecorder) IterateSessionsByUserID(ctx, userID, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IterateSessionsByUserID", reflect.TypeOf((*MockISession)(nil).IterateSessionsByUserID), ctx, userID, fn)
+}
+
+// RevokeSessionByID mocks base method.
+func (m *MockISession) RevokeSessionByID(ctx context.Context, sessionID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeSessionByID", ctx, sessionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeSessionByID indicates an expected call of RevokeSessionByID.
+func (mr *MockISessionMockRecorder) RevokeSessionByID(ctx, sessionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSessionByID", reflect.TypeOf((*MockISession)(nil).RevokeSessionByID), ctx, sessionID)
+}
+
+// RevokeSessionsByUserID mocks base method.
+func (m *MockISession) RevokeSessionsByUserID(ctx context.Context, userID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeSessionsByUserID", ctx, userID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeSessionsByUserID indicates an expected call of RevokeSessionsByUserID.
+func (mr *MockISessionMockRecorder) RevokeSessionsByUserID(ctx, userID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSessionsByUserID", reflect.TypeOf((*MockISession)(nil).RevokeSessionsByUserID), ctx, userID)
+}
+
+// UpdateSessionLastSeenAt mocks base method.
+func (m *MockISession) UpdateSessionLastSeenAt(ctx context.Context, sessionID int64, lastSeenAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSessionLastSeenAt", ctx, sessionID, lastSeenAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSessionLastSeenAt indicates an expected call of UpdateSessionLastSeenAt.
+func (mr *MockISessionMockRecorder) UpdateSessionLastSeenAt(ctx, sessionID, lastSeenAt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSessionLastSeenAt", reflect.TypeOf((*MockISession)(nil).UpdateSessionLastSeenAt), ctx, sessionID, lastSeenAt)
+}
@@ -0,0 +1,232 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: webhook.go
+//
+// Generated by this command:
+//
+//	mockgen -source=webhook.go -destination=mockrepo/webhook.go -package=mockrepo
+//
+
+// Package mockrepo is a generated GoMock package.
+package mockrepo
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	entity "github.com/Hidayathamir/go-user/internal/repo/db/entity"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIWebhook is a mock of IWebhook interface.
+type MockIWebhook struct {
+	ctrl     *gomock.Controller
+	recorder *MockIWebhookMockRecorder
+}
+
+// MockIWebhookMockRecorder is the mock recorder for MockIWebhook.
+type MockIWebhookMockRecorder struct {
+	mock *MockIWebhook
+}
+
+// NewMockIWebhook creates a new mock instance.
+func NewMockIWebhook(ctrl *gomock.Controller) *MockIWebhook {
+	mock := &MockIWebhook{ctrl: ctrl}
+	mock.recorder = &MockIWebhookMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIWebhook) EXPECT() *MockIWebhookMockRecorder {
+	return m.recorder
+}
+
+// ClaimPendingDeliveries mocks base method.
+func (m *MockIWebhook) ClaimPendingDeliveries(ctx context.Context, now, leaseUntil time.Time, limit uint64) ([]entity.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimPendingDeliveries", ctx, now, leaseUntil, limit)
+	ret0, _ := ret[0].([]entity.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimPendingDeliveries indicates an expected call of ClaimPendingDeliveries.
+func (mr *MockIWebhookMockRecorder) ClaimPendingDeliveries(ctx, now, leaseUntil, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimPendingDeliveries", reflect.TypeOf((*MockIWebhook)(nil).ClaimPendingDeliveries), ctx, now, leaseUntil, limit)
+}
+
+// CreateDeliveries mocks base method.
+func (m *MockIWebhook) CreateDeliveries(ctx context.Context, eventID int64, eventType string, payload []byte, now time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDeliveries", ctx, eventID, eventType, payload, now)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateDeliveries indicates an expected call of CreateDeliveries.
+func (mr *MockIWebhookMockRecorder) CreateDeliveries(ctx, eventID, eventType, payload, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDeliveries", reflect.TypeOf((*MockIWebhook)(nil).CreateDeliveries), ctx, eventID, eventType, payload, now)
+}
+
+// CreateDeliveryAttempt mocks base method.
+func (m *MockIWebhook) CreateDeliveryAttempt(ctx context.Context, attempt entity.WebhookDeliveryAttempt) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateDeliveryAttempt", ctx, attempt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateDeliveryAttempt indicates an expected call of CreateDeliveryAttempt.
+func (mr *MockIWebhookMockRecorder) CreateDeliveryAttempt(ctx, attempt any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateDeliveryAttempt", reflect.TypeOf((*MockIWebhook)(nil).CreateDeliveryAttempt), ctx, attempt)
+}
+
+// CreateSubscription mocks base method.
+func (m *MockIWebhook) CreateSubscription(ctx context.Context, subscription entity.WebhookSubscription) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSubscription", ctx, subscription)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSubscription indicates an expected call of CreateSubscription.
+func (mr *MockIWebhookMockRecorder) CreateSubscription(ctx, subscription any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSubscription", reflect.TypeOf((*MockIWebhook)(nil).CreateSubscription), ctx, subscription)
+}
+
+// DeleteDeliveriesByUserIDs mocks base method.
+func (m *MockIWebhook) DeleteDeliveriesByUserIDs(ctx context.Context, userIDs []int64) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteDeliveriesByUserIDs", ctx, userIDs)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteDeliveriesByUserIDs indicates an expected call of DeleteDeliveriesByUserIDs.
+func (mr *MockIWebhookMockRecorder) DeleteDeliveriesByUserIDs(ctx, userIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDeliveriesByUserIDs", reflect.TypeOf((*MockIWebhook)(nil).DeleteDeliveriesByUserIDs), ctx, userIDs)
+}
+
+// DeleteSubscription mocks base method.
+func (m *MockIWebhook) DeleteSubscription(ctx context.Context, subscriptionID int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSubscription", ctx, subscriptionID)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSubscription indicates an expected call of DeleteSubscription.
+func (mr *MockIWebhookMockRecorder) DeleteSubscription(ctx, subscriptionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSubscription", reflect.TypeOf((*MockIWebhook)(nil).DeleteSubscription), ctx, subscriptionID)
+}
+
+// GetDeliveriesBySubscriptionID mocks base method.
+func (m *MockIWebhook) GetDeliveriesBySubscriptionID(ctx context.Context, subscriptionID int64, status string, limit uint64) ([]entity.WebhookDelivery, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeliveriesBySubscriptionID", ctx, subscriptionID, status, limit)
+	ret0, _ := ret[0].([]entity.WebhookDelivery)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeliveriesBySubscriptionID indicates an expected call of GetDeliveriesBySubscriptionID.
+func (mr *MockIWebhookMockRecorder) GetDeliveriesBySubscriptionID(ctx, subscriptionID, status, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeliveriesBySubscriptionID", reflect.TypeOf((*MockIWebhook)(nil).GetDeliveriesBySubscriptionID), ctx, subscriptionID, status, limit)
+}
+
+// GetDeliveryAttemptsByDeliveryIDs mocks base method.
+func (m *MockIWebhook) GetDeliveryAttemptsByDeliveryIDs(ctx context.Context, deliveryIDs []int64) ([]entity.WebhookDeliveryAttempt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeliveryAttemptsByDeliveryIDs", ctx, deliveryIDs)
+	ret0, _ := ret[0].([]entity.WebhookDeliveryAttempt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeliveryAttemptsByDeliveryIDs indicates an expected call of GetDeliveryAttemptsByDeliveryIDs.
+func (mr *MockIWebhookMockRecorder) GetDeliveryAttemptsByDeliveryIDs(ctx, deliveryIDs any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeliveryAttemptsByDeliveryIDs", reflect.TypeOf((*MockIWebhook)(nil).GetDeliveryAttemptsByDeliveryIDs), ctx, deliveryIDs)
+}
+
+// GetSubscriptionByID mocks base method.
+func (m *MockIWebhook) GetSubscriptionByID(ctx context.Context, subscriptionID int64) (entity.WebhookSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubscriptionByID", ctx, subscriptionID)
+	ret0, _ := ret[0].(entity.WebhookSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubscriptionByID indicates an expected call of GetSubscriptionByID.
+func (mr *MockIWebhookMockRecorder) GetSubscriptionByID(ctx, subscriptionID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubscriptionByID", reflect.TypeOf((*MockIWebhook)(nil).GetSubscriptionByID), ctx, subscriptionID)
+}
+
+// GetSubscriptions mocks base method.
+func (m *MockIWebhook) GetSubscriptions(ctx context.Context) ([]entity.WebhookSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubscriptions", ctx)
+	ret0, _ := ret[0].([]entity.WebhookSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubscriptions indicates an expected call of GetSubscriptions.
+func (mr *MockIWebhookMockRecorder) GetSubscriptions(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubscriptions", reflect.TypeOf((*MockIWebhook)(nil).GetSubscriptions), ctx)
+}
+
+// RedeliverDelivery mocks base method.
+func (m *MockIWebhook) RedeliverDelivery(ctx context.Context, deliveryID int64, now time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RedeliverDelivery", ctx, deliveryID, now)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RedeliverDelivery indicates an expected call of RedeliverDelivery.
+func (mr *MockIWebhookMockRecorder) RedeliverDelivery(ctx, deliveryID, now any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RedeliverDelivery", reflect.TypeOf((*MockIWebhook)(nil).RedeliverDelivery), ctx, deliveryID, now)
+}
+
+// UpdateDelivery mocks base method.
+func (m *MockIWebhook) UpdateDelivery(ctx context.Context, delivery entity.WebhookDelivery, leaseUntil time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateDelivery", ctx, delivery, leaseUntil)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateDelivery indicates an expected call of UpdateDelivery.
+func (mr *MockIWebhookMockRecorder) UpdateDelivery(ctx, delivery, leaseUntil any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateDelivery", reflect.TypeOf((*MockIWebhook)(nil).UpdateDelivery), ctx, delivery, leaseUntil)
+}
+
+// UpdateSubscription mocks base method.
+func (m *MockIWebhook) UpdateSubscription(ctx context.Context, subscription entity.WebhookSubscription) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSubscription", ctx, subscription)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateSubscription indicates an expected call of UpdateSubscription.
+func (mr *MockIWebhookMockRecorder) UpdateSubscription(ctx, subscription any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSubscription", reflect.TypeOf((*MockIWebhook)(nil).UpdateSubscription), ctx, subscription)
+}
@@ -0,0 +1,323 @@
+package repo
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Hidayathamir/go-user/config"
+	"github.com/Hidayathamir/go-user/internal/pkg/query"
+	"github.com/Hidayathamir/go-user/internal/repo/db"
+	"github.com/Hidayathamir/go-user/internal/repo/db/entity"
+	"github.com/Hidayathamir/go-user/internal/repo/db/entity/table"
+	sq "github.com/Masterminds/squirrel"
+)
+
+//go:generate mockgen -source=outbox.go -destination=mockrepo/outbox.go -package=mockrepo
+
+// IOutbox contains abstraction of repo domain event outbox.
+type IOutbox interface {
+	// ClaimPendingEvents claim not published events which are due and not
+	// claimed at now, oldest first. Returned events are claimed until
+	// lockedUntil, concurrent relay skip them.
+	ClaimPendingEvents(ctx context.Context, now time.Time, lockedUntil time.Time, limit uint64) ([]entity.OutboxEvent, error)
+	// MarkEventPublished mark event claimed until lockedUntil as published
+	// and release the claim. Return ErrClaimLost if the claim is not held.
+	MarkEventPublished(ctx context.Context, eventID int64, lockedUntil time.Time, publishedAt time.Time) error
+	// MarkEventFailed increase attempt of event claimed until lockedUntil,
+	// schedule the next attempt and release the claim. Return ErrClaimLost if
+	// the claim is not held.
+	MarkEventFailed(ctx context.Context, eventID int64, lockedUntil time.Time, nextAttemptAt time.Time, lastError string) error
+	// GetEventsAfter return events of the event types written by transaction
+	// older than every running transaction, after position (afterXactID,
+	// afterID), in commit safe order of transaction id then id.
+	GetEventsAfter(ctx context.Context, afterXactID int64, afterID int64, eventTypes []string, limit uint64) ([]entity.OutboxEvent, error)
+	// GetOldestRunningXactID return id of the oldest running transaction,
+	// events of every transaction before it are committed or rolled back.
+	GetOldestRunningXactID(ctx context.Context) (int64, error)
+	// DeleteEventsByUserIDs delete events of the users, return number of
+	// deleted events.
+	DeleteEventsByUserIDs(ctx context.Context, userIDs []int64) (int64, error)
+}
+
+// Outbox implement IOutbox.
+type Outbox struct {
+	cfg config.Config
+	db  *db.Postgres
+}
+
+var _ IOutbox = &Outbox{}
+
+// NewOutbox return *Outbox which implement repo.IOutbox.
+func NewOutbox(cfg config.Config, db *db.Postgres) *Outbox {
+	return &Outbox{
+		cfg: cfg,
+		db:  db,
+	}
+}
+
+// ClaimPendingEvents claim not published events which are due and not claimed
+// at now, oldest first. Returned events are claimed until lockedUntil,
+// concurrent relay skip them. Claim is done in one statement so row lock is
+// held only while claiming, not while publishing. An event whose relay crashed
+// is claimed again after lockedUntil.
+func (o *Outbox) ClaimPendingEvents(ctx context.Context, now time.Time, lockedUntil time.Time, limit uint64) ([]entity.OutboxEvent, error) {
+	// Subquery use question placeholder, the outer builder renumbers them.
+	pending := sq.
+		Select(table.OutboxEvent.ID).
+		From(table.OutboxEvent.String()).
+		Where(sq.Eq{
+			table.OutboxEvent.PublishedAt: nil,
+		}).
+		Where(sq.LtOrEq{
+			table.OutboxEvent.NextAttemptAt: now,
+		}).
+		Where(sq.Or{
+			sq.Eq{table.OutboxEvent.LockedUntil: nil},
+			sq.LtOrEq{table.OutboxEvent.LockedUntil: now},
+		}).
+		OrderBy(table.OutboxEvent.NextAttemptAt, table.OutboxEvent.ID).
+		Limit(limit).
+		Suffix("FOR UPDATE SKIP LOCKED")
+
+	sql, args, err := o.db.Builder.
+		Update(table.OutboxEvent.String()).
+		Set(table.OutboxEvent.LockedUntil, lockedUntil).
+		Where(sq.Expr(table.OutboxEvent.ID+" IN (?)", pending)).
+		Suffix(query.Returning(strings.Join([]string{
+			table.OutboxEvent.ID, table.OutboxEvent.EventType, table.OutboxEvent.UserID,
+			table.OutboxEvent.Payload, table.OutboxEvent.CreatedAt, table.OutboxEvent.PublishedAt,
+			table.OutboxEvent.Attempt, table.OutboxEvent.NextAttemptAt, table.OutboxEvent.LastError,
+			table.OutboxEvent.XactID,
+		}, ", "))).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("Outbox.db.Builder.ToSql: %w", err)
+	}
+
+	events, err := o.queryEvents(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("Outbox.queryEvents: %w", err)
+	}
+
+	// RETURNING does not keep the order of the subquery.
+	slices.SortFunc(events, func(a, b entity.OutboxEvent) int {
+		if c := a.NextAttemptAt.Compare(b.NextAttemptAt); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.ID, b.ID)
+	})
+
+	return events, nil
+}
+
+// oldestRunningXactID is sql expression of id of the oldest running
+// transaction, comparable with column xact_id.
+const oldestRunningXactID = "pg_snapshot_xmin(pg_current_snapshot())::text::bigint"
+
+// GetEventsAfter return events of the event types written by transaction older
+// than every running transaction, after position (afterXactID, afterID), in
+// order of transaction id then id.
+//
+// Id is taken from the sequence before commit, so an event with smaller id can
+// be committed after a bigger one. Transaction id of every transaction still
+// able to commit is at least the oldest running one, so events before it are
+// final and anything committed later comes after them in this order.
+func (o *Outbox) GetEventsAfter(ctx context.Context, afterXactID int64, afterID int64, eventTypes []string, limit uint64) ([]entity.OutboxEvent, error) {
+	sql, args, err := o.db.Builder.
+		Select(
+			table.OutboxEvent.ID, table.OutboxEvent.EventType, table.OutboxEvent.UserID,
+			table.OutboxEvent.Payload, table.OutboxEvent.CreatedAt, table.OutboxEvent.PublishedAt,
+			table.OutboxEvent.Attempt, table.OutboxEvent.NextAttemptAt, table.OutboxEvent.LastError,
+			table.OutboxEvent.XactID,
+		).
+		From(table.OutboxEvent.String()).
+		Where(sq.Expr("("+table.OutboxEvent.XactID+", "+table.OutboxEvent.ID+") > (?, ?)", afterXactID, afterID)).
+		Where(sq.Eq{
+			table.OutboxEvent.EventType: eventTypes,
+		}).
+		Where(table.OutboxEvent.XactID+" < "+oldestRunningXactID).
+		OrderBy(table.OutboxEvent.XactID, table.OutboxEvent.ID).
+		Limit(limit).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("Outbox.db.Builder.ToSql: %w", err)
+	}
+
+	events, err := o.queryEvents(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("Outbox.queryEvents: %w", err)
+	}
+
+	return events, nil
+}
+
+// GetOldestRunningXactID return id of the oldest running transaction, events
+// of every transaction before it are committed or rolled back.
+func (o *Outbox) GetOldestRunningXactID(ctx context.Context) (int64, error) {
+	sql, args, err := o.db.Builder.
+		Select(oldestRunningXactID).
+		ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("Outbox.db.Builder.ToSql: %w", err)
+	}
+
+	var xactID int64
+	err = o.db.Pool.QueryRow(ctx, sql, args...).Scan(&xactID)
+	if err != nil {
+		return 0, fmt.Errorf("Outbox.db.Pool.QueryRow.Scan: %w", err)
+	}
+
+	return xactID, nil
+}
+
+// MarkEventPublished mark event claimed until lockedUntil as published and
+// release the claim. The claim is held while locked_until is still the value
+// the relay set, otherwise other relay claimed the event after the claim
+// expired and ErrClaimLost is returned.
+func (o *Outbox) MarkEventPublished(ctx context.Context, eventID int64, lockedUntil time.Time, publishedAt time.Time) error {
+	sql, args, err := o.db.Builder.
+		Update(table.OutboxEvent.String()).
+		Set(table.OutboxEvent.PublishedAt, publishedAt).
+		Set(table.OutboxEvent.Attempt, sq.Expr(table.OutboxEvent.Attempt+" + 1")).
+		Set(table.OutboxEvent.LastError, "").
+		Set(table.OutboxEvent.LockedUntil, nil).
+		Where(sq.Eq{
+			table.OutboxEvent.ID:          eventID,
+			table.OutboxEvent.LockedUntil: lockedUntil,
+		}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("Outbox.db.Builder.ToSql: %w", err)
+	}
+
+	commandTag, err := o.db.Pool.Exec(ctx, sql, args...)
+	if err != nil {
+		return fmt.Errorf("Outbox.db.Pool.Exec: %w", err)
+	}
+
+	if commandTag.RowsAffected() == 0 {
+		return fmt.Errorf("%w: pgconn.CommandTag.RowsAffected == 0", ErrClaimLost)
+	}
+
+	return nil
+}
+
+// MarkEventFailed increase attempt of event claimed until lockedUntil,
+// schedule the next attempt and release the claim. Return ErrClaimLost if the
+// claim is not held, see MarkEventPublished.
+func (o *Outbox) MarkEventFailed(ctx context.Context, eventID int64, lockedUntil time.Time, nextAttemptAt time.Time, lastError string) error {
+	sql, args, err := o.db.Builder.
+		Update(table.OutboxEvent.String()).
+		Set(table.OutboxEvent.Attempt, sq.Expr(table.OutboxEvent.Attempt+" + 1")).
+		Set(table.OutboxEvent.NextAttemptAt, nextAttemptAt).
+		Set(table.OutboxEvent.LastError, lastError).
+		Set(table.OutboxEvent.LockedUntil, nil).
+		Where(sq.Eq{
+			table.OutboxEvent.ID:          eventID,
+			table.OutboxEvent.LockedUntil: lockedUntil,
+		}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("Outbox.db.Builder.ToSql: %w", err)
+	}
+
+	commandTag, err := o.db.Pool.Exec(ctx, sql, args...)
+	if err != nil {
+		return fmt.Errorf("Outbox.db.Pool.Exec: %w", err)
+	}
+
+	if commandTag.RowsAffected() == 0 {
+		return fmt.Errorf("%w: pgconn.CommandTag.RowsAffected == 0", ErrClaimLost)
+	}
+
+	return nil
+}
+
+// DeleteEventsByUserIDs delete events of the users, published or not, return
+// number of deleted events.
+func (o *Outbox) DeleteEventsByUserIDs(ctx context.Context, userIDs []int64) (int64, error) {
+	sql, args, err := o.db.Builder.
+		Delete(table.OutboxEvent.String()).
+		Where(sq.Eq{
+			table.OutboxEvent.UserID: userIDs,
+		}).
+		ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("Outbox.db.Builder.ToSql: %w", err)
+	}
+
+	commandTag, err := o.db.Pool.Exec(ctx, sql, args...)
+	if err != nil {
+		return 0, fmt.Errorf("Outbox.db.Pool.Exec: %w", err)
+	}
+
+	return commandTag.RowsAffected(), nil
+}
+
+// insertOutboxEvent write event to outbox. Call it with ctx of the transaction
+// which does the change, so the event is stored if and only if the change is.
+func insertOutboxEvent(ctx context.Context, pg *db.Postgres, eventType string, userID int64, data any) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("json.Marshal: %w", err)
+	}
+
+	now := time.Now()
+
+	sql, args, err := pg.Builder.
+		Insert(table.OutboxEvent.String()).
+		Columns(
+			table.OutboxEvent.EventType, table.OutboxEvent.UserID, table.OutboxEvent.Payload,
+			table.OutboxEvent.CreatedAt, table.OutboxEvent.NextAttemptAt,
+		).
+		Values(
+			eventType, userID, payload,
+			now, now,
+		).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("db.Postgres.Builder.ToSql: %w", err)
+	}
+
+	_, err = pg.Pool.Exec(ctx, sql, args...)
+	if err != nil {
+		return fmt.Errorf("db.Postgres.Pool.Exec: %w", err)
+	}
+
+	return nil
+}
+
+func (o *Outbox) queryEvents(ctx context.Context, sql string, args ...any) ([]entity.OutboxEvent, error) {
+	rows, err := o.db.Pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("Outbox.db.Pool.Query: %w", err)
+	}
+	defer rows.Close()
+
+	events := []entity.OutboxEvent{}
+	for rows.Next() {
+		event := entity.OutboxEvent{}
+		err := rows.Scan(
+			&event.ID, &event.EventType, &event.UserID,
+			&event.Payload, &event.CreatedAt, &event.PublishedAt,
+			&event.Attempt, &event.NextAttemptAt, &event.LastError,
+			&event.XactID,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("pgx.Rows.Scan: %w", err)
+		}
+		events = append(events, event)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("pgx.Rows.Err: %w", err)
+	}
+
+	return events, nil
+}
@@ -0,0 +1,65 @@
+package repo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Hidayathamir/go-user/internal/repo/db"
+	"github.com/Hidayathamir/go-user/pkg/gouser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIntegrationOutboxGetEventsAfter(t *testing.T) {
+	t.Parallel()
+
+	t.Run("event of slow transaction committed out of order should not be skipped", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := initTestIntegration(t)
+
+		pg, err := db.NewPGPoolConn(cfg)
+		require.NoError(t, err)
+
+		o := NewOutbox(cfg, pg)
+		ctx := context.Background()
+		eventTypes := []string{gouser.EventTypeUserDeleted}
+
+		startXactID, err := o.GetOldestRunningXactID(ctx)
+		require.NoError(t, err)
+
+		// Slow transaction take the smaller event id and commit last.
+		slowTx, err := pg.Pool.Begin(ctx)
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = slowTx.Rollback(context.Background()) })
+
+		err = insertOutboxEvent(db.ContextWithTx(ctx, slowTx), pg, gouser.EventTypeUserDeleted, 44, gouser.EventUserDeleted{UserID: 44})
+		require.NoError(t, err)
+
+		fastTx, err := pg.Pool.Begin(ctx)
+		require.NoError(t, err)
+
+		err = insertOutboxEvent(db.ContextWithTx(ctx, fastTx), pg, gouser.EventTypeUserDeleted, 45, gouser.EventUserDeleted{UserID: 45})
+		require.NoError(t, err)
+
+		require.NoError(t, fastTx.Commit(ctx))
+
+		events, err := o.GetEventsAfter(ctx, startXactID, 0, eventTypes, 10)
+		require.NoError(t, err)
+		assert.Empty(t, events, "event of fast transaction should wait for the slow one")
+
+		require.NoError(t, slowTx.Commit(ctx))
+
+		events, err = o.GetEventsAfter(ctx, startXactID, 0, eventTypes, 10)
+		require.NoError(t, err)
+		require.Len(t, events, 2)
+		assert.Equal(t, int64(44), events[0].UserID)
+		assert.Equal(t, int64(45), events[1].UserID)
+		assert.Less(t, events[0].ID, events[1].ID)
+
+		last := events[1]
+		events, err = o.GetEventsAfter(ctx, last.XactID, last.ID, eventTypes, 10)
+		require.NoError(t, err)
+		assert.Empty(t, events)
+	})
+}
@@ -0,0 +1,404 @@
+package repo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Hidayathamir/go-user/config"
+	"github.com/Hidayathamir/go-user/internal/repo/db"
+	"github.com/Hidayathamir/go-user/pkg/gouser"
+	"github.com/pashagolub/pgxmock/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var outboxEventColumns = []string{
+	"id", "event_type", "user_id", "payload", "created_at", "published_at",
+	"attempt", "next_attempt_at", "last_error", "xact_id",
+}
+
+func TestUnitOutboxClaimPendingEvents(t *testing.T) {
+	t.Parallel()
+
+	t.Run("claim pending events success", func(t *testing.T) {
+		t.Parallel()
+
+		mockpool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+
+		o := &Outbox{
+			cfg: config.Config{},
+			db: &db.Postgres{
+				Builder: builder,
+				Pool:    mockpool,
+			},
+		}
+
+		now := time.Now()
+		lockedUntil := now.Add(time.Minute)
+		mockpool.
+			ExpectQuery(`UPDATE "outbox_event" SET locked_until = \$1 WHERE id IN \(SELECT id FROM "outbox_event" WHERE published_at IS NULL AND next_attempt_at <= \$2 AND \(locked_until IS NULL OR locked_until <= \$3\) ORDER BY next_attempt_at, id LIMIT 10 FOR UPDATE SKIP LOCKED\) RETURNING id, .*`).
+			WithArgs(lockedUntil, now, now).
+			WillReturnRows(pgxmock.NewRows(outboxEventColumns).
+				AddRow(int64(2), gouser.EventTypeUserDeleted, int64(45), []byte(`{}`), now, nil, 2, now, "timeout", int64(701)).
+				AddRow(int64(1), gouser.EventTypeUserRegistered, int64(44), []byte(`{}`), now, nil, 0, now, "", int64(700)),
+			)
+
+		events, err := o.ClaimPendingEvents(context.Background(), now, lockedUntil, 10)
+
+		require.NoError(t, err)
+		require.Len(t, events, 2)
+		assert.Equal(t, int64(1), events[0].ID)
+		assert.Equal(t, gouser.EventTypeUserDeleted, events[1].EventType)
+		assert.Equal(t, 2, events[1].Attempt)
+	})
+	t.Run("Query error should return error", func(t *testing.T) {
+		t.Parallel()
+
+		mockpool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+
+		o := &Outbox{
+			cfg: config.Config{},
+			db: &db.Postgres{
+				Builder: builder,
+				Pool:    mockpool,
+			},
+		}
+
+		now := time.Now()
+		lockedUntil := now.Add(time.Minute)
+		mockpool.ExpectQuery("UPDATE").WithArgs(lockedUntil, now, now).WillReturnError(assert.AnError)
+
+		events, err := o.ClaimPendingEvents(context.Background(), now, lockedUntil, 10)
+
+		assert.Nil(t, events)
+		require.Error(t, err)
+		require.ErrorIs(t, err, assert.AnError)
+	})
+}
+
+func TestUnitOutboxMarkEventPublished(t *testing.T) {
+	t.Parallel()
+
+	t.Run("mark event published success", func(t *testing.T) {
+		t.Parallel()
+
+		mockpool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+
+		o := &Outbox{
+			cfg: config.Config{},
+			db: &db.Postgres{
+				Builder: builder,
+				Pool:    mockpool,
+			},
+		}
+
+		now := time.Now()
+		lockedUntil := now.Add(time.Minute)
+		mockpool.
+			ExpectExec(`UPDATE "outbox_event" SET .* WHERE id = \$4 AND locked_until = \$5`).WithArgs(now, "", nil, int64(1), lockedUntil).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+		err = o.MarkEventPublished(context.Background(), 1, lockedUntil, now)
+
+		require.NoError(t, err)
+		require.NoError(t, mockpool.ExpectationsWereMet())
+	})
+	t.Run("Exec error should return error", func(t *testing.T) {
+		t.Parallel()
+
+		mockpool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+
+		o := &Outbox{
+			cfg: config.Config{},
+			db: &db.Postgres{
+				Builder: builder,
+				Pool:    mockpool,
+			},
+		}
+
+		now := time.Now()
+		lockedUntil := now.Add(time.Minute)
+		mockpool.
+			ExpectExec("UPDATE").WithArgs(now, "", nil, int64(1), lockedUntil).
+			WillReturnError(assert.AnError)
+
+		err = o.MarkEventPublished(context.Background(), 1, lockedUntil, now)
+
+		require.Error(t, err)
+		require.ErrorIs(t, err, assert.AnError)
+	})
+	t.Run("claim taken over should return error claim lost", func(t *testing.T) {
+		t.Parallel()
+
+		mockpool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+
+		o := &Outbox{
+			cfg: config.Config{},
+			db: &db.Postgres{
+				Builder: builder,
+				Pool:    mockpool,
+			},
+		}
+
+		now := time.Now()
+		lockedUntil := now.Add(time.Minute)
+		mockpool.
+			ExpectExec("UPDATE").WithArgs(now, "", nil, int64(1), lockedUntil).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+
+		err = o.MarkEventPublished(context.Background(), 1, lockedUntil, now)
+
+		require.Error(t, err)
+		require.ErrorIs(t, err, ErrClaimLost)
+	})
+}
+
+func TestUnitOutboxMarkEventFailed(t *testing.T) {
+	t.Parallel()
+
+	t.Run("mark event failed success", func(t *testing.T) {
+		t.Parallel()
+
+		mockpool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+
+		o := &Outbox{
+			cfg: config.Config{},
+			db: &db.Postgres{
+				Builder: builder,
+				Pool:    mockpool,
+			},
+		}
+
+		nextAttemptAt := time.Now().Add(time.Minute)
+		lockedUntil := time.Now().Add(time.Minute)
+		mockpool.
+			ExpectExec(`UPDATE "outbox_event" SET attempt = attempt \+ 1, next_attempt_at = \$1, last_error = \$2, locked_until = \$3 WHERE id = \$4 AND locked_until = \$5`).WithArgs(nextAttemptAt, "timeout", nil, int64(1), lockedUntil).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+		err = o.MarkEventFailed(context.Background(), 1, lockedUntil, nextAttemptAt, "timeout")
+
+		require.NoError(t, err)
+		require.NoError(t, mockpool.ExpectationsWereMet())
+	})
+	t.Run("Exec error should return error", func(t *testing.T) {
+		t.Parallel()
+
+		mockpool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+
+		o := &Outbox{
+			cfg: config.Config{},
+			db: &db.Postgres{
+				Builder: builder,
+				Pool:    mockpool,
+			},
+		}
+
+		nextAttemptAt := time.Now().Add(time.Minute)
+		lockedUntil := time.Now().Add(time.Minute)
+		mockpool.
+			ExpectExec("UPDATE").WithArgs(nextAttemptAt, "timeout", nil, int64(1), lockedUntil).
+			WillReturnError(assert.AnError)
+
+		err = o.MarkEventFailed(context.Background(), 1, lockedUntil, nextAttemptAt, "timeout")
+
+		require.Error(t, err)
+		require.ErrorIs(t, err, assert.AnError)
+	})
+	t.Run("claim taken over should return error claim lost", func(t *testing.T) {
+		t.Parallel()
+
+		mockpool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+
+		o := &Outbox{
+			cfg: config.Config{},
+			db: &db.Postgres{
+				Builder: builder,
+				Pool:    mockpool,
+			},
+		}
+
+		nextAttemptAt := time.Now().Add(time.Minute)
+		lockedUntil := time.Now().Add(time.Minute)
+		mockpool.
+			ExpectExec("UPDATE").WithArgs(nextAttemptAt, "timeout", nil, int64(1), lockedUntil).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+
+		err = o.MarkEventFailed(context.Background(), 1, lockedUntil, nextAttemptAt, "timeout")
+
+		require.Error(t, err)
+		require.ErrorIs(t, err, ErrClaimLost)
+	})
+}
+
+func TestUnitOutboxGetEventsAfter(t *testing.T) {
+	t.Parallel()
+
+	t.Run("get events after position success", func(t *testing.T) {
+		t.Parallel()
+
+		mockpool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+
+		o := &Outbox{
+			cfg: config.Config{},
+			db: &db.Postgres{
+				Builder: builder,
+				Pool:    mockpool,
+			},
+		}
+
+		now := time.Now()
+		eventTypes := []string{gouser.EventTypeUserRegistered, gouser.EventTypeUserDeleted}
+		mockpool.
+			ExpectQuery(`SELECT .* FROM "outbox_event" WHERE \(xact_id, id\) > \(\$1, \$2\) AND event_type IN \(\$3,\$4\) AND xact_id < pg_snapshot_xmin\(pg_current_snapshot\(\)\)::text::bigint ORDER BY xact_id, id LIMIT 10`).
+			WithArgs(int64(700), int64(7), gouser.EventTypeUserRegistered, gouser.EventTypeUserDeleted).
+			WillReturnRows(pgxmock.NewRows(outboxEventColumns).
+				AddRow(int64(9), gouser.EventTypeUserRegistered, int64(44), []byte(`{}`), now, &now, 1, now, "", int64(700)).
+				AddRow(int64(8), gouser.EventTypeUserDeleted, int64(45), []byte(`{}`), now, &now, 1, now, "", int64(701)),
+			)
+
+		events, err := o.GetEventsAfter(context.Background(), 700, 7, eventTypes, 10)
+
+		require.NoError(t, err)
+		require.Len(t, events, 2)
+		assert.Equal(t, int64(9), events[0].ID)
+		assert.Equal(t, int64(700), events[0].XactID)
+		assert.Equal(t, int64(8), events[1].ID)
+		assert.Equal(t, int64(701), events[1].XactID)
+	})
+	t.Run("Query error should return error", func(t *testing.T) {
+		t.Parallel()
+
+		mockpool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+
+		o := &Outbox{
+			cfg: config.Config{},
+			db: &db.Postgres{
+				Builder: builder,
+				Pool:    mockpool,
+			},
+		}
+
+		mockpool.
+			ExpectQuery("SELECT").
+			WithArgs(int64(700), int64(7), gouser.EventTypeUserDeleted).
+			WillReturnError(assert.AnError)
+
+		events, err := o.GetEventsAfter(context.Background(), 700, 7, []string{gouser.EventTypeUserDeleted}, 10)
+
+		assert.Nil(t, events)
+		require.Error(t, err)
+		require.ErrorIs(t, err, assert.AnError)
+	})
+}
+
+func TestUnitOutboxGetOldestRunningXactID(t *testing.T) {
+	t.Parallel()
+
+	t.Run("get oldest running xact id success", func(t *testing.T) {
+		t.Parallel()
+
+		mockpool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+
+		o := &Outbox{
+			cfg: config.Config{},
+			db: &db.Postgres{
+				Builder: builder,
+				Pool:    mockpool,
+			},
+		}
+
+		mockpool.
+			ExpectQuery(`SELECT pg_snapshot_xmin\(pg_current_snapshot\(\)\)::text::bigint`).
+			WillReturnRows(pgxmock.NewRows([]string{"pg_snapshot_xmin"}).AddRow(int64(700)))
+
+		xactID, err := o.GetOldestRunningXactID(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(700), xactID)
+	})
+	t.Run("QueryRow error should return error", func(t *testing.T) {
+		t.Parallel()
+
+		mockpool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+
+		o := &Outbox{
+			cfg: config.Config{},
+			db: &db.Postgres{
+				Builder: builder,
+				Pool:    mockpool,
+			},
+		}
+
+		mockpool.ExpectQuery("SELECT").WillReturnError(assert.AnError)
+
+		xactID, err := o.GetOldestRunningXactID(context.Background())
+
+		require.Error(t, err)
+		require.ErrorIs(t, err, assert.AnError)
+		assert.Equal(t, int64(0), xactID)
+	})
+}
+
+func TestUnitOutboxDeleteEventsByUserIDs(t *testing.T) {
+	t.Parallel()
+
+	t.Run("delete events by user ids success", func(t *testing.T) {
+		t.Parallel()
+
+		mockpool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+
+		o := &Outbox{
+			cfg: config.Config{},
+			db: &db.Postgres{
+				Builder: builder,
+				Pool:    mockpool,
+			},
+		}
+
+		mockpool.
+			ExpectExec(`DELETE FROM \"outbox_event\" WHERE user_id IN \(\$1,\$2\)`).
+			WithArgs(int64(44), int64(45)).
+			WillReturnResult(pgxmock.NewResult("DELETE", 3))
+
+		count, err := o.DeleteEventsByUserIDs(context.Background(), []int64{44, 45})
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), count)
+	})
+	t.Run("Exec error should return error", func(t *testing.T) {
+		t.Parallel()
+
+		mockpool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+
+		o := &Outbox{
+			cfg: config.Config{},
+			db: &db.Postgres{
+				Builder: builder,
+				Pool:    mockpool,
+			},
+		}
+
+		mockpool.ExpectExec("DELETE").WithArgs(int64(44), int64(45)).WillReturnError(assert.AnError)
+
+		count, err := o.DeleteEventsByUserIDs(context.Background(), []int64{44, 45})
+
+		require.Error(t, err)
+		require.ErrorIs(t, err, assert.AnError)
+		assert.Equal(t, int64(0), count)
+	})
+}
@@ -4,24 +4,75 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/Hidayathamir/go-user/config"
+	"github.com/Hidayathamir/go-user/internal/pkg/query"
 	"github.com/Hidayathamir/go-user/internal/repo/db"
 	"github.com/Hidayathamir/go-user/internal/repo/db/entity"
 	"github.com/Hidayathamir/go-user/internal/repo/db/entity/table"
 	"github.com/Hidayathamir/go-user/pkg/gouser"
 	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgerrcode"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 )
 
 //go:generate mockgen -source=profile.go -destination=mockrepo/profile.go -package=mockrepo
 
 // IProfile contains abstraction of repo profile.
 type IProfile interface {
-	// GetProfileByUsername return user profile by username.
+	// GetProfileByUsername return user profile by username. Deleted user is
+	// treated as non-existent.
 	GetProfileByUsername(ctx context.Context, username string) (entity.User, error)
-	// UpdateProfileByUserID update user profile by user id.
+	// GetProfileByUserID return user profile by user id. Deleted user is
+	// treated as non-existent.
+	GetProfileByUserID(ctx context.Context, userID int64) (entity.User, error)
+	// UpdateProfileByUserID update user profile by user id. Event
+	// user.password_changed is written to outbox in the transaction bound to
+	// ctx when password is updated, it must be called within
+	// ITransactor.WithinTx.
 	UpdateProfileByUserID(ctx context.Context, user entity.User) error
+	// ListUsers return one page of not deleted users matching the filter.
+	ListUsers(ctx context.Context, filter ListUsersFilter) ([]entity.User, error)
+	// CountUsers return number of not deleted users matching the filter,
+	// sort and page of the filter are ignored.
+	CountUsers(ctx context.Context, filter ListUsersFilter) (int64, error)
+	// BatchGetProfiles return not deleted users which id is in userIDs or
+	// username is in usernames, in no particular order.
+	BatchGetProfiles(ctx context.Context, userIDs []int64, usernames []string) ([]entity.User, error)
+	// GetProfileByOldUsername return not deleted user profile who owned
+	// username before a change after changedAfter.
+	GetProfileByOldUsername(ctx context.Context, username string, changedAfter time.Time) (entity.User, error)
+	// ChangeUsername change username of the user and record the old username
+	// in username history. Event user.profile_updated is written to outbox in
+	// the transaction bound to ctx, it must be called within
+	// ITransactor.WithinTx.
+	ChangeUsername(ctx context.Context, userID int64, newUsername string, changedAt time.Time) error
+	// GetLastUsernameChangedAt return when the user changed username the last
+	// time, nil if never.
+	GetLastUsernameChangedAt(ctx context.Context, userID int64) (*time.Time, error)
+	// IterateUsernameHistoryByUserID call fn for every old username of the
+	// user, ordered by id. History is not buffered.
+	IterateUsernameHistoryByUserID(ctx context.Context, userID int64, fn func(entity.UsernameHistory) error) error
+}
+
+// ListUsersFilter is filter, sort and page of ListUsers.
+type ListUsersFilter struct {
+	// CreatedFrom is inclusive, CreatedTo is exclusive.
+	CreatedFrom    *time.Time
+	CreatedTo      *time.Time
+	Status         string
+	UsernamePrefix string
+	// SortBy is one of gouser.UserSortBy*, users with the same sort value are
+	// sorted by id.
+	SortBy   string
+	SortDesc bool
+	// After is the last user of the previous page, only its id and the
+	// SortBy field are used.
+	After *entity.User
+	Limit uint64
 }
 
 // Profile implement IProfile.
@@ -40,16 +91,19 @@ func NewProfile(cfg config.Config, db *db.Postgres) *Profile {
 	}
 }
 
-// GetProfileByUsername return user profile by username.
+// GetProfileByUsername return user profile by username. Deleted user is
+// treated as non-existent.
 func (p *Profile) GetProfileByUsername(ctx context.Context, username string) (entity.User, error) {
 	sql, args, err := p.db.Builder.
 		Select(
 			table.User.ID, table.User.Username, table.User.Password,
-			table.User.CreatedAt, table.User.UpdatedAt,
+			table.User.Role, table.User.CreatedAt, table.User.UpdatedAt,
+			table.User.Status, table.User.SuspendedUntil, table.User.SuspensionReason,
 		).
 		From(table.User.String()).
 		Where(sq.Eq{
-			table.User.Username: username,
+			table.User.Username:  username,
+			table.User.DeletedAt: nil,
 		}).
 		ToSql()
 	if err != nil {
@@ -59,7 +113,8 @@ func (p *Profile) GetProfileByUsername(ctx context.Context, username string) (en
 	user := entity.User{}
 	err = p.db.Pool.QueryRow(ctx, sql, args...).Scan(
 		&user.ID, &user.Username, &user.Password,
-		&user.CreatedAt, &user.UpdatedAt,
+		&user.Role, &user.CreatedAt, &user.UpdatedAt,
+		&user.Status, &user.SuspendedUntil, &user.SuspensionReason,
 	)
 	if err != nil {
 		err := fmt.Errorf("Profile.db.Pool.QueryRow: %w", err)
@@ -72,8 +127,51 @@ func (p *Profile) GetProfileByUsername(ctx context.Context, username string) (en
 	return user, nil
 }
 
-// UpdateProfileByUserID update user profile by user id.
+// GetProfileByUserID return user profile by user id. Deleted user is treated
+// as non-existent.
+func (p *Profile) GetProfileByUserID(ctx context.Context, userID int64) (entity.User, error) {
+	sql, args, err := p.db.Builder.
+		Select(
+			table.User.ID, table.User.Username, table.User.Password,
+			table.User.Role, table.User.CreatedAt, table.User.UpdatedAt,
+			table.User.Status, table.User.SuspendedUntil, table.User.SuspensionReason,
+		).
+		From(table.User.String()).
+		Where(sq.Eq{
+			table.User.ID:        userID,
+			table.User.DeletedAt: nil,
+		}).
+		ToSql()
+	if err != nil {
+		return entity.User{}, fmt.Errorf("Profile.db.Builder.ToSql: %w", err)
+	}
+
+	user := entity.User{}
+	err = p.db.Pool.QueryRow(ctx, sql, args...).Scan(
+		&user.ID, &user.Username, &user.Password,
+		&user.Role, &user.CreatedAt, &user.UpdatedAt,
+		&user.Status, &user.SuspendedUntil, &user.SuspensionReason,
+	)
+	if err != nil {
+		err := fmt.Errorf("Profile.db.Pool.QueryRow: %w", err)
+		if errors.Is(err, pgx.ErrNoRows) {
+			err = fmt.Errorf("%w: %w", gouser.ErrUnknownUserID, err)
+		}
+		return entity.User{}, err
+	}
+
+	return user, nil
+}
+
+// UpdateProfileByUserID update user profile by user id. Event
+// user.password_changed is written to outbox in the transaction bound to ctx
+// when password is updated, it must be called within ITransactor.WithinTx.
 func (p *Profile) UpdateProfileByUserID(ctx context.Context, user entity.User) error {
+	err := checkWithinTx(ctx)
+	if err != nil {
+		return fmt.Errorf("checkWithinTx: %w", err)
+	}
+
 	set := sq.Eq{}
 
 	if user.Password != "" {
@@ -84,7 +182,8 @@ func (p *Profile) UpdateProfileByUserID(ctx context.Context, user entity.User) e
 		Update(table.User.String()).
 		SetMap(set).
 		Where(sq.Eq{
-			table.User.ID: user.ID,
+			table.User.ID:        user.ID,
+			table.User.DeletedAt: nil,
 		}).
 		ToSql()
 	if err != nil {
@@ -104,5 +203,399 @@ func (p *Profile) UpdateProfileByUserID(ctx context.Context, user entity.User) e
 		return fmt.Errorf("pgconn.CommandTag.RowsAffected == 0: %w", pgx.ErrNoRows)
 	}
 
+	if user.Password != "" {
+		data := gouser.EventPasswordChanged{UserID: user.ID}
+		err := insertOutboxEvent(ctx, p.db, gouser.EventTypePasswordChanged, user.ID, data)
+		if err != nil {
+			return fmt.Errorf("insertOutboxEvent: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// ListUsers return one page of not deleted users matching the filter. Paging
+// is keyset on the sort field and id, so page is stable while users are
+// created.
+func (p *Profile) ListUsers(ctx context.Context, filter ListUsersFilter) ([]entity.User, error) {
+	sortColumn := table.User.ID
+	switch filter.SortBy {
+	case gouser.UserSortByCreatedAt:
+		sortColumn = table.User.CreatedAt
+	case gouser.UserSortByUsername:
+		sortColumn = table.User.Username
+	}
+
+	direction, comparison := "ASC", ">"
+	if filter.SortDesc {
+		direction, comparison = "DESC", "<"
+	}
+
+	builder := p.db.Builder.
+		Select(
+			table.User.ID, table.User.Username, table.User.Password,
+			table.User.Role, table.User.CreatedAt, table.User.UpdatedAt,
+			table.User.Status, table.User.SuspendedUntil, table.User.SuspensionReason,
+		).
+		From(table.User.String()).
+		Where(listUsersWhere(filter))
+
+	if filter.After != nil {
+		switch sortColumn {
+		case table.User.ID:
+			builder = builder.Where(sq.Expr(table.User.ID+" "+comparison+" ?", filter.After.ID))
+		case table.User.CreatedAt:
+			builder = builder.Where(sq.Expr(
+				"("+table.User.CreatedAt+", "+table.User.ID+") "+comparison+" (?, ?)",
+				filter.After.CreatedAt, filter.After.ID,
+			))
+		case table.User.Username:
+			builder = builder.Where(sq.Expr(
+				"("+table.User.Username+", "+table.User.ID+") "+comparison+" (?, ?)",
+				filter.After.Username, filter.After.ID,
+			))
+		}
+	}
+
+	if sortColumn != table.User.ID {
+		builder = builder.OrderBy(sortColumn + " " + direction)
+	}
+
+	sql, args, err := builder.
+		OrderBy(table.User.ID + " " + direction).
+		Limit(filter.Limit).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("Profile.db.Builder.ToSql: %w", err)
+	}
+
+	rows, err := p.db.Pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("Profile.db.Pool.Query: %w", err)
+	}
+	defer rows.Close()
+
+	users := []entity.User{}
+	for rows.Next() {
+		user := entity.User{}
+		err := rows.Scan(
+			&user.ID, &user.Username, &user.Password,
+			&user.Role, &user.CreatedAt, &user.UpdatedAt,
+			&user.Status, &user.SuspendedUntil, &user.SuspensionReason,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("pgx.Rows.Scan: %w", err)
+		}
+		users = append(users, user)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("pgx.Rows.Err: %w", err)
+	}
+
+	return users, nil
+}
+
+// CountUsers return number of not deleted users matching the filter, sort and
+// page of the filter are ignored.
+func (p *Profile) CountUsers(ctx context.Context, filter ListUsersFilter) (int64, error) {
+	sql, args, err := p.db.Builder.
+		Select("COUNT(*)").
+		From(table.User.String()).
+		Where(listUsersWhere(filter)).
+		ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("Profile.db.Builder.ToSql: %w", err)
+	}
+
+	var count int64
+	err = p.db.Pool.QueryRow(ctx, sql, args...).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("Profile.db.Pool.QueryRow.Scan: %w", err)
+	}
+
+	return count, nil
+}
+
+// BatchGetProfiles return not deleted users which id is in userIDs or username
+// is in usernames, in no particular order. It is one query whatever the number
+// of ids and usernames.
+func (p *Profile) BatchGetProfiles(ctx context.Context, userIDs []int64, usernames []string) ([]entity.User, error) {
+	if userIDs == nil {
+		userIDs = []int64{}
+	}
+	if usernames == nil {
+		usernames = []string{}
+	}
+
+	sql, args, err := p.db.Builder.
+		Select(
+			table.User.ID, table.User.Username, table.User.Password,
+			table.User.Role, table.User.CreatedAt, table.User.UpdatedAt,
+			table.User.Status, table.User.SuspendedUntil, table.User.SuspensionReason,
+		).
+		From(table.User.String()).
+		Where(sq.Eq{
+			table.User.DeletedAt: nil,
+		}).
+		Where(sq.Or{
+			sq.Expr(table.User.ID+" = ANY(?)", userIDs),
+			sq.Expr(table.User.Username+" = ANY(?)", usernames),
+		}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("Profile.db.Builder.ToSql: %w", err)
+	}
+
+	rows, err := p.db.Pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("Profile.db.Pool.Query: %w", err)
+	}
+	defer rows.Close()
+
+	users := []entity.User{}
+	for rows.Next() {
+		user := entity.User{}
+		err := rows.Scan(
+			&user.ID, &user.Username, &user.Password,
+			&user.Role, &user.CreatedAt, &user.UpdatedAt,
+			&user.Status, &user.SuspendedUntil, &user.SuspensionReason,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("pgx.Rows.Scan: %w", err)
+		}
+		users = append(users, user)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("pgx.Rows.Err: %w", err)
+	}
+
+	return users, nil
+}
+
+func listUsersWhere(filter ListUsersFilter) sq.And {
+	where := sq.And{
+		sq.Eq{table.User.DeletedAt: nil},
+	}
+
+	if filter.Status != "" {
+		where = append(where, sq.Eq{table.User.Status: filter.Status})
+	}
+
+	if filter.UsernamePrefix != "" {
+		where = append(where, sq.Like{table.User.Username: escapeLike(filter.UsernamePrefix) + "%"})
+	}
+
+	if filter.CreatedFrom != nil {
+		where = append(where, sq.GtOrEq{table.User.CreatedAt: *filter.CreatedFrom})
+	}
+
+	if filter.CreatedTo != nil {
+		where = append(where, sq.Lt{table.User.CreatedAt: *filter.CreatedTo})
+	}
+
+	return where
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// escapeLike escape LIKE wildcard so s is matched literally.
+func escapeLike(s string) string {
+	return likeEscaper.Replace(s)
+}
+
+// GetProfileByOldUsername return not deleted user profile who owned username
+// before a change after changedAfter. When several users owned it, the latest
+// change wins.
+func (p *Profile) GetProfileByOldUsername(ctx context.Context, username string, changedAfter time.Time) (entity.User, error) {
+	sql, args, err := p.db.Builder.
+		Select(
+			table.User.Dot.ID, table.User.Dot.Username, table.User.Dot.Password,
+			table.User.Dot.Role, table.User.Dot.CreatedAt, table.User.Dot.UpdatedAt,
+			table.User.Dot.Status, table.User.Dot.SuspendedUntil, table.User.Dot.SuspensionReason,
+		).
+		From(table.UsernameHistory.String()).
+		Join(table.User.String() + " ON " + table.User.Dot.ID + " = " + table.UsernameHistory.Dot.UserID).
+		Where(sq.Eq{
+			table.UsernameHistory.Dot.Username: username,
+			table.User.Dot.DeletedAt:           nil,
+		}).
+		Where(sq.Gt{
+			table.UsernameHistory.Dot.ChangedAt: changedAfter,
+		}).
+		OrderBy(table.UsernameHistory.Dot.ChangedAt + " DESC").
+		Limit(1).
+		ToSql()
+	if err != nil {
+		return entity.User{}, fmt.Errorf("Profile.db.Builder.ToSql: %w", err)
+	}
+
+	user := entity.User{}
+	err = p.db.Pool.QueryRow(ctx, sql, args...).Scan(
+		&user.ID, &user.Username, &user.Password,
+		&user.Role, &user.CreatedAt, &user.UpdatedAt,
+		&user.Status, &user.SuspendedUntil, &user.SuspensionReason,
+	)
+	if err != nil {
+		err := fmt.Errorf("Profile.db.Pool.QueryRow: %w", err)
+		if errors.Is(err, pgx.ErrNoRows) {
+			err = fmt.Errorf("%w: %w", gouser.ErrUnknownUsername, err)
+		}
+		return entity.User{}, err
+	}
+
+	return user, nil
+}
+
+// ChangeUsername change username of the user and record the old username in
+// username history. Both are done in one statement so they can not diverge.
+// It fails with gouser.ErrDuplicateUsername when the username is taken. Event
+// user.profile_updated is written to outbox in the transaction bound to ctx, it
+// must be called within ITransactor.WithinTx.
+func (p *Profile) ChangeUsername(ctx context.Context, userID int64, newUsername string, changedAt time.Time) error {
+	err := checkWithinTx(ctx)
+	if err != nil {
+		return fmt.Errorf("checkWithinTx: %w", err)
+	}
+
+	// CTE parts use question placeholder, the outer builder renumbers them.
+	oldUser := sq.
+		Select(table.User.ID, table.User.Username).
+		From(table.User.String()).
+		Where(sq.Eq{
+			table.User.ID:        userID,
+			table.User.DeletedAt: nil,
+		}).
+		Suffix("FOR UPDATE")
+
+	updateUser := sq.
+		Update(table.User.String()).
+		Set(table.User.Username, newUsername).
+		Set(table.User.UpdatedAt, changedAt).
+		From("old").
+		Where(table.User.Dot.ID + " = old.id").
+		Suffix(query.Returning(table.User.Dot.ID))
+
+	sql, args, err := p.db.Builder.
+		Insert(table.UsernameHistory.String()).
+		PrefixExpr(sq.ConcatExpr("WITH old AS (", oldUser, "), upd AS (", updateUser, ")")).
+		Columns(
+			table.UsernameHistory.UserID, table.UsernameHistory.Username,
+			table.UsernameHistory.ChangedAt,
+		).
+		Select(sq.
+			Select("old.id", "old.username").
+			Column("?::timestamptz", changedAt).
+			From("old").
+			Join("upd ON upd.id = old.id"),
+		).
+		Suffix(query.Returning(table.UsernameHistory.Username)).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("Profile.db.Builder.ToSql: %w", err)
+	}
+
+	var oldUsername string
+	err = p.db.Pool.QueryRow(ctx, sql, args...).Scan(&oldUsername)
+	if err != nil {
+		err := fmt.Errorf("Profile.db.Pool.QueryRow.Scan: %w", err)
+
+		if errors.Is(err, pgx.ErrNoRows) {
+			return fmt.Errorf("%w: %w", gouser.ErrUnknownUserID, err)
+		}
+
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			isErrDuplicateUsername := pgErr.Code == pgerrcode.UniqueViolation &&
+				pgErr.ConstraintName == table.User.Constraint.UserUn
+			if isErrDuplicateUsername {
+				return fmt.Errorf("%w: %w", gouser.ErrDuplicateUsername, err)
+			}
+		}
+
+		return err
+	}
+
+	data := gouser.EventProfileUpdated{UserID: userID, Username: newUsername, OldUsername: oldUsername}
+	err = insertOutboxEvent(ctx, p.db, gouser.EventTypeProfileUpdated, userID, data)
+	if err != nil {
+		return fmt.Errorf("insertOutboxEvent: %w", err)
+	}
+
+	return nil
+}
+
+// GetLastUsernameChangedAt return when the user changed username the last
+// time, nil if never.
+func (p *Profile) GetLastUsernameChangedAt(ctx context.Context, userID int64) (*time.Time, error) {
+	sql, args, err := p.db.Builder.
+		Select("MAX(" + table.UsernameHistory.ChangedAt + ")").
+		From(table.UsernameHistory.String()).
+		Where(sq.Eq{
+			table.UsernameHistory.UserID: userID,
+		}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("Profile.db.Builder.ToSql: %w", err)
+	}
+
+	var changedAt *time.Time
+	err = p.db.Pool.QueryRow(ctx, sql, args...).Scan(&changedAt)
+	if err != nil {
+		return nil, fmt.Errorf("Profile.db.Pool.QueryRow.Scan: %w", err)
+	}
+
+	return changedAt, nil
+}
+
+// IterateUsernameHistoryByUserID call fn for every old username of the user,
+// ordered by id. History is not buffered, iteration stops at the first error
+// returned by fn.
+func (p *Profile) IterateUsernameHistoryByUserID(ctx context.Context, userID int64, fn func(entity.UsernameHistory) error) error {
+	sql, args, err := p.db.Builder.
+		Select(
+			table.UsernameHistory.ID, table.UsernameHistory.UserID,
+			table.UsernameHistory.Username, table.UsernameHistory.ChangedAt,
+		).
+		From(table.UsernameHistory.String()).
+		Where(sq.Eq{
+			table.UsernameHistory.UserID: userID,
+		}).
+		OrderBy(table.UsernameHistory.ID).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("Profile.db.Builder.ToSql: %w", err)
+	}
+
+	rows, err := p.db.Pool.Query(ctx, sql, args...)
+	if err != nil {
+		return fmt.Errorf("Profile.db.Pool.Query: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		history := entity.UsernameHistory{}
+		err := rows.Scan(
+			&history.ID, &history.UserID,
+			&history.Username, &history.ChangedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("pgx.Rows.Scan: %w", err)
+		}
+
+		err = fn(history)
+		if err != nil {
+			return fmt.Errorf("fn: %w", err)
+		}
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return fmt.Errorf("pgx.Rows.Err: %w", err)
+	}
+
 	return nil
 }
@@ -9,7 +9,9 @@ import (
 	"github.com/Hidayathamir/go-user/internal/repo/db"
 	"github.com/Hidayathamir/go-user/internal/repo/db/entity"
 	"github.com/Hidayathamir/go-user/pkg/gouser"
+	"github.com/jackc/pgerrcode"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/pashagolub/pgxmock/v3"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -36,9 +38,13 @@ func TestUnitProfileGetProfileByUsername(t *testing.T) {
 		mockpool.ExpectQuery("SELECT").WithArgs("hidayat").
 			WillReturnRows(
 				pgxmock.NewRows(
-					[]string{"id", "username", "password", "created_at", "updated_at"},
+					[]string{
+						"id", "username", "password", "role", "created_at", "updated_at",
+						"status", "suspended_until", "suspension_reason",
+					},
 				).AddRow(
-					int64(441), "hidayat", "dummyhashedpassword", now, now,
+					int64(441), "hidayat", "dummyhashedpassword", "user", now, now,
+					"active", (*time.Time)(nil), "",
 				),
 			)
 
@@ -48,8 +54,11 @@ func TestUnitProfileGetProfileByUsername(t *testing.T) {
 		assert.NotEmpty(t, user)
 		assert.Equal(t, "hidayat", user.Username)
 		assert.Equal(t, "dummyhashedpassword", user.Password)
+		assert.Equal(t, "user", user.Role)
 		assert.Equal(t, now, user.CreatedAt)
 		assert.Equal(t, now, user.UpdatedAt)
+		assert.Equal(t, "active", user.Status)
+		assert.Nil(t, user.SuspendedUntil)
 	})
 	t.Run("QueryRow Scan error should return error", func(t *testing.T) {
 		t.Parallel()
@@ -99,6 +108,73 @@ func TestUnitProfileGetProfileByUsername(t *testing.T) {
 	})
 }
 
+func TestUnitProfileGetProfileByUserID(t *testing.T) {
+	t.Parallel()
+
+	t.Run("get profile by user id success", func(t *testing.T) {
+		t.Parallel()
+
+		mockpool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+
+		p := &Profile{
+			cfg: config.Config{},
+			db: &db.Postgres{
+				Builder: builder,
+				Pool:    mockpool,
+			},
+		}
+
+		now := time.Now()
+		mockpool.ExpectQuery("SELECT").WithArgs(int64(441)).
+			WillReturnRows(
+				pgxmock.NewRows(
+					[]string{
+						"id", "username", "password", "role", "created_at", "updated_at",
+						"status", "suspended_until", "suspension_reason",
+					},
+				).AddRow(
+					int64(441), "hidayat", "dummyhashedpassword", "admin", now, now,
+					"suspended", &now, "spam",
+				),
+			)
+
+		user, err := p.GetProfileByUserID(context.Background(), 441)
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(441), user.ID)
+		assert.Equal(t, "hidayat", user.Username)
+		assert.Equal(t, "admin", user.Role)
+		assert.Equal(t, "suspended", user.Status)
+		require.NotNil(t, user.SuspendedUntil)
+		assert.Equal(t, now, *user.SuspendedUntil)
+		assert.Equal(t, "spam", user.SuspensionReason)
+	})
+	t.Run("QueryRow Scan no row error should return error", func(t *testing.T) {
+		t.Parallel()
+
+		mockpool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+
+		p := &Profile{
+			cfg: config.Config{},
+			db: &db.Postgres{
+				Builder: builder,
+				Pool:    mockpool,
+			},
+		}
+
+		mockpool.ExpectQuery("SELECT").WithArgs(int64(441)).
+			WillReturnError(pgx.ErrNoRows)
+
+		user, err := p.GetProfileByUserID(context.Background(), 441)
+
+		assert.Empty(t, user)
+		require.Error(t, err)
+		require.ErrorIs(t, err, gouser.ErrUnknownUserID)
+	})
+}
+
 func TestUnitProfileUpdateProfileByUserID(t *testing.T) {
 	t.Parallel()
 
@@ -112,19 +188,32 @@ func TestUnitProfileUpdateProfileByUserID(t *testing.T) {
 			cfg: config.Config{},
 			db: &db.Postgres{
 				Builder: builder,
-				Pool:    mockpool,
+				Pool:    db.NewContextPool(mockpool),
 			},
 		}
 
+		mockpool.ExpectBegin()
+		tx, err := mockpool.Begin(context.Background())
+		require.NoError(t, err)
+		ctx := db.ContextWithTx(context.Background(), tx)
+
 		mockpool.ExpectExec("UPDATE").WithArgs("newpassword", int64(776)).
 			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
-
-		err = p.UpdateProfileByUserID(context.Background(), entity.User{
+		mockpool.
+			ExpectExec("INSERT INTO \"outbox_event\"").
+			WithArgs(
+				gouser.EventTypePasswordChanged, int64(776), []byte(`{"user_id":776}`),
+				anyTime{}, anyTime{},
+			).
+			WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+		err = p.UpdateProfileByUserID(ctx, entity.User{
 			ID:       776,
 			Password: "newpassword",
 		})
 
 		require.NoError(t, err)
+		require.NoError(t, mockpool.ExpectationsWereMet())
 	})
 	t.Run("Exec error should return error", func(t *testing.T) {
 		t.Parallel()
@@ -136,14 +225,19 @@ func TestUnitProfileUpdateProfileByUserID(t *testing.T) {
 			cfg: config.Config{},
 			db: &db.Postgres{
 				Builder: builder,
-				Pool:    mockpool,
+				Pool:    db.NewContextPool(mockpool),
 			},
 		}
 
+		mockpool.ExpectBegin()
+		tx, err := mockpool.Begin(context.Background())
+		require.NoError(t, err)
+		ctx := db.ContextWithTx(context.Background(), tx)
+
 		mockpool.ExpectExec("UPDATE").WithArgs("newpassword", int64(776)).
 			WillReturnError(assert.AnError)
 
-		err = p.UpdateProfileByUserID(context.Background(), entity.User{
+		err = p.UpdateProfileByUserID(ctx, entity.User{
 			ID:       776,
 			Password: "newpassword",
 		})
@@ -161,14 +255,19 @@ func TestUnitProfileUpdateProfileByUserID(t *testing.T) {
 			cfg: config.Config{},
 			db: &db.Postgres{
 				Builder: builder,
-				Pool:    mockpool,
+				Pool:    db.NewContextPool(mockpool),
 			},
 		}
 
+		mockpool.ExpectBegin()
+		tx, err := mockpool.Begin(context.Background())
+		require.NoError(t, err)
+		ctx := db.ContextWithTx(context.Background(), tx)
+
 		mockpool.ExpectExec("UPDATE").WithArgs("newpassword", int64(776)).
 			WillReturnResult(pgxmock.NewResult("UPDATE", 0))
 
-		err = p.UpdateProfileByUserID(context.Background(), entity.User{
+		err = p.UpdateProfileByUserID(ctx, entity.User{
 			ID:       776,
 			Password: "newpassword",
 		})
@@ -176,6 +275,44 @@ func TestUnitProfileUpdateProfileByUserID(t *testing.T) {
 		require.Error(t, err)
 		require.ErrorContains(t, err, "RowsAffected == 0")
 	})
+	t.Run("insert outbox event error should return error", func(t *testing.T) {
+		t.Parallel()
+
+		mockpool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+
+		p := &Profile{
+			cfg: config.Config{},
+			db: &db.Postgres{
+				Builder: builder,
+				Pool:    db.NewContextPool(mockpool),
+			},
+		}
+
+		mockpool.ExpectBegin()
+		tx, err := mockpool.Begin(context.Background())
+		require.NoError(t, err)
+		ctx := db.ContextWithTx(context.Background(), tx)
+
+		mockpool.ExpectExec("UPDATE").WithArgs("newpassword", int64(776)).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+		mockpool.
+			ExpectExec("INSERT INTO \"outbox_event\"").
+			WithArgs(
+				gouser.EventTypePasswordChanged, int64(776), []byte(`{"user_id":776}`),
+				anyTime{}, anyTime{},
+			).
+			WillReturnError(assert.AnError)
+
+		err = p.UpdateProfileByUserID(ctx, entity.User{
+			ID:       776,
+			Password: "newpassword",
+		})
+
+		require.Error(t, err)
+		require.ErrorIs(t, err, assert.AnError)
+		require.NoError(t, mockpool.ExpectationsWereMet())
+	})
 	t.Run("ToSql nothing to be update error should return error", func(t *testing.T) {
 		t.Parallel()
 
@@ -186,11 +323,16 @@ func TestUnitProfileUpdateProfileByUserID(t *testing.T) {
 			cfg: config.Config{},
 			db: &db.Postgres{
 				Builder: builder,
-				Pool:    mockpool,
+				Pool:    db.NewContextPool(mockpool),
 			},
 		}
 
-		err = p.UpdateProfileByUserID(context.Background(), entity.User{
+		mockpool.ExpectBegin()
+		tx, err := mockpool.Begin(context.Background())
+		require.NoError(t, err)
+		ctx := db.ContextWithTx(context.Background(), tx)
+
+		err = p.UpdateProfileByUserID(ctx, entity.User{
 			ID:       776,
 			Password: "",
 		})
@@ -198,4 +340,531 @@ func TestUnitProfileUpdateProfileByUserID(t *testing.T) {
 		require.Error(t, err)
 		require.ErrorIs(t, err, gouser.ErrNothingToBeUpdate)
 	})
+	t.Run("ctx without transaction should return error", func(t *testing.T) {
+		t.Parallel()
+
+		mockpool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+
+		p := &Profile{
+			cfg: config.Config{},
+			db: &db.Postgres{
+				Builder: builder,
+				Pool:    db.NewContextPool(mockpool),
+			},
+		}
+
+		err = p.UpdateProfileByUserID(context.Background(), entity.User{ID: 776, Password: "newpassword"})
+
+		require.ErrorIs(t, err, errNotWithinTx)
+		require.NoError(t, mockpool.ExpectationsWereMet())
+	})
+}
+
+var userColumns = []string{
+	"id", "username", "password", "role", "created_at", "updated_at",
+	"status", "suspended_until", "suspension_reason",
+}
+
+func TestUnitProfileListUsers(t *testing.T) {
+	t.Parallel()
+
+	t.Run("list users with filter success", func(t *testing.T) {
+		t.Parallel()
+
+		mockpool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+
+		p := &Profile{
+			cfg: config.Config{},
+			db: &db.Postgres{
+				Builder: builder,
+				Pool:    mockpool,
+			},
+		}
+
+		now := time.Now()
+		mockpool.
+			ExpectQuery(`SELECT .* WHERE \(deleted_at IS NULL AND status = \$1 AND username LIKE \$2 AND created_at >= \$3\) ORDER BY id ASC LIMIT 3`).
+			WithArgs("active", `hid\_a%`, now).
+			WillReturnRows(pgxmock.NewRows(userColumns).
+				AddRow(int64(1), "hid_ayat", "hashed", "user", now, now, "active", (*time.Time)(nil), "").
+				AddRow(int64(2), "hid_ayat2", "hashed", "user", now, now, "active", (*time.Time)(nil), ""),
+			)
+
+		users, err := p.ListUsers(context.Background(), ListUsersFilter{
+			CreatedFrom:    &now,
+			Status:         "active",
+			UsernamePrefix: "hid_a",
+			SortBy:         gouser.UserSortByID,
+			Limit:          3,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, users, 2)
+		assert.Equal(t, int64(1), users[0].ID)
+		assert.Equal(t, int64(2), users[1].ID)
+	})
+	t.Run("list users after cursor sort by created at desc", func(t *testing.T) {
+		t.Parallel()
+
+		mockpool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+
+		p := &Profile{
+			cfg: config.Config{},
+			db: &db.Postgres{
+				Builder: builder,
+				Pool:    mockpool,
+			},
+		}
+
+		now := time.Now()
+		mockpool.
+			ExpectQuery(`SELECT .* WHERE \(deleted_at IS NULL\) AND \(created_at, id\) < \(\$1, \$2\) ORDER BY created_at DESC, id DESC LIMIT 2`).
+			WithArgs(now, int64(9)).
+			WillReturnRows(pgxmock.NewRows(userColumns).
+				AddRow(int64(8), "hidayat", "hashed", "user", now, now, "active", (*time.Time)(nil), ""),
+			)
+
+		users, err := p.ListUsers(context.Background(), ListUsersFilter{
+			SortBy:   gouser.UserSortByCreatedAt,
+			SortDesc: true,
+			After:    &entity.User{ID: 9, CreatedAt: now},
+			Limit:    2,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, users, 1)
+		assert.Equal(t, int64(8), users[0].ID)
+	})
+	t.Run("Query error should return error", func(t *testing.T) {
+		t.Parallel()
+
+		mockpool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+
+		p := &Profile{
+			cfg: config.Config{},
+			db: &db.Postgres{
+				Builder: builder,
+				Pool:    mockpool,
+			},
+		}
+
+		mockpool.ExpectQuery("SELECT").WithArgs().WillReturnError(assert.AnError)
+
+		users, err := p.ListUsers(context.Background(), ListUsersFilter{Limit: 2})
+
+		assert.Nil(t, users)
+		require.Error(t, err)
+		require.ErrorIs(t, err, assert.AnError)
+	})
+}
+
+func TestUnitProfileCountUsers(t *testing.T) {
+	t.Parallel()
+
+	t.Run("count users success", func(t *testing.T) {
+		t.Parallel()
+
+		mockpool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+
+		p := &Profile{
+			cfg: config.Config{},
+			db: &db.Postgres{
+				Builder: builder,
+				Pool:    mockpool,
+			},
+		}
+
+		mockpool.ExpectQuery(`SELECT COUNT\(\*\) FROM "user" WHERE \(deleted_at IS NULL AND status = \$1\)`).
+			WithArgs("suspended").
+			WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(int64(7)))
+
+		count, err := p.CountUsers(context.Background(), ListUsersFilter{
+			Status: "suspended",
+			After:  &entity.User{ID: 9},
+			Limit:  2,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(7), count)
+	})
+}
+
+func TestUnitEscapeLike(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, `100\%\_off\\`, escapeLike(`100%_off\`))
+}
+
+func TestUnitProfileBatchGetProfiles(t *testing.T) {
+	t.Parallel()
+
+	t.Run("batch get profiles success", func(t *testing.T) {
+		t.Parallel()
+
+		mockpool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+
+		p := &Profile{
+			cfg: config.Config{},
+			db: &db.Postgres{
+				Builder: builder,
+				Pool:    mockpool,
+			},
+		}
+
+		now := time.Now()
+		mockpool.
+			ExpectQuery(`SELECT .* WHERE deleted_at IS NULL AND \(id = ANY\(\$1\) OR username = ANY\(\$2\)\)`).
+			WithArgs([]int64{1, 2}, []string{"hidayat"}).
+			WillReturnRows(pgxmock.NewRows(userColumns).
+				AddRow(int64(3), "hidayat", "hashed", "user", now, now, "active", (*time.Time)(nil), "").
+				AddRow(int64(1), "hidayat1", "hashed", "user", now, now, "active", (*time.Time)(nil), ""),
+			)
+
+		users, err := p.BatchGetProfiles(context.Background(), []int64{1, 2}, []string{"hidayat"})
+
+		require.NoError(t, err)
+		require.Len(t, users, 2)
+		assert.Equal(t, int64(3), users[0].ID)
+		assert.Equal(t, int64(1), users[1].ID)
+	})
+	t.Run("nil usernames should be sent as empty array", func(t *testing.T) {
+		t.Parallel()
+
+		mockpool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+
+		p := &Profile{
+			cfg: config.Config{},
+			db: &db.Postgres{
+				Builder: builder,
+				Pool:    mockpool,
+			},
+		}
+
+		mockpool.ExpectQuery("SELECT").WithArgs([]int64{1}, []string{}).
+			WillReturnRows(pgxmock.NewRows(userColumns))
+
+		users, err := p.BatchGetProfiles(context.Background(), []int64{1}, nil)
+
+		require.NoError(t, err)
+		assert.Empty(t, users)
+	})
+	t.Run("Query error should return error", func(t *testing.T) {
+		t.Parallel()
+
+		mockpool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+
+		p := &Profile{
+			cfg: config.Config{},
+			db: &db.Postgres{
+				Builder: builder,
+				Pool:    mockpool,
+			},
+		}
+
+		mockpool.ExpectQuery("SELECT").WithArgs([]int64{1}, []string{}).WillReturnError(assert.AnError)
+
+		users, err := p.BatchGetProfiles(context.Background(), []int64{1}, nil)
+
+		assert.Nil(t, users)
+		require.Error(t, err)
+		require.ErrorIs(t, err, assert.AnError)
+	})
+}
+
+func TestUnitProfileGetProfileByOldUsername(t *testing.T) {
+	t.Parallel()
+
+	t.Run("get profile by old username success", func(t *testing.T) {
+		t.Parallel()
+
+		mockpool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+
+		p := &Profile{
+			cfg: config.Config{},
+			db: &db.Postgres{
+				Builder: builder,
+				Pool:    mockpool,
+			},
+		}
+
+		now := time.Now()
+		changedAfter := now.Add(-time.Hour)
+		mockpool.
+			ExpectQuery(`SELECT .* FROM "username_history" JOIN "user" ON "user".id = "username_history".user_id WHERE .* ORDER BY "username_history".changed_at DESC LIMIT 1`).
+			WithArgs("hidayat", changedAfter).
+			WillReturnRows(pgxmock.NewRows(userColumns).
+				AddRow(int64(3), "hidayat2", "hashed", "user", now, now, "active", (*time.Time)(nil), ""),
+			)
+
+		user, err := p.GetProfileByOldUsername(context.Background(), "hidayat", changedAfter)
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), user.ID)
+		assert.Equal(t, "hidayat2", user.Username)
+	})
+	t.Run("QueryRow Scan no row error should return error unknown username", func(t *testing.T) {
+		t.Parallel()
+
+		mockpool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+
+		p := &Profile{
+			cfg: config.Config{},
+			db: &db.Postgres{
+				Builder: builder,
+				Pool:    mockpool,
+			},
+		}
+
+		changedAfter := time.Now()
+		mockpool.ExpectQuery("SELECT").WithArgs("hidayat", changedAfter).
+			WillReturnError(pgx.ErrNoRows)
+
+		user, err := p.GetProfileByOldUsername(context.Background(), "hidayat", changedAfter)
+
+		assert.Empty(t, user)
+		require.Error(t, err)
+		require.ErrorIs(t, err, gouser.ErrUnknownUsername)
+	})
+}
+
+func TestUnitProfileChangeUsername(t *testing.T) {
+	t.Parallel()
+
+	t.Run("change username success", func(t *testing.T) {
+		t.Parallel()
+
+		mockpool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+
+		p := &Profile{
+			cfg: config.Config{},
+			db: &db.Postgres{
+				Builder: builder,
+				Pool:    db.NewContextPool(mockpool),
+			},
+		}
+
+		now := time.Now()
+		mockpool.ExpectBegin()
+		tx, err := mockpool.Begin(context.Background())
+		require.NoError(t, err)
+		ctx := db.ContextWithTx(context.Background(), tx)
+
+		mockpool.
+			ExpectQuery(`WITH old AS \(SELECT .* FOR UPDATE\), upd AS \(UPDATE "user" .* RETURNING "user".id\) INSERT INTO "username_history" .* RETURNING username`).
+			WithArgs(int64(44), "hidayat2", now, now).
+			WillReturnRows(pgxmock.NewRows([]string{"username"}).AddRow("hidayat"))
+		mockpool.
+			ExpectExec("INSERT INTO \"outbox_event\"").
+			WithArgs(
+				gouser.EventTypeProfileUpdated, int64(44),
+				[]byte(`{"user_id":44,"username":"hidayat2","old_username":"hidayat"}`),
+				anyTime{}, anyTime{},
+			).
+			WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+		err = p.ChangeUsername(ctx, 44, "hidayat2", now)
+
+		require.NoError(t, err)
+		require.NoError(t, mockpool.ExpectationsWereMet())
+	})
+	t.Run("username taken by another user should return error duplicate username", func(t *testing.T) {
+		t.Parallel()
+
+		mockpool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+
+		p := &Profile{
+			cfg: config.Config{},
+			db: &db.Postgres{
+				Builder: builder,
+				Pool:    db.NewContextPool(mockpool),
+			},
+		}
+
+		now := time.Now()
+		mockpool.ExpectBegin()
+		tx, err := mockpool.Begin(context.Background())
+		require.NoError(t, err)
+		ctx := db.ContextWithTx(context.Background(), tx)
+
+		mockpool.
+			ExpectQuery("WITH").WithArgs(int64(44), "hidayat2", now, now).
+			WillReturnError(&pgconn.PgError{
+				Code:           pgerrcode.UniqueViolation,
+				ConstraintName: "user_un",
+			})
+
+		err = p.ChangeUsername(ctx, 44, "hidayat2", now)
+
+		require.Error(t, err)
+		require.ErrorIs(t, err, gouser.ErrDuplicateUsername)
+	})
+	t.Run("unknown user should return error unknown user id", func(t *testing.T) {
+		t.Parallel()
+
+		mockpool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+
+		p := &Profile{
+			cfg: config.Config{},
+			db: &db.Postgres{
+				Builder: builder,
+				Pool:    db.NewContextPool(mockpool),
+			},
+		}
+
+		now := time.Now()
+		mockpool.ExpectBegin()
+		tx, err := mockpool.Begin(context.Background())
+		require.NoError(t, err)
+		ctx := db.ContextWithTx(context.Background(), tx)
+
+		mockpool.
+			ExpectQuery("WITH").WithArgs(int64(44), "hidayat2", now, now).
+			WillReturnRows(pgxmock.NewRows([]string{"username"}))
+
+		err = p.ChangeUsername(ctx, 44, "hidayat2", now)
+
+		require.Error(t, err)
+		require.ErrorIs(t, err, gouser.ErrUnknownUserID)
+	})
+	t.Run("ctx without transaction should return error", func(t *testing.T) {
+		t.Parallel()
+
+		mockpool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+
+		p := &Profile{
+			cfg: config.Config{},
+			db: &db.Postgres{
+				Builder: builder,
+				Pool:    db.NewContextPool(mockpool),
+			},
+		}
+
+		err = p.ChangeUsername(context.Background(), 44, "hidayat2", time.Now())
+
+		require.ErrorIs(t, err, errNotWithinTx)
+		require.NoError(t, mockpool.ExpectationsWereMet())
+	})
+}
+
+func TestUnitProfileGetLastUsernameChangedAt(t *testing.T) {
+	t.Parallel()
+
+	t.Run("user changed username should return last change time", func(t *testing.T) {
+		t.Parallel()
+
+		mockpool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+
+		p := &Profile{
+			cfg: config.Config{},
+			db: &db.Postgres{
+				Builder: builder,
+				Pool:    mockpool,
+			},
+		}
+
+		now := time.Now()
+		mockpool.ExpectQuery(`SELECT MAX\(changed_at\) FROM "username_history"`).WithArgs(int64(44)).
+			WillReturnRows(pgxmock.NewRows([]string{"max"}).AddRow(&now))
+
+		changedAt, err := p.GetLastUsernameChangedAt(context.Background(), 44)
+
+		require.NoError(t, err)
+		require.NotNil(t, changedAt)
+		assert.Equal(t, now, *changedAt)
+	})
+	t.Run("user never changed username should return nil", func(t *testing.T) {
+		t.Parallel()
+
+		mockpool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+
+		p := &Profile{
+			cfg: config.Config{},
+			db: &db.Postgres{
+				Builder: builder,
+				Pool:    mockpool,
+			},
+		}
+
+		mockpool.ExpectQuery("SELECT").WithArgs(int64(44)).
+			WillReturnRows(pgxmock.NewRows([]string{"max"}).AddRow((*time.Time)(nil)))
+
+		changedAt, err := p.GetLastUsernameChangedAt(context.Background(), 44)
+
+		require.NoError(t, err)
+		assert.Nil(t, changedAt)
+	})
+}
+
+func TestUnitProfileIterateUsernameHistoryByUserID(t *testing.T) {
+	t.Parallel()
+
+	t.Run("iterate username history success", func(t *testing.T) {
+		t.Parallel()
+
+		mockpool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+
+		p := &Profile{
+			cfg: config.Config{},
+			db: &db.Postgres{
+				Builder: builder,
+				Pool:    mockpool,
+			},
+		}
+
+		now := time.Now()
+		mockpool.ExpectQuery("SELECT .* FROM \"username_history\" WHERE user_id = \\$1 ORDER BY id").WithArgs(int64(12)).
+			WillReturnRows(pgxmock.NewRows([]string{"id", "user_id", "username", "changed_at"}).
+				AddRow(int64(1), int64(12), "hidayat", now).
+				AddRow(int64(2), int64(12), "hidayat2", now),
+			)
+
+		usernames := []string{}
+		err = p.IterateUsernameHistoryByUserID(context.Background(), 12, func(history entity.UsernameHistory) error {
+			usernames = append(usernames, history.Username)
+			return nil
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"hidayat", "hidayat2"}, usernames)
+	})
+	t.Run("Query error should return error", func(t *testing.T) {
+		t.Parallel()
+
+		mockpool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+
+		p := &Profile{
+			cfg: config.Config{},
+			db: &db.Postgres{
+				Builder: builder,
+				Pool:    mockpool,
+			},
+		}
+
+		mockpool.ExpectQuery("SELECT").WithArgs(int64(12)).WillReturnError(assert.AnError)
+
+		err = p.IterateUsernameHistoryByUserID(context.Background(), 12, func(entity.UsernameHistory) error {
+			return nil
+		})
+
+		require.Error(t, err)
+		require.ErrorIs(t, err, assert.AnError)
+	})
 }
@@ -1,2 +1,8 @@
 // Package repo contains the data access layer.
 package repo
+
+import "errors"
+
+// ErrClaimLost occurs when worker update a row it claimed, but the claim
+// expired and the row is claimed by other worker or already done.
+var ErrClaimLost = errors.New("claim lost")
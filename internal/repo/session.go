@@ -0,0 +1,301 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Hidayathamir/go-user/config"
+	"github.com/Hidayathamir/go-user/internal/pkg/query"
+	"github.com/Hidayathamir/go-user/internal/repo/db"
+	"github.com/Hidayathamir/go-user/internal/repo/db/entity"
+	"github.com/Hidayathamir/go-user/internal/repo/db/entity/table"
+	"github.com/Hidayathamir/go-user/pkg/gouser"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+)
+
+//go:generate mockgen -source=session.go -destination=mockrepo/session.go -package=mockrepo
+
+// ISession contains abstraction of repo session.
+type ISession interface {
+	// CreateSession create new session.
+	CreateSession(ctx context.Context, session entity.Session) (int64, error)
+	// GetSessionByID return session by session id.
+	GetSessionByID(ctx context.Context, sessionID int64) (entity.Session, error)
+	// GetSessionByJTI return session by JWT ID.
+	GetSessionByJTI(ctx context.Context, jti string) (entity.Session, error)
+	// GetActiveSessionsByUserID return not revoked and not expired sessions
+	// by user id.
+	GetActiveSessionsByUserID(ctx context.Context, userID int64) ([]entity.Session, error)
+	// UpdateSessionLastSeenAt update session last seen time.
+	UpdateSessionLastSeenAt(ctx context.Context, sessionID int64, lastSeenAt time.Time) error
+	// RevokeSessionByID revoke session by session id.
+	RevokeSessionByID(ctx context.Context, sessionID int64) error
+	// RevokeSessionsByUserID revoke all sessions of the user.
+	RevokeSessionsByUserID(ctx context.Context, userID int64) error
+	// IterateSessionsByUserID call fn for every session of the user including
+	// revoked and expired, ordered by id. Sessions are not buffered.
+	IterateSessionsByUserID(ctx context.Context, userID int64, fn func(entity.Session) error) error
+}
+
+// Session implement ISession.
+type Session struct {
+	cfg config.Config
+	db  *db.Postgres
+}
+
+var _ ISession = &Session{}
+
+// NewSession return *Session which implement repo.ISession.
+func NewSession(cfg config.Config, db *db.Postgres) *Session {
+	return &Session{
+		cfg: cfg,
+		db:  db,
+	}
+}
+
+// CreateSession create new session.
+func (s *Session) CreateSession(ctx context.Context, session entity.Session) (int64, error) {
+	sql, args, err := s.db.Builder.
+		Insert(table.Session.String()).
+		Columns(
+			table.Session.UserID, table.Session.JTI,
+			table.Session.UserAgent, table.Session.IP,
+			table.Session.CreatedAt, table.Session.LastSeenAt, table.Session.ExpiredAt,
+		).
+		Values(
+			session.UserID, session.JTI,
+			session.UserAgent, session.IP,
+			session.CreatedAt, session.LastSeenAt, session.ExpiredAt,
+		).
+		Suffix(query.Returning(table.Session.ID)).
+		ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("Session.db.Builder.ToSql: %w", err)
+	}
+
+	var sessionID int64
+	err = s.db.Pool.QueryRow(ctx, sql, args...).Scan(&sessionID)
+	if err != nil {
+		return 0, fmt.Errorf("Session.db.Pool.QueryRow.Scan: %w", err)
+	}
+
+	return sessionID, nil
+}
+
+// GetSessionByID return session by session id.
+func (s *Session) GetSessionByID(ctx context.Context, sessionID int64) (entity.Session, error) {
+	return s.getSession(ctx, sq.Eq{table.Session.ID: sessionID})
+}
+
+// GetSessionByJTI return session by JWT ID.
+func (s *Session) GetSessionByJTI(ctx context.Context, jti string) (entity.Session, error) {
+	return s.getSession(ctx, sq.Eq{table.Session.JTI: jti})
+}
+
+func (s *Session) getSession(ctx context.Context, where sq.Eq) (entity.Session, error) {
+	sql, args, err := s.db.Builder.
+		Select(
+			table.Session.ID, table.Session.UserID, table.Session.JTI,
+			table.Session.UserAgent, table.Session.IP,
+			table.Session.CreatedAt, table.Session.LastSeenAt,
+			table.Session.ExpiredAt, table.Session.RevokedAt,
+		).
+		From(table.Session.String()).
+		Where(where).
+		ToSql()
+	if err != nil {
+		return entity.Session{}, fmt.Errorf("Session.db.Builder.ToSql: %w", err)
+	}
+
+	session := entity.Session{}
+	err = s.db.Pool.QueryRow(ctx, sql, args...).Scan(
+		&session.ID, &session.UserID, &session.JTI,
+		&session.UserAgent, &session.IP,
+		&session.CreatedAt, &session.LastSeenAt,
+		&session.ExpiredAt, &session.RevokedAt,
+	)
+	if err != nil {
+		err := fmt.Errorf("Session.db.Pool.QueryRow: %w", err)
+		if errors.Is(err, pgx.ErrNoRows) {
+			err = fmt.Errorf("%w: %w", gouser.ErrUnknownSession, err)
+		}
+		return entity.Session{}, err
+	}
+
+	return session, nil
+}
+
+// GetActiveSessionsByUserID return not revoked and not expired sessions by
+// user id.
+func (s *Session) GetActiveSessionsByUserID(ctx context.Context, userID int64) ([]entity.Session, error) {
+	sql, args, err := s.db.Builder.
+		Select(
+			table.Session.ID, table.Session.UserID, table.Session.JTI,
+			table.Session.UserAgent, table.Session.IP,
+			table.Session.CreatedAt, table.Session.LastSeenAt,
+			table.Session.ExpiredAt, table.Session.RevokedAt,
+		).
+		From(table.Session.String()).
+		Where(sq.Eq{
+			table.Session.UserID:    userID,
+			table.Session.RevokedAt: nil,
+		}).
+		Where(sq.Gt{
+			table.Session.ExpiredAt: time.Now(),
+		}).
+		OrderBy(table.Session.LastSeenAt + " DESC").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("Session.db.Builder.ToSql: %w", err)
+	}
+
+	rows, err := s.db.Pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("Session.db.Pool.Query: %w", err)
+	}
+	defer rows.Close()
+
+	sessions := []entity.Session{}
+	for rows.Next() {
+		session := entity.Session{}
+		err := rows.Scan(
+			&session.ID, &session.UserID, &session.JTI,
+			&session.UserAgent, &session.IP,
+			&session.CreatedAt, &session.LastSeenAt,
+			&session.ExpiredAt, &session.RevokedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("pgx.Rows.Scan: %w", err)
+		}
+		sessions = append(sessions, session)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("pgx.Rows.Err: %w", err)
+	}
+
+	return sessions, nil
+}
+
+// UpdateSessionLastSeenAt update session last seen time.
+func (s *Session) UpdateSessionLastSeenAt(ctx context.Context, sessionID int64, lastSeenAt time.Time) error {
+	sql, args, err := s.db.Builder.
+		Update(table.Session.String()).
+		Set(table.Session.LastSeenAt, lastSeenAt).
+		Where(sq.Eq{
+			table.Session.ID: sessionID,
+		}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("Session.db.Builder.ToSql: %w", err)
+	}
+
+	_, err = s.db.Pool.Exec(ctx, sql, args...)
+	if err != nil {
+		return fmt.Errorf("Session.db.Pool.Exec: %w", err)
+	}
+
+	return nil
+}
+
+// RevokeSessionByID revoke session by session id. Revoking an already revoked
+// session is a no-op.
+func (s *Session) RevokeSessionByID(ctx context.Context, sessionID int64) error {
+	sql, args, err := s.db.Builder.
+		Update(table.Session.String()).
+		Set(table.Session.RevokedAt, time.Now()).
+		Where(sq.Eq{
+			table.Session.ID:        sessionID,
+			table.Session.RevokedAt: nil,
+		}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("Session.db.Builder.ToSql: %w", err)
+	}
+
+	_, err = s.db.Pool.Exec(ctx, sql, args...)
+	if err != nil {
+		return fmt.Errorf("Session.db.Pool.Exec: %w", err)
+	}
+
+	return nil
+}
+
+// RevokeSessionsByUserID revoke all not revoked sessions of the user.
+func (s *Session) RevokeSessionsByUserID(ctx context.Context, userID int64) error {
+	sql, args, err := s.db.Builder.
+		Update(table.Session.String()).
+		Set(table.Session.RevokedAt, time.Now()).
+		Where(sq.Eq{
+			table.Session.UserID:    userID,
+			table.Session.RevokedAt: nil,
+		}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("Session.db.Builder.ToSql: %w", err)
+	}
+
+	_, err = s.db.Pool.Exec(ctx, sql, args...)
+	if err != nil {
+		return fmt.Errorf("Session.db.Pool.Exec: %w", err)
+	}
+
+	return nil
+}
+
+// IterateSessionsByUserID call fn for every session of the user including
+// revoked and expired, ordered by id. Sessions are not buffered, iteration
+// stops at the first error returned by fn.
+func (s *Session) IterateSessionsByUserID(ctx context.Context, userID int64, fn func(entity.Session) error) error {
+	sql, args, err := s.db.Builder.
+		Select(
+			table.Session.ID, table.Session.UserID, table.Session.JTI,
+			table.Session.UserAgent, table.Session.IP,
+			table.Session.CreatedAt, table.Session.LastSeenAt,
+			table.Session.ExpiredAt, table.Session.RevokedAt,
+		).
+		From(table.Session.String()).
+		Where(sq.Eq{
+			table.Session.UserID: userID,
+		}).
+		OrderBy(table.Session.ID).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("Session.db.Builder.ToSql: %w", err)
+	}
+
+	rows, err := s.db.Pool.Query(ctx, sql, args...)
+	if err != nil {
+		return fmt.Errorf("Session.db.Pool.Query: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		session := entity.Session{}
+		err := rows.Scan(
+			&session.ID, &session.UserID, &session.JTI,
+			&session.UserAgent, &session.IP,
+			&session.CreatedAt, &session.LastSeenAt,
+			&session.ExpiredAt, &session.RevokedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("pgx.Rows.Scan: %w", err)
+		}
+
+		err = fn(session)
+		if err != nil {
+			return fmt.Errorf("fn: %w", err)
+		}
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return fmt.Errorf("pgx.Rows.Err: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,382 @@
+package repo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Hidayathamir/go-user/config"
+	"github.com/Hidayathamir/go-user/internal/repo/db"
+	"github.com/Hidayathamir/go-user/internal/repo/db/entity"
+	"github.com/Hidayathamir/go-user/pkg/gouser"
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var sessionColumns = []string{
+	"id", "user_id", "jti", "user_agent", "ip",
+	"created_at", "last_seen_at", "expired_at", "revoked_at",
+}
+
+func TestUnitSessionCreateSession(t *testing.T) {
+	t.Parallel()
+
+	t.Run("create session success", func(t *testing.T) {
+		t.Parallel()
+
+		mockpool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+
+		s := &Session{
+			cfg: config.Config{},
+			db: &db.Postgres{
+				Builder: builder,
+				Pool:    mockpool,
+			},
+		}
+
+		now := time.Now()
+		mockpool.
+			ExpectQuery("INSERT").WithArgs(int64(12), "myjti", "Mozilla/5.0", "10.0.0.1", now, now, now).
+			WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(int64(5)))
+
+		sessionID, err := s.CreateSession(context.Background(), entity.Session{
+			UserID:     12,
+			JTI:        "myjti",
+			UserAgent:  "Mozilla/5.0",
+			IP:         "10.0.0.1",
+			CreatedAt:  now,
+			LastSeenAt: now,
+			ExpiredAt:  now,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(5), sessionID)
+	})
+	t.Run("QueryRow Scan error should return error", func(t *testing.T) {
+		t.Parallel()
+
+		mockpool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+
+		s := &Session{
+			cfg: config.Config{},
+			db: &db.Postgres{
+				Builder: builder,
+				Pool:    mockpool,
+			},
+		}
+
+		now := time.Now()
+		mockpool.
+			ExpectQuery("INSERT").WithArgs(int64(12), "myjti", "", "", now, now, now).
+			WillReturnError(assert.AnError)
+
+		sessionID, err := s.CreateSession(context.Background(), entity.Session{
+			UserID:     12,
+			JTI:        "myjti",
+			CreatedAt:  now,
+			LastSeenAt: now,
+			ExpiredAt:  now,
+		})
+
+		require.Error(t, err)
+		require.ErrorIs(t, err, assert.AnError)
+		assert.Equal(t, int64(0), sessionID)
+	})
+}
+
+func TestUnitSessionGetSessionByJTI(t *testing.T) {
+	t.Parallel()
+
+	t.Run("get session by jti success", func(t *testing.T) {
+		t.Parallel()
+
+		mockpool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+
+		s := &Session{
+			cfg: config.Config{},
+			db: &db.Postgres{
+				Builder: builder,
+				Pool:    mockpool,
+			},
+		}
+
+		now := time.Now()
+		mockpool.ExpectQuery("SELECT").WithArgs("myjti").
+			WillReturnRows(pgxmock.NewRows(sessionColumns).AddRow(
+				int64(5), int64(12), "myjti", "Mozilla/5.0", "10.0.0.1", now, now, now, nil,
+			))
+
+		session, err := s.GetSessionByJTI(context.Background(), "myjti")
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(5), session.ID)
+		assert.Equal(t, int64(12), session.UserID)
+		assert.Equal(t, "Mozilla/5.0", session.UserAgent)
+		assert.Nil(t, session.RevokedAt)
+	})
+	t.Run("QueryRow Scan no row error should return error", func(t *testing.T) {
+		t.Parallel()
+
+		mockpool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+
+		s := &Session{
+			cfg: config.Config{},
+			db: &db.Postgres{
+				Builder: builder,
+				Pool:    mockpool,
+			},
+		}
+
+		mockpool.ExpectQuery("SELECT").WithArgs("myjti").WillReturnError(pgx.ErrNoRows)
+
+		session, err := s.GetSessionByJTI(context.Background(), "myjti")
+
+		assert.Empty(t, session)
+		require.Error(t, err)
+		require.ErrorIs(t, err, gouser.ErrUnknownSession)
+	})
+}
+
+func TestUnitSessionGetActiveSessionsByUserID(t *testing.T) {
+	t.Parallel()
+
+	t.Run("get active sessions success", func(t *testing.T) {
+		t.Parallel()
+
+		mockpool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+
+		s := &Session{
+			cfg: config.Config{},
+			db: &db.Postgres{
+				Builder: builder,
+				Pool:    mockpool,
+			},
+		}
+
+		now := time.Now()
+		mockpool.ExpectQuery("SELECT .* WHERE .*revoked_at IS NULL").WithArgs(int64(12), anyTime{}).
+			WillReturnRows(pgxmock.NewRows(sessionColumns).
+				AddRow(int64(5), int64(12), "jti5", "Mozilla/5.0", "10.0.0.1", now, now, now, nil).
+				AddRow(int64(6), int64(12), "jti6", "curl/8.0", "10.0.0.2", now, now, now, nil),
+			)
+
+		sessions, err := s.GetActiveSessionsByUserID(context.Background(), 12)
+
+		require.NoError(t, err)
+		require.Len(t, sessions, 2)
+		assert.Equal(t, "jti5", sessions[0].JTI)
+		assert.Equal(t, "jti6", sessions[1].JTI)
+	})
+	t.Run("Query error should return error", func(t *testing.T) {
+		t.Parallel()
+
+		mockpool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+
+		s := &Session{
+			cfg: config.Config{},
+			db: &db.Postgres{
+				Builder: builder,
+				Pool:    mockpool,
+			},
+		}
+
+		mockpool.ExpectQuery("SELECT").WithArgs(int64(12), anyTime{}).WillReturnError(assert.AnError)
+
+		sessions, err := s.GetActiveSessionsByUserID(context.Background(), 12)
+
+		assert.Nil(t, sessions)
+		require.Error(t, err)
+		require.ErrorIs(t, err, assert.AnError)
+	})
+}
+
+func TestUnitSessionRevokeSessionByID(t *testing.T) {
+	t.Parallel()
+
+	t.Run("revoke session success", func(t *testing.T) {
+		t.Parallel()
+
+		mockpool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+
+		s := &Session{
+			cfg: config.Config{},
+			db: &db.Postgres{
+				Builder: builder,
+				Pool:    mockpool,
+			},
+		}
+
+		mockpool.ExpectExec("UPDATE").WithArgs(anyTime{}, int64(5)).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+		err = s.RevokeSessionByID(context.Background(), 5)
+
+		require.NoError(t, err)
+	})
+	t.Run("Exec error should return error", func(t *testing.T) {
+		t.Parallel()
+
+		mockpool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+
+		s := &Session{
+			cfg: config.Config{},
+			db: &db.Postgres{
+				Builder: builder,
+				Pool:    mockpool,
+			},
+		}
+
+		mockpool.ExpectExec("UPDATE").WithArgs(anyTime{}, int64(5)).WillReturnError(assert.AnError)
+
+		err = s.RevokeSessionByID(context.Background(), 5)
+
+		require.Error(t, err)
+		require.ErrorContains(t, err, "Session.db.Pool.Exec")
+	})
+}
+
+func TestUnitSessionRevokeSessionsByUserID(t *testing.T) {
+	t.Parallel()
+
+	t.Run("revoke sessions success", func(t *testing.T) {
+		t.Parallel()
+
+		mockpool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+
+		s := &Session{
+			cfg: config.Config{},
+			db: &db.Postgres{
+				Builder: builder,
+				Pool:    mockpool,
+			},
+		}
+
+		mockpool.ExpectExec("UPDATE").WithArgs(anyTime{}, int64(44)).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 3))
+
+		err = s.RevokeSessionsByUserID(context.Background(), 44)
+
+		require.NoError(t, err)
+	})
+	t.Run("Exec error should return error", func(t *testing.T) {
+		t.Parallel()
+
+		mockpool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+
+		s := &Session{
+			cfg: config.Config{},
+			db: &db.Postgres{
+				Builder: builder,
+				Pool:    mockpool,
+			},
+		}
+
+		mockpool.ExpectExec("UPDATE").WithArgs(anyTime{}, int64(44)).WillReturnError(assert.AnError)
+
+		err = s.RevokeSessionsByUserID(context.Background(), 44)
+
+		require.Error(t, err)
+		require.ErrorIs(t, err, assert.AnError)
+	})
+}
+
+func TestUnitSessionIterateSessionsByUserID(t *testing.T) {
+	t.Parallel()
+
+	t.Run("iterate sessions success", func(t *testing.T) {
+		t.Parallel()
+
+		mockpool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+
+		s := &Session{
+			cfg: config.Config{},
+			db: &db.Postgres{
+				Builder: builder,
+				Pool:    mockpool,
+			},
+		}
+
+		now := time.Now()
+		mockpool.ExpectQuery("SELECT .* ORDER BY id").WithArgs(int64(12)).
+			WillReturnRows(pgxmock.NewRows(sessionColumns).
+				AddRow(int64(5), int64(12), "jti5", "Mozilla/5.0", "10.0.0.1", now, now, now, &now).
+				AddRow(int64(6), int64(12), "jti6", "curl/8.0", "10.0.0.2", now, now, now, nil),
+			)
+
+		jtis := []string{}
+		err = s.IterateSessionsByUserID(context.Background(), 12, func(session entity.Session) error {
+			jtis = append(jtis, session.JTI)
+			return nil
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"jti5", "jti6"}, jtis)
+	})
+	t.Run("fn error should stop iteration and return error", func(t *testing.T) {
+		t.Parallel()
+
+		mockpool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+
+		s := &Session{
+			cfg: config.Config{},
+			db: &db.Postgres{
+				Builder: builder,
+				Pool:    mockpool,
+			},
+		}
+
+		now := time.Now()
+		mockpool.ExpectQuery("SELECT").WithArgs(int64(12)).
+			WillReturnRows(pgxmock.NewRows(sessionColumns).
+				AddRow(int64(5), int64(12), "jti5", "Mozilla/5.0", "10.0.0.1", now, now, now, nil).
+				AddRow(int64(6), int64(12), "jti6", "curl/8.0", "10.0.0.2", now, now, now, nil),
+			)
+
+		callCount := 0
+		err = s.IterateSessionsByUserID(context.Background(), 12, func(entity.Session) error {
+			callCount++
+			return assert.AnError
+		})
+
+		require.Error(t, err)
+		require.ErrorIs(t, err, assert.AnError)
+		assert.Equal(t, 1, callCount)
+	})
+	t.Run("Query error should return error", func(t *testing.T) {
+		t.Parallel()
+
+		mockpool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+
+		s := &Session{
+			cfg: config.Config{},
+			db: &db.Postgres{
+				Builder: builder,
+				Pool:    mockpool,
+			},
+		}
+
+		mockpool.ExpectQuery("SELECT").WithArgs(int64(12)).WillReturnError(assert.AnError)
+
+		err = s.IterateSessionsByUserID(context.Background(), 12, func(entity.Session) error {
+			return nil
+		})
+
+		require.Error(t, err)
+		require.ErrorIs(t, err, assert.AnError)
+	})
+}
@@ -0,0 +1,143 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Hidayathamir/go-user/config"
+	"github.com/Hidayathamir/go-user/internal/pkg/logger"
+	"github.com/Hidayathamir/go-user/internal/repo/db"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+//go:generate mockgen -source=transactor.go -destination=mockrepo/transactor.go -package=mockrepo
+
+// txMaxAttempt is how many times a transaction is run when it keeps failing
+// with serialization failure or deadlock.
+const txMaxAttempt = 3
+
+// errNotWithinTx occurs when repo call which must be atomic with other writes
+// is not bound to transaction.
+var errNotWithinTx = errors.New("repo call should be within transaction, use ITransactor.WithinTx")
+
+// checkWithinTx return errNotWithinTx when ctx is not bound to transaction.
+func checkWithinTx(ctx context.Context) error {
+	if _, ok := db.TxFromContext(ctx); !ok {
+		return errNotWithinTx
+	}
+	return nil
+}
+
+// ITransactor contains abstraction of unit of work, it run several repo calls
+// in one transaction.
+type ITransactor interface {
+	// WithinTx run fn in a transaction. Repo called with the ctx given to fn
+	// use the transaction. It commits when fn return nil, else rolls back.
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+	// WithinTxOptions is WithinTx with transaction options, e.g isolation
+	// level.
+	WithinTxOptions(ctx context.Context, txOptions pgx.TxOptions, fn func(ctx context.Context) error) error
+}
+
+// Transactor implement ITransactor.
+type Transactor struct {
+	cfg config.Config
+	db  *db.Postgres
+}
+
+var _ ITransactor = &Transactor{}
+
+// NewTransactor return *Transactor which implement repo.ITransactor.
+func NewTransactor(cfg config.Config, db *db.Postgres) *Transactor {
+	return &Transactor{
+		cfg: cfg,
+		db:  db,
+	}
+}
+
+// WithinTx run fn in a transaction. Repo called with the ctx given to fn use
+// the transaction. It commits when fn return nil, else rolls back.
+//
+// When ctx is already in a transaction fn runs in a savepoint, so error of fn
+// only rolls back what fn did. The outermost transaction is retried as a
+// whole on serialization failure or deadlock, fn must be safe to run again.
+func (t *Transactor) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return t.WithinTxOptions(ctx, pgx.TxOptions{}, fn)
+}
+
+// WithinTxOptions is WithinTx with transaction options, e.g isolation level.
+// txOptions is ignored for savepoint.
+func (t *Transactor) WithinTxOptions(ctx context.Context, txOptions pgx.TxOptions, fn func(ctx context.Context) error) error {
+	if tx, ok := db.TxFromContext(ctx); ok {
+		err := t.run(ctx, tx.Begin, fn)
+		if err != nil {
+			return fmt.Errorf("Transactor.run savepoint: %w", err)
+		}
+		return nil
+	}
+
+	begin := func(ctx context.Context) (pgx.Tx, error) {
+		return t.db.Pool.BeginTx(ctx, txOptions)
+	}
+
+	var err error
+	for attempt := 1; attempt <= txMaxAttempt; attempt++ {
+		err = t.run(ctx, begin, fn)
+		if err == nil {
+			return nil
+		}
+		if !isRetryableTxError(err) {
+			break
+		}
+
+		logger.FromContext(ctx).
+			WithField("attempt count", attempt).
+			Warnf("retry transaction: %v", err)
+	}
+
+	return fmt.Errorf("Transactor.run: %w", err)
+}
+
+// run begin a transaction, run fn within it then commit or roll back.
+func (t *Transactor) run(ctx context.Context, begin func(ctx context.Context) (pgx.Tx, error), fn func(ctx context.Context) error) (err error) {
+	tx, err := begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin: %w", err)
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback(ctx)
+			panic(p)
+		}
+	}()
+
+	err = fn(db.ContextWithTx(ctx, tx))
+	if err != nil {
+		errRollback := tx.Rollback(ctx)
+		if errRollback != nil {
+			return errors.Join(fmt.Errorf("fn: %w", err), fmt.Errorf("pgx.Tx.Rollback: %w", errRollback))
+		}
+		return fmt.Errorf("fn: %w", err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return fmt.Errorf("pgx.Tx.Commit: %w", err)
+	}
+
+	return nil
+}
+
+// isRetryableTxError return true if the transaction failed because of
+// concurrent transaction and may succeed when run again.
+func isRetryableTxError(err error) bool {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return false
+	}
+	return pgErr.Code == pgerrcode.SerializationFailure || pgErr.Code == pgerrcode.DeadlockDetected
+}
@@ -0,0 +1,311 @@
+package repo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Hidayathamir/go-user/config"
+	"github.com/Hidayathamir/go-user/internal/repo/db"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/pashagolub/pgxmock/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitTransactorWithinTx(t *testing.T) {
+	t.Parallel()
+
+	t.Run("fn success should commit", func(t *testing.T) {
+		t.Parallel()
+
+		mockpool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+
+		pg := &db.Postgres{
+			Builder: builder,
+			Pool:    db.NewContextPool(mockpool),
+		}
+		tr := &Transactor{cfg: config.Config{}, db: pg}
+		s := &Session{cfg: config.Config{}, db: pg}
+
+		mockpool.ExpectBegin()
+		mockpool.
+			ExpectExec("UPDATE").WithArgs(anyTime{}, int64(44)).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+		mockpool.
+			ExpectExec("UPDATE").WithArgs(anyTime{}, int64(45)).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+		mockpool.ExpectCommit()
+
+		err = tr.WithinTx(context.Background(), func(ctx context.Context) error {
+			_, ok := db.TxFromContext(ctx)
+			assert.True(t, ok)
+
+			err := s.RevokeSessionsByUserID(ctx, 44)
+			if err != nil {
+				return err
+			}
+			return s.RevokeSessionsByUserID(ctx, 45)
+		})
+
+		require.NoError(t, err)
+		require.NoError(t, mockpool.ExpectationsWereMet())
+	})
+	t.Run("fn error should roll back", func(t *testing.T) {
+		t.Parallel()
+
+		mockpool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+
+		tr := &Transactor{
+			cfg: config.Config{},
+			db: &db.Postgres{
+				Builder: builder,
+				Pool:    db.NewContextPool(mockpool),
+			},
+		}
+
+		mockpool.ExpectBegin()
+		mockpool.ExpectRollback()
+
+		err = tr.WithinTx(context.Background(), func(ctx context.Context) error {
+			return assert.AnError
+		})
+
+		require.Error(t, err)
+		require.ErrorIs(t, err, assert.AnError)
+		require.NoError(t, mockpool.ExpectationsWereMet())
+	})
+	t.Run("begin error should return error without calling fn", func(t *testing.T) {
+		t.Parallel()
+
+		mockpool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+
+		tr := &Transactor{
+			cfg: config.Config{},
+			db: &db.Postgres{
+				Builder: builder,
+				Pool:    db.NewContextPool(mockpool),
+			},
+		}
+
+		mockpool.ExpectBegin().WillReturnError(assert.AnError)
+
+		isCalled := false
+		err = tr.WithinTx(context.Background(), func(ctx context.Context) error {
+			isCalled = true
+			return nil
+		})
+
+		require.Error(t, err)
+		require.ErrorIs(t, err, assert.AnError)
+		assert.False(t, isCalled)
+	})
+	t.Run("fn panic should roll back and panic again", func(t *testing.T) {
+		t.Parallel()
+
+		mockpool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+
+		tr := &Transactor{
+			cfg: config.Config{},
+			db: &db.Postgres{
+				Builder: builder,
+				Pool:    db.NewContextPool(mockpool),
+			},
+		}
+
+		mockpool.ExpectBegin()
+		mockpool.ExpectRollback()
+
+		assert.PanicsWithValue(t, "boom", func() {
+			_ = tr.WithinTx(context.Background(), func(ctx context.Context) error {
+				panic("boom")
+			})
+		})
+		require.NoError(t, mockpool.ExpectationsWereMet())
+	})
+	t.Run("nested fn should run in savepoint", func(t *testing.T) {
+		t.Parallel()
+
+		mockpool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+
+		tr := &Transactor{
+			cfg: config.Config{},
+			db: &db.Postgres{
+				Builder: builder,
+				Pool:    db.NewContextPool(mockpool),
+			},
+		}
+
+		// pgxmock tx Begin is the savepoint of pgx tx.
+		mockpool.ExpectBegin()
+		mockpool.ExpectBegin()
+		mockpool.ExpectRollback()
+		mockpool.ExpectCommit()
+
+		err = tr.WithinTx(context.Background(), func(ctx context.Context) error {
+			errNested := tr.WithinTx(ctx, func(ctx context.Context) error {
+				return assert.AnError
+			})
+			assert.ErrorIs(t, errNested, assert.AnError)
+
+			// outer transaction keeps going after savepoint is rolled back.
+			return nil
+		})
+
+		require.NoError(t, err)
+		require.NoError(t, mockpool.ExpectationsWereMet())
+	})
+	t.Run("serialization failure should retry the whole transaction", func(t *testing.T) {
+		t.Parallel()
+
+		mockpool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+
+		tr := &Transactor{
+			cfg: config.Config{},
+			db: &db.Postgres{
+				Builder: builder,
+				Pool:    db.NewContextPool(mockpool),
+			},
+		}
+
+		txOptions := pgx.TxOptions{IsoLevel: pgx.Serializable}
+		mockpool.ExpectBeginTx(txOptions)
+		mockpool.ExpectCommit().WillReturnError(&pgconn.PgError{Code: pgerrcode.SerializationFailure})
+		mockpool.ExpectBeginTx(txOptions)
+		mockpool.ExpectCommit()
+
+		callCount := 0
+		err = tr.WithinTxOptions(context.Background(), txOptions, func(ctx context.Context) error {
+			callCount++
+			return nil
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, 2, callCount)
+		require.NoError(t, mockpool.ExpectationsWereMet())
+	})
+	t.Run("deadlock on every attempt should return error after max attempt", func(t *testing.T) {
+		t.Parallel()
+
+		mockpool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+
+		tr := &Transactor{
+			cfg: config.Config{},
+			db: &db.Postgres{
+				Builder: builder,
+				Pool:    db.NewContextPool(mockpool),
+			},
+		}
+
+		for i := 0; i < txMaxAttempt; i++ {
+			mockpool.ExpectBegin()
+			mockpool.ExpectRollback()
+		}
+
+		callCount := 0
+		err = tr.WithinTx(context.Background(), func(ctx context.Context) error {
+			callCount++
+			return &pgconn.PgError{Code: pgerrcode.DeadlockDetected}
+		})
+
+		require.Error(t, err)
+		assert.True(t, isRetryableTxError(err))
+		assert.Equal(t, txMaxAttempt, callCount)
+		require.NoError(t, mockpool.ExpectationsWereMet())
+	})
+	t.Run("non retryable error should not retry", func(t *testing.T) {
+		t.Parallel()
+
+		mockpool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+
+		tr := &Transactor{
+			cfg: config.Config{},
+			db: &db.Postgres{
+				Builder: builder,
+				Pool:    db.NewContextPool(mockpool),
+			},
+		}
+
+		mockpool.ExpectBegin()
+		mockpool.ExpectRollback()
+
+		callCount := 0
+		err = tr.WithinTx(context.Background(), func(ctx context.Context) error {
+			callCount++
+			return &pgconn.PgError{Code: pgerrcode.UniqueViolation}
+		})
+
+		require.Error(t, err)
+		assert.Equal(t, 1, callCount)
+		require.NoError(t, mockpool.ExpectationsWereMet())
+	})
+}
+
+func TestUnitContextPool(t *testing.T) {
+	t.Parallel()
+
+	t.Run("ctx with tx should run query in tx", func(t *testing.T) {
+		t.Parallel()
+
+		mockpool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+		mocktx, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+
+		s := &Session{
+			cfg: config.Config{},
+			db: &db.Postgres{
+				Builder: builder,
+				Pool:    db.NewContextPool(mockpool),
+			},
+		}
+
+		mocktx.ExpectBegin()
+		tx, err := mocktx.Begin(context.Background())
+		require.NoError(t, err)
+
+		mocktx.
+			ExpectExec("UPDATE").WithArgs(anyTime{}, int64(1)).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+		ctx := db.ContextWithTx(context.Background(), tx)
+		err = s.UpdateSessionLastSeenAt(ctx, 1, time.Now())
+
+		require.NoError(t, err)
+		require.NoError(t, mocktx.ExpectationsWereMet())
+		require.NoError(t, mockpool.ExpectationsWereMet())
+	})
+	t.Run("ctx without tx should run query in pool", func(t *testing.T) {
+		t.Parallel()
+
+		mockpool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+
+		s := &Session{
+			cfg: config.Config{},
+			db: &db.Postgres{
+				Builder: builder,
+				Pool:    db.NewContextPool(mockpool),
+			},
+		}
+
+		mockpool.
+			ExpectExec("UPDATE").WithArgs(anyTime{}, int64(1)).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+		err = s.UpdateSessionLastSeenAt(context.Background(), 1, time.Now())
+
+		require.NoError(t, err)
+		require.NoError(t, mockpool.ExpectationsWereMet())
+	})
+}
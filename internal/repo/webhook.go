@@ -0,0 +1,583 @@
+package repo
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Hidayathamir/go-user/config"
+	"github.com/Hidayathamir/go-user/internal/pkg/query"
+	"github.com/Hidayathamir/go-user/internal/repo/db"
+	"github.com/Hidayathamir/go-user/internal/repo/db/entity"
+	"github.com/Hidayathamir/go-user/internal/repo/db/entity/table"
+	"github.com/Hidayathamir/go-user/pkg/gouser"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+)
+
+//go:generate mockgen -source=webhook.go -destination=mockrepo/webhook.go -package=mockrepo
+
+// IWebhook contains abstraction of repo outgoing webhook.
+type IWebhook interface {
+	// CreateSubscription create webhook subscription, return its id.
+	CreateSubscription(ctx context.Context, subscription entity.WebhookSubscription) (int64, error)
+	// GetSubscriptions return all webhook subscriptions.
+	GetSubscriptions(ctx context.Context) ([]entity.WebhookSubscription, error)
+	// GetSubscriptionByID return webhook subscription by id.
+	GetSubscriptionByID(ctx context.Context, subscriptionID int64) (entity.WebhookSubscription, error)
+	// UpdateSubscription update url, event types and active flag of webhook
+	// subscription, secret is updated only if not empty.
+	UpdateSubscription(ctx context.Context, subscription entity.WebhookSubscription) error
+	// DeleteSubscription delete webhook subscription and its deliveries.
+	DeleteSubscription(ctx context.Context, subscriptionID int64) error
+	// CreateDeliveries create pending delivery of the event for each active
+	// subscription to the event type, return number of created deliveries.
+	// Event already delivered to a subscription is skipped.
+	CreateDeliveries(ctx context.Context, eventID int64, eventType string, payload []byte, now time.Time) (int64, error)
+	// ClaimPendingDeliveries claim pending deliveries of active
+	// subscriptions which are due at now, oldest first, by moving their next
+	// attempt to leaseUntil. Concurrent worker skip them until then.
+	ClaimPendingDeliveries(ctx context.Context, now time.Time, leaseUntil time.Time, limit uint64) ([]entity.WebhookDelivery, error)
+	// UpdateDelivery update status, attempt and next attempt of delivery
+	// claimed until leaseUntil. Return ErrClaimLost if the claim is not held.
+	UpdateDelivery(ctx context.Context, delivery entity.WebhookDelivery, leaseUntil time.Time) error
+	// CreateDeliveryAttempt write log of one delivery attempt.
+	CreateDeliveryAttempt(ctx context.Context, attempt entity.WebhookDeliveryAttempt) error
+	// GetDeliveriesBySubscriptionID return the latest deliveries of
+	// subscription, newest first. Empty status means any status.
+	GetDeliveriesBySubscriptionID(ctx context.Context, subscriptionID int64, status string, limit uint64) ([]entity.WebhookDelivery, error)
+	// GetDeliveryAttemptsByDeliveryIDs return attempts of the deliveries,
+	// oldest first.
+	GetDeliveryAttemptsByDeliveryIDs(ctx context.Context, deliveryIDs []int64) ([]entity.WebhookDeliveryAttempt, error)
+	// RedeliverDelivery make delivery pending again with attempt reset, it
+	// is delivered at the next worker run.
+	RedeliverDelivery(ctx context.Context, deliveryID int64, now time.Time) error
+	// DeleteDeliveriesByUserIDs delete deliveries of events of the users,
+	// return number of deleted deliveries. Call it before the events are
+	// deleted from outbox.
+	DeleteDeliveriesByUserIDs(ctx context.Context, userIDs []int64) (int64, error)
+}
+
+// Webhook implement IWebhook.
+type Webhook struct {
+	cfg config.Config
+	db  *db.Postgres
+}
+
+var _ IWebhook = &Webhook{}
+
+// NewWebhook return *Webhook which implement repo.IWebhook.
+func NewWebhook(cfg config.Config, db *db.Postgres) *Webhook {
+	return &Webhook{
+		cfg: cfg,
+		db:  db,
+	}
+}
+
+// CreateSubscription create webhook subscription, return its id.
+func (w *Webhook) CreateSubscription(ctx context.Context, subscription entity.WebhookSubscription) (int64, error) {
+	now := time.Now()
+
+	sql, args, err := w.db.Builder.
+		Insert(table.WebhookSubscription.String()).
+		Columns(
+			table.WebhookSubscription.URL, table.WebhookSubscription.EventTypes,
+			table.WebhookSubscription.Secret, table.WebhookSubscription.IsActive,
+			table.WebhookSubscription.CreatedAt, table.WebhookSubscription.UpdatedAt,
+		).
+		Values(
+			subscription.URL, subscription.EventTypes,
+			subscription.Secret, subscription.IsActive,
+			now, now,
+		).
+		Suffix(query.Returning(table.WebhookSubscription.ID)).
+		ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("Webhook.db.Builder.ToSql: %w", err)
+	}
+
+	var subscriptionID int64
+	err = w.db.Pool.QueryRow(ctx, sql, args...).Scan(&subscriptionID)
+	if err != nil {
+		return 0, fmt.Errorf("Webhook.db.Pool.QueryRow.Scan: %w", err)
+	}
+
+	return subscriptionID, nil
+}
+
+// GetSubscriptions return all webhook subscriptions.
+func (w *Webhook) GetSubscriptions(ctx context.Context) ([]entity.WebhookSubscription, error) {
+	sql, args, err := w.db.Builder.
+		Select(
+			table.WebhookSubscription.ID, table.WebhookSubscription.URL,
+			table.WebhookSubscription.EventTypes, table.WebhookSubscription.Secret,
+			table.WebhookSubscription.IsActive, table.WebhookSubscription.CreatedAt,
+			table.WebhookSubscription.UpdatedAt,
+		).
+		From(table.WebhookSubscription.String()).
+		OrderBy(table.WebhookSubscription.ID).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("Webhook.db.Builder.ToSql: %w", err)
+	}
+
+	rows, err := w.db.Pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("Webhook.db.Pool.Query: %w", err)
+	}
+	defer rows.Close()
+
+	subscriptions := []entity.WebhookSubscription{}
+	for rows.Next() {
+		subscription := entity.WebhookSubscription{}
+		err := rows.Scan(
+			&subscription.ID, &subscription.URL,
+			&subscription.EventTypes, &subscription.Secret,
+			&subscription.IsActive, &subscription.CreatedAt,
+			&subscription.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("pgx.Rows.Scan: %w", err)
+		}
+		subscriptions = append(subscriptions, subscription)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("pgx.Rows.Err: %w", err)
+	}
+
+	return subscriptions, nil
+}
+
+// GetSubscriptionByID return webhook subscription by id.
+func (w *Webhook) GetSubscriptionByID(ctx context.Context, subscriptionID int64) (entity.WebhookSubscription, error) {
+	sql, args, err := w.db.Builder.
+		Select(
+			table.WebhookSubscription.ID, table.WebhookSubscription.URL,
+			table.WebhookSubscription.EventTypes, table.WebhookSubscription.Secret,
+			table.WebhookSubscription.IsActive, table.WebhookSubscription.CreatedAt,
+			table.WebhookSubscription.UpdatedAt,
+		).
+		From(table.WebhookSubscription.String()).
+		Where(sq.Eq{
+			table.WebhookSubscription.ID: subscriptionID,
+		}).
+		ToSql()
+	if err != nil {
+		return entity.WebhookSubscription{}, fmt.Errorf("Webhook.db.Builder.ToSql: %w", err)
+	}
+
+	subscription := entity.WebhookSubscription{}
+	err = w.db.Pool.QueryRow(ctx, sql, args...).Scan(
+		&subscription.ID, &subscription.URL,
+		&subscription.EventTypes, &subscription.Secret,
+		&subscription.IsActive, &subscription.CreatedAt,
+		&subscription.UpdatedAt,
+	)
+	if err != nil {
+		err := fmt.Errorf("Webhook.db.Pool.QueryRow: %w", err)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.WebhookSubscription{}, fmt.Errorf("%w: %w", gouser.ErrUnknownWebhookSubscription, err)
+		}
+		return entity.WebhookSubscription{}, err
+	}
+
+	return subscription, nil
+}
+
+// UpdateSubscription update url, event types and active flag of webhook
+// subscription, secret is updated only if not empty.
+func (w *Webhook) UpdateSubscription(ctx context.Context, subscription entity.WebhookSubscription) error {
+	set := sq.Eq{
+		table.WebhookSubscription.URL:        subscription.URL,
+		table.WebhookSubscription.EventTypes: subscription.EventTypes,
+		table.WebhookSubscription.IsActive:   subscription.IsActive,
+		table.WebhookSubscription.UpdatedAt:  time.Now(),
+	}
+
+	if subscription.Secret != "" {
+		set[table.WebhookSubscription.Secret] = subscription.Secret
+	}
+
+	sql, args, err := w.db.Builder.
+		Update(table.WebhookSubscription.String()).
+		SetMap(set).
+		Where(sq.Eq{
+			table.WebhookSubscription.ID: subscription.ID,
+		}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("Webhook.db.Builder.ToSql: %w", err)
+	}
+
+	commandTag, err := w.db.Pool.Exec(ctx, sql, args...)
+	if err != nil {
+		return fmt.Errorf("Webhook.db.Pool.Exec: %w", err)
+	}
+
+	if commandTag.RowsAffected() == 0 {
+		return fmt.Errorf("%w: pgconn.CommandTag.RowsAffected == 0: %w", gouser.ErrUnknownWebhookSubscription, pgx.ErrNoRows)
+	}
+
+	return nil
+}
+
+// DeleteSubscription delete webhook subscription and its deliveries.
+func (w *Webhook) DeleteSubscription(ctx context.Context, subscriptionID int64) error {
+	sql, args, err := w.db.Builder.
+		Delete(table.WebhookSubscription.String()).
+		Where(sq.Eq{
+			table.WebhookSubscription.ID: subscriptionID,
+		}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("Webhook.db.Builder.ToSql: %w", err)
+	}
+
+	commandTag, err := w.db.Pool.Exec(ctx, sql, args...)
+	if err != nil {
+		return fmt.Errorf("Webhook.db.Pool.Exec: %w", err)
+	}
+
+	if commandTag.RowsAffected() == 0 {
+		return fmt.Errorf("%w: pgconn.CommandTag.RowsAffected == 0: %w", gouser.ErrUnknownWebhookSubscription, pgx.ErrNoRows)
+	}
+
+	return nil
+}
+
+// CreateDeliveries create pending delivery of the event for each active
+// subscription to the event type, return number of created deliveries. Event
+// already delivered to a subscription is skipped, so it is safe to call again
+// for the same event.
+func (w *Webhook) CreateDeliveries(ctx context.Context, eventID int64, eventType string, payload []byte, now time.Time) (int64, error) {
+	sql, args, err := w.db.Builder.
+		Insert(table.WebhookDelivery.String()).
+		Columns(
+			table.WebhookDelivery.SubscriptionID, table.WebhookDelivery.EventID,
+			table.WebhookDelivery.EventType, table.WebhookDelivery.Payload,
+			table.WebhookDelivery.Status, table.WebhookDelivery.NextAttemptAt,
+			table.WebhookDelivery.CreatedAt, table.WebhookDelivery.UpdatedAt,
+		).
+		Select(sq.
+			Select(table.WebhookSubscription.ID).
+			Column("?::bigint", eventID).
+			Column("?::varchar", eventType).
+			Column("?::jsonb", payload).
+			Column("?::varchar", entity.WebhookDeliveryStatusPending).
+			Column("?::timestamptz", now).
+			Column("?::timestamptz", now).
+			Column("?::timestamptz", now).
+			From(table.WebhookSubscription.String()).
+			Where(sq.Eq{
+				table.WebhookSubscription.IsActive: true,
+			}).
+			Where("?::varchar = ANY("+table.WebhookSubscription.EventTypes+")", eventType),
+		).
+		Suffix("ON CONFLICT ON CONSTRAINT " + table.WebhookDelivery.Constraint.WebhookDeliverySubscriptionEventUn + " DO NOTHING").
+		ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("Webhook.db.Builder.ToSql: %w", err)
+	}
+
+	commandTag, err := w.db.Pool.Exec(ctx, sql, args...)
+	if err != nil {
+		return 0, fmt.Errorf("Webhook.db.Pool.Exec: %w", err)
+	}
+
+	return commandTag.RowsAffected(), nil
+}
+
+// ClaimPendingDeliveries claim pending deliveries of active subscriptions
+// which are due at now, oldest first, by moving their next attempt to
+// leaseUntil. Concurrent worker skip them until then. Claim is done in one
+// statement so row lock is held only while claiming, not while sending. A
+// delivery whose worker crashed is picked again after leaseUntil.
+func (w *Webhook) ClaimPendingDeliveries(ctx context.Context, now time.Time, leaseUntil time.Time, limit uint64) ([]entity.WebhookDelivery, error) {
+	// Subquery use question placeholder, the outer builder renumbers them.
+	pending := sq.
+		Select(table.WebhookDelivery.Dot.ID).
+		From(table.WebhookDelivery.String()).
+		Join(table.WebhookSubscription.String()+" ON "+table.WebhookSubscription.Dot.ID+" = "+table.WebhookDelivery.Dot.SubscriptionID).
+		Where(sq.Eq{
+			table.WebhookDelivery.Dot.Status:       entity.WebhookDeliveryStatusPending,
+			table.WebhookSubscription.Dot.IsActive: true,
+		}).
+		Where(sq.LtOrEq{
+			table.WebhookDelivery.Dot.NextAttemptAt: now,
+		}).
+		OrderBy(table.WebhookDelivery.Dot.NextAttemptAt, table.WebhookDelivery.Dot.ID).
+		Limit(limit).
+		Suffix("FOR UPDATE OF " + table.WebhookDelivery.String() + " SKIP LOCKED")
+
+	sql, args, err := w.db.Builder.
+		Update(table.WebhookDelivery.String()).
+		Set(table.WebhookDelivery.NextAttemptAt, leaseUntil).
+		Where(sq.Expr(table.WebhookDelivery.ID+" IN (?)", pending)).
+		Suffix(query.Returning(strings.Join([]string{
+			table.WebhookDelivery.ID, table.WebhookDelivery.SubscriptionID,
+			table.WebhookDelivery.EventID, table.WebhookDelivery.EventType,
+			table.WebhookDelivery.Payload, table.WebhookDelivery.Status,
+			table.WebhookDelivery.Attempt, table.WebhookDelivery.NextAttemptAt,
+			table.WebhookDelivery.CreatedAt, table.WebhookDelivery.UpdatedAt,
+		}, ", "))).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("Webhook.db.Builder.ToSql: %w", err)
+	}
+
+	deliveries, err := w.queryDeliveries(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("Webhook.queryDeliveries: %w", err)
+	}
+
+	// RETURNING does not keep the order of the subquery, every claimed
+	// delivery has the same next attempt now.
+	slices.SortFunc(deliveries, func(a, b entity.WebhookDelivery) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+
+	return deliveries, nil
+}
+
+// UpdateDelivery update status, attempt and next attempt of delivery claimed
+// until leaseUntil. The claim is held while the delivery is still pending with
+// next_attempt_at the worker set, otherwise other worker claimed it after the
+// claim expired, or it was redelivered or deleted, and ErrClaimLost is
+// returned.
+func (w *Webhook) UpdateDelivery(ctx context.Context, delivery entity.WebhookDelivery, leaseUntil time.Time) error {
+	sql, args, err := w.db.Builder.
+		Update(table.WebhookDelivery.String()).
+		Set(table.WebhookDelivery.Status, delivery.Status).
+		Set(table.WebhookDelivery.Attempt, delivery.Attempt).
+		Set(table.WebhookDelivery.NextAttemptAt, delivery.NextAttemptAt).
+		Set(table.WebhookDelivery.UpdatedAt, time.Now()).
+		Where(sq.Eq{
+			table.WebhookDelivery.ID:            delivery.ID,
+			table.WebhookDelivery.Status:        entity.WebhookDeliveryStatusPending,
+			table.WebhookDelivery.NextAttemptAt: leaseUntil,
+		}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("Webhook.db.Builder.ToSql: %w", err)
+	}
+
+	commandTag, err := w.db.Pool.Exec(ctx, sql, args...)
+	if err != nil {
+		return fmt.Errorf("Webhook.db.Pool.Exec: %w", err)
+	}
+
+	if commandTag.RowsAffected() == 0 {
+		return fmt.Errorf("%w: pgconn.CommandTag.RowsAffected == 0", ErrClaimLost)
+	}
+
+	return nil
+}
+
+// CreateDeliveryAttempt write log of one delivery attempt.
+func (w *Webhook) CreateDeliveryAttempt(ctx context.Context, attempt entity.WebhookDeliveryAttempt) error {
+	sql, args, err := w.db.Builder.
+		Insert(table.WebhookDeliveryAttempt.String()).
+		Columns(
+			table.WebhookDeliveryAttempt.DeliveryID, table.WebhookDeliveryAttempt.AttemptedAt,
+			table.WebhookDeliveryAttempt.StatusCode, table.WebhookDeliveryAttempt.Error,
+			table.WebhookDeliveryAttempt.DurationMS,
+		).
+		Values(
+			attempt.DeliveryID, attempt.AttemptedAt,
+			attempt.StatusCode, attempt.Error,
+			attempt.DurationMS,
+		).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("Webhook.db.Builder.ToSql: %w", err)
+	}
+
+	_, err = w.db.Pool.Exec(ctx, sql, args...)
+	if err != nil {
+		return fmt.Errorf("Webhook.db.Pool.Exec: %w", err)
+	}
+
+	return nil
+}
+
+// GetDeliveriesBySubscriptionID return the latest deliveries of subscription,
+// newest first. Empty status means any status.
+func (w *Webhook) GetDeliveriesBySubscriptionID(ctx context.Context, subscriptionID int64, status string, limit uint64) ([]entity.WebhookDelivery, error) {
+	where := sq.Eq{
+		table.WebhookDelivery.SubscriptionID: subscriptionID,
+	}
+
+	if status != "" {
+		where[table.WebhookDelivery.Status] = status
+	}
+
+	sql, args, err := w.db.Builder.
+		Select(
+			table.WebhookDelivery.ID, table.WebhookDelivery.SubscriptionID,
+			table.WebhookDelivery.EventID, table.WebhookDelivery.EventType,
+			table.WebhookDelivery.Payload, table.WebhookDelivery.Status,
+			table.WebhookDelivery.Attempt, table.WebhookDelivery.NextAttemptAt,
+			table.WebhookDelivery.CreatedAt, table.WebhookDelivery.UpdatedAt,
+		).
+		From(table.WebhookDelivery.String()).
+		Where(where).
+		OrderBy(table.WebhookDelivery.ID + " DESC").
+		Limit(limit).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("Webhook.db.Builder.ToSql: %w", err)
+	}
+
+	deliveries, err := w.queryDeliveries(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("Webhook.queryDeliveries: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+// GetDeliveryAttemptsByDeliveryIDs return attempts of the deliveries, oldest
+// first.
+func (w *Webhook) GetDeliveryAttemptsByDeliveryIDs(ctx context.Context, deliveryIDs []int64) ([]entity.WebhookDeliveryAttempt, error) {
+	if len(deliveryIDs) == 0 {
+		return []entity.WebhookDeliveryAttempt{}, nil
+	}
+
+	sql, args, err := w.db.Builder.
+		Select(
+			table.WebhookDeliveryAttempt.ID, table.WebhookDeliveryAttempt.DeliveryID,
+			table.WebhookDeliveryAttempt.AttemptedAt, table.WebhookDeliveryAttempt.StatusCode,
+			table.WebhookDeliveryAttempt.Error, table.WebhookDeliveryAttempt.DurationMS,
+		).
+		From(table.WebhookDeliveryAttempt.String()).
+		Where(sq.Eq{
+			table.WebhookDeliveryAttempt.DeliveryID: deliveryIDs,
+		}).
+		OrderBy(table.WebhookDeliveryAttempt.AttemptedAt, table.WebhookDeliveryAttempt.ID).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("Webhook.db.Builder.ToSql: %w", err)
+	}
+
+	rows, err := w.db.Pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("Webhook.db.Pool.Query: %w", err)
+	}
+	defer rows.Close()
+
+	attempts := []entity.WebhookDeliveryAttempt{}
+	for rows.Next() {
+		attempt := entity.WebhookDeliveryAttempt{}
+		err := rows.Scan(
+			&attempt.ID, &attempt.DeliveryID,
+			&attempt.AttemptedAt, &attempt.StatusCode,
+			&attempt.Error, &attempt.DurationMS,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("pgx.Rows.Scan: %w", err)
+		}
+		attempts = append(attempts, attempt)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("pgx.Rows.Err: %w", err)
+	}
+
+	return attempts, nil
+}
+
+// RedeliverDelivery make delivery pending again with attempt reset, it is
+// delivered at the next worker run.
+func (w *Webhook) RedeliverDelivery(ctx context.Context, deliveryID int64, now time.Time) error {
+	sql, args, err := w.db.Builder.
+		Update(table.WebhookDelivery.String()).
+		Set(table.WebhookDelivery.Status, entity.WebhookDeliveryStatusPending).
+		Set(table.WebhookDelivery.Attempt, 0).
+		Set(table.WebhookDelivery.NextAttemptAt, now).
+		Set(table.WebhookDelivery.UpdatedAt, now).
+		Where(sq.Eq{
+			table.WebhookDelivery.ID: deliveryID,
+		}).
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("Webhook.db.Builder.ToSql: %w", err)
+	}
+
+	commandTag, err := w.db.Pool.Exec(ctx, sql, args...)
+	if err != nil {
+		return fmt.Errorf("Webhook.db.Pool.Exec: %w", err)
+	}
+
+	if commandTag.RowsAffected() == 0 {
+		return fmt.Errorf("%w: pgconn.CommandTag.RowsAffected == 0: %w", gouser.ErrUnknownWebhookDelivery, pgx.ErrNoRows)
+	}
+
+	return nil
+}
+
+// DeleteDeliveriesByUserIDs delete deliveries of events of the users, return
+// number of deleted deliveries. Delivery attempts are deleted by cascade.
+// Delivery has no user id, it is found by its outbox event, so call it before
+// the events are deleted from outbox.
+func (w *Webhook) DeleteDeliveriesByUserIDs(ctx context.Context, userIDs []int64) (int64, error) {
+	// Subquery use question placeholder, the outer builder renumbers them.
+	events := sq.
+		Select(table.OutboxEvent.ID).
+		From(table.OutboxEvent.String()).
+		Where(sq.Eq{
+			table.OutboxEvent.UserID: userIDs,
+		})
+
+	sql, args, err := w.db.Builder.
+		Delete(table.WebhookDelivery.String()).
+		Where(sq.Expr(table.WebhookDelivery.EventID+" IN (?)", events)).
+		ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("Webhook.db.Builder.ToSql: %w", err)
+	}
+
+	commandTag, err := w.db.Pool.Exec(ctx, sql, args...)
+	if err != nil {
+		return 0, fmt.Errorf("Webhook.db.Pool.Exec: %w", err)
+	}
+
+	return commandTag.RowsAffected(), nil
+}
+
+func (w *Webhook) queryDeliveries(ctx context.Context, sql string, args ...any) ([]entity.WebhookDelivery, error) {
+	rows, err := w.db.Pool.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, fmt.Errorf("Webhook.db.Pool.Query: %w", err)
+	}
+	defer rows.Close()
+
+	deliveries := []entity.WebhookDelivery{}
+	for rows.Next() {
+		delivery := entity.WebhookDelivery{}
+		err := rows.Scan(
+			&delivery.ID, &delivery.SubscriptionID,
+			&delivery.EventID, &delivery.EventType,
+			&delivery.Payload, &delivery.Status,
+			&delivery.Attempt, &delivery.NextAttemptAt,
+			&delivery.CreatedAt, &delivery.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("pgx.Rows.Scan: %w", err)
+		}
+		deliveries = append(deliveries, delivery)
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return nil, fmt.Errorf("pgx.Rows.Err: %w", err)
+	}
+
+	return deliveries, nil
+}
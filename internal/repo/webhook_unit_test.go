@@ -0,0 +1,481 @@
+package repo
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Hidayathamir/go-user/config"
+	"github.com/Hidayathamir/go-user/internal/repo/db"
+	"github.com/Hidayathamir/go-user/internal/repo/db/entity"
+	"github.com/Hidayathamir/go-user/pkg/gouser"
+	"github.com/jackc/pgx/v5"
+	"github.com/pashagolub/pgxmock/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var webhookDeliveryColumns = []string{
+	"id", "subscription_id", "event_id", "event_type", "payload", "status",
+	"attempt", "next_attempt_at", "created_at", "updated_at",
+}
+
+func TestUnitWebhookGetSubscriptionByID(t *testing.T) {
+	t.Parallel()
+
+	t.Run("get subscription success", func(t *testing.T) {
+		t.Parallel()
+
+		mockpool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+
+		w := &Webhook{
+			cfg: config.Config{},
+			db: &db.Postgres{
+				Builder: builder,
+				Pool:    mockpool,
+			},
+		}
+
+		now := time.Now()
+		mockpool.
+			ExpectQuery(`SELECT .* FROM \"webhook_subscription\" WHERE`).WithArgs(int64(3)).
+			WillReturnRows(pgxmock.NewRows([]string{"id", "url", "event_types", "secret", "is_active", "created_at", "updated_at"}).
+				AddRow(int64(3), "https://example.com/hook", []string{gouser.EventTypeUserRegistered}, "0123456789abcdef", true, now, now),
+			)
+
+		subscription, err := w.GetSubscriptionByID(context.Background(), 3)
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), subscription.ID)
+		assert.Equal(t, []string{gouser.EventTypeUserRegistered}, subscription.EventTypes)
+	})
+	t.Run("no rows should return error unknown webhook subscription", func(t *testing.T) {
+		t.Parallel()
+
+		mockpool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+
+		w := &Webhook{
+			cfg: config.Config{},
+			db: &db.Postgres{
+				Builder: builder,
+				Pool:    mockpool,
+			},
+		}
+
+		mockpool.ExpectQuery("SELECT").WithArgs(int64(3)).WillReturnError(pgx.ErrNoRows)
+
+		subscription, err := w.GetSubscriptionByID(context.Background(), 3)
+
+		assert.Empty(t, subscription)
+		require.Error(t, err)
+		require.ErrorIs(t, err, gouser.ErrUnknownWebhookSubscription)
+	})
+}
+
+func TestUnitWebhookUpdateSubscription(t *testing.T) {
+	t.Parallel()
+
+	t.Run("empty secret should keep secret", func(t *testing.T) {
+		t.Parallel()
+
+		mockpool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+
+		w := &Webhook{
+			cfg: config.Config{},
+			db: &db.Postgres{
+				Builder: builder,
+				Pool:    mockpool,
+			},
+		}
+
+		mockpool.
+			ExpectExec(`UPDATE \"webhook_subscription\" SET event_types = .*, is_active = .*, updated_at = .*, url = .* WHERE`).
+			WithArgs([]string{gouser.EventTypeUserDeleted}, false, anyTime{}, "https://example.com/hook", int64(3)).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+		err = w.UpdateSubscription(context.Background(), entity.WebhookSubscription{
+			ID:         3,
+			URL:        "https://example.com/hook",
+			EventTypes: []string{gouser.EventTypeUserDeleted},
+		})
+
+		require.NoError(t, err)
+		require.NoError(t, mockpool.ExpectationsWereMet())
+	})
+	t.Run("no rows affected should return error unknown webhook subscription", func(t *testing.T) {
+		t.Parallel()
+
+		mockpool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+
+		w := &Webhook{
+			cfg: config.Config{},
+			db: &db.Postgres{
+				Builder: builder,
+				Pool:    mockpool,
+			},
+		}
+
+		mockpool.
+			ExpectExec(`UPDATE \"webhook_subscription\" SET .* secret = .*`).
+			WithArgs(pgxmock.AnyArg(), true, "0123456789abcdef", anyTime{}, pgxmock.AnyArg(), int64(3)).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+
+		err = w.UpdateSubscription(context.Background(), entity.WebhookSubscription{
+			ID:         3,
+			URL:        "https://example.com/hook",
+			EventTypes: []string{gouser.EventTypeUserDeleted},
+			Secret:     "0123456789abcdef",
+			IsActive:   true,
+		})
+
+		require.Error(t, err)
+		require.ErrorIs(t, err, gouser.ErrUnknownWebhookSubscription)
+	})
+}
+
+func TestUnitWebhookCreateDeliveries(t *testing.T) {
+	t.Parallel()
+
+	t.Run("create deliveries success", func(t *testing.T) {
+		t.Parallel()
+
+		mockpool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+
+		w := &Webhook{
+			cfg: config.Config{},
+			db: &db.Postgres{
+				Builder: builder,
+				Pool:    mockpool,
+			},
+		}
+
+		now := time.Now()
+		payload := []byte(`{"id":7}`)
+		mockpool.
+			ExpectExec(`INSERT INTO \"webhook_delivery\" .* SELECT .* FROM \"webhook_subscription\" WHERE is_active = .* ANY\(event_types\) ON CONFLICT ON CONSTRAINT webhook_delivery_subscription_event_un DO NOTHING`).
+			WithArgs(
+				int64(7), gouser.EventTypeUserRegistered, payload, entity.WebhookDeliveryStatusPending,
+				now, now, now, true, gouser.EventTypeUserRegistered,
+			).
+			WillReturnResult(pgxmock.NewResult("INSERT", 2))
+
+		count, err := w.CreateDeliveries(context.Background(), 7, gouser.EventTypeUserRegistered, payload, now)
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), count)
+		require.NoError(t, mockpool.ExpectationsWereMet())
+	})
+	t.Run("Exec error should return error", func(t *testing.T) {
+		t.Parallel()
+
+		mockpool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+
+		w := &Webhook{
+			cfg: config.Config{},
+			db: &db.Postgres{
+				Builder: builder,
+				Pool:    mockpool,
+			},
+		}
+
+		now := time.Now()
+		payload := []byte(`{}`)
+		mockpool.
+			ExpectExec("INSERT").
+			WithArgs(
+				int64(7), gouser.EventTypeUserRegistered, payload, entity.WebhookDeliveryStatusPending,
+				now, now, now, true, gouser.EventTypeUserRegistered,
+			).
+			WillReturnError(assert.AnError)
+
+		count, err := w.CreateDeliveries(context.Background(), 7, gouser.EventTypeUserRegistered, payload, now)
+
+		require.Error(t, err)
+		require.ErrorIs(t, err, assert.AnError)
+		assert.Equal(t, int64(0), count)
+	})
+}
+
+func TestUnitWebhookClaimPendingDeliveries(t *testing.T) {
+	t.Parallel()
+
+	t.Run("claim pending deliveries success", func(t *testing.T) {
+		t.Parallel()
+
+		mockpool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+
+		w := &Webhook{
+			cfg: config.Config{},
+			db: &db.Postgres{
+				Builder: builder,
+				Pool:    mockpool,
+			},
+		}
+
+		now := time.Now()
+		leaseUntil := now.Add(time.Minute)
+		mockpool.
+			ExpectQuery(`UPDATE "webhook_delivery" SET next_attempt_at = \$1 WHERE id IN \(SELECT "webhook_delivery".id FROM "webhook_delivery" JOIN "webhook_subscription" ON .* LIMIT 10 FOR UPDATE OF "webhook_delivery" SKIP LOCKED\) RETURNING id, .*`).
+			WithArgs(leaseUntil, entity.WebhookDeliveryStatusPending, true, now).
+			WillReturnRows(pgxmock.NewRows(webhookDeliveryColumns).
+				AddRow(int64(22), int64(3), int64(8), gouser.EventTypeUserDeleted, []byte(`{}`), entity.WebhookDeliveryStatusPending, 0, leaseUntil, now, now).
+				AddRow(int64(21), int64(3), int64(7), gouser.EventTypeUserRegistered, []byte(`{}`), entity.WebhookDeliveryStatusPending, 1, leaseUntil, now, now),
+			)
+
+		deliveries, err := w.ClaimPendingDeliveries(context.Background(), now, leaseUntil, 10)
+
+		require.NoError(t, err)
+		require.Len(t, deliveries, 2)
+		assert.Equal(t, int64(21), deliveries[0].ID)
+		assert.Equal(t, 1, deliveries[0].Attempt)
+		assert.Equal(t, int64(22), deliveries[1].ID)
+	})
+	t.Run("Query error should return error", func(t *testing.T) {
+		t.Parallel()
+
+		mockpool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+
+		w := &Webhook{
+			cfg: config.Config{},
+			db: &db.Postgres{
+				Builder: builder,
+				Pool:    mockpool,
+			},
+		}
+
+		now := time.Now()
+		leaseUntil := now.Add(time.Minute)
+		mockpool.ExpectQuery("UPDATE").WithArgs(leaseUntil, entity.WebhookDeliveryStatusPending, true, now).WillReturnError(assert.AnError)
+
+		deliveries, err := w.ClaimPendingDeliveries(context.Background(), now, leaseUntil, 10)
+
+		assert.Nil(t, deliveries)
+		require.Error(t, err)
+		require.ErrorIs(t, err, assert.AnError)
+	})
+}
+
+func TestUnitWebhookGetDeliveryAttemptsByDeliveryIDs(t *testing.T) {
+	t.Parallel()
+
+	t.Run("empty delivery ids should not query", func(t *testing.T) {
+		t.Parallel()
+
+		mockpool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+
+		w := &Webhook{
+			cfg: config.Config{},
+			db: &db.Postgres{
+				Builder: builder,
+				Pool:    mockpool,
+			},
+		}
+
+		attempts, err := w.GetDeliveryAttemptsByDeliveryIDs(context.Background(), nil)
+
+		require.NoError(t, err)
+		assert.Empty(t, attempts)
+		require.NoError(t, mockpool.ExpectationsWereMet())
+	})
+}
+
+func TestUnitWebhookUpdateDelivery(t *testing.T) {
+	t.Parallel()
+
+	t.Run("update delivery success", func(t *testing.T) {
+		t.Parallel()
+
+		mockpool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+
+		w := &Webhook{
+			cfg: config.Config{},
+			db: &db.Postgres{
+				Builder: builder,
+				Pool:    mockpool,
+			},
+		}
+
+		leaseUntil := time.Now().Add(time.Minute)
+		delivery := entity.WebhookDelivery{
+			ID:            21,
+			Status:        entity.WebhookDeliveryStatusSucceeded,
+			Attempt:       1,
+			NextAttemptAt: leaseUntil,
+		}
+		mockpool.
+			ExpectExec(`UPDATE "webhook_delivery" SET .* WHERE id = \$5 AND next_attempt_at = \$6 AND status = \$7`).
+			WithArgs(entity.WebhookDeliveryStatusSucceeded, 1, leaseUntil, pgxmock.AnyArg(), int64(21), leaseUntil, entity.WebhookDeliveryStatusPending).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+		err = w.UpdateDelivery(context.Background(), delivery, leaseUntil)
+
+		require.NoError(t, err)
+		require.NoError(t, mockpool.ExpectationsWereMet())
+	})
+	t.Run("Exec error should return error", func(t *testing.T) {
+		t.Parallel()
+
+		mockpool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+
+		w := &Webhook{
+			cfg: config.Config{},
+			db: &db.Postgres{
+				Builder: builder,
+				Pool:    mockpool,
+			},
+		}
+
+		leaseUntil := time.Now().Add(time.Minute)
+		mockpool.
+			ExpectExec("UPDATE").
+			WithArgs("", 0, time.Time{}, pgxmock.AnyArg(), int64(21), leaseUntil, entity.WebhookDeliveryStatusPending).
+			WillReturnError(assert.AnError)
+
+		err = w.UpdateDelivery(context.Background(), entity.WebhookDelivery{ID: 21}, leaseUntil)
+
+		require.Error(t, err)
+		require.ErrorIs(t, err, assert.AnError)
+	})
+	t.Run("claim taken over should return error claim lost", func(t *testing.T) {
+		t.Parallel()
+
+		mockpool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+
+		w := &Webhook{
+			cfg: config.Config{},
+			db: &db.Postgres{
+				Builder: builder,
+				Pool:    mockpool,
+			},
+		}
+
+		leaseUntil := time.Now().Add(time.Minute)
+		mockpool.
+			ExpectExec("UPDATE").
+			WithArgs("", 0, time.Time{}, pgxmock.AnyArg(), int64(21), leaseUntil, entity.WebhookDeliveryStatusPending).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+
+		err = w.UpdateDelivery(context.Background(), entity.WebhookDelivery{ID: 21}, leaseUntil)
+
+		require.Error(t, err)
+		require.ErrorIs(t, err, ErrClaimLost)
+	})
+}
+
+func TestUnitWebhookRedeliverDelivery(t *testing.T) {
+	t.Parallel()
+
+	t.Run("redeliver success", func(t *testing.T) {
+		t.Parallel()
+
+		mockpool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+
+		w := &Webhook{
+			cfg: config.Config{},
+			db: &db.Postgres{
+				Builder: builder,
+				Pool:    mockpool,
+			},
+		}
+
+		now := time.Now()
+		mockpool.
+			ExpectExec(`UPDATE \"webhook_delivery\" SET`).
+			WithArgs(entity.WebhookDeliveryStatusPending, 0, now, now, int64(21)).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+		err = w.RedeliverDelivery(context.Background(), 21, now)
+
+		require.NoError(t, err)
+		require.NoError(t, mockpool.ExpectationsWereMet())
+	})
+	t.Run("no rows affected should return error unknown webhook delivery", func(t *testing.T) {
+		t.Parallel()
+
+		mockpool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+
+		w := &Webhook{
+			cfg: config.Config{},
+			db: &db.Postgres{
+				Builder: builder,
+				Pool:    mockpool,
+			},
+		}
+
+		now := time.Now()
+		mockpool.
+			ExpectExec("UPDATE").
+			WithArgs(entity.WebhookDeliveryStatusPending, 0, now, now, int64(21)).
+			WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+
+		err = w.RedeliverDelivery(context.Background(), 21, now)
+
+		require.Error(t, err)
+		require.ErrorIs(t, err, gouser.ErrUnknownWebhookDelivery)
+	})
+}
+
+func TestUnitWebhookDeleteDeliveriesByUserIDs(t *testing.T) {
+	t.Parallel()
+
+	t.Run("delete deliveries by user ids success", func(t *testing.T) {
+		t.Parallel()
+
+		mockpool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+
+		w := &Webhook{
+			cfg: config.Config{},
+			db: &db.Postgres{
+				Builder: builder,
+				Pool:    mockpool,
+			},
+		}
+
+		mockpool.
+			ExpectExec(`DELETE FROM \"webhook_delivery\" WHERE event_id IN \(SELECT id FROM \"outbox_event\" WHERE user_id IN \(\$1,\$2\)\)`).
+			WithArgs(int64(44), int64(45)).
+			WillReturnResult(pgxmock.NewResult("DELETE", 3))
+
+		count, err := w.DeleteDeliveriesByUserIDs(context.Background(), []int64{44, 45})
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), count)
+	})
+	t.Run("Exec error should return error", func(t *testing.T) {
+		t.Parallel()
+
+		mockpool, err := pgxmock.NewPool(pgxmock.QueryMatcherOption(pgxmock.QueryMatcherRegexp))
+		require.NoError(t, err)
+
+		w := &Webhook{
+			cfg: config.Config{},
+			db: &db.Postgres{
+				Builder: builder,
+				Pool:    mockpool,
+			},
+		}
+
+		mockpool.ExpectExec("DELETE").WithArgs(int64(44), int64(45)).WillReturnError(assert.AnError)
+
+		count, err := w.DeleteDeliveriesByUserIDs(context.Background(), []int64{44, 45})
+
+		require.Error(t, err)
+		require.ErrorIs(t, err, assert.AnError)
+		assert.Equal(t, int64(0), count)
+	})
+}
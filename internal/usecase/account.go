@@ -0,0 +1,451 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Hidayathamir/go-user/config"
+	"github.com/Hidayathamir/go-user/internal/pkg/auth"
+	"github.com/Hidayathamir/go-user/internal/repo"
+	"github.com/Hidayathamir/go-user/internal/repo/db/entity"
+	"github.com/Hidayathamir/go-user/pkg/gouser"
+)
+
+//go:generate mockgen -source=account.go -destination=mockusecase/account.go -package=mockusecase
+
+// IAccount contains abstraction of usecase account lifecycle.
+type IAccount interface {
+	// DeleteAccount soft delete the user who own the JWT, password is
+	// required.
+	DeleteAccount(ctx context.Context, req gouser.ReqDeleteAccount) error
+	// RestoreAccount restore deleted user within retention period.
+	RestoreAccount(ctx context.Context, req gouser.ReqRestoreAccount) error
+	// PurgeDeletedAccounts hard delete users deleted longer than retention
+	// period, return number of purged users.
+	PurgeDeletedAccounts(ctx context.Context) (int64, error)
+	// UpdateUserStatus activate, suspend or disable any user, admin only.
+	UpdateUserStatus(ctx context.Context, req gouser.ReqUpdateUserStatus) error
+	// ChangeUsername change username of the user who own the JWT.
+	ChangeUsername(ctx context.Context, req gouser.ReqChangeUsername) error
+}
+
+// Account implement IAccount.
+type Account struct {
+	cfg          config.Config
+	guard        *guard
+	repoAccount  repo.IAccount
+	repoProfile  repo.IProfile
+	repoSession  repo.ISession
+	repoOutbox   repo.IOutbox
+	repoWebhook  repo.IWebhook
+	repoAuditLog repo.IAuditLog
+	auditor      *auditor
+	transactor   repo.ITransactor
+}
+
+var _ IAccount = &Account{}
+
+// NewAccount return *Account which implement IAccount.
+func NewAccount(cfg config.Config, repoAccount repo.IAccount, repoProfile repo.IProfile, repoSession repo.ISession, repoOutbox repo.IOutbox, repoWebhook repo.IWebhook, repoAuditLog repo.IAuditLog, transactor repo.ITransactor) *Account {
+	return &Account{
+		cfg:          cfg,
+		guard:        newGuard(cfg, repoSession, repoProfile),
+		repoAccount:  repoAccount,
+		repoProfile:  repoProfile,
+		repoSession:  repoSession,
+		repoOutbox:   repoOutbox,
+		repoWebhook:  repoWebhook,
+		repoAuditLog: repoAuditLog,
+		auditor:      newAuditor(cfg, repoAuditLog),
+		transactor:   transactor,
+	}
+}
+
+// DeleteAccount soft delete the user who own the JWT, password is required.
+// All user sessions are revoked, the user is deleted and the audit log is
+// appended in one transaction. Failure after authentication is audited too.
+func (a *Account) DeleteAccount(ctx context.Context, req gouser.ReqDeleteAccount) error {
+	err := req.Validate()
+	if err != nil {
+		err := fmt.Errorf("ReqDeleteAccount.Validate: %w", err)
+		return fmt.Errorf("%w: %w", gouser.ErrRequestInvalid, err)
+	}
+
+	_, user, err := a.guard.authenticateUser(ctx, req.UserJWT)
+	if err != nil {
+		return fmt.Errorf("Account.guard.authenticateUser: %w", err)
+	}
+
+	auditLog := entity.AuditLog{
+		ActorUserID:  auditUserID(user.ID),
+		TargetUserID: auditUserID(user.ID),
+		Action:       entity.AuditActionUserDelete,
+		IP:           req.IP,
+		UserAgent:    req.UserAgent,
+		RequestID:    req.RequestID,
+	}
+
+	err = a.deleteAccount(ctx, user, req.Password, auditLog)
+	if err != nil {
+		a.auditor.record(ctx, auditLog, err)
+		return fmt.Errorf("Account.deleteAccount: %w", err)
+	}
+
+	return nil
+}
+
+// deleteAccount is DeleteAccount after authentication, auditLog is appended in
+// the transaction which delete the user.
+func (a *Account) deleteAccount(ctx context.Context, user entity.User, password string, auditLog entity.AuditLog) error {
+	err := auth.CompareHashAndPassword(user.Password, password)
+	if err != nil {
+		err := fmt.Errorf("auth.CompareHashAndPassword: %w", err)
+		return fmt.Errorf("%w: %w", gouser.ErrWrongPassword, err)
+	}
+
+	err = a.transactor.WithinTx(ctx, func(ctx context.Context) error {
+		err := lockUsernames(ctx, a.repoAccount, user.Username)
+		if err != nil {
+			return fmt.Errorf("lockUsernames: %w", err)
+		}
+
+		err = a.repoSession.RevokeSessionsByUserID(ctx, user.ID)
+		if err != nil {
+			return fmt.Errorf("Account.repoSession.RevokeSessionsByUserID: %w", err)
+		}
+
+		err = a.repoAccount.SoftDeleteUser(ctx, user.ID, time.Now())
+		if err != nil {
+			return fmt.Errorf("Account.repoAccount.SoftDeleteUser: %w", err)
+		}
+
+		err = a.auditor.recordWithinTx(ctx, auditLog)
+		if err != nil {
+			return fmt.Errorf("Account.auditor.recordWithinTx: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("Account.transactor.WithinTx: %w", err)
+	}
+
+	return nil
+}
+
+// RestoreAccount restore deleted user within retention period. Username is
+// locked while the user is restored, the audit log is appended in the same
+// transaction. Failure after the deleted user is found is audited too.
+func (a *Account) RestoreAccount(ctx context.Context, req gouser.ReqRestoreAccount) error {
+	req.Username = gouser.NormalizeUsername(req.Username)
+
+	err := req.Validate()
+	if err != nil {
+		err := fmt.Errorf("ReqRestoreAccount.Validate: %w", err)
+		return fmt.Errorf("%w: %w", gouser.ErrRequestInvalid, err)
+	}
+
+	deletedAfter := time.Now().Add(-a.getDeletedRetention())
+
+	user, err := a.repoAccount.GetDeletedProfileByUsername(ctx, req.Username, deletedAfter)
+	if err != nil {
+		return fmt.Errorf("Account.repoAccount.GetDeletedProfileByUsername: %w", err)
+	}
+
+	auditLog := entity.AuditLog{
+		ActorUserID:  auditUserID(user.ID),
+		TargetUserID: auditUserID(user.ID),
+		Action:       entity.AuditActionUserRestore,
+		Detail:       fmt.Sprintf("username '%s'", user.Username),
+		IP:           req.IP,
+		UserAgent:    req.UserAgent,
+		RequestID:    req.RequestID,
+	}
+
+	err = a.restoreAccount(ctx, user, req.Password, auditLog)
+	if err != nil {
+		a.auditor.record(ctx, auditLog, err)
+		return fmt.Errorf("Account.restoreAccount: %w", err)
+	}
+
+	return nil
+}
+
+// restoreAccount is RestoreAccount after the deleted user is found, auditLog
+// is appended in the transaction which restore the user.
+func (a *Account) restoreAccount(ctx context.Context, user entity.User, password string, auditLog entity.AuditLog) error {
+	err := auth.CompareHashAndPassword(user.Password, password)
+	if err != nil {
+		err := fmt.Errorf("auth.CompareHashAndPassword: %w", err)
+		return fmt.Errorf("%w: %w", gouser.ErrWrongPassword, err)
+	}
+
+	err = a.transactor.WithinTx(ctx, func(ctx context.Context) error {
+		err := lockUsernames(ctx, a.repoAccount, user.Username)
+		if err != nil {
+			return fmt.Errorf("lockUsernames: %w", err)
+		}
+
+		err = a.repoAccount.RestoreUser(ctx, user.ID)
+		if err != nil {
+			return fmt.Errorf("Account.repoAccount.RestoreUser: %w", err)
+		}
+
+		err = a.auditor.recordWithinTx(ctx, auditLog)
+		if err != nil {
+			return fmt.Errorf("Account.auditor.recordWithinTx: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("Account.transactor.WithinTx: %w", err)
+	}
+
+	return nil
+}
+
+// PurgeDeletedAccounts hard delete users deleted longer than retention period,
+// return number of purged users. Users are purged in batches, each batch in
+// its own transaction together with their outbox events, webhook deliveries
+// and audit log entries, so personal data does not outlive the user.
+func (a *Account) PurgeDeletedAccounts(ctx context.Context) (int64, error) {
+	deletedBefore := time.Now().Add(-a.getDeletedRetention())
+	batchSize := uint64(a.cfg.Account.PurgeBatchSize)
+
+	var total int64
+	for {
+		var userIDs []int64
+		err := a.transactor.WithinTx(ctx, func(ctx context.Context) error {
+			var err error
+			userIDs, err = a.purgeDeletedAccountsBatch(ctx, deletedBefore, batchSize)
+			return err
+		})
+		if err != nil {
+			return total, fmt.Errorf("Account.transactor.WithinTx: %w", err)
+		}
+
+		total += int64(len(userIDs))
+		if uint64(len(userIDs)) < batchSize {
+			return total, nil
+		}
+	}
+}
+
+func (a *Account) purgeDeletedAccountsBatch(ctx context.Context, deletedBefore time.Time, batchSize uint64) ([]int64, error) {
+	userIDs, err := a.repoAccount.PurgeDeletedUsers(ctx, deletedBefore, batchSize)
+	if err != nil {
+		return nil, fmt.Errorf("Account.repoAccount.PurgeDeletedUsers: %w", err)
+	}
+
+	if len(userIDs) == 0 {
+		return userIDs, nil
+	}
+
+	// Deliveries are found by their outbox event, delete them first.
+	_, err = a.repoWebhook.DeleteDeliveriesByUserIDs(ctx, userIDs)
+	if err != nil {
+		return nil, fmt.Errorf("Account.repoWebhook.DeleteDeliveriesByUserIDs: %w", err)
+	}
+
+	_, err = a.repoOutbox.DeleteEventsByUserIDs(ctx, userIDs)
+	if err != nil {
+		return nil, fmt.Errorf("Account.repoOutbox.DeleteEventsByUserIDs: %w", err)
+	}
+
+	_, err = a.repoAuditLog.DeleteAuditLogsByUserIDs(ctx, userIDs)
+	if err != nil {
+		return nil, fmt.Errorf("Account.repoAuditLog.DeleteAuditLogsByUserIDs: %w", err)
+	}
+
+	return userIDs, nil
+}
+
+// UpdateUserStatus activate, suspend or disable any user, admin only. Sessions
+// of suspended or disabled user are revoked, the audit log is appended in the
+// same transaction. Failure after the admin is authenticated is audited too.
+func (a *Account) UpdateUserStatus(ctx context.Context, req gouser.ReqUpdateUserStatus) error {
+	err := req.Validate()
+	if err != nil {
+		err := fmt.Errorf("ReqUpdateUserStatus.Validate: %w", err)
+		return fmt.Errorf("%w: %w", gouser.ErrRequestInvalid, err)
+	}
+
+	claims, err := a.guard.authenticateAdmin(ctx, req.UserJWT)
+	if err != nil {
+		return fmt.Errorf("Account.guard.authenticateAdmin: %w", err)
+	}
+
+	auditLog := entity.AuditLog{
+		ActorUserID:  auditUserID(claims.UserID),
+		TargetUserID: auditUserID(req.UserID),
+		Action:       entity.AuditActionUserStatusUpdate,
+		Detail:       fmt.Sprintf("status '%s'", req.Status),
+		IP:           req.IP,
+		UserAgent:    req.UserAgent,
+		RequestID:    req.RequestID,
+	}
+
+	err = a.updateUserStatus(ctx, claims.UserID, req.ToEntityUser(), auditLog)
+	if err != nil {
+		a.auditor.record(ctx, auditLog, err)
+		return fmt.Errorf("Account.updateUserStatus: %w", err)
+	}
+
+	return nil
+}
+
+// updateUserStatus is UpdateUserStatus after the admin is authenticated,
+// auditLog is appended in the transaction which update the status.
+func (a *Account) updateUserStatus(ctx context.Context, adminUserID int64, user entity.User, auditLog entity.AuditLog) error {
+	if adminUserID == user.ID {
+		return fmt.Errorf("%w: admin can not update own status", gouser.ErrForbidden)
+	}
+
+	if user.Status == entity.UserStatusSuspended && !user.SuspendedUntil.After(time.Now()) {
+		return fmt.Errorf("%w: suspended until must be in the future", gouser.ErrRequestInvalid)
+	}
+
+	err := a.transactor.WithinTx(ctx, func(ctx context.Context) error {
+		err := a.repoAccount.UpdateUserStatus(ctx, user)
+		if err != nil {
+			return fmt.Errorf("Account.repoAccount.UpdateUserStatus: %w", err)
+		}
+
+		if user.Status != entity.UserStatusActive {
+			err = a.repoSession.RevokeSessionsByUserID(ctx, user.ID)
+			if err != nil {
+				return fmt.Errorf("Account.repoSession.RevokeSessionsByUserID: %w", err)
+			}
+		}
+
+		err = a.auditor.recordWithinTx(ctx, auditLog)
+		if err != nil {
+			return fmt.Errorf("Account.auditor.recordWithinTx: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("Account.transactor.WithinTx: %w", err)
+	}
+
+	return nil
+}
+
+// ChangeUsername change username of the user who own the JWT. The old username
+// keeps resolving to the user and can not be claimed by other user within
+// history grace period. User can change username once per cooldown period.
+// Both old and new username and the user are locked while cooldown and new
+// username are checked and new username is claimed, the audit log is appended
+// in the same transaction. Failure after authentication is audited too.
+func (a *Account) ChangeUsername(ctx context.Context, req gouser.ReqChangeUsername) error {
+	req.Username = gouser.NormalizeUsername(req.Username)
+
+	err := req.Validate()
+	if err != nil {
+		err := fmt.Errorf("ReqChangeUsername.Validate: %w", err)
+		return fmt.Errorf("%w: %w", gouser.ErrRequestInvalid, err)
+	}
+
+	_, user, err := a.guard.authenticateUser(ctx, req.UserJWT)
+	if err != nil {
+		return fmt.Errorf("Account.guard.authenticateUser: %w", err)
+	}
+
+	auditLog := entity.AuditLog{
+		ActorUserID:  auditUserID(user.ID),
+		TargetUserID: auditUserID(user.ID),
+		Action:       entity.AuditActionUsernameChange,
+		Detail:       fmt.Sprintf("username '%s' to '%s'", user.Username, req.Username),
+		IP:           req.IP,
+		UserAgent:    req.UserAgent,
+		RequestID:    req.RequestID,
+	}
+
+	err = a.changeUsername(ctx, user, req.Username, auditLog)
+	if err != nil {
+		a.auditor.record(ctx, auditLog, err)
+		return fmt.Errorf("Account.changeUsername: %w", err)
+	}
+
+	return nil
+}
+
+// changeUsername is ChangeUsername after authentication, auditLog is appended
+// in the transaction which change the username.
+func (a *Account) changeUsername(ctx context.Context, user entity.User, username string, auditLog entity.AuditLog) error {
+	if username == user.Username {
+		return fmt.Errorf("%w: username is unchanged", gouser.ErrNothingToBeUpdate)
+	}
+
+	err := a.transactor.WithinTx(ctx, func(ctx context.Context) error {
+		err := lockUsernames(ctx, a.repoAccount, user.Username, username)
+		if err != nil {
+			return fmt.Errorf("lockUsernames: %w", err)
+		}
+
+		err = a.repoAccount.LockUser(ctx, user.ID)
+		if err != nil {
+			return fmt.Errorf("Account.repoAccount.LockUser: %w", err)
+		}
+
+		now := time.Now()
+
+		err = a.checkUsernameChangeCooldown(ctx, user.ID, now)
+		if err != nil {
+			return fmt.Errorf("Account.checkUsernameChangeCooldown: %w", err)
+		}
+
+		err = checkUsernameClaimable(ctx, a.cfg, a.repoAccount, a.repoProfile, username, user.ID)
+		if err != nil {
+			return fmt.Errorf("checkUsernameClaimable: %w", err)
+		}
+
+		err = a.repoProfile.ChangeUsername(ctx, user.ID, username, now)
+		if err != nil {
+			return fmt.Errorf("Account.repoProfile.ChangeUsername: %w", err)
+		}
+
+		err = a.auditor.recordWithinTx(ctx, auditLog)
+		if err != nil {
+			return fmt.Errorf("Account.auditor.recordWithinTx: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("Account.transactor.WithinTx: %w", err)
+	}
+
+	return nil
+}
+
+// checkUsernameChangeCooldown return gouser.ErrUsernameChangeCooldown when the
+// user changed username within cooldown period. Call it holding the user lock
+// so concurrent changes see each other.
+func (a *Account) checkUsernameChangeCooldown(ctx context.Context, userID int64, now time.Time) error {
+	cooldown := a.getUsernameChangeCooldown()
+	if cooldown <= 0 {
+		return nil
+	}
+
+	lastChangedAt, err := a.repoProfile.GetLastUsernameChangedAt(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("Account.repoProfile.GetLastUsernameChangedAt: %w", err)
+	}
+	if lastChangedAt != nil && now.Before(lastChangedAt.Add(cooldown)) {
+		return fmt.Errorf("%w: next change is allowed after %s", gouser.ErrUsernameChangeCooldown, lastChangedAt.Add(cooldown).Format(time.RFC3339))
+	}
+
+	return nil
+}
+
+func (a *Account) getUsernameChangeCooldown() time.Duration {
+	return time.Duration(a.cfg.Username.ChangeCooldownHour) * time.Hour
+}
+
+func (a *Account) getDeletedRetention() time.Duration {
+	return time.Duration(a.cfg.Account.DeletedRetentionHour) * time.Hour
+}
@@ -0,0 +1,1325 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Hidayathamir/go-user/config"
+	"github.com/Hidayathamir/go-user/internal/pkg/auth"
+	"github.com/Hidayathamir/go-user/internal/repo/db/entity"
+	"github.com/Hidayathamir/go-user/internal/repo/mockrepo"
+	"github.com/Hidayathamir/go-user/pkg/gouser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+const hashedMyPassword = "$2a$10$KrDmeYfFUKWtTn9aS1ZrQ.L6WG0l0aQUStjxfOnm4U8gH9MqWrFKO" // hashed of "mypassword"
+
+func TestUnitAccountDeleteAccount(t *testing.T) {
+	t.Parallel()
+
+	t.Run("delete account success", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoAccount := mockrepo.NewMockIAccount(ctrl)
+		repoProfile := mockrepo.NewMockIProfile(ctrl)
+		repoSession := mockrepo.NewMockISession(ctrl)
+		transactor := mockrepo.NewMockITransactor(ctrl)
+		repoAuditLog := mockrepo.NewMockIAuditLog(ctrl)
+
+		cfg := config.Config{
+			JWT: config.JWT{ExpireHour: 24, SignedKey: "secretsignkey"},
+		}
+
+		a := &Account{
+			cfg:         cfg,
+			guard:       newGuard(cfg, repoSession, repoProfile),
+			repoAccount: repoAccount,
+			repoSession: repoSession,
+			transactor:  transactor,
+			auditor:     newAuditor(cfg, repoAuditLog),
+		}
+
+		repoSession.EXPECT().
+			GetSessionByJTI(gomock.Any(), "jti1").
+			Return(entity.Session{ID: 1, UserID: 44, JTI: "jti1"}, nil)
+		repoSession.EXPECT().UpdateSessionLastSeenAt(gomock.Any(), int64(1), gomock.Any()).Return(nil)
+		repoProfile.EXPECT().
+			GetProfileByUserID(gomock.Any(), int64(44)).
+			Return(entity.User{ID: 44, Username: "hidayat", Password: hashedMyPassword, Status: entity.UserStatusActive}, nil)
+		repoAccount.EXPECT().LockUsername(gomock.Any(), "hidayat").Return(nil)
+		repoSession.EXPECT().RevokeSessionsByUserID(gomock.Any(), int64(44)).Return(nil)
+		repoAccount.EXPECT().SoftDeleteUser(gomock.Any(), int64(44), gomock.Any()).Return(nil)
+
+		inTx := false
+		transactor.EXPECT().
+			WithinTx(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+				inTx = true
+				defer func() { inTx = false }()
+				return fn(ctx)
+			})
+
+		var auditLog entity.AuditLog
+		auditedInTx := false
+		repoAuditLog.EXPECT().
+			CreateAuditLog(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, a entity.AuditLog) error {
+				auditLog = a
+				auditedInTx = inTx
+				return nil
+			})
+
+		err := a.DeleteAccount(context.Background(), gouser.ReqDeleteAccount{
+			UserJWT:   auth.GenerateUserJWTToken(44, "jti1", cfg),
+			Password:  "mypassword",
+			UserAgent: "Mozilla/5.0",
+			IP:        "10.0.0.1",
+			RequestID: "req-1",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, entity.AuditActionUserDelete, auditLog.Action)
+		assert.Equal(t, entity.AuditResultSuccess, auditLog.Result)
+		require.NotNil(t, auditLog.ActorUserID)
+		assert.Equal(t, int64(44), *auditLog.ActorUserID)
+		require.NotNil(t, auditLog.TargetUserID)
+		assert.Equal(t, int64(44), *auditLog.TargetUserID)
+		assert.True(t, auditedInTx)
+		assert.Equal(t, "10.0.0.1", auditLog.IP)
+		assert.Equal(t, "Mozilla/5.0", auditLog.UserAgent)
+		assert.Equal(t, "req-1", auditLog.RequestID)
+	})
+	t.Run("wrong password should return error", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoAccount := mockrepo.NewMockIAccount(ctrl)
+		repoProfile := mockrepo.NewMockIProfile(ctrl)
+		repoSession := mockrepo.NewMockISession(ctrl)
+		repoAuditLog := mockrepo.NewMockIAuditLog(ctrl)
+
+		cfg := config.Config{
+			JWT: config.JWT{ExpireHour: 24, SignedKey: "secretsignkey"},
+		}
+
+		a := &Account{
+			cfg:         cfg,
+			guard:       newGuard(cfg, repoSession, repoProfile),
+			repoAccount: repoAccount,
+			repoSession: repoSession,
+			auditor:     newAuditor(cfg, repoAuditLog),
+		}
+
+		repoSession.EXPECT().
+			GetSessionByJTI(gomock.Any(), "jti1").
+			Return(entity.Session{ID: 1, UserID: 44, JTI: "jti1"}, nil)
+		repoSession.EXPECT().UpdateSessionLastSeenAt(gomock.Any(), int64(1), gomock.Any()).Return(nil)
+		repoProfile.EXPECT().
+			GetProfileByUserID(gomock.Any(), int64(44)).
+			Return(entity.User{ID: 44, Password: hashedMyPassword, Status: entity.UserStatusActive}, nil)
+
+		var auditLog entity.AuditLog
+		repoAuditLog.EXPECT().
+			CreateAuditLog(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, a entity.AuditLog) error {
+				auditLog = a
+				return nil
+			})
+
+		err := a.DeleteAccount(context.Background(), gouser.ReqDeleteAccount{
+			UserJWT:  auth.GenerateUserJWTToken(44, "jti1", cfg),
+			Password: "wrongpassword",
+		})
+
+		require.Error(t, err)
+		require.ErrorIs(t, err, gouser.ErrWrongPassword)
+		assert.Equal(t, entity.AuditActionUserDelete, auditLog.Action)
+		assert.Equal(t, entity.AuditResultFailure, auditLog.Result)
+		require.NotNil(t, auditLog.ActorUserID)
+		assert.Equal(t, int64(44), *auditLog.ActorUserID)
+		require.NotNil(t, auditLog.TargetUserID)
+		assert.Equal(t, int64(44), *auditLog.TargetUserID)
+	})
+	t.Run("call repo SoftDeleteUser error should return error", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoAccount := mockrepo.NewMockIAccount(ctrl)
+		repoProfile := mockrepo.NewMockIProfile(ctrl)
+		repoSession := mockrepo.NewMockISession(ctrl)
+		transactor := mockrepo.NewMockITransactor(ctrl)
+		repoAuditLog := mockrepo.NewMockIAuditLog(ctrl)
+
+		cfg := config.Config{
+			JWT: config.JWT{ExpireHour: 24, SignedKey: "secretsignkey"},
+		}
+
+		a := &Account{
+			cfg:         cfg,
+			guard:       newGuard(cfg, repoSession, repoProfile),
+			repoAccount: repoAccount,
+			repoSession: repoSession,
+			transactor:  transactor,
+			auditor:     newAuditor(cfg, repoAuditLog),
+		}
+
+		repoSession.EXPECT().
+			GetSessionByJTI(gomock.Any(), "jti1").
+			Return(entity.Session{ID: 1, UserID: 44, JTI: "jti1"}, nil)
+		repoSession.EXPECT().UpdateSessionLastSeenAt(gomock.Any(), int64(1), gomock.Any()).Return(nil)
+		repoProfile.EXPECT().
+			GetProfileByUserID(gomock.Any(), int64(44)).
+			Return(entity.User{ID: 44, Username: "hidayat", Password: hashedMyPassword, Status: entity.UserStatusActive}, nil)
+		repoAccount.EXPECT().LockUsername(gomock.Any(), "hidayat").Return(nil)
+		repoSession.EXPECT().RevokeSessionsByUserID(gomock.Any(), int64(44)).Return(nil)
+		repoAccount.EXPECT().SoftDeleteUser(gomock.Any(), int64(44), gomock.Any()).Return(assert.AnError)
+
+		transactor.EXPECT().
+			WithinTx(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+				return fn(ctx)
+			})
+
+		var auditLog entity.AuditLog
+		repoAuditLog.EXPECT().
+			CreateAuditLog(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, a entity.AuditLog) error {
+				auditLog = a
+				return nil
+			})
+
+		err := a.DeleteAccount(context.Background(), gouser.ReqDeleteAccount{
+			UserJWT:  auth.GenerateUserJWTToken(44, "jti1", cfg),
+			Password: "mypassword",
+		})
+
+		require.Error(t, err)
+		require.ErrorIs(t, err, assert.AnError)
+		assert.Equal(t, entity.AuditActionUserDelete, auditLog.Action)
+		assert.Equal(t, entity.AuditResultFailure, auditLog.Result)
+		require.NotNil(t, auditLog.ActorUserID)
+		assert.Equal(t, int64(44), *auditLog.ActorUserID)
+		require.NotNil(t, auditLog.TargetUserID)
+		assert.Equal(t, int64(44), *auditLog.TargetUserID)
+	})
+	t.Run("call repo CreateAuditLog error should roll back delete and record failure", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoAccount := mockrepo.NewMockIAccount(ctrl)
+		repoProfile := mockrepo.NewMockIProfile(ctrl)
+		repoSession := mockrepo.NewMockISession(ctrl)
+		transactor := mockrepo.NewMockITransactor(ctrl)
+		repoAuditLog := mockrepo.NewMockIAuditLog(ctrl)
+
+		cfg := config.Config{
+			JWT: config.JWT{ExpireHour: 24, SignedKey: "secretsignkey"},
+		}
+
+		a := &Account{
+			cfg:         cfg,
+			guard:       newGuard(cfg, repoSession, repoProfile),
+			repoAccount: repoAccount,
+			repoSession: repoSession,
+			transactor:  transactor,
+			auditor:     newAuditor(cfg, repoAuditLog),
+		}
+
+		repoSession.EXPECT().
+			GetSessionByJTI(gomock.Any(), "jti1").
+			Return(entity.Session{ID: 1, UserID: 44, JTI: "jti1"}, nil)
+		repoSession.EXPECT().UpdateSessionLastSeenAt(gomock.Any(), int64(1), gomock.Any()).Return(nil)
+		repoProfile.EXPECT().
+			GetProfileByUserID(gomock.Any(), int64(44)).
+			Return(entity.User{ID: 44, Username: "hidayat", Password: hashedMyPassword, Status: entity.UserStatusActive}, nil)
+		repoAccount.EXPECT().LockUsername(gomock.Any(), "hidayat").Return(nil)
+		repoSession.EXPECT().RevokeSessionsByUserID(gomock.Any(), int64(44)).Return(nil)
+		repoAccount.EXPECT().SoftDeleteUser(gomock.Any(), int64(44), gomock.Any()).Return(nil)
+
+		// Transaction is rolled back because fn return error.
+		transactor.EXPECT().
+			WithinTx(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+				return fn(ctx)
+			})
+
+		var auditLog entity.AuditLog
+		gomock.InOrder(
+			repoAuditLog.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any()).Return(assert.AnError),
+			repoAuditLog.EXPECT().
+				CreateAuditLog(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, a entity.AuditLog) error {
+					auditLog = a
+					return nil
+				}),
+		)
+
+		err := a.DeleteAccount(context.Background(), gouser.ReqDeleteAccount{
+			UserJWT:  auth.GenerateUserJWTToken(44, "jti1", cfg),
+			Password: "mypassword",
+		})
+
+		require.Error(t, err)
+		require.ErrorIs(t, err, assert.AnError)
+		assert.Equal(t, entity.AuditActionUserDelete, auditLog.Action)
+		assert.Equal(t, entity.AuditResultFailure, auditLog.Result)
+	})
+	t.Run("transaction error should return error", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoAccount := mockrepo.NewMockIAccount(ctrl)
+		repoProfile := mockrepo.NewMockIProfile(ctrl)
+		repoSession := mockrepo.NewMockISession(ctrl)
+		transactor := mockrepo.NewMockITransactor(ctrl)
+		repoAuditLog := mockrepo.NewMockIAuditLog(ctrl)
+
+		cfg := config.Config{
+			JWT: config.JWT{ExpireHour: 24, SignedKey: "secretsignkey"},
+		}
+
+		a := &Account{
+			cfg:         cfg,
+			guard:       newGuard(cfg, repoSession, repoProfile),
+			repoAccount: repoAccount,
+			repoSession: repoSession,
+			transactor:  transactor,
+			auditor:     newAuditor(cfg, repoAuditLog),
+		}
+
+		repoSession.EXPECT().
+			GetSessionByJTI(gomock.Any(), "jti1").
+			Return(entity.Session{ID: 1, UserID: 44, JTI: "jti1"}, nil)
+		repoSession.EXPECT().UpdateSessionLastSeenAt(gomock.Any(), int64(1), gomock.Any()).Return(nil)
+		repoProfile.EXPECT().
+			GetProfileByUserID(gomock.Any(), int64(44)).
+			Return(entity.User{ID: 44, Password: hashedMyPassword, Status: entity.UserStatusActive}, nil)
+		transactor.EXPECT().WithinTx(gomock.Any(), gomock.Any()).Return(assert.AnError)
+
+		var auditLog entity.AuditLog
+		repoAuditLog.EXPECT().
+			CreateAuditLog(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, a entity.AuditLog) error {
+				auditLog = a
+				return nil
+			})
+
+		err := a.DeleteAccount(context.Background(), gouser.ReqDeleteAccount{
+			UserJWT:  auth.GenerateUserJWTToken(44, "jti1", cfg),
+			Password: "mypassword",
+		})
+
+		require.Error(t, err)
+		require.ErrorIs(t, err, assert.AnError)
+		assert.Equal(t, entity.AuditActionUserDelete, auditLog.Action)
+		assert.Equal(t, entity.AuditResultFailure, auditLog.Result)
+		require.NotNil(t, auditLog.ActorUserID)
+		assert.Equal(t, int64(44), *auditLog.ActorUserID)
+		require.NotNil(t, auditLog.TargetUserID)
+		assert.Equal(t, int64(44), *auditLog.TargetUserID)
+	})
+	t.Run("request validate error should return error", func(t *testing.T) {
+		t.Parallel()
+
+		a := &Account{cfg: config.Config{}}
+
+		err := a.DeleteAccount(context.Background(), gouser.ReqDeleteAccount{UserJWT: "jwt"})
+
+		require.Error(t, err)
+		require.ErrorIs(t, err, gouser.ErrRequestInvalid)
+	})
+}
+
+func TestUnitAccountRestoreAccount(t *testing.T) {
+	t.Parallel()
+
+	t.Run("restore account success", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoAccount := mockrepo.NewMockIAccount(ctrl)
+		transactor := mockrepo.NewMockITransactor(ctrl)
+		repoAuditLog := mockrepo.NewMockIAuditLog(ctrl)
+
+		a := &Account{
+			cfg: config.Config{
+				Account: config.Account{DeletedRetentionHour: 24},
+			},
+			repoAccount: repoAccount,
+			transactor:  transactor,
+			auditor:     newAuditor(config.Config{}, repoAuditLog),
+		}
+
+		repoAccount.EXPECT().
+			GetDeletedProfileByUsername(gomock.Any(), "hidayat", gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, deletedAfter time.Time) (entity.User, error) {
+				assert.WithinDuration(t, time.Now().Add(-24*time.Hour), deletedAfter, time.Minute)
+				return entity.User{ID: 44, Username: "hidayat", Password: hashedMyPassword}, nil
+			})
+		repoAccount.EXPECT().LockUsername(gomock.Any(), "hidayat").Return(nil)
+		repoAccount.EXPECT().RestoreUser(gomock.Any(), int64(44)).Return(nil)
+
+		transactor.EXPECT().
+			WithinTx(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+				return fn(ctx)
+			})
+
+		var auditLog entity.AuditLog
+		repoAuditLog.EXPECT().
+			CreateAuditLog(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, a entity.AuditLog) error {
+				auditLog = a
+				return nil
+			})
+
+		err := a.RestoreAccount(context.Background(), gouser.ReqRestoreAccount{
+			Username: "hidayat",
+			Password: "mypassword",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, entity.AuditActionUserRestore, auditLog.Action)
+		assert.Equal(t, entity.AuditResultSuccess, auditLog.Result)
+		require.NotNil(t, auditLog.ActorUserID)
+		assert.Equal(t, int64(44), *auditLog.ActorUserID)
+		require.NotNil(t, auditLog.TargetUserID)
+		assert.Equal(t, int64(44), *auditLog.TargetUserID)
+	})
+	t.Run("deleted user not found should return error", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoAccount := mockrepo.NewMockIAccount(ctrl)
+		repoAuditLog := mockrepo.NewMockIAuditLog(ctrl)
+
+		a := &Account{
+			cfg:         config.Config{},
+			repoAccount: repoAccount,
+			auditor:     newAuditor(config.Config{}, repoAuditLog),
+		}
+
+		repoAccount.EXPECT().
+			GetDeletedProfileByUsername(gomock.Any(), "hidayat", gomock.Any()).
+			Return(entity.User{}, gouser.ErrUnknownUsername)
+
+		err := a.RestoreAccount(context.Background(), gouser.ReqRestoreAccount{
+			Username: "hidayat",
+			Password: "mypassword",
+		})
+
+		require.Error(t, err)
+		require.ErrorIs(t, err, gouser.ErrUnknownUsername)
+	})
+	t.Run("wrong password should return error", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoAccount := mockrepo.NewMockIAccount(ctrl)
+		repoAuditLog := mockrepo.NewMockIAuditLog(ctrl)
+
+		a := &Account{
+			cfg:         config.Config{},
+			repoAccount: repoAccount,
+			auditor:     newAuditor(config.Config{}, repoAuditLog),
+		}
+
+		repoAccount.EXPECT().
+			GetDeletedProfileByUsername(gomock.Any(), "hidayat", gomock.Any()).
+			Return(entity.User{ID: 44, Password: hashedMyPassword}, nil)
+
+		var auditLog entity.AuditLog
+		repoAuditLog.EXPECT().
+			CreateAuditLog(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, a entity.AuditLog) error {
+				auditLog = a
+				return nil
+			})
+
+		err := a.RestoreAccount(context.Background(), gouser.ReqRestoreAccount{
+			Username: "hidayat",
+			Password: "wrongpassword",
+		})
+
+		require.Error(t, err)
+		require.ErrorIs(t, err, gouser.ErrWrongPassword)
+		assert.Equal(t, entity.AuditActionUserRestore, auditLog.Action)
+		assert.Equal(t, entity.AuditResultFailure, auditLog.Result)
+		require.NotNil(t, auditLog.ActorUserID)
+		assert.Equal(t, int64(44), *auditLog.ActorUserID)
+		require.NotNil(t, auditLog.TargetUserID)
+		assert.Equal(t, int64(44), *auditLog.TargetUserID)
+	})
+	t.Run("request validate error should return error", func(t *testing.T) {
+		t.Parallel()
+
+		a := &Account{cfg: config.Config{}}
+
+		err := a.RestoreAccount(context.Background(), gouser.ReqRestoreAccount{Username: "hidayat"})
+
+		require.Error(t, err)
+		require.ErrorIs(t, err, gouser.ErrRequestInvalid)
+	})
+}
+
+func TestUnitAccountPurgeDeletedAccounts(t *testing.T) {
+	t.Parallel()
+
+	t.Run("purge deleted accounts success should purge in batches with user data", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoAccount := mockrepo.NewMockIAccount(ctrl)
+		repoOutbox := mockrepo.NewMockIOutbox(ctrl)
+		repoWebhook := mockrepo.NewMockIWebhook(ctrl)
+		repoAuditLog := mockrepo.NewMockIAuditLog(ctrl)
+		transactor := mockrepo.NewMockITransactor(ctrl)
+
+		a := &Account{
+			cfg: config.Config{
+				Account: config.Account{DeletedRetentionHour: 720, PurgeBatchSize: 2},
+			},
+			repoAccount:  repoAccount,
+			repoOutbox:   repoOutbox,
+			repoWebhook:  repoWebhook,
+			repoAuditLog: repoAuditLog,
+			transactor:   transactor,
+		}
+
+		transactor.EXPECT().
+			WithinTx(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+				return fn(ctx)
+			}).
+			Times(2)
+
+		gomock.InOrder(
+			repoAccount.EXPECT().
+				PurgeDeletedUsers(gomock.Any(), gomock.Any(), uint64(2)).
+				DoAndReturn(func(_ context.Context, deletedBefore time.Time, _ uint64) ([]int64, error) {
+					assert.WithinDuration(t, time.Now().Add(-720*time.Hour), deletedBefore, time.Minute)
+					return []int64{44, 45}, nil
+				}),
+			repoWebhook.EXPECT().DeleteDeliveriesByUserIDs(gomock.Any(), []int64{44, 45}).Return(int64(1), nil),
+			repoOutbox.EXPECT().DeleteEventsByUserIDs(gomock.Any(), []int64{44, 45}).Return(int64(4), nil),
+			repoAuditLog.EXPECT().DeleteAuditLogsByUserIDs(gomock.Any(), []int64{44, 45}).Return(int64(6), nil),
+			repoAccount.EXPECT().PurgeDeletedUsers(gomock.Any(), gomock.Any(), uint64(2)).Return([]int64{46}, nil),
+			repoWebhook.EXPECT().DeleteDeliveriesByUserIDs(gomock.Any(), []int64{46}).Return(int64(0), nil),
+			repoOutbox.EXPECT().DeleteEventsByUserIDs(gomock.Any(), []int64{46}).Return(int64(2), nil),
+			repoAuditLog.EXPECT().DeleteAuditLogsByUserIDs(gomock.Any(), []int64{46}).Return(int64(3), nil),
+		)
+
+		count, err := a.PurgeDeletedAccounts(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), count)
+	})
+	t.Run("no deleted user should not delete user data", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoAccount := mockrepo.NewMockIAccount(ctrl)
+		transactor := mockrepo.NewMockITransactor(ctrl)
+
+		a := &Account{
+			cfg: config.Config{
+				Account: config.Account{PurgeBatchSize: 2},
+			},
+			repoAccount: repoAccount,
+			transactor:  transactor,
+		}
+
+		transactor.EXPECT().
+			WithinTx(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+				return fn(ctx)
+			})
+		repoAccount.EXPECT().PurgeDeletedUsers(gomock.Any(), gomock.Any(), uint64(2)).Return([]int64{}, nil)
+
+		count, err := a.PurgeDeletedAccounts(context.Background())
+
+		require.NoError(t, err)
+		assert.Zero(t, count)
+	})
+	t.Run("call repo PurgeDeletedUsers error should return error", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoAccount := mockrepo.NewMockIAccount(ctrl)
+		transactor := mockrepo.NewMockITransactor(ctrl)
+
+		a := &Account{
+			cfg: config.Config{
+				Account: config.Account{PurgeBatchSize: 2},
+			},
+			repoAccount: repoAccount,
+			transactor:  transactor,
+		}
+
+		transactor.EXPECT().
+			WithinTx(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+				return fn(ctx)
+			})
+		repoAccount.EXPECT().PurgeDeletedUsers(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil, assert.AnError)
+
+		count, err := a.PurgeDeletedAccounts(context.Background())
+
+		require.Error(t, err)
+		require.ErrorIs(t, err, assert.AnError)
+		assert.Zero(t, count)
+	})
+	t.Run("call repo DeleteAuditLogsByUserIDs error should return error", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoAccount := mockrepo.NewMockIAccount(ctrl)
+		repoOutbox := mockrepo.NewMockIOutbox(ctrl)
+		repoWebhook := mockrepo.NewMockIWebhook(ctrl)
+		repoAuditLog := mockrepo.NewMockIAuditLog(ctrl)
+		transactor := mockrepo.NewMockITransactor(ctrl)
+
+		a := &Account{
+			cfg: config.Config{
+				Account: config.Account{PurgeBatchSize: 2},
+			},
+			repoAccount:  repoAccount,
+			repoOutbox:   repoOutbox,
+			repoWebhook:  repoWebhook,
+			repoAuditLog: repoAuditLog,
+			transactor:   transactor,
+		}
+
+		transactor.EXPECT().
+			WithinTx(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+				return fn(ctx)
+			})
+		repoAccount.EXPECT().PurgeDeletedUsers(gomock.Any(), gomock.Any(), gomock.Any()).Return([]int64{44}, nil)
+		repoWebhook.EXPECT().DeleteDeliveriesByUserIDs(gomock.Any(), gomock.Any()).Return(int64(0), nil)
+		repoOutbox.EXPECT().DeleteEventsByUserIDs(gomock.Any(), gomock.Any()).Return(int64(0), nil)
+		repoAuditLog.EXPECT().DeleteAuditLogsByUserIDs(gomock.Any(), gomock.Any()).Return(int64(0), assert.AnError)
+
+		count, err := a.PurgeDeletedAccounts(context.Background())
+
+		require.Error(t, err)
+		require.ErrorIs(t, err, assert.AnError)
+		assert.Zero(t, count)
+	})
+}
+
+func TestUnitAccountUpdateUserStatus(t *testing.T) {
+	t.Parallel()
+
+	t.Run("suspend user success should revoke sessions", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoAccount := mockrepo.NewMockIAccount(ctrl)
+		repoProfile := mockrepo.NewMockIProfile(ctrl)
+		repoSession := mockrepo.NewMockISession(ctrl)
+		transactor := mockrepo.NewMockITransactor(ctrl)
+		repoAuditLog := mockrepo.NewMockIAuditLog(ctrl)
+
+		cfg := config.Config{
+			JWT: config.JWT{ExpireHour: 24, SignedKey: "secretsignkey"},
+		}
+
+		a := &Account{
+			cfg:         cfg,
+			guard:       newGuard(cfg, repoSession, repoProfile),
+			repoAccount: repoAccount,
+			repoSession: repoSession,
+			transactor:  transactor,
+			auditor:     newAuditor(cfg, repoAuditLog),
+		}
+
+		suspendedUntil := time.Now().Add(time.Hour)
+		repoSession.EXPECT().
+			GetSessionByJTI(gomock.Any(), "jtiadmin").
+			Return(entity.Session{ID: 1, UserID: 1, JTI: "jtiadmin"}, nil)
+		repoSession.EXPECT().UpdateSessionLastSeenAt(gomock.Any(), int64(1), gomock.Any()).Return(nil)
+		repoProfile.EXPECT().
+			GetProfileByUserID(gomock.Any(), int64(1)).
+			Return(entity.User{ID: 1, Role: entity.UserRoleAdmin, Status: entity.UserStatusActive}, nil)
+		repoAccount.EXPECT().
+			UpdateUserStatus(gomock.Any(), entity.User{
+				ID:               44,
+				Status:           entity.UserStatusSuspended,
+				SuspendedUntil:   &suspendedUntil,
+				SuspensionReason: "spam",
+			}).Return(nil)
+		repoSession.EXPECT().RevokeSessionsByUserID(gomock.Any(), int64(44)).Return(nil)
+
+		transactor.EXPECT().
+			WithinTx(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+				return fn(ctx)
+			})
+
+		var auditLog entity.AuditLog
+		repoAuditLog.EXPECT().
+			CreateAuditLog(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, a entity.AuditLog) error {
+				auditLog = a
+				return nil
+			})
+
+		err := a.UpdateUserStatus(context.Background(), gouser.ReqUpdateUserStatus{
+			UserJWT:          auth.GenerateUserJWTToken(1, "jtiadmin", cfg),
+			UserID:           44,
+			Status:           entity.UserStatusSuspended,
+			SuspendedUntil:   &suspendedUntil,
+			SuspensionReason: "spam",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, entity.AuditActionUserStatusUpdate, auditLog.Action)
+		assert.Equal(t, entity.AuditResultSuccess, auditLog.Result)
+		require.NotNil(t, auditLog.ActorUserID)
+		assert.Equal(t, int64(1), *auditLog.ActorUserID)
+		require.NotNil(t, auditLog.TargetUserID)
+		assert.Equal(t, int64(44), *auditLog.TargetUserID)
+	})
+	t.Run("activate user should clear suspension and keep sessions", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoAccount := mockrepo.NewMockIAccount(ctrl)
+		repoProfile := mockrepo.NewMockIProfile(ctrl)
+		repoSession := mockrepo.NewMockISession(ctrl)
+		transactor := mockrepo.NewMockITransactor(ctrl)
+		repoAuditLog := mockrepo.NewMockIAuditLog(ctrl)
+
+		cfg := config.Config{
+			JWT: config.JWT{ExpireHour: 24, SignedKey: "secretsignkey"},
+		}
+
+		a := &Account{
+			cfg:         cfg,
+			guard:       newGuard(cfg, repoSession, repoProfile),
+			repoAccount: repoAccount,
+			repoSession: repoSession,
+			transactor:  transactor,
+			auditor:     newAuditor(cfg, repoAuditLog),
+		}
+
+		suspendedUntil := time.Now().Add(time.Hour)
+		repoSession.EXPECT().
+			GetSessionByJTI(gomock.Any(), "jtiadmin").
+			Return(entity.Session{ID: 1, UserID: 1, JTI: "jtiadmin"}, nil)
+		repoSession.EXPECT().UpdateSessionLastSeenAt(gomock.Any(), int64(1), gomock.Any()).Return(nil)
+		repoProfile.EXPECT().
+			GetProfileByUserID(gomock.Any(), int64(1)).
+			Return(entity.User{ID: 1, Role: entity.UserRoleAdmin, Status: entity.UserStatusActive}, nil)
+		repoAccount.EXPECT().
+			UpdateUserStatus(gomock.Any(), entity.User{ID: 44, Status: entity.UserStatusActive}).
+			Return(nil)
+
+		transactor.EXPECT().
+			WithinTx(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+				return fn(ctx)
+			})
+
+		var auditLog entity.AuditLog
+		repoAuditLog.EXPECT().
+			CreateAuditLog(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, a entity.AuditLog) error {
+				auditLog = a
+				return nil
+			})
+
+		err := a.UpdateUserStatus(context.Background(), gouser.ReqUpdateUserStatus{
+			UserJWT:          auth.GenerateUserJWTToken(1, "jtiadmin", cfg),
+			UserID:           44,
+			Status:           entity.UserStatusActive,
+			SuspendedUntil:   &suspendedUntil,
+			SuspensionReason: "spam",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, entity.AuditActionUserStatusUpdate, auditLog.Action)
+		assert.Equal(t, entity.AuditResultSuccess, auditLog.Result)
+		require.NotNil(t, auditLog.ActorUserID)
+		assert.Equal(t, int64(1), *auditLog.ActorUserID)
+		require.NotNil(t, auditLog.TargetUserID)
+		assert.Equal(t, int64(44), *auditLog.TargetUserID)
+	})
+	t.Run("not admin should return error forbidden", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoAccount := mockrepo.NewMockIAccount(ctrl)
+		repoProfile := mockrepo.NewMockIProfile(ctrl)
+		repoSession := mockrepo.NewMockISession(ctrl)
+		repoAuditLog := mockrepo.NewMockIAuditLog(ctrl)
+
+		cfg := config.Config{
+			JWT: config.JWT{ExpireHour: 24, SignedKey: "secretsignkey"},
+		}
+
+		a := &Account{
+			cfg:         cfg,
+			guard:       newGuard(cfg, repoSession, repoProfile),
+			repoAccount: repoAccount,
+			repoSession: repoSession,
+			auditor:     newAuditor(cfg, repoAuditLog),
+		}
+
+		repoSession.EXPECT().
+			GetSessionByJTI(gomock.Any(), "jti2").
+			Return(entity.Session{ID: 2, UserID: 2, JTI: "jti2"}, nil)
+		repoSession.EXPECT().UpdateSessionLastSeenAt(gomock.Any(), int64(2), gomock.Any()).Return(nil)
+		repoProfile.EXPECT().
+			GetProfileByUserID(gomock.Any(), int64(2)).
+			Return(entity.User{ID: 2, Role: entity.UserRoleUser, Status: entity.UserStatusActive}, nil)
+
+		err := a.UpdateUserStatus(context.Background(), gouser.ReqUpdateUserStatus{
+			UserJWT: auth.GenerateUserJWTToken(2, "jti2", cfg),
+			UserID:  44,
+			Status:  entity.UserStatusDisabled,
+		})
+
+		require.Error(t, err)
+		require.ErrorIs(t, err, gouser.ErrForbidden)
+	})
+	t.Run("suspended until in the past should return error", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoAccount := mockrepo.NewMockIAccount(ctrl)
+		repoProfile := mockrepo.NewMockIProfile(ctrl)
+		repoSession := mockrepo.NewMockISession(ctrl)
+		repoAuditLog := mockrepo.NewMockIAuditLog(ctrl)
+
+		cfg := config.Config{
+			JWT: config.JWT{ExpireHour: 24, SignedKey: "secretsignkey"},
+		}
+
+		a := &Account{
+			cfg:         cfg,
+			guard:       newGuard(cfg, repoSession, repoProfile),
+			repoAccount: repoAccount,
+			repoSession: repoSession,
+			auditor:     newAuditor(cfg, repoAuditLog),
+		}
+
+		suspendedUntil := time.Now().Add(-time.Hour)
+		repoSession.EXPECT().
+			GetSessionByJTI(gomock.Any(), "jtiadmin").
+			Return(entity.Session{ID: 1, UserID: 1, JTI: "jtiadmin"}, nil)
+		repoSession.EXPECT().UpdateSessionLastSeenAt(gomock.Any(), int64(1), gomock.Any()).Return(nil)
+		repoProfile.EXPECT().
+			GetProfileByUserID(gomock.Any(), int64(1)).
+			Return(entity.User{ID: 1, Role: entity.UserRoleAdmin, Status: entity.UserStatusActive}, nil)
+
+		var auditLog entity.AuditLog
+		repoAuditLog.EXPECT().
+			CreateAuditLog(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, a entity.AuditLog) error {
+				auditLog = a
+				return nil
+			})
+
+		err := a.UpdateUserStatus(context.Background(), gouser.ReqUpdateUserStatus{
+			UserJWT:        auth.GenerateUserJWTToken(1, "jtiadmin", cfg),
+			UserID:         44,
+			Status:         entity.UserStatusSuspended,
+			SuspendedUntil: &suspendedUntil,
+		})
+
+		require.Error(t, err)
+		require.ErrorIs(t, err, gouser.ErrRequestInvalid)
+		assert.Equal(t, entity.AuditActionUserStatusUpdate, auditLog.Action)
+		assert.Equal(t, entity.AuditResultFailure, auditLog.Result)
+		require.NotNil(t, auditLog.ActorUserID)
+		assert.Equal(t, int64(1), *auditLog.ActorUserID)
+		require.NotNil(t, auditLog.TargetUserID)
+		assert.Equal(t, int64(44), *auditLog.TargetUserID)
+	})
+	t.Run("request validate error should return error", func(t *testing.T) {
+		t.Parallel()
+
+		a := &Account{cfg: config.Config{}}
+
+		err := a.UpdateUserStatus(context.Background(), gouser.ReqUpdateUserStatus{
+			UserJWT: "jwt",
+			UserID:  44,
+			Status:  entity.UserStatusSuspended,
+		})
+
+		require.Error(t, err)
+		require.ErrorIs(t, err, gouser.ErrRequestInvalid)
+	})
+}
+
+func TestUnitAccountChangeUsername(t *testing.T) {
+	t.Parallel()
+
+	t.Run("change username success", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoAccount := mockrepo.NewMockIAccount(ctrl)
+		repoProfile := mockrepo.NewMockIProfile(ctrl)
+		repoSession := mockrepo.NewMockISession(ctrl)
+		transactor := mockrepo.NewMockITransactor(ctrl)
+		repoAuditLog := mockrepo.NewMockIAuditLog(ctrl)
+
+		cfg := config.Config{
+			JWT:      config.JWT{ExpireHour: 24, SignedKey: "secretsignkey"},
+			Account:  config.Account{HoldDeletedUsername: true},
+			Username: config.Username{ChangeCooldownHour: 24, HistoryGraceHour: 48},
+		}
+
+		a := &Account{
+			cfg:         cfg,
+			guard:       newGuard(cfg, repoSession, repoProfile),
+			repoAccount: repoAccount,
+			repoProfile: repoProfile,
+			repoSession: repoSession,
+			transactor:  transactor,
+			auditor:     newAuditor(cfg, repoAuditLog),
+		}
+
+		repoSession.EXPECT().
+			GetSessionByJTI(gomock.Any(), "jti1").
+			Return(entity.Session{ID: 1, UserID: 44, JTI: "jti1"}, nil)
+		repoSession.EXPECT().UpdateSessionLastSeenAt(gomock.Any(), int64(1), gomock.Any()).Return(nil)
+		repoProfile.EXPECT().
+			GetProfileByUserID(gomock.Any(), int64(44)).
+			Return(entity.User{ID: 44, Username: "hidayat", Status: entity.UserStatusActive}, nil)
+
+		transactor.EXPECT().
+			WithinTx(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+				return fn(ctx)
+			})
+		lastChangedAt := time.Now().Add(-25 * time.Hour)
+		gomock.InOrder(
+			repoAccount.EXPECT().LockUsername(gomock.Any(), "hidayat").Return(nil),
+			repoAccount.EXPECT().LockUsername(gomock.Any(), "hidayat2").Return(nil),
+			repoAccount.EXPECT().LockUser(gomock.Any(), int64(44)).Return(nil),
+			repoProfile.EXPECT().GetLastUsernameChangedAt(gomock.Any(), int64(44)).Return(&lastChangedAt, nil),
+		)
+		repoAccount.EXPECT().IsUsernameHeldByDeletedUser(gomock.Any(), "hidayat2").Return(false, nil)
+		repoProfile.EXPECT().
+			GetProfileByOldUsername(gomock.Any(), "hidayat2", gomock.Any()).
+			Return(entity.User{}, gouser.ErrUnknownUsername)
+		repoProfile.EXPECT().ChangeUsername(gomock.Any(), int64(44), "hidayat2", gomock.Any()).Return(nil)
+
+		var auditLog entity.AuditLog
+		repoAuditLog.EXPECT().
+			CreateAuditLog(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, a entity.AuditLog) error {
+				auditLog = a
+				return nil
+			})
+
+		err := a.ChangeUsername(context.Background(), gouser.ReqChangeUsername{
+			UserJWT:  auth.GenerateUserJWTToken(44, "jti1", cfg),
+			Username: "hidayat2",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, entity.AuditActionUsernameChange, auditLog.Action)
+		assert.Equal(t, entity.AuditResultSuccess, auditLog.Result)
+		require.NotNil(t, auditLog.ActorUserID)
+		assert.Equal(t, int64(44), *auditLog.ActorUserID)
+		require.NotNil(t, auditLog.TargetUserID)
+		assert.Equal(t, int64(44), *auditLog.TargetUserID)
+	})
+	t.Run("user can take back own old username", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoAccount := mockrepo.NewMockIAccount(ctrl)
+		repoProfile := mockrepo.NewMockIProfile(ctrl)
+		repoSession := mockrepo.NewMockISession(ctrl)
+		transactor := mockrepo.NewMockITransactor(ctrl)
+		repoAuditLog := mockrepo.NewMockIAuditLog(ctrl)
+
+		cfg := config.Config{
+			JWT:      config.JWT{ExpireHour: 24, SignedKey: "secretsignkey"},
+			Account:  config.Account{HoldDeletedUsername: true},
+			Username: config.Username{ChangeCooldownHour: 24, HistoryGraceHour: 48},
+		}
+
+		a := &Account{
+			cfg:         cfg,
+			guard:       newGuard(cfg, repoSession, repoProfile),
+			repoAccount: repoAccount,
+			repoProfile: repoProfile,
+			repoSession: repoSession,
+			transactor:  transactor,
+			auditor:     newAuditor(cfg, repoAuditLog),
+		}
+
+		repoSession.EXPECT().
+			GetSessionByJTI(gomock.Any(), "jti1").
+			Return(entity.Session{ID: 1, UserID: 44, JTI: "jti1"}, nil)
+		repoSession.EXPECT().UpdateSessionLastSeenAt(gomock.Any(), int64(1), gomock.Any()).Return(nil)
+		repoProfile.EXPECT().
+			GetProfileByUserID(gomock.Any(), int64(44)).
+			Return(entity.User{ID: 44, Username: "hidayat", Status: entity.UserStatusActive}, nil)
+
+		repoProfile.EXPECT().GetLastUsernameChangedAt(gomock.Any(), int64(44)).Return(nil, nil)
+		transactor.EXPECT().
+			WithinTx(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+				return fn(ctx)
+			})
+		repoAccount.EXPECT().LockUsername(gomock.Any(), "hidayat").Return(nil)
+		repoAccount.EXPECT().LockUsername(gomock.Any(), "hidayat1").Return(nil)
+		repoAccount.EXPECT().LockUser(gomock.Any(), int64(44)).Return(nil)
+		repoAccount.EXPECT().IsUsernameHeldByDeletedUser(gomock.Any(), "hidayat1").Return(false, nil)
+		repoProfile.EXPECT().
+			GetProfileByOldUsername(gomock.Any(), "hidayat1", gomock.Any()).
+			Return(entity.User{ID: 44}, nil)
+		repoProfile.EXPECT().ChangeUsername(gomock.Any(), int64(44), "hidayat1", gomock.Any()).Return(nil)
+
+		var auditLog entity.AuditLog
+		repoAuditLog.EXPECT().
+			CreateAuditLog(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, a entity.AuditLog) error {
+				auditLog = a
+				return nil
+			})
+
+		err := a.ChangeUsername(context.Background(), gouser.ReqChangeUsername{
+			UserJWT:  auth.GenerateUserJWTToken(44, "jti1", cfg),
+			Username: "hidayat1",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, entity.AuditActionUsernameChange, auditLog.Action)
+		assert.Equal(t, entity.AuditResultSuccess, auditLog.Result)
+		require.NotNil(t, auditLog.ActorUserID)
+		assert.Equal(t, int64(44), *auditLog.ActorUserID)
+		require.NotNil(t, auditLog.TargetUserID)
+		assert.Equal(t, int64(44), *auditLog.TargetUserID)
+	})
+	t.Run("change within cooldown should return error cooldown", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoAccount := mockrepo.NewMockIAccount(ctrl)
+		repoProfile := mockrepo.NewMockIProfile(ctrl)
+		repoSession := mockrepo.NewMockISession(ctrl)
+		transactor := mockrepo.NewMockITransactor(ctrl)
+		repoAuditLog := mockrepo.NewMockIAuditLog(ctrl)
+
+		cfg := config.Config{
+			JWT:      config.JWT{ExpireHour: 24, SignedKey: "secretsignkey"},
+			Account:  config.Account{HoldDeletedUsername: true},
+			Username: config.Username{ChangeCooldownHour: 24, HistoryGraceHour: 48},
+		}
+
+		a := &Account{
+			cfg:         cfg,
+			guard:       newGuard(cfg, repoSession, repoProfile),
+			repoAccount: repoAccount,
+			repoProfile: repoProfile,
+			repoSession: repoSession,
+			transactor:  transactor,
+			auditor:     newAuditor(cfg, repoAuditLog),
+		}
+
+		repoSession.EXPECT().
+			GetSessionByJTI(gomock.Any(), "jti1").
+			Return(entity.Session{ID: 1, UserID: 44, JTI: "jti1"}, nil)
+		repoSession.EXPECT().UpdateSessionLastSeenAt(gomock.Any(), int64(1), gomock.Any()).Return(nil)
+		repoProfile.EXPECT().
+			GetProfileByUserID(gomock.Any(), int64(44)).
+			Return(entity.User{ID: 44, Username: "hidayat", Status: entity.UserStatusActive}, nil)
+
+		transactor.EXPECT().
+			WithinTx(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+				return fn(ctx)
+			})
+		repoAccount.EXPECT().LockUsername(gomock.Any(), "hidayat").Return(nil)
+		repoAccount.EXPECT().LockUsername(gomock.Any(), "hidayat2").Return(nil)
+		repoAccount.EXPECT().LockUser(gomock.Any(), int64(44)).Return(nil)
+		lastChangedAt := time.Now().Add(-time.Hour)
+		repoProfile.EXPECT().GetLastUsernameChangedAt(gomock.Any(), int64(44)).Return(&lastChangedAt, nil)
+
+		var auditLog entity.AuditLog
+		repoAuditLog.EXPECT().
+			CreateAuditLog(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, a entity.AuditLog) error {
+				auditLog = a
+				return nil
+			})
+
+		err := a.ChangeUsername(context.Background(), gouser.ReqChangeUsername{
+			UserJWT:  auth.GenerateUserJWTToken(44, "jti1", cfg),
+			Username: "hidayat2",
+		})
+
+		require.Error(t, err)
+		require.ErrorIs(t, err, gouser.ErrUsernameChangeCooldown)
+		assert.Equal(t, entity.AuditActionUsernameChange, auditLog.Action)
+		assert.Equal(t, entity.AuditResultFailure, auditLog.Result)
+		require.NotNil(t, auditLog.ActorUserID)
+		assert.Equal(t, int64(44), *auditLog.ActorUserID)
+		require.NotNil(t, auditLog.TargetUserID)
+		assert.Equal(t, int64(44), *auditLog.TargetUserID)
+	})
+	t.Run("old username of other user within grace period should return error duplicate username", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoAccount := mockrepo.NewMockIAccount(ctrl)
+		repoProfile := mockrepo.NewMockIProfile(ctrl)
+		repoSession := mockrepo.NewMockISession(ctrl)
+		transactor := mockrepo.NewMockITransactor(ctrl)
+		repoAuditLog := mockrepo.NewMockIAuditLog(ctrl)
+
+		cfg := config.Config{
+			JWT:      config.JWT{ExpireHour: 24, SignedKey: "secretsignkey"},
+			Account:  config.Account{HoldDeletedUsername: true},
+			Username: config.Username{ChangeCooldownHour: 24, HistoryGraceHour: 48},
+		}
+
+		a := &Account{
+			cfg:         cfg,
+			guard:       newGuard(cfg, repoSession, repoProfile),
+			repoAccount: repoAccount,
+			repoProfile: repoProfile,
+			repoSession: repoSession,
+			transactor:  transactor,
+			auditor:     newAuditor(cfg, repoAuditLog),
+		}
+
+		repoSession.EXPECT().
+			GetSessionByJTI(gomock.Any(), "jti1").
+			Return(entity.Session{ID: 1, UserID: 44, JTI: "jti1"}, nil)
+		repoSession.EXPECT().UpdateSessionLastSeenAt(gomock.Any(), int64(1), gomock.Any()).Return(nil)
+		repoProfile.EXPECT().
+			GetProfileByUserID(gomock.Any(), int64(44)).
+			Return(entity.User{ID: 44, Username: "hidayat", Status: entity.UserStatusActive}, nil)
+
+		repoProfile.EXPECT().GetLastUsernameChangedAt(gomock.Any(), int64(44)).Return(nil, nil)
+		transactor.EXPECT().
+			WithinTx(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+				return fn(ctx)
+			})
+		repoAccount.EXPECT().LockUsername(gomock.Any(), "hidayat").Return(nil)
+		repoAccount.EXPECT().LockUsername(gomock.Any(), "hidayat2").Return(nil)
+		repoAccount.EXPECT().LockUser(gomock.Any(), int64(44)).Return(nil)
+		repoAccount.EXPECT().IsUsernameHeldByDeletedUser(gomock.Any(), "hidayat2").Return(false, nil)
+		repoProfile.EXPECT().
+			GetProfileByOldUsername(gomock.Any(), "hidayat2", gomock.Any()).
+			Return(entity.User{ID: 45}, nil)
+
+		var auditLog entity.AuditLog
+		repoAuditLog.EXPECT().
+			CreateAuditLog(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, a entity.AuditLog) error {
+				auditLog = a
+				return nil
+			})
+
+		err := a.ChangeUsername(context.Background(), gouser.ReqChangeUsername{
+			UserJWT:  auth.GenerateUserJWTToken(44, "jti1", cfg),
+			Username: "hidayat2",
+		})
+
+		require.Error(t, err)
+		require.ErrorIs(t, err, gouser.ErrDuplicateUsername)
+		assert.Equal(t, entity.AuditActionUsernameChange, auditLog.Action)
+		assert.Equal(t, entity.AuditResultFailure, auditLog.Result)
+		require.NotNil(t, auditLog.ActorUserID)
+		assert.Equal(t, int64(44), *auditLog.ActorUserID)
+		require.NotNil(t, auditLog.TargetUserID)
+		assert.Equal(t, int64(44), *auditLog.TargetUserID)
+	})
+	t.Run("username held by deleted user should return error duplicate username", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoAccount := mockrepo.NewMockIAccount(ctrl)
+		repoProfile := mockrepo.NewMockIProfile(ctrl)
+		repoSession := mockrepo.NewMockISession(ctrl)
+		transactor := mockrepo.NewMockITransactor(ctrl)
+		repoAuditLog := mockrepo.NewMockIAuditLog(ctrl)
+
+		cfg := config.Config{
+			JWT:      config.JWT{ExpireHour: 24, SignedKey: "secretsignkey"},
+			Account:  config.Account{HoldDeletedUsername: true},
+			Username: config.Username{ChangeCooldownHour: 24, HistoryGraceHour: 48},
+		}
+
+		a := &Account{
+			cfg:         cfg,
+			guard:       newGuard(cfg, repoSession, repoProfile),
+			repoAccount: repoAccount,
+			repoProfile: repoProfile,
+			repoSession: repoSession,
+			transactor:  transactor,
+			auditor:     newAuditor(cfg, repoAuditLog),
+		}
+
+		repoSession.EXPECT().
+			GetSessionByJTI(gomock.Any(), "jti1").
+			Return(entity.Session{ID: 1, UserID: 44, JTI: "jti1"}, nil)
+		repoSession.EXPECT().UpdateSessionLastSeenAt(gomock.Any(), int64(1), gomock.Any()).Return(nil)
+		repoProfile.EXPECT().
+			GetProfileByUserID(gomock.Any(), int64(44)).
+			Return(entity.User{ID: 44, Username: "hidayat", Status: entity.UserStatusActive}, nil)
+
+		repoProfile.EXPECT().GetLastUsernameChangedAt(gomock.Any(), int64(44)).Return(nil, nil)
+		transactor.EXPECT().
+			WithinTx(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+				return fn(ctx)
+			})
+		repoAccount.EXPECT().LockUsername(gomock.Any(), "hidayat").Return(nil)
+		repoAccount.EXPECT().LockUsername(gomock.Any(), "hidayat2").Return(nil)
+		repoAccount.EXPECT().LockUser(gomock.Any(), int64(44)).Return(nil)
+		repoAccount.EXPECT().IsUsernameHeldByDeletedUser(gomock.Any(), "hidayat2").Return(true, nil)
+
+		var auditLog entity.AuditLog
+		repoAuditLog.EXPECT().
+			CreateAuditLog(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, a entity.AuditLog) error {
+				auditLog = a
+				return nil
+			})
+
+		err := a.ChangeUsername(context.Background(), gouser.ReqChangeUsername{
+			UserJWT:  auth.GenerateUserJWTToken(44, "jti1", cfg),
+			Username: "hidayat2",
+		})
+
+		require.Error(t, err)
+		require.ErrorIs(t, err, gouser.ErrDuplicateUsername)
+		assert.Equal(t, entity.AuditActionUsernameChange, auditLog.Action)
+		assert.Equal(t, entity.AuditResultFailure, auditLog.Result)
+		require.NotNil(t, auditLog.ActorUserID)
+		assert.Equal(t, int64(44), *auditLog.ActorUserID)
+		require.NotNil(t, auditLog.TargetUserID)
+		assert.Equal(t, int64(44), *auditLog.TargetUserID)
+	})
+	t.Run("same username should return error nothing to be update", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoAccount := mockrepo.NewMockIAccount(ctrl)
+		repoProfile := mockrepo.NewMockIProfile(ctrl)
+		repoSession := mockrepo.NewMockISession(ctrl)
+		repoAuditLog := mockrepo.NewMockIAuditLog(ctrl)
+
+		cfg := config.Config{
+			JWT:      config.JWT{ExpireHour: 24, SignedKey: "secretsignkey"},
+			Account:  config.Account{HoldDeletedUsername: true},
+			Username: config.Username{ChangeCooldownHour: 24, HistoryGraceHour: 48},
+		}
+
+		a := &Account{
+			cfg:         cfg,
+			guard:       newGuard(cfg, repoSession, repoProfile),
+			repoAccount: repoAccount,
+			repoProfile: repoProfile,
+			repoSession: repoSession,
+			auditor:     newAuditor(cfg, repoAuditLog),
+		}
+
+		repoSession.EXPECT().
+			GetSessionByJTI(gomock.Any(), "jti1").
+			Return(entity.Session{ID: 1, UserID: 44, JTI: "jti1"}, nil)
+		repoSession.EXPECT().UpdateSessionLastSeenAt(gomock.Any(), int64(1), gomock.Any()).Return(nil)
+		repoProfile.EXPECT().
+			GetProfileByUserID(gomock.Any(), int64(44)).
+			Return(entity.User{ID: 44, Username: "hidayat", Status: entity.UserStatusActive}, nil)
+
+		var auditLog entity.AuditLog
+		repoAuditLog.EXPECT().
+			CreateAuditLog(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, a entity.AuditLog) error {
+				auditLog = a
+				return nil
+			})
+
+		err := a.ChangeUsername(context.Background(), gouser.ReqChangeUsername{
+			UserJWT:  auth.GenerateUserJWTToken(44, "jti1", cfg),
+			Username: "hidayat",
+		})
+
+		require.Error(t, err)
+		require.ErrorIs(t, err, gouser.ErrNothingToBeUpdate)
+		assert.Equal(t, entity.AuditActionUsernameChange, auditLog.Action)
+		assert.Equal(t, entity.AuditResultFailure, auditLog.Result)
+		require.NotNil(t, auditLog.ActorUserID)
+		assert.Equal(t, int64(44), *auditLog.ActorUserID)
+		require.NotNil(t, auditLog.TargetUserID)
+		assert.Equal(t, int64(44), *auditLog.TargetUserID)
+	})
+	t.Run("invalid username should return error request invalid", func(t *testing.T) {
+		t.Parallel()
+
+		usernames := []string{"me", "id", "batch", "username", "export", "ab", "hi/dayat", "-hidayat"}
+		for _, username := range usernames {
+			a := &Account{cfg: config.Config{}}
+
+			err := a.ChangeUsername(context.Background(), gouser.ReqChangeUsername{
+				UserJWT:  "dummy jwt",
+				Username: username,
+			})
+
+			require.Error(t, err, username)
+			require.ErrorIs(t, err, gouser.ErrRequestInvalid, username)
+		}
+	})
+}
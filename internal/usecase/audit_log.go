@@ -0,0 +1,148 @@
+package usecase
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Hidayathamir/go-user/config"
+	"github.com/Hidayathamir/go-user/internal/repo"
+	"github.com/Hidayathamir/go-user/pkg/gouser"
+)
+
+//go:generate mockgen -source=audit_log.go -destination=mockusecase/audit_log.go -package=mockusecase
+
+// IAuditLog contains abstraction of usecase audit log.
+type IAuditLog interface {
+	// GetAuditLogs return one page of audit log entries, newest first, admin
+	// only.
+	GetAuditLogs(ctx context.Context, req gouser.ReqGetAuditLogs) (gouser.ResGetAuditLogs, error)
+	// PurgeAuditLogs delete audit log entries older than retention period,
+	// return number of purged entries.
+	PurgeAuditLogs(ctx context.Context) (int64, error)
+}
+
+// AuditLog implement IAuditLog.
+type AuditLog struct {
+	cfg          config.Config
+	guard        *guard
+	repoAuditLog repo.IAuditLog
+}
+
+var _ IAuditLog = &AuditLog{}
+
+// NewAuditLog return *AuditLog which implement IAuditLog.
+func NewAuditLog(cfg config.Config, repoAuditLog repo.IAuditLog, repoSession repo.ISession, repoProfile repo.IProfile) *AuditLog {
+	return &AuditLog{
+		cfg:          cfg,
+		guard:        newGuard(cfg, repoSession, repoProfile),
+		repoAuditLog: repoAuditLog,
+	}
+}
+
+// GetAuditLogs return one page of audit log entries, newest first, admin only.
+func (a *AuditLog) GetAuditLogs(ctx context.Context, req gouser.ReqGetAuditLogs) (gouser.ResGetAuditLogs, error) {
+	err := req.Validate()
+	if err != nil {
+		err := fmt.Errorf("ReqGetAuditLogs.Validate: %w", err)
+		return gouser.ResGetAuditLogs{}, fmt.Errorf("%w: %w", gouser.ErrRequestInvalid, err)
+	}
+
+	_, err = a.guard.authenticateAdmin(ctx, req.UserJWT)
+	if err != nil {
+		return gouser.ResGetAuditLogs{}, fmt.Errorf("AuditLog.guard.authenticateAdmin: %w", err)
+	}
+
+	limit := req.Limit
+	if limit == 0 {
+		limit = gouser.GetAuditLogsDefaultLimit
+	}
+
+	filter := repo.AuditLogFilter{
+		ActorUserID:  req.ActorUserID,
+		TargetUserID: req.TargetUserID,
+		Action:       req.Action,
+		Result:       req.Result,
+		CreatedFrom:  req.CreatedFrom,
+		CreatedTo:    req.CreatedTo,
+		// Fetch one more entry to know whether there is a next page.
+		Limit: uint64(limit) + 1,
+	}
+
+	if req.Cursor != "" {
+		filter.BeforeID, err = decodeAuditLogsCursor(req.Cursor)
+		if err != nil {
+			err := fmt.Errorf("decodeAuditLogsCursor: %w", err)
+			return gouser.ResGetAuditLogs{}, fmt.Errorf("%w: %w", gouser.ErrRequestInvalid, err)
+		}
+	}
+
+	auditLogs, err := a.repoAuditLog.GetAuditLogs(ctx, filter)
+	if err != nil {
+		return gouser.ResGetAuditLogs{}, fmt.Errorf("AuditLog.repoAuditLog.GetAuditLogs: %w", err)
+	}
+
+	res := gouser.ResGetAuditLogs{AuditLogs: make([]gouser.AuditLog, 0, len(auditLogs))}
+
+	if len(auditLogs) > limit {
+		auditLogs = auditLogs[:limit]
+		res.NextCursor, err = encodeAuditLogsCursor(auditLogs[len(auditLogs)-1].ID)
+		if err != nil {
+			return gouser.ResGetAuditLogs{}, fmt.Errorf("encodeAuditLogsCursor: %w", err)
+		}
+	}
+
+	for _, auditLog := range auditLogs {
+		res.AuditLogs = append(res.AuditLogs, gouser.AuditLog{}.LoadEntityAuditLog(auditLog))
+	}
+
+	return res, nil
+}
+
+// PurgeAuditLogs delete audit log entries older than retention period, return
+// number of purged entries.
+func (a *AuditLog) PurgeAuditLogs(ctx context.Context) (int64, error) {
+	createdBefore := time.Now().Add(-time.Duration(a.cfg.AuditLog.RetentionHour) * time.Hour)
+
+	count, err := a.repoAuditLog.PurgeAuditLogs(ctx, createdBefore)
+	if err != nil {
+		return 0, fmt.Errorf("AuditLog.repoAuditLog.PurgeAuditLogs: %w", err)
+	}
+
+	return count, nil
+}
+
+// auditLogsCursor is the position of the last entry of a GetAuditLogs page.
+type auditLogsCursor struct {
+	ID int64 `json:"i"`
+}
+
+func encodeAuditLogsCursor(auditLogID int64) (string, error) {
+	cursorJSON, err := json.Marshal(auditLogsCursor{ID: auditLogID})
+	if err != nil {
+		return "", fmt.Errorf("json.Marshal: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(cursorJSON), nil
+}
+
+func decodeAuditLogsCursor(s string) (int64, error) {
+	cursorJSON, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return 0, fmt.Errorf("base64.RawURLEncoding.DecodeString: %w", err)
+	}
+
+	cursor := auditLogsCursor{}
+	err = json.Unmarshal(cursorJSON, &cursor)
+	if err != nil {
+		return 0, fmt.Errorf("json.Unmarshal: %w", err)
+	}
+
+	if cursor.ID <= 0 {
+		return 0, fmt.Errorf("cursor audit log id must be positive, got %d", cursor.ID)
+	}
+
+	return cursor.ID, nil
+}
@@ -0,0 +1,267 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Hidayathamir/go-user/config"
+	"github.com/Hidayathamir/go-user/internal/pkg/auth"
+	"github.com/Hidayathamir/go-user/internal/repo"
+	"github.com/Hidayathamir/go-user/internal/repo/db/entity"
+	"github.com/Hidayathamir/go-user/internal/repo/mockrepo"
+	"github.com/Hidayathamir/go-user/pkg/gouser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUnitAuditLogGetAuditLogs(t *testing.T) {
+	t.Parallel()
+
+	t.Run("admin get audit logs should return page and next cursor", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoAuditLog := mockrepo.NewMockIAuditLog(ctrl)
+		repoSession := mockrepo.NewMockISession(ctrl)
+		repoProfile := mockrepo.NewMockIProfile(ctrl)
+
+		cfg := config.Config{
+			JWT: config.JWT{ExpireHour: 24, SignedKey: "secretsignkey"},
+		}
+
+		a := &AuditLog{
+			cfg:          cfg,
+			guard:        newGuard(cfg, repoSession, repoProfile),
+			repoAuditLog: repoAuditLog,
+		}
+
+		repoSession.EXPECT().
+			GetSessionByJTI(gomock.Any(), "jtiadmin").
+			Return(entity.Session{ID: 1, UserID: 1, JTI: "jtiadmin"}, nil)
+		repoSession.EXPECT().UpdateSessionLastSeenAt(gomock.Any(), int64(1), gomock.Any()).Return(nil)
+		repoProfile.EXPECT().
+			GetProfileByUserID(gomock.Any(), int64(1)).
+			Return(entity.User{ID: 1, Role: entity.UserRoleAdmin, Status: entity.UserStatusActive}, nil)
+
+		targetUserID := int64(44)
+		repoAuditLog.EXPECT().
+			GetAuditLogs(gomock.Any(), repo.AuditLogFilter{
+				TargetUserID: &targetUserID,
+				Action:       entity.AuditActionUserLogin,
+				Result:       entity.AuditResultFailure,
+				Limit:        3,
+			}).
+			Return([]entity.AuditLog{
+				{ID: 30, TargetUserID: &targetUserID, Action: entity.AuditActionUserLogin, Result: entity.AuditResultFailure},
+				{ID: 20, TargetUserID: &targetUserID, Action: entity.AuditActionUserLogin, Result: entity.AuditResultFailure},
+				{ID: 10, TargetUserID: &targetUserID, Action: entity.AuditActionUserLogin, Result: entity.AuditResultFailure},
+			}, nil)
+
+		res, err := a.GetAuditLogs(context.Background(), gouser.ReqGetAuditLogs{
+			UserJWT:      auth.GenerateUserJWTToken(1, "jtiadmin", cfg),
+			Limit:        2,
+			TargetUserID: &targetUserID,
+			Action:       entity.AuditActionUserLogin,
+			Result:       entity.AuditResultFailure,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, res.AuditLogs, 2)
+		assert.Equal(t, int64(30), res.AuditLogs[0].ID)
+		assert.Equal(t, int64(20), res.AuditLogs[1].ID)
+		require.NotEmpty(t, res.NextCursor)
+
+		beforeID, err := decodeAuditLogsCursor(res.NextCursor)
+		require.NoError(t, err)
+		assert.Equal(t, int64(20), beforeID)
+	})
+	t.Run("last page should return empty next cursor", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoAuditLog := mockrepo.NewMockIAuditLog(ctrl)
+		repoSession := mockrepo.NewMockISession(ctrl)
+		repoProfile := mockrepo.NewMockIProfile(ctrl)
+
+		cfg := config.Config{
+			JWT: config.JWT{ExpireHour: 24, SignedKey: "secretsignkey"},
+		}
+
+		a := &AuditLog{
+			cfg:          cfg,
+			guard:        newGuard(cfg, repoSession, repoProfile),
+			repoAuditLog: repoAuditLog,
+		}
+
+		repoSession.EXPECT().
+			GetSessionByJTI(gomock.Any(), "jtiadmin").
+			Return(entity.Session{ID: 1, UserID: 1, JTI: "jtiadmin"}, nil)
+		repoSession.EXPECT().UpdateSessionLastSeenAt(gomock.Any(), int64(1), gomock.Any()).Return(nil)
+		repoProfile.EXPECT().
+			GetProfileByUserID(gomock.Any(), int64(1)).
+			Return(entity.User{ID: 1, Role: entity.UserRoleAdmin, Status: entity.UserStatusActive}, nil)
+
+		cursor, err := encodeAuditLogsCursor(20)
+		require.NoError(t, err)
+
+		repoAuditLog.EXPECT().
+			GetAuditLogs(gomock.Any(), repo.AuditLogFilter{
+				BeforeID: 20,
+				Limit:    gouser.GetAuditLogsDefaultLimit + 1,
+			}).
+			Return([]entity.AuditLog{{ID: 10}}, nil)
+
+		res, err := a.GetAuditLogs(context.Background(), gouser.ReqGetAuditLogs{
+			UserJWT: auth.GenerateUserJWTToken(1, "jtiadmin", cfg),
+			Cursor:  cursor,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, res.AuditLogs, 1)
+		assert.Empty(t, res.NextCursor)
+	})
+	t.Run("non admin should return error", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoAuditLog := mockrepo.NewMockIAuditLog(ctrl)
+		repoSession := mockrepo.NewMockISession(ctrl)
+		repoProfile := mockrepo.NewMockIProfile(ctrl)
+
+		cfg := config.Config{
+			JWT: config.JWT{ExpireHour: 24, SignedKey: "secretsignkey"},
+		}
+
+		a := &AuditLog{
+			cfg:          cfg,
+			guard:        newGuard(cfg, repoSession, repoProfile),
+			repoAuditLog: repoAuditLog,
+		}
+
+		repoSession.EXPECT().
+			GetSessionByJTI(gomock.Any(), "jti1").
+			Return(entity.Session{ID: 1, UserID: 44, JTI: "jti1"}, nil)
+		repoSession.EXPECT().UpdateSessionLastSeenAt(gomock.Any(), int64(1), gomock.Any()).Return(nil)
+		repoProfile.EXPECT().
+			GetProfileByUserID(gomock.Any(), int64(44)).
+			Return(entity.User{ID: 44, Role: entity.UserRoleUser, Status: entity.UserStatusActive}, nil)
+
+		res, err := a.GetAuditLogs(context.Background(), gouser.ReqGetAuditLogs{
+			UserJWT: auth.GenerateUserJWTToken(44, "jti1", cfg),
+		})
+
+		assert.Empty(t, res)
+		require.Error(t, err)
+		require.ErrorIs(t, err, gouser.ErrForbidden)
+	})
+	t.Run("unknown action should return error", func(t *testing.T) {
+		t.Parallel()
+
+		a := &AuditLog{}
+
+		res, err := a.GetAuditLogs(context.Background(), gouser.ReqGetAuditLogs{
+			UserJWT: "jwt",
+			Action:  "user.unknown",
+		})
+
+		assert.Empty(t, res)
+		require.Error(t, err)
+		require.ErrorIs(t, err, gouser.ErrRequestInvalid)
+	})
+	t.Run("invalid cursor should return error", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoSession := mockrepo.NewMockISession(ctrl)
+		repoProfile := mockrepo.NewMockIProfile(ctrl)
+
+		cfg := config.Config{
+			JWT: config.JWT{ExpireHour: 24, SignedKey: "secretsignkey"},
+		}
+
+		a := &AuditLog{
+			cfg:   cfg,
+			guard: newGuard(cfg, repoSession, repoProfile),
+		}
+
+		repoSession.EXPECT().
+			GetSessionByJTI(gomock.Any(), "jtiadmin").
+			Return(entity.Session{ID: 1, UserID: 1, JTI: "jtiadmin"}, nil)
+		repoSession.EXPECT().UpdateSessionLastSeenAt(gomock.Any(), int64(1), gomock.Any()).Return(nil)
+		repoProfile.EXPECT().
+			GetProfileByUserID(gomock.Any(), int64(1)).
+			Return(entity.User{ID: 1, Role: entity.UserRoleAdmin, Status: entity.UserStatusActive}, nil)
+
+		res, err := a.GetAuditLogs(context.Background(), gouser.ReqGetAuditLogs{
+			UserJWT: auth.GenerateUserJWTToken(1, "jtiadmin", cfg),
+			Cursor:  "not a cursor",
+		})
+
+		assert.Empty(t, res)
+		require.Error(t, err)
+		require.ErrorIs(t, err, gouser.ErrRequestInvalid)
+	})
+}
+
+func TestUnitAuditLogPurgeAuditLogs(t *testing.T) {
+	t.Parallel()
+
+	t.Run("purge should delete entries older than retention", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoAuditLog := mockrepo.NewMockIAuditLog(ctrl)
+
+		a := &AuditLog{
+			cfg:          config.Config{AuditLog: config.AuditLog{RetentionHour: 24}},
+			repoAuditLog: repoAuditLog,
+		}
+
+		var createdBefore time.Time
+		repoAuditLog.EXPECT().
+			PurgeAuditLogs(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, before time.Time) (int64, error) {
+				createdBefore = before
+				return int64(5), nil
+			})
+
+		count, err := a.PurgeAuditLogs(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(5), count)
+		assert.WithinDuration(t, time.Now().Add(-24*time.Hour), createdBefore, time.Minute)
+	})
+	t.Run("call repo PurgeAuditLogs error should return error", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoAuditLog := mockrepo.NewMockIAuditLog(ctrl)
+
+		a := &AuditLog{
+			cfg:          config.Config{AuditLog: config.AuditLog{RetentionHour: 24}},
+			repoAuditLog: repoAuditLog,
+		}
+
+		repoAuditLog.EXPECT().PurgeAuditLogs(gomock.Any(), gomock.Any()).Return(int64(0), assert.AnError)
+
+		count, err := a.PurgeAuditLogs(context.Background())
+
+		require.Error(t, err)
+		require.ErrorIs(t, err, assert.AnError)
+		assert.Equal(t, int64(0), count)
+	})
+}
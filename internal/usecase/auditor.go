@@ -0,0 +1,74 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Hidayathamir/go-user/config"
+	"github.com/Hidayathamir/go-user/internal/pkg/logger"
+	"github.com/Hidayathamir/go-user/internal/repo"
+	"github.com/Hidayathamir/go-user/internal/repo/db/entity"
+)
+
+// auditor appends security relevant actions to the audit log. It is shared by
+// usecases that perform them.
+type auditor struct {
+	cfg          config.Config
+	repoAuditLog repo.IAuditLog
+}
+
+func newAuditor(cfg config.Config, repoAuditLog repo.IAuditLog) *auditor {
+	return &auditor{
+		cfg:          cfg,
+		repoAuditLog: repoAuditLog,
+	}
+}
+
+// record append auditLog with result of the action, actionErr nil means
+// success, else failure and actionErr is added to detail. Failing to append is
+// logged and not returned, the action is already done.
+func (a *auditor) record(ctx context.Context, auditLog entity.AuditLog, actionErr error) {
+	auditLog.Result = entity.AuditResultSuccess
+	if actionErr != nil {
+		auditLog.Result = entity.AuditResultFailure
+		if auditLog.Detail != "" {
+			auditLog.Detail += ": "
+		}
+		auditLog.Detail += actionErr.Error()
+	}
+	auditLog.CreatedAt = time.Now()
+
+	// Record even when the client gave up on the request.
+	err := a.repoAuditLog.CreateAuditLog(context.WithoutCancel(ctx), auditLog)
+	if err != nil {
+		logger.FromContext(ctx).
+			WithField("action", auditLog.Action).
+			WithField("result", auditLog.Result).
+			Errorf("auditor.repoAuditLog.CreateAuditLog: %v", err)
+	}
+}
+
+// recordWithinTx append auditLog of a successful action. Call it with ctx of
+// the transaction which does the action, so the entry is stored if and only if
+// the action is.
+func (a *auditor) recordWithinTx(ctx context.Context, auditLog entity.AuditLog) error {
+	auditLog.Result = entity.AuditResultSuccess
+	auditLog.CreatedAt = time.Now()
+
+	err := a.repoAuditLog.CreateAuditLog(ctx, auditLog)
+	if err != nil {
+		return fmt.Errorf("auditor.repoAuditLog.CreateAuditLog: %w", err)
+	}
+
+	return nil
+}
+
+// auditUserID return pointer to userID, nil if userID is 0 which means
+// unknown user.
+func auditUserID(userID int64) *int64 {
+	if userID == 0 {
+		return nil
+	}
+	return &userID
+}
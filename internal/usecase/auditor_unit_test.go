@@ -0,0 +1,172 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Hidayathamir/go-user/config"
+	"github.com/Hidayathamir/go-user/internal/repo/db/entity"
+	"github.com/Hidayathamir/go-user/internal/repo/mockrepo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUnitAuditorRecord(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil action error should record success", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoAuditLog := mockrepo.NewMockIAuditLog(ctrl)
+
+		a := newAuditor(config.Config{}, repoAuditLog)
+
+		var auditLog entity.AuditLog
+		repoAuditLog.EXPECT().
+			CreateAuditLog(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, a entity.AuditLog) error {
+				auditLog = a
+				return nil
+			})
+
+		a.record(context.Background(), entity.AuditLog{
+			Action: entity.AuditActionUserLogin,
+			Detail: "username 'hidayat'",
+		}, nil)
+
+		assert.Equal(t, entity.AuditResultSuccess, auditLog.Result)
+		assert.Equal(t, "username 'hidayat'", auditLog.Detail)
+		assert.False(t, auditLog.CreatedAt.IsZero())
+	})
+	t.Run("action error should record failure with error in detail", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoAuditLog := mockrepo.NewMockIAuditLog(ctrl)
+
+		a := newAuditor(config.Config{}, repoAuditLog)
+
+		var auditLog entity.AuditLog
+		repoAuditLog.EXPECT().
+			CreateAuditLog(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, a entity.AuditLog) error {
+				auditLog = a
+				return nil
+			})
+
+		a.record(context.Background(), entity.AuditLog{
+			Action: entity.AuditActionUserLogin,
+			Detail: "username 'hidayat'",
+		}, assert.AnError)
+
+		assert.Equal(t, entity.AuditResultFailure, auditLog.Result)
+		assert.Equal(t, "username 'hidayat': "+assert.AnError.Error(), auditLog.Detail)
+	})
+	t.Run("canceled context should still record", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoAuditLog := mockrepo.NewMockIAuditLog(ctrl)
+
+		a := newAuditor(config.Config{}, repoAuditLog)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		var ctxErr error
+		repoAuditLog.EXPECT().
+			CreateAuditLog(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, _ entity.AuditLog) error {
+				ctxErr = ctx.Err()
+				return nil
+			})
+
+		a.record(ctx, entity.AuditLog{Action: entity.AuditActionUserLogin}, nil)
+
+		assert.NoError(t, ctxErr)
+	})
+	t.Run("call repo CreateAuditLog error should not panic", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoAuditLog := mockrepo.NewMockIAuditLog(ctrl)
+
+		a := newAuditor(config.Config{}, repoAuditLog)
+
+		repoAuditLog.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any()).Return(assert.AnError)
+
+		assert.NotPanics(t, func() {
+			a.record(context.Background(), entity.AuditLog{Action: entity.AuditActionUserLogin}, nil)
+		})
+	})
+}
+
+func TestUnitAuditorRecordWithinTx(t *testing.T) {
+	t.Parallel()
+
+	t.Run("record within tx should record success", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoAuditLog := mockrepo.NewMockIAuditLog(ctrl)
+
+		a := newAuditor(config.Config{}, repoAuditLog)
+
+		var auditLog entity.AuditLog
+		repoAuditLog.EXPECT().
+			CreateAuditLog(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, a entity.AuditLog) error {
+				auditLog = a
+				return nil
+			})
+
+		err := a.recordWithinTx(context.Background(), entity.AuditLog{
+			Action: entity.AuditActionUserDelete,
+			Detail: "username 'hidayat'",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, entity.AuditResultSuccess, auditLog.Result)
+		assert.Equal(t, "username 'hidayat'", auditLog.Detail)
+		assert.False(t, auditLog.CreatedAt.IsZero())
+	})
+	t.Run("call repo CreateAuditLog error should return error", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoAuditLog := mockrepo.NewMockIAuditLog(ctrl)
+
+		a := newAuditor(config.Config{}, repoAuditLog)
+
+		repoAuditLog.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any()).Return(assert.AnError)
+
+		err := a.recordWithinTx(context.Background(), entity.AuditLog{Action: entity.AuditActionUserDelete})
+
+		require.Error(t, err)
+		require.ErrorIs(t, err, assert.AnError)
+	})
+}
+
+func TestUnitAuditUserID(t *testing.T) {
+	t.Parallel()
+
+	assert.Nil(t, auditUserID(0))
+	userID := auditUserID(7)
+	if assert.NotNil(t, userID) {
+		assert.Equal(t, int64(7), *userID)
+	}
+}
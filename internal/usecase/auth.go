@@ -2,12 +2,20 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/Hidayathamir/go-user/config"
 	"github.com/Hidayathamir/go-user/internal/pkg/auth"
+	"github.com/Hidayathamir/go-user/internal/pkg/logger"
+	"github.com/Hidayathamir/go-user/internal/pkg/metrics"
 	"github.com/Hidayathamir/go-user/internal/repo"
+	"github.com/Hidayathamir/go-user/internal/repo/db/entity"
 	"github.com/Hidayathamir/go-user/pkg/gouser"
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
 )
 
 //go:generate mockgen -source=auth.go -destination=mockusecase/auth.go -package=mockusecase
@@ -25,64 +33,175 @@ type Auth struct {
 	cfg         config.Config
 	repoAuth    repo.IAuth
 	repoProfile repo.IProfile
+	repoSession repo.ISession
+	repoAccount repo.IAccount
+	transactor  repo.ITransactor
+	auditor     *auditor
 }
 
 var _ IAuth = &Auth{}
 
 // NewAuth return *Auth which implement IAuth.
-func NewAuth(cfg config.Config, repoAuth repo.IAuth, repoProfile repo.IProfile) *Auth {
+func NewAuth(cfg config.Config, repoAuth repo.IAuth, repoProfile repo.IProfile, repoSession repo.ISession, repoAccount repo.IAccount, repoAuditLog repo.IAuditLog, transactor repo.ITransactor) *Auth {
 	return &Auth{
 		cfg:         cfg,
 		repoAuth:    repoAuth,
 		repoProfile: repoProfile,
+		repoSession: repoSession,
+		repoAccount: repoAccount,
+		transactor:  transactor,
+		auditor:     newAuditor(cfg, repoAuditLog),
 	}
 }
 
-// LoginUser validate username and password.
+// LoginUser validate username and password. Login attempt is recorded in
+// audit log whether it succeeds or not.
 func (a *Auth) LoginUser(ctx context.Context, req gouser.ReqLoginUser) (gouser.ResLoginUser, error) {
+	req.Username = gouser.NormalizeUsername(req.Username)
+
 	err := req.Validate()
 	if err != nil {
 		err := fmt.Errorf("ReqLoginUser.Validate: %w", err)
-		return gouser.ResLoginUser{}, fmt.Errorf("%w: %w", gouser.ErrRequestInvalid, err)
+		err = fmt.Errorf("%w: %w", gouser.ErrRequestInvalid, err)
+		countAuthOutcome(metrics.LoginTotal, err)
+		return gouser.ResLoginUser{}, err
+	}
+
+	res, userID, err := a.loginUser(ctx, req)
+	if userID != 0 {
+		logger.AddFields(ctx, logrus.Fields{logger.FieldUserID: userID})
+	}
+
+	countAuthOutcome(metrics.LoginTotal, err)
+
+	a.auditor.record(ctx, entity.AuditLog{
+		ActorUserID:  auditUserID(userID),
+		TargetUserID: auditUserID(userID),
+		Action:       entity.AuditActionUserLogin,
+		Detail:       fmt.Sprintf("username '%s'", req.Username),
+		IP:           req.IP,
+		UserAgent:    req.UserAgent,
+		RequestID:    req.RequestID,
+	}, err)
+
+	if err != nil {
+		return gouser.ResLoginUser{}, fmt.Errorf("Auth.loginUser: %w", err)
 	}
 
+	return res, nil
+}
+
+// loginUser is LoginUser without validation and audit, it also return id of
+// the user, 0 if username is unknown.
+func (a *Auth) loginUser(ctx context.Context, req gouser.ReqLoginUser) (gouser.ResLoginUser, int64, error) {
 	user, err := a.repoProfile.GetProfileByUsername(ctx, req.Username)
 	if err != nil {
-		return gouser.ResLoginUser{}, fmt.Errorf("Auth.repoProfile.GetProfileByUsername: %w", err)
+		return gouser.ResLoginUser{}, 0, fmt.Errorf("Auth.repoProfile.GetProfileByUsername: %w", err)
 	}
 
 	err = auth.CompareHashAndPassword(user.Password, req.Password)
 	if err != nil {
 		err := fmt.Errorf("auth.CompareHashAndPassword: %w", err)
-		return gouser.ResLoginUser{}, fmt.Errorf("%w: %w", gouser.ErrWrongPassword, err)
+		return gouser.ResLoginUser{}, user.ID, fmt.Errorf("%w: %w", gouser.ErrWrongPassword, err)
+	}
+
+	now := time.Now()
+
+	err = checkUserActive(user, now)
+	if err != nil {
+		return gouser.ResLoginUser{}, user.ID, fmt.Errorf("checkUserActive: %w", err)
+	}
+
+	session := entity.Session{
+		UserID:     user.ID,
+		JTI:        uuid.NewString(),
+		UserAgent:  req.UserAgent,
+		IP:         req.IP,
+		CreatedAt:  now,
+		LastSeenAt: now,
+		ExpiredAt:  auth.GetJWTExpiredAt(a.cfg, now),
+	}
+
+	_, err = a.repoSession.CreateSession(ctx, session)
+	if err != nil {
+		return gouser.ResLoginUser{}, user.ID, fmt.Errorf("Auth.repoSession.CreateSession: %w", err)
 	}
 
-	userJWT := auth.GenerateUserJWTToken(user.ID, a.cfg)
+	userJWT := auth.GenerateUserJWTToken(user.ID, session.JTI, a.cfg)
 
 	res := gouser.ResLoginUser{
 		UserJWT: userJWT,
 	}
 
-	return res, nil
+	return res, user.ID, nil
 }
 
-// RegisterUser register new user.
+// RegisterUser register new user. Registration is recorded in audit log
+// whether it succeeds or not.
 func (a *Auth) RegisterUser(ctx context.Context, req gouser.ReqRegisterUser) (gouser.ResRegisterUser, error) {
+	req.Username = gouser.NormalizeUsername(req.Username)
+
 	err := req.Validate()
 	if err != nil {
 		err := fmt.Errorf("ReqRegisterUser.Validate: %w", err)
-		return gouser.ResRegisterUser{}, fmt.Errorf("%w: %w", gouser.ErrRequestInvalid, err)
+		err = fmt.Errorf("%w: %w", gouser.ErrRequestInvalid, err)
+		countAuthOutcome(metrics.RegisterTotal, err)
+		return gouser.ResRegisterUser{}, err
+	}
+
+	res, err := a.registerUser(ctx, req)
+
+	countAuthOutcome(metrics.RegisterTotal, err)
+
+	a.auditor.record(ctx, entity.AuditLog{
+		ActorUserID:  auditUserID(res.UserID),
+		TargetUserID: auditUserID(res.UserID),
+		Action:       entity.AuditActionUserRegister,
+		Detail:       fmt.Sprintf("username '%s'", req.Username),
+		IP:           req.IP,
+		UserAgent:    req.UserAgent,
+		RequestID:    req.RequestID,
+	}, err)
+
+	if err != nil {
+		return gouser.ResRegisterUser{}, fmt.Errorf("Auth.registerUser: %w", err)
 	}
 
+	return res, nil
+}
+
+// registerUser is RegisterUser without validation and audit. Username is
+// checked and claimed in one transaction holding the username lock.
+func (a *Auth) registerUser(ctx context.Context, req gouser.ReqRegisterUser) (gouser.ResRegisterUser, error) {
 	user := req.ToEntityUser()
+
+	var err error
 	user.Password, err = auth.GenerateHashPassword(user.Password)
 	if err != nil {
 		return gouser.ResRegisterUser{}, fmt.Errorf("auth.GenerateHashPassword: %w", err)
 	}
 
-	userID, err := a.repoAuth.RegisterUser(ctx, user)
+	var userID int64
+	err = a.transactor.WithinTx(ctx, func(ctx context.Context) error {
+		err := lockUsernames(ctx, a.repoAccount, user.Username)
+		if err != nil {
+			return fmt.Errorf("lockUsernames: %w", err)
+		}
+
+		err = checkUsernameClaimable(ctx, a.cfg, a.repoAccount, a.repoProfile, user.Username, 0)
+		if err != nil {
+			return fmt.Errorf("checkUsernameClaimable: %w", err)
+		}
+
+		userID, err = a.repoAuth.RegisterUser(ctx, user)
+		if err != nil {
+			return fmt.Errorf("Auth.repoAuth.RegisterUser: %w", err)
+		}
+
+		return nil
+	})
 	if err != nil {
-		return gouser.ResRegisterUser{}, fmt.Errorf("Auth.repoAuth.RegisterUser: %w", err)
+		return gouser.ResRegisterUser{}, fmt.Errorf("Auth.transactor.WithinTx: %w", err)
 	}
 
 	res := gouser.ResRegisterUser{
@@ -91,3 +210,32 @@ func (a *Auth) RegisterUser(ctx context.Context, req gouser.ReqRegisterUser) (go
 
 	return res, nil
 }
+
+// countAuthOutcome increase counter of login or registration by result and
+// failure reason.
+func countAuthOutcome(counter *prometheus.CounterVec, err error) {
+	if err == nil {
+		counter.WithLabelValues(metrics.ResultSuccess, "").Inc()
+		return
+	}
+	counter.WithLabelValues(metrics.ResultFailure, authFailureReason(err)).Inc()
+}
+
+// authFailureReason return low cardinality reason of login or registration
+// error.
+func authFailureReason(err error) string {
+	switch {
+	case errors.Is(err, gouser.ErrRequestInvalid):
+		return "request_invalid"
+	case errors.Is(err, gouser.ErrUnknownUsername):
+		return "unknown_username"
+	case errors.Is(err, gouser.ErrWrongPassword):
+		return "wrong_password"
+	case errors.Is(err, gouser.ErrUserNotActive):
+		return "user_not_active"
+	case errors.Is(err, gouser.ErrDuplicateUsername):
+		return "duplicate_username"
+	default:
+		return "internal"
+	}
+}
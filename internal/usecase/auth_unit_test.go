@@ -2,6 +2,8 @@ package usecase
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -27,6 +29,8 @@ func TestUnitAuthLoginUser(t *testing.T) {
 
 		repoAuth := mockrepo.NewMockIAuth(ctrl)
 		repoProfile := mockrepo.NewMockIProfile(ctrl)
+		repoSession := mockrepo.NewMockISession(ctrl)
+		repoAuditLog := mockrepo.NewMockIAuditLog(ctrl)
 
 		cfg := config.Config{
 			JWT: config.JWT{ExpireHour: 24, SignedKey: "secretjwtkey"},
@@ -36,6 +40,8 @@ func TestUnitAuthLoginUser(t *testing.T) {
 			cfg:         cfg,
 			repoAuth:    repoAuth,
 			repoProfile: repoProfile,
+			repoSession: repoSession,
+			auditor:     newAuditor(cfg, repoAuditLog),
 		}
 
 		repoProfile.EXPECT().
@@ -46,11 +52,31 @@ func TestUnitAuthLoginUser(t *testing.T) {
 				Password:  "$2a$10$KrDmeYfFUKWtTn9aS1ZrQ.L6WG0l0aQUStjxfOnm4U8gH9MqWrFKO", // hashed of "mypassword"
 				CreatedAt: time.Time{},
 				UpdatedAt: time.Time{},
+				Status:    entity.UserStatusActive,
 			}, nil)
 
+		var createdSession entity.Session
+		repoSession.EXPECT().
+			CreateSession(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, session entity.Session) (int64, error) {
+				createdSession = session
+				return int64(7), nil
+			})
+
+		var auditLog entity.AuditLog
+		repoAuditLog.EXPECT().
+			CreateAuditLog(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, a entity.AuditLog) error {
+				auditLog = a
+				return nil
+			})
+
 		resLoginUser, err := a.LoginUser(context.Background(), gouser.ReqLoginUser{
-			Username: "hidayat",
-			Password: "mypassword",
+			Username:  "hidayat",
+			Password:  "mypassword",
+			UserAgent: "Mozilla/5.0",
+			IP:        "10.0.0.1",
+			RequestID: "req-1",
 		})
 
 		require.NoError(t, err)
@@ -59,6 +85,106 @@ func TestUnitAuthLoginUser(t *testing.T) {
 		userID, err := auth.GetUserIDFromJWTTokenString(cfg, resLoginUser.UserJWT)
 		require.NoError(t, err)
 		assert.Equal(t, int64(99), userID)
+		claims, err := auth.GetUserJWTClaimsFromJWTTokenString(cfg, resLoginUser.UserJWT)
+		require.NoError(t, err)
+		assert.Equal(t, createdSession.JTI, claims.JTI)
+		assert.Equal(t, int64(99), createdSession.UserID)
+		assert.Equal(t, "Mozilla/5.0", createdSession.UserAgent)
+		assert.Equal(t, "10.0.0.1", createdSession.IP)
+		assert.Equal(t, entity.AuditActionUserLogin, auditLog.Action)
+		assert.Equal(t, entity.AuditResultSuccess, auditLog.Result)
+		require.NotNil(t, auditLog.ActorUserID)
+		assert.Equal(t, int64(99), *auditLog.ActorUserID)
+		assert.Equal(t, "10.0.0.1", auditLog.IP)
+		assert.Equal(t, "Mozilla/5.0", auditLog.UserAgent)
+		assert.Equal(t, "req-1", auditLog.RequestID)
+	})
+	t.Run("call repo CreateSession error should return error", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoAuth := mockrepo.NewMockIAuth(ctrl)
+		repoProfile := mockrepo.NewMockIProfile(ctrl)
+		repoSession := mockrepo.NewMockISession(ctrl)
+		repoAuditLog := mockrepo.NewMockIAuditLog(ctrl)
+
+		cfg := config.Config{
+			JWT: config.JWT{ExpireHour: 24, SignedKey: "secretjwtkey"},
+		}
+
+		a := &Auth{
+			cfg:         cfg,
+			repoAuth:    repoAuth,
+			repoProfile: repoProfile,
+			repoSession: repoSession,
+			auditor:     newAuditor(cfg, repoAuditLog),
+		}
+
+		repoProfile.EXPECT().
+			GetProfileByUsername(gomock.Any(), "hidayat").
+			Return(entity.User{
+				ID:       99,
+				Username: "hidayat",
+				Password: "$2a$10$KrDmeYfFUKWtTn9aS1ZrQ.L6WG0l0aQUStjxfOnm4U8gH9MqWrFKO", // hashed of "mypassword"
+				Status:   entity.UserStatusActive,
+			}, nil)
+
+		repoSession.EXPECT().
+			CreateSession(gomock.Any(), gomock.Any()).
+			Return(int64(0), assert.AnError)
+
+		repoAuditLog.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any()).Return(nil)
+
+		resLoginUser, err := a.LoginUser(context.Background(), gouser.ReqLoginUser{
+			Username: "hidayat",
+			Password: "mypassword",
+		})
+
+		assert.Empty(t, resLoginUser)
+		require.Error(t, err)
+		require.ErrorIs(t, err, assert.AnError)
+	})
+	t.Run("login suspended user should return error", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoProfile := mockrepo.NewMockIProfile(ctrl)
+		repoAuditLog := mockrepo.NewMockIAuditLog(ctrl)
+
+		a := &Auth{
+			cfg:         config.Config{},
+			repoProfile: repoProfile,
+			auditor:     newAuditor(config.Config{}, repoAuditLog),
+		}
+
+		suspendedUntil := time.Now().Add(time.Hour)
+		repoProfile.EXPECT().
+			GetProfileByUsername(gomock.Any(), "hidayat").
+			Return(entity.User{
+				ID:               99,
+				Username:         "hidayat",
+				Password:         "$2a$10$KrDmeYfFUKWtTn9aS1ZrQ.L6WG0l0aQUStjxfOnm4U8gH9MqWrFKO", // hashed of "mypassword"
+				Status:           entity.UserStatusSuspended,
+				SuspendedUntil:   &suspendedUntil,
+				SuspensionReason: "spam",
+			}, nil)
+
+		repoAuditLog.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any()).Return(nil)
+
+		resLoginUser, err := a.LoginUser(context.Background(), gouser.ReqLoginUser{
+			Username: "hidayat",
+			Password: "mypassword",
+		})
+
+		assert.Empty(t, resLoginUser)
+		require.Error(t, err)
+		require.ErrorIs(t, err, gouser.ErrUserNotActive)
+		require.ErrorContains(t, err, "spam")
+		require.ErrorContains(t, err, suspendedUntil.Format(time.RFC3339))
 	})
 	t.Run("login user with wrong password should return error", func(t *testing.T) {
 		t.Parallel()
@@ -68,6 +194,8 @@ func TestUnitAuthLoginUser(t *testing.T) {
 
 		repoAuth := mockrepo.NewMockIAuth(ctrl)
 		repoProfile := mockrepo.NewMockIProfile(ctrl)
+		repoSession := mockrepo.NewMockISession(ctrl)
+		repoAuditLog := mockrepo.NewMockIAuditLog(ctrl)
 
 		cfg := config.Config{
 			JWT: config.JWT{ExpireHour: 24, SignedKey: "secretjwtkey"},
@@ -77,6 +205,8 @@ func TestUnitAuthLoginUser(t *testing.T) {
 			cfg:         cfg,
 			repoAuth:    repoAuth,
 			repoProfile: repoProfile,
+			repoSession: repoSession,
+			auditor:     newAuditor(cfg, repoAuditLog),
 		}
 
 		repoProfile.EXPECT().
@@ -89,6 +219,14 @@ func TestUnitAuthLoginUser(t *testing.T) {
 				UpdatedAt: time.Time{},
 			}, nil)
 
+		var auditLog entity.AuditLog
+		repoAuditLog.EXPECT().
+			CreateAuditLog(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, a entity.AuditLog) error {
+				auditLog = a
+				return nil
+			})
+
 		resLoginUser, err := a.LoginUser(context.Background(), gouser.ReqLoginUser{
 			Username: "hidayat",
 			Password: "wrongpassword",
@@ -97,6 +235,10 @@ func TestUnitAuthLoginUser(t *testing.T) {
 		assert.Empty(t, resLoginUser)
 		require.Error(t, err)
 		require.ErrorIs(t, err, gouser.ErrWrongPassword)
+		assert.Equal(t, entity.AuditResultFailure, auditLog.Result)
+		require.NotNil(t, auditLog.ActorUserID)
+		assert.Equal(t, int64(99), *auditLog.ActorUserID)
+		assert.Contains(t, auditLog.Detail, gouser.ErrWrongPassword.Error())
 	})
 	t.Run("call repo GetProfileByUsername error should return error", func(t *testing.T) {
 		t.Parallel()
@@ -106,6 +248,8 @@ func TestUnitAuthLoginUser(t *testing.T) {
 
 		repoAuth := mockrepo.NewMockIAuth(ctrl)
 		repoProfile := mockrepo.NewMockIProfile(ctrl)
+		repoSession := mockrepo.NewMockISession(ctrl)
+		repoAuditLog := mockrepo.NewMockIAuditLog(ctrl)
 
 		cfg := config.Config{
 			JWT: config.JWT{ExpireHour: 24, SignedKey: "secretjwtkey"},
@@ -115,12 +259,22 @@ func TestUnitAuthLoginUser(t *testing.T) {
 			cfg:         cfg,
 			repoAuth:    repoAuth,
 			repoProfile: repoProfile,
+			repoSession: repoSession,
+			auditor:     newAuditor(cfg, repoAuditLog),
 		}
 
 		repoProfile.EXPECT().
 			GetProfileByUsername(gomock.Any(), "hidayat").
 			Return(entity.User{}, assert.AnError)
 
+		var auditLog entity.AuditLog
+		repoAuditLog.EXPECT().
+			CreateAuditLog(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, a entity.AuditLog) error {
+				auditLog = a
+				return nil
+			})
+
 		resLoginUser, err := a.LoginUser(context.Background(), gouser.ReqLoginUser{
 			Username: "hidayat",
 			Password: "mypassword",
@@ -129,6 +283,9 @@ func TestUnitAuthLoginUser(t *testing.T) {
 		assert.Empty(t, resLoginUser)
 		require.Error(t, err)
 		require.ErrorIs(t, err, assert.AnError)
+		assert.Equal(t, entity.AuditResultFailure, auditLog.Result)
+		assert.Nil(t, auditLog.ActorUserID)
+		assert.Contains(t, auditLog.Detail, "username 'hidayat'")
 	})
 	t.Run("request validate error should return error", func(t *testing.T) {
 		t.Parallel()
@@ -140,6 +297,7 @@ func TestUnitAuthLoginUser(t *testing.T) {
 			cfg:         config.Config{},
 			repoAuth:    mockrepo.NewMockIAuth(ctrl),
 			repoProfile: mockrepo.NewMockIProfile(ctrl),
+			repoSession: mockrepo.NewMockISession(ctrl),
 		}
 
 		t.Run("username empty should return error", func(t *testing.T) {
@@ -176,15 +334,33 @@ func TestUnitAuthRegisterUser(t *testing.T) {
 
 		repoAuth := mockrepo.NewMockIAuth(ctrl)
 		repoProfile := mockrepo.NewMockIProfile(ctrl)
+		repoSession := mockrepo.NewMockISession(ctrl)
+		repoAccount := mockrepo.NewMockIAccount(ctrl)
+		repoAuditLog := mockrepo.NewMockIAuditLog(ctrl)
+		transactor := mockrepo.NewMockITransactor(ctrl)
 
 		a := &Auth{
 			cfg:         config.Config{},
 			repoAuth:    repoAuth,
 			repoProfile: repoProfile,
+			repoSession: repoSession,
+			repoAccount: repoAccount,
+			transactor:  transactor,
+			auditor:     newAuditor(config.Config{}, repoAuditLog),
 		}
 
+		transactor.EXPECT().
+			WithinTx(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+				return fn(ctx)
+			})
+
+		repoAccount.EXPECT().LockUsername(gomock.Any(), "hidayat").Return(nil)
+
 		repoAuth.EXPECT().RegisterUser(gomock.Any(), gomock.Any()).Return(int64(34), nil)
 
+		repoAuditLog.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any()).Return(nil)
+
 		resRegisterUser, err := a.RegisterUser(context.Background(), gouser.ReqRegisterUser{
 			Username: "hidayat",
 			Password: "mypassword",
@@ -194,6 +370,158 @@ func TestUnitAuthRegisterUser(t *testing.T) {
 		assert.Equal(t, int64(34), resRegisterUser.UserID)
 		require.NoError(t, err)
 	})
+	t.Run("username held by deleted user should return error", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoAuth := mockrepo.NewMockIAuth(ctrl)
+		repoAccount := mockrepo.NewMockIAccount(ctrl)
+		repoAuditLog := mockrepo.NewMockIAuditLog(ctrl)
+		transactor := mockrepo.NewMockITransactor(ctrl)
+
+		a := &Auth{
+			cfg: config.Config{
+				Account: config.Account{HoldDeletedUsername: true},
+			},
+			repoAuth:    repoAuth,
+			repoAccount: repoAccount,
+			transactor:  transactor,
+			auditor:     newAuditor(config.Config{}, repoAuditLog),
+		}
+
+		transactor.EXPECT().
+			WithinTx(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+				return fn(ctx)
+			})
+
+		repoAccount.EXPECT().LockUsername(gomock.Any(), "hidayat").Return(nil)
+
+		repoAccount.EXPECT().IsUsernameHeldByDeletedUser(gomock.Any(), "hidayat").Return(true, nil)
+
+		repoAuditLog.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any()).Return(nil)
+
+		resRegisterUser, err := a.RegisterUser(context.Background(), gouser.ReqRegisterUser{
+			Username: "hidayat",
+			Password: "mypassword",
+		})
+
+		assert.Empty(t, resRegisterUser)
+		require.Error(t, err)
+		require.ErrorIs(t, err, gouser.ErrDuplicateUsername)
+	})
+	t.Run("old username of other user within grace period should return error", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoAuth := mockrepo.NewMockIAuth(ctrl)
+		repoAccount := mockrepo.NewMockIAccount(ctrl)
+		repoProfile := mockrepo.NewMockIProfile(ctrl)
+		repoAuditLog := mockrepo.NewMockIAuditLog(ctrl)
+		transactor := mockrepo.NewMockITransactor(ctrl)
+
+		a := &Auth{
+			cfg: config.Config{
+				Username: config.Username{HistoryGraceHour: 48},
+			},
+			repoAuth:    repoAuth,
+			repoAccount: repoAccount,
+			repoProfile: repoProfile,
+			transactor:  transactor,
+			auditor:     newAuditor(config.Config{}, repoAuditLog),
+		}
+
+		transactor.EXPECT().
+			WithinTx(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+				return fn(ctx)
+			})
+
+		repoAccount.EXPECT().LockUsername(gomock.Any(), "hidayat").Return(nil)
+		repoProfile.EXPECT().
+			GetProfileByOldUsername(gomock.Any(), "hidayat", gomock.Any()).
+			Return(entity.User{ID: 45}, nil)
+
+		repoAuditLog.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any()).Return(nil)
+
+		resRegisterUser, err := a.RegisterUser(context.Background(), gouser.ReqRegisterUser{
+			Username: "hidayat",
+			Password: "mypassword",
+		})
+
+		assert.Empty(t, resRegisterUser)
+		require.Error(t, err)
+		require.ErrorIs(t, err, gouser.ErrDuplicateUsername)
+	})
+	t.Run("username should be registered lowercase", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoAuth := mockrepo.NewMockIAuth(ctrl)
+		repoAccount := mockrepo.NewMockIAccount(ctrl)
+		repoAuditLog := mockrepo.NewMockIAuditLog(ctrl)
+		transactor := mockrepo.NewMockITransactor(ctrl)
+
+		a := &Auth{
+			cfg:         config.Config{},
+			repoAuth:    repoAuth,
+			repoAccount: repoAccount,
+			transactor:  transactor,
+			auditor:     newAuditor(config.Config{}, repoAuditLog),
+		}
+
+		transactor.EXPECT().
+			WithinTx(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+				return fn(ctx)
+			})
+
+		repoAccount.EXPECT().LockUsername(gomock.Any(), "hidayat").Return(nil)
+
+		repoAuth.EXPECT().
+			RegisterUser(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, user entity.User) (int64, error) {
+				assert.Equal(t, "hidayat", user.Username)
+				return int64(34), nil
+			})
+
+		repoAuditLog.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any()).Return(nil)
+
+		resRegisterUser, err := a.RegisterUser(context.Background(), gouser.ReqRegisterUser{
+			Username: " Hidayat ",
+			Password: "mypassword",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(34), resRegisterUser.UserID)
+	})
+	t.Run("invalid username should return error", func(t *testing.T) {
+		t.Parallel()
+
+		usernames := []string{
+			"me", "id", "batch", "username", "export",
+			"ab", strings.Repeat("a", gouser.UsernameMaxLength+1),
+			"hi dayat", "hidayat!", "_hidayat", "hidáyat",
+		}
+		for _, username := range usernames {
+			a := &Auth{cfg: config.Config{}}
+
+			resRegisterUser, err := a.RegisterUser(context.Background(), gouser.ReqRegisterUser{
+				Username: username,
+				Password: "mypassword",
+			})
+
+			assert.Empty(t, resRegisterUser, username)
+			require.Error(t, err, username)
+			require.ErrorIs(t, err, gouser.ErrRequestInvalid, username)
+		}
+	})
 	t.Run("call repo RegisterUser error should return error", func(t *testing.T) {
 		t.Parallel()
 
@@ -202,17 +530,73 @@ func TestUnitAuthRegisterUser(t *testing.T) {
 
 		repoAuth := mockrepo.NewMockIAuth(ctrl)
 		repoProfile := mockrepo.NewMockIProfile(ctrl)
+		repoSession := mockrepo.NewMockISession(ctrl)
+		repoAccount := mockrepo.NewMockIAccount(ctrl)
+		repoAuditLog := mockrepo.NewMockIAuditLog(ctrl)
+		transactor := mockrepo.NewMockITransactor(ctrl)
 
 		a := &Auth{
 			cfg:         config.Config{},
 			repoAuth:    repoAuth,
 			repoProfile: repoProfile,
+			repoSession: repoSession,
+			repoAccount: repoAccount,
+			transactor:  transactor,
+			auditor:     newAuditor(config.Config{}, repoAuditLog),
 		}
 
+		transactor.EXPECT().
+			WithinTx(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+				return fn(ctx)
+			})
+
+		repoAccount.EXPECT().LockUsername(gomock.Any(), "hidayat").Return(nil)
+
 		repoAuth.EXPECT().
 			RegisterUser(gomock.Any(), gomock.Any()).
 			Return(int64(0), assert.AnError)
 
+		repoAuditLog.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any()).Return(nil)
+
+		resRegisterUser, err := a.RegisterUser(context.Background(), gouser.ReqRegisterUser{
+			Username: "hidayat",
+			Password: "mypassword",
+		})
+
+		assert.Empty(t, resRegisterUser)
+		require.Error(t, err)
+		require.ErrorIs(t, err, assert.AnError)
+	})
+	t.Run("lock username error should return error", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoAuth := mockrepo.NewMockIAuth(ctrl)
+		repoAccount := mockrepo.NewMockIAccount(ctrl)
+		repoAuditLog := mockrepo.NewMockIAuditLog(ctrl)
+		transactor := mockrepo.NewMockITransactor(ctrl)
+
+		a := &Auth{
+			cfg:         config.Config{},
+			repoAuth:    repoAuth,
+			repoAccount: repoAccount,
+			transactor:  transactor,
+			auditor:     newAuditor(config.Config{}, repoAuditLog),
+		}
+
+		transactor.EXPECT().
+			WithinTx(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+				return fn(ctx)
+			})
+
+		repoAccount.EXPECT().LockUsername(gomock.Any(), "hidayat").Return(assert.AnError)
+
+		repoAuditLog.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any()).Return(nil)
+
 		resRegisterUser, err := a.RegisterUser(context.Background(), gouser.ReqRegisterUser{
 			Username: "hidayat",
 			Password: "mypassword",
@@ -230,13 +614,19 @@ func TestUnitAuthRegisterUser(t *testing.T) {
 
 		repoAuth := mockrepo.NewMockIAuth(ctrl)
 		repoProfile := mockrepo.NewMockIProfile(ctrl)
+		repoSession := mockrepo.NewMockISession(ctrl)
+		repoAuditLog := mockrepo.NewMockIAuditLog(ctrl)
 
 		a := &Auth{
 			cfg:         config.Config{},
 			repoAuth:    repoAuth,
 			repoProfile: repoProfile,
+			repoSession: repoSession,
+			auditor:     newAuditor(config.Config{}, repoAuditLog),
 		}
 
+		repoAuditLog.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any()).Return(nil)
+
 		resRegisterUser, err := a.RegisterUser(context.Background(), gouser.ReqRegisterUser{
 			Username: "hidayat",
 			Password: uuid.NewString() + uuid.NewString() + uuid.NewString(), // password > 72 bytes will error bcrypt
@@ -253,11 +643,13 @@ func TestUnitAuthRegisterUser(t *testing.T) {
 
 		repoAuth := mockrepo.NewMockIAuth(ctrl)
 		repoProfile := mockrepo.NewMockIProfile(ctrl)
+		repoSession := mockrepo.NewMockISession(ctrl)
 
 		a := &Auth{
 			cfg:         config.Config{},
 			repoAuth:    repoAuth,
 			repoProfile: repoProfile,
+			repoSession: repoSession,
 		}
 
 		t.Run("empty username should return error", func(t *testing.T) {
@@ -282,3 +674,22 @@ func TestUnitAuthRegisterUser(t *testing.T) {
 		})
 	})
 }
+
+func TestUnitAuthFailureReason(t *testing.T) {
+	t.Parallel()
+
+	t.Run("sentinel error should return its reason", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, "request_invalid", authFailureReason(fmt.Errorf("%w: %w", gouser.ErrRequestInvalid, assert.AnError)))
+		assert.Equal(t, "unknown_username", authFailureReason(fmt.Errorf("Auth.loginUser: %w", gouser.ErrUnknownUsername)))
+		assert.Equal(t, "wrong_password", authFailureReason(fmt.Errorf("Auth.loginUser: %w", gouser.ErrWrongPassword)))
+		assert.Equal(t, "user_not_active", authFailureReason(fmt.Errorf("checkUserActive: %w", gouser.ErrUserNotActive)))
+		assert.Equal(t, "duplicate_username", authFailureReason(fmt.Errorf("checkUsernameClaimable: %w", gouser.ErrDuplicateUsername)))
+	})
+	t.Run("other error should return internal", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Equal(t, "internal", authFailureReason(assert.AnError))
+	})
+}
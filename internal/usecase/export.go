@@ -0,0 +1,263 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/Hidayathamir/go-user/config"
+	"github.com/Hidayathamir/go-user/internal/repo"
+	"github.com/Hidayathamir/go-user/internal/repo/db/entity"
+	"github.com/Hidayathamir/go-user/pkg/gouser"
+)
+
+//go:generate mockgen -source=export.go -destination=mockusecase/export.go -package=mockusecase
+
+// IExport contains abstraction of usecase personal data export.
+type IExport interface {
+	// ExportMyData write export archive of the user who own the JWT to w.
+	ExportMyData(ctx context.Context, req gouser.ReqExportMyData, w io.Writer) error
+	// ExportUserData write export archive of any user to w, admin only.
+	ExportUserData(ctx context.Context, req gouser.ReqExportUserData, w io.Writer) error
+}
+
+// Export implement IExport.
+type Export struct {
+	cfg          config.Config
+	guard        *guard
+	repoProfile  repo.IProfile
+	repoSession  repo.ISession
+	repoAuditLog repo.IAuditLog
+}
+
+var _ IExport = &Export{}
+
+// NewExport return *Export which implement IExport.
+func NewExport(cfg config.Config, repoProfile repo.IProfile, repoSession repo.ISession, repoAuditLog repo.IAuditLog) *Export {
+	return &Export{
+		cfg:          cfg,
+		guard:        newGuard(cfg, repoSession, repoProfile),
+		repoProfile:  repoProfile,
+		repoSession:  repoSession,
+		repoAuditLog: repoAuditLog,
+	}
+}
+
+// ExportMyData write export archive of the user who own the JWT to w. Nothing
+// is written to w when it returns error before the archive is started.
+func (e *Export) ExportMyData(ctx context.Context, req gouser.ReqExportMyData, w io.Writer) error {
+	err := req.Validate()
+	if err != nil {
+		err := fmt.Errorf("ReqExportMyData.Validate: %w", err)
+		return fmt.Errorf("%w: %w", gouser.ErrRequestInvalid, err)
+	}
+
+	_, user, err := e.guard.authenticateUser(ctx, req.UserJWT)
+	if err != nil {
+		return fmt.Errorf("Export.guard.authenticateUser: %w", err)
+	}
+
+	err = e.writeArchive(ctx, w, user)
+	if err != nil {
+		return fmt.Errorf("Export.writeArchive: %w", err)
+	}
+
+	return nil
+}
+
+// ExportUserData write export archive of any user to w, admin only. Nothing is
+// written to w when it returns error before the archive is started.
+func (e *Export) ExportUserData(ctx context.Context, req gouser.ReqExportUserData, w io.Writer) error {
+	err := req.Validate()
+	if err != nil {
+		err := fmt.Errorf("ReqExportUserData.Validate: %w", err)
+		return fmt.Errorf("%w: %w", gouser.ErrRequestInvalid, err)
+	}
+
+	_, err = e.guard.authenticateAdmin(ctx, req.UserJWT)
+	if err != nil {
+		return fmt.Errorf("Export.guard.authenticateAdmin: %w", err)
+	}
+
+	user, err := e.repoProfile.GetProfileByUserID(ctx, req.UserID)
+	if err != nil {
+		return fmt.Errorf("Export.repoProfile.GetProfileByUserID: %w", err)
+	}
+
+	err = e.writeArchive(ctx, w, user)
+	if err != nil {
+		return fmt.Errorf("Export.writeArchive: %w", err)
+	}
+
+	return nil
+}
+
+// writeArchive write export archive as one JSON object. List fields are
+// streamed from repo to w one item at a time.
+func (e *Export) writeArchive(ctx context.Context, w io.Writer, user entity.User) error {
+	archive := &exportArchiveWriter{w: w}
+
+	err := archive.begin()
+	if err != nil {
+		return fmt.Errorf("exportArchiveWriter.begin: %w", err)
+	}
+
+	err = archive.field("version", gouser.ExportVersion)
+	if err != nil {
+		return fmt.Errorf("exportArchiveWriter.field: %w", err)
+	}
+
+	err = archive.field("exported_at", time.Now())
+	if err != nil {
+		return fmt.Errorf("exportArchiveWriter.field: %w", err)
+	}
+
+	err = archive.field("user", gouser.ExportUser{}.LoadEntityUser(user))
+	if err != nil {
+		return fmt.Errorf("exportArchiveWriter.field: %w", err)
+	}
+
+	err = archive.arrayField("sessions", func(add func(any) error) error {
+		return e.repoSession.IterateSessionsByUserID(ctx, user.ID, func(session entity.Session) error {
+			return add(gouser.ExportSession{}.LoadEntitySession(session))
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("exportArchiveWriter.arrayField: %w", err)
+	}
+
+	err = archive.arrayField("username_history", func(add func(any) error) error {
+		return e.repoProfile.IterateUsernameHistoryByUserID(ctx, user.ID, func(history entity.UsernameHistory) error {
+			return add(gouser.ExportUsernameHistory{}.LoadEntityUsernameHistory(history))
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("exportArchiveWriter.arrayField: %w", err)
+	}
+
+	err = archive.arrayField("audit_logs", func(add func(any) error) error {
+		return e.repoAuditLog.IterateAuditLogsByUserID(ctx, user.ID, func(auditLog entity.AuditLog) error {
+			return add(gouser.ExportAuditLog{}.LoadEntityAuditLog(user.ID, auditLog))
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("exportArchiveWriter.arrayField: %w", err)
+	}
+
+	err = archive.end()
+	if err != nil {
+		return fmt.Errorf("exportArchiveWriter.end: %w", err)
+	}
+
+	return nil
+}
+
+// exportArchiveWriter write JSON object field by field, so a large list does
+// not need to be buffered.
+type exportArchiveWriter struct {
+	w          io.Writer
+	fieldCount int
+}
+
+func (a *exportArchiveWriter) begin() error {
+	_, err := io.WriteString(a.w, "{")
+	return err
+}
+
+func (a *exportArchiveWriter) end() error {
+	_, err := io.WriteString(a.w, "}\n")
+	return err
+}
+
+func (a *exportArchiveWriter) writeKey(key string) error {
+	if a.fieldCount > 0 {
+		_, err := io.WriteString(a.w, ",")
+		if err != nil {
+			return fmt.Errorf("io.WriteString: %w", err)
+		}
+	}
+	a.fieldCount++
+
+	keyJSON, err := json.Marshal(key)
+	if err != nil {
+		return fmt.Errorf("json.Marshal: %w", err)
+	}
+
+	_, err = a.w.Write(append(keyJSON, ':'))
+	if err != nil {
+		return fmt.Errorf("io.Writer.Write: %w", err)
+	}
+
+	return nil
+}
+
+func (a *exportArchiveWriter) writeValue(value any) error {
+	valueJSON, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("json.Marshal: %w", err)
+	}
+
+	_, err = a.w.Write(valueJSON)
+	if err != nil {
+		return fmt.Errorf("io.Writer.Write: %w", err)
+	}
+
+	return nil
+}
+
+func (a *exportArchiveWriter) field(key string, value any) error {
+	err := a.writeKey(key)
+	if err != nil {
+		return fmt.Errorf("exportArchiveWriter.writeKey: %w", err)
+	}
+
+	err = a.writeValue(value)
+	if err != nil {
+		return fmt.Errorf("exportArchiveWriter.writeValue: %w", err)
+	}
+
+	return nil
+}
+
+// arrayField write JSON array field, iterate should call add for every item.
+func (a *exportArchiveWriter) arrayField(key string, iterate func(add func(any) error) error) error {
+	err := a.writeKey(key)
+	if err != nil {
+		return fmt.Errorf("exportArchiveWriter.writeKey: %w", err)
+	}
+
+	_, err = io.WriteString(a.w, "[")
+	if err != nil {
+		return fmt.Errorf("io.WriteString: %w", err)
+	}
+
+	itemCount := 0
+	err = iterate(func(item any) error {
+		if itemCount > 0 {
+			_, err := io.WriteString(a.w, ",")
+			if err != nil {
+				return fmt.Errorf("io.WriteString: %w", err)
+			}
+		}
+		itemCount++
+
+		err := a.writeValue(item)
+		if err != nil {
+			return fmt.Errorf("exportArchiveWriter.writeValue: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("iterate: %w", err)
+	}
+
+	_, err = io.WriteString(a.w, "]")
+	if err != nil {
+		return fmt.Errorf("io.WriteString: %w", err)
+	}
+
+	return nil
+}
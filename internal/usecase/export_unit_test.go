@@ -0,0 +1,382 @@
+package usecase
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/Hidayathamir/go-user/config"
+	"github.com/Hidayathamir/go-user/internal/pkg/auth"
+	"github.com/Hidayathamir/go-user/internal/repo/db/entity"
+	"github.com/Hidayathamir/go-user/internal/repo/mockrepo"
+	"github.com/Hidayathamir/go-user/pkg/gouser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type exportArchive struct {
+	Version         int                            `json:"version"`
+	ExportedAt      time.Time                      `json:"exported_at"`
+	User            map[string]any                 `json:"user"`
+	Sessions        []gouser.ExportSession         `json:"sessions"`
+	UsernameHistory []gouser.ExportUsernameHistory `json:"username_history"`
+	AuditLogs       []gouser.ExportAuditLog        `json:"audit_logs"`
+}
+
+func TestUnitExportExportMyData(t *testing.T) {
+	t.Parallel()
+
+	t.Run("export my data success", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoProfile := mockrepo.NewMockIProfile(ctrl)
+		repoSession := mockrepo.NewMockISession(ctrl)
+		repoAuditLog := mockrepo.NewMockIAuditLog(ctrl)
+
+		cfg := config.Config{
+			JWT: config.JWT{ExpireHour: 24, SignedKey: "secretsignkey"},
+		}
+
+		e := &Export{
+			cfg:          cfg,
+			guard:        newGuard(cfg, repoSession, repoProfile),
+			repoProfile:  repoProfile,
+			repoSession:  repoSession,
+			repoAuditLog: repoAuditLog,
+		}
+
+		repoSession.EXPECT().
+			GetSessionByJTI(gomock.Any(), "jti1").
+			Return(entity.Session{ID: 1, UserID: 44, JTI: "jti1"}, nil)
+		repoSession.EXPECT().UpdateSessionLastSeenAt(gomock.Any(), int64(1), gomock.Any()).Return(nil)
+		repoProfile.EXPECT().
+			GetProfileByUserID(gomock.Any(), int64(44)).
+			Return(entity.User{ID: 44, Username: "hidayat", Password: "hashedpassword", Status: entity.UserStatusActive}, nil)
+		repoSession.EXPECT().
+			IterateSessionsByUserID(gomock.Any(), int64(44), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ int64, fn func(entity.Session) error) error {
+				for _, session := range []entity.Session{
+					{ID: 1, UserID: 44, JTI: "jti1", UserAgent: "Mozilla/5.0"},
+					{ID: 2, UserID: 44, JTI: "jti2", UserAgent: "curl/8.0"},
+				} {
+					err := fn(session)
+					if err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+		repoProfile.EXPECT().
+			IterateUsernameHistoryByUserID(gomock.Any(), int64(44), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ int64, fn func(entity.UsernameHistory) error) error {
+				return fn(entity.UsernameHistory{ID: 3, UserID: 44, Username: "hidayat_old"})
+			})
+		userID, adminID := int64(44), int64(1)
+		repoAuditLog.EXPECT().
+			IterateAuditLogsByUserID(gomock.Any(), int64(44), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ int64, fn func(entity.AuditLog) error) error {
+				for _, auditLog := range []entity.AuditLog{
+					{ID: 7, ActorUserID: &userID, TargetUserID: &userID, Action: entity.AuditActionUserLogin, IP: "10.0.0.1", UserAgent: "Mozilla/5.0"},
+					{ID: 8, ActorUserID: &adminID, TargetUserID: &userID, Action: entity.AuditActionUserPasswordChange, IP: "10.9.9.9", UserAgent: "admin-tool"},
+				} {
+					err := fn(auditLog)
+					if err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+
+		buf := &bytes.Buffer{}
+		err := e.ExportMyData(context.Background(), gouser.ReqExportMyData{
+			UserJWT: auth.GenerateUserJWTToken(44, "jti1", cfg),
+		}, buf)
+
+		require.NoError(t, err)
+		assert.NotContains(t, buf.String(), "hashedpassword")
+		assert.NotContains(t, buf.String(), "10.9.9.9")
+
+		archive := exportArchive{}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &archive))
+		assert.Equal(t, gouser.ExportVersion, archive.Version)
+		assert.NotZero(t, archive.ExportedAt)
+		assert.Equal(t, "hidayat", archive.User["username"])
+		assert.NotContains(t, archive.User, "password")
+		require.Len(t, archive.Sessions, 2)
+		assert.Equal(t, "Mozilla/5.0", archive.Sessions[0].UserAgent)
+		assert.Equal(t, "curl/8.0", archive.Sessions[1].UserAgent)
+		require.Len(t, archive.UsernameHistory, 1)
+		assert.Equal(t, "hidayat_old", archive.UsernameHistory[0].Username)
+		require.Len(t, archive.AuditLogs, 2)
+		assert.Equal(t, entity.AuditActionUserLogin, archive.AuditLogs[0].Action)
+		assert.Equal(t, "10.0.0.1", archive.AuditLogs[0].IP)
+		assert.Equal(t, entity.AuditActionUserPasswordChange, archive.AuditLogs[1].Action)
+		assert.Equal(t, &adminID, archive.AuditLogs[1].ActorUserID)
+		assert.Empty(t, archive.AuditLogs[1].IP)
+		assert.Empty(t, archive.AuditLogs[1].UserAgent)
+	})
+	t.Run("user without session should export empty list", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoProfile := mockrepo.NewMockIProfile(ctrl)
+		repoSession := mockrepo.NewMockISession(ctrl)
+		repoAuditLog := mockrepo.NewMockIAuditLog(ctrl)
+
+		cfg := config.Config{
+			JWT: config.JWT{ExpireHour: 24, SignedKey: "secretsignkey"},
+		}
+
+		e := &Export{
+			cfg:          cfg,
+			guard:        newGuard(cfg, repoSession, repoProfile),
+			repoProfile:  repoProfile,
+			repoSession:  repoSession,
+			repoAuditLog: repoAuditLog,
+		}
+
+		repoSession.EXPECT().
+			GetSessionByJTI(gomock.Any(), "jti1").
+			Return(entity.Session{ID: 1, UserID: 44, JTI: "jti1"}, nil)
+		repoSession.EXPECT().UpdateSessionLastSeenAt(gomock.Any(), int64(1), gomock.Any()).Return(nil)
+		repoProfile.EXPECT().
+			GetProfileByUserID(gomock.Any(), int64(44)).
+			Return(entity.User{ID: 44, Status: entity.UserStatusActive}, nil)
+		repoSession.EXPECT().IterateSessionsByUserID(gomock.Any(), int64(44), gomock.Any()).Return(nil)
+		repoProfile.EXPECT().IterateUsernameHistoryByUserID(gomock.Any(), int64(44), gomock.Any()).Return(nil)
+		repoAuditLog.EXPECT().IterateAuditLogsByUserID(gomock.Any(), int64(44), gomock.Any()).Return(nil)
+
+		buf := &bytes.Buffer{}
+		err := e.ExportMyData(context.Background(), gouser.ReqExportMyData{
+			UserJWT: auth.GenerateUserJWTToken(44, "jti1", cfg),
+		}, buf)
+
+		require.NoError(t, err)
+		assert.Contains(t, buf.String(), `"sessions":[]`)
+		assert.Contains(t, buf.String(), `"username_history":[]`)
+		assert.Contains(t, buf.String(), `"audit_logs":[]`)
+	})
+	t.Run("authenticate error should not write archive", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoProfile := mockrepo.NewMockIProfile(ctrl)
+		repoSession := mockrepo.NewMockISession(ctrl)
+		repoAuditLog := mockrepo.NewMockIAuditLog(ctrl)
+
+		cfg := config.Config{
+			JWT: config.JWT{ExpireHour: 24, SignedKey: "secretsignkey"},
+		}
+
+		e := &Export{
+			cfg:          cfg,
+			guard:        newGuard(cfg, repoSession, repoProfile),
+			repoProfile:  repoProfile,
+			repoSession:  repoSession,
+			repoAuditLog: repoAuditLog,
+		}
+
+		buf := &bytes.Buffer{}
+		err := e.ExportMyData(context.Background(), gouser.ReqExportMyData{
+			UserJWT: "invalidjwt",
+		}, buf)
+
+		require.Error(t, err)
+		require.ErrorIs(t, err, gouser.ErrJWTAuth)
+		assert.Empty(t, buf.String())
+	})
+}
+
+func TestUnitExportExportUserData(t *testing.T) {
+	t.Parallel()
+
+	t.Run("admin export user data success", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoProfile := mockrepo.NewMockIProfile(ctrl)
+		repoSession := mockrepo.NewMockISession(ctrl)
+		repoAuditLog := mockrepo.NewMockIAuditLog(ctrl)
+
+		cfg := config.Config{
+			JWT: config.JWT{ExpireHour: 24, SignedKey: "secretsignkey"},
+		}
+
+		e := &Export{
+			cfg:          cfg,
+			guard:        newGuard(cfg, repoSession, repoProfile),
+			repoProfile:  repoProfile,
+			repoSession:  repoSession,
+			repoAuditLog: repoAuditLog,
+		}
+
+		repoSession.EXPECT().
+			GetSessionByJTI(gomock.Any(), "jtiadmin").
+			Return(entity.Session{ID: 1, UserID: 1, JTI: "jtiadmin"}, nil)
+		repoSession.EXPECT().UpdateSessionLastSeenAt(gomock.Any(), int64(1), gomock.Any()).Return(nil)
+		repoProfile.EXPECT().
+			GetProfileByUserID(gomock.Any(), int64(1)).
+			Return(entity.User{ID: 1, Role: entity.UserRoleAdmin, Status: entity.UserStatusActive}, nil)
+		repoProfile.EXPECT().
+			GetProfileByUserID(gomock.Any(), int64(44)).
+			Return(entity.User{ID: 44, Username: "hidayat", Status: entity.UserStatusDisabled}, nil)
+		repoSession.EXPECT().IterateSessionsByUserID(gomock.Any(), int64(44), gomock.Any()).Return(nil)
+		repoProfile.EXPECT().IterateUsernameHistoryByUserID(gomock.Any(), int64(44), gomock.Any()).Return(nil)
+		repoAuditLog.EXPECT().IterateAuditLogsByUserID(gomock.Any(), int64(44), gomock.Any()).Return(nil)
+
+		buf := &bytes.Buffer{}
+		err := e.ExportUserData(context.Background(), gouser.ReqExportUserData{
+			UserJWT: auth.GenerateUserJWTToken(1, "jtiadmin", cfg),
+			UserID:  44,
+		}, buf)
+
+		require.NoError(t, err)
+
+		archive := exportArchive{}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &archive))
+		assert.Equal(t, "hidayat", archive.User["username"])
+		assert.Equal(t, entity.UserStatusDisabled, archive.User["status"])
+	})
+	t.Run("non admin should return error", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoProfile := mockrepo.NewMockIProfile(ctrl)
+		repoSession := mockrepo.NewMockISession(ctrl)
+		repoAuditLog := mockrepo.NewMockIAuditLog(ctrl)
+
+		cfg := config.Config{
+			JWT: config.JWT{ExpireHour: 24, SignedKey: "secretsignkey"},
+		}
+
+		e := &Export{
+			cfg:          cfg,
+			guard:        newGuard(cfg, repoSession, repoProfile),
+			repoProfile:  repoProfile,
+			repoSession:  repoSession,
+			repoAuditLog: repoAuditLog,
+		}
+
+		repoSession.EXPECT().
+			GetSessionByJTI(gomock.Any(), "jti2").
+			Return(entity.Session{ID: 2, UserID: 2, JTI: "jti2"}, nil)
+		repoSession.EXPECT().UpdateSessionLastSeenAt(gomock.Any(), int64(2), gomock.Any()).Return(nil)
+		repoProfile.EXPECT().
+			GetProfileByUserID(gomock.Any(), int64(2)).
+			Return(entity.User{ID: 2, Role: entity.UserRoleUser, Status: entity.UserStatusActive}, nil)
+
+		buf := &bytes.Buffer{}
+		err := e.ExportUserData(context.Background(), gouser.ReqExportUserData{
+			UserJWT: auth.GenerateUserJWTToken(2, "jti2", cfg),
+			UserID:  44,
+		}, buf)
+
+		require.Error(t, err)
+		require.ErrorIs(t, err, gouser.ErrForbidden)
+		assert.Empty(t, buf.String())
+	})
+	t.Run("repo IterateSessionsByUserID error should return error", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoProfile := mockrepo.NewMockIProfile(ctrl)
+		repoSession := mockrepo.NewMockISession(ctrl)
+		repoAuditLog := mockrepo.NewMockIAuditLog(ctrl)
+
+		cfg := config.Config{
+			JWT: config.JWT{ExpireHour: 24, SignedKey: "secretsignkey"},
+		}
+
+		e := &Export{
+			cfg:          cfg,
+			guard:        newGuard(cfg, repoSession, repoProfile),
+			repoProfile:  repoProfile,
+			repoSession:  repoSession,
+			repoAuditLog: repoAuditLog,
+		}
+
+		repoSession.EXPECT().
+			GetSessionByJTI(gomock.Any(), "jtiadmin").
+			Return(entity.Session{ID: 1, UserID: 1, JTI: "jtiadmin"}, nil)
+		repoSession.EXPECT().UpdateSessionLastSeenAt(gomock.Any(), int64(1), gomock.Any()).Return(nil)
+		repoProfile.EXPECT().
+			GetProfileByUserID(gomock.Any(), int64(1)).
+			Return(entity.User{ID: 1, Role: entity.UserRoleAdmin, Status: entity.UserStatusActive}, nil)
+		repoProfile.EXPECT().
+			GetProfileByUserID(gomock.Any(), int64(44)).
+			Return(entity.User{ID: 44}, nil)
+		repoSession.EXPECT().
+			IterateSessionsByUserID(gomock.Any(), int64(44), gomock.Any()).
+			Return(assert.AnError)
+
+		err := e.ExportUserData(context.Background(), gouser.ReqExportUserData{
+			UserJWT: auth.GenerateUserJWTToken(1, "jtiadmin", cfg),
+			UserID:  44,
+		}, &bytes.Buffer{})
+
+		require.Error(t, err)
+		require.ErrorIs(t, err, assert.AnError)
+	})
+	t.Run("repo IterateAuditLogsByUserID error should return error", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoProfile := mockrepo.NewMockIProfile(ctrl)
+		repoSession := mockrepo.NewMockISession(ctrl)
+		repoAuditLog := mockrepo.NewMockIAuditLog(ctrl)
+
+		cfg := config.Config{
+			JWT: config.JWT{ExpireHour: 24, SignedKey: "secretsignkey"},
+		}
+
+		e := &Export{
+			cfg:          cfg,
+			guard:        newGuard(cfg, repoSession, repoProfile),
+			repoProfile:  repoProfile,
+			repoSession:  repoSession,
+			repoAuditLog: repoAuditLog,
+		}
+
+		repoSession.EXPECT().
+			GetSessionByJTI(gomock.Any(), "jtiadmin").
+			Return(entity.Session{ID: 1, UserID: 1, JTI: "jtiadmin"}, nil)
+		repoSession.EXPECT().UpdateSessionLastSeenAt(gomock.Any(), int64(1), gomock.Any()).Return(nil)
+		repoProfile.EXPECT().
+			GetProfileByUserID(gomock.Any(), int64(1)).
+			Return(entity.User{ID: 1, Role: entity.UserRoleAdmin, Status: entity.UserStatusActive}, nil)
+		repoProfile.EXPECT().
+			GetProfileByUserID(gomock.Any(), int64(44)).
+			Return(entity.User{ID: 44}, nil)
+		repoSession.EXPECT().IterateSessionsByUserID(gomock.Any(), int64(44), gomock.Any()).Return(nil)
+		repoProfile.EXPECT().IterateUsernameHistoryByUserID(gomock.Any(), int64(44), gomock.Any()).Return(nil)
+		repoAuditLog.EXPECT().
+			IterateAuditLogsByUserID(gomock.Any(), int64(44), gomock.Any()).
+			Return(assert.AnError)
+
+		err := e.ExportUserData(context.Background(), gouser.ReqExportUserData{
+			UserJWT: auth.GenerateUserJWTToken(1, "jtiadmin", cfg),
+			UserID:  44,
+		}, &bytes.Buffer{})
+
+		require.Error(t, err)
+		require.ErrorIs(t, err, assert.AnError)
+	})
+}
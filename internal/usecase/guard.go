@@ -0,0 +1,117 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Hidayathamir/go-user/config"
+	"github.com/Hidayathamir/go-user/internal/pkg/auth"
+	"github.com/Hidayathamir/go-user/internal/pkg/logger"
+	"github.com/Hidayathamir/go-user/internal/repo"
+	"github.com/Hidayathamir/go-user/internal/repo/db/entity"
+	"github.com/Hidayathamir/go-user/pkg/gouser"
+	"github.com/sirupsen/logrus"
+)
+
+// guard authenticates user JWT against the session it is bound to, and
+// authorizes admin. It is shared by usecases that accept user JWT.
+type guard struct {
+	cfg         config.Config
+	repoSession repo.ISession
+	repoProfile repo.IProfile
+}
+
+func newGuard(cfg config.Config, repoSession repo.ISession, repoProfile repo.IProfile) *guard {
+	return &guard{
+		cfg:         cfg,
+		repoSession: repoSession,
+		repoProfile: repoProfile,
+	}
+}
+
+// authenticate validates user JWT, the session bound to it and the user
+// status, return JWT claims. Token of revoked session or not active user is
+// refused.
+func (g *guard) authenticate(ctx context.Context, userJWT string) (auth.UserJWTClaims, error) {
+	claims, _, err := g.authenticateUser(ctx, userJWT)
+	if err != nil {
+		return auth.UserJWTClaims{}, fmt.Errorf("guard.authenticateUser: %w", err)
+	}
+	return claims, nil
+}
+
+// authenticateUser is like authenticate but also return the user who own the
+// JWT.
+func (g *guard) authenticateUser(ctx context.Context, userJWT string) (auth.UserJWTClaims, entity.User, error) {
+	claims, err := auth.GetUserJWTClaimsFromJWTTokenString(g.cfg, userJWT)
+	if err != nil {
+		return auth.UserJWTClaims{}, entity.User{}, fmt.Errorf("auth.GetUserJWTClaimsFromJWTTokenString: %w", err)
+	}
+
+	logger.AddFields(ctx, logrus.Fields{logger.FieldUserID: claims.UserID})
+
+	session, err := g.repoSession.GetSessionByJTI(ctx, claims.JTI)
+	if err != nil {
+		err := fmt.Errorf("guard.repoSession.GetSessionByJTI: %w", err)
+		return auth.UserJWTClaims{}, entity.User{}, fmt.Errorf("%w: %w", gouser.ErrJWTAuth, err)
+	}
+
+	if session.UserID != claims.UserID {
+		err := fmt.Errorf("session user id %d != jwt user id %d: %w", session.UserID, claims.UserID, gouser.ErrUnknownSession)
+		return auth.UserJWTClaims{}, entity.User{}, fmt.Errorf("%w: %w", gouser.ErrJWTAuth, err)
+	}
+
+	if session.RevokedAt != nil {
+		return auth.UserJWTClaims{}, entity.User{}, fmt.Errorf("%w: %w", gouser.ErrJWTAuth, gouser.ErrSessionRevoked)
+	}
+
+	user, err := g.repoProfile.GetProfileByUserID(ctx, claims.UserID)
+	if err != nil {
+		err := fmt.Errorf("guard.repoProfile.GetProfileByUserID: %w", err)
+		return auth.UserJWTClaims{}, entity.User{}, fmt.Errorf("%w: %w", gouser.ErrJWTAuth, err)
+	}
+
+	err = checkUserActive(user, time.Now())
+	if err != nil {
+		return auth.UserJWTClaims{}, entity.User{}, fmt.Errorf("checkUserActive: %w", err)
+	}
+
+	err = g.repoSession.UpdateSessionLastSeenAt(ctx, session.ID, time.Now())
+	if err != nil {
+		logger.FromContext(ctx).Warnf("guard.repoSession.UpdateSessionLastSeenAt: %v", err)
+	}
+
+	return claims, user, nil
+}
+
+// authenticateAdmin is like authenticate but also require the user to be
+// admin.
+func (g *guard) authenticateAdmin(ctx context.Context, userJWT string) (auth.UserJWTClaims, error) {
+	claims, user, err := g.authenticateUser(ctx, userJWT)
+	if err != nil {
+		return auth.UserJWTClaims{}, fmt.Errorf("guard.authenticateUser: %w", err)
+	}
+
+	if user.Role != entity.UserRoleAdmin {
+		return auth.UserJWTClaims{}, fmt.Errorf("%w: user role is '%s', need '%s'", gouser.ErrForbidden, user.Role, entity.UserRoleAdmin)
+	}
+
+	return claims, nil
+}
+
+// checkUserActive return gouser.ErrUserNotActive if user is suspended or
+// disabled at time now.
+func checkUserActive(user entity.User, now time.Time) error {
+	if user.IsActive(now) {
+		return nil
+	}
+
+	if user.Status == entity.UserStatusSuspended && user.SuspendedUntil != nil {
+		return fmt.Errorf("%w: user is suspended until %s, reason '%s'",
+			gouser.ErrUserNotActive, user.SuspendedUntil.Format(time.RFC3339), user.SuspensionReason,
+		)
+	}
+
+	return fmt.Errorf("%w: user status is '%s', reason '%s'", gouser.ErrUserNotActive, user.Status, user.SuspensionReason)
+}
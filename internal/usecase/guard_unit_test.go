@@ -0,0 +1,108 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Hidayathamir/go-user/config"
+	"github.com/Hidayathamir/go-user/internal/pkg/auth"
+	"github.com/Hidayathamir/go-user/internal/repo/db/entity"
+	"github.com/Hidayathamir/go-user/internal/repo/mockrepo"
+	"github.com/Hidayathamir/go-user/pkg/gouser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUnitGuardAuthenticate(t *testing.T) {
+	t.Parallel()
+
+	t.Run("disabled user should return error", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoSession := mockrepo.NewMockISession(ctrl)
+		repoProfile := mockrepo.NewMockIProfile(ctrl)
+
+		cfg := config.Config{
+			JWT: config.JWT{ExpireHour: 24, SignedKey: "secretsignkey"},
+		}
+
+		g := newGuard(cfg, repoSession, repoProfile)
+
+		repoSession.EXPECT().
+			GetSessionByJTI(gomock.Any(), "jti1").
+			Return(entity.Session{ID: 1, UserID: 44, JTI: "jti1"}, nil)
+		repoProfile.EXPECT().
+			GetProfileByUserID(gomock.Any(), int64(44)).
+			Return(entity.User{ID: 44, Status: entity.UserStatusDisabled, SuspensionReason: "abuse"}, nil)
+
+		claims, err := g.authenticate(context.Background(), auth.GenerateUserJWTToken(44, "jti1", cfg))
+
+		assert.Empty(t, claims)
+		require.Error(t, err)
+		require.ErrorIs(t, err, gouser.ErrUserNotActive)
+		require.ErrorContains(t, err, "abuse")
+	})
+	t.Run("expired suspension should be accepted", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoSession := mockrepo.NewMockISession(ctrl)
+		repoProfile := mockrepo.NewMockIProfile(ctrl)
+
+		cfg := config.Config{
+			JWT: config.JWT{ExpireHour: 24, SignedKey: "secretsignkey"},
+		}
+
+		g := newGuard(cfg, repoSession, repoProfile)
+
+		suspendedUntil := time.Now().Add(-time.Minute)
+		repoSession.EXPECT().
+			GetSessionByJTI(gomock.Any(), "jti1").
+			Return(entity.Session{ID: 1, UserID: 44, JTI: "jti1"}, nil)
+		repoProfile.EXPECT().
+			GetProfileByUserID(gomock.Any(), int64(44)).
+			Return(entity.User{ID: 44, Status: entity.UserStatusSuspended, SuspendedUntil: &suspendedUntil}, nil)
+		repoSession.EXPECT().UpdateSessionLastSeenAt(gomock.Any(), int64(1), gomock.Any()).Return(nil)
+
+		claims, err := g.authenticate(context.Background(), auth.GenerateUserJWTToken(44, "jti1", cfg))
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(44), claims.UserID)
+	})
+	t.Run("deleted user should return error", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoSession := mockrepo.NewMockISession(ctrl)
+		repoProfile := mockrepo.NewMockIProfile(ctrl)
+
+		cfg := config.Config{
+			JWT: config.JWT{ExpireHour: 24, SignedKey: "secretsignkey"},
+		}
+
+		g := newGuard(cfg, repoSession, repoProfile)
+
+		repoSession.EXPECT().
+			GetSessionByJTI(gomock.Any(), "jti1").
+			Return(entity.Session{ID: 1, UserID: 44, JTI: "jti1"}, nil)
+		repoProfile.EXPECT().
+			GetProfileByUserID(gomock.Any(), int64(44)).
+			Return(entity.User{}, gouser.ErrUnknownUserID)
+
+		claims, err := g.authenticate(context.Background(), auth.GenerateUserJWTToken(44, "jti1", cfg))
+
+		assert.Empty(t, claims)
+		require.Error(t, err)
+		require.ErrorIs(t, err, gouser.ErrJWTAuth)
+		require.ErrorIs(t, err, gouser.ErrUnknownUserID)
+	})
+}
@@ -0,0 +1,112 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: account.go
+//
+// Generated by this command:
+//
+//	mockgen -source=account.go -destination=mockusecase/account.go -package=mockusecase
+//
+
+// Package mockusecase is a generated GoMock package.
+package mockusecase
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gouser "github.com/Hidayathamir/go-user/pkg/gouser"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIAccount is a mock of IAccount interface.
+type MockIAccount struct {
+	ctrl     *gomock.Controller
+	recorder *MockIAccountMockRecorder
+}
+
+// MockIAccountMockRecorder is the mock recorder for MockIAccount.
+type MockIAccountMockRecorder struct {
+	mock *MockIAccount
+}
+
+// NewMockIAccount creates a new mock instance.
+func NewMockIAccount(ctrl *gomock.Controller) *MockIAccount {
+	mock := &MockIAccount{ctrl: ctrl}
+	mock.recorder = &MockIAccountMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIAccount) EXPECT() *MockIAccountMockRecorder {
+	return m.recorder
+}
+
+// ChangeUsername mocks base method.
+func (m *MockIAccount) ChangeUsername(ctx context.Context, req gouser.ReqChangeUsername) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeUsername", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ChangeUsername indicates an expected call of ChangeUsername.
+func (mr *MockIAccountMockRecorder) ChangeUsername(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeUsername", reflect.TypeOf((*MockIAccount)(nil).ChangeUsername), ctx, req)
+}
+
+// DeleteAccount mocks base method.
+func (m *MockIAccount) DeleteAccount(ctx context.Context, req gouser.ReqDeleteAccount) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAccount", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAccount indicates an expected call of DeleteAccount.
+func (mr *MockIAccountMockRecorder) DeleteAccount(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAccount", reflect.TypeOf((*MockIAccount)(nil).DeleteAccount), ctx, req)
+}
+
+// PurgeDeletedAccounts mocks base method.
+func (m *MockIAccount) PurgeDeletedAccounts(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeDeletedAccounts", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeDeletedAccounts indicates an expected call of PurgeDeletedAccounts.
+func (mr *MockIAccountMockRecorder) PurgeDeletedAccounts(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeDeletedAccounts", reflect.TypeOf((*MockIAccount)(nil).PurgeDeletedAccounts), ctx)
+}
+
+// RestoreAccount mocks base method.
+func (m *MockIAccount) RestoreAccount(ctx context.Context, req gouser.ReqRestoreAccount) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreAccount", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RestoreAccount indicates an expected call of RestoreAccount.
+func (mr *MockIAccountMockRecorder) RestoreAccount(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreAccount", reflect.TypeOf((*MockIAccount)(nil).RestoreAccount), ctx, req)
+}
+
+// UpdateUserStatus mocks base method.
+func (m *MockIAccount) UpdateUserStatus(ctx context.Context, req gouser.ReqUpdateUserStatus) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUserStatus", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUserStatus indicates an expected call of UpdateUserStatus.
+func (mr *MockIAccountMockRecorder) UpdateUserStatus(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUserStatus", reflect.TypeOf((*MockIAccount)(nil).UpdateUserStatus), ctx, req)
+}
@@ -0,0 +1,71 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: audit_log.go
+//
+// Generated by this command:
+//
+//	mockgen -source=audit_log.go -destination=mockusecase/audit_log.go -package=mockusecase
+//
+
+// Package mockusecase is a generated GoMock package.
+package mockusecase
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gouser "github.com/Hidayathamir/go-user/pkg/gouser"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIAuditLog is a mock of IAuditLog interface.
+type MockIAuditLog struct {
+	ctrl     *gomock.Controller
+	recorder *MockIAuditLogMockRecorder
+}
+
+// MockIAuditLogMockRecorder is the mock recorder for MockIAuditLog.
+type MockIAuditLogMockRecorder struct {
+	mock *MockIAuditLog
+}
+
+// NewMockIAuditLog creates a new mock instance.
+func NewMockIAuditLog(ctrl *gomock.Controller) *MockIAuditLog {
+	mock := &MockIAuditLog{ctrl: ctrl}
+	mock.recorder = &MockIAuditLogMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIAuditLog) EXPECT() *MockIAuditLogMockRecorder {
+	return m.recorder
+}
+
+// GetAuditLogs mocks base method.
+func (m *MockIAuditLog) GetAuditLogs(ctx context.Context, req gouser.ReqGetAuditLogs) (gouser.ResGetAuditLogs, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAuditLogs", ctx, req)
+	ret0, _ := ret[0].(gouser.ResGetAuditLogs)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAuditLogs indicates an expected call of GetAuditLogs.
+func (mr *MockIAuditLogMockRecorder) GetAuditLogs(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAuditLogs", reflect.TypeOf((*MockIAuditLog)(nil).GetAuditLogs), ctx, req)
+}
+
+// PurgeAuditLogs mocks base method.
+func (m *MockIAuditLog) PurgeAuditLogs(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeAuditLogs", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeAuditLogs indicates an expected call of PurgeAuditLogs.
+func (mr *MockIAuditLogMockRecorder) PurgeAuditLogs(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeAuditLogs", reflect.TypeOf((*MockIAuditLog)(nil).PurgeAuditLogs), ctx)
+}
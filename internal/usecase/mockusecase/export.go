@@ -0,0 +1,70 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: export.go
+//
+// Generated by this command:
+//
+//	mockgen -source=export.go -destination=mockusecase/export.go -package=mockusecase
+//
+
+// Package mockusecase is a generated GoMock package.
+package mockusecase
+
+import (
+	context "context"
+	io "io"
+	reflect "reflect"
+
+	gouser "github.com/Hidayathamir/go-user/pkg/gouser"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIExport is a mock of IExport interface.
+type MockIExport struct {
+	ctrl     *gomock.Controller
+	recorder *MockIExportMockRecorder
+}
+
+// MockIExportMockRecorder is the mock recorder for MockIExport.
+type MockIExportMockRecorder struct {
+	mock *MockIExport
+}
+
+// NewMockIExport creates a new mock instance.
+func NewMockIExport(ctrl *gomock.Controller) *MockIExport {
+	mock := &MockIExport{ctrl: ctrl}
+	mock.recorder = &MockIExportMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIExport) EXPECT() *MockIExportMockRecorder {
+	return m.recorder
+}
+
+// ExportMyData mocks base method.
+func (m *MockIExport) ExportMyData(ctx context.Context, req gouser.ReqExportMyData, w io.Writer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportMyData", ctx, req, w)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportMyData indicates an expected call of ExportMyData.
+func (mr *MockIExportMockRecorder) ExportMyData(ctx, req, w any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportMyData", reflect.TypeOf((*MockIExport)(nil).ExportMyData), ctx, req, w)
+}
+
+// ExportUserData mocks base method.
+func (m *MockIExport) ExportUserData(ctx context.Context, req gouser.ReqExportUserData, w io.Writer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportUserData", ctx, req, w)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportUserData indicates an expected call of ExportUserData.
+func (mr *MockIExportMockRecorder) ExportUserData(ctx, req, w any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportUserData", reflect.TypeOf((*MockIExport)(nil).ExportUserData), ctx, req, w)
+}
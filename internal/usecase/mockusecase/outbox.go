@@ -0,0 +1,55 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: outbox.go
+//
+// Generated by this command:
+//
+//	mockgen -source=outbox.go -destination=mockusecase/outbox.go -package=mockusecase
+//
+
+// Package mockusecase is a generated GoMock package.
+package mockusecase
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIOutbox is a mock of IOutbox interface.
+type MockIOutbox struct {
+	ctrl     *gomock.Controller
+	recorder *MockIOutboxMockRecorder
+}
+
+// MockIOutboxMockRecorder is the mock recorder for MockIOutbox.
+type MockIOutboxMockRecorder struct {
+	mock *MockIOutbox
+}
+
+// NewMockIOutbox creates a new mock instance.
+func NewMockIOutbox(ctrl *gomock.Controller) *MockIOutbox {
+	mock := &MockIOutbox{ctrl: ctrl}
+	mock.recorder = &MockIOutboxMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIOutbox) EXPECT() *MockIOutboxMockRecorder {
+	return m.recorder
+}
+
+// RelayEvents mocks base method.
+func (m *MockIOutbox) RelayEvents(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RelayEvents", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RelayEvents indicates an expected call of RelayEvents.
+func (mr *MockIOutboxMockRecorder) RelayEvents(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RelayEvents", reflect.TypeOf((*MockIOutbox)(nil).RelayEvents), ctx)
+}
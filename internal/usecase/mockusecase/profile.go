@@ -40,6 +40,51 @@ func (m *MockIProfile) EXPECT() *MockIProfileMockRecorder {
 	return m.recorder
 }
 
+// BatchGetProfiles mocks base method.
+func (m *MockIProfile) BatchGetProfiles(ctx context.Context, req gouser.ReqBatchGetProfiles) (gouser.ResBatchGetProfiles, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BatchGetProfiles", ctx, req)
+	ret0, _ := ret[0].(gouser.ResBatchGetProfiles)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BatchGetProfiles indicates an expected call of BatchGetProfiles.
+func (mr *MockIProfileMockRecorder) BatchGetProfiles(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchGetProfiles", reflect.TypeOf((*MockIProfile)(nil).BatchGetProfiles), ctx, req)
+}
+
+// GetMyProfile mocks base method.
+func (m *MockIProfile) GetMyProfile(ctx context.Context, req gouser.ReqGetMyProfile) (gouser.ResGetMyProfile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMyProfile", ctx, req)
+	ret0, _ := ret[0].(gouser.ResGetMyProfile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMyProfile indicates an expected call of GetMyProfile.
+func (mr *MockIProfileMockRecorder) GetMyProfile(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMyProfile", reflect.TypeOf((*MockIProfile)(nil).GetMyProfile), ctx, req)
+}
+
+// GetProfileByUserID mocks base method.
+func (m *MockIProfile) GetProfileByUserID(ctx context.Context, req gouser.ReqGetProfileByUserID) (gouser.ResGetProfileByUsername, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProfileByUserID", ctx, req)
+	ret0, _ := ret[0].(gouser.ResGetProfileByUsername)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProfileByUserID indicates an expected call of GetProfileByUserID.
+func (mr *MockIProfileMockRecorder) GetProfileByUserID(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProfileByUserID", reflect.TypeOf((*MockIProfile)(nil).GetProfileByUserID), ctx, req)
+}
+
 // GetProfileByUsername mocks base method.
 func (m *MockIProfile) GetProfileByUsername(ctx context.Context, req gouser.ReqGetProfileByUsername) (gouser.ResGetProfileByUsername, error) {
 	m.ctrl.T.Helper()
@@ -55,6 +100,21 @@ func (mr *MockIProfileMockRecorder) GetProfileByUsername(ctx, req any) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProfileByUsername", reflect.TypeOf((*MockIProfile)(nil).GetProfileByUsername), ctx, req)
 }
 
+// ListUsers mocks base method.
+func (m *MockIProfile) ListUsers(ctx context.Context, req gouser.ReqListUsers) (gouser.ResListUsers, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListUsers", ctx, req)
+	ret0, _ := ret[0].(gouser.ResListUsers)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListUsers indicates an expected call of ListUsers.
+func (mr *MockIProfileMockRecorder) ListUsers(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListUsers", reflect.TypeOf((*MockIProfile)(nil).ListUsers), ctx, req)
+}
+
 // UpdateProfileByUserID mocks base method.
 func (m *MockIProfile) UpdateProfileByUserID(ctx context.Context, req gouser.ReqUpdateProfileByUserID) error {
 	m.ctrl.T.Helper()
@@ -68,3 +128,17 @@ func (mr *MockIProfileMockRecorder) UpdateProfileByUserID(ctx, req any) *gomock.
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProfileByUserID", reflect.TypeOf((*MockIProfile)(nil).UpdateProfileByUserID), ctx, req)
 }
+
+// WatchUsers mocks base method.
+func (m *MockIProfile) WatchUsers(ctx context.Context, req gouser.ReqWatchUsers, started func(string) error, send func(gouser.UserEvent) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WatchUsers", ctx, req, started, send)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// WatchUsers indicates an expected call of WatchUsers.
+func (mr *MockIProfileMockRecorder) WatchUsers(ctx, req, started, send any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WatchUsers", reflect.TypeOf((*MockIProfile)(nil).WatchUsers), ctx, req, started, send)
+}
@@ -0,0 +1,99 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: session.go
+//
+// Generated by this command:
+//
+//	mockgen -source=session.go -destination=mockusecase/session.go -package=mockusecase
+//
+
+// Package mockusecase is a generated GoMock package.
+package mockusecase
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gouser "github.com/Hidayathamir/go-user/pkg/gouser"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockISession is a mock of ISession interface.
+type MockISession struct {
+	ctrl     *gomock.Controller
+	recorder *MockISessionMockRecorder
+}
+
+// MockISessionMockRecorder is the mock recorder for MockISession.
+type MockISessionMockRecorder struct {
+	mock *MockISession
+}
+
+// NewMockISession creates a new mock instance.
+func NewMockISession(ctrl *gomock.Controller) *MockISession {
+	mock := &MockISession{ctrl: ctrl}
+	mock.recorder = &MockISessionMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockISession) EXPECT() *MockISessionMockRecorder {
+	return m.recorder
+}
+
+// GetMySessions mocks base method.
+func (m *MockISession) GetMySessions(ctx context.Context, req gouser.ReqGetMySessions) (gouser.ResGetSessions, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMySessions", ctx, req)
+	ret0, _ := ret[0].(gouser.ResGetSessions)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMySessions indicates an expected call of GetMySessions.
+func (mr *MockISessionMockRecorder) GetMySessions(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMySessions", reflect.TypeOf((*MockISession)(nil).GetMySessions), ctx, req)
+}
+
+// GetSessionsByUserID mocks base method.
+func (m *MockISession) GetSessionsByUserID(ctx context.Context, req gouser.ReqGetSessionsByUserID) (gouser.ResGetSessions, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSessionsByUserID", ctx, req)
+	ret0, _ := ret[0].(gouser.ResGetSessions)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSessionsByUserID indicates an expected call of GetSessionsByUserID.
+func (mr *MockISessionMockRecorder) GetSessionsByUserID(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSessionsByUserID", reflect.TypeOf((*MockISession)(nil).GetSessionsByUserID), ctx, req)
+}
+
+// RevokeMySession mocks base method.
+func (m *MockISession) RevokeMySession(ctx context.Context, req gouser.ReqRevokeMySession) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeMySession", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeMySession indicates an expected call of RevokeMySession.
+func (mr *MockISessionMockRecorder) RevokeMySession(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeMySession", reflect.TypeOf((*MockISession)(nil).RevokeMySession), ctx, req)
+}
+
+// RevokeSessionByID mocks base method.
+func (m *MockISession) RevokeSessionByID(ctx context.Context, req gouser.ReqRevokeSessionByID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeSessionByID", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeSessionByID indicates an expected call of RevokeSessionByID.
+func (mr *MockISessionMockRecorder) RevokeSessionByID(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeSessionByID", reflect.TypeOf((*MockISession)(nil).RevokeSessionByID), ctx, req)
+}
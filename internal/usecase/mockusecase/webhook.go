@@ -0,0 +1,143 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: webhook.go
+//
+// Generated by this command:
+//
+//	mockgen -source=webhook.go -destination=mockusecase/webhook.go -package=mockusecase
+//
+
+// Package mockusecase is a generated GoMock package.
+package mockusecase
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gouser "github.com/Hidayathamir/go-user/pkg/gouser"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockIWebhook is a mock of IWebhook interface.
+type MockIWebhook struct {
+	ctrl     *gomock.Controller
+	recorder *MockIWebhookMockRecorder
+}
+
+// MockIWebhookMockRecorder is the mock recorder for MockIWebhook.
+type MockIWebhookMockRecorder struct {
+	mock *MockIWebhook
+}
+
+// NewMockIWebhook creates a new mock instance.
+func NewMockIWebhook(ctrl *gomock.Controller) *MockIWebhook {
+	mock := &MockIWebhook{ctrl: ctrl}
+	mock.recorder = &MockIWebhookMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIWebhook) EXPECT() *MockIWebhookMockRecorder {
+	return m.recorder
+}
+
+// CreateWebhookSubscription mocks base method.
+func (m *MockIWebhook) CreateWebhookSubscription(ctx context.Context, req gouser.ReqCreateWebhookSubscription) (gouser.ResCreateWebhookSubscription, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWebhookSubscription", ctx, req)
+	ret0, _ := ret[0].(gouser.ResCreateWebhookSubscription)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWebhookSubscription indicates an expected call of CreateWebhookSubscription.
+func (mr *MockIWebhookMockRecorder) CreateWebhookSubscription(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWebhookSubscription", reflect.TypeOf((*MockIWebhook)(nil).CreateWebhookSubscription), ctx, req)
+}
+
+// DeleteWebhookSubscription mocks base method.
+func (m *MockIWebhook) DeleteWebhookSubscription(ctx context.Context, req gouser.ReqDeleteWebhookSubscription) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteWebhookSubscription", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteWebhookSubscription indicates an expected call of DeleteWebhookSubscription.
+func (mr *MockIWebhookMockRecorder) DeleteWebhookSubscription(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteWebhookSubscription", reflect.TypeOf((*MockIWebhook)(nil).DeleteWebhookSubscription), ctx, req)
+}
+
+// DeliverWebhooks mocks base method.
+func (m *MockIWebhook) DeliverWebhooks(ctx context.Context) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeliverWebhooks", ctx)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeliverWebhooks indicates an expected call of DeliverWebhooks.
+func (mr *MockIWebhookMockRecorder) DeliverWebhooks(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeliverWebhooks", reflect.TypeOf((*MockIWebhook)(nil).DeliverWebhooks), ctx)
+}
+
+// GetWebhookDeliveries mocks base method.
+func (m *MockIWebhook) GetWebhookDeliveries(ctx context.Context, req gouser.ReqGetWebhookDeliveries) (gouser.ResGetWebhookDeliveries, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhookDeliveries", ctx, req)
+	ret0, _ := ret[0].(gouser.ResGetWebhookDeliveries)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhookDeliveries indicates an expected call of GetWebhookDeliveries.
+func (mr *MockIWebhookMockRecorder) GetWebhookDeliveries(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhookDeliveries", reflect.TypeOf((*MockIWebhook)(nil).GetWebhookDeliveries), ctx, req)
+}
+
+// GetWebhookSubscriptions mocks base method.
+func (m *MockIWebhook) GetWebhookSubscriptions(ctx context.Context, req gouser.ReqGetWebhookSubscriptions) (gouser.ResGetWebhookSubscriptions, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhookSubscriptions", ctx, req)
+	ret0, _ := ret[0].(gouser.ResGetWebhookSubscriptions)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhookSubscriptions indicates an expected call of GetWebhookSubscriptions.
+func (mr *MockIWebhookMockRecorder) GetWebhookSubscriptions(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhookSubscriptions", reflect.TypeOf((*MockIWebhook)(nil).GetWebhookSubscriptions), ctx, req)
+}
+
+// RedeliverWebhook mocks base method.
+func (m *MockIWebhook) RedeliverWebhook(ctx context.Context, req gouser.ReqRedeliverWebhook) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RedeliverWebhook", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RedeliverWebhook indicates an expected call of RedeliverWebhook.
+func (mr *MockIWebhookMockRecorder) RedeliverWebhook(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RedeliverWebhook", reflect.TypeOf((*MockIWebhook)(nil).RedeliverWebhook), ctx, req)
+}
+
+// UpdateWebhookSubscription mocks base method.
+func (m *MockIWebhook) UpdateWebhookSubscription(ctx context.Context, req gouser.ReqUpdateWebhookSubscription) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWebhookSubscription", ctx, req)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateWebhookSubscription indicates an expected call of UpdateWebhookSubscription.
+func (mr *MockIWebhookMockRecorder) UpdateWebhookSubscription(ctx, req any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWebhookSubscription", reflect.TypeOf((*MockIWebhook)(nil).UpdateWebhookSubscription), ctx, req)
+}
@@ -0,0 +1,144 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Hidayathamir/go-user/config"
+	"github.com/Hidayathamir/go-user/internal/pkg/logger"
+	"github.com/Hidayathamir/go-user/internal/pkg/publisher"
+	"github.com/Hidayathamir/go-user/internal/repo"
+	"github.com/Hidayathamir/go-user/internal/repo/db/entity"
+	"github.com/Hidayathamir/go-user/pkg/gouser"
+)
+
+//go:generate mockgen -source=outbox.go -destination=mockusecase/outbox.go -package=mockusecase
+
+// IOutbox contains abstraction of usecase domain event outbox.
+type IOutbox interface {
+	// RelayEvents publish pending events, return number of published events.
+	RelayEvents(ctx context.Context) (int64, error)
+}
+
+// Outbox implement IOutbox.
+type Outbox struct {
+	cfg         config.Config
+	repoOutbox  repo.IOutbox
+	repoWebhook repo.IWebhook
+	publisher   publisher.IPublisher
+}
+
+var _ IOutbox = &Outbox{}
+
+// NewOutbox return *Outbox which implement IOutbox.
+func NewOutbox(cfg config.Config, repoOutbox repo.IOutbox, repoWebhook repo.IWebhook, publisher publisher.IPublisher) *Outbox {
+	return &Outbox{
+		cfg:         cfg,
+		repoOutbox:  repoOutbox,
+		repoWebhook: repoWebhook,
+		publisher:   publisher,
+	}
+}
+
+// RelayEvents publish pending events, return number of published events.
+//
+// Events are claimed for cfg.Outbox.LeaseSecond so concurrent relay does not
+// pick them. Claiming, queueing webhook deliveries and marking the result are
+// each one short statement, no transaction or row lock is held while
+// publishing. Event is marked published only after publisher succeed, so a
+// crash in between publishes it again once the claim expires, delivery is at
+// least once. Relay stops when the claim expires before the batch is done, or
+// when marking an event finds the claim taken over by other relay. Failed event
+// is retried with exponential backoff. Each event is also queued for delivery
+// to webhook subscriptions of its type.
+func (o *Outbox) RelayEvents(ctx context.Context) (int64, error) {
+	now := time.Now()
+	// Claim is identified by locked_until, truncate it to the precision of
+	// postgres timestamp so it compares equal when read back.
+	lockedUntil := now.Add(time.Duration(o.cfg.Outbox.LeaseSecond) * time.Second).Truncate(time.Microsecond)
+	events, err := o.repoOutbox.ClaimPendingEvents(ctx, now, lockedUntil, uint64(o.cfg.Outbox.BatchSize))
+	if err != nil {
+		return 0, fmt.Errorf("Outbox.repoOutbox.ClaimPendingEvents: %w", err)
+	}
+
+	var count int64
+	for _, event := range events {
+		// Event whose claim expired may be claimed by other relay already,
+		// leave the rest to the next run.
+		if time.Now().After(lockedUntil) {
+			break
+		}
+
+		isPublished, err := o.relayEvent(ctx, event, lockedUntil)
+		if errors.Is(err, repo.ErrClaimLost) {
+			logger.FromContext(ctx).WithField("event id", event.ID).Warnf("Outbox.relayEvent: %v", err)
+			break
+		}
+		if err != nil {
+			return count, fmt.Errorf("Outbox.relayEvent: %w", err)
+		}
+		if isPublished {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+// relayEvent queue webhook deliveries of event claimed until lockedUntil,
+// publish it, then mark the result. Return true if the event is published.
+func (o *Outbox) relayEvent(ctx context.Context, event entity.OutboxEvent, lockedUntil time.Time) (bool, error) {
+	domainEvent := gouser.Event{}.LoadEntityOutboxEvent(event)
+
+	payload, err := json.Marshal(domainEvent)
+	if err != nil {
+		return false, fmt.Errorf("json.Marshal: %w", err)
+	}
+
+	_, err = o.repoWebhook.CreateDeliveries(ctx, event.ID, event.EventType, payload, time.Now())
+	if err != nil {
+		return false, fmt.Errorf("Outbox.repoWebhook.CreateDeliveries: %w", err)
+	}
+
+	errPublish := o.publisher.Publish(ctx, domainEvent)
+	if errPublish != nil {
+		logger.FromContext(ctx).
+			WithField("event id", event.ID).
+			WithField("attempt count", event.Attempt+1).
+			Warnf("Outbox.publisher.Publish: %v", errPublish)
+
+		nextAttemptAt := time.Now().Add(o.getRetryDelay(event.Attempt))
+		err = o.repoOutbox.MarkEventFailed(ctx, event.ID, lockedUntil, nextAttemptAt, errPublish.Error())
+		if err != nil {
+			return false, fmt.Errorf("Outbox.repoOutbox.MarkEventFailed: %w", err)
+		}
+		return false, nil
+	}
+
+	err = o.repoOutbox.MarkEventPublished(ctx, event.ID, lockedUntil, time.Now())
+	if err != nil {
+		return false, fmt.Errorf("Outbox.repoOutbox.MarkEventPublished: %w", err)
+	}
+
+	return true, nil
+}
+
+// getRetryDelay return delay before the next attempt of event which failed
+// attempt times before.
+func (o *Outbox) getRetryDelay(attempt int) time.Duration {
+	baseDelay := time.Duration(o.cfg.Outbox.RetryBaseSecond) * time.Second
+	maxDelay := time.Duration(o.cfg.Outbox.RetryMaxSecond) * time.Second
+	return getBackoffDelay(baseDelay, maxDelay, attempt)
+}
+
+// getBackoffDelay return baseDelay doubled attempt times, up to maxDelay.
+func getBackoffDelay(baseDelay time.Duration, maxDelay time.Duration, attempt int) time.Duration {
+	delay := baseDelay
+	for i := 0; i < attempt && delay < maxDelay; i++ {
+		delay *= 2
+	}
+	return min(delay, maxDelay)
+}
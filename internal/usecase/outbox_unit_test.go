@@ -0,0 +1,309 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Hidayathamir/go-user/config"
+	"github.com/Hidayathamir/go-user/internal/pkg/publisher/mockpublisher"
+	"github.com/Hidayathamir/go-user/internal/repo"
+	"github.com/Hidayathamir/go-user/internal/repo/db/entity"
+	"github.com/Hidayathamir/go-user/internal/repo/mockrepo"
+	"github.com/Hidayathamir/go-user/pkg/gouser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUnitOutboxRelayEvents(t *testing.T) {
+	t.Parallel()
+
+	t.Run("publish success should mark event published", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoOutbox := mockrepo.NewMockIOutbox(ctrl)
+		repoWebhook := mockrepo.NewMockIWebhook(ctrl)
+		pub := mockpublisher.NewMockIPublisher(ctrl)
+
+		o := &Outbox{
+			cfg:         config.Config{Outbox: config.Outbox{BatchSize: 100, RetryBaseSecond: 5, RetryMaxSecond: 3600, LeaseSecond: 300}},
+			repoOutbox:  repoOutbox,
+			repoWebhook: repoWebhook,
+			publisher:   pub,
+		}
+
+		repoOutbox.EXPECT().
+			ClaimPendingEvents(gomock.Any(), gomock.Any(), gomock.Any(), uint64(100)).
+			Return([]entity.OutboxEvent{
+				{ID: 1, EventType: gouser.EventTypeUserRegistered, UserID: 44, Payload: []byte(`{}`)},
+				{ID: 2, EventType: gouser.EventTypeUserDeleted, UserID: 45, Payload: []byte(`{}`)},
+			}, nil)
+		repoWebhook.EXPECT().
+			CreateDeliveries(gomock.Any(), int64(1), gouser.EventTypeUserRegistered, gomock.Any(), gomock.Any()).
+			Return(int64(1), nil)
+		repoWebhook.EXPECT().
+			CreateDeliveries(gomock.Any(), int64(2), gouser.EventTypeUserDeleted, gomock.Any(), gomock.Any()).
+			Return(int64(0), nil)
+		pub.EXPECT().
+			Publish(gomock.Any(), gouser.Event{ID: 1, Type: gouser.EventTypeUserRegistered, UserID: 44, Data: []byte(`{}`)}).
+			Return(nil)
+		pub.EXPECT().
+			Publish(gomock.Any(), gouser.Event{ID: 2, Type: gouser.EventTypeUserDeleted, UserID: 45, Data: []byte(`{}`)}).
+			Return(nil)
+		repoOutbox.EXPECT().MarkEventPublished(gomock.Any(), int64(1), gomock.Any(), gomock.Any()).Return(nil)
+		repoOutbox.EXPECT().MarkEventPublished(gomock.Any(), int64(2), gomock.Any(), gomock.Any()).Return(nil)
+
+		count, err := o.RelayEvents(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), count)
+	})
+	t.Run("publish error should mark event failed and keep relaying", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoOutbox := mockrepo.NewMockIOutbox(ctrl)
+		repoWebhook := mockrepo.NewMockIWebhook(ctrl)
+		pub := mockpublisher.NewMockIPublisher(ctrl)
+
+		o := &Outbox{
+			cfg:         config.Config{Outbox: config.Outbox{BatchSize: 100, RetryBaseSecond: 5, RetryMaxSecond: 3600, LeaseSecond: 300}},
+			repoOutbox:  repoOutbox,
+			repoWebhook: repoWebhook,
+			publisher:   pub,
+		}
+
+		repoOutbox.EXPECT().
+			ClaimPendingEvents(gomock.Any(), gomock.Any(), gomock.Any(), uint64(100)).
+			Return([]entity.OutboxEvent{
+				{ID: 1, Attempt: 2, Payload: []byte(`{}`)},
+				{ID: 2, Payload: []byte(`{}`)},
+			}, nil)
+		repoWebhook.EXPECT().CreateDeliveries(gomock.Any(), int64(1), gomock.Any(), gomock.Any(), gomock.Any()).Return(int64(0), nil)
+		repoWebhook.EXPECT().CreateDeliveries(gomock.Any(), int64(2), gomock.Any(), gomock.Any(), gomock.Any()).Return(int64(0), nil)
+		pub.EXPECT().Publish(gomock.Any(), gomock.Any()).Return(assert.AnError)
+		pub.EXPECT().Publish(gomock.Any(), gomock.Any()).Return(nil)
+		repoOutbox.EXPECT().
+			MarkEventFailed(gomock.Any(), int64(1), gomock.Any(), gomock.Any(), assert.AnError.Error()).
+			DoAndReturn(func(_ context.Context, _ int64, _ time.Time, nextAttemptAt time.Time, _ string) error {
+				// third attempt waits 5s * 2^2.
+				assert.WithinDuration(t, time.Now().Add(20*time.Second), nextAttemptAt, time.Second)
+				return nil
+			})
+		repoOutbox.EXPECT().MarkEventPublished(gomock.Any(), int64(2), gomock.Any(), gomock.Any()).Return(nil)
+
+		count, err := o.RelayEvents(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+	})
+	t.Run("claim lost should stop relaying the rest", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoOutbox := mockrepo.NewMockIOutbox(ctrl)
+		repoWebhook := mockrepo.NewMockIWebhook(ctrl)
+		pub := mockpublisher.NewMockIPublisher(ctrl)
+
+		o := &Outbox{
+			cfg:         config.Config{Outbox: config.Outbox{BatchSize: 100, RetryBaseSecond: 5, RetryMaxSecond: 3600, LeaseSecond: 300}},
+			repoOutbox:  repoOutbox,
+			repoWebhook: repoWebhook,
+			publisher:   pub,
+		}
+
+		var claimedUntil time.Time
+		repoOutbox.EXPECT().
+			ClaimPendingEvents(gomock.Any(), gomock.Any(), gomock.Any(), uint64(100)).
+			DoAndReturn(func(_ context.Context, _ time.Time, lockedUntil time.Time, _ uint64) ([]entity.OutboxEvent, error) {
+				claimedUntil = lockedUntil
+				return []entity.OutboxEvent{
+					{ID: 1, Payload: []byte(`{}`)},
+					{ID: 2, Payload: []byte(`{}`)},
+				}, nil
+			})
+		repoWebhook.EXPECT().CreateDeliveries(gomock.Any(), int64(1), gomock.Any(), gomock.Any(), gomock.Any()).Return(int64(0), nil)
+		pub.EXPECT().Publish(gomock.Any(), gomock.Any()).Return(nil)
+		repoOutbox.EXPECT().
+			MarkEventPublished(gomock.Any(), int64(1), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ int64, lockedUntil time.Time, _ time.Time) error {
+				assert.Equal(t, claimedUntil, lockedUntil)
+				return fmt.Errorf("%w: pgconn.CommandTag.RowsAffected == 0", repo.ErrClaimLost)
+			})
+
+		count, err := o.RelayEvents(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), count)
+	})
+	t.Run("events should be claimed for lease second", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoOutbox := mockrepo.NewMockIOutbox(ctrl)
+		repoWebhook := mockrepo.NewMockIWebhook(ctrl)
+		pub := mockpublisher.NewMockIPublisher(ctrl)
+
+		o := &Outbox{
+			cfg:         config.Config{Outbox: config.Outbox{BatchSize: 100, RetryBaseSecond: 5, RetryMaxSecond: 3600, LeaseSecond: 300}},
+			repoOutbox:  repoOutbox,
+			repoWebhook: repoWebhook,
+			publisher:   pub,
+		}
+
+		repoOutbox.EXPECT().
+			ClaimPendingEvents(gomock.Any(), gomock.Any(), gomock.Any(), uint64(100)).
+			DoAndReturn(func(_ context.Context, now time.Time, lockedUntil time.Time, _ uint64) ([]entity.OutboxEvent, error) {
+				assert.Equal(t, now.Add(300*time.Second).Truncate(time.Microsecond), lockedUntil)
+				return []entity.OutboxEvent{}, nil
+			})
+
+		count, err := o.RelayEvents(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), count)
+	})
+	t.Run("expired claim should stop relaying", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoOutbox := mockrepo.NewMockIOutbox(ctrl)
+		repoWebhook := mockrepo.NewMockIWebhook(ctrl)
+		pub := mockpublisher.NewMockIPublisher(ctrl)
+
+		o := &Outbox{
+			cfg:         config.Config{Outbox: config.Outbox{BatchSize: 100, RetryBaseSecond: 5, RetryMaxSecond: 3600, LeaseSecond: 0}},
+			repoOutbox:  repoOutbox,
+			repoWebhook: repoWebhook,
+			publisher:   pub,
+		}
+
+		repoOutbox.EXPECT().
+			ClaimPendingEvents(gomock.Any(), gomock.Any(), gomock.Any(), uint64(100)).
+			DoAndReturn(func(_ context.Context, _ time.Time, lockedUntil time.Time, _ uint64) ([]entity.OutboxEvent, error) {
+				for !time.Now().After(lockedUntil) {
+					time.Sleep(time.Millisecond)
+				}
+				return []entity.OutboxEvent{{ID: 1, Payload: []byte(`{}`)}}, nil
+			})
+
+		count, err := o.RelayEvents(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), count)
+	})
+	t.Run("ClaimPendingEvents error should return error", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoOutbox := mockrepo.NewMockIOutbox(ctrl)
+		repoWebhook := mockrepo.NewMockIWebhook(ctrl)
+		pub := mockpublisher.NewMockIPublisher(ctrl)
+
+		o := &Outbox{
+			cfg:         config.Config{Outbox: config.Outbox{BatchSize: 100, RetryBaseSecond: 5, RetryMaxSecond: 3600, LeaseSecond: 300}},
+			repoOutbox:  repoOutbox,
+			repoWebhook: repoWebhook,
+			publisher:   pub,
+		}
+
+		repoOutbox.EXPECT().
+			ClaimPendingEvents(gomock.Any(), gomock.Any(), gomock.Any(), uint64(100)).
+			Return(nil, assert.AnError)
+
+		count, err := o.RelayEvents(context.Background())
+
+		require.Error(t, err)
+		require.ErrorIs(t, err, assert.AnError)
+		assert.Equal(t, int64(0), count)
+	})
+	t.Run("MarkEventPublished error should return error", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoOutbox := mockrepo.NewMockIOutbox(ctrl)
+		repoWebhook := mockrepo.NewMockIWebhook(ctrl)
+		pub := mockpublisher.NewMockIPublisher(ctrl)
+
+		o := &Outbox{
+			cfg:         config.Config{Outbox: config.Outbox{BatchSize: 100, RetryBaseSecond: 5, RetryMaxSecond: 3600, LeaseSecond: 300}},
+			repoOutbox:  repoOutbox,
+			repoWebhook: repoWebhook,
+			publisher:   pub,
+		}
+
+		repoOutbox.EXPECT().
+			ClaimPendingEvents(gomock.Any(), gomock.Any(), gomock.Any(), uint64(100)).
+			Return([]entity.OutboxEvent{{ID: 1, Payload: []byte(`{}`)}}, nil)
+		repoWebhook.EXPECT().CreateDeliveries(gomock.Any(), int64(1), gomock.Any(), gomock.Any(), gomock.Any()).Return(int64(0), nil)
+		pub.EXPECT().Publish(gomock.Any(), gomock.Any()).Return(nil)
+		repoOutbox.EXPECT().MarkEventPublished(gomock.Any(), int64(1), gomock.Any(), gomock.Any()).Return(assert.AnError)
+
+		count, err := o.RelayEvents(context.Background())
+
+		require.Error(t, err)
+		require.ErrorIs(t, err, assert.AnError)
+		assert.Equal(t, int64(0), count)
+	})
+	t.Run("CreateDeliveries error should return error without publishing", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoOutbox := mockrepo.NewMockIOutbox(ctrl)
+		repoWebhook := mockrepo.NewMockIWebhook(ctrl)
+		pub := mockpublisher.NewMockIPublisher(ctrl)
+
+		o := &Outbox{
+			cfg:         config.Config{Outbox: config.Outbox{BatchSize: 100, RetryBaseSecond: 5, RetryMaxSecond: 3600, LeaseSecond: 300}},
+			repoOutbox:  repoOutbox,
+			repoWebhook: repoWebhook,
+			publisher:   pub,
+		}
+
+		repoOutbox.EXPECT().
+			ClaimPendingEvents(gomock.Any(), gomock.Any(), gomock.Any(), uint64(100)).
+			Return([]entity.OutboxEvent{{ID: 1, Payload: []byte(`{}`)}}, nil)
+		repoWebhook.EXPECT().
+			CreateDeliveries(gomock.Any(), int64(1), gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(int64(0), assert.AnError)
+
+		count, err := o.RelayEvents(context.Background())
+
+		require.Error(t, err)
+		require.ErrorIs(t, err, assert.AnError)
+		assert.Equal(t, int64(0), count)
+	})
+}
+
+func TestUnitOutboxGetRetryDelay(t *testing.T) {
+	t.Parallel()
+
+	o := &Outbox{
+		cfg: config.Config{Outbox: config.Outbox{RetryBaseSecond: 5, RetryMaxSecond: 60}},
+	}
+
+	assert.Equal(t, 5*time.Second, o.getRetryDelay(0))
+	assert.Equal(t, 10*time.Second, o.getRetryDelay(1))
+	assert.Equal(t, 40*time.Second, o.getRetryDelay(3))
+	assert.Equal(t, 60*time.Second, o.getRetryDelay(4))
+	assert.Equal(t, 60*time.Second, o.getRetryDelay(1000))
+}
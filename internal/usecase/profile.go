@@ -2,11 +2,16 @@ package usecase
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/Hidayathamir/go-user/config"
 	"github.com/Hidayathamir/go-user/internal/pkg/auth"
 	"github.com/Hidayathamir/go-user/internal/repo"
+	"github.com/Hidayathamir/go-user/internal/repo/db/entity"
 	"github.com/Hidayathamir/go-user/pkg/gouser"
 )
 
@@ -16,28 +21,51 @@ import (
 type IProfile interface {
 	// GetProfileByUsername return user profile by username.
 	GetProfileByUsername(ctx context.Context, req gouser.ReqGetProfileByUsername) (gouser.ResGetProfileByUsername, error)
+	// GetProfileByUserID return user profile by user id.
+	GetProfileByUserID(ctx context.Context, req gouser.ReqGetProfileByUserID) (gouser.ResGetProfileByUsername, error)
+	// GetMyProfile return owner view profile of the user who own the JWT.
+	GetMyProfile(ctx context.Context, req gouser.ReqGetMyProfile) (gouser.ResGetMyProfile, error)
 	// UpdateProfileByUserID update user profile by user id.
 	UpdateProfileByUserID(ctx context.Context, req gouser.ReqUpdateProfileByUserID) error
+	// ListUsers return one page of users, admin only.
+	ListUsers(ctx context.Context, req gouser.ReqListUsers) (gouser.ResListUsers, error)
+	// BatchGetProfiles return user profiles by user ids and usernames.
+	BatchGetProfiles(ctx context.Context, req gouser.ReqBatchGetProfiles) (gouser.ResBatchGetProfiles, error)
+	// WatchUsers call started with the cursor the stream starts after, then
+	// call send with every user create, update and delete after it, it
+	// returns when ctx is done or started or send return error. Admin only.
+	WatchUsers(ctx context.Context, req gouser.ReqWatchUsers, started func(cursor string) error, send func(gouser.UserEvent) error) error
 }
 
 // Profile implement IProfile.
 type Profile struct {
 	cfg         config.Config
+	guard       *guard
 	repoProfile repo.IProfile
+	repoOutbox  repo.IOutbox
+	transactor  repo.ITransactor
+	auditor     *auditor
 }
 
 var _ IProfile = &Profile{}
 
 // NewProfile return *Profile which implement IProfile.
-func NewProfile(cfg config.Config, repoProfile repo.IProfile) *Profile {
+func NewProfile(cfg config.Config, repoProfile repo.IProfile, repoSession repo.ISession, repoOutbox repo.IOutbox, repoAuditLog repo.IAuditLog, transactor repo.ITransactor) *Profile {
 	return &Profile{
 		cfg:         cfg,
+		guard:       newGuard(cfg, repoSession, repoProfile),
 		repoProfile: repoProfile,
+		repoOutbox:  repoOutbox,
+		transactor:  transactor,
+		auditor:     newAuditor(cfg, repoAuditLog),
 	}
 }
 
-// GetProfileByUsername return user profile by username.
+// GetProfileByUsername return user profile by username. An old username
+// within history grace period resolves to the current user marked as moved.
 func (p *Profile) GetProfileByUsername(ctx context.Context, req gouser.ReqGetProfileByUsername) (gouser.ResGetProfileByUsername, error) {
+	req.Username = gouser.NormalizeUsername(req.Username)
+
 	err := req.Validate()
 	if err != nil {
 		err := fmt.Errorf("gouser.ReqGetProfileByUsername.Validate: %w", err)
@@ -45,6 +73,15 @@ func (p *Profile) GetProfileByUsername(ctx context.Context, req gouser.ReqGetPro
 	}
 
 	user, err := p.repoProfile.GetProfileByUsername(ctx, req.Username)
+	if errors.Is(err, gouser.ErrUnknownUsername) {
+		res, errOld := p.getProfileByOldUsername(ctx, req.Username)
+		if errOld == nil {
+			return res, nil
+		}
+		if !errors.Is(errOld, gouser.ErrUnknownUsername) {
+			return gouser.ResGetProfileByUsername{}, fmt.Errorf("Profile.getProfileByOldUsername: %w", errOld)
+		}
+	}
 	if err != nil {
 		return gouser.ResGetProfileByUsername{}, fmt.Errorf("Profile.repoProfile.GetProfileByUsername: %w", err)
 	}
@@ -55,7 +92,69 @@ func (p *Profile) GetProfileByUsername(ctx context.Context, req gouser.ReqGetPro
 	return res, nil
 }
 
-// UpdateProfileByUserID update user profile by user id.
+// getProfileByOldUsername return profile of the user who changed username
+// from username within history grace period, marked as moved.
+func (p *Profile) getProfileByOldUsername(ctx context.Context, username string) (gouser.ResGetProfileByUsername, error) {
+	grace := getUsernameHistoryGrace(p.cfg)
+	if grace <= 0 {
+		return gouser.ResGetProfileByUsername{}, fmt.Errorf("%w: username history is disabled", gouser.ErrUnknownUsername)
+	}
+
+	user, err := p.repoProfile.GetProfileByOldUsername(ctx, username, time.Now().Add(-grace))
+	if err != nil {
+		return gouser.ResGetProfileByUsername{}, fmt.Errorf("Profile.repoProfile.GetProfileByOldUsername: %w", err)
+	}
+
+	res := gouser.ResGetProfileByUsername{}
+	res = res.LoadEntityUser(user)
+	res.Moved = true
+
+	return res, nil
+}
+
+// GetProfileByUserID return user profile by user id.
+func (p *Profile) GetProfileByUserID(ctx context.Context, req gouser.ReqGetProfileByUserID) (gouser.ResGetProfileByUsername, error) {
+	err := req.Validate()
+	if err != nil {
+		err := fmt.Errorf("gouser.ReqGetProfileByUserID.Validate: %w", err)
+		return gouser.ResGetProfileByUsername{}, fmt.Errorf("%w: %w", gouser.ErrRequestInvalid, err)
+	}
+
+	user, err := p.repoProfile.GetProfileByUserID(ctx, req.UserID)
+	if err != nil {
+		return gouser.ResGetProfileByUsername{}, fmt.Errorf("Profile.repoProfile.GetProfileByUserID: %w", err)
+	}
+
+	res := gouser.ResGetProfileByUsername{}
+	res = res.LoadEntityUser(user)
+
+	return res, nil
+}
+
+// GetMyProfile return owner view profile of the user who own the JWT. Not
+// active user is refused like on any other JWT request, suspending a user
+// revokes all the user sessions anyway. Suspended or disabled user sees the
+// status, expiry and reason in the LoginUser error instead.
+func (p *Profile) GetMyProfile(ctx context.Context, req gouser.ReqGetMyProfile) (gouser.ResGetMyProfile, error) {
+	err := req.Validate()
+	if err != nil {
+		err := fmt.Errorf("gouser.ReqGetMyProfile.Validate: %w", err)
+		return gouser.ResGetMyProfile{}, fmt.Errorf("%w: %w", gouser.ErrRequestInvalid, err)
+	}
+
+	_, user, err := p.guard.authenticateUser(ctx, req.UserJWT)
+	if err != nil {
+		return gouser.ResGetMyProfile{}, fmt.Errorf("Profile.guard.authenticateUser: %w", err)
+	}
+
+	res := gouser.ResGetMyProfile{}
+	res = res.LoadEntityUser(user)
+
+	return res, nil
+}
+
+// UpdateProfileByUserID update user profile by user id. Password change is
+// recorded in audit log whether it succeeds or not.
 func (p *Profile) UpdateProfileByUserID(ctx context.Context, req gouser.ReqUpdateProfileByUserID) error {
 	err := req.Validate()
 	if err != nil {
@@ -63,25 +162,380 @@ func (p *Profile) UpdateProfileByUserID(ctx context.Context, req gouser.ReqUpdat
 		return fmt.Errorf("%w: %w", gouser.ErrRequestInvalid, err)
 	}
 
-	userID, err := auth.GetUserIDFromJWTTokenString(p.cfg, req.UserJWT)
+	claims, err := p.guard.authenticate(ctx, req.UserJWT)
+	if err != nil {
+		return fmt.Errorf("Profile.guard.authenticate: %w", err)
+	}
+
+	err = p.updateProfileByUserID(ctx, claims.UserID, req)
+
+	if req.Password != "" {
+		p.auditor.record(ctx, entity.AuditLog{
+			ActorUserID:  auditUserID(claims.UserID),
+			TargetUserID: auditUserID(claims.UserID),
+			Action:       entity.AuditActionUserPasswordChange,
+			IP:           req.IP,
+			UserAgent:    req.UserAgent,
+			RequestID:    req.RequestID,
+		}, err)
+	}
+
 	if err != nil {
-		return fmt.Errorf("auth.GetUserIDFromJWTTokenString: %w", err)
+		return fmt.Errorf("Profile.updateProfileByUserID: %w", err)
 	}
 
+	return nil
+}
+
+// updateProfileByUserID is UpdateProfileByUserID without validation,
+// authentication and audit.
+func (p *Profile) updateProfileByUserID(ctx context.Context, userID int64, req gouser.ReqUpdateProfileByUserID) error {
 	user := req.ToEntityUser()
 	user.ID = userID
 
 	if user.Password != "" {
-		user.Password, err = auth.GenerateHashPassword(user.Password)
+		hashedPassword, err := auth.GenerateHashPassword(user.Password)
 		if err != nil {
 			return fmt.Errorf("auth.GenerateHashPassword: %w", err)
 		}
+		user.Password = hashedPassword
 	}
 
-	err = p.repoProfile.UpdateProfileByUserID(ctx, user)
+	err := p.transactor.WithinTx(ctx, func(ctx context.Context) error {
+		err := p.repoProfile.UpdateProfileByUserID(ctx, user)
+		if err != nil {
+			return fmt.Errorf("Profile.repoProfile.UpdateProfileByUserID: %w", err)
+		}
+		return nil
+	})
 	if err != nil {
-		return fmt.Errorf("Profile.repoProfile.UpdateProfileByUserID: %w", err)
+		return fmt.Errorf("Profile.transactor.WithinTx: %w", err)
 	}
 
 	return nil
 }
+
+// ListUsers return one page of users, admin only.
+func (p *Profile) ListUsers(ctx context.Context, req gouser.ReqListUsers) (gouser.ResListUsers, error) {
+	err := req.Validate()
+	if err != nil {
+		err := fmt.Errorf("ReqListUsers.Validate: %w", err)
+		return gouser.ResListUsers{}, fmt.Errorf("%w: %w", gouser.ErrRequestInvalid, err)
+	}
+
+	_, err = p.guard.authenticateAdmin(ctx, req.UserJWT)
+	if err != nil {
+		return gouser.ResListUsers{}, fmt.Errorf("Profile.guard.authenticateAdmin: %w", err)
+	}
+
+	limit := req.Limit
+	if limit == 0 {
+		limit = gouser.ListUsersDefaultLimit
+	}
+
+	filter := repo.ListUsersFilter{
+		CreatedFrom:    req.CreatedFrom,
+		CreatedTo:      req.CreatedTo,
+		Status:         req.Status,
+		UsernamePrefix: req.UsernamePrefix,
+		SortBy:         req.SortBy,
+		SortDesc:       req.SortOrder == gouser.SortOrderDesc,
+		// Fetch one more user to know whether there is a next page.
+		Limit: uint64(limit) + 1,
+	}
+	if filter.SortBy == "" {
+		filter.SortBy = gouser.UserSortByID
+	}
+
+	if req.Cursor != "" {
+		after, err := decodeListUsersCursor(req.Cursor, filter.SortBy)
+		if err != nil {
+			err := fmt.Errorf("decodeListUsersCursor: %w", err)
+			return gouser.ResListUsers{}, fmt.Errorf("%w: %w", gouser.ErrRequestInvalid, err)
+		}
+		filter.After = &after
+	}
+
+	users, err := p.repoProfile.ListUsers(ctx, filter)
+	if err != nil {
+		return gouser.ResListUsers{}, fmt.Errorf("Profile.repoProfile.ListUsers: %w", err)
+	}
+
+	res := gouser.ResListUsers{Users: make([]gouser.User, 0, len(users))}
+
+	if len(users) > limit {
+		users = users[:limit]
+		res.NextCursor, err = encodeListUsersCursor(users[len(users)-1], filter.SortBy)
+		if err != nil {
+			return gouser.ResListUsers{}, fmt.Errorf("encodeListUsersCursor: %w", err)
+		}
+	}
+
+	for _, user := range users {
+		res.Users = append(res.Users, gouser.User{}.LoadEntityUser(user))
+	}
+
+	if req.WithTotalCount {
+		totalCount, err := p.repoProfile.CountUsers(ctx, filter)
+		if err != nil {
+			return gouser.ResListUsers{}, fmt.Errorf("Profile.repoProfile.CountUsers: %w", err)
+		}
+		res.TotalCount = &totalCount
+	}
+
+	return res, nil
+}
+
+// BatchGetProfiles return user profiles by user ids and usernames, keeping the
+// request order. Not found user ids and usernames are returned as missing, a
+// repeated user id, username or user is returned once.
+func (p *Profile) BatchGetProfiles(ctx context.Context, req gouser.ReqBatchGetProfiles) (gouser.ResBatchGetProfiles, error) {
+	err := req.Validate()
+	if err != nil {
+		err := fmt.Errorf("ReqBatchGetProfiles.Validate: %w", err)
+		return gouser.ResBatchGetProfiles{}, fmt.Errorf("%w: %w", gouser.ErrRequestInvalid, err)
+	}
+
+	users, err := p.repoProfile.BatchGetProfiles(ctx, req.UserIDs, req.Usernames)
+	if err != nil {
+		return gouser.ResBatchGetProfiles{}, fmt.Errorf("Profile.repoProfile.BatchGetProfiles: %w", err)
+	}
+
+	userByID := make(map[int64]entity.User, len(users))
+	userByUsername := make(map[string]entity.User, len(users))
+	for _, user := range users {
+		userByID[user.ID] = user
+		userByUsername[user.Username] = user
+	}
+
+	res := gouser.ResBatchGetProfiles{
+		Profiles:         make([]gouser.ResGetProfileByUsername, 0, len(users)),
+		MissingUserIDs:   []int64{},
+		MissingUsernames: []string{},
+	}
+
+	isAdded := make(map[int64]bool, len(users))
+	add := func(user entity.User) {
+		if isAdded[user.ID] {
+			return
+		}
+		isAdded[user.ID] = true
+		res.Profiles = append(res.Profiles, gouser.ResGetProfileByUsername{}.LoadEntityUser(user))
+	}
+
+	isUserIDSeen := make(map[int64]bool, len(req.UserIDs))
+	for _, userID := range req.UserIDs {
+		if isUserIDSeen[userID] {
+			continue
+		}
+		isUserIDSeen[userID] = true
+
+		user, ok := userByID[userID]
+		if !ok {
+			res.MissingUserIDs = append(res.MissingUserIDs, userID)
+			continue
+		}
+		add(user)
+	}
+
+	isUsernameSeen := make(map[string]bool, len(req.Usernames))
+	for _, username := range req.Usernames {
+		if isUsernameSeen[username] {
+			continue
+		}
+		isUsernameSeen[username] = true
+
+		user, ok := userByUsername[username]
+		if !ok {
+			res.MissingUsernames = append(res.MissingUsernames, username)
+			continue
+		}
+		add(user)
+	}
+
+	return res, nil
+}
+
+// WatchUsers call started with the cursor the stream starts after, then call
+// send with every user create, update and delete after it, it returns when ctx
+// is done or started or send return error. Admin only, the JWT is checked when
+// the stream starts.
+//
+// Events are read from the persisted domain event log, so a client resuming
+// with the cursor of its last handled event misses nothing. Empty req.Cursor
+// starts after the newest event, started tells the client that position so it
+// can resume from there even if the stream breaks before the first event.
+// Events are streamed in order of the transaction which wrote them, only once
+// every older transaction is finished, so an event committed after a newer one
+// is not skipped. A long running transaction delays the stream until it ends.
+func (p *Profile) WatchUsers(ctx context.Context, req gouser.ReqWatchUsers, started func(cursor string) error, send func(gouser.UserEvent) error) error {
+	err := req.Validate()
+	if err != nil {
+		err := fmt.Errorf("gouser.ReqWatchUsers.Validate: %w", err)
+		return fmt.Errorf("%w: %w", gouser.ErrRequestInvalid, err)
+	}
+
+	_, err = p.guard.authenticateAdmin(ctx, req.UserJWT)
+	if err != nil {
+		return fmt.Errorf("Profile.guard.authenticateAdmin: %w", err)
+	}
+
+	var after watchUsersCursor
+	if req.Cursor == "" {
+		// Events of transactions before the oldest running one are all
+		// final, start right after them.
+		xactID, err := p.repoOutbox.GetOldestRunningXactID(ctx)
+		if err != nil {
+			return fmt.Errorf("Profile.repoOutbox.GetOldestRunningXactID: %w", err)
+		}
+		after = watchUsersCursor{XactID: xactID}
+	} else {
+		cursor, err := decodeWatchUsersCursor(req.Cursor)
+		if err != nil {
+			err := fmt.Errorf("decodeWatchUsersCursor: %w", err)
+			return fmt.Errorf("%w: %w", gouser.ErrRequestInvalid, err)
+		}
+		after = cursor
+	}
+
+	startCursor, err := encodeWatchUsersCursor(after)
+	if err != nil {
+		return fmt.Errorf("encodeWatchUsersCursor: %w", err)
+	}
+
+	err = started(startCursor)
+	if err != nil {
+		return fmt.Errorf("started: %w", err)
+	}
+
+	pollInterval := time.Duration(p.cfg.WatchUsers.PollIntervalMillisecond) * time.Millisecond
+	batchSize := p.cfg.WatchUsers.BatchSize
+
+	for {
+		events, err := p.repoOutbox.GetEventsAfter(ctx, after.XactID, after.EventID, gouser.WatchUsersEventTypes, uint64(batchSize))
+		if err != nil {
+			return fmt.Errorf("Profile.repoOutbox.GetEventsAfter: %w", err)
+		}
+
+		for _, event := range events {
+			position := watchUsersCursor{XactID: event.XactID, EventID: event.ID}
+			cursor, err := encodeWatchUsersCursor(position)
+			if err != nil {
+				return fmt.Errorf("encodeWatchUsersCursor: %w", err)
+			}
+
+			userEvent, err := gouser.UserEvent{}.LoadEntityOutboxEvent(event, cursor)
+			if err != nil {
+				return fmt.Errorf("gouser.UserEvent.LoadEntityOutboxEvent: %w", err)
+			}
+
+			err = send(userEvent)
+			if err != nil {
+				return fmt.Errorf("send: %w", err)
+			}
+
+			after = position
+		}
+
+		// Full batch means more events are waiting, read them right away.
+		if len(events) == batchSize {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("context.Context.Done: %w", ctx.Err())
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+// listUsersCursor is the position of the last user of a ListUsers page.
+type listUsersCursor struct {
+	SortBy    string    `json:"s"`
+	ID        int64     `json:"i"`
+	CreatedAt time.Time `json:"c"`
+	Username  string    `json:"u,omitempty"`
+}
+
+func encodeListUsersCursor(user entity.User, sortBy string) (string, error) {
+	cursor := listUsersCursor{SortBy: sortBy, ID: user.ID}
+	switch sortBy {
+	case gouser.UserSortByCreatedAt:
+		cursor.CreatedAt = user.CreatedAt
+	case gouser.UserSortByUsername:
+		cursor.Username = user.Username
+	}
+
+	cursorJSON, err := json.Marshal(cursor)
+	if err != nil {
+		return "", fmt.Errorf("json.Marshal: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(cursorJSON), nil
+}
+
+func decodeListUsersCursor(s string, sortBy string) (entity.User, error) {
+	cursorJSON, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return entity.User{}, fmt.Errorf("base64.RawURLEncoding.DecodeString: %w", err)
+	}
+
+	cursor := listUsersCursor{}
+	err = json.Unmarshal(cursorJSON, &cursor)
+	if err != nil {
+		return entity.User{}, fmt.Errorf("json.Unmarshal: %w", err)
+	}
+
+	if cursor.SortBy != sortBy {
+		return entity.User{}, fmt.Errorf("cursor is for sort by '%s' not '%s'", cursor.SortBy, sortBy)
+	}
+
+	user := entity.User{
+		ID:        cursor.ID,
+		CreatedAt: cursor.CreatedAt,
+		Username:  cursor.Username,
+	}
+
+	return user, nil
+}
+
+// watchUsersCursor is the position of the last event of WatchUsers stream.
+// Cursor issued before XactID existed has it 0, it resumes after the event
+// among events written before the column was added.
+type watchUsersCursor struct {
+	XactID  int64 `json:"x,omitempty"`
+	EventID int64 `json:"e"`
+}
+
+func encodeWatchUsersCursor(cursor watchUsersCursor) (string, error) {
+	cursorJSON, err := json.Marshal(cursor)
+	if err != nil {
+		return "", fmt.Errorf("json.Marshal: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(cursorJSON), nil
+}
+
+func decodeWatchUsersCursor(s string) (watchUsersCursor, error) {
+	cursorJSON, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return watchUsersCursor{}, fmt.Errorf("base64.RawURLEncoding.DecodeString: %w", err)
+	}
+
+	cursor := watchUsersCursor{}
+	err = json.Unmarshal(cursorJSON, &cursor)
+	if err != nil {
+		return watchUsersCursor{}, fmt.Errorf("json.Unmarshal: %w", err)
+	}
+
+	if cursor.XactID < 0 {
+		return watchUsersCursor{}, fmt.Errorf("cursor xact id can not be negative, got %d", cursor.XactID)
+	}
+
+	if cursor.EventID < 0 {
+		return watchUsersCursor{}, fmt.Errorf("cursor event id can not be negative, got %d", cursor.EventID)
+	}
+
+	return cursor, nil
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/Hidayathamir/go-user/config"
 	"github.com/Hidayathamir/go-user/internal/pkg/auth"
+	"github.com/Hidayathamir/go-user/internal/repo"
 	"github.com/Hidayathamir/go-user/internal/repo/db/entity"
 	"github.com/Hidayathamir/go-user/internal/repo/mockrepo"
 	"github.com/Hidayathamir/go-user/pkg/gouser"
@@ -76,6 +77,88 @@ func TestUnitProfileGetProfileByUsername(t *testing.T) {
 		require.Error(t, err)
 		require.ErrorIs(t, err, assert.AnError)
 	})
+	t.Run("old username within grace period should return current user marked as moved", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoProfile := mockrepo.NewMockIProfile(ctrl)
+
+		p := &Profile{
+			cfg: config.Config{
+				Username: config.Username{HistoryGraceHour: 48},
+			},
+			repoProfile: repoProfile,
+		}
+
+		repoProfile.EXPECT().
+			GetProfileByUsername(gomock.Any(), "hidayat").
+			Return(entity.User{}, gouser.ErrUnknownUsername)
+		repoProfile.EXPECT().
+			GetProfileByOldUsername(gomock.Any(), "hidayat", gomock.Any()).
+			Return(entity.User{ID: 124, Username: "hidayat2"}, nil)
+
+		profile, err := p.GetProfileByUsername(context.Background(), gouser.ReqGetProfileByUsername{Username: "hidayat"})
+
+		require.NoError(t, err)
+		assert.Equal(t, gouser.ResGetProfileByUsername{
+			ID:       124,
+			Username: "hidayat2",
+			Moved:    true,
+		}, profile)
+	})
+	t.Run("unknown username and unknown old username should return error unknown username", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoProfile := mockrepo.NewMockIProfile(ctrl)
+
+		p := &Profile{
+			cfg: config.Config{
+				Username: config.Username{HistoryGraceHour: 48},
+			},
+			repoProfile: repoProfile,
+		}
+
+		repoProfile.EXPECT().
+			GetProfileByUsername(gomock.Any(), "hidayat").
+			Return(entity.User{}, gouser.ErrUnknownUsername)
+		repoProfile.EXPECT().
+			GetProfileByOldUsername(gomock.Any(), "hidayat", gomock.Any()).
+			Return(entity.User{}, gouser.ErrUnknownUsername)
+
+		profile, err := p.GetProfileByUsername(context.Background(), gouser.ReqGetProfileByUsername{Username: "hidayat"})
+
+		assert.Empty(t, profile)
+		require.Error(t, err)
+		require.ErrorIs(t, err, gouser.ErrUnknownUsername)
+	})
+	t.Run("unknown username with history disabled should not look up old username", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoProfile := mockrepo.NewMockIProfile(ctrl)
+
+		p := &Profile{
+			cfg:         config.Config{},
+			repoProfile: repoProfile,
+		}
+
+		repoProfile.EXPECT().
+			GetProfileByUsername(gomock.Any(), "hidayat").
+			Return(entity.User{}, gouser.ErrUnknownUsername)
+
+		profile, err := p.GetProfileByUsername(context.Background(), gouser.ReqGetProfileByUsername{Username: "hidayat"})
+
+		assert.Empty(t, profile)
+		require.Error(t, err)
+		require.ErrorIs(t, err, gouser.ErrUnknownUsername)
+	})
 	t.Run("usernam empty should return error", func(t *testing.T) {
 		t.Parallel()
 
@@ -107,6 +190,9 @@ func TestUnitProfileUpdateProfileByUserID(t *testing.T) {
 		defer ctrl.Finish()
 
 		repoProfile := mockrepo.NewMockIProfile(ctrl)
+		repoSession := mockrepo.NewMockISession(ctrl)
+		repoAuditLog := mockrepo.NewMockIAuditLog(ctrl)
+		transactor := mockrepo.NewMockITransactor(ctrl)
 
 		cfg := config.Config{
 			JWT: config.JWT{ExpireHour: 24, SignedKey: "secretsignkey"},
@@ -114,17 +200,48 @@ func TestUnitProfileUpdateProfileByUserID(t *testing.T) {
 
 		p := &Profile{
 			cfg:         cfg,
+			guard:       newGuard(cfg, repoSession, repoProfile),
 			repoProfile: repoProfile,
+			transactor:  transactor,
+			auditor:     newAuditor(cfg, repoAuditLog),
 		}
 
+		repoSession.EXPECT().
+			GetSessionByJTI(gomock.Any(), "jti441").
+			Return(entity.Session{ID: 1, UserID: 441, JTI: "jti441"}, nil)
+		repoSession.EXPECT().UpdateSessionLastSeenAt(gomock.Any(), int64(1), gomock.Any()).Return(nil)
+		repoProfile.EXPECT().
+			GetProfileByUserID(gomock.Any(), int64(441)).
+			Return(entity.User{ID: 441, Status: entity.UserStatusActive}, nil)
+		transactor.EXPECT().
+			WithinTx(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+				return fn(ctx)
+			})
 		repoProfile.EXPECT().UpdateProfileByUserID(gomock.Any(), gomock.Any()).Return(nil)
 
+		var auditLog entity.AuditLog
+		repoAuditLog.EXPECT().
+			CreateAuditLog(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, a entity.AuditLog) error {
+				auditLog = a
+				return nil
+			})
+
 		err := p.UpdateProfileByUserID(context.Background(), gouser.ReqUpdateProfileByUserID{
-			UserJWT:  auth.GenerateUserJWTToken(441, cfg),
-			Password: "dummypassword",
+			UserJWT:   auth.GenerateUserJWTToken(441, "jti441", cfg),
+			Password:  "dummypassword",
+			IP:        "10.0.0.1",
+			RequestID: "req-1",
 		})
 
 		require.NoError(t, err)
+		assert.Equal(t, entity.AuditActionUserPasswordChange, auditLog.Action)
+		assert.Equal(t, entity.AuditResultSuccess, auditLog.Result)
+		require.NotNil(t, auditLog.TargetUserID)
+		assert.Equal(t, int64(441), *auditLog.TargetUserID)
+		assert.Equal(t, "10.0.0.1", auditLog.IP)
+		assert.Equal(t, "req-1", auditLog.RequestID)
 	})
 	t.Run("call repo UpdateProfileByUserID error should return error", func(t *testing.T) {
 		t.Parallel()
@@ -133,6 +250,9 @@ func TestUnitProfileUpdateProfileByUserID(t *testing.T) {
 		defer ctrl.Finish()
 
 		repoProfile := mockrepo.NewMockIProfile(ctrl)
+		repoSession := mockrepo.NewMockISession(ctrl)
+		repoAuditLog := mockrepo.NewMockIAuditLog(ctrl)
+		transactor := mockrepo.NewMockITransactor(ctrl)
 
 		cfg := config.Config{
 			JWT: config.JWT{ExpireHour: 24, SignedKey: "secretsignkey"},
@@ -140,15 +260,32 @@ func TestUnitProfileUpdateProfileByUserID(t *testing.T) {
 
 		p := &Profile{
 			cfg:         cfg,
+			guard:       newGuard(cfg, repoSession, repoProfile),
 			repoProfile: repoProfile,
+			transactor:  transactor,
+			auditor:     newAuditor(cfg, repoAuditLog),
 		}
 
+		repoSession.EXPECT().
+			GetSessionByJTI(gomock.Any(), "jti2342").
+			Return(entity.Session{ID: 2, UserID: 2342, JTI: "jti2342"}, nil)
+		repoSession.EXPECT().UpdateSessionLastSeenAt(gomock.Any(), int64(2), gomock.Any()).Return(nil)
+		repoProfile.EXPECT().
+			GetProfileByUserID(gomock.Any(), int64(2342)).
+			Return(entity.User{ID: 2342, Status: entity.UserStatusActive}, nil)
+		transactor.EXPECT().
+			WithinTx(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+				return fn(ctx)
+			})
 		repoProfile.EXPECT().
 			UpdateProfileByUserID(gomock.Any(), gomock.Any()).
 			Return(assert.AnError)
 
+		repoAuditLog.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any()).Return(nil)
+
 		err := p.UpdateProfileByUserID(context.Background(), gouser.ReqUpdateProfileByUserID{
-			UserJWT:  auth.GenerateUserJWTToken(2342, cfg),
+			UserJWT:  auth.GenerateUserJWTToken(2342, "jti2342", cfg),
 			Password: "dummypassword",
 		})
 
@@ -162,6 +299,7 @@ func TestUnitProfileUpdateProfileByUserID(t *testing.T) {
 		defer ctrl.Finish()
 
 		repoProfile := mockrepo.NewMockIProfile(ctrl)
+		repoSession := mockrepo.NewMockISession(ctrl)
 
 		cfg := config.Config{
 			JWT: config.JWT{ExpireHour: 24, SignedKey: "secretsignkey"},
@@ -169,6 +307,7 @@ func TestUnitProfileUpdateProfileByUserID(t *testing.T) {
 
 		p := &Profile{
 			cfg:         cfg,
+			guard:       newGuard(cfg, repoSession, repoProfile),
 			repoProfile: repoProfile,
 		}
 
@@ -187,6 +326,7 @@ func TestUnitProfileUpdateProfileByUserID(t *testing.T) {
 		defer ctrl.Finish()
 
 		repoProfile := mockrepo.NewMockIProfile(ctrl)
+		repoSession := mockrepo.NewMockISession(ctrl)
 
 		cfg := config.Config{
 			JWT: config.JWT{ExpireHour: 24, SignedKey: "secretsignkey"},
@@ -194,6 +334,7 @@ func TestUnitProfileUpdateProfileByUserID(t *testing.T) {
 
 		p := &Profile{
 			cfg:         cfg,
+			guard:       newGuard(cfg, repoSession, repoProfile),
 			repoProfile: repoProfile,
 		}
 
@@ -212,6 +353,8 @@ func TestUnitProfileUpdateProfileByUserID(t *testing.T) {
 		defer ctrl.Finish()
 
 		repoProfile := mockrepo.NewMockIProfile(ctrl)
+		repoSession := mockrepo.NewMockISession(ctrl)
+		repoAuditLog := mockrepo.NewMockIAuditLog(ctrl)
 
 		cfg := config.Config{
 			JWT: config.JWT{ExpireHour: 24, SignedKey: "secretsignkey"},
@@ -219,15 +362,834 @@ func TestUnitProfileUpdateProfileByUserID(t *testing.T) {
 
 		p := &Profile{
 			cfg:         cfg,
+			guard:       newGuard(cfg, repoSession, repoProfile),
 			repoProfile: repoProfile,
+			auditor:     newAuditor(cfg, repoAuditLog),
 		}
 
+		repoSession.EXPECT().
+			GetSessionByJTI(gomock.Any(), "jti323").
+			Return(entity.Session{ID: 3, UserID: 323, JTI: "jti323"}, nil)
+		repoSession.EXPECT().UpdateSessionLastSeenAt(gomock.Any(), int64(3), gomock.Any()).Return(nil)
+		repoProfile.EXPECT().
+			GetProfileByUserID(gomock.Any(), int64(323)).
+			Return(entity.User{ID: 323, Status: entity.UserStatusActive}, nil)
+
+		repoAuditLog.EXPECT().CreateAuditLog(gomock.Any(), gomock.Any()).Return(nil)
+
 		err := p.UpdateProfileByUserID(context.Background(), gouser.ReqUpdateProfileByUserID{
-			UserJWT:  "Bearer " + auth.GenerateUserJWTToken(323, cfg),
+			UserJWT:  "Bearer " + auth.GenerateUserJWTToken(323, "jti323", cfg),
 			Password: uuid.NewString() + uuid.NewString() + uuid.NewString(),
 		})
 
 		require.Error(t, err)
 		require.ErrorContains(t, err, "auth.GenerateHashPassword")
 	})
+	t.Run("user jwt of revoked session should return error", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoProfile := mockrepo.NewMockIProfile(ctrl)
+		repoSession := mockrepo.NewMockISession(ctrl)
+
+		cfg := config.Config{
+			JWT: config.JWT{ExpireHour: 24, SignedKey: "secretsignkey"},
+		}
+
+		p := &Profile{
+			cfg:         cfg,
+			guard:       newGuard(cfg, repoSession, repoProfile),
+			repoProfile: repoProfile,
+		}
+
+		revokedAt := time.Now()
+		repoSession.EXPECT().
+			GetSessionByJTI(gomock.Any(), "jti441").
+			Return(entity.Session{ID: 1, UserID: 441, JTI: "jti441", RevokedAt: &revokedAt}, nil)
+
+		err := p.UpdateProfileByUserID(context.Background(), gouser.ReqUpdateProfileByUserID{
+			UserJWT:  auth.GenerateUserJWTToken(441, "jti441", cfg),
+			Password: "dummypassword",
+		})
+
+		require.Error(t, err)
+		require.ErrorIs(t, err, gouser.ErrJWTAuth)
+		require.ErrorIs(t, err, gouser.ErrSessionRevoked)
+	})
+}
+
+func TestUnitProfileListUsers(t *testing.T) {
+	t.Parallel()
+
+	t.Run("list users success should return next cursor and total count", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoProfile := mockrepo.NewMockIProfile(ctrl)
+		repoSession := mockrepo.NewMockISession(ctrl)
+
+		cfg := config.Config{
+			JWT: config.JWT{ExpireHour: 24, SignedKey: "secretsignkey"},
+		}
+
+		p := &Profile{
+			cfg:         cfg,
+			guard:       newGuard(cfg, repoSession, repoProfile),
+			repoProfile: repoProfile,
+		}
+
+		now := time.Now()
+		repoSession.EXPECT().
+			GetSessionByJTI(gomock.Any(), "jtiadmin").
+			Return(entity.Session{ID: 1, UserID: 1, JTI: "jtiadmin"}, nil).Times(2)
+		repoSession.EXPECT().UpdateSessionLastSeenAt(gomock.Any(), int64(1), gomock.Any()).Return(nil).Times(2)
+		repoProfile.EXPECT().
+			GetProfileByUserID(gomock.Any(), int64(1)).
+			Return(entity.User{ID: 1, Role: entity.UserRoleAdmin, Status: entity.UserStatusActive}, nil).Times(2)
+
+		filter := repo.ListUsersFilter{
+			Status:         entity.UserStatusActive,
+			UsernamePrefix: "hid",
+			SortBy:         gouser.UserSortByCreatedAt,
+			SortDesc:       true,
+			Limit:          3,
+		}
+		repoProfile.EXPECT().ListUsers(gomock.Any(), filter).Return([]entity.User{
+			{ID: 9, Username: "hidayat", Password: "hashed", CreatedAt: now},
+			{ID: 8, Username: "hidayat2", CreatedAt: now},
+			{ID: 7, Username: "hidayat3", CreatedAt: now},
+		}, nil)
+		repoProfile.EXPECT().CountUsers(gomock.Any(), filter).Return(int64(3), nil)
+
+		req := gouser.ReqListUsers{
+			UserJWT:        auth.GenerateUserJWTToken(1, "jtiadmin", cfg),
+			Limit:          2,
+			Status:         entity.UserStatusActive,
+			UsernamePrefix: "hid",
+			SortBy:         gouser.UserSortByCreatedAt,
+			SortOrder:      gouser.SortOrderDesc,
+			WithTotalCount: true,
+		}
+		res, err := p.ListUsers(context.Background(), req)
+
+		require.NoError(t, err)
+		require.Len(t, res.Users, 2)
+		assert.Equal(t, int64(9), res.Users[0].ID)
+		assert.Equal(t, int64(8), res.Users[1].ID)
+		assert.NotEmpty(t, res.NextCursor)
+		require.NotNil(t, res.TotalCount)
+		assert.Equal(t, int64(3), *res.TotalCount)
+
+		filter.After = &entity.User{ID: 8, CreatedAt: now}
+		repoProfile.EXPECT().
+			ListUsers(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, got repo.ListUsersFilter) ([]entity.User, error) {
+				require.NotNil(t, got.After)
+				assert.Equal(t, int64(8), got.After.ID)
+				assert.True(t, now.Equal(got.After.CreatedAt))
+				return []entity.User{{ID: 7, Username: "hidayat3", CreatedAt: now}}, nil
+			})
+
+		req.Cursor = res.NextCursor
+		req.WithTotalCount = false
+		res, err = p.ListUsers(context.Background(), req)
+
+		require.NoError(t, err)
+		require.Len(t, res.Users, 1)
+		assert.Empty(t, res.NextCursor)
+		assert.Nil(t, res.TotalCount)
+	})
+	t.Run("cursor of other sort should return error", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoProfile := mockrepo.NewMockIProfile(ctrl)
+		repoSession := mockrepo.NewMockISession(ctrl)
+
+		cfg := config.Config{
+			JWT: config.JWT{ExpireHour: 24, SignedKey: "secretsignkey"},
+		}
+
+		p := &Profile{
+			cfg:         cfg,
+			guard:       newGuard(cfg, repoSession, repoProfile),
+			repoProfile: repoProfile,
+		}
+
+		repoSession.EXPECT().
+			GetSessionByJTI(gomock.Any(), "jtiadmin").
+			Return(entity.Session{ID: 1, UserID: 1, JTI: "jtiadmin"}, nil)
+		repoSession.EXPECT().UpdateSessionLastSeenAt(gomock.Any(), int64(1), gomock.Any()).Return(nil)
+		repoProfile.EXPECT().
+			GetProfileByUserID(gomock.Any(), int64(1)).
+			Return(entity.User{ID: 1, Role: entity.UserRoleAdmin, Status: entity.UserStatusActive}, nil)
+
+		cursor, err := encodeListUsersCursor(entity.User{ID: 8, Username: "hidayat"}, gouser.UserSortByUsername)
+		require.NoError(t, err)
+
+		res, err := p.ListUsers(context.Background(), gouser.ReqListUsers{
+			UserJWT: auth.GenerateUserJWTToken(1, "jtiadmin", cfg),
+			Cursor:  cursor,
+		})
+
+		assert.Empty(t, res)
+		require.Error(t, err)
+		require.ErrorIs(t, err, gouser.ErrRequestInvalid)
+	})
+	t.Run("non admin should return error", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoProfile := mockrepo.NewMockIProfile(ctrl)
+		repoSession := mockrepo.NewMockISession(ctrl)
+
+		cfg := config.Config{
+			JWT: config.JWT{ExpireHour: 24, SignedKey: "secretsignkey"},
+		}
+
+		p := &Profile{
+			cfg:         cfg,
+			guard:       newGuard(cfg, repoSession, repoProfile),
+			repoProfile: repoProfile,
+		}
+
+		repoSession.EXPECT().
+			GetSessionByJTI(gomock.Any(), "jti2").
+			Return(entity.Session{ID: 2, UserID: 2, JTI: "jti2"}, nil)
+		repoSession.EXPECT().UpdateSessionLastSeenAt(gomock.Any(), int64(2), gomock.Any()).Return(nil)
+		repoProfile.EXPECT().
+			GetProfileByUserID(gomock.Any(), int64(2)).
+			Return(entity.User{ID: 2, Role: entity.UserRoleUser, Status: entity.UserStatusActive}, nil)
+
+		res, err := p.ListUsers(context.Background(), gouser.ReqListUsers{
+			UserJWT: auth.GenerateUserJWTToken(2, "jti2", cfg),
+		})
+
+		assert.Empty(t, res)
+		require.Error(t, err)
+		require.ErrorIs(t, err, gouser.ErrForbidden)
+	})
+	t.Run("invalid request should return error", func(t *testing.T) {
+		t.Parallel()
+
+		p := &Profile{}
+
+		res, err := p.ListUsers(context.Background(), gouser.ReqListUsers{
+			UserJWT: "dummyjwt",
+			Limit:   gouser.ListUsersMaxLimit + 1,
+		})
+
+		assert.Empty(t, res)
+		require.Error(t, err)
+		require.ErrorIs(t, err, gouser.ErrRequestInvalid)
+	})
+}
+
+func TestUnitProfileBatchGetProfiles(t *testing.T) {
+	t.Parallel()
+
+	t.Run("batch get profiles should keep request order and return missing", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoProfile := mockrepo.NewMockIProfile(ctrl)
+
+		p := &Profile{
+			cfg:         config.Config{},
+			repoProfile: repoProfile,
+		}
+
+		req := gouser.ReqBatchGetProfiles{
+			UserIDs:   []int64{3, 99, 1, 3},
+			Usernames: []string{"hidayat1", "unknown", "hidayat2", "unknown"},
+		}
+		repoProfile.EXPECT().
+			BatchGetProfiles(gomock.Any(), req.UserIDs, req.Usernames).
+			Return([]entity.User{
+				{ID: 1, Username: "hidayat1", Password: "hashed"},
+				{ID: 2, Username: "hidayat2"},
+				{ID: 3, Username: "hidayat3"},
+			}, nil)
+
+		res, err := p.BatchGetProfiles(context.Background(), req)
+
+		require.NoError(t, err)
+		require.Len(t, res.Profiles, 3)
+		assert.Equal(t, int64(3), res.Profiles[0].ID)
+		assert.Equal(t, int64(1), res.Profiles[1].ID)
+		assert.Equal(t, int64(2), res.Profiles[2].ID)
+		assert.Equal(t, []int64{99}, res.MissingUserIDs)
+		assert.Equal(t, []string{"unknown"}, res.MissingUsernames)
+	})
+	t.Run("too many user ids and usernames should return error", func(t *testing.T) {
+		t.Parallel()
+
+		p := &Profile{}
+
+		res, err := p.BatchGetProfiles(context.Background(), gouser.ReqBatchGetProfiles{
+			UserIDs:   make([]int64, gouser.BatchGetProfilesMaxSize),
+			Usernames: []string{"hidayat"},
+		})
+
+		assert.Empty(t, res)
+		require.Error(t, err)
+		require.ErrorIs(t, err, gouser.ErrRequestInvalid)
+	})
+	t.Run("repo BatchGetProfiles error should return error", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoProfile := mockrepo.NewMockIProfile(ctrl)
+
+		p := &Profile{
+			cfg:         config.Config{},
+			repoProfile: repoProfile,
+		}
+
+		repoProfile.EXPECT().
+			BatchGetProfiles(gomock.Any(), []int64{1}, nil).
+			Return(nil, assert.AnError)
+
+		res, err := p.BatchGetProfiles(context.Background(), gouser.ReqBatchGetProfiles{UserIDs: []int64{1}})
+
+		assert.Empty(t, res)
+		require.Error(t, err)
+		require.ErrorIs(t, err, assert.AnError)
+	})
+}
+
+func TestUnitProfileGetProfileByUserID(t *testing.T) {
+	t.Parallel()
+
+	t.Run("get profile by user id success", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoProfile := mockrepo.NewMockIProfile(ctrl)
+
+		p := &Profile{
+			cfg:         config.Config{},
+			repoProfile: repoProfile,
+		}
+
+		now := time.Now()
+		repoProfile.EXPECT().
+			GetProfileByUserID(gomock.Any(), int64(44)).
+			Return(entity.User{ID: 44, Username: "hidayat", Password: "hashed", Role: entity.UserRoleAdmin, CreatedAt: now, UpdatedAt: now}, nil)
+
+		res, err := p.GetProfileByUserID(context.Background(), gouser.ReqGetProfileByUserID{UserID: 44})
+
+		require.NoError(t, err)
+		assert.Equal(t, gouser.ResGetProfileByUsername{ID: 44, Username: "hidayat", CreatedAt: now, UpdatedAt: now}, res)
+	})
+	t.Run("repo GetProfileByUserID error should return error", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoProfile := mockrepo.NewMockIProfile(ctrl)
+
+		p := &Profile{
+			cfg:         config.Config{},
+			repoProfile: repoProfile,
+		}
+
+		repoProfile.EXPECT().
+			GetProfileByUserID(gomock.Any(), int64(44)).
+			Return(entity.User{}, gouser.ErrUnknownUserID)
+
+		res, err := p.GetProfileByUserID(context.Background(), gouser.ReqGetProfileByUserID{UserID: 44})
+
+		assert.Empty(t, res)
+		require.Error(t, err)
+		require.ErrorIs(t, err, gouser.ErrUnknownUserID)
+	})
+	t.Run("empty user id should return error", func(t *testing.T) {
+		t.Parallel()
+
+		p := &Profile{}
+
+		res, err := p.GetProfileByUserID(context.Background(), gouser.ReqGetProfileByUserID{})
+
+		assert.Empty(t, res)
+		require.Error(t, err)
+		require.ErrorIs(t, err, gouser.ErrRequestInvalid)
+	})
+}
+
+func TestUnitProfileGetMyProfile(t *testing.T) {
+	t.Parallel()
+
+	t.Run("get my profile success should return private fields", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoProfile := mockrepo.NewMockIProfile(ctrl)
+		repoSession := mockrepo.NewMockISession(ctrl)
+
+		cfg := config.Config{
+			JWT: config.JWT{ExpireHour: 24, SignedKey: "secretsignkey"},
+		}
+
+		p := &Profile{
+			cfg:         cfg,
+			guard:       newGuard(cfg, repoSession, repoProfile),
+			repoProfile: repoProfile,
+		}
+
+		now := time.Now()
+		repoSession.EXPECT().
+			GetSessionByJTI(gomock.Any(), "jti1").
+			Return(entity.Session{ID: 1, UserID: 44, JTI: "jti1"}, nil)
+		repoSession.EXPECT().UpdateSessionLastSeenAt(gomock.Any(), int64(1), gomock.Any()).Return(nil)
+		repoProfile.EXPECT().
+			GetProfileByUserID(gomock.Any(), int64(44)).
+			Return(entity.User{
+				ID: 44, Username: "hidayat", Password: "hashed", Role: entity.UserRoleUser,
+				Status: entity.UserStatusActive, CreatedAt: now, UpdatedAt: now,
+			}, nil)
+
+		res, err := p.GetMyProfile(context.Background(), gouser.ReqGetMyProfile{
+			UserJWT: auth.GenerateUserJWTToken(44, "jti1", cfg),
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, gouser.ResGetMyProfile{
+			ID: 44, Username: "hidayat", Role: entity.UserRoleUser,
+			Status: entity.UserStatusActive, CreatedAt: now, UpdatedAt: now,
+		}, res)
+	})
+	t.Run("invalid jwt should return error", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := config.Config{
+			JWT: config.JWT{ExpireHour: 24, SignedKey: "secretsignkey"},
+		}
+
+		p := &Profile{
+			cfg:   cfg,
+			guard: newGuard(cfg, nil, nil),
+		}
+
+		res, err := p.GetMyProfile(context.Background(), gouser.ReqGetMyProfile{UserJWT: "invalidjwt"})
+
+		assert.Empty(t, res)
+		require.Error(t, err)
+	})
+}
+
+func startedNoop(string) error {
+	return nil
+}
+
+func TestUnitProfileWatchUsers(t *testing.T) {
+	t.Parallel()
+
+	t.Run("cursor should resume after its event", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoOutbox := mockrepo.NewMockIOutbox(ctrl)
+		repoProfile := mockrepo.NewMockIProfile(ctrl)
+		repoSession := mockrepo.NewMockISession(ctrl)
+
+		cfg := config.Config{
+			JWT:        config.JWT{ExpireHour: 24, SignedKey: "secretsignkey"},
+			WatchUsers: config.WatchUsers{PollIntervalMillisecond: 500, BatchSize: 100},
+		}
+
+		p := &Profile{
+			cfg:        cfg,
+			guard:      newGuard(cfg, repoSession, repoProfile),
+			repoOutbox: repoOutbox,
+		}
+
+		repoSession.EXPECT().
+			GetSessionByJTI(gomock.Any(), "jtiadmin").
+			Return(entity.Session{ID: 1, UserID: 1, JTI: "jtiadmin"}, nil)
+		repoSession.EXPECT().UpdateSessionLastSeenAt(gomock.Any(), int64(1), gomock.Any()).Return(nil)
+		repoProfile.EXPECT().
+			GetProfileByUserID(gomock.Any(), int64(1)).
+			Return(entity.User{ID: 1, Role: entity.UserRoleAdmin, Status: entity.UserStatusActive}, nil)
+
+		cursor, err := encodeWatchUsersCursor(watchUsersCursor{XactID: 700, EventID: 7})
+		require.NoError(t, err)
+
+		now := time.Now()
+		repoOutbox.EXPECT().
+			GetEventsAfter(gomock.Any(), int64(700), int64(7), gouser.WatchUsersEventTypes, uint64(100)).
+			Return([]entity.OutboxEvent{
+				{ID: 8, EventType: gouser.EventTypeUserRegistered, UserID: 44, Payload: []byte(`{"user_id":44,"username":"hidayat"}`), CreatedAt: now, XactID: 700},
+				{ID: 10, EventType: gouser.EventTypeProfileUpdated, UserID: 44, Payload: []byte(`{"user_id":44,"username":"hidayat2","old_username":"hidayat"}`), CreatedAt: now, XactID: 701},
+				{ID: 11, EventType: gouser.EventTypeUserDeleted, UserID: 44, Payload: []byte(`{"user_id":44}`), CreatedAt: now, XactID: 702},
+			}, nil)
+
+		ctx, cancel := context.WithCancel(context.Background())
+
+		userEvents := []gouser.UserEvent{}
+		err = p.WatchUsers(ctx, gouser.ReqWatchUsers{UserJWT: auth.GenerateUserJWTToken(1, "jtiadmin", cfg), Cursor: cursor}, startedNoop, func(userEvent gouser.UserEvent) error {
+			userEvents = append(userEvents, userEvent)
+			if len(userEvents) == 3 {
+				cancel()
+			}
+			return nil
+		})
+
+		require.Error(t, err)
+		require.ErrorIs(t, err, context.Canceled)
+		require.Len(t, userEvents, 3)
+		assert.Equal(t, gouser.UserChangeCreated, userEvents[0].Change)
+		assert.Equal(t, "hidayat", userEvents[0].Username)
+		assert.Equal(t, gouser.UserChangeUpdated, userEvents[1].Change)
+		assert.Equal(t, "hidayat2", userEvents[1].Username)
+		assert.Equal(t, "hidayat", userEvents[1].OldUsername)
+		assert.Equal(t, gouser.UserChangeDeleted, userEvents[2].Change)
+		assert.Equal(t, int64(44), userEvents[2].UserID)
+
+		position, err := decodeWatchUsersCursor(userEvents[2].Cursor)
+		require.NoError(t, err)
+		assert.Equal(t, watchUsersCursor{XactID: 702, EventID: 11}, position)
+	})
+	t.Run("empty cursor should start after the last event", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoOutbox := mockrepo.NewMockIOutbox(ctrl)
+		repoProfile := mockrepo.NewMockIProfile(ctrl)
+		repoSession := mockrepo.NewMockISession(ctrl)
+
+		cfg := config.Config{
+			JWT:        config.JWT{ExpireHour: 24, SignedKey: "secretsignkey"},
+			WatchUsers: config.WatchUsers{PollIntervalMillisecond: 500, BatchSize: 100},
+		}
+
+		p := &Profile{
+			cfg:        cfg,
+			guard:      newGuard(cfg, repoSession, repoProfile),
+			repoOutbox: repoOutbox,
+		}
+
+		repoSession.EXPECT().
+			GetSessionByJTI(gomock.Any(), "jtiadmin").
+			Return(entity.Session{ID: 1, UserID: 1, JTI: "jtiadmin"}, nil)
+		repoSession.EXPECT().UpdateSessionLastSeenAt(gomock.Any(), int64(1), gomock.Any()).Return(nil)
+		repoProfile.EXPECT().
+			GetProfileByUserID(gomock.Any(), int64(1)).
+			Return(entity.User{ID: 1, Role: entity.UserRoleAdmin, Status: entity.UserStatusActive}, nil)
+
+		repoOutbox.EXPECT().GetOldestRunningXactID(gomock.Any()).Return(int64(700), nil)
+		repoOutbox.EXPECT().
+			GetEventsAfter(gomock.Any(), int64(700), int64(0), gouser.WatchUsersEventTypes, uint64(100)).
+			Return([]entity.OutboxEvent{}, nil)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		startCursor := ""
+		err := p.WatchUsers(ctx, gouser.ReqWatchUsers{UserJWT: auth.GenerateUserJWTToken(1, "jtiadmin", cfg)}, func(cursor string) error {
+			startCursor = cursor
+			return nil
+		}, func(gouser.UserEvent) error {
+			return nil
+		})
+
+		require.Error(t, err)
+		require.ErrorIs(t, err, context.Canceled)
+
+		position, err := decodeWatchUsersCursor(startCursor)
+		require.NoError(t, err)
+		assert.Equal(t, watchUsersCursor{XactID: 700}, position)
+	})
+	t.Run("full batch should read the next batch right away", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoOutbox := mockrepo.NewMockIOutbox(ctrl)
+		repoProfile := mockrepo.NewMockIProfile(ctrl)
+		repoSession := mockrepo.NewMockISession(ctrl)
+
+		cfg := config.Config{
+			JWT:        config.JWT{ExpireHour: 24, SignedKey: "secretsignkey"},
+			WatchUsers: config.WatchUsers{PollIntervalMillisecond: 500, BatchSize: 1},
+		}
+
+		p := &Profile{
+			cfg:        cfg,
+			guard:      newGuard(cfg, repoSession, repoProfile),
+			repoOutbox: repoOutbox,
+		}
+
+		repoSession.EXPECT().
+			GetSessionByJTI(gomock.Any(), "jtiadmin").
+			Return(entity.Session{ID: 1, UserID: 1, JTI: "jtiadmin"}, nil)
+		repoSession.EXPECT().UpdateSessionLastSeenAt(gomock.Any(), int64(1), gomock.Any()).Return(nil)
+		repoProfile.EXPECT().
+			GetProfileByUserID(gomock.Any(), int64(1)).
+			Return(entity.User{ID: 1, Role: entity.UserRoleAdmin, Status: entity.UserStatusActive}, nil)
+
+		cursor, err := encodeWatchUsersCursor(watchUsersCursor{XactID: 700, EventID: 7})
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+
+		gomock.InOrder(
+			repoOutbox.EXPECT().
+				GetEventsAfter(gomock.Any(), int64(700), int64(7), gomock.Any(), uint64(1)).
+				Return([]entity.OutboxEvent{{ID: 8, EventType: gouser.EventTypeUserDeleted, UserID: 44, XactID: 701}}, nil),
+			repoOutbox.EXPECT().
+				GetEventsAfter(gomock.Any(), int64(701), int64(8), gomock.Any(), uint64(1)).
+				DoAndReturn(func(context.Context, int64, int64, []string, uint64) ([]entity.OutboxEvent, error) {
+					cancel()
+					return []entity.OutboxEvent{}, nil
+				}),
+		)
+
+		err = p.WatchUsers(ctx, gouser.ReqWatchUsers{UserJWT: auth.GenerateUserJWTToken(1, "jtiadmin", cfg), Cursor: cursor}, startedNoop, func(gouser.UserEvent) error {
+			return nil
+		})
+
+		require.Error(t, err)
+		require.ErrorIs(t, err, context.Canceled)
+	})
+	t.Run("event of slow transaction committed later with smaller id should be sent", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoOutbox := mockrepo.NewMockIOutbox(ctrl)
+		repoProfile := mockrepo.NewMockIProfile(ctrl)
+		repoSession := mockrepo.NewMockISession(ctrl)
+
+		cfg := config.Config{
+			JWT:        config.JWT{ExpireHour: 24, SignedKey: "secretsignkey"},
+			WatchUsers: config.WatchUsers{PollIntervalMillisecond: 1, BatchSize: 100},
+		}
+
+		p := &Profile{
+			cfg:        cfg,
+			guard:      newGuard(cfg, repoSession, repoProfile),
+			repoOutbox: repoOutbox,
+		}
+
+		repoSession.EXPECT().
+			GetSessionByJTI(gomock.Any(), "jtiadmin").
+			Return(entity.Session{ID: 1, UserID: 1, JTI: "jtiadmin"}, nil)
+		repoSession.EXPECT().UpdateSessionLastSeenAt(gomock.Any(), int64(1), gomock.Any()).Return(nil)
+		repoProfile.EXPECT().
+			GetProfileByUserID(gomock.Any(), int64(1)).
+			Return(entity.User{ID: 1, Role: entity.UserRoleAdmin, Status: entity.UserStatusActive}, nil)
+
+		cursor, err := encodeWatchUsersCursor(watchUsersCursor{XactID: 700, EventID: 7})
+		require.NoError(t, err)
+
+		// Event 8 is written by transaction 705 which is still running at the
+		// first read, event 9 is committed first. The next read continue after
+		// event 9 by transaction id, so event 8 is not skipped.
+		gomock.InOrder(
+			repoOutbox.EXPECT().
+				GetEventsAfter(gomock.Any(), int64(700), int64(7), gomock.Any(), uint64(100)).
+				Return([]entity.OutboxEvent{{ID: 9, EventType: gouser.EventTypeUserDeleted, UserID: 45, XactID: 701}}, nil),
+			repoOutbox.EXPECT().
+				GetEventsAfter(gomock.Any(), int64(701), int64(9), gomock.Any(), uint64(100)).
+				Return([]entity.OutboxEvent{{ID: 8, EventType: gouser.EventTypeUserDeleted, UserID: 44, XactID: 705}}, nil),
+		)
+
+		ctx, cancel := context.WithCancel(context.Background())
+
+		userIDs := []int64{}
+		err = p.WatchUsers(ctx, gouser.ReqWatchUsers{UserJWT: auth.GenerateUserJWTToken(1, "jtiadmin", cfg), Cursor: cursor}, startedNoop, func(userEvent gouser.UserEvent) error {
+			userIDs = append(userIDs, userEvent.UserID)
+			if len(userIDs) == 2 {
+				cancel()
+			}
+			return nil
+		})
+
+		require.Error(t, err)
+		require.ErrorIs(t, err, context.Canceled)
+		assert.Equal(t, []int64{45, 44}, userIDs)
+	})
+	t.Run("invalid cursor should return error", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoProfile := mockrepo.NewMockIProfile(ctrl)
+		repoSession := mockrepo.NewMockISession(ctrl)
+
+		cfg := config.Config{
+			JWT: config.JWT{ExpireHour: 24, SignedKey: "secretsignkey"},
+		}
+
+		p := &Profile{
+			cfg:   cfg,
+			guard: newGuard(cfg, repoSession, repoProfile),
+		}
+
+		repoSession.EXPECT().
+			GetSessionByJTI(gomock.Any(), "jtiadmin").
+			Return(entity.Session{ID: 1, UserID: 1, JTI: "jtiadmin"}, nil)
+		repoSession.EXPECT().UpdateSessionLastSeenAt(gomock.Any(), int64(1), gomock.Any()).Return(nil)
+		repoProfile.EXPECT().
+			GetProfileByUserID(gomock.Any(), int64(1)).
+			Return(entity.User{ID: 1, Role: entity.UserRoleAdmin, Status: entity.UserStatusActive}, nil)
+
+		err := p.WatchUsers(context.Background(), gouser.ReqWatchUsers{UserJWT: auth.GenerateUserJWTToken(1, "jtiadmin", cfg), Cursor: "not a cursor"}, startedNoop, func(gouser.UserEvent) error {
+			return nil
+		})
+
+		require.Error(t, err)
+		require.ErrorIs(t, err, gouser.ErrRequestInvalid)
+	})
+	t.Run("empty jwt should return error", func(t *testing.T) {
+		t.Parallel()
+
+		p := &Profile{}
+
+		err := p.WatchUsers(context.Background(), gouser.ReqWatchUsers{}, startedNoop, func(gouser.UserEvent) error {
+			return nil
+		})
+
+		require.Error(t, err)
+		require.ErrorIs(t, err, gouser.ErrRequestInvalid)
+	})
+	t.Run("non admin should return error", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoProfile := mockrepo.NewMockIProfile(ctrl)
+		repoSession := mockrepo.NewMockISession(ctrl)
+
+		cfg := config.Config{
+			JWT: config.JWT{ExpireHour: 24, SignedKey: "secretsignkey"},
+		}
+
+		p := &Profile{
+			cfg:   cfg,
+			guard: newGuard(cfg, repoSession, repoProfile),
+		}
+
+		repoSession.EXPECT().
+			GetSessionByJTI(gomock.Any(), "jtiuser").
+			Return(entity.Session{ID: 2, UserID: 44, JTI: "jtiuser"}, nil)
+		repoSession.EXPECT().UpdateSessionLastSeenAt(gomock.Any(), int64(2), gomock.Any()).Return(nil)
+		repoProfile.EXPECT().
+			GetProfileByUserID(gomock.Any(), int64(44)).
+			Return(entity.User{ID: 44, Role: entity.UserRoleUser, Status: entity.UserStatusActive}, nil)
+
+		err := p.WatchUsers(context.Background(), gouser.ReqWatchUsers{UserJWT: auth.GenerateUserJWTToken(44, "jtiuser", cfg)}, startedNoop, func(gouser.UserEvent) error {
+			return nil
+		})
+
+		require.Error(t, err)
+		require.ErrorIs(t, err, gouser.ErrForbidden)
+	})
+	t.Run("send error should return error", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoOutbox := mockrepo.NewMockIOutbox(ctrl)
+		repoProfile := mockrepo.NewMockIProfile(ctrl)
+		repoSession := mockrepo.NewMockISession(ctrl)
+
+		cfg := config.Config{
+			JWT:        config.JWT{ExpireHour: 24, SignedKey: "secretsignkey"},
+			WatchUsers: config.WatchUsers{PollIntervalMillisecond: 500, BatchSize: 100},
+		}
+
+		p := &Profile{
+			cfg:        cfg,
+			guard:      newGuard(cfg, repoSession, repoProfile),
+			repoOutbox: repoOutbox,
+		}
+
+		repoSession.EXPECT().
+			GetSessionByJTI(gomock.Any(), "jtiadmin").
+			Return(entity.Session{ID: 1, UserID: 1, JTI: "jtiadmin"}, nil)
+		repoSession.EXPECT().UpdateSessionLastSeenAt(gomock.Any(), int64(1), gomock.Any()).Return(nil)
+		repoProfile.EXPECT().
+			GetProfileByUserID(gomock.Any(), int64(1)).
+			Return(entity.User{ID: 1, Role: entity.UserRoleAdmin, Status: entity.UserStatusActive}, nil)
+
+		repoOutbox.EXPECT().GetOldestRunningXactID(gomock.Any()).Return(int64(0), nil)
+		repoOutbox.EXPECT().
+			GetEventsAfter(gomock.Any(), int64(0), int64(0), gomock.Any(), uint64(100)).
+			Return([]entity.OutboxEvent{{ID: 1, EventType: gouser.EventTypeUserDeleted, UserID: 44}}, nil)
+
+		err := p.WatchUsers(context.Background(), gouser.ReqWatchUsers{UserJWT: auth.GenerateUserJWTToken(1, "jtiadmin", cfg)}, startedNoop, func(gouser.UserEvent) error {
+			return assert.AnError
+		})
+
+		require.Error(t, err)
+		require.ErrorIs(t, err, assert.AnError)
+	})
+	t.Run("started error should return error", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoOutbox := mockrepo.NewMockIOutbox(ctrl)
+		repoProfile := mockrepo.NewMockIProfile(ctrl)
+		repoSession := mockrepo.NewMockISession(ctrl)
+
+		cfg := config.Config{
+			JWT:        config.JWT{ExpireHour: 24, SignedKey: "secretsignkey"},
+			WatchUsers: config.WatchUsers{PollIntervalMillisecond: 500, BatchSize: 100},
+		}
+
+		p := &Profile{
+			cfg:        cfg,
+			guard:      newGuard(cfg, repoSession, repoProfile),
+			repoOutbox: repoOutbox,
+		}
+
+		repoSession.EXPECT().
+			GetSessionByJTI(gomock.Any(), "jtiadmin").
+			Return(entity.Session{ID: 1, UserID: 1, JTI: "jtiadmin"}, nil)
+		repoSession.EXPECT().UpdateSessionLastSeenAt(gomock.Any(), int64(1), gomock.Any()).Return(nil)
+		repoProfile.EXPECT().
+			GetProfileByUserID(gomock.Any(), int64(1)).
+			Return(entity.User{ID: 1, Role: entity.UserRoleAdmin, Status: entity.UserStatusActive}, nil)
+
+		repoOutbox.EXPECT().GetOldestRunningXactID(gomock.Any()).Return(int64(0), nil)
+
+		err := p.WatchUsers(context.Background(), gouser.ReqWatchUsers{UserJWT: auth.GenerateUserJWTToken(1, "jtiadmin", cfg)}, func(string) error {
+			return assert.AnError
+		}, func(gouser.UserEvent) error {
+			return nil
+		})
+
+		require.Error(t, err)
+		require.ErrorIs(t, err, assert.AnError)
+	})
 }
@@ -0,0 +1,195 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Hidayathamir/go-user/config"
+	"github.com/Hidayathamir/go-user/internal/repo"
+	"github.com/Hidayathamir/go-user/internal/repo/db/entity"
+	"github.com/Hidayathamir/go-user/pkg/gouser"
+)
+
+//go:generate mockgen -source=session.go -destination=mockusecase/session.go -package=mockusecase
+
+// ISession contains abstraction of usecase session.
+type ISession interface {
+	// GetMySessions return active sessions of the user who own the JWT.
+	GetMySessions(ctx context.Context, req gouser.ReqGetMySessions) (gouser.ResGetSessions, error)
+	// RevokeMySession revoke one session of the user who own the JWT.
+	RevokeMySession(ctx context.Context, req gouser.ReqRevokeMySession) error
+	// GetSessionsByUserID return active sessions of any user, admin only.
+	GetSessionsByUserID(ctx context.Context, req gouser.ReqGetSessionsByUserID) (gouser.ResGetSessions, error)
+	// RevokeSessionByID revoke any user session, admin only.
+	RevokeSessionByID(ctx context.Context, req gouser.ReqRevokeSessionByID) error
+}
+
+// Session implement ISession.
+type Session struct {
+	cfg         config.Config
+	guard       *guard
+	repoSession repo.ISession
+	auditor     *auditor
+	transactor  repo.ITransactor
+}
+
+var _ ISession = &Session{}
+
+// NewSession return *Session which implement ISession.
+func NewSession(cfg config.Config, repoSession repo.ISession, repoProfile repo.IProfile, repoAuditLog repo.IAuditLog, transactor repo.ITransactor) *Session {
+	return &Session{
+		cfg:         cfg,
+		guard:       newGuard(cfg, repoSession, repoProfile),
+		repoSession: repoSession,
+		auditor:     newAuditor(cfg, repoAuditLog),
+		transactor:  transactor,
+	}
+}
+
+// GetMySessions return active sessions of the user who own the JWT.
+func (s *Session) GetMySessions(ctx context.Context, req gouser.ReqGetMySessions) (gouser.ResGetSessions, error) {
+	err := req.Validate()
+	if err != nil {
+		err := fmt.Errorf("ReqGetMySessions.Validate: %w", err)
+		return gouser.ResGetSessions{}, fmt.Errorf("%w: %w", gouser.ErrRequestInvalid, err)
+	}
+
+	claims, err := s.guard.authenticate(ctx, req.UserJWT)
+	if err != nil {
+		return gouser.ResGetSessions{}, fmt.Errorf("Session.guard.authenticate: %w", err)
+	}
+
+	sessions, err := s.repoSession.GetActiveSessionsByUserID(ctx, claims.UserID)
+	if err != nil {
+		return gouser.ResGetSessions{}, fmt.Errorf("Session.repoSession.GetActiveSessionsByUserID: %w", err)
+	}
+
+	res := gouser.ResGetSessions{}
+	res = res.LoadEntitySessions(sessions, claims.JTI)
+
+	return res, nil
+}
+
+// RevokeMySession revoke one session of the user who own the JWT. Failure
+// after authentication is audited too.
+func (s *Session) RevokeMySession(ctx context.Context, req gouser.ReqRevokeMySession) error {
+	err := req.Validate()
+	if err != nil {
+		err := fmt.Errorf("ReqRevokeMySession.Validate: %w", err)
+		return fmt.Errorf("%w: %w", gouser.ErrRequestInvalid, err)
+	}
+
+	claims, err := s.guard.authenticate(ctx, req.UserJWT)
+	if err != nil {
+		return fmt.Errorf("Session.guard.authenticate: %w", err)
+	}
+
+	auditLog := entity.AuditLog{
+		ActorUserID:  auditUserID(claims.UserID),
+		TargetUserID: auditUserID(claims.UserID),
+		Action:       entity.AuditActionSessionRevoke,
+		Detail:       fmt.Sprintf("session id %d", req.SessionID),
+		IP:           req.IP,
+		UserAgent:    req.UserAgent,
+		RequestID:    req.RequestID,
+	}
+
+	err = s.revokeSession(ctx, req.SessionID, claims.UserID, auditLog)
+	if err != nil {
+		s.auditor.record(ctx, auditLog, err)
+		return fmt.Errorf("Session.revokeSession: %w", err)
+	}
+
+	return nil
+}
+
+// GetSessionsByUserID return active sessions of any user, admin only.
+func (s *Session) GetSessionsByUserID(ctx context.Context, req gouser.ReqGetSessionsByUserID) (gouser.ResGetSessions, error) {
+	err := req.Validate()
+	if err != nil {
+		err := fmt.Errorf("ReqGetSessionsByUserID.Validate: %w", err)
+		return gouser.ResGetSessions{}, fmt.Errorf("%w: %w", gouser.ErrRequestInvalid, err)
+	}
+
+	claims, err := s.guard.authenticateAdmin(ctx, req.UserJWT)
+	if err != nil {
+		return gouser.ResGetSessions{}, fmt.Errorf("Session.guard.authenticateAdmin: %w", err)
+	}
+
+	sessions, err := s.repoSession.GetActiveSessionsByUserID(ctx, req.UserID)
+	if err != nil {
+		return gouser.ResGetSessions{}, fmt.Errorf("Session.repoSession.GetActiveSessionsByUserID: %w", err)
+	}
+
+	res := gouser.ResGetSessions{}
+	res = res.LoadEntitySessions(sessions, claims.JTI)
+
+	return res, nil
+}
+
+// RevokeSessionByID revoke any user session, admin only. Failure after the
+// admin is authenticated is audited too.
+func (s *Session) RevokeSessionByID(ctx context.Context, req gouser.ReqRevokeSessionByID) error {
+	err := req.Validate()
+	if err != nil {
+		err := fmt.Errorf("ReqRevokeSessionByID.Validate: %w", err)
+		return fmt.Errorf("%w: %w", gouser.ErrRequestInvalid, err)
+	}
+
+	claims, err := s.guard.authenticateAdmin(ctx, req.UserJWT)
+	if err != nil {
+		return fmt.Errorf("Session.guard.authenticateAdmin: %w", err)
+	}
+
+	auditLog := entity.AuditLog{
+		ActorUserID: auditUserID(claims.UserID),
+		Action:      entity.AuditActionSessionRevoke,
+		Detail:      fmt.Sprintf("session id %d", req.SessionID),
+		IP:          req.IP,
+		UserAgent:   req.UserAgent,
+		RequestID:   req.RequestID,
+	}
+
+	err = s.revokeSession(ctx, req.SessionID, 0, auditLog)
+	if err != nil {
+		s.auditor.record(ctx, auditLog, err)
+		return fmt.Errorf("Session.revokeSession: %w", err)
+	}
+
+	return nil
+}
+
+// revokeSession revoke the session, ownerUserID other than 0 requires the
+// session belongs to that user. auditLog is appended with the session owner as
+// target in the transaction which revoke the session.
+func (s *Session) revokeSession(ctx context.Context, sessionID int64, ownerUserID int64, auditLog entity.AuditLog) error {
+	session, err := s.repoSession.GetSessionByID(ctx, sessionID)
+	if err != nil {
+		return fmt.Errorf("Session.repoSession.GetSessionByID: %w", err)
+	}
+
+	if ownerUserID != 0 && session.UserID != ownerUserID {
+		return fmt.Errorf("session does not belong to user: %w", gouser.ErrUnknownSession)
+	}
+
+	auditLog.TargetUserID = auditUserID(session.UserID)
+
+	err = s.transactor.WithinTx(ctx, func(ctx context.Context) error {
+		err := s.repoSession.RevokeSessionByID(ctx, session.ID)
+		if err != nil {
+			return fmt.Errorf("Session.repoSession.RevokeSessionByID: %w", err)
+		}
+
+		err = s.auditor.recordWithinTx(ctx, auditLog)
+		if err != nil {
+			return fmt.Errorf("Session.auditor.recordWithinTx: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("Session.transactor.WithinTx: %w", err)
+	}
+
+	return nil
+}
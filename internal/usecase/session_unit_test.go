@@ -0,0 +1,385 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Hidayathamir/go-user/config"
+	"github.com/Hidayathamir/go-user/internal/pkg/auth"
+	"github.com/Hidayathamir/go-user/internal/repo/db/entity"
+	"github.com/Hidayathamir/go-user/internal/repo/mockrepo"
+	"github.com/Hidayathamir/go-user/pkg/gouser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUnitSessionGetMySessions(t *testing.T) {
+	t.Parallel()
+
+	t.Run("get my sessions success", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoSession := mockrepo.NewMockISession(ctrl)
+		repoProfile := mockrepo.NewMockIProfile(ctrl)
+
+		cfg := config.Config{
+			JWT: config.JWT{ExpireHour: 24, SignedKey: "secretsignkey"},
+		}
+
+		s := &Session{
+			cfg:         cfg,
+			guard:       newGuard(cfg, repoSession, repoProfile),
+			repoSession: repoSession,
+		}
+
+		repoSession.EXPECT().
+			GetSessionByJTI(gomock.Any(), "jti1").
+			Return(entity.Session{ID: 1, UserID: 44, JTI: "jti1"}, nil)
+		repoSession.EXPECT().UpdateSessionLastSeenAt(gomock.Any(), int64(1), gomock.Any()).Return(nil)
+		repoProfile.EXPECT().
+			GetProfileByUserID(gomock.Any(), int64(44)).
+			Return(entity.User{ID: 44, Status: entity.UserStatusActive}, nil)
+		repoSession.EXPECT().
+			GetActiveSessionsByUserID(gomock.Any(), int64(44)).
+			Return([]entity.Session{
+				{ID: 1, UserID: 44, JTI: "jti1", UserAgent: "Mozilla/5.0"},
+				{ID: 2, UserID: 44, JTI: "jti2", UserAgent: "curl/8.0"},
+			}, nil)
+
+		res, err := s.GetMySessions(context.Background(), gouser.ReqGetMySessions{
+			UserJWT: auth.GenerateUserJWTToken(44, "jti1", cfg),
+		})
+
+		require.NoError(t, err)
+		require.Len(t, res.Sessions, 2)
+		assert.Equal(t, int64(1), res.Sessions[0].ID)
+		assert.True(t, res.Sessions[0].IsCurrent)
+		assert.Equal(t, "curl/8.0", res.Sessions[1].UserAgent)
+		assert.False(t, res.Sessions[1].IsCurrent)
+	})
+	t.Run("session unknown should return error", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoSession := mockrepo.NewMockISession(ctrl)
+		repoProfile := mockrepo.NewMockIProfile(ctrl)
+
+		cfg := config.Config{
+			JWT: config.JWT{ExpireHour: 24, SignedKey: "secretsignkey"},
+		}
+
+		s := &Session{
+			cfg:         cfg,
+			guard:       newGuard(cfg, repoSession, repoProfile),
+			repoSession: repoSession,
+		}
+
+		repoSession.EXPECT().
+			GetSessionByJTI(gomock.Any(), "jti1").
+			Return(entity.Session{}, gouser.ErrUnknownSession)
+
+		res, err := s.GetMySessions(context.Background(), gouser.ReqGetMySessions{
+			UserJWT: auth.GenerateUserJWTToken(44, "jti1", cfg),
+		})
+
+		assert.Empty(t, res)
+		require.Error(t, err)
+		require.ErrorIs(t, err, gouser.ErrJWTAuth)
+		require.ErrorIs(t, err, gouser.ErrUnknownSession)
+	})
+	t.Run("request validate error should return error", func(t *testing.T) {
+		t.Parallel()
+
+		s := &Session{cfg: config.Config{}}
+
+		res, err := s.GetMySessions(context.Background(), gouser.ReqGetMySessions{UserJWT: ""})
+
+		assert.Empty(t, res)
+		require.Error(t, err)
+		require.ErrorIs(t, err, gouser.ErrRequestInvalid)
+	})
+}
+
+func TestUnitSessionRevokeMySession(t *testing.T) {
+	t.Parallel()
+
+	t.Run("revoke my session success", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoSession := mockrepo.NewMockISession(ctrl)
+		repoProfile := mockrepo.NewMockIProfile(ctrl)
+		repoAuditLog := mockrepo.NewMockIAuditLog(ctrl)
+		transactor := mockrepo.NewMockITransactor(ctrl)
+
+		cfg := config.Config{
+			JWT: config.JWT{ExpireHour: 24, SignedKey: "secretsignkey"},
+		}
+
+		s := &Session{
+			cfg:         cfg,
+			guard:       newGuard(cfg, repoSession, repoProfile),
+			repoSession: repoSession,
+			auditor:     newAuditor(cfg, repoAuditLog),
+			transactor:  transactor,
+		}
+
+		repoSession.EXPECT().
+			GetSessionByJTI(gomock.Any(), "jti1").
+			Return(entity.Session{ID: 1, UserID: 44, JTI: "jti1"}, nil)
+		repoSession.EXPECT().UpdateSessionLastSeenAt(gomock.Any(), int64(1), gomock.Any()).Return(nil)
+		repoProfile.EXPECT().
+			GetProfileByUserID(gomock.Any(), int64(44)).
+			Return(entity.User{ID: 44, Status: entity.UserStatusActive}, nil)
+		repoSession.EXPECT().
+			GetSessionByID(gomock.Any(), int64(2)).
+			Return(entity.Session{ID: 2, UserID: 44, JTI: "jti2"}, nil)
+		repoSession.EXPECT().RevokeSessionByID(gomock.Any(), int64(2)).Return(nil)
+
+		transactor.EXPECT().
+			WithinTx(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+				return fn(ctx)
+			})
+
+		var auditLog entity.AuditLog
+		repoAuditLog.EXPECT().
+			CreateAuditLog(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, a entity.AuditLog) error {
+				auditLog = a
+				return nil
+			})
+
+		err := s.RevokeMySession(context.Background(), gouser.ReqRevokeMySession{
+			UserJWT:   auth.GenerateUserJWTToken(44, "jti1", cfg),
+			SessionID: 2,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, entity.AuditActionSessionRevoke, auditLog.Action)
+		assert.Equal(t, entity.AuditResultSuccess, auditLog.Result)
+		require.NotNil(t, auditLog.ActorUserID)
+		assert.Equal(t, int64(44), *auditLog.ActorUserID)
+		require.NotNil(t, auditLog.TargetUserID)
+		assert.Equal(t, int64(44), *auditLog.TargetUserID)
+		assert.Equal(t, "session id 2", auditLog.Detail)
+	})
+	t.Run("revoke session of other user should return error", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoSession := mockrepo.NewMockISession(ctrl)
+		repoProfile := mockrepo.NewMockIProfile(ctrl)
+		repoAuditLog := mockrepo.NewMockIAuditLog(ctrl)
+
+		cfg := config.Config{
+			JWT: config.JWT{ExpireHour: 24, SignedKey: "secretsignkey"},
+		}
+
+		s := &Session{
+			cfg:         cfg,
+			guard:       newGuard(cfg, repoSession, repoProfile),
+			repoSession: repoSession,
+			auditor:     newAuditor(cfg, repoAuditLog),
+		}
+
+		repoSession.EXPECT().
+			GetSessionByJTI(gomock.Any(), "jti1").
+			Return(entity.Session{ID: 1, UserID: 44, JTI: "jti1"}, nil)
+		repoSession.EXPECT().UpdateSessionLastSeenAt(gomock.Any(), int64(1), gomock.Any()).Return(nil)
+		repoProfile.EXPECT().
+			GetProfileByUserID(gomock.Any(), int64(44)).
+			Return(entity.User{ID: 44, Status: entity.UserStatusActive}, nil)
+		repoSession.EXPECT().
+			GetSessionByID(gomock.Any(), int64(9)).
+			Return(entity.Session{ID: 9, UserID: 45, JTI: "jti9"}, nil)
+
+		var auditLog entity.AuditLog
+		repoAuditLog.EXPECT().
+			CreateAuditLog(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, a entity.AuditLog) error {
+				auditLog = a
+				return nil
+			})
+
+		err := s.RevokeMySession(context.Background(), gouser.ReqRevokeMySession{
+			UserJWT:   auth.GenerateUserJWTToken(44, "jti1", cfg),
+			SessionID: 9,
+		})
+
+		require.Error(t, err)
+		require.ErrorIs(t, err, gouser.ErrUnknownSession)
+		assert.Equal(t, entity.AuditActionSessionRevoke, auditLog.Action)
+		assert.Equal(t, entity.AuditResultFailure, auditLog.Result)
+		require.NotNil(t, auditLog.ActorUserID)
+		assert.Equal(t, int64(44), *auditLog.ActorUserID)
+	})
+}
+
+func TestUnitSessionGetSessionsByUserID(t *testing.T) {
+	t.Parallel()
+
+	t.Run("admin get sessions by user id success", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoSession := mockrepo.NewMockISession(ctrl)
+		repoProfile := mockrepo.NewMockIProfile(ctrl)
+
+		cfg := config.Config{
+			JWT: config.JWT{ExpireHour: 24, SignedKey: "secretsignkey"},
+		}
+
+		s := &Session{
+			cfg:         cfg,
+			guard:       newGuard(cfg, repoSession, repoProfile),
+			repoSession: repoSession,
+		}
+
+		repoSession.EXPECT().
+			GetSessionByJTI(gomock.Any(), "jtiadmin").
+			Return(entity.Session{ID: 1, UserID: 1, JTI: "jtiadmin"}, nil)
+		repoSession.EXPECT().UpdateSessionLastSeenAt(gomock.Any(), int64(1), gomock.Any()).Return(nil)
+		repoProfile.EXPECT().
+			GetProfileByUserID(gomock.Any(), int64(1)).
+			Return(entity.User{ID: 1, Role: entity.UserRoleAdmin, Status: entity.UserStatusActive}, nil)
+		repoSession.EXPECT().
+			GetActiveSessionsByUserID(gomock.Any(), int64(44)).
+			Return([]entity.Session{{ID: 2, UserID: 44, JTI: "jti2"}}, nil)
+
+		res, err := s.GetSessionsByUserID(context.Background(), gouser.ReqGetSessionsByUserID{
+			UserJWT: auth.GenerateUserJWTToken(1, "jtiadmin", cfg),
+			UserID:  44,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, res.Sessions, 1)
+		assert.Equal(t, int64(2), res.Sessions[0].ID)
+		assert.False(t, res.Sessions[0].IsCurrent)
+	})
+	t.Run("non admin should return error", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoSession := mockrepo.NewMockISession(ctrl)
+		repoProfile := mockrepo.NewMockIProfile(ctrl)
+
+		cfg := config.Config{
+			JWT: config.JWT{ExpireHour: 24, SignedKey: "secretsignkey"},
+		}
+
+		s := &Session{
+			cfg:         cfg,
+			guard:       newGuard(cfg, repoSession, repoProfile),
+			repoSession: repoSession,
+		}
+
+		repoSession.EXPECT().
+			GetSessionByJTI(gomock.Any(), "jti1").
+			Return(entity.Session{ID: 1, UserID: 44, JTI: "jti1"}, nil)
+		repoSession.EXPECT().UpdateSessionLastSeenAt(gomock.Any(), int64(1), gomock.Any()).Return(nil)
+		repoProfile.EXPECT().
+			GetProfileByUserID(gomock.Any(), int64(44)).
+			Return(entity.User{ID: 44, Role: entity.UserRoleUser, Status: entity.UserStatusActive}, nil)
+
+		res, err := s.GetSessionsByUserID(context.Background(), gouser.ReqGetSessionsByUserID{
+			UserJWT: auth.GenerateUserJWTToken(44, "jti1", cfg),
+			UserID:  45,
+		})
+
+		assert.Empty(t, res)
+		require.Error(t, err)
+		require.ErrorIs(t, err, gouser.ErrForbidden)
+	})
+}
+
+func TestUnitSessionRevokeSessionByID(t *testing.T) {
+	t.Parallel()
+
+	t.Run("admin revoke session success", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoSession := mockrepo.NewMockISession(ctrl)
+		repoProfile := mockrepo.NewMockIProfile(ctrl)
+		repoAuditLog := mockrepo.NewMockIAuditLog(ctrl)
+		transactor := mockrepo.NewMockITransactor(ctrl)
+
+		cfg := config.Config{
+			JWT: config.JWT{ExpireHour: 24, SignedKey: "secretsignkey"},
+		}
+
+		s := &Session{
+			cfg:         cfg,
+			guard:       newGuard(cfg, repoSession, repoProfile),
+			repoSession: repoSession,
+			auditor:     newAuditor(cfg, repoAuditLog),
+			transactor:  transactor,
+		}
+
+		repoSession.EXPECT().
+			GetSessionByJTI(gomock.Any(), "jtiadmin").
+			Return(entity.Session{ID: 1, UserID: 1, JTI: "jtiadmin"}, nil)
+		repoSession.EXPECT().UpdateSessionLastSeenAt(gomock.Any(), int64(1), gomock.Any()).Return(nil)
+		repoProfile.EXPECT().
+			GetProfileByUserID(gomock.Any(), int64(1)).
+			Return(entity.User{ID: 1, Role: entity.UserRoleAdmin, Status: entity.UserStatusActive}, nil)
+		repoSession.EXPECT().
+			GetSessionByID(gomock.Any(), int64(9)).
+			Return(entity.Session{ID: 9, UserID: 45, JTI: "jti9"}, nil)
+		repoSession.EXPECT().RevokeSessionByID(gomock.Any(), int64(9)).Return(nil)
+
+		transactor.EXPECT().
+			WithinTx(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+				return fn(ctx)
+			})
+
+		var auditLog entity.AuditLog
+		repoAuditLog.EXPECT().
+			CreateAuditLog(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, a entity.AuditLog) error {
+				auditLog = a
+				return nil
+			})
+
+		err := s.RevokeSessionByID(context.Background(), gouser.ReqRevokeSessionByID{
+			UserJWT:   auth.GenerateUserJWTToken(1, "jtiadmin", cfg),
+			SessionID: 9,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, entity.AuditActionSessionRevoke, auditLog.Action)
+		assert.Equal(t, entity.AuditResultSuccess, auditLog.Result)
+		require.NotNil(t, auditLog.ActorUserID)
+		assert.Equal(t, int64(1), *auditLog.ActorUserID)
+		require.NotNil(t, auditLog.TargetUserID)
+		assert.Equal(t, int64(45), *auditLog.TargetUserID)
+	})
+	t.Run("request validate error should return error", func(t *testing.T) {
+		t.Parallel()
+
+		s := &Session{cfg: config.Config{}}
+
+		err := s.RevokeSessionByID(context.Background(), gouser.ReqRevokeSessionByID{UserJWT: "Bearer x"})
+
+		require.Error(t, err)
+		require.ErrorIs(t, err, gouser.ErrRequestInvalid)
+	})
+}
@@ -0,0 +1,104 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/Hidayathamir/go-user/internal/pkg/tracing"
+	"github.com/Hidayathamir/go-user/pkg/gouser"
+)
+
+// AuthTracing implement IAuth, it wraps IAuth with span per method.
+type AuthTracing struct {
+	next IAuth
+}
+
+var _ IAuth = &AuthTracing{}
+
+// NewAuthTracing return *AuthTracing which implement IAuth.
+func NewAuthTracing(next IAuth) *AuthTracing {
+	return &AuthTracing{next: next}
+}
+
+// RegisterUser implements IAuth.
+func (a *AuthTracing) RegisterUser(ctx context.Context, req gouser.ReqRegisterUser) (gouser.ResRegisterUser, error) {
+	ctx, span := tracing.Start(ctx, "usecase.Auth.RegisterUser")
+	res, err := a.next.RegisterUser(ctx, req)
+	tracing.End(span, err)
+	return res, err
+}
+
+// LoginUser implements IAuth.
+func (a *AuthTracing) LoginUser(ctx context.Context, req gouser.ReqLoginUser) (gouser.ResLoginUser, error) {
+	ctx, span := tracing.Start(ctx, "usecase.Auth.LoginUser")
+	res, err := a.next.LoginUser(ctx, req)
+	tracing.End(span, err)
+	return res, err
+}
+
+// ProfileTracing implement IProfile, it wraps IProfile with span per method.
+type ProfileTracing struct {
+	next IProfile
+}
+
+var _ IProfile = &ProfileTracing{}
+
+// NewProfileTracing return *ProfileTracing which implement IProfile.
+func NewProfileTracing(next IProfile) *ProfileTracing {
+	return &ProfileTracing{next: next}
+}
+
+// GetProfileByUsername implements IProfile.
+func (p *ProfileTracing) GetProfileByUsername(ctx context.Context, req gouser.ReqGetProfileByUsername) (gouser.ResGetProfileByUsername, error) {
+	ctx, span := tracing.Start(ctx, "usecase.Profile.GetProfileByUsername")
+	res, err := p.next.GetProfileByUsername(ctx, req)
+	tracing.End(span, err)
+	return res, err
+}
+
+// GetProfileByUserID implements IProfile.
+func (p *ProfileTracing) GetProfileByUserID(ctx context.Context, req gouser.ReqGetProfileByUserID) (gouser.ResGetProfileByUsername, error) {
+	ctx, span := tracing.Start(ctx, "usecase.Profile.GetProfileByUserID")
+	res, err := p.next.GetProfileByUserID(ctx, req)
+	tracing.End(span, err)
+	return res, err
+}
+
+// GetMyProfile implements IProfile.
+func (p *ProfileTracing) GetMyProfile(ctx context.Context, req gouser.ReqGetMyProfile) (gouser.ResGetMyProfile, error) {
+	ctx, span := tracing.Start(ctx, "usecase.Profile.GetMyProfile")
+	res, err := p.next.GetMyProfile(ctx, req)
+	tracing.End(span, err)
+	return res, err
+}
+
+// UpdateProfileByUserID implements IProfile.
+func (p *ProfileTracing) UpdateProfileByUserID(ctx context.Context, req gouser.ReqUpdateProfileByUserID) error {
+	ctx, span := tracing.Start(ctx, "usecase.Profile.UpdateProfileByUserID")
+	err := p.next.UpdateProfileByUserID(ctx, req)
+	tracing.End(span, err)
+	return err
+}
+
+// ListUsers implements IProfile.
+func (p *ProfileTracing) ListUsers(ctx context.Context, req gouser.ReqListUsers) (gouser.ResListUsers, error) {
+	ctx, span := tracing.Start(ctx, "usecase.Profile.ListUsers")
+	res, err := p.next.ListUsers(ctx, req)
+	tracing.End(span, err)
+	return res, err
+}
+
+// BatchGetProfiles implements IProfile.
+func (p *ProfileTracing) BatchGetProfiles(ctx context.Context, req gouser.ReqBatchGetProfiles) (gouser.ResBatchGetProfiles, error) {
+	ctx, span := tracing.Start(ctx, "usecase.Profile.BatchGetProfiles")
+	res, err := p.next.BatchGetProfiles(ctx, req)
+	tracing.End(span, err)
+	return res, err
+}
+
+// WatchUsers implements IProfile, the span lasts the watch lifetime.
+func (p *ProfileTracing) WatchUsers(ctx context.Context, req gouser.ReqWatchUsers, started func(cursor string) error, send func(gouser.UserEvent) error) error {
+	ctx, span := tracing.Start(ctx, "usecase.Profile.WatchUsers")
+	err := p.next.WatchUsers(ctx, req, started, send)
+	tracing.End(span, err)
+	return err
+}
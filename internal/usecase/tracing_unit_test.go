@@ -0,0 +1,109 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Hidayathamir/go-user/internal/pkg/tracing"
+	"github.com/Hidayathamir/go-user/internal/pkg/tracing/tracingtest"
+	"github.com/Hidayathamir/go-user/internal/usecase/mockusecase"
+	"github.com/Hidayathamir/go-user/pkg/gouser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUnitAuthTracingLoginUser(t *testing.T) {
+	t.Parallel()
+
+	tracingtest.Setup()
+
+	t.Run("login user should be traced as child of caller span", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		next := mockusecase.NewMockIAuth(ctrl)
+		a := NewAuthTracing(next)
+
+		ctx, root := tracing.Start(context.Background(), t.Name())
+
+		next.EXPECT().
+			LoginUser(gomock.Any(), gouser.ReqLoginUser{Username: "hidayat"}).
+			DoAndReturn(func(ctx context.Context, _ gouser.ReqLoginUser) (gouser.ResLoginUser, error) {
+				assert.NotEqual(t, root.SpanContext().SpanID(), trace.SpanContextFromContext(ctx).SpanID())
+				return gouser.ResLoginUser{UserJWT: "jwt"}, nil
+			})
+
+		res, err := a.LoginUser(ctx, gouser.ReqLoginUser{Username: "hidayat"})
+		root.End()
+
+		require.NoError(t, err)
+		assert.Equal(t, "jwt", res.UserJWT)
+
+		span, ok := tracingtest.SpanByName(root.SpanContext().TraceID(), "usecase.Auth.LoginUser")
+		require.True(t, ok)
+		assert.Equal(t, root.SpanContext().SpanID(), span.Parent.SpanID())
+		assert.Equal(t, codes.Unset, span.Status.Code)
+	})
+	t.Run("login user error should be recorded on span", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		next := mockusecase.NewMockIAuth(ctrl)
+		a := NewAuthTracing(next)
+
+		ctx, root := tracing.Start(context.Background(), t.Name())
+
+		next.EXPECT().
+			LoginUser(gomock.Any(), gomock.Any()).
+			Return(gouser.ResLoginUser{}, gouser.ErrWrongPassword)
+
+		_, err := a.LoginUser(ctx, gouser.ReqLoginUser{})
+		root.End()
+
+		require.ErrorIs(t, err, gouser.ErrWrongPassword)
+
+		span, ok := tracingtest.SpanByName(root.SpanContext().TraceID(), "usecase.Auth.LoginUser")
+		require.True(t, ok)
+		assert.Equal(t, codes.Error, span.Status.Code)
+		assert.Equal(t, gouser.ErrWrongPassword.Error(), span.Status.Description)
+	})
+}
+
+func TestUnitProfileTracingUpdateProfileByUserID(t *testing.T) {
+	t.Parallel()
+
+	tracingtest.Setup()
+
+	t.Run("update profile error should be recorded on span", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		next := mockusecase.NewMockIProfile(ctrl)
+		p := NewProfileTracing(next)
+
+		ctx, root := tracing.Start(context.Background(), t.Name())
+
+		next.EXPECT().
+			UpdateProfileByUserID(gomock.Any(), gomock.Any()).
+			Return(assert.AnError)
+
+		err := p.UpdateProfileByUserID(ctx, gouser.ReqUpdateProfileByUserID{})
+		root.End()
+
+		require.ErrorIs(t, err, assert.AnError)
+
+		span, ok := tracingtest.SpanByName(root.SpanContext().TraceID(), "usecase.Profile.UpdateProfileByUserID")
+		require.True(t, ok)
+		assert.Equal(t, root.SpanContext().SpanID(), span.Parent.SpanID())
+		assert.Equal(t, codes.Error, span.Status.Code)
+	})
+}
@@ -0,0 +1,66 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/Hidayathamir/go-user/config"
+	"github.com/Hidayathamir/go-user/internal/repo"
+	"github.com/Hidayathamir/go-user/pkg/gouser"
+)
+
+// lockUsernames lock usernames until the transaction bound to ctx ends. Locks
+// are taken in sorted order so two transactions locking the same usernames do
+// not deadlock.
+func lockUsernames(ctx context.Context, repoAccount repo.IAccount, usernames ...string) error {
+	usernames = slices.Clone(usernames)
+	slices.Sort(usernames)
+	usernames = slices.Compact(usernames)
+
+	for _, username := range usernames {
+		err := repoAccount.LockUsername(ctx, username)
+		if err != nil {
+			return fmt.Errorf("repo.IAccount.LockUsername: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// checkUsernameClaimable return gouser.ErrDuplicateUsername when username is
+// held for another user, either by a deleted user which is not purged yet or
+// as old username within history grace period. userID is the user who claims
+// the username, 0 for a new user. Uniqueness of current usernames is left to
+// the database. It should be called after username is locked with
+// lockUsernames, within the same transaction which claims the username.
+func checkUsernameClaimable(ctx context.Context, cfg config.Config, repoAccount repo.IAccount, repoProfile repo.IProfile, username string, userID int64) error {
+	if cfg.Account.HoldDeletedUsername {
+		isHeld, err := repoAccount.IsUsernameHeldByDeletedUser(ctx, username)
+		if err != nil {
+			return fmt.Errorf("repo.IAccount.IsUsernameHeldByDeletedUser: %w", err)
+		}
+		if isHeld {
+			return fmt.Errorf("%w: username is held by deleted user", gouser.ErrDuplicateUsername)
+		}
+	}
+
+	grace := getUsernameHistoryGrace(cfg)
+	if grace > 0 {
+		owner, err := repoProfile.GetProfileByOldUsername(ctx, username, time.Now().Add(-grace))
+		if err != nil && !errors.Is(err, gouser.ErrUnknownUsername) {
+			return fmt.Errorf("repo.IProfile.GetProfileByOldUsername: %w", err)
+		}
+		if err == nil && owner.ID != userID {
+			return fmt.Errorf("%w: username is held as old username of other user", gouser.ErrDuplicateUsername)
+		}
+	}
+
+	return nil
+}
+
+func getUsernameHistoryGrace(cfg config.Config) time.Duration {
+	return time.Duration(cfg.Username.HistoryGraceHour) * time.Hour
+}
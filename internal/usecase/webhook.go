@@ -0,0 +1,349 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Hidayathamir/go-user/config"
+	"github.com/Hidayathamir/go-user/internal/pkg/logger"
+	"github.com/Hidayathamir/go-user/internal/pkg/webhook"
+	"github.com/Hidayathamir/go-user/internal/repo"
+	"github.com/Hidayathamir/go-user/internal/repo/db/entity"
+	"github.com/Hidayathamir/go-user/pkg/gouser"
+)
+
+//go:generate mockgen -source=webhook.go -destination=mockusecase/webhook.go -package=mockusecase
+
+// IWebhook contains abstraction of usecase outgoing webhook.
+type IWebhook interface {
+	// CreateWebhookSubscription create webhook subscription, admin only.
+	CreateWebhookSubscription(ctx context.Context, req gouser.ReqCreateWebhookSubscription) (gouser.ResCreateWebhookSubscription, error)
+	// GetWebhookSubscriptions return all webhook subscriptions, admin only.
+	GetWebhookSubscriptions(ctx context.Context, req gouser.ReqGetWebhookSubscriptions) (gouser.ResGetWebhookSubscriptions, error)
+	// UpdateWebhookSubscription update webhook subscription, admin only.
+	UpdateWebhookSubscription(ctx context.Context, req gouser.ReqUpdateWebhookSubscription) error
+	// DeleteWebhookSubscription delete webhook subscription and its
+	// deliveries, admin only.
+	DeleteWebhookSubscription(ctx context.Context, req gouser.ReqDeleteWebhookSubscription) error
+	// GetWebhookDeliveries return the latest deliveries of webhook
+	// subscription with their attempts, admin only.
+	GetWebhookDeliveries(ctx context.Context, req gouser.ReqGetWebhookDeliveries) (gouser.ResGetWebhookDeliveries, error)
+	// RedeliverWebhook queue delivery to be sent again, admin only.
+	RedeliverWebhook(ctx context.Context, req gouser.ReqRedeliverWebhook) error
+	// DeliverWebhooks send pending deliveries, return number of succeeded
+	// deliveries.
+	DeliverWebhooks(ctx context.Context) (int64, error)
+}
+
+// Webhook implement IWebhook.
+type Webhook struct {
+	cfg         config.Config
+	guard       *guard
+	repoWebhook repo.IWebhook
+	transactor  repo.ITransactor
+	sender      webhook.ISender
+}
+
+var _ IWebhook = &Webhook{}
+
+// NewWebhook return *Webhook which implement IWebhook.
+func NewWebhook(cfg config.Config, repoWebhook repo.IWebhook, repoSession repo.ISession, repoProfile repo.IProfile, transactor repo.ITransactor, sender webhook.ISender) *Webhook {
+	return &Webhook{
+		cfg:         cfg,
+		guard:       newGuard(cfg, repoSession, repoProfile),
+		repoWebhook: repoWebhook,
+		transactor:  transactor,
+		sender:      sender,
+	}
+}
+
+// CreateWebhookSubscription create webhook subscription, admin only.
+func (w *Webhook) CreateWebhookSubscription(ctx context.Context, req gouser.ReqCreateWebhookSubscription) (gouser.ResCreateWebhookSubscription, error) {
+	err := req.Validate()
+	if err != nil {
+		err := fmt.Errorf("ReqCreateWebhookSubscription.Validate: %w", err)
+		return gouser.ResCreateWebhookSubscription{}, fmt.Errorf("%w: %w", gouser.ErrRequestInvalid, err)
+	}
+
+	_, err = w.guard.authenticateAdmin(ctx, req.UserJWT)
+	if err != nil {
+		return gouser.ResCreateWebhookSubscription{}, fmt.Errorf("Webhook.guard.authenticateAdmin: %w", err)
+	}
+
+	subscriptionID, err := w.repoWebhook.CreateSubscription(ctx, req.ToEntityWebhookSubscription())
+	if err != nil {
+		return gouser.ResCreateWebhookSubscription{}, fmt.Errorf("Webhook.repoWebhook.CreateSubscription: %w", err)
+	}
+
+	return gouser.ResCreateWebhookSubscription{ID: subscriptionID}, nil
+}
+
+// GetWebhookSubscriptions return all webhook subscriptions, admin only.
+func (w *Webhook) GetWebhookSubscriptions(ctx context.Context, req gouser.ReqGetWebhookSubscriptions) (gouser.ResGetWebhookSubscriptions, error) {
+	err := req.Validate()
+	if err != nil {
+		err := fmt.Errorf("ReqGetWebhookSubscriptions.Validate: %w", err)
+		return gouser.ResGetWebhookSubscriptions{}, fmt.Errorf("%w: %w", gouser.ErrRequestInvalid, err)
+	}
+
+	_, err = w.guard.authenticateAdmin(ctx, req.UserJWT)
+	if err != nil {
+		return gouser.ResGetWebhookSubscriptions{}, fmt.Errorf("Webhook.guard.authenticateAdmin: %w", err)
+	}
+
+	subscriptions, err := w.repoWebhook.GetSubscriptions(ctx)
+	if err != nil {
+		return gouser.ResGetWebhookSubscriptions{}, fmt.Errorf("Webhook.repoWebhook.GetSubscriptions: %w", err)
+	}
+
+	res := gouser.ResGetWebhookSubscriptions{}
+	res = res.LoadEntityWebhookSubscriptions(subscriptions)
+
+	return res, nil
+}
+
+// UpdateWebhookSubscription update webhook subscription, admin only.
+func (w *Webhook) UpdateWebhookSubscription(ctx context.Context, req gouser.ReqUpdateWebhookSubscription) error {
+	err := req.Validate()
+	if err != nil {
+		err := fmt.Errorf("ReqUpdateWebhookSubscription.Validate: %w", err)
+		return fmt.Errorf("%w: %w", gouser.ErrRequestInvalid, err)
+	}
+
+	_, err = w.guard.authenticateAdmin(ctx, req.UserJWT)
+	if err != nil {
+		return fmt.Errorf("Webhook.guard.authenticateAdmin: %w", err)
+	}
+
+	err = w.repoWebhook.UpdateSubscription(ctx, req.ToEntityWebhookSubscription())
+	if err != nil {
+		return fmt.Errorf("Webhook.repoWebhook.UpdateSubscription: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteWebhookSubscription delete webhook subscription and its deliveries,
+// admin only.
+func (w *Webhook) DeleteWebhookSubscription(ctx context.Context, req gouser.ReqDeleteWebhookSubscription) error {
+	err := req.Validate()
+	if err != nil {
+		err := fmt.Errorf("ReqDeleteWebhookSubscription.Validate: %w", err)
+		return fmt.Errorf("%w: %w", gouser.ErrRequestInvalid, err)
+	}
+
+	_, err = w.guard.authenticateAdmin(ctx, req.UserJWT)
+	if err != nil {
+		return fmt.Errorf("Webhook.guard.authenticateAdmin: %w", err)
+	}
+
+	err = w.repoWebhook.DeleteSubscription(ctx, req.SubscriptionID)
+	if err != nil {
+		return fmt.Errorf("Webhook.repoWebhook.DeleteSubscription: %w", err)
+	}
+
+	return nil
+}
+
+// GetWebhookDeliveries return the latest deliveries of webhook subscription
+// with their attempts, admin only.
+func (w *Webhook) GetWebhookDeliveries(ctx context.Context, req gouser.ReqGetWebhookDeliveries) (gouser.ResGetWebhookDeliveries, error) {
+	err := req.Validate()
+	if err != nil {
+		err := fmt.Errorf("ReqGetWebhookDeliveries.Validate: %w", err)
+		return gouser.ResGetWebhookDeliveries{}, fmt.Errorf("%w: %w", gouser.ErrRequestInvalid, err)
+	}
+
+	_, err = w.guard.authenticateAdmin(ctx, req.UserJWT)
+	if err != nil {
+		return gouser.ResGetWebhookDeliveries{}, fmt.Errorf("Webhook.guard.authenticateAdmin: %w", err)
+	}
+
+	_, err = w.repoWebhook.GetSubscriptionByID(ctx, req.SubscriptionID)
+	if err != nil {
+		return gouser.ResGetWebhookDeliveries{}, fmt.Errorf("Webhook.repoWebhook.GetSubscriptionByID: %w", err)
+	}
+
+	limit := req.Limit
+	if limit == 0 {
+		limit = gouser.GetWebhookDeliveriesDefaultLimit
+	}
+
+	deliveries, err := w.repoWebhook.GetDeliveriesBySubscriptionID(ctx, req.SubscriptionID, req.Status, uint64(limit))
+	if err != nil {
+		return gouser.ResGetWebhookDeliveries{}, fmt.Errorf("Webhook.repoWebhook.GetDeliveriesBySubscriptionID: %w", err)
+	}
+
+	deliveryIDs := make([]int64, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		deliveryIDs = append(deliveryIDs, delivery.ID)
+	}
+
+	attempts, err := w.repoWebhook.GetDeliveryAttemptsByDeliveryIDs(ctx, deliveryIDs)
+	if err != nil {
+		return gouser.ResGetWebhookDeliveries{}, fmt.Errorf("Webhook.repoWebhook.GetDeliveryAttemptsByDeliveryIDs: %w", err)
+	}
+
+	res := gouser.ResGetWebhookDeliveries{}
+	res = res.LoadEntityWebhookDeliveries(deliveries, attempts)
+
+	return res, nil
+}
+
+// RedeliverWebhook queue delivery to be sent again, admin only. Delivery of
+// any status can be redelivered, attempt is reset so dead delivery gets full
+// retries again.
+func (w *Webhook) RedeliverWebhook(ctx context.Context, req gouser.ReqRedeliverWebhook) error {
+	err := req.Validate()
+	if err != nil {
+		err := fmt.Errorf("ReqRedeliverWebhook.Validate: %w", err)
+		return fmt.Errorf("%w: %w", gouser.ErrRequestInvalid, err)
+	}
+
+	_, err = w.guard.authenticateAdmin(ctx, req.UserJWT)
+	if err != nil {
+		return fmt.Errorf("Webhook.guard.authenticateAdmin: %w", err)
+	}
+
+	err = w.repoWebhook.RedeliverDelivery(ctx, req.DeliveryID, time.Now())
+	if err != nil {
+		return fmt.Errorf("Webhook.repoWebhook.RedeliverDelivery: %w", err)
+	}
+
+	return nil
+}
+
+// DeliverWebhooks send pending deliveries, return number of succeeded
+// deliveries.
+//
+// Deliveries are claimed for cfg.Webhook.LeaseSecond so concurrent worker does
+// not pick them, and are sent without holding transaction or row lock. Worker
+// stops when the claim expires before the batch is done, or when updating a
+// delivery finds the claim taken over by other worker. Delivery whose
+// subscription was deleted or disabled after claiming is skipped. Every attempt
+// is logged. Failed delivery is retried with exponential backoff, after the max
+// attempt it is marked dead and only sent again by RedeliverWebhook.
+func (w *Webhook) DeliverWebhooks(ctx context.Context) (int64, error) {
+	now := time.Now()
+	// Claim is identified by next_attempt_at, truncate it to the precision of
+	// postgres timestamp so it compares equal when read back.
+	leaseUntil := now.Add(time.Duration(w.cfg.Webhook.LeaseSecond) * time.Second).Truncate(time.Microsecond)
+	deliveries, err := w.repoWebhook.ClaimPendingDeliveries(ctx, now, leaseUntil, uint64(w.cfg.Webhook.BatchSize))
+	if err != nil {
+		return 0, fmt.Errorf("Webhook.repoWebhook.ClaimPendingDeliveries: %w", err)
+	}
+
+	if len(deliveries) == 0 {
+		return 0, nil
+	}
+
+	subscriptions, err := w.repoWebhook.GetSubscriptions(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("Webhook.repoWebhook.GetSubscriptions: %w", err)
+	}
+
+	subscriptionByID := make(map[int64]entity.WebhookSubscription, len(subscriptions))
+	for _, subscription := range subscriptions {
+		subscriptionByID[subscription.ID] = subscription
+	}
+
+	var count int64
+	for _, delivery := range deliveries {
+		// Delivery whose claim expired may be claimed by other worker
+		// already, leave the rest to the next run.
+		if time.Now().After(leaseUntil) {
+			break
+		}
+
+		// Subscription deleted or disabled after claiming, its deliveries are
+		// deleted with it or picked again once it is active.
+		subscription, ok := subscriptionByID[delivery.SubscriptionID]
+		if !ok || !subscription.IsActive {
+			logger.FromContext(ctx).
+				WithField("delivery id", delivery.ID).
+				WithField("subscription id", delivery.SubscriptionID).
+				Warn("skip webhook delivery of deleted or inactive subscription")
+			continue
+		}
+
+		isSucceeded, err := w.deliverWebhook(ctx, subscription, delivery, leaseUntil)
+		if errors.Is(err, repo.ErrClaimLost) {
+			logger.FromContext(ctx).WithField("delivery id", delivery.ID).Warnf("Webhook.deliverWebhook: %v", err)
+			break
+		}
+		if err != nil {
+			return count, fmt.Errorf("Webhook.deliverWebhook: %w", err)
+		}
+		if isSucceeded {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+// deliverWebhook send delivery claimed until leaseUntil, then log the attempt
+// and update the delivery in one transaction. Return true if the delivery
+// succeeded.
+func (w *Webhook) deliverWebhook(ctx context.Context, subscription entity.WebhookSubscription, delivery entity.WebhookDelivery, leaseUntil time.Time) (bool, error) {
+	attemptedAt := time.Now()
+	statusCode, errSend := w.sender.Send(ctx, subscription.URL, subscription.Secret, delivery.ID, delivery.EventType, delivery.Payload)
+
+	attempt := entity.WebhookDeliveryAttempt{
+		DeliveryID:  delivery.ID,
+		AttemptedAt: attemptedAt,
+		StatusCode:  statusCode,
+		DurationMS:  time.Since(attemptedAt).Milliseconds(),
+	}
+	if errSend != nil {
+		attempt.Error = errSend.Error()
+	}
+
+	delivery.Attempt++
+
+	switch {
+	case errSend == nil:
+		delivery.Status = entity.WebhookDeliveryStatusSucceeded
+	case delivery.Attempt >= w.cfg.Webhook.MaxAttempt:
+		logger.FromContext(ctx).
+			WithField("delivery id", delivery.ID).
+			WithField("attempt count", delivery.Attempt).
+			Warnf("webhook delivery is dead: Webhook.sender.Send: %v", errSend)
+		delivery.Status = entity.WebhookDeliveryStatusDead
+	default:
+		logger.FromContext(ctx).
+			WithField("delivery id", delivery.ID).
+			WithField("attempt count", delivery.Attempt).
+			Warnf("Webhook.sender.Send: %v", errSend)
+		delivery.NextAttemptAt = time.Now().Add(w.getRetryDelay(delivery.Attempt - 1))
+	}
+
+	err := w.transactor.WithinTx(ctx, func(ctx context.Context) error {
+		err := w.repoWebhook.CreateDeliveryAttempt(ctx, attempt)
+		if err != nil {
+			return fmt.Errorf("Webhook.repoWebhook.CreateDeliveryAttempt: %w", err)
+		}
+
+		err = w.repoWebhook.UpdateDelivery(ctx, delivery, leaseUntil)
+		if err != nil {
+			return fmt.Errorf("Webhook.repoWebhook.UpdateDelivery: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return false, fmt.Errorf("Webhook.transactor.WithinTx: %w", err)
+	}
+
+	return errSend == nil, nil
+}
+
+// getRetryDelay return delay before the next attempt of delivery which failed
+// attempt times before.
+func (w *Webhook) getRetryDelay(attempt int) time.Duration {
+	baseDelay := time.Duration(w.cfg.Webhook.RetryBaseSecond) * time.Second
+	maxDelay := time.Duration(w.cfg.Webhook.RetryMaxSecond) * time.Second
+	return getBackoffDelay(baseDelay, maxDelay, attempt)
+}
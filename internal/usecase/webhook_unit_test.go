@@ -0,0 +1,683 @@
+package usecase
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/Hidayathamir/go-user/config"
+	"github.com/Hidayathamir/go-user/internal/pkg/auth"
+	"github.com/Hidayathamir/go-user/internal/pkg/webhook"
+	"github.com/Hidayathamir/go-user/internal/repo"
+	"github.com/Hidayathamir/go-user/internal/repo/db/entity"
+	"github.com/Hidayathamir/go-user/internal/repo/mockrepo"
+	"github.com/Hidayathamir/go-user/pkg/gouser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUnitWebhookCreateWebhookSubscription(t *testing.T) {
+	t.Parallel()
+
+	t.Run("admin create subscription success", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoWebhook := mockrepo.NewMockIWebhook(ctrl)
+		repoSession := mockrepo.NewMockISession(ctrl)
+		repoProfile := mockrepo.NewMockIProfile(ctrl)
+
+		cfg := config.Config{
+			JWT: config.JWT{ExpireHour: 24, SignedKey: "secretsignkey"},
+		}
+
+		w := &Webhook{
+			cfg:         cfg,
+			guard:       newGuard(cfg, repoSession, repoProfile),
+			repoWebhook: repoWebhook,
+		}
+
+		repoSession.EXPECT().
+			GetSessionByJTI(gomock.Any(), "jtiadmin").
+			Return(entity.Session{ID: 1, UserID: 1, JTI: "jtiadmin"}, nil)
+		repoSession.EXPECT().UpdateSessionLastSeenAt(gomock.Any(), int64(1), gomock.Any()).Return(nil)
+		repoProfile.EXPECT().
+			GetProfileByUserID(gomock.Any(), int64(1)).
+			Return(entity.User{ID: 1, Role: entity.UserRoleAdmin, Status: entity.UserStatusActive}, nil)
+		repoWebhook.EXPECT().
+			CreateSubscription(gomock.Any(), entity.WebhookSubscription{
+				URL:        "https://example.com/hook",
+				EventTypes: []string{gouser.EventTypeUserRegistered},
+				Secret:     "0123456789abcdef",
+				IsActive:   true,
+			}).
+			Return(int64(3), nil)
+
+		res, err := w.CreateWebhookSubscription(context.Background(), gouser.ReqCreateWebhookSubscription{
+			UserJWT:    auth.GenerateUserJWTToken(1, "jtiadmin", cfg),
+			URL:        "https://example.com/hook",
+			EventTypes: []string{gouser.EventTypeUserRegistered},
+			Secret:     "0123456789abcdef",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), res.ID)
+	})
+	t.Run("unknown event type should return error", func(t *testing.T) {
+		t.Parallel()
+
+		w := &Webhook{}
+
+		res, err := w.CreateWebhookSubscription(context.Background(), gouser.ReqCreateWebhookSubscription{
+			UserJWT:    "jwt",
+			URL:        "https://example.com/hook",
+			EventTypes: []string{"user.unknown"},
+			Secret:     "0123456789abcdef",
+		})
+
+		assert.Empty(t, res)
+		require.Error(t, err)
+		require.ErrorIs(t, err, gouser.ErrRequestInvalid)
+	})
+	t.Run("non admin should return error", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoWebhook := mockrepo.NewMockIWebhook(ctrl)
+		repoSession := mockrepo.NewMockISession(ctrl)
+		repoProfile := mockrepo.NewMockIProfile(ctrl)
+
+		cfg := config.Config{
+			JWT: config.JWT{ExpireHour: 24, SignedKey: "secretsignkey"},
+		}
+
+		w := &Webhook{
+			cfg:         cfg,
+			guard:       newGuard(cfg, repoSession, repoProfile),
+			repoWebhook: repoWebhook,
+		}
+
+		repoSession.EXPECT().
+			GetSessionByJTI(gomock.Any(), "jti1").
+			Return(entity.Session{ID: 1, UserID: 44, JTI: "jti1"}, nil)
+		repoSession.EXPECT().UpdateSessionLastSeenAt(gomock.Any(), int64(1), gomock.Any()).Return(nil)
+		repoProfile.EXPECT().
+			GetProfileByUserID(gomock.Any(), int64(44)).
+			Return(entity.User{ID: 44, Role: entity.UserRoleUser, Status: entity.UserStatusActive}, nil)
+
+		res, err := w.CreateWebhookSubscription(context.Background(), gouser.ReqCreateWebhookSubscription{
+			UserJWT:    auth.GenerateUserJWTToken(44, "jti1", cfg),
+			URL:        "https://example.com/hook",
+			EventTypes: []string{gouser.EventTypeUserRegistered},
+			Secret:     "0123456789abcdef",
+		})
+
+		assert.Empty(t, res)
+		require.Error(t, err)
+		require.ErrorIs(t, err, gouser.ErrForbidden)
+	})
+}
+
+func TestUnitWebhookGetWebhookDeliveries(t *testing.T) {
+	t.Parallel()
+
+	t.Run("admin get deliveries success", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoWebhook := mockrepo.NewMockIWebhook(ctrl)
+		repoSession := mockrepo.NewMockISession(ctrl)
+		repoProfile := mockrepo.NewMockIProfile(ctrl)
+
+		cfg := config.Config{
+			JWT: config.JWT{ExpireHour: 24, SignedKey: "secretsignkey"},
+		}
+
+		w := &Webhook{
+			cfg:         cfg,
+			guard:       newGuard(cfg, repoSession, repoProfile),
+			repoWebhook: repoWebhook,
+		}
+
+		repoSession.EXPECT().
+			GetSessionByJTI(gomock.Any(), "jtiadmin").
+			Return(entity.Session{ID: 1, UserID: 1, JTI: "jtiadmin"}, nil)
+		repoSession.EXPECT().UpdateSessionLastSeenAt(gomock.Any(), int64(1), gomock.Any()).Return(nil)
+		repoProfile.EXPECT().
+			GetProfileByUserID(gomock.Any(), int64(1)).
+			Return(entity.User{ID: 1, Role: entity.UserRoleAdmin, Status: entity.UserStatusActive}, nil)
+		repoWebhook.EXPECT().
+			GetSubscriptionByID(gomock.Any(), int64(3)).
+			Return(entity.WebhookSubscription{ID: 3}, nil)
+		repoWebhook.EXPECT().
+			GetDeliveriesBySubscriptionID(gomock.Any(), int64(3), entity.WebhookDeliveryStatusDead, uint64(gouser.GetWebhookDeliveriesDefaultLimit)).
+			Return([]entity.WebhookDelivery{
+				{ID: 11, SubscriptionID: 3, Status: entity.WebhookDeliveryStatusDead, Attempt: 2},
+				{ID: 10, SubscriptionID: 3, Status: entity.WebhookDeliveryStatusDead},
+			}, nil)
+		repoWebhook.EXPECT().
+			GetDeliveryAttemptsByDeliveryIDs(gomock.Any(), []int64{11, 10}).
+			Return([]entity.WebhookDeliveryAttempt{
+				{ID: 1, DeliveryID: 11, StatusCode: http.StatusInternalServerError},
+				{ID: 2, DeliveryID: 11, Error: "timeout"},
+			}, nil)
+
+		res, err := w.GetWebhookDeliveries(context.Background(), gouser.ReqGetWebhookDeliveries{
+			UserJWT:        auth.GenerateUserJWTToken(1, "jtiadmin", cfg),
+			SubscriptionID: 3,
+			Status:         entity.WebhookDeliveryStatusDead,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, res.Deliveries, 2)
+		require.Len(t, res.Deliveries[0].Attempts, 2)
+		assert.Equal(t, http.StatusInternalServerError, res.Deliveries[0].Attempts[0].StatusCode)
+		assert.Equal(t, "timeout", res.Deliveries[0].Attempts[1].Error)
+		assert.Empty(t, res.Deliveries[1].Attempts)
+	})
+	t.Run("unknown subscription should return error", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoWebhook := mockrepo.NewMockIWebhook(ctrl)
+		repoSession := mockrepo.NewMockISession(ctrl)
+		repoProfile := mockrepo.NewMockIProfile(ctrl)
+
+		cfg := config.Config{
+			JWT: config.JWT{ExpireHour: 24, SignedKey: "secretsignkey"},
+		}
+
+		w := &Webhook{
+			cfg:         cfg,
+			guard:       newGuard(cfg, repoSession, repoProfile),
+			repoWebhook: repoWebhook,
+		}
+
+		repoSession.EXPECT().
+			GetSessionByJTI(gomock.Any(), "jtiadmin").
+			Return(entity.Session{ID: 1, UserID: 1, JTI: "jtiadmin"}, nil)
+		repoSession.EXPECT().UpdateSessionLastSeenAt(gomock.Any(), int64(1), gomock.Any()).Return(nil)
+		repoProfile.EXPECT().
+			GetProfileByUserID(gomock.Any(), int64(1)).
+			Return(entity.User{ID: 1, Role: entity.UserRoleAdmin, Status: entity.UserStatusActive}, nil)
+		repoWebhook.EXPECT().
+			GetSubscriptionByID(gomock.Any(), int64(3)).
+			Return(entity.WebhookSubscription{}, gouser.ErrUnknownWebhookSubscription)
+
+		res, err := w.GetWebhookDeliveries(context.Background(), gouser.ReqGetWebhookDeliveries{
+			UserJWT:        auth.GenerateUserJWTToken(1, "jtiadmin", cfg),
+			SubscriptionID: 3,
+		})
+
+		assert.Empty(t, res)
+		require.Error(t, err)
+		require.ErrorIs(t, err, gouser.ErrUnknownWebhookSubscription)
+	})
+}
+
+func TestUnitWebhookRedeliverWebhook(t *testing.T) {
+	t.Parallel()
+
+	t.Run("admin redeliver success", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoWebhook := mockrepo.NewMockIWebhook(ctrl)
+		repoSession := mockrepo.NewMockISession(ctrl)
+		repoProfile := mockrepo.NewMockIProfile(ctrl)
+
+		cfg := config.Config{
+			JWT: config.JWT{ExpireHour: 24, SignedKey: "secretsignkey"},
+		}
+
+		w := &Webhook{
+			cfg:         cfg,
+			guard:       newGuard(cfg, repoSession, repoProfile),
+			repoWebhook: repoWebhook,
+		}
+
+		repoSession.EXPECT().
+			GetSessionByJTI(gomock.Any(), "jtiadmin").
+			Return(entity.Session{ID: 1, UserID: 1, JTI: "jtiadmin"}, nil)
+		repoSession.EXPECT().UpdateSessionLastSeenAt(gomock.Any(), int64(1), gomock.Any()).Return(nil)
+		repoProfile.EXPECT().
+			GetProfileByUserID(gomock.Any(), int64(1)).
+			Return(entity.User{ID: 1, Role: entity.UserRoleAdmin, Status: entity.UserStatusActive}, nil)
+		repoWebhook.EXPECT().RedeliverDelivery(gomock.Any(), int64(11), gomock.Any()).Return(nil)
+
+		err := w.RedeliverWebhook(context.Background(), gouser.ReqRedeliverWebhook{
+			UserJWT:    auth.GenerateUserJWTToken(1, "jtiadmin", cfg),
+			DeliveryID: 11,
+		})
+
+		require.NoError(t, err)
+	})
+	t.Run("unknown delivery should return error", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoWebhook := mockrepo.NewMockIWebhook(ctrl)
+		repoSession := mockrepo.NewMockISession(ctrl)
+		repoProfile := mockrepo.NewMockIProfile(ctrl)
+
+		cfg := config.Config{
+			JWT: config.JWT{ExpireHour: 24, SignedKey: "secretsignkey"},
+		}
+
+		w := &Webhook{
+			cfg:         cfg,
+			guard:       newGuard(cfg, repoSession, repoProfile),
+			repoWebhook: repoWebhook,
+		}
+
+		repoSession.EXPECT().
+			GetSessionByJTI(gomock.Any(), "jtiadmin").
+			Return(entity.Session{ID: 1, UserID: 1, JTI: "jtiadmin"}, nil)
+		repoSession.EXPECT().UpdateSessionLastSeenAt(gomock.Any(), int64(1), gomock.Any()).Return(nil)
+		repoProfile.EXPECT().
+			GetProfileByUserID(gomock.Any(), int64(1)).
+			Return(entity.User{ID: 1, Role: entity.UserRoleAdmin, Status: entity.UserStatusActive}, nil)
+		repoWebhook.EXPECT().
+			RedeliverDelivery(gomock.Any(), int64(11), gomock.Any()).
+			Return(gouser.ErrUnknownWebhookDelivery)
+
+		err := w.RedeliverWebhook(context.Background(), gouser.ReqRedeliverWebhook{
+			UserJWT:    auth.GenerateUserJWTToken(1, "jtiadmin", cfg),
+			DeliveryID: 11,
+		})
+
+		require.Error(t, err)
+		require.ErrorIs(t, err, gouser.ErrUnknownWebhookDelivery)
+	})
+}
+
+func TestUnitWebhookDeliverWebhooks(t *testing.T) {
+	t.Parallel()
+
+	t.Run("receiver response 2xx should mark delivery succeeded", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		secret := "0123456789abcdef"
+		payload := []byte(`{"id":7}`)
+
+		server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			body, err := io.ReadAll(r.Body)
+			assert.NoError(t, err)
+			timestamp, err := strconv.ParseInt(r.Header.Get(gouser.HeaderWebhookTimestamp), 10, 64)
+			assert.NoError(t, err)
+			assert.True(t, gouser.VerifyWebhookSignature(secret, timestamp, body, r.Header.Get(gouser.HeaderWebhookSignature)))
+			assert.Equal(t, "21", r.Header.Get(gouser.HeaderWebhookDelivery))
+			assert.Equal(t, gouser.EventTypeUserRegistered, r.Header.Get(gouser.HeaderWebhookEvent))
+			rw.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+
+		repoWebhook := mockrepo.NewMockIWebhook(ctrl)
+		transactor := mockrepo.NewMockITransactor(ctrl)
+
+		w := &Webhook{
+			cfg:         config.Config{Webhook: config.Webhook{BatchSize: 100, MaxAttempt: 3, RetryBaseSecond: 10, RetryMaxSecond: 3600, LeaseSecond: 300}},
+			repoWebhook: repoWebhook,
+			transactor:  transactor,
+			sender:      webhook.NewSender(server.Client()),
+		}
+
+		transactor.EXPECT().
+			WithinTx(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+				return fn(ctx)
+			})
+		repoWebhook.EXPECT().
+			ClaimPendingDeliveries(gomock.Any(), gomock.Any(), gomock.Any(), uint64(100)).
+			Return([]entity.WebhookDelivery{
+				{ID: 21, SubscriptionID: 3, EventType: gouser.EventTypeUserRegistered, Payload: payload, Status: entity.WebhookDeliveryStatusPending},
+			}, nil)
+		repoWebhook.EXPECT().
+			GetSubscriptions(gomock.Any()).
+			Return([]entity.WebhookSubscription{{ID: 3, URL: server.URL, Secret: secret, IsActive: true}}, nil)
+		repoWebhook.EXPECT().
+			CreateDeliveryAttempt(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, attempt entity.WebhookDeliveryAttempt) error {
+				assert.Equal(t, int64(21), attempt.DeliveryID)
+				assert.Equal(t, http.StatusNoContent, attempt.StatusCode)
+				assert.Empty(t, attempt.Error)
+				return nil
+			})
+		repoWebhook.EXPECT().
+			UpdateDelivery(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, delivery entity.WebhookDelivery, _ time.Time) error {
+				assert.Equal(t, entity.WebhookDeliveryStatusSucceeded, delivery.Status)
+				assert.Equal(t, 1, delivery.Attempt)
+				return nil
+			})
+
+		count, err := w.DeliverWebhooks(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+	})
+	t.Run("receiver response non 2xx should retry with backoff", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			rw.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+
+		repoWebhook := mockrepo.NewMockIWebhook(ctrl)
+		transactor := mockrepo.NewMockITransactor(ctrl)
+
+		w := &Webhook{
+			cfg:         config.Config{Webhook: config.Webhook{BatchSize: 100, MaxAttempt: 3, RetryBaseSecond: 10, RetryMaxSecond: 3600, LeaseSecond: 300}},
+			repoWebhook: repoWebhook,
+			transactor:  transactor,
+			sender:      webhook.NewSender(server.Client()),
+		}
+
+		transactor.EXPECT().
+			WithinTx(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+				return fn(ctx)
+			})
+		repoWebhook.EXPECT().
+			ClaimPendingDeliveries(gomock.Any(), gomock.Any(), gomock.Any(), uint64(100)).
+			Return([]entity.WebhookDelivery{
+				{ID: 21, SubscriptionID: 3, Payload: []byte(`{}`), Status: entity.WebhookDeliveryStatusPending, Attempt: 1},
+			}, nil)
+		repoWebhook.EXPECT().
+			GetSubscriptions(gomock.Any()).
+			Return([]entity.WebhookSubscription{{ID: 3, URL: server.URL, Secret: "0123456789abcdef", IsActive: true}}, nil)
+		repoWebhook.EXPECT().
+			CreateDeliveryAttempt(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, attempt entity.WebhookDeliveryAttempt) error {
+				assert.Equal(t, http.StatusServiceUnavailable, attempt.StatusCode)
+				assert.Contains(t, attempt.Error, "503")
+				return nil
+			})
+		repoWebhook.EXPECT().
+			UpdateDelivery(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, delivery entity.WebhookDelivery, _ time.Time) error {
+				assert.Equal(t, entity.WebhookDeliveryStatusPending, delivery.Status)
+				assert.Equal(t, 2, delivery.Attempt)
+				// second attempt waits 10s * 2^1.
+				assert.WithinDuration(t, time.Now().Add(20*time.Second), delivery.NextAttemptAt, time.Second)
+				return nil
+			})
+
+		count, err := w.DeliverWebhooks(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), count)
+	})
+	t.Run("failed last attempt should mark delivery dead", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			rw.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+
+		repoWebhook := mockrepo.NewMockIWebhook(ctrl)
+		transactor := mockrepo.NewMockITransactor(ctrl)
+
+		w := &Webhook{
+			cfg:         config.Config{Webhook: config.Webhook{BatchSize: 100, MaxAttempt: 3, RetryBaseSecond: 10, RetryMaxSecond: 3600, LeaseSecond: 300}},
+			repoWebhook: repoWebhook,
+			transactor:  transactor,
+			sender:      webhook.NewSender(server.Client()),
+		}
+
+		transactor.EXPECT().
+			WithinTx(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+				return fn(ctx)
+			})
+		repoWebhook.EXPECT().
+			ClaimPendingDeliveries(gomock.Any(), gomock.Any(), gomock.Any(), uint64(100)).
+			Return([]entity.WebhookDelivery{
+				{ID: 21, SubscriptionID: 3, Payload: []byte(`{}`), Status: entity.WebhookDeliveryStatusPending, Attempt: 2},
+			}, nil)
+		repoWebhook.EXPECT().
+			GetSubscriptions(gomock.Any()).
+			Return([]entity.WebhookSubscription{{ID: 3, URL: server.URL, Secret: "0123456789abcdef", IsActive: true}}, nil)
+		repoWebhook.EXPECT().CreateDeliveryAttempt(gomock.Any(), gomock.Any()).Return(nil)
+		repoWebhook.EXPECT().
+			UpdateDelivery(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, delivery entity.WebhookDelivery, _ time.Time) error {
+				assert.Equal(t, entity.WebhookDeliveryStatusDead, delivery.Status)
+				assert.Equal(t, 3, delivery.Attempt)
+				return nil
+			})
+
+		count, err := w.DeliverWebhooks(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), count)
+	})
+	t.Run("no pending delivery should do nothing", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoWebhook := mockrepo.NewMockIWebhook(ctrl)
+		transactor := mockrepo.NewMockITransactor(ctrl)
+
+		w := &Webhook{
+			cfg:         config.Config{Webhook: config.Webhook{BatchSize: 100, MaxAttempt: 3, RetryBaseSecond: 10, RetryMaxSecond: 3600, LeaseSecond: 300}},
+			repoWebhook: repoWebhook,
+			transactor:  transactor,
+		}
+
+		repoWebhook.EXPECT().
+			ClaimPendingDeliveries(gomock.Any(), gomock.Any(), gomock.Any(), uint64(100)).
+			Return([]entity.WebhookDelivery{}, nil)
+
+		count, err := w.DeliverWebhooks(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), count)
+	})
+	t.Run("deliveries should be claimed for lease second", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoWebhook := mockrepo.NewMockIWebhook(ctrl)
+		transactor := mockrepo.NewMockITransactor(ctrl)
+
+		w := &Webhook{
+			cfg:         config.Config{Webhook: config.Webhook{BatchSize: 100, MaxAttempt: 3, RetryBaseSecond: 10, RetryMaxSecond: 3600, LeaseSecond: 300}},
+			repoWebhook: repoWebhook,
+			transactor:  transactor,
+		}
+
+		repoWebhook.EXPECT().
+			ClaimPendingDeliveries(gomock.Any(), gomock.Any(), gomock.Any(), uint64(100)).
+			DoAndReturn(func(_ context.Context, now time.Time, leaseUntil time.Time, _ uint64) ([]entity.WebhookDelivery, error) {
+				assert.Equal(t, now.Add(300*time.Second).Truncate(time.Microsecond), leaseUntil)
+				return []entity.WebhookDelivery{}, nil
+			})
+
+		count, err := w.DeliverWebhooks(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), count)
+	})
+	t.Run("expired claim should stop delivering", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoWebhook := mockrepo.NewMockIWebhook(ctrl)
+		transactor := mockrepo.NewMockITransactor(ctrl)
+
+		w := &Webhook{
+			cfg:         config.Config{Webhook: config.Webhook{BatchSize: 100, MaxAttempt: 3, RetryBaseSecond: 10, RetryMaxSecond: 3600, LeaseSecond: 0}},
+			repoWebhook: repoWebhook,
+			transactor:  transactor,
+		}
+
+		repoWebhook.EXPECT().
+			ClaimPendingDeliveries(gomock.Any(), gomock.Any(), gomock.Any(), uint64(100)).
+			DoAndReturn(func(_ context.Context, _ time.Time, leaseUntil time.Time, _ uint64) ([]entity.WebhookDelivery, error) {
+				for !time.Now().After(leaseUntil) {
+					time.Sleep(time.Millisecond)
+				}
+				return []entity.WebhookDelivery{{ID: 21, SubscriptionID: 3, Payload: []byte(`{}`)}}, nil
+			})
+		repoWebhook.EXPECT().
+			GetSubscriptions(gomock.Any()).
+			Return([]entity.WebhookSubscription{{ID: 3, URL: "http://localhost", Secret: "0123456789abcdef", IsActive: true}}, nil)
+
+		count, err := w.DeliverWebhooks(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), count)
+	})
+	t.Run("claim lost should stop delivering the rest", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		var requestCount int
+		server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			requestCount++
+			rw.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+
+		repoWebhook := mockrepo.NewMockIWebhook(ctrl)
+		transactor := mockrepo.NewMockITransactor(ctrl)
+
+		w := &Webhook{
+			cfg:         config.Config{Webhook: config.Webhook{BatchSize: 100, MaxAttempt: 3, RetryBaseSecond: 10, RetryMaxSecond: 3600, LeaseSecond: 300}},
+			repoWebhook: repoWebhook,
+			transactor:  transactor,
+			sender:      webhook.NewSender(server.Client()),
+		}
+
+		var claimedUntil time.Time
+		transactor.EXPECT().
+			WithinTx(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+				return fn(ctx)
+			})
+		repoWebhook.EXPECT().
+			ClaimPendingDeliveries(gomock.Any(), gomock.Any(), gomock.Any(), uint64(100)).
+			DoAndReturn(func(_ context.Context, _ time.Time, leaseUntil time.Time, _ uint64) ([]entity.WebhookDelivery, error) {
+				claimedUntil = leaseUntil
+				return []entity.WebhookDelivery{
+					{ID: 21, SubscriptionID: 3, Payload: []byte(`{}`), Status: entity.WebhookDeliveryStatusPending, NextAttemptAt: leaseUntil},
+					{ID: 22, SubscriptionID: 3, Payload: []byte(`{}`), Status: entity.WebhookDeliveryStatusPending, NextAttemptAt: leaseUntil},
+				}, nil
+			})
+		repoWebhook.EXPECT().
+			GetSubscriptions(gomock.Any()).
+			Return([]entity.WebhookSubscription{{ID: 3, URL: server.URL, Secret: "0123456789abcdef", IsActive: true}}, nil)
+		repoWebhook.EXPECT().CreateDeliveryAttempt(gomock.Any(), gomock.Any()).Return(nil)
+		repoWebhook.EXPECT().
+			UpdateDelivery(gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, delivery entity.WebhookDelivery, leaseUntil time.Time) error {
+				assert.Equal(t, int64(21), delivery.ID)
+				assert.Equal(t, claimedUntil, leaseUntil)
+				return repo.ErrClaimLost
+			})
+
+		count, err := w.DeliverWebhooks(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), count)
+		assert.Equal(t, 1, requestCount)
+	})
+	t.Run("deleted or inactive subscription should skip delivery", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, r *http.Request) {
+			assert.Fail(t, "delivery of deleted or inactive subscription should not be sent")
+		}))
+		defer server.Close()
+
+		repoWebhook := mockrepo.NewMockIWebhook(ctrl)
+		transactor := mockrepo.NewMockITransactor(ctrl)
+
+		w := &Webhook{
+			cfg:         config.Config{Webhook: config.Webhook{BatchSize: 100, MaxAttempt: 3, RetryBaseSecond: 10, RetryMaxSecond: 3600, LeaseSecond: 300}},
+			repoWebhook: repoWebhook,
+			transactor:  transactor,
+			sender:      webhook.NewSender(server.Client()),
+		}
+
+		repoWebhook.EXPECT().
+			ClaimPendingDeliveries(gomock.Any(), gomock.Any(), gomock.Any(), uint64(100)).
+			Return([]entity.WebhookDelivery{
+				{ID: 21, SubscriptionID: 3, Payload: []byte(`{}`), Status: entity.WebhookDeliveryStatusPending},
+				{ID: 22, SubscriptionID: 4, Payload: []byte(`{}`), Status: entity.WebhookDeliveryStatusPending},
+			}, nil)
+		repoWebhook.EXPECT().
+			GetSubscriptions(gomock.Any()).
+			Return([]entity.WebhookSubscription{{ID: 4, URL: server.URL, Secret: "0123456789abcdef", IsActive: false}}, nil)
+
+		count, err := w.DeliverWebhooks(context.Background())
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(0), count)
+	})
+	t.Run("ClaimPendingDeliveries error should return error", func(t *testing.T) {
+		t.Parallel()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repoWebhook := mockrepo.NewMockIWebhook(ctrl)
+		transactor := mockrepo.NewMockITransactor(ctrl)
+
+		w := &Webhook{
+			cfg:         config.Config{Webhook: config.Webhook{BatchSize: 100, MaxAttempt: 3, RetryBaseSecond: 10, RetryMaxSecond: 3600, LeaseSecond: 300}},
+			repoWebhook: repoWebhook,
+			transactor:  transactor,
+		}
+
+		repoWebhook.EXPECT().
+			ClaimPendingDeliveries(gomock.Any(), gomock.Any(), gomock.Any(), uint64(100)).
+			Return(nil, assert.AnError)
+
+		count, err := w.DeliverWebhooks(context.Background())
+
+		require.Error(t, err)
+		require.ErrorIs(t, err, assert.AnError)
+		assert.Equal(t, int64(0), count)
+	})
+}
@@ -1,8 +1,14 @@
 // Package main to run go build.
 package main
 
-import "github.com/Hidayathamir/go-user/internal/app"
+import (
+	"github.com/Hidayathamir/go-user/internal/app"
+	"github.com/sirupsen/logrus"
+)
 
 func main() {
-	app.Run()
+	err := app.Run()
+	if err != nil {
+		logrus.Fatalf("app.Run: %v", err)
+	}
 }
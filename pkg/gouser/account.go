@@ -0,0 +1,137 @@
+package gouser
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Hidayathamir/go-user/internal/repo/db/entity"
+)
+
+// ReqDeleteAccount -.
+type ReqDeleteAccount struct {
+	UserJWT string `json:"-" redact:"true"`
+	// Password is required to re-authenticate the user before deleting.
+	Password string `json:"password" redact:"true"`
+	// UserAgent, IP and RequestID are filled by controller from the incoming
+	// request, recorded in audit log.
+	UserAgent string `json:"-"`
+	IP        string `json:"-"`
+	RequestID string `json:"-"`
+}
+
+// Validate validate ReqDeleteAccount.
+func (r ReqDeleteAccount) Validate() error {
+	if r.UserJWT == "" {
+		return errors.New("ReqDeleteAccount.UserJWT can not be empty")
+	}
+	if r.Password == "" {
+		return errors.New("ReqDeleteAccount.Password can not be empty")
+	}
+	return nil
+}
+
+// ReqRestoreAccount -.
+type ReqRestoreAccount struct {
+	Username string `json:"username"`
+	Password string `json:"password" redact:"true"`
+	// UserAgent, IP and RequestID are filled by controller from the incoming
+	// request, recorded in audit log.
+	UserAgent string `json:"-"`
+	IP        string `json:"-"`
+	RequestID string `json:"-"`
+}
+
+// Validate validate ReqRestoreAccount.
+func (r ReqRestoreAccount) Validate() error {
+	if r.Username == "" {
+		return errors.New("ReqRestoreAccount.Username can not be empty")
+	}
+	if r.Password == "" {
+		return errors.New("ReqRestoreAccount.Password can not be empty")
+	}
+	return nil
+}
+
+// ReqUpdateUserStatus -.
+type ReqUpdateUserStatus struct {
+	// UserJWT is admin user JWT.
+	UserJWT string `json:"-" redact:"true"`
+	UserID  int64  `json:"user_id"`
+	// Status is "active", "suspended" or "disabled".
+	Status string `json:"status"`
+	// SuspendedUntil is required when status is "suspended", the user becomes
+	// active again after it.
+	SuspendedUntil   *time.Time `json:"suspended_until"`
+	SuspensionReason string     `json:"suspension_reason"`
+	// UserAgent, IP and RequestID are filled by controller from the incoming
+	// request, recorded in audit log.
+	UserAgent string `json:"-"`
+	IP        string `json:"-"`
+	RequestID string `json:"-"`
+}
+
+// Validate validate ReqUpdateUserStatus.
+func (r ReqUpdateUserStatus) Validate() error {
+	if r.UserJWT == "" {
+		return errors.New("ReqUpdateUserStatus.UserJWT can not be empty")
+	}
+	if r.UserID == 0 {
+		return errors.New("ReqUpdateUserStatus.UserID can not be empty")
+	}
+	switch r.Status {
+	case entity.UserStatusActive, entity.UserStatusDisabled:
+	case entity.UserStatusSuspended:
+		if r.SuspendedUntil == nil {
+			return errors.New("ReqUpdateUserStatus.SuspendedUntil can not be empty when status is suspended")
+		}
+	default:
+		return fmt.Errorf("ReqUpdateUserStatus.Status unknown status '%s'", r.Status)
+	}
+	return nil
+}
+
+// ToEntityUser transform ReqUpdateUserStatus to entity.User. Active user has
+// no suspension, only suspended user has suspended until.
+func (r ReqUpdateUserStatus) ToEntityUser() entity.User {
+	user := entity.User{
+		ID:               r.UserID,
+		Status:           r.Status,
+		SuspendedUntil:   r.SuspendedUntil,
+		SuspensionReason: r.SuspensionReason,
+	}
+	switch r.Status {
+	case entity.UserStatusActive:
+		user.SuspendedUntil = nil
+		user.SuspensionReason = ""
+	case entity.UserStatusDisabled:
+		user.SuspendedUntil = nil
+	}
+	return user
+}
+
+// ReqChangeUsername -.
+type ReqChangeUsername struct {
+	UserJWT  string `json:"-" redact:"true"`
+	Username string `json:"username"`
+	// UserAgent, IP and RequestID are filled by controller from the incoming
+	// request, recorded in audit log.
+	UserAgent string `json:"-"`
+	IP        string `json:"-"`
+	RequestID string `json:"-"`
+}
+
+// Validate validate ReqChangeUsername.
+func (r ReqChangeUsername) Validate() error {
+	if r.UserJWT == "" {
+		return errors.New("ReqChangeUsername.UserJWT can not be empty")
+	}
+	if r.Username == "" {
+		return errors.New("ReqChangeUsername.Username can not be empty")
+	}
+	err := validateUsername(r.Username)
+	if err != nil {
+		return fmt.Errorf("ReqChangeUsername.Username: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package gouser
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Hidayathamir/go-user/internal/repo/db/entity"
+)
+
+// GetAuditLogs page size.
+const (
+	GetAuditLogsDefaultLimit = 50
+	GetAuditLogsMaxLimit     = 500
+)
+
+// ReqGetAuditLogs -.
+type ReqGetAuditLogs struct {
+	// UserJWT is admin user JWT.
+	UserJWT string `json:"-" form:"-" redact:"true"`
+	// Cursor is ResGetAuditLogs.NextCursor of the previous page, empty for
+	// the first page.
+	Cursor string `json:"cursor" form:"cursor"`
+	// Limit is page size, default GetAuditLogsDefaultLimit.
+	Limit        int    `json:"limit"          form:"limit"`
+	ActorUserID  *int64 `json:"actor_user_id"  form:"actor_user_id"`
+	TargetUserID *int64 `json:"target_user_id" form:"target_user_id"`
+	// Action filter, empty means any action.
+	Action string `json:"action" form:"action"`
+	// Result filter, empty means any result.
+	Result string `json:"result" form:"result"`
+	// CreatedFrom is inclusive, CreatedTo is exclusive.
+	CreatedFrom *time.Time `json:"created_from" form:"created_from"`
+	CreatedTo   *time.Time `json:"created_to"   form:"created_to"`
+}
+
+// Validate validate ReqGetAuditLogs.
+func (r ReqGetAuditLogs) Validate() error {
+	if r.UserJWT == "" {
+		return errors.New("ReqGetAuditLogs.UserJWT can not be empty")
+	}
+	if r.Limit < 0 || r.Limit > GetAuditLogsMaxLimit {
+		return fmt.Errorf("ReqGetAuditLogs.Limit must be between 0 and %d", GetAuditLogsMaxLimit)
+	}
+	switch r.Action {
+	case "", entity.AuditActionUserRegister, entity.AuditActionUserLogin, entity.AuditActionUserPasswordChange,
+		entity.AuditActionUserDelete, entity.AuditActionUserRestore, entity.AuditActionUsernameChange,
+		entity.AuditActionUserStatusUpdate, entity.AuditActionSessionRevoke:
+	default:
+		return fmt.Errorf("ReqGetAuditLogs.Action unknown action '%s'", r.Action)
+	}
+	switch r.Result {
+	case "", entity.AuditResultSuccess, entity.AuditResultFailure:
+	default:
+		return fmt.Errorf("ReqGetAuditLogs.Result unknown result '%s'", r.Result)
+	}
+	if r.CreatedFrom != nil && r.CreatedTo != nil && !r.CreatedFrom.Before(*r.CreatedTo) {
+		return errors.New("ReqGetAuditLogs.CreatedFrom must be before ReqGetAuditLogs.CreatedTo")
+	}
+	return nil
+}
+
+// AuditLog -.
+type AuditLog struct {
+	ID           int64     `json:"id"`
+	ActorUserID  *int64    `json:"actor_user_id"`
+	TargetUserID *int64    `json:"target_user_id"`
+	Action       string    `json:"action"`
+	Result       string    `json:"result"`
+	Detail       string    `json:"detail"`
+	IP           string    `json:"ip"`
+	UserAgent    string    `json:"user_agent"`
+	RequestID    string    `json:"request_id"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// LoadEntityAuditLog load from entity.AuditLog then return AuditLog.
+func (a AuditLog) LoadEntityAuditLog(auditLog entity.AuditLog) AuditLog {
+	return AuditLog{
+		ID:           auditLog.ID,
+		ActorUserID:  auditLog.ActorUserID,
+		TargetUserID: auditLog.TargetUserID,
+		Action:       auditLog.Action,
+		Result:       auditLog.Result,
+		Detail:       auditLog.Detail,
+		IP:           auditLog.IP,
+		UserAgent:    auditLog.UserAgent,
+		RequestID:    auditLog.RequestID,
+		CreatedAt:    auditLog.CreatedAt,
+	}
+}
+
+// ResGetAuditLogs -.
+type ResGetAuditLogs struct {
+	// AuditLogs is newest first.
+	AuditLogs []AuditLog `json:"audit_logs"`
+	// NextCursor is empty when there is no next page.
+	NextCursor string `json:"next_cursor"`
+}
@@ -2,6 +2,7 @@ package gouser
 
 import (
 	"errors"
+	"fmt"
 
 	"github.com/Hidayathamir/go-user/internal/repo/db/entity"
 )
@@ -9,7 +10,12 @@ import (
 // ReqLoginUser -.
 type ReqLoginUser struct {
 	Username string `json:"username"`
-	Password string `json:"password"`
+	Password string `json:"password" redact:"true"`
+	// UserAgent and IP are filled by controller from the incoming request,
+	// recorded in the login session and audit log.
+	UserAgent string `json:"-"`
+	IP        string `json:"-"`
+	RequestID string `json:"-"`
 }
 
 // Validate validate ReqLoginUser.
@@ -25,13 +31,18 @@ func (r ReqLoginUser) Validate() error {
 
 // ResLoginUser -.
 type ResLoginUser struct {
-	UserJWT string `json:"user_jwt"`
+	UserJWT string `json:"user_jwt" redact:"true"`
 }
 
 // ReqRegisterUser -.
 type ReqRegisterUser struct {
 	Username string `json:"username"`
-	Password string `json:"password"`
+	Password string `json:"password" redact:"true"`
+	// UserAgent, IP and RequestID are filled by controller from the incoming
+	// request, recorded in audit log.
+	UserAgent string `json:"-"`
+	IP        string `json:"-"`
+	RequestID string `json:"-"`
 }
 
 // Validate validate ReqRegisterUser.
@@ -39,6 +50,10 @@ func (r ReqRegisterUser) Validate() error {
 	if r.Username == "" {
 		return errors.New("ReqRegisterUser.Username can not be empty")
 	}
+	err := validateUsername(r.Username)
+	if err != nil {
+		return fmt.Errorf("ReqRegisterUser.Username: %w", err)
+	}
 	if r.Password == "" {
 		return errors.New("ReqRegisterUser.Password can not be empty")
 	}
@@ -15,4 +15,25 @@ var (
 	ErrDuplicateUsername = errors.New("duplicate username")
 	// ErrUnknownUsername occurs when username does not exists.
 	ErrUnknownUsername = errors.New("unknown username")
+	// ErrUnknownUserID occurs when user id does not exists.
+	ErrUnknownUserID = errors.New("unknown user id")
+	// ErrUnknownSession occurs when session does not exists or does not
+	// belong to the user.
+	ErrUnknownSession = errors.New("unknown session")
+	// ErrSessionRevoked occurs when user JWT is bound to a revoked session.
+	ErrSessionRevoked = errors.New("session revoked")
+	// ErrForbidden occurs when user is not allowed to do the action.
+	ErrForbidden = errors.New("forbidden")
+	// ErrUserNotActive occurs when user is suspended or disabled.
+	ErrUserNotActive = errors.New("user not active")
+	// ErrUsernameChangeCooldown occurs when user change username again
+	// before cooldown period is over.
+	ErrUsernameChangeCooldown = errors.New("username change cooldown")
+	// ErrUnknownWebhookSubscription occurs when webhook subscription does not
+	// exists.
+	ErrUnknownWebhookSubscription = errors.New("unknown webhook subscription")
+	// ErrUnknownWebhookDelivery occurs when webhook delivery does not exists.
+	ErrUnknownWebhookDelivery = errors.New("unknown webhook delivery")
+	// ErrTooManyRequests occurs when client is rate limited.
+	ErrTooManyRequests = errors.New("too many requests")
 )
@@ -0,0 +1,151 @@
+package gouser
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Hidayathamir/go-user/internal/repo/db/entity"
+)
+
+// Event type list.
+const (
+	EventTypeUserRegistered  = "user.registered"
+	EventTypePasswordChanged = "user.password_changed"
+	EventTypeProfileUpdated  = "user.profile_updated"
+	EventTypeUserDeleted     = "user.deleted"
+)
+
+// IsEventType return true if eventType is one of EventType*.
+func IsEventType(eventType string) bool {
+	switch eventType {
+	case EventTypeUserRegistered, EventTypePasswordChanged, EventTypeProfileUpdated, EventTypeUserDeleted:
+		return true
+	default:
+		return false
+	}
+}
+
+// Event is domain event published to other services. Delivery is at least
+// once, consumer should ignore event with an ID it already handled.
+type Event struct {
+	ID         int64     `json:"id"`
+	Type       string    `json:"type"`
+	UserID     int64     `json:"user_id"`
+	OccurredAt time.Time `json:"occurred_at"`
+	// Data is one of Event* struct depending on Type.
+	Data json.RawMessage `json:"data"`
+}
+
+// LoadEntityOutboxEvent load from entity.OutboxEvent then return Event.
+func (e Event) LoadEntityOutboxEvent(event entity.OutboxEvent) Event {
+	return Event{
+		ID:         event.ID,
+		Type:       event.EventType,
+		UserID:     event.UserID,
+		OccurredAt: event.CreatedAt,
+		Data:       event.Payload,
+	}
+}
+
+// EventUserRegistered is data of event user.registered.
+type EventUserRegistered struct {
+	UserID   int64  `json:"user_id"`
+	Username string `json:"username"`
+}
+
+// EventPasswordChanged is data of event user.password_changed.
+type EventPasswordChanged struct {
+	UserID int64 `json:"user_id"`
+}
+
+// EventProfileUpdated is data of event user.profile_updated.
+type EventProfileUpdated struct {
+	UserID      int64  `json:"user_id"`
+	Username    string `json:"username"`
+	OldUsername string `json:"old_username"`
+}
+
+// EventUserDeleted is data of event user.deleted.
+type EventUserDeleted struct {
+	UserID int64 `json:"user_id"`
+}
+
+// User change of UserEvent.
+const (
+	UserChangeCreated = "created"
+	UserChangeUpdated = "updated"
+	UserChangeDeleted = "deleted"
+)
+
+// WatchUsersEventTypes is event types streamed by WatchUsers.
+var WatchUsersEventTypes = []string{EventTypeUserRegistered, EventTypeProfileUpdated, EventTypeUserDeleted}
+
+// ReqWatchUsers -.
+type ReqWatchUsers struct {
+	// UserJWT is JWT of admin user.
+	UserJWT string `json:"-" redact:"true"`
+	// Cursor is UserEvent.Cursor of the last handled event, the stream
+	// resumes right after it. Empty means only events from now on.
+	Cursor string `json:"cursor"`
+}
+
+// Validate validate ReqWatchUsers.
+func (r ReqWatchUsers) Validate() error {
+	if r.UserJWT == "" {
+		return errors.New("ReqWatchUsers.UserJWT can not be empty")
+	}
+	return nil
+}
+
+// UserEvent is one change of a user pushed by WatchUsers.
+type UserEvent struct {
+	// Cursor is the position of this event, pass it as ReqWatchUsers.Cursor
+	// to resume after this event.
+	Cursor string `json:"cursor"`
+	// Change is UserChangeCreated, UserChangeUpdated or UserChangeDeleted.
+	Change string `json:"change"`
+	UserID int64  `json:"user_id"`
+	// Username is empty when Change is UserChangeDeleted.
+	Username string `json:"username"`
+	// OldUsername is set when the username is changed.
+	OldUsername string    `json:"old_username"`
+	OccurredAt  time.Time `json:"occurred_at"`
+}
+
+// LoadEntityOutboxEvent load from entity.OutboxEvent of one of
+// WatchUsersEventTypes then return UserEvent at cursor.
+func (u UserEvent) LoadEntityOutboxEvent(event entity.OutboxEvent, cursor string) (UserEvent, error) {
+	userEvent := UserEvent{
+		Cursor:     cursor,
+		UserID:     event.UserID,
+		OccurredAt: event.CreatedAt,
+	}
+
+	switch event.EventType {
+	case EventTypeUserRegistered:
+		data := EventUserRegistered{}
+		err := json.Unmarshal(event.Payload, &data)
+		if err != nil {
+			return UserEvent{}, fmt.Errorf("json.Unmarshal: %w", err)
+		}
+		userEvent.Change = UserChangeCreated
+		userEvent.Username = data.Username
+	case EventTypeProfileUpdated:
+		data := EventProfileUpdated{}
+		err := json.Unmarshal(event.Payload, &data)
+		if err != nil {
+			return UserEvent{}, fmt.Errorf("json.Unmarshal: %w", err)
+		}
+		userEvent.Change = UserChangeUpdated
+		userEvent.Username = data.Username
+		userEvent.OldUsername = data.OldUsername
+	case EventTypeUserDeleted:
+		userEvent.Change = UserChangeDeleted
+	default:
+		return UserEvent{}, fmt.Errorf("event type '%s' is not a user change", event.EventType)
+	}
+
+	return userEvent, nil
+}
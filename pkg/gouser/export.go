@@ -0,0 +1,144 @@
+package gouser
+
+import (
+	"errors"
+	"time"
+
+	"github.com/Hidayathamir/go-user/internal/repo/db/entity"
+)
+
+// ExportVersion is the version of personal data export archive format.
+// Increase it when a field of the archive is changed or removed.
+const ExportVersion = 1
+
+// ExportUser is user data in export archive, password hash is left out.
+type ExportUser struct {
+	ID               int64      `json:"id"`
+	Username         string     `json:"username"`
+	Role             string     `json:"role"`
+	Status           string     `json:"status"`
+	SuspendedUntil   *time.Time `json:"suspended_until"`
+	SuspensionReason string     `json:"suspension_reason"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+}
+
+// LoadEntityUser load from entity.User then return ExportUser.
+func (e ExportUser) LoadEntityUser(user entity.User) ExportUser {
+	return ExportUser{
+		ID:               user.ID,
+		Username:         user.Username,
+		Role:             user.Role,
+		Status:           user.Status,
+		SuspendedUntil:   user.SuspendedUntil,
+		SuspensionReason: user.SuspensionReason,
+		CreatedAt:        user.CreatedAt,
+		UpdatedAt:        user.UpdatedAt,
+	}
+}
+
+// ExportSession is session data in export archive.
+type ExportSession struct {
+	ID         int64      `json:"id"`
+	UserAgent  string     `json:"user_agent"`
+	IP         string     `json:"ip"`
+	CreatedAt  time.Time  `json:"created_at"`
+	LastSeenAt time.Time  `json:"last_seen_at"`
+	ExpiredAt  time.Time  `json:"expired_at"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+}
+
+// LoadEntitySession load from entity.Session then return ExportSession.
+func (e ExportSession) LoadEntitySession(session entity.Session) ExportSession {
+	return ExportSession{
+		ID:         session.ID,
+		UserAgent:  session.UserAgent,
+		IP:         session.IP,
+		CreatedAt:  session.CreatedAt,
+		LastSeenAt: session.LastSeenAt,
+		ExpiredAt:  session.ExpiredAt,
+		RevokedAt:  session.RevokedAt,
+	}
+}
+
+// ExportAuditLog is audit log entry in export archive, the user is actor or
+// target of it.
+type ExportAuditLog struct {
+	ID           int64     `json:"id"`
+	ActorUserID  *int64    `json:"actor_user_id"`
+	TargetUserID *int64    `json:"target_user_id"`
+	Action       string    `json:"action"`
+	Result       string    `json:"result"`
+	Detail       string    `json:"detail"`
+	IP           string    `json:"ip"`
+	UserAgent    string    `json:"user_agent"`
+	RequestID    string    `json:"request_id"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// LoadEntityAuditLog load from entity.AuditLog then return ExportAuditLog. IP
+// and user agent are left out when the actor is other user, e.g admin, they
+// are not the user data.
+func (e ExportAuditLog) LoadEntityAuditLog(userID int64, auditLog entity.AuditLog) ExportAuditLog {
+	res := ExportAuditLog{
+		ID:           auditLog.ID,
+		ActorUserID:  auditLog.ActorUserID,
+		TargetUserID: auditLog.TargetUserID,
+		Action:       auditLog.Action,
+		Result:       auditLog.Result,
+		Detail:       auditLog.Detail,
+		RequestID:    auditLog.RequestID,
+		CreatedAt:    auditLog.CreatedAt,
+	}
+	if auditLog.ActorUserID == nil || *auditLog.ActorUserID == userID {
+		res.IP = auditLog.IP
+		res.UserAgent = auditLog.UserAgent
+	}
+	return res
+}
+
+// ExportUsernameHistory is old username in export archive.
+type ExportUsernameHistory struct {
+	Username  string    `json:"username"`
+	ChangedAt time.Time `json:"changed_at"`
+}
+
+// LoadEntityUsernameHistory load from entity.UsernameHistory then return
+// ExportUsernameHistory.
+func (e ExportUsernameHistory) LoadEntityUsernameHistory(history entity.UsernameHistory) ExportUsernameHistory {
+	return ExportUsernameHistory{
+		Username:  history.Username,
+		ChangedAt: history.ChangedAt,
+	}
+}
+
+// ReqExportMyData -.
+type ReqExportMyData struct {
+	UserJWT string `json:"-" redact:"true"`
+}
+
+// Validate validate ReqExportMyData.
+func (r ReqExportMyData) Validate() error {
+	if r.UserJWT == "" {
+		return errors.New("ReqExportMyData.UserJWT can not be empty")
+	}
+	return nil
+}
+
+// ReqExportUserData -.
+type ReqExportUserData struct {
+	// UserJWT is admin user JWT.
+	UserJWT string `json:"-" redact:"true"`
+	UserID  int64  `json:"user_id"`
+}
+
+// Validate validate ReqExportUserData.
+func (r ReqExportUserData) Validate() error {
+	if r.UserJWT == "" {
+		return errors.New("ReqExportUserData.UserJWT can not be empty")
+	}
+	if r.UserID == 0 {
+		return errors.New("ReqExportUserData.UserID can not be empty")
+	}
+	return nil
+}
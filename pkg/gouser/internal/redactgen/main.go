@@ -0,0 +1,144 @@
+// Command redactgen generate Redact, String and LogValue methods for every
+// struct type in a package having field tagged `redact:"true"`, see
+// gouser.Redactor. Run it with go generate from the package directory.
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+func main() {
+	output := flag.String("output", "redact_gen.go", "generated file name, in the package directory")
+	flag.Parse()
+
+	err := run(".", *output)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "redactgen: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(dir string, output string) error {
+	pkgName, typeNames, err := findRedactedTypes(dir, output)
+	if err != nil {
+		return fmt.Errorf("findRedactedTypes: %w", err)
+	}
+
+	src, err := generate(pkgName, typeNames)
+	if err != nil {
+		return fmt.Errorf("generate: %w", err)
+	}
+
+	err = os.WriteFile(filepath.Join(dir, output), src, 0o600)
+	if err != nil {
+		return fmt.Errorf("os.WriteFile: %w", err)
+	}
+
+	return nil
+}
+
+// findRedactedTypes return package name and names of struct types having
+// field tagged `redact:"true"`, ordered by file name then declaration. Test
+// files and output are skipped.
+func findRedactedTypes(dir string, output string) (string, []string, error) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, func(fi os.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go") && fi.Name() != output
+	}, parser.SkipObjectResolution)
+	if err != nil {
+		return "", nil, fmt.Errorf("parser.ParseDir: %w", err)
+	}
+	if len(pkgs) != 1 {
+		return "", nil, fmt.Errorf("want 1 package in '%s', got %d", dir, len(pkgs))
+	}
+
+	var pkg *ast.Package
+	for _, p := range pkgs {
+		pkg = p
+	}
+
+	fileNames := make([]string, 0, len(pkg.Files))
+	for fileName := range pkg.Files {
+		fileNames = append(fileNames, fileName)
+	}
+	sort.Strings(fileNames)
+
+	typeNames := []string{}
+	for _, fileName := range fileNames {
+		ast.Inspect(pkg.Files[fileName], func(n ast.Node) bool {
+			typeSpec, ok := n.(*ast.TypeSpec)
+			if !ok {
+				return true
+			}
+			structType, ok := typeSpec.Type.(*ast.StructType)
+			if ok && hasRedactedField(structType) {
+				typeNames = append(typeNames, typeSpec.Name.Name)
+			}
+			return false
+		})
+	}
+
+	return pkg.Name, typeNames, nil
+}
+
+func hasRedactedField(structType *ast.StructType) bool {
+	for _, field := range structType.Fields.List {
+		if field.Tag == nil {
+			continue
+		}
+		tag, err := strconv.Unquote(field.Tag.Value)
+		if err != nil {
+			continue
+		}
+		if reflect.StructTag(tag).Get("redact") == "true" {
+			return true
+		}
+	}
+	return false
+}
+
+// generate return formatted source declaring the methods of typeNames.
+func generate(pkgName string, typeNames []string) ([]byte, error) {
+	b := bytes.Buffer{}
+	b.WriteString("// Code generated by redactgen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&b, "package %s\n\n", pkgName)
+	b.WriteString("import \"log/slog\"\n")
+
+	for _, typeName := range typeNames {
+		fmt.Fprintf(&b, `
+// Redact implements Redactor.
+func (r %[1]s) Redact() any {
+	return redact(r)
+}
+
+// String implements fmt.Stringer, secret is redacted.
+func (r %[1]s) String() string {
+	return redactedString(redact(r))
+}
+
+// LogValue implements slog.LogValuer, secret is redacted.
+func (r %[1]s) LogValue() slog.Value {
+	return redactedLogValue(redact(r))
+}
+`, typeName)
+	}
+
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("format.Source: %w", err)
+	}
+
+	return src, nil
+}
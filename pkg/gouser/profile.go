@@ -2,6 +2,9 @@ package gouser
 
 import (
 	"errors"
+	"fmt"
+	"regexp"
+	"strings"
 	"time"
 
 	"github.com/Hidayathamir/go-user/internal/repo/db/entity"
@@ -26,6 +29,9 @@ type ResGetProfileByUsername struct {
 	Username  string    `json:"username"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
+	// Moved is true when the requested username is an old username of the
+	// user, Username is the current one.
+	Moved bool `json:"moved"`
 }
 
 // LoadEntityUser load from entity.User then return ResGetProfileByUsername.
@@ -38,10 +44,108 @@ func (r ResGetProfileByUsername) LoadEntityUser(user entity.User) ResGetProfileB
 	}
 }
 
+// Username length limit, in characters.
+const (
+	UsernameMinLength = 3
+	UsernameMaxLength = 50
+)
+
+// usernamePattern is charset of username, lowercase so username is unique
+// regardless of case.
+var usernamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+
+// reservedUsernames can not be registered because they collide with API path
+// segments under /api/v1/users, e.g GET /api/v1/users/me.
+var reservedUsernames = map[string]bool{
+	"batch":    true,
+	"export":   true,
+	"id":       true,
+	"me":       true,
+	"username": true,
+}
+
+// NormalizeUsername return username as it is stored, lowercase without
+// surrounding spaces.
+func NormalizeUsername(username string) string {
+	return strings.ToLower(strings.TrimSpace(username))
+}
+
+// validateUsername validate normalized username which is going to be claimed.
+func validateUsername(username string) error {
+	if len(username) < UsernameMinLength || len(username) > UsernameMaxLength {
+		return fmt.Errorf("must be %d to %d characters", UsernameMinLength, UsernameMaxLength)
+	}
+	if !usernamePattern.MatchString(username) {
+		return errors.New("must be lowercase letters, digits, underscore or hyphen, starting with letter or digit")
+	}
+	if reservedUsernames[username] {
+		return fmt.Errorf("'%s' is reserved", username)
+	}
+	return nil
+}
+
+// ReqGetProfileByUserID -.
+type ReqGetProfileByUserID struct {
+	UserID int64 `json:"user_id"`
+}
+
+// Validate validate ReqGetProfileByUserID.
+func (r ReqGetProfileByUserID) Validate() error {
+	if r.UserID == 0 {
+		return errors.New("ReqGetProfileByUserID.UserID can not be empty")
+	}
+	return nil
+}
+
+// ReqGetMyProfile -.
+type ReqGetMyProfile struct {
+	UserJWT string `json:"-" redact:"true"`
+}
+
+// Validate validate ReqGetMyProfile.
+func (r ReqGetMyProfile) Validate() error {
+	if r.UserJWT == "" {
+		return errors.New("ReqGetMyProfile.UserJWT can not be empty")
+	}
+	return nil
+}
+
+// ResGetMyProfile is the owner view of user profile, it contains private
+// fields which ResGetProfileByUsername hides.
+type ResGetMyProfile struct {
+	ID               int64      `json:"id"`
+	Username         string     `json:"username"`
+	Role             string     `json:"role"`
+	Status           string     `json:"status"`
+	SuspendedUntil   *time.Time `json:"suspended_until"`
+	SuspensionReason string     `json:"suspension_reason"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+}
+
+// LoadEntityUser load from entity.User then return ResGetMyProfile.
+func (r ResGetMyProfile) LoadEntityUser(user entity.User) ResGetMyProfile {
+	return ResGetMyProfile{
+		ID:               user.ID,
+		Username:         user.Username,
+		Role:             user.Role,
+		Status:           user.Status,
+		SuspendedUntil:   user.SuspendedUntil,
+		SuspensionReason: user.SuspensionReason,
+		CreatedAt:        user.CreatedAt,
+		UpdatedAt:        user.UpdatedAt,
+	}
+}
+
 // ReqUpdateProfileByUserID -.
 type ReqUpdateProfileByUserID struct {
-	UserJWT  string `json:"-"`
-	Password string `json:"password"`
+	UserJWT  string `json:"-" redact:"true"`
+	Password string `json:"password" redact:"true"`
+	// UserAgent, IP and RequestID are filled by controller from the incoming
+	// request, recorded in audit log.
+	UserAgent string `json:"-"`
+	IP        string `json:"-"`
+	RequestID string `json:"-"`
 }
 
 // Validate validate ReqUpdateProfileByUserID.
@@ -58,3 +162,142 @@ func (r ReqUpdateProfileByUserID) ToEntityUser() entity.User {
 		Password: r.Password,
 	}
 }
+
+// ListUsers sort field and order.
+const (
+	UserSortByID        = "id"
+	UserSortByCreatedAt = "created_at"
+	UserSortByUsername  = "username"
+
+	SortOrderAsc  = "asc"
+	SortOrderDesc = "desc"
+)
+
+// ListUsers page size.
+const (
+	ListUsersDefaultLimit = 20
+	ListUsersMaxLimit     = 100
+)
+
+// User -.
+type User struct {
+	ID               int64      `json:"id"`
+	Username         string     `json:"username"`
+	Role             string     `json:"role"`
+	Status           string     `json:"status"`
+	SuspendedUntil   *time.Time `json:"suspended_until"`
+	SuspensionReason string     `json:"suspension_reason"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+}
+
+// LoadEntityUser load from entity.User then return User.
+func (u User) LoadEntityUser(user entity.User) User {
+	return User{
+		ID:               user.ID,
+		Username:         user.Username,
+		Role:             user.Role,
+		Status:           user.Status,
+		SuspendedUntil:   user.SuspendedUntil,
+		SuspensionReason: user.SuspensionReason,
+		CreatedAt:        user.CreatedAt,
+		UpdatedAt:        user.UpdatedAt,
+	}
+}
+
+// ReqListUsers -.
+type ReqListUsers struct {
+	// UserJWT is admin user JWT.
+	UserJWT string `json:"-" form:"-" redact:"true"`
+	// Cursor is ResListUsers.NextCursor of the previous page, empty for the
+	// first page. It must be used with the same filter and sort.
+	Cursor string `json:"cursor" form:"cursor"`
+	// Limit is page size, default ListUsersDefaultLimit.
+	Limit int `json:"limit" form:"limit"`
+	// Status filter, empty means any status.
+	Status         string `json:"status" form:"status"`
+	UsernamePrefix string `json:"username_prefix" form:"username_prefix"`
+	// CreatedFrom is inclusive, CreatedTo is exclusive.
+	CreatedFrom *time.Time `json:"created_from" form:"created_from"`
+	CreatedTo   *time.Time `json:"created_to" form:"created_to"`
+	// SortBy is "id", "created_at" or "username", default "id".
+	SortBy string `json:"sort_by" form:"sort_by"`
+	// SortOrder is "asc" or "desc", default "asc".
+	SortOrder      string `json:"sort_order" form:"sort_order"`
+	WithTotalCount bool   `json:"with_total_count" form:"with_total_count"`
+}
+
+// Validate validate ReqListUsers.
+func (r ReqListUsers) Validate() error {
+	if r.UserJWT == "" {
+		return errors.New("ReqListUsers.UserJWT can not be empty")
+	}
+	if r.Limit < 0 || r.Limit > ListUsersMaxLimit {
+		return fmt.Errorf("ReqListUsers.Limit must be between 0 and %d", ListUsersMaxLimit)
+	}
+	switch r.Status {
+	case "", entity.UserStatusActive, entity.UserStatusSuspended, entity.UserStatusDisabled:
+	default:
+		return fmt.Errorf("ReqListUsers.Status unknown status '%s'", r.Status)
+	}
+	switch r.SortBy {
+	case "", UserSortByID, UserSortByCreatedAt, UserSortByUsername:
+	default:
+		return fmt.Errorf("ReqListUsers.SortBy unknown sort by '%s'", r.SortBy)
+	}
+	switch r.SortOrder {
+	case "", SortOrderAsc, SortOrderDesc:
+	default:
+		return fmt.Errorf("ReqListUsers.SortOrder unknown sort order '%s'", r.SortOrder)
+	}
+	if r.CreatedFrom != nil && r.CreatedTo != nil && !r.CreatedFrom.Before(*r.CreatedTo) {
+		return errors.New("ReqListUsers.CreatedFrom must be before ReqListUsers.CreatedTo")
+	}
+	return nil
+}
+
+// ResListUsers -.
+type ResListUsers struct {
+	Users []User `json:"users"`
+	// NextCursor is empty when there is no next page.
+	NextCursor string `json:"next_cursor"`
+	// TotalCount is number of users matching the filter, only set when
+	// ReqListUsers.WithTotalCount is true.
+	TotalCount *int64 `json:"total_count,omitempty"`
+}
+
+// BatchGetProfilesMaxSize is the max number of user ids plus usernames in one
+// ReqBatchGetProfiles.
+const BatchGetProfilesMaxSize = 100
+
+// ReqBatchGetProfiles -.
+type ReqBatchGetProfiles struct {
+	UserIDs   []int64  `json:"user_ids"`
+	Usernames []string `json:"usernames"`
+}
+
+// Validate validate ReqBatchGetProfiles.
+func (r ReqBatchGetProfiles) Validate() error {
+	size := len(r.UserIDs) + len(r.Usernames)
+	if size == 0 {
+		return errors.New("ReqBatchGetProfiles.UserIDs and ReqBatchGetProfiles.Usernames can not be both empty")
+	}
+	if size > BatchGetProfilesMaxSize {
+		return fmt.Errorf("ReqBatchGetProfiles can not have more than %d user ids and usernames", BatchGetProfilesMaxSize)
+	}
+	for _, username := range r.Usernames {
+		if username == "" {
+			return errors.New("ReqBatchGetProfiles.Usernames can not contain empty username")
+		}
+	}
+	return nil
+}
+
+// ResBatchGetProfiles -.
+type ResBatchGetProfiles struct {
+	// Profiles follow the request order, user ids first then usernames. A
+	// user requested more than once is returned once.
+	Profiles         []ResGetProfileByUsername `json:"profiles"`
+	MissingUserIDs   []int64                   `json:"missing_user_ids"`
+	MissingUsernames []string                  `json:"missing_usernames"`
+}
@@ -0,0 +1,77 @@
+package gouser
+
+import (
+	"fmt"
+	"log/slog"
+	"reflect"
+	"strings"
+)
+
+// Redacted replace secret value, e.g password or JWT, in log and error.
+const Redacted = "[REDACTED]"
+
+//go:generate go run ./internal/redactgen -output redact_gen.go
+
+// Redactor is implemented by type holding secret in field tagged
+// `redact:"true"`. Such type also implements fmt.Stringer and slog.LogValuer
+// with secret redacted, so it is safe to print or log as is. The methods are
+// generated into redact_gen.go, run go generate after tagging a field.
+type Redactor interface {
+	// Redact return copy with non empty secret replaced by Redacted, safe to
+	// serialize.
+	Redact() any
+}
+
+// redact return copy of struct v with non empty string field tagged
+// `redact:"true"` replaced by Redacted.
+func redact[T any](v T) T {
+	rv := reflect.ValueOf(&v).Elem()
+	for i := 0; i < rv.NumField(); i++ {
+		if rv.Type().Field(i).Tag.Get("redact") != "true" {
+			continue
+		}
+		field := rv.Field(i)
+		if field.Kind() == reflect.String && field.String() != "" {
+			field.SetString(Redacted)
+		}
+	}
+	return v
+}
+
+// redactedString format exported fields of struct v like %+v, v should be
+// redacted already. Fields are formatted one by one so String of v is not
+// called again.
+func redactedString(v any) string {
+	rv := reflect.ValueOf(v)
+
+	b := strings.Builder{}
+	b.WriteString("{")
+	for i := 0; i < rv.NumField(); i++ {
+		if !rv.Type().Field(i).IsExported() {
+			continue
+		}
+		if b.Len() > 1 {
+			b.WriteString(" ")
+		}
+		fmt.Fprintf(&b, "%s:%+v", rv.Type().Field(i).Name, rv.Field(i).Interface())
+	}
+	b.WriteString("}")
+
+	return b.String()
+}
+
+// redactedLogValue return group of exported fields of struct v, v should be
+// redacted already.
+func redactedLogValue(v any) slog.Value {
+	rv := reflect.ValueOf(v)
+
+	attrs := []slog.Attr{}
+	for i := 0; i < rv.NumField(); i++ {
+		if !rv.Type().Field(i).IsExported() {
+			continue
+		}
+		attrs = append(attrs, slog.Any(rv.Type().Field(i).Name, rv.Field(i).Interface()))
+	}
+
+	return slog.GroupValue(attrs...)
+}
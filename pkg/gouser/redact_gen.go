@@ -0,0 +1,365 @@
+// Code generated by redactgen. DO NOT EDIT.
+
+package gouser
+
+import "log/slog"
+
+// Redact implements Redactor.
+func (r ReqDeleteAccount) Redact() any {
+	return redact(r)
+}
+
+// String implements fmt.Stringer, secret is redacted.
+func (r ReqDeleteAccount) String() string {
+	return redactedString(redact(r))
+}
+
+// LogValue implements slog.LogValuer, secret is redacted.
+func (r ReqDeleteAccount) LogValue() slog.Value {
+	return redactedLogValue(redact(r))
+}
+
+// Redact implements Redactor.
+func (r ReqRestoreAccount) Redact() any {
+	return redact(r)
+}
+
+// String implements fmt.Stringer, secret is redacted.
+func (r ReqRestoreAccount) String() string {
+	return redactedString(redact(r))
+}
+
+// LogValue implements slog.LogValuer, secret is redacted.
+func (r ReqRestoreAccount) LogValue() slog.Value {
+	return redactedLogValue(redact(r))
+}
+
+// Redact implements Redactor.
+func (r ReqUpdateUserStatus) Redact() any {
+	return redact(r)
+}
+
+// String implements fmt.Stringer, secret is redacted.
+func (r ReqUpdateUserStatus) String() string {
+	return redactedString(redact(r))
+}
+
+// LogValue implements slog.LogValuer, secret is redacted.
+func (r ReqUpdateUserStatus) LogValue() slog.Value {
+	return redactedLogValue(redact(r))
+}
+
+// Redact implements Redactor.
+func (r ReqChangeUsername) Redact() any {
+	return redact(r)
+}
+
+// String implements fmt.Stringer, secret is redacted.
+func (r ReqChangeUsername) String() string {
+	return redactedString(redact(r))
+}
+
+// LogValue implements slog.LogValuer, secret is redacted.
+func (r ReqChangeUsername) LogValue() slog.Value {
+	return redactedLogValue(redact(r))
+}
+
+// Redact implements Redactor.
+func (r ReqGetAuditLogs) Redact() any {
+	return redact(r)
+}
+
+// String implements fmt.Stringer, secret is redacted.
+func (r ReqGetAuditLogs) String() string {
+	return redactedString(redact(r))
+}
+
+// LogValue implements slog.LogValuer, secret is redacted.
+func (r ReqGetAuditLogs) LogValue() slog.Value {
+	return redactedLogValue(redact(r))
+}
+
+// Redact implements Redactor.
+func (r ReqLoginUser) Redact() any {
+	return redact(r)
+}
+
+// String implements fmt.Stringer, secret is redacted.
+func (r ReqLoginUser) String() string {
+	return redactedString(redact(r))
+}
+
+// LogValue implements slog.LogValuer, secret is redacted.
+func (r ReqLoginUser) LogValue() slog.Value {
+	return redactedLogValue(redact(r))
+}
+
+// Redact implements Redactor.
+func (r ResLoginUser) Redact() any {
+	return redact(r)
+}
+
+// String implements fmt.Stringer, secret is redacted.
+func (r ResLoginUser) String() string {
+	return redactedString(redact(r))
+}
+
+// LogValue implements slog.LogValuer, secret is redacted.
+func (r ResLoginUser) LogValue() slog.Value {
+	return redactedLogValue(redact(r))
+}
+
+// Redact implements Redactor.
+func (r ReqRegisterUser) Redact() any {
+	return redact(r)
+}
+
+// String implements fmt.Stringer, secret is redacted.
+func (r ReqRegisterUser) String() string {
+	return redactedString(redact(r))
+}
+
+// LogValue implements slog.LogValuer, secret is redacted.
+func (r ReqRegisterUser) LogValue() slog.Value {
+	return redactedLogValue(redact(r))
+}
+
+// Redact implements Redactor.
+func (r ReqWatchUsers) Redact() any {
+	return redact(r)
+}
+
+// String implements fmt.Stringer, secret is redacted.
+func (r ReqWatchUsers) String() string {
+	return redactedString(redact(r))
+}
+
+// LogValue implements slog.LogValuer, secret is redacted.
+func (r ReqWatchUsers) LogValue() slog.Value {
+	return redactedLogValue(redact(r))
+}
+
+// Redact implements Redactor.
+func (r ReqExportMyData) Redact() any {
+	return redact(r)
+}
+
+// String implements fmt.Stringer, secret is redacted.
+func (r ReqExportMyData) String() string {
+	return redactedString(redact(r))
+}
+
+// LogValue implements slog.LogValuer, secret is redacted.
+func (r ReqExportMyData) LogValue() slog.Value {
+	return redactedLogValue(redact(r))
+}
+
+// Redact implements Redactor.
+func (r ReqExportUserData) Redact() any {
+	return redact(r)
+}
+
+// String implements fmt.Stringer, secret is redacted.
+func (r ReqExportUserData) String() string {
+	return redactedString(redact(r))
+}
+
+// LogValue implements slog.LogValuer, secret is redacted.
+func (r ReqExportUserData) LogValue() slog.Value {
+	return redactedLogValue(redact(r))
+}
+
+// Redact implements Redactor.
+func (r ReqGetMyProfile) Redact() any {
+	return redact(r)
+}
+
+// String implements fmt.Stringer, secret is redacted.
+func (r ReqGetMyProfile) String() string {
+	return redactedString(redact(r))
+}
+
+// LogValue implements slog.LogValuer, secret is redacted.
+func (r ReqGetMyProfile) LogValue() slog.Value {
+	return redactedLogValue(redact(r))
+}
+
+// Redact implements Redactor.
+func (r ReqUpdateProfileByUserID) Redact() any {
+	return redact(r)
+}
+
+// String implements fmt.Stringer, secret is redacted.
+func (r ReqUpdateProfileByUserID) String() string {
+	return redactedString(redact(r))
+}
+
+// LogValue implements slog.LogValuer, secret is redacted.
+func (r ReqUpdateProfileByUserID) LogValue() slog.Value {
+	return redactedLogValue(redact(r))
+}
+
+// Redact implements Redactor.
+func (r ReqListUsers) Redact() any {
+	return redact(r)
+}
+
+// String implements fmt.Stringer, secret is redacted.
+func (r ReqListUsers) String() string {
+	return redactedString(redact(r))
+}
+
+// LogValue implements slog.LogValuer, secret is redacted.
+func (r ReqListUsers) LogValue() slog.Value {
+	return redactedLogValue(redact(r))
+}
+
+// Redact implements Redactor.
+func (r ReqGetMySessions) Redact() any {
+	return redact(r)
+}
+
+// String implements fmt.Stringer, secret is redacted.
+func (r ReqGetMySessions) String() string {
+	return redactedString(redact(r))
+}
+
+// LogValue implements slog.LogValuer, secret is redacted.
+func (r ReqGetMySessions) LogValue() slog.Value {
+	return redactedLogValue(redact(r))
+}
+
+// Redact implements Redactor.
+func (r ReqRevokeMySession) Redact() any {
+	return redact(r)
+}
+
+// String implements fmt.Stringer, secret is redacted.
+func (r ReqRevokeMySession) String() string {
+	return redactedString(redact(r))
+}
+
+// LogValue implements slog.LogValuer, secret is redacted.
+func (r ReqRevokeMySession) LogValue() slog.Value {
+	return redactedLogValue(redact(r))
+}
+
+// Redact implements Redactor.
+func (r ReqGetSessionsByUserID) Redact() any {
+	return redact(r)
+}
+
+// String implements fmt.Stringer, secret is redacted.
+func (r ReqGetSessionsByUserID) String() string {
+	return redactedString(redact(r))
+}
+
+// LogValue implements slog.LogValuer, secret is redacted.
+func (r ReqGetSessionsByUserID) LogValue() slog.Value {
+	return redactedLogValue(redact(r))
+}
+
+// Redact implements Redactor.
+func (r ReqRevokeSessionByID) Redact() any {
+	return redact(r)
+}
+
+// String implements fmt.Stringer, secret is redacted.
+func (r ReqRevokeSessionByID) String() string {
+	return redactedString(redact(r))
+}
+
+// LogValue implements slog.LogValuer, secret is redacted.
+func (r ReqRevokeSessionByID) LogValue() slog.Value {
+	return redactedLogValue(redact(r))
+}
+
+// Redact implements Redactor.
+func (r ReqCreateWebhookSubscription) Redact() any {
+	return redact(r)
+}
+
+// String implements fmt.Stringer, secret is redacted.
+func (r ReqCreateWebhookSubscription) String() string {
+	return redactedString(redact(r))
+}
+
+// LogValue implements slog.LogValuer, secret is redacted.
+func (r ReqCreateWebhookSubscription) LogValue() slog.Value {
+	return redactedLogValue(redact(r))
+}
+
+// Redact implements Redactor.
+func (r ReqGetWebhookSubscriptions) Redact() any {
+	return redact(r)
+}
+
+// String implements fmt.Stringer, secret is redacted.
+func (r ReqGetWebhookSubscriptions) String() string {
+	return redactedString(redact(r))
+}
+
+// LogValue implements slog.LogValuer, secret is redacted.
+func (r ReqGetWebhookSubscriptions) LogValue() slog.Value {
+	return redactedLogValue(redact(r))
+}
+
+// Redact implements Redactor.
+func (r ReqUpdateWebhookSubscription) Redact() any {
+	return redact(r)
+}
+
+// String implements fmt.Stringer, secret is redacted.
+func (r ReqUpdateWebhookSubscription) String() string {
+	return redactedString(redact(r))
+}
+
+// LogValue implements slog.LogValuer, secret is redacted.
+func (r ReqUpdateWebhookSubscription) LogValue() slog.Value {
+	return redactedLogValue(redact(r))
+}
+
+// Redact implements Redactor.
+func (r ReqDeleteWebhookSubscription) Redact() any {
+	return redact(r)
+}
+
+// String implements fmt.Stringer, secret is redacted.
+func (r ReqDeleteWebhookSubscription) String() string {
+	return redactedString(redact(r))
+}
+
+// LogValue implements slog.LogValuer, secret is redacted.
+func (r ReqDeleteWebhookSubscription) LogValue() slog.Value {
+	return redactedLogValue(redact(r))
+}
+
+// Redact implements Redactor.
+func (r ReqGetWebhookDeliveries) Redact() any {
+	return redact(r)
+}
+
+// String implements fmt.Stringer, secret is redacted.
+func (r ReqGetWebhookDeliveries) String() string {
+	return redactedString(redact(r))
+}
+
+// LogValue implements slog.LogValuer, secret is redacted.
+func (r ReqGetWebhookDeliveries) LogValue() slog.Value {
+	return redactedLogValue(redact(r))
+}
+
+// Redact implements Redactor.
+func (r ReqRedeliverWebhook) Redact() any {
+	return redact(r)
+}
+
+// String implements fmt.Stringer, secret is redacted.
+func (r ReqRedeliverWebhook) String() string {
+	return redactedString(redact(r))
+}
+
+// LogValue implements slog.LogValuer, secret is redacted.
+func (r ReqRedeliverWebhook) LogValue() slog.Value {
+	return redactedLogValue(redact(r))
+}
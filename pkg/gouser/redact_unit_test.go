@@ -0,0 +1,145 @@
+package gouser
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"log/slog"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// redactors list every type holding secret, TestUnitRedactTag checks it is
+// complete.
+var redactors = []Redactor{
+	ReqDeleteAccount{}, ReqRestoreAccount{}, ReqUpdateUserStatus{}, ReqChangeUsername{},
+	ReqGetAuditLogs{},
+	ReqLoginUser{}, ResLoginUser{}, ReqRegisterUser{},
+	ReqWatchUsers{},
+	ReqExportMyData{}, ReqExportUserData{},
+	ReqGetMyProfile{}, ReqUpdateProfileByUserID{}, ReqListUsers{},
+	ReqGetMySessions{}, ReqRevokeMySession{}, ReqGetSessionsByUserID{}, ReqRevokeSessionByID{},
+	ReqCreateWebhookSubscription{}, ReqGetWebhookSubscriptions{}, ReqUpdateWebhookSubscription{},
+	ReqDeleteWebhookSubscription{}, ReqGetWebhookDeliveries{}, ReqRedeliverWebhook{},
+}
+
+func TestUnitRedactor(t *testing.T) {
+	t.Parallel()
+
+	// withSecret return copy of v with every field tagged redact set to
+	// secret.
+	withSecret := func(t *testing.T, v Redactor, secret string) Redactor { //nolint:ireturn
+		t.Helper()
+		rv := reflect.New(reflect.TypeOf(v)).Elem()
+		rv.Set(reflect.ValueOf(v))
+		count := 0
+		for i := 0; i < rv.NumField(); i++ {
+			if rv.Type().Field(i).Tag.Get("redact") == "true" {
+				rv.Field(i).SetString(secret)
+				count++
+			}
+		}
+		require.Positive(t, count, "%T has no field tagged redact", v)
+		redactor, ok := rv.Interface().(Redactor)
+		require.True(t, ok)
+		return redactor
+	}
+
+	t.Run("secret should not appear in string, log value and json", func(t *testing.T) {
+		t.Parallel()
+
+		for _, redactor := range redactors {
+			secret := fmt.Sprintf("s3cr3t-%T", redactor)
+			v := withSecret(t, redactor, secret)
+
+			assert.NotContains(t, fmt.Sprint(v), secret)
+			assert.NotContains(t, fmt.Sprintf("%+v", v), secret)
+			assert.NotContains(t, fmt.Sprintf("%v", []any{v}), secret)
+
+			buf := &bytes.Buffer{}
+			slog.New(slog.NewJSONHandler(buf, nil)).Info("request", "req", v)
+			assert.NotContains(t, buf.String(), secret)
+			assert.Contains(t, buf.String(), Redacted)
+
+			jsonByte, err := json.Marshal(v.Redact())
+			require.NoError(t, err)
+			assert.NotContains(t, string(jsonByte), secret)
+		}
+	})
+	t.Run("non secret field should be kept and empty secret stay empty", func(t *testing.T) {
+		t.Parallel()
+
+		req := ReqLoginUser{Username: "hidayat", IP: "192.0.2.1"}
+
+		assert.Equal(t, "{Username:hidayat Password: UserAgent: IP:192.0.2.1 RequestID:}", req.String())
+		assert.Equal(t, req, req.Redact())
+	})
+	t.Run("redact should not modify original", func(t *testing.T) {
+		t.Parallel()
+
+		req := ReqLoginUser{Username: "hidayat", Password: "mypassword"}
+
+		redacted, ok := req.Redact().(ReqLoginUser)
+		require.True(t, ok)
+
+		assert.Equal(t, Redacted, redacted.Password)
+		assert.Equal(t, "mypassword", req.Password)
+		assert.Equal(t, "{Username:hidayat Password:[REDACTED] UserAgent: IP: RequestID:}", req.String())
+	})
+}
+
+func TestUnitRedactTag(t *testing.T) {
+	t.Parallel()
+
+	redactorNames := map[string]bool{}
+	for _, redactor := range redactors {
+		redactorNames[reflect.TypeOf(redactor).Name()] = true
+		assert.Implements(t, (*fmt.Stringer)(nil), redactor)
+		assert.Implements(t, (*slog.LogValuer)(nil), redactor)
+	}
+
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, ".", func(fi os.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go")
+	}, parser.SkipObjectResolution)
+	require.NoError(t, err)
+	require.Contains(t, pkgs, "gouser")
+
+	taggedNames := []string{}
+	for fileName, file := range pkgs["gouser"].Files {
+		ast.Inspect(file, func(n ast.Node) bool {
+			typeSpec, ok := n.(*ast.TypeSpec)
+			if !ok || !typeSpec.Name.IsExported() {
+				return true
+			}
+			structType, ok := typeSpec.Type.(*ast.StructType)
+			if !ok {
+				return false
+			}
+			for _, field := range structType.Fields.List {
+				if field.Tag == nil {
+					continue
+				}
+				tag, err := strconv.Unquote(field.Tag.Value)
+				require.NoError(t, err)
+				if _, ok := reflect.StructTag(tag).Lookup("redact"); ok {
+					taggedNames = append(taggedNames, typeSpec.Name.Name)
+					assert.True(t, redactorNames[typeSpec.Name.Name], "%s in %s has field tagged redact but is not a Redactor in redactors, run go generate and add it", typeSpec.Name.Name, fileName)
+					break
+				}
+			}
+			return false
+		})
+	}
+
+	assert.Len(t, taggedNames, len(redactors), "every Redactor in redactors should have field tagged redact")
+}
@@ -0,0 +1,17 @@
+package gouser
+
+import "context"
+
+type requestIDKey struct{}
+
+// WithRequestID return ctx carrying request id. Server put id of incoming
+// request, client put id to be sent in outgoing request.
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, requestID)
+}
+
+// RequestIDFromContext return request id carried by ctx, empty if none.
+func RequestIDFromContext(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDKey{}).(string)
+	return requestID
+}
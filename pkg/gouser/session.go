@@ -0,0 +1,124 @@
+package gouser
+
+import (
+	"errors"
+	"time"
+
+	"github.com/Hidayathamir/go-user/internal/repo/db/entity"
+)
+
+// Session -.
+type Session struct {
+	ID         int64     `json:"id"`
+	UserAgent  string    `json:"user_agent"`
+	IP         string    `json:"ip"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+	ExpiredAt  time.Time `json:"expired_at"`
+	// IsCurrent is true when the session is the one carried by the request
+	// user JWT.
+	IsCurrent bool `json:"is_current"`
+}
+
+// LoadEntitySession load from entity.Session then return Session.
+func (s Session) LoadEntitySession(session entity.Session, currentJTI string) Session {
+	return Session{
+		ID:         session.ID,
+		UserAgent:  session.UserAgent,
+		IP:         session.IP,
+		CreatedAt:  session.CreatedAt,
+		LastSeenAt: session.LastSeenAt,
+		ExpiredAt:  session.ExpiredAt,
+		IsCurrent:  currentJTI != "" && session.JTI == currentJTI,
+	}
+}
+
+// ResGetSessions -.
+type ResGetSessions struct {
+	Sessions []Session `json:"sessions"`
+}
+
+// LoadEntitySessions load from []entity.Session then return ResGetSessions.
+func (r ResGetSessions) LoadEntitySessions(sessions []entity.Session, currentJTI string) ResGetSessions {
+	res := ResGetSessions{Sessions: make([]Session, 0, len(sessions))}
+	for _, session := range sessions {
+		res.Sessions = append(res.Sessions, Session{}.LoadEntitySession(session, currentJTI))
+	}
+	return res
+}
+
+// ReqGetMySessions -.
+type ReqGetMySessions struct {
+	UserJWT string `json:"-" redact:"true"`
+}
+
+// Validate validate ReqGetMySessions.
+func (r ReqGetMySessions) Validate() error {
+	if r.UserJWT == "" {
+		return errors.New("ReqGetMySessions.UserJWT can not be empty")
+	}
+	return nil
+}
+
+// ReqRevokeMySession -.
+type ReqRevokeMySession struct {
+	UserJWT   string `json:"-" redact:"true"`
+	SessionID int64  `json:"session_id"`
+	// UserAgent, IP and RequestID are filled by controller from the incoming
+	// request, recorded in audit log.
+	UserAgent string `json:"-"`
+	IP        string `json:"-"`
+	RequestID string `json:"-"`
+}
+
+// Validate validate ReqRevokeMySession.
+func (r ReqRevokeMySession) Validate() error {
+	if r.UserJWT == "" {
+		return errors.New("ReqRevokeMySession.UserJWT can not be empty")
+	}
+	if r.SessionID == 0 {
+		return errors.New("ReqRevokeMySession.SessionID can not be empty")
+	}
+	return nil
+}
+
+// ReqGetSessionsByUserID -.
+type ReqGetSessionsByUserID struct {
+	// UserJWT is admin user JWT.
+	UserJWT string `json:"-" redact:"true"`
+	UserID  int64  `json:"user_id"`
+}
+
+// Validate validate ReqGetSessionsByUserID.
+func (r ReqGetSessionsByUserID) Validate() error {
+	if r.UserJWT == "" {
+		return errors.New("ReqGetSessionsByUserID.UserJWT can not be empty")
+	}
+	if r.UserID == 0 {
+		return errors.New("ReqGetSessionsByUserID.UserID can not be empty")
+	}
+	return nil
+}
+
+// ReqRevokeSessionByID -.
+type ReqRevokeSessionByID struct {
+	// UserJWT is admin user JWT.
+	UserJWT   string `json:"-" redact:"true"`
+	SessionID int64  `json:"session_id"`
+	// UserAgent, IP and RequestID are filled by controller from the incoming
+	// request, recorded in audit log.
+	UserAgent string `json:"-"`
+	IP        string `json:"-"`
+	RequestID string `json:"-"`
+}
+
+// Validate validate ReqRevokeSessionByID.
+func (r ReqRevokeSessionByID) Validate() error {
+	if r.UserJWT == "" {
+		return errors.New("ReqRevokeSessionByID.UserJWT can not be empty")
+	}
+	if r.SessionID == 0 {
+		return errors.New("ReqRevokeSessionByID.SessionID can not be empty")
+	}
+	return nil
+}
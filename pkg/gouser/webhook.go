@@ -0,0 +1,338 @@
+package gouser
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/Hidayathamir/go-user/internal/repo/db/entity"
+)
+
+// Webhook request header sent to the receiver.
+const (
+	// HeaderWebhookDelivery is the delivery id, redelivery keeps the id.
+	HeaderWebhookDelivery = "X-Webhook-Delivery"
+	// HeaderWebhookEvent is the event type.
+	HeaderWebhookEvent = "X-Webhook-Event"
+	// HeaderWebhookTimestamp is unix second when the request is sent.
+	HeaderWebhookTimestamp = "X-Webhook-Timestamp"
+	// HeaderWebhookSignature is the payload signature, see
+	// SignWebhookPayload.
+	HeaderWebhookSignature = "X-Webhook-Signature"
+)
+
+// WebhookSecretMinLength is minimum length of webhook subscription secret.
+const WebhookSecretMinLength = 16
+
+const (
+	GetWebhookDeliveriesDefaultLimit = 20
+	GetWebhookDeliveriesMaxLimit     = 100
+)
+
+// SignWebhookPayload return signature of webhook payload sent at timestamp,
+// it is "sha256=" followed by hex of HMAC-SHA256 of "<timestamp>.<payload>"
+// keyed by subscription secret.
+func SignWebhookPayload(secret string, timestamp int64, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifyWebhookSignature return true if signature is the signature of payload
+// sent at timestamp. Receiver should also refuse old timestamp to prevent
+// replay.
+func VerifyWebhookSignature(secret string, timestamp int64, payload []byte, signature string) bool {
+	expected := SignWebhookPayload(secret, timestamp, payload)
+	return hmac.Equal([]byte(expected), []byte(signature))
+}
+
+// WebhookSubscription -. Secret is never returned.
+type WebhookSubscription struct {
+	ID         int64     `json:"id"`
+	URL        string    `json:"url"`
+	EventTypes []string  `json:"event_types"`
+	IsActive   bool      `json:"is_active"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// LoadEntityWebhookSubscription load from entity.WebhookSubscription then
+// return WebhookSubscription.
+func (w WebhookSubscription) LoadEntityWebhookSubscription(subscription entity.WebhookSubscription) WebhookSubscription {
+	return WebhookSubscription{
+		ID:         subscription.ID,
+		URL:        subscription.URL,
+		EventTypes: subscription.EventTypes,
+		IsActive:   subscription.IsActive,
+		CreatedAt:  subscription.CreatedAt,
+		UpdatedAt:  subscription.UpdatedAt,
+	}
+}
+
+// ReqCreateWebhookSubscription -.
+type ReqCreateWebhookSubscription struct {
+	// UserJWT is admin user JWT.
+	UserJWT    string   `json:"-" redact:"true"`
+	URL        string   `json:"url"`
+	EventTypes []string `json:"event_types"`
+	Secret     string   `json:"secret" redact:"true"`
+}
+
+// Validate validate ReqCreateWebhookSubscription.
+func (r ReqCreateWebhookSubscription) Validate() error {
+	if r.UserJWT == "" {
+		return errors.New("ReqCreateWebhookSubscription.UserJWT can not be empty")
+	}
+	err := validateWebhookSubscription(r.URL, r.EventTypes)
+	if err != nil {
+		return fmt.Errorf("ReqCreateWebhookSubscription: %w", err)
+	}
+	if len(r.Secret) < WebhookSecretMinLength {
+		return fmt.Errorf("ReqCreateWebhookSubscription.Secret must be at least %d characters", WebhookSecretMinLength)
+	}
+	return nil
+}
+
+// ToEntityWebhookSubscription return entity.WebhookSubscription, new
+// subscription is active.
+func (r ReqCreateWebhookSubscription) ToEntityWebhookSubscription() entity.WebhookSubscription {
+	return entity.WebhookSubscription{
+		URL:        r.URL,
+		EventTypes: r.EventTypes,
+		Secret:     r.Secret,
+		IsActive:   true,
+	}
+}
+
+// ResCreateWebhookSubscription -.
+type ResCreateWebhookSubscription struct {
+	ID int64 `json:"id"`
+}
+
+// ReqGetWebhookSubscriptions -.
+type ReqGetWebhookSubscriptions struct {
+	// UserJWT is admin user JWT.
+	UserJWT string `json:"-" redact:"true"`
+}
+
+// Validate validate ReqGetWebhookSubscriptions.
+func (r ReqGetWebhookSubscriptions) Validate() error {
+	if r.UserJWT == "" {
+		return errors.New("ReqGetWebhookSubscriptions.UserJWT can not be empty")
+	}
+	return nil
+}
+
+// ResGetWebhookSubscriptions -.
+type ResGetWebhookSubscriptions struct {
+	Subscriptions []WebhookSubscription `json:"subscriptions"`
+}
+
+// LoadEntityWebhookSubscriptions load from []entity.WebhookSubscription then
+// return ResGetWebhookSubscriptions.
+func (r ResGetWebhookSubscriptions) LoadEntityWebhookSubscriptions(subscriptions []entity.WebhookSubscription) ResGetWebhookSubscriptions {
+	res := ResGetWebhookSubscriptions{Subscriptions: make([]WebhookSubscription, 0, len(subscriptions))}
+	for _, subscription := range subscriptions {
+		res.Subscriptions = append(res.Subscriptions, WebhookSubscription{}.LoadEntityWebhookSubscription(subscription))
+	}
+	return res
+}
+
+// ReqUpdateWebhookSubscription -. It replaces url, event types and active
+// flag, secret is kept when empty.
+type ReqUpdateWebhookSubscription struct {
+	// UserJWT is admin user JWT.
+	UserJWT        string   `json:"-" redact:"true"`
+	SubscriptionID int64    `json:"-"`
+	URL            string   `json:"url"`
+	EventTypes     []string `json:"event_types"`
+	IsActive       bool     `json:"is_active"`
+	Secret         string   `json:"secret" redact:"true"`
+}
+
+// Validate validate ReqUpdateWebhookSubscription.
+func (r ReqUpdateWebhookSubscription) Validate() error {
+	if r.UserJWT == "" {
+		return errors.New("ReqUpdateWebhookSubscription.UserJWT can not be empty")
+	}
+	if r.SubscriptionID == 0 {
+		return errors.New("ReqUpdateWebhookSubscription.SubscriptionID can not be empty")
+	}
+	err := validateWebhookSubscription(r.URL, r.EventTypes)
+	if err != nil {
+		return fmt.Errorf("ReqUpdateWebhookSubscription: %w", err)
+	}
+	if r.Secret != "" && len(r.Secret) < WebhookSecretMinLength {
+		return fmt.Errorf("ReqUpdateWebhookSubscription.Secret must be at least %d characters", WebhookSecretMinLength)
+	}
+	return nil
+}
+
+// ToEntityWebhookSubscription return entity.WebhookSubscription.
+func (r ReqUpdateWebhookSubscription) ToEntityWebhookSubscription() entity.WebhookSubscription {
+	return entity.WebhookSubscription{
+		ID:         r.SubscriptionID,
+		URL:        r.URL,
+		EventTypes: r.EventTypes,
+		Secret:     r.Secret,
+		IsActive:   r.IsActive,
+	}
+}
+
+// ReqDeleteWebhookSubscription -.
+type ReqDeleteWebhookSubscription struct {
+	// UserJWT is admin user JWT.
+	UserJWT        string `json:"-" redact:"true"`
+	SubscriptionID int64  `json:"subscription_id"`
+}
+
+// Validate validate ReqDeleteWebhookSubscription.
+func (r ReqDeleteWebhookSubscription) Validate() error {
+	if r.UserJWT == "" {
+		return errors.New("ReqDeleteWebhookSubscription.UserJWT can not be empty")
+	}
+	if r.SubscriptionID == 0 {
+		return errors.New("ReqDeleteWebhookSubscription.SubscriptionID can not be empty")
+	}
+	return nil
+}
+
+// WebhookDeliveryAttempt -.
+type WebhookDeliveryAttempt struct {
+	AttemptedAt time.Time `json:"attempted_at"`
+	// StatusCode is 0 when receiver does not respond.
+	StatusCode int    `json:"status_code"`
+	Error      string `json:"error"`
+	DurationMS int64  `json:"duration_ms"`
+}
+
+// WebhookDelivery -.
+type WebhookDelivery struct {
+	ID             int64     `json:"id"`
+	SubscriptionID int64     `json:"subscription_id"`
+	EventID        int64     `json:"event_id"`
+	EventType      string    `json:"event_type"`
+	Status         string    `json:"status"`
+	Attempt        int       `json:"attempt"`
+	NextAttemptAt  time.Time `json:"next_attempt_at"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+	// Attempts is log of delivery attempts, oldest first.
+	Attempts []WebhookDeliveryAttempt `json:"attempts"`
+}
+
+// ReqGetWebhookDeliveries -.
+type ReqGetWebhookDeliveries struct {
+	// UserJWT is admin user JWT.
+	UserJWT        string `json:"-" form:"-" redact:"true"`
+	SubscriptionID int64  `json:"-" form:"-"`
+	// Status filter, empty means any status.
+	Status string `json:"status" form:"status"`
+	// Limit is number of the latest deliveries, default
+	// GetWebhookDeliveriesDefaultLimit.
+	Limit int `json:"limit" form:"limit"`
+}
+
+// Validate validate ReqGetWebhookDeliveries.
+func (r ReqGetWebhookDeliveries) Validate() error {
+	if r.UserJWT == "" {
+		return errors.New("ReqGetWebhookDeliveries.UserJWT can not be empty")
+	}
+	if r.SubscriptionID == 0 {
+		return errors.New("ReqGetWebhookDeliveries.SubscriptionID can not be empty")
+	}
+	switch r.Status {
+	case "", entity.WebhookDeliveryStatusPending, entity.WebhookDeliveryStatusSucceeded, entity.WebhookDeliveryStatusDead:
+	default:
+		return fmt.Errorf("ReqGetWebhookDeliveries.Status unknown status '%s'", r.Status)
+	}
+	if r.Limit < 0 || r.Limit > GetWebhookDeliveriesMaxLimit {
+		return fmt.Errorf("ReqGetWebhookDeliveries.Limit must be between 0 and %d", GetWebhookDeliveriesMaxLimit)
+	}
+	return nil
+}
+
+// ResGetWebhookDeliveries -.
+type ResGetWebhookDeliveries struct {
+	// Deliveries is newest first.
+	Deliveries []WebhookDelivery `json:"deliveries"`
+}
+
+// LoadEntityWebhookDeliveries load from []entity.WebhookDelivery and their
+// []entity.WebhookDeliveryAttempt then return ResGetWebhookDeliveries.
+func (r ResGetWebhookDeliveries) LoadEntityWebhookDeliveries(deliveries []entity.WebhookDelivery, attempts []entity.WebhookDeliveryAttempt) ResGetWebhookDeliveries {
+	attemptsByDeliveryID := map[int64][]WebhookDeliveryAttempt{}
+	for _, attempt := range attempts {
+		attemptsByDeliveryID[attempt.DeliveryID] = append(attemptsByDeliveryID[attempt.DeliveryID], WebhookDeliveryAttempt{
+			AttemptedAt: attempt.AttemptedAt,
+			StatusCode:  attempt.StatusCode,
+			Error:       attempt.Error,
+			DurationMS:  attempt.DurationMS,
+		})
+	}
+
+	res := ResGetWebhookDeliveries{Deliveries: make([]WebhookDelivery, 0, len(deliveries))}
+	for _, delivery := range deliveries {
+		deliveryAttempts := attemptsByDeliveryID[delivery.ID]
+		if deliveryAttempts == nil {
+			deliveryAttempts = []WebhookDeliveryAttempt{}
+		}
+		res.Deliveries = append(res.Deliveries, WebhookDelivery{
+			ID:             delivery.ID,
+			SubscriptionID: delivery.SubscriptionID,
+			EventID:        delivery.EventID,
+			EventType:      delivery.EventType,
+			Status:         delivery.Status,
+			Attempt:        delivery.Attempt,
+			NextAttemptAt:  delivery.NextAttemptAt,
+			CreatedAt:      delivery.CreatedAt,
+			UpdatedAt:      delivery.UpdatedAt,
+			Attempts:       deliveryAttempts,
+		})
+	}
+	return res
+}
+
+// ReqRedeliverWebhook -.
+type ReqRedeliverWebhook struct {
+	// UserJWT is admin user JWT.
+	UserJWT    string `json:"-" redact:"true"`
+	DeliveryID int64  `json:"delivery_id"`
+}
+
+// Validate validate ReqRedeliverWebhook.
+func (r ReqRedeliverWebhook) Validate() error {
+	if r.UserJWT == "" {
+		return errors.New("ReqRedeliverWebhook.UserJWT can not be empty")
+	}
+	if r.DeliveryID == 0 {
+		return errors.New("ReqRedeliverWebhook.DeliveryID can not be empty")
+	}
+	return nil
+}
+
+func validateWebhookSubscription(rawURL string, eventTypes []string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("URL invalid: %w", err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("URL must be absolute http or https URL, got '%s'", rawURL)
+	}
+	if len(eventTypes) == 0 {
+		return errors.New("EventTypes can not be empty")
+	}
+	for _, eventType := range eventTypes {
+		if !IsEventType(eventType) {
+			return fmt.Errorf("EventTypes unknown event type '%s'", eventType)
+		}
+	}
+	return nil
+}
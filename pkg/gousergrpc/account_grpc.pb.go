@@ -0,0 +1,213 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.12.4
+// source: pkg/gousergrpc/account.proto
+
+package gousergrpc
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// AccountClient is the client API for Account service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type AccountClient interface {
+	DeleteAccount(ctx context.Context, in *ReqDeleteAccount, opts ...grpc.CallOption) (*AccountEmpty, error)
+	RestoreAccount(ctx context.Context, in *ReqRestoreAccount, opts ...grpc.CallOption) (*AccountEmpty, error)
+	UpdateUserStatus(ctx context.Context, in *ReqUpdateUserStatus, opts ...grpc.CallOption) (*AccountEmpty, error)
+	ChangeUsername(ctx context.Context, in *ReqChangeUsername, opts ...grpc.CallOption) (*AccountEmpty, error)
+}
+
+type accountClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAccountClient(cc grpc.ClientConnInterface) AccountClient {
+	return &accountClient{cc}
+}
+
+func (c *accountClient) DeleteAccount(ctx context.Context, in *ReqDeleteAccount, opts ...grpc.CallOption) (*AccountEmpty, error) {
+	out := new(AccountEmpty)
+	err := c.cc.Invoke(ctx, "/gousergrpc.Account/DeleteAccount", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountClient) RestoreAccount(ctx context.Context, in *ReqRestoreAccount, opts ...grpc.CallOption) (*AccountEmpty, error) {
+	out := new(AccountEmpty)
+	err := c.cc.Invoke(ctx, "/gousergrpc.Account/RestoreAccount", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountClient) UpdateUserStatus(ctx context.Context, in *ReqUpdateUserStatus, opts ...grpc.CallOption) (*AccountEmpty, error) {
+	out := new(AccountEmpty)
+	err := c.cc.Invoke(ctx, "/gousergrpc.Account/UpdateUserStatus", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountClient) ChangeUsername(ctx context.Context, in *ReqChangeUsername, opts ...grpc.CallOption) (*AccountEmpty, error) {
+	out := new(AccountEmpty)
+	err := c.cc.Invoke(ctx, "/gousergrpc.Account/ChangeUsername", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AccountServer is the server API for Account service.
+// All implementations must embed UnimplementedAccountServer
+// for forward compatibility
+type AccountServer interface {
+	DeleteAccount(context.Context, *ReqDeleteAccount) (*AccountEmpty, error)
+	RestoreAccount(context.Context, *ReqRestoreAccount) (*AccountEmpty, error)
+	UpdateUserStatus(context.Context, *ReqUpdateUserStatus) (*AccountEmpty, error)
+	ChangeUsername(context.Context, *ReqChangeUsername) (*AccountEmpty, error)
+	mustEmbedUnimplementedAccountServer()
+}
+
+// UnimplementedAccountServer must be embedded to have forward compatible implementations.
+type UnimplementedAccountServer struct {
+}
+
+func (UnimplementedAccountServer) DeleteAccount(context.Context, *ReqDeleteAccount) (*AccountEmpty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteAccount not implemented")
+}
+func (UnimplementedAccountServer) RestoreAccount(context.Context, *ReqRestoreAccount) (*AccountEmpty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreAccount not implemented")
+}
+func (UnimplementedAccountServer) UpdateUserStatus(context.Context, *ReqUpdateUserStatus) (*AccountEmpty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateUserStatus not implemented")
+}
+func (UnimplementedAccountServer) ChangeUsername(context.Context, *ReqChangeUsername) (*AccountEmpty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ChangeUsername not implemented")
+}
+func (UnimplementedAccountServer) mustEmbedUnimplementedAccountServer() {}
+
+// UnsafeAccountServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AccountServer will
+// result in compilation errors.
+type UnsafeAccountServer interface {
+	mustEmbedUnimplementedAccountServer()
+}
+
+func RegisterAccountServer(s grpc.ServiceRegistrar, srv AccountServer) {
+	s.RegisterService(&Account_ServiceDesc, srv)
+}
+
+func _Account_DeleteAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReqDeleteAccount)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServer).DeleteAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gousergrpc.Account/DeleteAccount",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServer).DeleteAccount(ctx, req.(*ReqDeleteAccount))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Account_RestoreAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReqRestoreAccount)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServer).RestoreAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gousergrpc.Account/RestoreAccount",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServer).RestoreAccount(ctx, req.(*ReqRestoreAccount))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Account_UpdateUserStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReqUpdateUserStatus)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServer).UpdateUserStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gousergrpc.Account/UpdateUserStatus",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServer).UpdateUserStatus(ctx, req.(*ReqUpdateUserStatus))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Account_ChangeUsername_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReqChangeUsername)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServer).ChangeUsername(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gousergrpc.Account/ChangeUsername",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServer).ChangeUsername(ctx, req.(*ReqChangeUsername))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Account_ServiceDesc is the grpc.ServiceDesc for Account service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Account_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "gousergrpc.Account",
+	HandlerType: (*AccountServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "DeleteAccount",
+			Handler:    _Account_DeleteAccount_Handler,
+		},
+		{
+			MethodName: "RestoreAccount",
+			Handler:    _Account_RestoreAccount_Handler,
+		},
+		{
+			MethodName: "UpdateUserStatus",
+			Handler:    _Account_UpdateUserStatus_Handler,
+		},
+		{
+			MethodName: "ChangeUsername",
+			Handler:    _Account_ChangeUsername_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "pkg/gousergrpc/account.proto",
+}
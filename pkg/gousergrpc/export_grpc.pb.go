@@ -0,0 +1,195 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.12.4
+// source: pkg/gousergrpc/export.proto
+
+package gousergrpc
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// ExportClient is the client API for Export service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ExportClient interface {
+	ExportMyData(ctx context.Context, in *ReqExportMyData, opts ...grpc.CallOption) (Export_ExportMyDataClient, error)
+	ExportUserData(ctx context.Context, in *ReqExportUserData, opts ...grpc.CallOption) (Export_ExportUserDataClient, error)
+}
+
+type exportClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewExportClient(cc grpc.ClientConnInterface) ExportClient {
+	return &exportClient{cc}
+}
+
+func (c *exportClient) ExportMyData(ctx context.Context, in *ReqExportMyData, opts ...grpc.CallOption) (Export_ExportMyDataClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Export_ServiceDesc.Streams[0], "/gousergrpc.Export/ExportMyData", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &exportExportMyDataClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Export_ExportMyDataClient interface {
+	Recv() (*ExportChunk, error)
+	grpc.ClientStream
+}
+
+type exportExportMyDataClient struct {
+	grpc.ClientStream
+}
+
+func (x *exportExportMyDataClient) Recv() (*ExportChunk, error) {
+	m := new(ExportChunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *exportClient) ExportUserData(ctx context.Context, in *ReqExportUserData, opts ...grpc.CallOption) (Export_ExportUserDataClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Export_ServiceDesc.Streams[1], "/gousergrpc.Export/ExportUserData", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &exportExportUserDataClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Export_ExportUserDataClient interface {
+	Recv() (*ExportChunk, error)
+	grpc.ClientStream
+}
+
+type exportExportUserDataClient struct {
+	grpc.ClientStream
+}
+
+func (x *exportExportUserDataClient) Recv() (*ExportChunk, error) {
+	m := new(ExportChunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// ExportServer is the server API for Export service.
+// All implementations must embed UnimplementedExportServer
+// for forward compatibility
+type ExportServer interface {
+	ExportMyData(*ReqExportMyData, Export_ExportMyDataServer) error
+	ExportUserData(*ReqExportUserData, Export_ExportUserDataServer) error
+	mustEmbedUnimplementedExportServer()
+}
+
+// UnimplementedExportServer must be embedded to have forward compatible implementations.
+type UnimplementedExportServer struct {
+}
+
+func (UnimplementedExportServer) ExportMyData(*ReqExportMyData, Export_ExportMyDataServer) error {
+	return status.Errorf(codes.Unimplemented, "method ExportMyData not implemented")
+}
+func (UnimplementedExportServer) ExportUserData(*ReqExportUserData, Export_ExportUserDataServer) error {
+	return status.Errorf(codes.Unimplemented, "method ExportUserData not implemented")
+}
+func (UnimplementedExportServer) mustEmbedUnimplementedExportServer() {}
+
+// UnsafeExportServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ExportServer will
+// result in compilation errors.
+type UnsafeExportServer interface {
+	mustEmbedUnimplementedExportServer()
+}
+
+func RegisterExportServer(s grpc.ServiceRegistrar, srv ExportServer) {
+	s.RegisterService(&Export_ServiceDesc, srv)
+}
+
+func _Export_ExportMyData_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ReqExportMyData)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ExportServer).ExportMyData(m, &exportExportMyDataServer{stream})
+}
+
+type Export_ExportMyDataServer interface {
+	Send(*ExportChunk) error
+	grpc.ServerStream
+}
+
+type exportExportMyDataServer struct {
+	grpc.ServerStream
+}
+
+func (x *exportExportMyDataServer) Send(m *ExportChunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Export_ExportUserData_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ReqExportUserData)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ExportServer).ExportUserData(m, &exportExportUserDataServer{stream})
+}
+
+type Export_ExportUserDataServer interface {
+	Send(*ExportChunk) error
+	grpc.ServerStream
+}
+
+type exportExportUserDataServer struct {
+	grpc.ServerStream
+}
+
+func (x *exportExportUserDataServer) Send(m *ExportChunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// Export_ServiceDesc is the grpc.ServiceDesc for Export service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Export_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "gousergrpc.Export",
+	HandlerType: (*ExportServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ExportMyData",
+			Handler:       _Export_ExportMyData_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ExportUserData",
+			Handler:       _Export_ExportUserData_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "pkg/gousergrpc/export.proto",
+}
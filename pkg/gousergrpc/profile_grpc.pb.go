@@ -23,7 +23,12 @@ const _ = grpc.SupportPackageIsVersion7
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type ProfileClient interface {
 	GetProfileByUsername(ctx context.Context, in *ReqGetProfileByUsername, opts ...grpc.CallOption) (*ResGetProfileByUsername, error)
+	GetProfileByUserID(ctx context.Context, in *ReqGetProfileByUserID, opts ...grpc.CallOption) (*ResGetProfileByUsername, error)
+	GetMyProfile(ctx context.Context, in *ReqGetMyProfile, opts ...grpc.CallOption) (*ResGetMyProfile, error)
 	UpdateProfileByUserID(ctx context.Context, in *ReqUpdateProfileByUserID, opts ...grpc.CallOption) (*ProfileEmpty, error)
+	ListUsers(ctx context.Context, in *ReqListUsers, opts ...grpc.CallOption) (*ResListUsers, error)
+	BatchGetProfiles(ctx context.Context, in *ReqBatchGetProfiles, opts ...grpc.CallOption) (*ResBatchGetProfiles, error)
+	WatchUsers(ctx context.Context, in *ReqWatchUsers, opts ...grpc.CallOption) (Profile_WatchUsersClient, error)
 }
 
 type profileClient struct {
@@ -43,6 +48,24 @@ func (c *profileClient) GetProfileByUsername(ctx context.Context, in *ReqGetProf
 	return out, nil
 }
 
+func (c *profileClient) GetProfileByUserID(ctx context.Context, in *ReqGetProfileByUserID, opts ...grpc.CallOption) (*ResGetProfileByUsername, error) {
+	out := new(ResGetProfileByUsername)
+	err := c.cc.Invoke(ctx, "/gousergrpc.Profile/GetProfileByUserID", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *profileClient) GetMyProfile(ctx context.Context, in *ReqGetMyProfile, opts ...grpc.CallOption) (*ResGetMyProfile, error) {
+	out := new(ResGetMyProfile)
+	err := c.cc.Invoke(ctx, "/gousergrpc.Profile/GetMyProfile", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *profileClient) UpdateProfileByUserID(ctx context.Context, in *ReqUpdateProfileByUserID, opts ...grpc.CallOption) (*ProfileEmpty, error) {
 	out := new(ProfileEmpty)
 	err := c.cc.Invoke(ctx, "/gousergrpc.Profile/UpdateProfileByUserID", in, out, opts...)
@@ -52,12 +75,67 @@ func (c *profileClient) UpdateProfileByUserID(ctx context.Context, in *ReqUpdate
 	return out, nil
 }
 
+func (c *profileClient) ListUsers(ctx context.Context, in *ReqListUsers, opts ...grpc.CallOption) (*ResListUsers, error) {
+	out := new(ResListUsers)
+	err := c.cc.Invoke(ctx, "/gousergrpc.Profile/ListUsers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *profileClient) BatchGetProfiles(ctx context.Context, in *ReqBatchGetProfiles, opts ...grpc.CallOption) (*ResBatchGetProfiles, error) {
+	out := new(ResBatchGetProfiles)
+	err := c.cc.Invoke(ctx, "/gousergrpc.Profile/BatchGetProfiles", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *profileClient) WatchUsers(ctx context.Context, in *ReqWatchUsers, opts ...grpc.CallOption) (Profile_WatchUsersClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Profile_ServiceDesc.Streams[0], "/gousergrpc.Profile/WatchUsers", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &profileWatchUsersClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Profile_WatchUsersClient interface {
+	Recv() (*UserEvent, error)
+	grpc.ClientStream
+}
+
+type profileWatchUsersClient struct {
+	grpc.ClientStream
+}
+
+func (x *profileWatchUsersClient) Recv() (*UserEvent, error) {
+	m := new(UserEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 // ProfileServer is the server API for Profile service.
 // All implementations must embed UnimplementedProfileServer
 // for forward compatibility
 type ProfileServer interface {
 	GetProfileByUsername(context.Context, *ReqGetProfileByUsername) (*ResGetProfileByUsername, error)
+	GetProfileByUserID(context.Context, *ReqGetProfileByUserID) (*ResGetProfileByUsername, error)
+	GetMyProfile(context.Context, *ReqGetMyProfile) (*ResGetMyProfile, error)
 	UpdateProfileByUserID(context.Context, *ReqUpdateProfileByUserID) (*ProfileEmpty, error)
+	ListUsers(context.Context, *ReqListUsers) (*ResListUsers, error)
+	BatchGetProfiles(context.Context, *ReqBatchGetProfiles) (*ResBatchGetProfiles, error)
+	WatchUsers(*ReqWatchUsers, Profile_WatchUsersServer) error
 	mustEmbedUnimplementedProfileServer()
 }
 
@@ -68,9 +146,24 @@ type UnimplementedProfileServer struct {
 func (UnimplementedProfileServer) GetProfileByUsername(context.Context, *ReqGetProfileByUsername) (*ResGetProfileByUsername, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetProfileByUsername not implemented")
 }
+func (UnimplementedProfileServer) GetProfileByUserID(context.Context, *ReqGetProfileByUserID) (*ResGetProfileByUsername, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetProfileByUserID not implemented")
+}
+func (UnimplementedProfileServer) GetMyProfile(context.Context, *ReqGetMyProfile) (*ResGetMyProfile, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMyProfile not implemented")
+}
 func (UnimplementedProfileServer) UpdateProfileByUserID(context.Context, *ReqUpdateProfileByUserID) (*ProfileEmpty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method UpdateProfileByUserID not implemented")
 }
+func (UnimplementedProfileServer) ListUsers(context.Context, *ReqListUsers) (*ResListUsers, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListUsers not implemented")
+}
+func (UnimplementedProfileServer) BatchGetProfiles(context.Context, *ReqBatchGetProfiles) (*ResBatchGetProfiles, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchGetProfiles not implemented")
+}
+func (UnimplementedProfileServer) WatchUsers(*ReqWatchUsers, Profile_WatchUsersServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchUsers not implemented")
+}
 func (UnimplementedProfileServer) mustEmbedUnimplementedProfileServer() {}
 
 // UnsafeProfileServer may be embedded to opt out of forward compatibility for this service.
@@ -102,6 +195,42 @@ func _Profile_GetProfileByUsername_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Profile_GetProfileByUserID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReqGetProfileByUserID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProfileServer).GetProfileByUserID(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gousergrpc.Profile/GetProfileByUserID",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProfileServer).GetProfileByUserID(ctx, req.(*ReqGetProfileByUserID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Profile_GetMyProfile_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReqGetMyProfile)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProfileServer).GetMyProfile(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gousergrpc.Profile/GetMyProfile",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProfileServer).GetMyProfile(ctx, req.(*ReqGetMyProfile))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Profile_UpdateProfileByUserID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ReqUpdateProfileByUserID)
 	if err := dec(in); err != nil {
@@ -120,6 +249,63 @@ func _Profile_UpdateProfileByUserID_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Profile_ListUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReqListUsers)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProfileServer).ListUsers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gousergrpc.Profile/ListUsers",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProfileServer).ListUsers(ctx, req.(*ReqListUsers))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Profile_BatchGetProfiles_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReqBatchGetProfiles)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProfileServer).BatchGetProfiles(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gousergrpc.Profile/BatchGetProfiles",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProfileServer).BatchGetProfiles(ctx, req.(*ReqBatchGetProfiles))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Profile_WatchUsers_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ReqWatchUsers)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ProfileServer).WatchUsers(m, &profileWatchUsersServer{stream})
+}
+
+type Profile_WatchUsersServer interface {
+	Send(*UserEvent) error
+	grpc.ServerStream
+}
+
+type profileWatchUsersServer struct {
+	grpc.ServerStream
+}
+
+func (x *profileWatchUsersServer) Send(m *UserEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 // Profile_ServiceDesc is the grpc.ServiceDesc for Profile service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -131,11 +317,33 @@ var Profile_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetProfileByUsername",
 			Handler:    _Profile_GetProfileByUsername_Handler,
 		},
+		{
+			MethodName: "GetProfileByUserID",
+			Handler:    _Profile_GetProfileByUserID_Handler,
+		},
+		{
+			MethodName: "GetMyProfile",
+			Handler:    _Profile_GetMyProfile_Handler,
+		},
 		{
 			MethodName: "UpdateProfileByUserID",
 			Handler:    _Profile_UpdateProfileByUserID_Handler,
 		},
+		{
+			MethodName: "ListUsers",
+			Handler:    _Profile_ListUsers_Handler,
+		},
+		{
+			MethodName: "BatchGetProfiles",
+			Handler:    _Profile_BatchGetProfiles_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchUsers",
+			Handler:       _Profile_WatchUsers_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "pkg/gousergrpc/profile.proto",
 }
@@ -0,0 +1,213 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.12.4
+// source: pkg/gousergrpc/session.proto
+
+package gousergrpc
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// SessionClient is the client API for Session service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type SessionClient interface {
+	GetMySessions(ctx context.Context, in *ReqGetMySessions, opts ...grpc.CallOption) (*ResGetSessions, error)
+	RevokeMySession(ctx context.Context, in *ReqRevokeMySession, opts ...grpc.CallOption) (*SessionEmpty, error)
+	GetSessionsByUserID(ctx context.Context, in *ReqGetSessionsByUserID, opts ...grpc.CallOption) (*ResGetSessions, error)
+	RevokeSessionByID(ctx context.Context, in *ReqRevokeSessionByID, opts ...grpc.CallOption) (*SessionEmpty, error)
+}
+
+type sessionClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSessionClient(cc grpc.ClientConnInterface) SessionClient {
+	return &sessionClient{cc}
+}
+
+func (c *sessionClient) GetMySessions(ctx context.Context, in *ReqGetMySessions, opts ...grpc.CallOption) (*ResGetSessions, error) {
+	out := new(ResGetSessions)
+	err := c.cc.Invoke(ctx, "/gousergrpc.Session/GetMySessions", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sessionClient) RevokeMySession(ctx context.Context, in *ReqRevokeMySession, opts ...grpc.CallOption) (*SessionEmpty, error) {
+	out := new(SessionEmpty)
+	err := c.cc.Invoke(ctx, "/gousergrpc.Session/RevokeMySession", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sessionClient) GetSessionsByUserID(ctx context.Context, in *ReqGetSessionsByUserID, opts ...grpc.CallOption) (*ResGetSessions, error) {
+	out := new(ResGetSessions)
+	err := c.cc.Invoke(ctx, "/gousergrpc.Session/GetSessionsByUserID", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *sessionClient) RevokeSessionByID(ctx context.Context, in *ReqRevokeSessionByID, opts ...grpc.CallOption) (*SessionEmpty, error) {
+	out := new(SessionEmpty)
+	err := c.cc.Invoke(ctx, "/gousergrpc.Session/RevokeSessionByID", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// SessionServer is the server API for Session service.
+// All implementations must embed UnimplementedSessionServer
+// for forward compatibility
+type SessionServer interface {
+	GetMySessions(context.Context, *ReqGetMySessions) (*ResGetSessions, error)
+	RevokeMySession(context.Context, *ReqRevokeMySession) (*SessionEmpty, error)
+	GetSessionsByUserID(context.Context, *ReqGetSessionsByUserID) (*ResGetSessions, error)
+	RevokeSessionByID(context.Context, *ReqRevokeSessionByID) (*SessionEmpty, error)
+	mustEmbedUnimplementedSessionServer()
+}
+
+// UnimplementedSessionServer must be embedded to have forward compatible implementations.
+type UnimplementedSessionServer struct {
+}
+
+func (UnimplementedSessionServer) GetMySessions(context.Context, *ReqGetMySessions) (*ResGetSessions, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMySessions not implemented")
+}
+func (UnimplementedSessionServer) RevokeMySession(context.Context, *ReqRevokeMySession) (*SessionEmpty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeMySession not implemented")
+}
+func (UnimplementedSessionServer) GetSessionsByUserID(context.Context, *ReqGetSessionsByUserID) (*ResGetSessions, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSessionsByUserID not implemented")
+}
+func (UnimplementedSessionServer) RevokeSessionByID(context.Context, *ReqRevokeSessionByID) (*SessionEmpty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeSessionByID not implemented")
+}
+func (UnimplementedSessionServer) mustEmbedUnimplementedSessionServer() {}
+
+// UnsafeSessionServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SessionServer will
+// result in compilation errors.
+type UnsafeSessionServer interface {
+	mustEmbedUnimplementedSessionServer()
+}
+
+func RegisterSessionServer(s grpc.ServiceRegistrar, srv SessionServer) {
+	s.RegisterService(&Session_ServiceDesc, srv)
+}
+
+func _Session_GetMySessions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReqGetMySessions)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SessionServer).GetMySessions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gousergrpc.Session/GetMySessions",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SessionServer).GetMySessions(ctx, req.(*ReqGetMySessions))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Session_RevokeMySession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReqRevokeMySession)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SessionServer).RevokeMySession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gousergrpc.Session/RevokeMySession",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SessionServer).RevokeMySession(ctx, req.(*ReqRevokeMySession))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Session_GetSessionsByUserID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReqGetSessionsByUserID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SessionServer).GetSessionsByUserID(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gousergrpc.Session/GetSessionsByUserID",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SessionServer).GetSessionsByUserID(ctx, req.(*ReqGetSessionsByUserID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Session_RevokeSessionByID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReqRevokeSessionByID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SessionServer).RevokeSessionByID(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/gousergrpc.Session/RevokeSessionByID",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SessionServer).RevokeSessionByID(ctx, req.(*ReqRevokeSessionByID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Session_ServiceDesc is the grpc.ServiceDesc for Session service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Session_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "gousergrpc.Session",
+	HandlerType: (*SessionServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetMySessions",
+			Handler:    _Session_GetMySessions_Handler,
+		},
+		{
+			MethodName: "RevokeMySession",
+			Handler:    _Session_RevokeMySession_Handler,
+		},
+		{
+			MethodName: "GetSessionsByUserID",
+			Handler:    _Session_GetSessionsByUserID_Handler,
+		},
+		{
+			MethodName: "RevokeSessionByID",
+			Handler:    _Session_RevokeSessionByID_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "pkg/gousergrpc/session.proto",
+}
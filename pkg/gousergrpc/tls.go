@@ -0,0 +1,14 @@
+package gousergrpc
+
+import (
+	"crypto/tls"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+)
+
+// WithTLS return dial option connecting to go-user grpc server over TLS with
+// tlsConfig, see gouser.NewClientTLSConfig.
+func WithTLS(tlsConfig *tls.Config) grpc.DialOption {
+	return grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig))
+}
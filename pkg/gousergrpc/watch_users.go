@@ -0,0 +1,115 @@
+package gousergrpc
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// WatchUsersCursorHeader is header of Profile.WatchUsers holding the cursor the
+// stream starts after. Resume from it when the stream breaks before the first
+// event.
+const WatchUsersCursorHeader = "x-watch-users-cursor"
+
+// Default backoff of UserWatcher.
+const (
+	WatchUsersDefaultMinBackoff = 500 * time.Millisecond
+	WatchUsersDefaultMaxBackoff = 30 * time.Second
+)
+
+// UserWatcher watch user changes with Profile.WatchUsers and reconnect when
+// the stream breaks.
+type UserWatcher struct {
+	Client ProfileClient
+	// UserJWT is JWT of admin user, sent on every connect.
+	UserJWT string
+	// MinBackoff is wait before the first reconnect, doubled on each failed
+	// reconnect up to MaxBackoff. It is reset once an event is received.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+}
+
+// NewUserWatcher return *UserWatcher with default backoff.
+func NewUserWatcher(client ProfileClient, userJWT string) *UserWatcher {
+	return &UserWatcher{
+		Client:     client,
+		UserJWT:    userJWT,
+		MinBackoff: WatchUsersDefaultMinBackoff,
+		MaxBackoff: WatchUsersDefaultMaxBackoff,
+	}
+}
+
+// Watch call handle with every user change after cursor, empty cursor means
+// changes from now on. When the stream breaks it reconnects and resumes after
+// the last handled event, so no change is missed. Persist UserEvent.Cursor of
+// handled event to resume from there after restart.
+//
+// It returns when ctx is done, handle returns error or the server refuses
+// the cursor or the JWT.
+func (u *UserWatcher) Watch(ctx context.Context, cursor string, handle func(*UserEvent) error) error {
+	backoff := u.MinBackoff
+	for {
+		stream, err := u.Client.WatchUsers(ctx, &ReqWatchUsers{Cursor: cursor, UserJwt: u.UserJWT})
+		if err == nil {
+			cursor, err = u.getStartCursor(stream, cursor)
+		}
+		if err == nil {
+			for {
+				userEvent, errRecv := stream.Recv()
+				if errRecv != nil {
+					// io.EOF too, server closes the stream when it stops.
+					err = errRecv
+					break
+				}
+
+				errHandle := handle(userEvent)
+				if errHandle != nil {
+					return fmt.Errorf("handle: %w", errHandle)
+				}
+
+				cursor = userEvent.GetCursor()
+				backoff = u.MinBackoff
+			}
+		}
+
+		if ctx.Err() != nil {
+			return fmt.Errorf("context.Context.Err: %w", ctx.Err())
+		}
+		switch status.Code(err) {
+		case codes.InvalidArgument, codes.Unauthenticated, codes.PermissionDenied:
+			return fmt.Errorf("ProfileClient.WatchUsers: %w", err)
+		}
+
+		logrus.
+			WithField("backoff", backoff.String()).
+			Warnf("reconnect watch users: %v", err)
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("context.Context.Done: %w", ctx.Err())
+		case <-time.After(backoff):
+		}
+
+		backoff = min(backoff*2, u.MaxBackoff)
+	}
+}
+
+// getStartCursor return the cursor the stream starts after, from its header.
+// It return cursor if the header has none, old server does not send it.
+func (u *UserWatcher) getStartCursor(stream Profile_WatchUsersClient, cursor string) (string, error) {
+	header, err := stream.Header()
+	if err != nil {
+		return cursor, fmt.Errorf("Profile_WatchUsersClient.Header: %w", err)
+	}
+
+	startCursor := header.Get(WatchUsersCursorHeader)
+	if len(startCursor) == 0 {
+		return cursor, nil
+	}
+
+	return startCursor[0], nil
+}
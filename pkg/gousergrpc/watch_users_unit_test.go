@@ -0,0 +1,194 @@
+package gousergrpc
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+type mockWatchUsersStream struct {
+	grpc.ClientStream
+
+	header metadata.MD
+	events []*UserEvent
+	err    error
+}
+
+func (m *mockWatchUsersStream) Header() (metadata.MD, error) {
+	return m.header, nil
+}
+
+func (m *mockWatchUsersStream) Recv() (*UserEvent, error) {
+	if len(m.events) == 0 {
+		return nil, m.err
+	}
+	userEvent := m.events[0]
+	m.events = m.events[1:]
+	return userEvent, nil
+}
+
+type mockWatchUsersClient struct {
+	ProfileClient
+
+	// results is returned in order by each WatchUsers call.
+	results  []mockWatchUsersResult
+	cursors  []string
+	userJWTs []string
+}
+
+type mockWatchUsersResult struct {
+	stream *mockWatchUsersStream
+	err    error
+}
+
+func (m *mockWatchUsersClient) WatchUsers(_ context.Context, in *ReqWatchUsers, _ ...grpc.CallOption) (Profile_WatchUsersClient, error) {
+	m.cursors = append(m.cursors, in.GetCursor())
+	m.userJWTs = append(m.userJWTs, in.GetUserJwt())
+	result := m.results[0]
+	m.results = m.results[1:]
+	if result.err != nil {
+		return nil, result.err
+	}
+	return result.stream, nil
+}
+
+func TestUnitUserWatcherWatch(t *testing.T) {
+	t.Parallel()
+
+	t.Run("broken stream should reconnect after the last handled event", func(t *testing.T) {
+		t.Parallel()
+
+		client := &mockWatchUsersClient{
+			results: []mockWatchUsersResult{
+				{stream: &mockWatchUsersStream{
+					events: []*UserEvent{{Cursor: "c1", UserId: 1}, {Cursor: "c2", UserId: 2}},
+					err:    status.Error(codes.Unavailable, "server restart"),
+				}},
+				{err: status.Error(codes.Unavailable, "connection refused")},
+				{stream: &mockWatchUsersStream{
+					events: []*UserEvent{{Cursor: "c3", UserId: 3}},
+					err:    io.EOF,
+				}},
+				{stream: &mockWatchUsersStream{
+					err: status.Error(codes.InvalidArgument, "cursor invalid"),
+				}},
+			},
+		}
+
+		u := &UserWatcher{Client: client, UserJWT: "adminjwt", MinBackoff: time.Millisecond, MaxBackoff: 2 * time.Millisecond}
+
+		userIDs := []int64{}
+		err := u.Watch(context.Background(), "c0", func(userEvent *UserEvent) error {
+			userIDs = append(userIDs, userEvent.GetUserId())
+			return nil
+		})
+
+		require.Error(t, err)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.Equal(t, []int64{1, 2, 3}, userIDs)
+		assert.Equal(t, []string{"c0", "c2", "c2", "c3"}, client.cursors)
+		assert.Equal(t, []string{"adminjwt", "adminjwt", "adminjwt", "adminjwt"}, client.userJWTs)
+	})
+	t.Run("broken stream before the first event should reconnect after the start cursor", func(t *testing.T) {
+		t.Parallel()
+
+		client := &mockWatchUsersClient{
+			results: []mockWatchUsersResult{
+				{stream: &mockWatchUsersStream{
+					header: metadata.Pairs(WatchUsersCursorHeader, "c5"),
+					err:    status.Error(codes.Unavailable, "server restart"),
+				}},
+				{stream: &mockWatchUsersStream{
+					header: metadata.Pairs(WatchUsersCursorHeader, "c5"),
+					events: []*UserEvent{{Cursor: "c6", UserId: 6}},
+					err:    status.Error(codes.InvalidArgument, "cursor invalid"),
+				}},
+			},
+		}
+
+		u := &UserWatcher{Client: client, UserJWT: "adminjwt", MinBackoff: time.Millisecond, MaxBackoff: 2 * time.Millisecond}
+
+		userIDs := []int64{}
+		err := u.Watch(context.Background(), "", func(userEvent *UserEvent) error {
+			userIDs = append(userIDs, userEvent.GetUserId())
+			return nil
+		})
+
+		require.Error(t, err)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.Equal(t, []int64{6}, userIDs)
+		assert.Equal(t, []string{"", "c5"}, client.cursors)
+	})
+	t.Run("refused jwt should not reconnect", func(t *testing.T) {
+		t.Parallel()
+
+		client := &mockWatchUsersClient{
+			results: []mockWatchUsersResult{
+				{stream: &mockWatchUsersStream{
+					err: status.Error(codes.PermissionDenied, "forbidden"),
+				}},
+			},
+		}
+
+		u := NewUserWatcher(client, "userjwt")
+
+		err := u.Watch(context.Background(), "", func(*UserEvent) error {
+			return nil
+		})
+
+		require.Error(t, err)
+		assert.Equal(t, codes.PermissionDenied, status.Code(err))
+		assert.Len(t, client.cursors, 1)
+	})
+	t.Run("handle error should return error", func(t *testing.T) {
+		t.Parallel()
+
+		client := &mockWatchUsersClient{
+			results: []mockWatchUsersResult{
+				{stream: &mockWatchUsersStream{events: []*UserEvent{{Cursor: "c1", UserId: 1}}}},
+			},
+		}
+
+		u := NewUserWatcher(client, "adminjwt")
+
+		err := u.Watch(context.Background(), "", func(*UserEvent) error {
+			return assert.AnError
+		})
+
+		require.Error(t, err)
+		require.ErrorIs(t, err, assert.AnError)
+	})
+	t.Run("context done should stop reconnecting", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancel := context.WithCancel(context.Background())
+
+		client := &mockWatchUsersClient{
+			results: []mockWatchUsersResult{
+				{stream: &mockWatchUsersStream{
+					events: []*UserEvent{{Cursor: "c1", UserId: 1}},
+					err:    status.Error(codes.Canceled, "context canceled"),
+				}},
+			},
+		}
+
+		u := NewUserWatcher(client, "adminjwt")
+
+		err := u.Watch(ctx, "", func(*UserEvent) error {
+			cancel()
+			return nil
+		})
+
+		require.Error(t, err)
+		require.ErrorIs(t, err, context.Canceled)
+		assert.Len(t, client.cursors, 1)
+	})
+}
@@ -31,6 +31,9 @@ type IAuthClient interface {
 type AuthClient struct {
 	// BaseURL eg. http://localhost:8080.
 	BaseURL string
+	// HTTPClient send requests, http.DefaultClient if nil. Use NewHTTPClient
+	// to call https server requiring client certificate.
+	HTTPClient *http.Client
 }
 
 var _ IAuthClient = &AuthClient{}
@@ -60,10 +63,11 @@ func (a *AuthClient) LoginUser(ctx context.Context, req gouser.ReqLoginUser) (go
 		return fail("http.NewRequestWithContext", err)
 	}
 	httpReq.Header.Add(header.ContentType, header.AppJSON)
+	setRequestID(httpReq)
 
-	httpRes, err := http.DefaultClient.Do(httpReq)
+	httpRes, err := httpClientOrDefault(a.HTTPClient).Do(httpReq)
 	if err != nil {
-		return fail("http.DefaultClient.Do", err)
+		return fail("http.Client.Do", err)
 	}
 	defer func() {
 		err := httpRes.Body.Close()
@@ -114,10 +118,11 @@ func (a *AuthClient) RegisterUser(ctx context.Context, req gouser.ReqRegisterUse
 		return fail("http.NewRequestWithContext", err)
 	}
 	httpReq.Header.Add(header.ContentType, header.AppJSON)
+	setRequestID(httpReq)
 
-	httpRes, err := http.DefaultClient.Do(httpReq)
+	httpRes, err := httpClientOrDefault(a.HTTPClient).Do(httpReq)
 	if err != nil {
-		return fail("http.DefaultClient.Do", err)
+		return fail("http.Client.Do", err)
 	}
 	defer func() {
 		err := httpRes.Body.Close()
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/Hidayathamir/go-user/internal/controller/http"
+	"github.com/Hidayathamir/go-user/internal/pkg/health"
 	"github.com/Hidayathamir/go-user/internal/repo/db"
 	"github.com/Hidayathamir/go-user/pkg/gouser"
 	"github.com/gin-gonic/gin"
@@ -30,7 +31,9 @@ func TestHTTPClientLoginUser(t *testing.T) {
 
 	go func() {
 		gin.SetMode(gin.TestMode)
-		err := http.RunServer(cfg, pg)
+		server, err := http.NewServer(cfg, pg, health.NewChecker())
+		assert.NoError(t, err)
+		err = server.ListenAndServe()
 		assert.NoError(t, err)
 	}()
 
@@ -69,7 +72,9 @@ func TestHTTPClientRegisterUser(t *testing.T) {
 
 	go func() {
 		gin.SetMode(gin.TestMode)
-		err := http.RunServer(cfg, pg)
+		server, err := http.NewServer(cfg, pg, health.NewChecker())
+		assert.NoError(t, err)
+		err = server.ListenAndServe()
 		assert.NoError(t, err)
 	}()
 
@@ -1,2 +1,35 @@
 // Package gouserhttp contains http client for go-user.
 package gouserhttp
+
+import (
+	"crypto/tls"
+	"net/http"
+
+	"github.com/Hidayathamir/go-user/internal/pkg/header"
+	"github.com/Hidayathamir/go-user/pkg/gouser"
+)
+
+// setRequestID set X-Request-ID header of httpReq to request id carried by its
+// context, see gouser.WithRequestID, so the call is correlated with caller
+// request. Server generates one if not set.
+func setRequestID(httpReq *http.Request) {
+	requestID := gouser.RequestIDFromContext(httpReq.Context())
+	if requestID != "" {
+		httpReq.Header.Set(header.RequestID, requestID)
+	}
+}
+
+// NewHTTPClient return *http.Client calling https server with tlsConfig, see
+// gouser.NewClientTLSConfig.
+func NewHTTPClient(tlsConfig *tls.Config) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone() //nolint:forcetypeassert
+	transport.TLSClientConfig = tlsConfig
+	return &http.Client{Transport: transport}
+}
+
+func httpClientOrDefault(httpClient *http.Client) *http.Client {
+	if httpClient == nil {
+		return http.DefaultClient
+	}
+	return httpClient
+}
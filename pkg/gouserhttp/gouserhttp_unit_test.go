@@ -0,0 +1,108 @@
+package gouserhttp
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Hidayathamir/go-user/config"
+	"github.com/Hidayathamir/go-user/internal/pkg/header"
+	"github.com/Hidayathamir/go-user/internal/pkg/tlsconfig"
+	"github.com/Hidayathamir/go-user/internal/pkg/tlsconfig/tlsconfigtest"
+	"github.com/Hidayathamir/go-user/pkg/gouser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnitSetRequestID(t *testing.T) {
+	t.Parallel()
+
+	t.Run("request id in context should be sent in header", func(t *testing.T) {
+		t.Parallel()
+
+		var gotRequestID string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			gotRequestID = r.Header.Get(header.RequestID)
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{"data":{"user_jwt":"jwt"}}`))
+		}))
+		defer server.Close()
+
+		ctx := gouser.WithRequestID(context.Background(), "req-outbound")
+		_, err := NewAuthClient(server.URL).LoginUser(ctx, gouser.ReqLoginUser{Username: "hidayat", Password: "mypassword"})
+
+		require.NoError(t, err)
+		assert.Equal(t, "req-outbound", gotRequestID)
+	})
+	t.Run("context without request id should not set header", func(t *testing.T) {
+		t.Parallel()
+
+		httpReq := httptest.NewRequest(http.MethodGet, "/", nil)
+
+		setRequestID(httpReq)
+
+		assert.Empty(t, httpReq.Header.Values(header.RequestID))
+	})
+}
+
+func TestUnitNewHTTPClient(t *testing.T) {
+	t.Parallel()
+
+	ca := tlsconfigtest.NewCA(t, "test-ca")
+	dir := t.TempDir()
+	certFile, keyFile := ca.IssueFiles(t, dir, "server")
+	caFile := tlsconfigtest.WriteFile(t, dir, "ca.crt", ca.CertPEM())
+	clientCertFile, clientKeyFile := ca.IssueFiles(t, dir, "client-1")
+
+	tlsConfig, err := tlsconfig.NewServerConfig(config.TLS{
+		CertFile:          certFile,
+		KeyFile:           keyFile,
+		ClientCAFile:      caFile,
+		RequireClientCert: true,
+	})
+	require.NoError(t, err)
+
+	server := &http.Server{
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(`{"data":{"user_jwt":"` + r.TLS.PeerCertificates[0].Subject.CommonName + `"}}`))
+		}),
+		TLSConfig:         tlsConfig,
+		ReadHeaderTimeout: time.Second,
+	}
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() { _ = server.ServeTLS(listener, "", "") }()
+	t.Cleanup(func() { _ = server.Close() })
+
+	baseURL := "https://" + listener.Addr().String()
+
+	t.Run("client with certificate should call mutual tls server", func(t *testing.T) {
+		t.Parallel()
+
+		clientTLSConfig, err := gouser.NewClientTLSConfig(gouser.ClientTLS{CAFile: caFile, CertFile: clientCertFile, KeyFile: clientKeyFile})
+		require.NoError(t, err)
+		client := NewAuthClient(baseURL)
+		client.HTTPClient = NewHTTPClient(clientTLSConfig)
+
+		res, err := client.LoginUser(context.Background(), gouser.ReqLoginUser{Username: "hidayat", Password: "mypassword"})
+
+		require.NoError(t, err)
+		assert.Equal(t, "client-1", res.UserJWT)
+	})
+	t.Run("client without certificate should fail", func(t *testing.T) {
+		t.Parallel()
+
+		clientTLSConfig, err := gouser.NewClientTLSConfig(gouser.ClientTLS{CAFile: caFile})
+		require.NoError(t, err)
+		client := NewAuthClient(baseURL)
+		client.HTTPClient = NewHTTPClient(clientTLSConfig)
+
+		_, err = client.LoginUser(context.Background(), gouser.ReqLoginUser{Username: "hidayat", Password: "mypassword"})
+
+		require.Error(t, err)
+	})
+}
@@ -8,6 +8,9 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"strconv"
+	"time"
 
 	controllerHTTP "github.com/Hidayathamir/go-user/internal/controller/http"
 	"github.com/Hidayathamir/go-user/internal/pkg/header"
@@ -20,19 +23,31 @@ var (
 	APIProfileGetProfileByUsername = func(username string) string {
 		return "/api/v1/users/" + username
 	}
-	APIProfileUsers = "/api/v1/users"
+	APIProfileGetProfileByUserID = func(userID int64) string {
+		return "/api/v1/users/id/" + strconv.FormatInt(userID, 10)
+	}
+	APIProfileUsers            = "/api/v1/users"
+	APIProfileMe               = "/api/v1/users/me"
+	APIProfileBatchGetProfiles = "/api/v1/users/batch"
 )
 
 // IProfileClient -.
 type IProfileClient interface {
 	GetProfileByUsername(ctx context.Context, req gouser.ReqGetProfileByUsername) (gouser.ResGetProfileByUsername, error)
+	GetProfileByUserID(ctx context.Context, req gouser.ReqGetProfileByUserID) (gouser.ResGetProfileByUsername, error)
+	GetMyProfile(ctx context.Context, req gouser.ReqGetMyProfile) (gouser.ResGetMyProfile, error)
 	UpdateProfileByUserID(ctx context.Context, req gouser.ReqUpdateProfileByUserID) error
+	ListUsers(ctx context.Context, req gouser.ReqListUsers) (gouser.ResListUsers, error)
+	BatchGetProfiles(ctx context.Context, req gouser.ReqBatchGetProfiles) (gouser.ResBatchGetProfiles, error)
 }
 
 // ProfileClient -.
 type ProfileClient struct {
 	// BaseURL eg. http://localhost:8080.
 	BaseURL string
+	// HTTPClient send requests, http.DefaultClient if nil. Use NewHTTPClient
+	// to call https server requiring client certificate.
+	HTTPClient *http.Client
 }
 
 var _ IProfileClient = &ProfileClient{}
@@ -57,10 +72,61 @@ func (p *ProfileClient) GetProfileByUsername(ctx context.Context, req gouser.Req
 		return fail("http.NewRequestWithContext", err)
 	}
 	httpReq.Header.Add(header.ContentType, header.AppJSON)
+	setRequestID(httpReq)
+
+	httpRes, err := httpClientOrDefault(p.HTTPClient).Do(httpReq)
+	if err != nil {
+		return fail("http.Client.Do", err)
+	}
+	defer func() {
+		err := httpRes.Body.Close()
+		if err != nil {
+			logrus.Warnf("http.Response.Body.Close: %v", err)
+		}
+	}()
+
+	httpResBody, err := io.ReadAll(httpRes.Body)
+	if err != nil {
+		return fail("io.ReadAll", err)
+	}
+
+	if httpRes.StatusCode != http.StatusOK {
+		resErr := controllerHTTP.ResError{}
+		err := json.Unmarshal(httpResBody, &resErr)
+		if err != nil {
+			return fail("json.Unmarshal", err)
+		}
+		return fail("http.Response.StatusCode != http.StatusOk", errors.New(resErr.Error))
+	}
+
+	res := controllerHTTP.ResGetProfileByUsername{}
+
+	err = json.Unmarshal(httpResBody, &res)
+	if err != nil {
+		return fail("json.Unmarshal", err)
+	}
+
+	return res.Data, nil
+}
+
+// GetProfileByUserID implements IProfileClient.
+func (p *ProfileClient) GetProfileByUserID(ctx context.Context, req gouser.ReqGetProfileByUserID) (gouser.ResGetProfileByUsername, error) {
+	url := p.BaseURL + APIProfileGetProfileByUserID(req.UserID)
+
+	fail := func(msg string, err error) (gouser.ResGetProfileByUsername, error) {
+		return gouser.ResGetProfileByUsername{}, fmt.Errorf(msg+": %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fail("http.NewRequestWithContext", err)
+	}
+	httpReq.Header.Add(header.ContentType, header.AppJSON)
+	setRequestID(httpReq)
 
-	httpRes, err := http.DefaultClient.Do(httpReq)
+	httpRes, err := httpClientOrDefault(p.HTTPClient).Do(httpReq)
 	if err != nil {
-		return fail("http.DefaultClient.Do", err)
+		return fail("http.Client.Do", err)
 	}
 	defer func() {
 		err := httpRes.Body.Close()
@@ -93,6 +159,57 @@ func (p *ProfileClient) GetProfileByUsername(ctx context.Context, req gouser.Req
 	return res.Data, nil
 }
 
+// GetMyProfile implements IProfileClient.
+func (p *ProfileClient) GetMyProfile(ctx context.Context, req gouser.ReqGetMyProfile) (gouser.ResGetMyProfile, error) {
+	url := p.BaseURL + APIProfileMe
+
+	fail := func(msg string, err error) (gouser.ResGetMyProfile, error) {
+		return gouser.ResGetMyProfile{}, fmt.Errorf(msg+": %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fail("http.NewRequestWithContext", err)
+	}
+	httpReq.Header.Add(header.ContentType, header.AppJSON)
+	setRequestID(httpReq)
+	httpReq.Header.Add(header.Authorization, req.UserJWT)
+
+	httpRes, err := httpClientOrDefault(p.HTTPClient).Do(httpReq)
+	if err != nil {
+		return fail("http.Client.Do", err)
+	}
+	defer func() {
+		err := httpRes.Body.Close()
+		if err != nil {
+			logrus.Warnf("http.Response.Body.Close: %v", err)
+		}
+	}()
+
+	httpResBody, err := io.ReadAll(httpRes.Body)
+	if err != nil {
+		return fail("io.ReadAll", err)
+	}
+
+	if httpRes.StatusCode != http.StatusOK {
+		resErr := controllerHTTP.ResError{}
+		err := json.Unmarshal(httpResBody, &resErr)
+		if err != nil {
+			return fail("json.Unmarshal", err)
+		}
+		return fail("http.Response.StatusCode != http.StatusOk", errors.New(resErr.Error))
+	}
+
+	res := controllerHTTP.ResGetMyProfile{}
+
+	err = json.Unmarshal(httpResBody, &res)
+	if err != nil {
+		return fail("json.Unmarshal", err)
+	}
+
+	return res.Data, nil
+}
+
 // UpdateProfileByUserID implements IProfileClient.
 func (p *ProfileClient) UpdateProfileByUserID(ctx context.Context, req gouser.ReqUpdateProfileByUserID) error {
 	url := p.BaseURL + APIProfileUsers
@@ -107,11 +224,12 @@ func (p *ProfileClient) UpdateProfileByUserID(ctx context.Context, req gouser.Re
 		return fmt.Errorf("http.NewRequestWithContext: %w", err)
 	}
 	httpReq.Header.Add(header.ContentType, header.AppJSON)
+	setRequestID(httpReq)
 	httpReq.Header.Add(header.Authorization, req.UserJWT)
 
-	httpRes, err := http.DefaultClient.Do(httpReq)
+	httpRes, err := httpClientOrDefault(p.HTTPClient).Do(httpReq)
 	if err != nil {
-		return fmt.Errorf("http.DefaultClient.Do: %w", err)
+		return fmt.Errorf("http.Client.Do: %w", err)
 	}
 	defer func() {
 		err := httpRes.Body.Close()
@@ -136,3 +254,138 @@ func (p *ProfileClient) UpdateProfileByUserID(ctx context.Context, req gouser.Re
 
 	return nil
 }
+
+// ListUsers implements IProfileClient.
+func (p *ProfileClient) ListUsers(ctx context.Context, req gouser.ReqListUsers) (gouser.ResListUsers, error) {
+	query := url.Values{}
+	if req.Cursor != "" {
+		query.Set("cursor", req.Cursor)
+	}
+	if req.Limit != 0 {
+		query.Set("limit", strconv.Itoa(req.Limit))
+	}
+	if req.Status != "" {
+		query.Set("status", req.Status)
+	}
+	if req.UsernamePrefix != "" {
+		query.Set("username_prefix", req.UsernamePrefix)
+	}
+	if req.CreatedFrom != nil {
+		query.Set("created_from", req.CreatedFrom.Format(time.RFC3339Nano))
+	}
+	if req.CreatedTo != nil {
+		query.Set("created_to", req.CreatedTo.Format(time.RFC3339Nano))
+	}
+	if req.SortBy != "" {
+		query.Set("sort_by", req.SortBy)
+	}
+	if req.SortOrder != "" {
+		query.Set("sort_order", req.SortOrder)
+	}
+	if req.WithTotalCount {
+		query.Set("with_total_count", "true")
+	}
+
+	url := p.BaseURL + APIProfileUsers + "?" + query.Encode()
+
+	fail := func(msg string, err error) (gouser.ResListUsers, error) {
+		return gouser.ResListUsers{}, fmt.Errorf(msg+": %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return fail("http.NewRequestWithContext", err)
+	}
+	httpReq.Header.Add(header.ContentType, header.AppJSON)
+	setRequestID(httpReq)
+	httpReq.Header.Add(header.Authorization, req.UserJWT)
+
+	httpRes, err := httpClientOrDefault(p.HTTPClient).Do(httpReq)
+	if err != nil {
+		return fail("http.Client.Do", err)
+	}
+	defer func() {
+		err := httpRes.Body.Close()
+		if err != nil {
+			logrus.Warnf("http.Response.Body.Close: %v", err)
+		}
+	}()
+
+	httpResBody, err := io.ReadAll(httpRes.Body)
+	if err != nil {
+		return fail("io.ReadAll", err)
+	}
+
+	if httpRes.StatusCode != http.StatusOK {
+		resErr := controllerHTTP.ResError{}
+		err := json.Unmarshal(httpResBody, &resErr)
+		if err != nil {
+			return fail("json.Unmarshal", err)
+		}
+		return fail("http.Response.StatusCode != http.StatusOk", errors.New(resErr.Error))
+	}
+
+	res := controllerHTTP.ResListUsers{}
+
+	err = json.Unmarshal(httpResBody, &res)
+	if err != nil {
+		return fail("json.Unmarshal", err)
+	}
+
+	return res.Data, nil
+}
+
+// BatchGetProfiles implements IProfileClient.
+func (p *ProfileClient) BatchGetProfiles(ctx context.Context, req gouser.ReqBatchGetProfiles) (gouser.ResBatchGetProfiles, error) {
+	url := p.BaseURL + APIProfileBatchGetProfiles
+
+	fail := func(msg string, err error) (gouser.ResBatchGetProfiles, error) {
+		return gouser.ResBatchGetProfiles{}, fmt.Errorf(msg+": %w", err)
+	}
+
+	reqJSONByte, err := json.Marshal(req)
+	if err != nil {
+		return fail("json.Marshal", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewBuffer(reqJSONByte))
+	if err != nil {
+		return fail("http.NewRequestWithContext", err)
+	}
+	httpReq.Header.Add(header.ContentType, header.AppJSON)
+	setRequestID(httpReq)
+
+	httpRes, err := httpClientOrDefault(p.HTTPClient).Do(httpReq)
+	if err != nil {
+		return fail("http.Client.Do", err)
+	}
+	defer func() {
+		err := httpRes.Body.Close()
+		if err != nil {
+			logrus.Warnf("http.Response.Body.Close: %v", err)
+		}
+	}()
+
+	httpResBody, err := io.ReadAll(httpRes.Body)
+	if err != nil {
+		return fail("io.ReadAll", err)
+	}
+
+	if httpRes.StatusCode != http.StatusOK {
+		resErr := controllerHTTP.ResError{}
+		err := json.Unmarshal(httpResBody, &resErr)
+		if err != nil {
+			return fail("json.Unmarshal", err)
+		}
+		return fail("http.Response.StatusCode != http.StatusOk", errors.New(resErr.Error))
+	}
+
+	res := controllerHTTP.ResBatchGetProfiles{}
+
+	err = json.Unmarshal(httpResBody, &res)
+	if err != nil {
+		return fail("json.Unmarshal", err)
+	}
+
+	return res.Data, nil
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/Hidayathamir/go-user/internal/controller/http"
+	"github.com/Hidayathamir/go-user/internal/pkg/health"
 	"github.com/Hidayathamir/go-user/internal/repo/db"
 	"github.com/Hidayathamir/go-user/pkg/gouser"
 	"github.com/gin-gonic/gin"
@@ -30,7 +31,9 @@ func TestHTTPClientUpdateProfile(t *testing.T) {
 
 	go func() {
 		gin.SetMode(gin.TestMode)
-		err := http.RunServer(cfg, pg)
+		server, err := http.NewServer(cfg, pg, health.NewChecker())
+		assert.NoError(t, err)
+		err = server.ListenAndServe()
 		assert.NoError(t, err)
 	}()
 
@@ -76,7 +79,9 @@ func TestHTTPClientGetProfile(t *testing.T) {
 
 	go func() {
 		gin.SetMode(gin.TestMode)
-		err := http.RunServer(cfg, pg)
+		server, err := http.NewServer(cfg, pg, health.NewChecker())
+		assert.NoError(t, err)
+		err = server.ListenAndServe()
 		assert.NoError(t, err)
 	}()
 
@@ -106,3 +111,99 @@ func TestHTTPClientGetProfile(t *testing.T) {
 
 	logrus.Info(resGetProfile)
 }
+
+func TestHTTPClientBatchGetProfiles(t *testing.T) {
+	t.Parallel()
+
+	cfg := initTestIntegration(t)
+
+	pg, err := db.NewPGPoolConn(cfg)
+	require.NoError(t, err)
+
+	go func() {
+		gin.SetMode(gin.TestMode)
+		server, err := http.NewServer(cfg, pg, health.NewChecker())
+		assert.NoError(t, err)
+		err = server.ListenAndServe()
+		assert.NoError(t, err)
+	}()
+
+	time.Sleep(time.Second * 1) // wait http server run.
+
+	baseURL := "http://" + cfg.HTTP.Host + ":" + strconv.Itoa(cfg.HTTP.Port)
+
+	gouserAuthClient := NewAuthClient(baseURL)
+
+	username := uuid.NewString()
+	password := uuid.NewString()
+
+	reqRegister := gouser.ReqRegisterUser{
+		Username: username,
+		Password: password,
+	}
+	resRegister, err := gouserAuthClient.RegisterUser(context.Background(), reqRegister)
+	require.NoError(t, err)
+
+	gouserProfileClient := NewProfileClient(baseURL)
+
+	unknownUsername := uuid.NewString()
+	reqBatchGetProfiles := gouser.ReqBatchGetProfiles{
+		Usernames: []string{unknownUsername, username},
+	}
+	resBatchGetProfiles, err := gouserProfileClient.BatchGetProfiles(context.Background(), reqBatchGetProfiles)
+	require.NoError(t, err)
+
+	require.Len(t, resBatchGetProfiles.Profiles, 1)
+	assert.Equal(t, resRegister.UserID, resBatchGetProfiles.Profiles[0].ID)
+	assert.Equal(t, []string{unknownUsername}, resBatchGetProfiles.MissingUsernames)
+}
+
+func TestHTTPClientGetMyProfile(t *testing.T) {
+	t.Parallel()
+
+	cfg := initTestIntegration(t)
+
+	pg, err := db.NewPGPoolConn(cfg)
+	require.NoError(t, err)
+
+	go func() {
+		gin.SetMode(gin.TestMode)
+		server, err := http.NewServer(cfg, pg, health.NewChecker())
+		assert.NoError(t, err)
+		err = server.ListenAndServe()
+		assert.NoError(t, err)
+	}()
+
+	time.Sleep(time.Second * 1) // wait http server run.
+
+	baseURL := "http://" + cfg.HTTP.Host + ":" + strconv.Itoa(cfg.HTTP.Port)
+	gouserAuthClient := NewAuthClient(baseURL)
+
+	username := uuid.NewString()
+	password := uuid.NewString()
+
+	reqRegister := gouser.ReqRegisterUser{
+		Username: username,
+		Password: password,
+	}
+	resRegister, err := gouserAuthClient.RegisterUser(context.Background(), reqRegister)
+	require.NoError(t, err)
+
+	reqLogin := gouser.ReqLoginUser{
+		Username: username,
+		Password: password,
+	}
+	resLogin, err := gouserAuthClient.LoginUser(context.Background(), reqLogin)
+	require.NoError(t, err)
+
+	gouserProfileClient := NewProfileClient(baseURL)
+
+	resGetMyProfile, err := gouserProfileClient.GetMyProfile(context.Background(), gouser.ReqGetMyProfile{UserJWT: resLogin.UserJWT})
+	require.NoError(t, err)
+	assert.Equal(t, username, resGetMyProfile.Username)
+	assert.Equal(t, "active", resGetMyProfile.Status)
+
+	resGetProfile, err := gouserProfileClient.GetProfileByUserID(context.Background(), gouser.ReqGetProfileByUserID{UserID: resRegister.UserID})
+	require.NoError(t, err)
+	assert.Equal(t, username, resGetProfile.Username)
+}